@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -18,7 +19,7 @@ import (
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	httpSwagger "github.com/swaggo/http-swagger"
-	"golang.org/x/time/rate"
+	"go.opentelemetry.io/otel"
 
 	// --- Project Imports ---
 	// Docs (Keep for Swagger generation)
@@ -27,13 +28,22 @@ import (
 	// Adapters
 	httpadapter "github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http"
 	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/middleware"
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/health"
+	metricsadapter "github.com/yvanyang/language-learning-player-api/internal/adapter/metrics"
 	repo "github.com/yvanyang/language-learning-player-api/internal/adapter/repository/postgres"
+	feedfetchadapter "github.com/yvanyang/language-learning-player-api/internal/adapter/service/feedfetch"
 	googleauthadapter "github.com/yvanyang/language-learning-player-api/internal/adapter/service/google_auth"
 	minioadapter "github.com/yvanyang/language-learning-player-api/internal/adapter/service/minio"
+	ratelimitadapter "github.com/yvanyang/language-learning-player-api/internal/adapter/service/ratelimit"
+	stagingadapter "github.com/yvanyang/language-learning-player-api/internal/adapter/service/staging"
+	webhookadapter "github.com/yvanyang/language-learning-player-api/internal/adapter/service/webhook"
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/tracing"
 
 	// Core
 	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
 	uc "github.com/yvanyang/language-learning-player-api/internal/usecase"
+	"github.com/yvanyang/language-learning-player-api/migrations"
 
 	// Packages
 	"github.com/yvanyang/language-learning-player-api/pkg/logger"
@@ -62,7 +72,7 @@ import (
 // @tag.name Audio Collections
 // @tag.description Operations related to managing audio collections (playlists, courses).
 // @tag.name User Activity
-// @tag.description Operations related to tracking user interactions like playback progress and bookmarks. Timestamp/Progress values in requests/responses are in milliseconds.
+// @tag.description Operations related to tracking user interactions like playback progress, bookmarks and loops. Timestamp/Progress values in requests/responses are in milliseconds.
 // @tag.name Uploads
 // @tag.description Operations related to requesting upload URLs and finalizing uploads.
 // @tag.name Health
@@ -95,8 +105,15 @@ func main() {
 	// --- Dependency Initialization ---
 	appLogger.Info("Initializing dependencies...")
 
+	// Tracing (installs a no-op provider when disabled)
+	tracerProvider, shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, appLogger)
+	if err != nil {
+		appLogger.Error("Failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+
 	// Database Pool
-	dbPool, err := repo.NewPgxPool(ctx, cfg.Database, appLogger)
+	dbPool, err := repo.NewPgxPool(ctx, cfg.Database, tracing.NewPgxTracer(tracerProvider), appLogger)
 	if err != nil {
 		appLogger.Error("Failed to initialize database connection pool", "error", err)
 		os.Exit(1)
@@ -110,7 +127,27 @@ func main() {
 	collectionRepo := repo.NewAudioCollectionRepository(dbPool, appLogger)
 	progressRepo := repo.NewPlaybackProgressRepository(dbPool, appLogger)
 	bookmarkRepo := repo.NewBookmarkRepository(dbPool, appLogger)
+	trackChapterRepo := repo.NewTrackChapterRepository(dbPool, appLogger)
+	loopRepo := repo.NewLoopRepository(dbPool, appLogger)
 	refreshTokenRepo := repo.NewRefreshTokenRepository(dbPool, appLogger)
+	auditRepo := repo.NewAuditEventRepository(dbPool, appLogger)
+	outboxRepo := repo.NewOutboxRepository(dbPool, appLogger)
+	webhookRepo := repo.NewWebhookSubscriptionRepository(dbPool, appLogger)
+	webhookDeliveryRepo := repo.NewWebhookDeliveryRepository(dbPool, appLogger)
+	jobRepo := repo.NewJobRepository(dbPool, appLogger)
+	importRunRepo := repo.NewImportRunRepository(dbPool, appLogger)
+	resumableUploadRepo := repo.NewResumableUploadRepository(dbPool, appLogger)
+	courseRepo := repo.NewCourseRepository(dbPool, appLogger)
+	collectionMemberRepo := repo.NewCollectionMemberRepository(dbPool, appLogger)
+	feedTokenRepo := repo.NewFeedTokenRepository(dbPool, appLogger)
+	feedSubscriptionRepo := repo.NewFeedSubscriptionRepository(dbPool, appLogger)
+
+	// Metrics
+	appMetrics := metricsadapter.New()
+	if err := appMetrics.Register(metricsadapter.NewPgxPoolCollector(dbPool)); err != nil {
+		appLogger.Error("Failed to register database pool metrics", "error", err)
+		os.Exit(1)
+	}
 
 	// Services / Helpers
 	secHelper, err := security.NewSecurity(cfg.JWT.SecretKey, appLogger)
@@ -118,31 +155,112 @@ func main() {
 		appLogger.Error("Failed to initialize security helper", "error", err)
 		os.Exit(1)
 	}
-	storageService, err := minioadapter.NewMinioStorageService(cfg.Minio, appLogger)
+	feedLinkSigner, err := security.NewURLSigner(cfg.Feed.SigningKey)
+	if err != nil {
+		appLogger.Error("Failed to initialize feed link signer", "error", err)
+		os.Exit(1)
+	}
+	minioService, err := minioadapter.NewMinioStorageService(cfg.Minio, appLogger)
 	if err != nil {
 		appLogger.Error("Failed to initialize MinIO storage service", "error", err)
 		os.Exit(1)
 	}
+	storageService := tracing.NewTracedFileStorage(metricsadapter.NewInstrumentedFileStorage(minioService, appMetrics), tracerProvider)
 	googleAuthService, err := googleauthadapter.NewGoogleAuthService(cfg.Google.ClientID, appLogger)
 	if err != nil {
 		// Non-fatal: Log warning if Google Auth isn't critical
 		appLogger.Warn("Failed to initialize Google Auth service (Google login disabled?)", "error", err)
 	}
+	uploadStaging, err := stagingadapter.NewFilesystemStore(cfg.Upload.StagingDir, appLogger)
+	if err != nil {
+		appLogger.Error("Failed to initialize upload staging directory", "error", err, "dir", cfg.Upload.StagingDir)
+		os.Exit(1)
+	}
 	validator := validation.New()
 
+	// Readiness checks (/readyz)
+	latestMigration, err := migrations.LatestVersion()
+	if err != nil {
+		appLogger.Error("Failed to read embedded migrations", "error", err)
+		os.Exit(1)
+	}
+	readiness := health.NewReadiness(appLogger)
+	readiness.Register(health.Checker{Name: "database", Check: health.DatabasePing(dbPool), Timeout: 2 * time.Second})
+	readiness.Register(health.Checker{Name: "storage", Check: minioService.CheckBucket, Timeout: 3 * time.Second})
+	readiness.Register(health.Checker{Name: "migrations", Check: health.MigrationVersion(dbPool, latestMigration), Timeout: 2 * time.Second, CacheTTL: time.Minute})
+
+	// Rate Limiter (cleanup goroutine is tied to the server lifecycle below)
+	var rateLimiter *middleware.RateLimiter
+	if cfg.RateLimit.Enabled {
+		var rateLimitStore port.RateLimitStore
+		if cfg.RateLimit.Backend == config.RateLimitBackendPostgres {
+			rateLimitStore = repo.NewRateLimitRepository(dbPool, appLogger)
+		} else {
+			rateLimitStore = ratelimitadapter.NewMemoryStore(appLogger)
+		}
+		appLogger.Info("Rate limiting enabled", "backend", cfg.RateLimit.Backend)
+		rateLimiter = middleware.NewRateLimiter(cfg.RateLimit, rateLimitStore, secHelper, appLogger)
+	} else {
+		appLogger.Warn("Rate limiting is disabled by configuration")
+	}
+
+	// Idempotency-Key handling for resource-creating POST routes (applied per route below)
+	var idempotency *middleware.Idempotency
+	idempotent := func(next http.Handler) http.Handler { return next }
+	if cfg.Idempotency.Enabled {
+		idempotency = middleware.NewIdempotency(cfg.Idempotency, repo.NewIdempotencyRepository(dbPool, appLogger), appLogger)
+		idempotent = idempotency.Handler
+	}
+
 	// Use Cases (Injecting dependencies)
-	authUseCase := uc.NewAuthUseCase(cfg.JWT, userRepo, refreshTokenRepo, secHelper, googleAuthService, appLogger)
-	audioUseCase := uc.NewAudioContentUseCase(cfg, trackRepo, collectionRepo, storageService, txManager, progressRepo, bookmarkRepo, appLogger)
-	activityUseCase := uc.NewUserActivityUseCase(progressRepo, bookmarkRepo, trackRepo, appLogger)
-	uploadUseCase := uc.NewUploadUseCase(cfg.Minio, trackRepo, storageService, txManager, appLogger)
+	authUseCase := uc.NewAuthUseCase(cfg.JWT, userRepo, refreshTokenRepo, secHelper, googleAuthService, txManager, auditRepo, appMetrics, appLogger)
+	audioUseCase := uc.NewAudioContentUseCase(cfg, trackRepo, collectionRepo, storageService, txManager, auditRepo, courseRepo, collectionMemberRepo, progressRepo, bookmarkRepo, trackChapterRepo, loopRepo, appLogger)
+	activityUseCase := uc.NewUserActivityUseCase(cfg.DeepLink, progressRepo, bookmarkRepo, loopRepo, trackRepo, trackChapterRepo, txManager, outboxRepo, appMetrics, appLogger)
+	uploadUseCase := uc.NewUploadUseCase(cfg.Upload, cfg.Minio, userRepo, trackRepo, storageService, txManager, jobRepo, auditRepo, outboxRepo, appMetrics, appLogger)
+	courseUseCase := uc.NewCourseUseCase(courseRepo, collectionRepo, collectionMemberRepo, trackRepo, progressRepo, txManager, auditRepo, outboxRepo, appLogger)
+	sharingUseCase := uc.NewCollectionSharingUseCase(collectionRepo, collectionMemberRepo, userRepo, txManager, auditRepo, outboxRepo, appLogger)
+	resumableUploadUseCase := uc.NewResumableUploadUseCase(cfg.Upload, uploadUseCase, resumableUploadRepo, uploadStaging, appLogger)
 	userUseCase := uc.NewUserUseCase(userRepo, appLogger)
+	auditUseCase := uc.NewAuditUseCase(auditRepo, userRepo, appLogger)
+	webhookUseCase := uc.NewWebhookUseCase(webhookRepo, webhookDeliveryRepo, userRepo, txManager, auditRepo, appLogger)
+	feedUseCase := uc.NewFeedUseCase(cfg.Feed, audioUseCase, feedTokenRepo, userRepo, feedLinkSigner, appLogger)
+	importUseCase := uc.NewImportUseCase(cfg.Import, cfg.Minio, userRepo, trackRepo, collectionRepo, importRunRepo, jobRepo, storageService, txManager, auditRepo, outboxRepo, appLogger)
+	feedFetcher := feedfetchadapter.NewFetcher(cfg.FeedIngest, appLogger)
+	feedIngestUseCase := uc.NewFeedIngestUseCase(cfg.FeedIngest, cfg.Minio, userRepo, trackRepo, trackChapterRepo, collectionRepo, feedSubscriptionRepo, jobRepo, storageService, feedFetcher, txManager, auditRepo, outboxRepo, appLogger)
+
+	// Webhook dispatcher (delivers outbox events; worker is tied to the server lifecycle below)
+	var webhookDispatcher *uc.WebhookDispatcher
+	if cfg.Webhook.Enabled {
+		webhookSender := webhookadapter.NewSender(cfg.Webhook.RequestTimeout, appLogger)
+		webhookDispatcher = uc.NewWebhookDispatcher(cfg.Webhook, webhookDeliveryRepo, webhookSender, appLogger)
+	} else {
+		appLogger.Warn("Webhook delivery is disabled by configuration; events accumulate in the outbox")
+	}
+
+	// Background job runner (in-process mode; otherwise jobs are run by cmd/worker)
+	var jobRunner *uc.JobRunner
+	if cfg.Jobs.RunInAPI {
+		jobRunner = uc.NewJobRunner(cfg.Jobs, jobRepo, appLogger)
+		if err := uc.RegisterJobHandlers(jobRunner, cfg.Jobs, uc.JobDependencies{JobRepo: jobRepo, TrackRepo: trackRepo, Storage: storageService, Importer: importUseCase, Feeds: feedIngestUseCase}, appLogger); err != nil {
+			appLogger.Error("Failed to register job handlers", "error", err)
+			os.Exit(1)
+		}
+	}
 
 	// HTTP Handlers (Injecting use cases)
 	authHandler := httpadapter.NewAuthHandler(authUseCase, validator)
 	audioHandler := httpadapter.NewAudioHandler(audioUseCase, validator)
 	activityHandler := httpadapter.NewUserActivityHandler(activityUseCase, validator)
-	uploadHandler := httpadapter.NewUploadHandler(uploadUseCase, validator)
+	uploadHandler := httpadapter.NewUploadHandler(uploadUseCase, cfg.Upload, validator)
+	courseHandler := httpadapter.NewCourseHandler(courseUseCase, validator)
+	sharingHandler := httpadapter.NewCollectionSharingHandler(sharingUseCase, validator)
+	tusHandler := httpadapter.NewTusHandler(resumableUploadUseCase, cfg.Upload, validator)
 	userHandler := httpadapter.NewUserHandler(userUseCase)
+	auditHandler := httpadapter.NewAuditHandler(auditUseCase)
+	webhookHandler := httpadapter.NewWebhookHandler(webhookUseCase, validator)
+	importHandler := httpadapter.NewImportHandler(importUseCase, cfg.Import)
+	feedHandler := httpadapter.NewFeedHandler(feedUseCase)
+	feedSubscriptionHandler := httpadapter.NewFeedSubscriptionHandler(feedIngestUseCase, validator)
 
 	appLogger.Info("Dependencies initialized successfully")
 
@@ -151,36 +269,49 @@ func main() {
 	router := chi.NewRouter()
 
 	// --- Global Middleware Setup (Applied to ALL routes in order) ---
-	router.Use(middleware.RequestID)                             // 1. Assign request ID
-	router.Use(middleware.RequestLogger)                         // 2. Log requests (uses request ID)
-	router.Use(middleware.Recoverer)                             // 3. Recover from panics
-	router.Use(chimiddleware.RealIP)                             // 4. Determine real client IP
-	ipLimiter := middleware.NewIPRateLimiter(rate.Limit(10), 20) // TODO: Make rate limit configurable
-	router.Use(middleware.RateLimit(ipLimiter))                  // 5. Apply IP-based rate limiting
-	router.Use(chimiddleware.StripSlashes)                       // 6. Remove trailing slashes
-	router.Use(chimiddleware.Timeout(60 * time.Second))          // 7. Request timeout
+	router.Use(middleware.RequestID)                                            // 1. Assign request ID
+	router.Use(middleware.Tracing(tracerProvider, otel.GetTextMapPropagator())) // 2. Start server span (uses request ID)
+	router.Use(middleware.RequestLogger)                                        // 3. Log requests (uses request ID)
+	router.Use(middleware.Metrics(appMetrics))                                  // 4. Record request count/latency per route
+	router.Use(middleware.Recoverer)                                            // 5. Recover from panics
+	router.Use(chimiddleware.RealIP)                                            // 6. Determine real client IP
+	router.Use(middleware.ClientIP)                                             // 7. Expose client IP to use cases (audit log)
+	if rateLimiter != nil {
+		router.Use(rateLimiter.Handler) // 8. Apply per-route, per-client rate limiting
+	}
+	router.Use(chimiddleware.StripSlashes)              // 9. Remove trailing slashes
+	router.Use(chimiddleware.Timeout(60 * time.Second)) // 10. Request timeout
 
 	// --- CORS Middleware ---
 	// Apply CORS globally before routing to specific handlers
 	router.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   cfg.Cors.AllowedOrigins,
 		AllowedMethods:   cfg.Cors.AllowedMethods,
-		AllowedHeaders:   cfg.Cors.AllowedHeaders,
-		ExposedHeaders:   []string{"Link", "X-Request-ID"}, // Expose necessary headers
+		AllowedHeaders:   append(append(cfg.Cors.AllowedHeaders, "traceparent", "tracestate", middleware.HeaderIdempotencyKey, "If-Match", "If-None-Match"), httpadapter.TusRequestHeaders...),
+		ExposedHeaders:   append(append([]string{"Link", "X-Request-ID", "ETag", middleware.HeaderIdempotentReplayed}, middleware.RateLimitHeaders...), httpadapter.TusResponseHeaders...), // Expose necessary headers
 		AllowCredentials: cfg.Cors.AllowCredentials,
 		MaxAge:           cfg.Cors.MaxAge, // Cache preflight response
 	}))
 
-	// --- Non-API Routes (Health Check, Root Redirect to Docs) ---
+	// --- Non-API Routes (Health Checks, Root Redirect to Docs) ---
+	// Liveness: the process is up and serving HTTP
 	router.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain")
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintln(w, "OK")
 	})
+	// Readiness: dependencies are reachable and the instance is not shutting down
+	router.Get("/readyz", readiness.Handler)
 	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/swagger/index.html", http.StatusFound)
 	})
 
+	// --- Metrics Route (on the API port unless a separate admin port is configured) ---
+	metricsHandler := middleware.RequireBearerToken(cfg.Metrics.Token)(appMetrics.Handler())
+	if cfg.Metrics.Enabled && cfg.Metrics.AdminPort == "" {
+		router.Handle(cfg.Metrics.Path, metricsHandler)
+	}
+
 	// --- Swagger Documentation Route ---
 	router.Group(func(r chi.Router) {
 		// Apply relaxed security headers specifically for Swagger UI compatibility
@@ -207,7 +338,30 @@ func main() {
 			// Public Audio Content Retrieval
 			// Uses audioHandler
 			public.Get("/audio/tracks", audioHandler.ListTracks)
-			public.Get("/audio/tracks/{trackId}", audioHandler.GetTrackDetails) // Track detail potentially public
+			public.Get("/audio/tracks/{trackId}", audioHandler.GetTrackDetails)           // Track detail potentially public
+			public.Get("/audio/tracks/{trackId}/chapters", audioHandler.GetTrackChapters) // ?format=podlove|webvtt|id3 downloads a chapter file
+
+			// tus discovery (no credentials needed to learn the server's capabilities)
+			public.Options("/uploads/tus", tusHandler.Options)
+
+			// Podcast feeds (secret feed URLs and signed stream links carry their own credentials)
+			// Uses feedHandler
+			public.Get("/feeds/collections/{collectionId}", feedHandler.GetPublicFeed) // Public and unlisted collections
+			public.Get("/feeds/private/{token}", feedHandler.GetPrivateFeed)
+			public.Get("/feeds/stream/{trackId}", feedHandler.StreamTrack)
+			public.Head("/feeds/stream/{trackId}", feedHandler.StreamTrack)
+		})
+
+		// --- Collection and Course Browsing (Authentication Optional) ---
+		// Signed-in users also see their own private collections and the unpublished courses they own or are enrolled in
+		r.Group(func(browse chi.Router) {
+			browse.Use(middleware.OptionalAuthenticator(secHelper))
+			browse.Get("/audio/collections", audioHandler.ListCollections)                           // Public collections only
+			browse.Get("/audio/collections/{collectionId}", audioHandler.GetCollectionDetails)       // Respects collection visibility
+			browse.Get("/audio/collections/{collectionId}/tracks", audioHandler.GetCollectionTracks) // Paginated; evaluates smart collections
+			browse.Get("/audio/collections/{collectionId}/export", audioHandler.ExportCollection)    // ?format=m3u8|xspf|json
+			browse.Get("/courses", courseHandler.ListCourses)
+			browse.Get("/courses/{collectionId}", courseHandler.GetCourse)
 		})
 
 		// --- Protected API Routes (Authentication Required) ---
@@ -224,6 +378,11 @@ func main() {
 			protected.Route("/users/me", func(me chi.Router) {
 				me.Get("/", userHandler.GetMyProfile)                  // Uses userHandler
 				me.Get("/collections", audioHandler.ListMyCollections) // Uses audioHandler (List OWN collections)
+				me.Get("/enrollments", courseHandler.ListMyEnrollments)
+				// Collection invitations addressed to the user - Uses sharingHandler
+				me.Get("/invitations", sharingHandler.ListMyInvitations)
+				me.Post("/invitations/{invitationId}/accept", sharingHandler.AcceptInvitation)
+				me.Post("/invitations/{invitationId}/decline", sharingHandler.DeclineInvitation)
 				// User Activity (Progress) - Uses activityHandler
 				me.Route("/progress", func(progress chi.Router) {
 					progress.Get("/", activityHandler.ListProgress)
@@ -233,41 +392,111 @@ func main() {
 				// User Activity (Bookmarks) - Uses activityHandler
 				me.Route("/bookmarks", func(bookmarks chi.Router) {
 					bookmarks.Get("/", activityHandler.ListBookmarks)
-					bookmarks.Post("/", activityHandler.CreateBookmark)
+					bookmarks.With(idempotent).Post("/", activityHandler.CreateBookmark)
 					bookmarks.Delete("/{bookmarkId}", activityHandler.DeleteBookmark)
 				})
+				me.Route("/loops", func(loops chi.Router) {
+					loops.Get("/", activityHandler.ListLoops)
+					loops.With(idempotent).Post("/", activityHandler.CreateLoop)
+					loops.Get("/{loopId}", activityHandler.GetLoop)
+					loops.Put("/{loopId}", activityHandler.UpdateLoop)
+					loops.Delete("/{loopId}", activityHandler.DeleteLoop)
+					loops.Get("/{loopId}/link", activityHandler.GetLoopLink)
+				})
 			})
 
+			// --- Track Chapter Routes (uploader only) ---
+			// Uses audioHandler
+			protected.Put("/audio/tracks/{trackId}/chapters", audioHandler.UpdateTrackChapters)
+			protected.Post("/audio/tracks/{trackId}/chapters/import", audioHandler.ImportTrackChapters) // Podlove JSON, WebVTT or ID3 body
+
 			// --- Audio Collection Management Routes ---
 			// Uses audioHandler
-			protected.Route("/audio/collections", func(collections chi.Router) {
-				collections.Post("/", audioHandler.CreateCollection) // Create new collection
-				// Routes for a specific collection
-				collections.Route("/{collectionId}", func(collection chi.Router) {
-					collection.Get("/", audioHandler.GetCollectionDetails)
-					collection.Put("/", audioHandler.UpdateCollectionMetadata)
-					collection.Delete("/", audioHandler.DeleteCollection)
-					collection.Put("/tracks", audioHandler.UpdateCollectionTracks)
-				})
-			})
+			protected.With(idempotent).Post("/audio/collections", audioHandler.CreateCollection) // Create new collection
+			protected.Put("/audio/collections/{collectionId}", audioHandler.UpdateCollectionMetadata)
+			protected.Delete("/audio/collections/{collectionId}", audioHandler.DeleteCollection)
+			protected.Put("/audio/collections/{collectionId}/tracks", audioHandler.UpdateCollectionTracks)
+			protected.Post("/audio/collections/{collectionId}/tracks", audioHandler.AddCollectionTrack)
+			protected.Put("/audio/collections/{collectionId}/tracks/{trackId}/position", audioHandler.MoveCollectionTrack)
+			protected.Delete("/audio/collections/{collectionId}/tracks/{trackId}", audioHandler.RemoveCollectionTrack)
+			protected.Put("/audio/collections/{collectionId}/rule", audioHandler.UpdateSmartRule)
+			protected.With(idempotent).Post("/audio/collections/{collectionId}/freeze", audioHandler.FreezeSmartCollection)
+			protected.With(idempotent).Post("/audio/collections/{collectionId}/fork", audioHandler.ForkCollection)
+			protected.With(idempotent).Post("/audio/collections/import", audioHandler.ImportPlaylist) // M3U8, XSPF or JSON body
+
+			// --- Collection Sharing Routes ---
+			// Uses sharingHandler
+			protected.With(idempotent).Post("/audio/collections/{collectionId}/invitations", sharingHandler.InviteCollaborator)
+			protected.Get("/audio/collections/{collectionId}/invitations", sharingHandler.ListCollectionInvitations)
+			protected.Delete("/audio/collections/{collectionId}/invitations/{invitationId}", sharingHandler.RevokeInvitation)
+			protected.Get("/audio/collections/{collectionId}/members", sharingHandler.ListCollectionMembers)
+			protected.Delete("/audio/collections/{collectionId}/members/{userId}", sharingHandler.RemoveCollectionMember)
+			protected.Post("/audio/collections/{collectionId}/transfer", sharingHandler.TransferOwnership)
+
+			// --- Podcast Feed Routes ---
+			// Uses feedHandler
+			protected.With(idempotent).Post("/audio/collections/{collectionId}/feed-token", feedHandler.IssueFeedToken)
+			protected.Delete("/audio/collections/{collectionId}/feed-token", feedHandler.RevokeFeedToken)
+
+			// --- Course Authoring and Enrollment Routes ---
+			// Uses courseHandler
+			protected.Put("/courses/{collectionId}/outline", courseHandler.UpdateCourseOutline)
+			protected.Post("/courses/{collectionId}/publish", courseHandler.PublishCourse)
+			protected.Delete("/courses/{collectionId}/publish", courseHandler.UnpublishCourse)
+			protected.Post("/courses/{collectionId}/enrollment", courseHandler.Enroll)
+			protected.Delete("/courses/{collectionId}/enrollment", courseHandler.Unenroll)
+			protected.Get("/courses/{collectionId}/progress", courseHandler.GetCourseProgress)
 
 			// --- Upload Request Routes (Need auth to know who is uploading) ---
 			// Uses uploadHandler
 			protected.Route("/uploads/audio", func(upload chi.Router) {
 				upload.Post("/request", uploadHandler.RequestUpload)
 				upload.Post("/batch/request", uploadHandler.RequestBatchUpload)
+				upload.Post("/direct", uploadHandler.UploadDirect) // Not idempotent: the middleware would buffer the file
 			})
+			protected.Get("/uploads/duplicates", uploadHandler.ListDuplicates)
+
+			// Resumable uploads (tus 1.0); not idempotent: tus offsets already make retries safe
+			protected.Post("/uploads/tus", tusHandler.CreateUpload)
+			protected.Head("/uploads/tus/{uploadId}", tusHandler.GetUploadOffset)
+			protected.Patch("/uploads/tus/{uploadId}", tusHandler.WriteChunk)
+			protected.Delete("/uploads/tus/{uploadId}", tusHandler.TerminateUpload)
 
 			// --- Upload Completion / Track Creation Routes (Need auth for ownership) ---
 			// Uses uploadHandler
-			protected.Post("/audio/tracks", uploadHandler.CompleteUploadAndCreateTrack)
-			protected.Post("/audio/tracks/batch/complete", uploadHandler.CompleteBatchUploadAndCreateTracks)
-
-			// --- Admin Routes Placeholder (Could be further nested or have dedicated middleware) ---
-			// protected.Route("/admin", func(admin chi.Router) {
-			// 	admin.Use(middleware.RequireAdminRole) // Example Admin Role Check Middleware
-			// 	// admin.Get("/users", ...)
-			// })
+			protected.With(idempotent).Post("/audio/tracks", uploadHandler.CompleteUploadAndCreateTrack)
+			protected.With(idempotent).Post("/audio/tracks/batch/complete", uploadHandler.CompleteBatchUploadAndCreateTracks)
+
+			// --- Admin Routes (admin role is enforced by the use cases) ---
+			protected.Route("/admin", func(admin chi.Router) {
+				admin.Get("/audit-events", auditHandler.ListAuditEvents)
+				admin.Get("/uploads/duplicates", uploadHandler.ListAllDuplicates)
+				admin.Route("/webhooks", func(webhooks chi.Router) {
+					webhooks.Get("/", webhookHandler.ListWebhooks)
+					webhooks.With(idempotent).Post("/", webhookHandler.CreateWebhook)
+					webhooks.Route("/{webhookId}", func(webhook chi.Router) {
+						webhook.Get("/", webhookHandler.GetWebhook)
+						webhook.Put("/", webhookHandler.UpdateWebhook)
+						webhook.Delete("/", webhookHandler.DeleteWebhook)
+						webhook.Get("/deliveries", webhookHandler.ListDeliveries)
+						webhook.Post("/deliveries/{deliveryId}/redeliver", webhookHandler.RedeliverDelivery)
+					})
+				})
+				admin.Route("/imports", func(imports chi.Router) {
+					imports.Post("/", importHandler.StartImport) // Not idempotent: the middleware would buffer the archive
+					imports.Get("/{importId}", importHandler.GetImport)
+				})
+				admin.Route("/feeds", func(feeds chi.Router) {
+					feeds.Get("/", feedSubscriptionHandler.ListSubscriptions)
+					feeds.With(idempotent).Post("/", feedSubscriptionHandler.Subscribe)
+					feeds.Post("/opml", feedSubscriptionHandler.ImportOPML)
+					feeds.Route("/{feedId}", func(feed chi.Router) {
+						feed.Get("/", feedSubscriptionHandler.GetSubscription)
+						feed.Delete("/", feedSubscriptionHandler.Unsubscribe)
+						feed.Post("/refresh", feedSubscriptionHandler.RefreshSubscription)
+					})
+				})
+			})
 		})
 	})
 
@@ -283,9 +512,70 @@ func main() {
 		ErrorLog:     slog.NewLogLogger(appLogger.Handler(), slog.LevelError), // Use slog for server errors
 	}
 
+	// --- Admin Server (metrics on a separate port) ---
+	var adminSrv *http.Server
+	if cfg.Metrics.Enabled && cfg.Metrics.AdminPort != "" {
+		adminRouter := chi.NewRouter()
+		adminRouter.Use(middleware.Recoverer)
+		adminRouter.Handle(cfg.Metrics.Path, metricsHandler)
+		adminSrv = &http.Server{
+			Addr:         ":" + cfg.Metrics.AdminPort,
+			Handler:      adminRouter,
+			ReadTimeout:  cfg.Server.ReadTimeout,
+			WriteTimeout: cfg.Server.WriteTimeout,
+			IdleTimeout:  cfg.Server.IdleTimeout,
+			ErrorLog:     slog.NewLogLogger(appLogger.Handler(), slog.LevelError),
+		}
+	}
+
 	// --- Start Server & Graceful Shutdown ---
 	serverErrors := make(chan error, 1) // Channel to capture server errors
 
+	if adminSrv != nil {
+		go func() {
+			appLogger.Info("Starting admin server", "address", adminSrv.Addr)
+			if err := adminSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErrors <- fmt.Errorf("admin server: %w", err)
+			}
+		}()
+	}
+
+	// Background workers stop when ctx is cancelled (i.e. on shutdown signal)
+	var workers sync.WaitGroup
+	if rateLimiter != nil {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			rateLimiter.CleanUpOldLimiters(ctx)
+		}()
+	}
+	if idempotency != nil {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			idempotency.CleanUpExpired(ctx)
+		}()
+	}
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		resumableUploadUseCase.CleanUpExpired(ctx)
+	}()
+	if webhookDispatcher != nil {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			webhookDispatcher.Run(ctx)
+		}()
+	}
+	if jobRunner != nil {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			jobRunner.Run(ctx)
+		}()
+	}
+
 	go func() {
 		appLogger.Info("Starting server", "address", srv.Addr)
 		serverErrors <- srv.ListenAndServe() // This blocks until server stops
@@ -307,6 +597,13 @@ func main() {
 		stop() // Prevent context cancellation from multiple signals
 		appLogger.Info("Shutting down server gracefully, press Ctrl+C again to force")
 
+		// Report not-ready first so load balancers stop sending new traffic
+		readiness.SetShuttingDown()
+		if cfg.Server.ShutdownDrainDelay > 0 {
+			appLogger.Info("Waiting for load balancers to drain traffic", "delay", cfg.Server.ShutdownDrainDelay)
+			time.Sleep(cfg.Server.ShutdownDrainDelay)
+		}
+
 		// Create a context with a timeout for the shutdown process
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
@@ -316,8 +613,14 @@ func main() {
 			// Error during shutdown (e.g., timeout exceeded)
 			appLogger.Error("Server forced to shutdown", "error", err)
 		}
+		if adminSrv != nil {
+			if err := adminSrv.Shutdown(shutdownCtx); err != nil {
+				appLogger.Error("Admin server forced to shutdown", "error", err)
+			}
+		}
 
 		appLogger.Info("Server shutdown complete.")
+		workers.Wait()
 	}
 
 	// --- Final Cleanup (after shutdown or error exit) ---
@@ -326,5 +629,12 @@ func main() {
 	appLogger.Info("Closing database connection pool...")
 	dbPool.Close() // Close the database pool
 	appLogger.Info("Database connection pool closed.")
+
+	// Flush any buffered spans
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFlush()
+	if err := shutdownTracing(flushCtx); err != nil {
+		appLogger.Error("Failed to flush traces", "error", err)
+	}
 	appLogger.Info("Application finished.")
 }
@@ -0,0 +1,397 @@
+// cmd/llpctl/commands.go
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	repo "github.com/yvanyang/language-learning-player-api/internal/adapter/repository/postgres"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	uc "github.com/yvanyang/language-learning-player-api/internal/usecase"
+)
+
+// --- Migrations ---
+
+func migrateUp(ctx context.Context, env *environment, args []string) error {
+	if err := parseFlags("migrate up", args, nil); err != nil {
+		return err
+	}
+	migrator, err := newMigrator(env)
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(ctx)
+	for _, version := range applied {
+		fmt.Fprintf(env.stdout, "applied %d\n", version)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Fprintln(env.stdout, "no pending migrations")
+	}
+	return nil
+}
+
+func migrateDown(ctx context.Context, env *environment, args []string) error {
+	var steps int
+	err := parseFlags("migrate down", args, func(fs *flag.FlagSet) {
+		fs.IntVar(&steps, "steps", 1, "number of migrations to revert")
+	})
+	if err != nil {
+		return err
+	}
+	if steps < 1 {
+		return fmt.Errorf("-steps must be at least 1")
+	}
+	migrator, err := newMigrator(env)
+	if err != nil {
+		return err
+	}
+	reverted, err := migrator.Down(ctx, steps)
+	for _, version := range reverted {
+		fmt.Fprintf(env.stdout, "reverted %d\n", version)
+	}
+	return err
+}
+
+func migrateStatus(ctx context.Context, env *environment, args []string) error {
+	if err := parseFlags("migrate status", args, nil); err != nil {
+		return err
+	}
+	migrator, err := newMigrator(env)
+	if err != nil {
+		return err
+	}
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "current version: %d", status.Version)
+	if status.Dirty {
+		fmt.Fprint(env.stdout, " (dirty)")
+	}
+	fmt.Fprintf(env.stdout, "\nlatest version:  %d\n", status.Latest)
+	if len(status.Pending) == 0 {
+		fmt.Fprintln(env.stdout, "up to date")
+		return nil
+	}
+	fmt.Fprintln(env.stdout, "pending:")
+	for _, m := range status.Pending {
+		fmt.Fprintf(env.stdout, "  %06d %s\n", m.Version, m.Name)
+	}
+	return nil
+}
+
+func newMigrator(env *environment) (*repo.Migrator, error) {
+	pool, err := env.db()
+	if err != nil {
+		return nil, err
+	}
+	return repo.NewMigrator(pool, env.logger)
+}
+
+// --- Users ---
+
+func userCreate(ctx context.Context, env *environment, args []string) error {
+	var email, name, password, role string
+	err := parseFlags("user create", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&email, "email", "", "email address (required)")
+		fs.StringVar(&name, "name", "", "display name")
+		fs.StringVar(&password, "password", "", "password (read from stdin if empty)")
+		fs.StringVar(&role, "role", string(domain.RoleUser), "role: user or admin")
+	})
+	if err != nil {
+		return err
+	}
+	if email == "" {
+		return fmt.Errorf("-email is required")
+	}
+	if password, err = passwordOrStdin(env, password); err != nil {
+		return err
+	}
+	maintenance, err := env.maintenance(false)
+	if err != nil {
+		return err
+	}
+	user, err := maintenance.CreateUser(ctx, email, name, password, domain.UserRole(role))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "created user %s (%s, role %s)\n", user.ID, user.Email.String(), user.Role)
+	return nil
+}
+
+func userPromote(ctx context.Context, env *environment, args []string) error {
+	var email, role string
+	err := parseFlags("user promote", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&email, "email", "", "email address (required)")
+		fs.StringVar(&role, "role", string(domain.RoleAdmin), "new role: admin or user")
+	})
+	if err != nil {
+		return err
+	}
+	if email == "" {
+		return fmt.Errorf("-email is required")
+	}
+	maintenance, err := env.maintenance(false)
+	if err != nil {
+		return err
+	}
+	user, err := maintenance.SetUserRole(ctx, email, domain.UserRole(role))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "user %s (%s) now has role %s\n", user.ID, user.Email.String(), user.Role)
+	return nil
+}
+
+func userResetPassword(ctx context.Context, env *environment, args []string) error {
+	var email, password string
+	err := parseFlags("user reset-password", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&email, "email", "", "email address (required)")
+		fs.StringVar(&password, "password", "", "new password (read from stdin if empty)")
+	})
+	if err != nil {
+		return err
+	}
+	if email == "" {
+		return fmt.Errorf("-email is required")
+	}
+	if password, err = passwordOrStdin(env, password); err != nil {
+		return err
+	}
+	maintenance, err := env.maintenance(false)
+	if err != nil {
+		return err
+	}
+	if err := maintenance.ResetPassword(ctx, email, password); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "password reset for %s; existing sessions revoked\n", email)
+	return nil
+}
+
+func userRevokeSessions(ctx context.Context, env *environment, args []string) error {
+	var email string
+	err := parseFlags("user revoke-sessions", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&email, "email", "", "email address (required)")
+	})
+	if err != nil {
+		return err
+	}
+	if email == "" {
+		return fmt.Errorf("-email is required")
+	}
+	maintenance, err := env.maintenance(false)
+	if err != nil {
+		return err
+	}
+	revoked, err := maintenance.RevokeSessions(ctx, email)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "revoked %d session(s) for %s\n", revoked, email)
+	return nil
+}
+
+// --- Seeding ---
+
+func seed(ctx context.Context, env *environment, args []string) error {
+	var file string
+	err := parseFlags("seed", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&file, "file", "", "YAML fixture (required), e.g. fixtures/demo.yaml")
+	})
+	if err != nil {
+		return err
+	}
+	if file == "" {
+		return fmt.Errorf("-file is required")
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("reading fixture: %w", err)
+	}
+	var fixture uc.SeedFixture
+	if err := yaml.Unmarshal(data, &fixture); err != nil {
+		return fmt.Errorf("parsing fixture %s: %w", file, err)
+	}
+
+	maintenance, err := env.maintenance(false)
+	if err != nil {
+		return err
+	}
+	result, err := maintenance.Seed(ctx, fixture)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "created %d user(s), %d track(s), %d collection(s); %d already existed\n",
+		result.UsersCreated, result.TracksCreated, result.CollectionsCreated, result.Skipped)
+	return nil
+}
+
+// --- Import ---
+
+func importLibrary(ctx context.Context, env *environment, args []string) error {
+	var dir, zipFile, owner, tags string
+	var opts port.ImportOptions
+	err := parseFlags("import", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&dir, "dir", "", "directory containing the library")
+		fs.StringVar(&zipFile, "zip", "", "ZIP archive containing the library")
+		fs.StringVar(&owner, "owner", "", "email of the user who will own the tracks and courses (required)")
+		fs.StringVar(&opts.LanguageCode, "language", "", "language code for files without one in the manifest or tags")
+		fs.StringVar(&opts.Level, "level", "", "level for files without one in the manifest or tags")
+		fs.BoolVar(&opts.IsPublic, "public", false, "make tracks public unless the manifest says otherwise")
+		fs.StringVar(&tags, "tags", "", "comma-separated tags added to every track")
+		fs.StringVar(&opts.RootTitle, "title", "", "title of the course for top-level files (default: directory or archive name)")
+	})
+	if err != nil {
+		return err
+	}
+	if (dir == "") == (zipFile == "") {
+		return fmt.Errorf("exactly one of -dir and -zip is required")
+	}
+	if owner == "" {
+		return fmt.Errorf("-owner is required")
+	}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			opts.Tags = append(opts.Tags, tag)
+		}
+	}
+
+	importer, err := env.importer()
+	if err != nil {
+		return err
+	}
+	var report *domain.ImportReport
+	if dir != "" {
+		report, err = importer.ImportLibrary(ctx, os.DirFS(dir), filepath.Base(filepath.Clean(dir)), owner, opts)
+	} else {
+		archive, openErr := zip.OpenReader(zipFile)
+		if openErr != nil {
+			return fmt.Errorf("opening archive: %w", openErr)
+		}
+		defer archive.Close()
+		report, err = importer.ImportLibrary(ctx, &archive.Reader, filepath.Base(zipFile), owner, opts)
+	}
+	if report != nil {
+		for _, item := range report.Items {
+			if item.Status == domain.ImportItemFailed {
+				fmt.Fprintf(env.stdout, "failed:  %s: %s\n", item.Path, item.Error)
+			}
+		}
+		fmt.Fprintf(env.stdout, "created %d track(s), %d already existed, %d failed; created %d course(s), updated %d\n",
+			report.TracksCreated, report.TracksExisting, report.TracksFailed, report.CollectionsCreated, report.CollectionsUpdated)
+	}
+	if err != nil {
+		return err
+	}
+	if report.TracksFailed > 0 {
+		return errors.New("some files could not be imported")
+	}
+	return nil
+}
+
+// --- Storage ---
+
+func storageVerify(ctx context.Context, env *environment, args []string) error {
+	var input uc.VerifyStorageInput
+	err := parseFlags("storage verify", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&input.Prefix, "prefix", "user-uploads/", "only report unreferenced objects under this key prefix")
+		fs.DurationVar(&input.MinOrphanAge, "min-age", 24*time.Hour, "ignore unreferenced objects newer than this (uploads in progress)")
+		fs.BoolVar(&input.Repair, "repair", false, "delete unreferenced objects and tracks whose object is missing")
+	})
+	if err != nil {
+		return err
+	}
+	maintenance, err := env.maintenance(true)
+	if err != nil {
+		return err
+	}
+	report, err := maintenance.VerifyStorage(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.stdout, "checked %d track(s) and %d object(s)\n", report.TracksChecked, report.ObjectsChecked)
+	for _, track := range report.MissingObjects {
+		fmt.Fprintf(env.stdout, "missing object:      %s/%s (track %s %q)\n", track.MinioBucket, track.MinioObjectKey, track.ID, track.Title)
+	}
+	for _, obj := range report.OrphanedObjects {
+		fmt.Fprintf(env.stdout, "unreferenced object: %s (%d bytes, %s)\n", obj.Key, obj.Size, obj.LastModified.Format(time.RFC3339))
+	}
+	if input.Repair {
+		fmt.Fprintf(env.stdout, "deleted %d track(s) and %d object(s)\n", report.TracksDeleted, report.ObjectsDeleted)
+		if report.TracksDeleted < len(report.MissingObjects) || report.ObjectsDeleted < len(report.OrphanedObjects) {
+			return errors.New("some repairs failed, see the log")
+		}
+	} else if len(report.MissingObjects) > 0 || len(report.OrphanedObjects) > 0 {
+		return errors.New("inconsistencies found; run with -repair to fix them")
+	}
+	return nil
+}
+
+func storageChecksums(ctx context.Context, env *environment, args []string) error {
+	if err := parseFlags("storage checksums", args, nil); err != nil {
+		return err
+	}
+	maintenance, err := env.maintenance(true)
+	if err != nil {
+		return err
+	}
+	report, err := maintenance.ComputeChecksums(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, track := range report.Failed {
+		fmt.Fprintf(env.stdout, "failed: %s/%s (track %s %q)\n", track.MinioBucket, track.MinioObjectKey, track.ID, track.Title)
+	}
+	fmt.Fprintf(env.stdout, "checked %d track(s), computed %d checksum(s)\n", report.TracksChecked, report.Computed)
+	if len(report.Failed) > 0 {
+		return errors.New("some checksums could not be computed, see the log")
+	}
+	return nil
+}
+
+// --- Helpers ---
+
+// parseFlags parses args with the flags defined by define. Positional arguments are rejected.
+func parseFlags(name string, args []string, define func(fs *flag.FlagSet)) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if define != nil {
+		define(fs)
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%s: unexpected arguments: %s", name, strings.Join(fs.Args(), " "))
+	}
+	return nil
+}
+
+// passwordOrStdin returns password, or the first line of standard input if it is empty.
+func passwordOrStdin(env *environment, password string) (string, error) {
+	if password != "" {
+		return password, nil
+	}
+	line, err := bufio.NewReader(env.stdin).ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", fmt.Errorf("reading password from stdin: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
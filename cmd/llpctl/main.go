@@ -0,0 +1,230 @@
+// cmd/llpctl/main.go
+
+// Command llpctl is the operator CLI: it runs the embedded migrations, manages users,
+// seeds demo content, imports audio libraries and checks storage consistency. It reads the same configuration
+// as the API server (config.*.yaml and APP_* environment variables).
+//
+// Usage:
+//
+//	llpctl [-v] <command> [<subcommand>] [flags]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	repo "github.com/yvanyang/language-learning-player-api/internal/adapter/repository/postgres"
+	minioadapter "github.com/yvanyang/language-learning-player-api/internal/adapter/service/minio"
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/tracing"
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	uc "github.com/yvanyang/language-learning-player-api/internal/usecase"
+	"github.com/yvanyang/language-learning-player-api/pkg/logger"
+	"github.com/yvanyang/language-learning-player-api/pkg/security"
+)
+
+const usage = `Usage: llpctl [-v] <command> [flags]
+
+Commands:
+  migrate up                     Apply all pending migrations
+  migrate down [-steps N]        Revert the last N migrations (default 1)
+  migrate status                 Show the current and pending migrations
+  user create -email E -name N [-password P] [-role user|admin]
+                                 Create a local user
+  user promote -email E [-role admin|user]
+                                 Change a user's role (default admin)
+  user reset-password -email E [-password P]
+                                 Set a new password and revoke the user's sessions
+  user revoke-sessions -email E  Revoke all refresh tokens of a user
+  seed -file F                   Create demo users, tracks and collections from a YAML fixture
+  import (-dir D | -zip F) -owner E [-language L] [-level L] [-public] [-tags T] [-title T]
+                                 Import a library of audio files as tracks and courses
+  storage verify [-prefix P] [-min-age D] [-repair]
+                                 Compare audio_tracks.minio_object_key with the bucket
+  storage checksums              Compute the content checksums missing from tracks
+
+Passwords not given with -password are read from the first line of standard input.
+`
+
+// command is a subcommand handler. args excludes the command names.
+type command func(ctx context.Context, env *environment, args []string) error
+
+var commands = map[string]command{
+	"migrate up":           migrateUp,
+	"migrate down":         migrateDown,
+	"migrate status":       migrateStatus,
+	"user create":          userCreate,
+	"user promote":         userPromote,
+	"user reset-password":  userResetPassword,
+	"user revoke-sessions": userRevokeSessions,
+	"seed":                 seed,
+	"import":               importLibrary,
+	"storage verify":       storageVerify,
+	"storage checksums":    storageChecksums,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout))
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) int {
+	global := flag.NewFlagSet("llpctl", flag.ContinueOnError)
+	global.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	verbose := global.Bool("v", false, "log at info level instead of warn")
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+
+	cmd, cmdArgs, ok := lookupCommand(global.Args())
+	if !ok {
+		global.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	env, err := newEnvironment(ctx, *verbose, stdin, stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer env.Close()
+
+	if err := cmd(ctx, env, cmdArgs); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// lookupCommand matches "group sub" before a single-word command.
+func lookupCommand(args []string) (command, []string, bool) {
+	if len(args) >= 2 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return cmd, args[2:], true
+		}
+	}
+	if len(args) >= 1 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd, args[1:], true
+		}
+	}
+	return nil, nil, false
+}
+
+// environment holds the configuration and lazily created dependencies, so that e.g.
+// migrations do not require MinIO to be reachable.
+type environment struct {
+	ctx    context.Context
+	cfg    config.Config
+	logger *slog.Logger
+	stdin  io.Reader
+	stdout io.Writer
+	pool   *pgxpool.Pool
+}
+
+func newEnvironment(ctx context.Context, verbose bool, stdin io.Reader, stdout io.Writer) (*environment, error) {
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		return nil, fmt.Errorf("loading configuration: %w", err)
+	}
+	logCfg := cfg.Log
+	if !verbose {
+		logCfg.Level = "warn"
+	}
+	return &environment{
+		ctx:    ctx,
+		cfg:    cfg,
+		logger: logger.NewLogger(logCfg),
+		stdin:  stdin,
+		stdout: stdout,
+	}, nil
+}
+
+func (e *environment) Close() {
+	if e.pool != nil {
+		e.pool.Close()
+	}
+}
+
+func (e *environment) db() (*pgxpool.Pool, error) {
+	if e.pool == nil {
+		pool, err := repo.NewPgxPool(e.ctx, e.cfg.Database, tracing.NewPgxTracer(noop.NewTracerProvider()), e.logger)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to database: %w", err)
+		}
+		e.pool = pool
+	}
+	return e.pool, nil
+}
+
+// maintenance wires the MaintenanceUseCase. withStorage connects to MinIO as well.
+func (e *environment) maintenance(withStorage bool) (*uc.MaintenanceUseCase, error) {
+	pool, err := e.db()
+	if err != nil {
+		return nil, err
+	}
+	secHelper, err := security.NewSecurity(e.cfg.JWT.SecretKey, e.logger)
+	if err != nil {
+		return nil, fmt.Errorf("initializing security helper: %w", err)
+	}
+	var storage port.FileStorageService
+	if withStorage {
+		storage, err = minioadapter.NewMinioStorageService(e.cfg.Minio, e.logger)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to storage: %w", err)
+		}
+	}
+	return uc.NewMaintenanceUseCase(
+		e.cfg.Minio,
+		repo.NewUserRepository(pool, e.logger),
+		repo.NewRefreshTokenRepository(pool, e.logger),
+		repo.NewAudioTrackRepository(pool, e.logger),
+		repo.NewAudioCollectionRepository(pool, e.logger),
+		storage,
+		repo.NewTxManager(pool, e.logger),
+		repo.NewAuditEventRepository(pool, e.logger),
+		secHelper,
+		e.logger,
+	), nil
+}
+
+// importer wires the ImportUseCase. Imports always connect to MinIO.
+func (e *environment) importer() (*uc.ImportUseCase, error) {
+	pool, err := e.db()
+	if err != nil {
+		return nil, err
+	}
+	storage, err := minioadapter.NewMinioStorageService(e.cfg.Minio, e.logger)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to storage: %w", err)
+	}
+	return uc.NewImportUseCase(
+		e.cfg.Import,
+		e.cfg.Minio,
+		repo.NewUserRepository(pool, e.logger),
+		repo.NewAudioTrackRepository(pool, e.logger),
+		repo.NewAudioCollectionRepository(pool, e.logger),
+		repo.NewImportRunRepository(pool, e.logger),
+		repo.NewJobRepository(pool, e.logger),
+		storage,
+		repo.NewTxManager(pool, e.logger),
+		repo.NewAuditEventRepository(pool, e.logger),
+		repo.NewOutboxRepository(pool, e.logger),
+		e.logger,
+	), nil
+}
@@ -0,0 +1,113 @@
+// cmd/worker/main.go
+
+// Command worker runs the background job runner as a standalone process. It uses the
+// same configuration and wiring as cmd/api; set jobs.runInApi to false to run jobs
+// only here.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	repo "github.com/yvanyang/language-learning-player-api/internal/adapter/repository/postgres"
+	feedfetchadapter "github.com/yvanyang/language-learning-player-api/internal/adapter/service/feedfetch"
+	minioadapter "github.com/yvanyang/language-learning-player-api/internal/adapter/service/minio"
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/tracing"
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	uc "github.com/yvanyang/language-learning-player-api/internal/usecase"
+	"github.com/yvanyang/language-learning-player-api/pkg/logger"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// --- Configuration ---
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// --- Logger ---
+	appLogger := logger.NewLogger(cfg.Log).With("process", "worker")
+	slog.SetDefault(appLogger)
+	appLogger.Info("Configuration loaded", "environment", os.Getenv("APP_ENV"))
+
+	// Tracing (installs a no-op provider when disabled)
+	tracerProvider, shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, appLogger)
+	if err != nil {
+		appLogger.Error("Failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+
+	// Database Pool
+	dbPool, err := repo.NewPgxPool(ctx, cfg.Database, tracing.NewPgxTracer(tracerProvider), appLogger)
+	if err != nil {
+		appLogger.Error("Failed to initialize database connection pool", "error", err)
+		os.Exit(1)
+	}
+	defer dbPool.Close()
+
+	txManager := repo.NewTxManager(dbPool, appLogger)
+
+	// Storage
+	minioService, err := minioadapter.NewMinioStorageService(cfg.Minio, appLogger)
+	if err != nil {
+		appLogger.Error("Failed to initialize MinIO storage service", "error", err)
+		os.Exit(1)
+	}
+	storageService := tracing.NewTracedFileStorage(minioService, tracerProvider)
+
+	// Job runner
+	jobRepo := repo.NewJobRepository(dbPool, appLogger)
+	userRepo := repo.NewUserRepository(dbPool, appLogger)
+	trackRepo := repo.NewAudioTrackRepository(dbPool, appLogger)
+	collectionRepo := repo.NewAudioCollectionRepository(dbPool, appLogger)
+	auditRepo := repo.NewAuditEventRepository(dbPool, appLogger)
+	outboxRepo := repo.NewOutboxRepository(dbPool, appLogger)
+	importUseCase := uc.NewImportUseCase(
+		cfg.Import, cfg.Minio,
+		userRepo, trackRepo, collectionRepo,
+		repo.NewImportRunRepository(dbPool, appLogger),
+		jobRepo, storageService, txManager, auditRepo, outboxRepo,
+		appLogger,
+	)
+	feedIngestUseCase := uc.NewFeedIngestUseCase(
+		cfg.FeedIngest, cfg.Minio,
+		userRepo, trackRepo, repo.NewTrackChapterRepository(dbPool, appLogger), collectionRepo,
+		repo.NewFeedSubscriptionRepository(dbPool, appLogger),
+		jobRepo, storageService,
+		feedfetchadapter.NewFetcher(cfg.FeedIngest, appLogger),
+		txManager, auditRepo, outboxRepo,
+		appLogger,
+	)
+	jobRunner := uc.NewJobRunner(cfg.Jobs, jobRepo, appLogger)
+	deps := uc.JobDependencies{JobRepo: jobRepo, TrackRepo: trackRepo, Storage: storageService, Importer: importUseCase, Feeds: feedIngestUseCase}
+	if err := uc.RegisterJobHandlers(jobRunner, cfg.Jobs, deps, appLogger); err != nil {
+		appLogger.Error("Failed to register job handlers", "error", err)
+		os.Exit(1)
+	}
+
+	// Run blocks until SIGINT/SIGTERM, then drains running jobs within jobs.shutdownTimeout
+	jobRunner.Run(ctx)
+	stop()
+
+	// --- Final Cleanup ---
+	appLogger.Info("Closing database connection pool...")
+	dbPool.Close()
+	appLogger.Info("Database connection pool closed.")
+
+	// Flush any buffered spans
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFlush()
+	if err := shutdownTracing(flushCtx); err != nil {
+		appLogger.Error("Failed to flush traces", "error", err)
+	}
+	appLogger.Info("Worker finished.")
+}
@@ -4,20 +4,30 @@
 package http
 
 import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"strconv"
 	"strings" // Import strings
+	"unicode"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/dto"        // Import for GetUserIDFromContext
 	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/middleware" // Correct middleware import path
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
 	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/chapters"
 	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
 	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+	"github.com/yvanyang/language-learning-player-api/pkg/playlist"
 	"github.com/yvanyang/language-learning-player-api/pkg/validation"
 )
 
@@ -41,14 +51,18 @@ func NewAudioHandler(uc port.AudioContentUseCase, v *validation.Validator) *Audi
 // GetTrackDetails handles GET /api/v1/audio/tracks/{trackId}
 // @Summary Get audio track details
 // @Description Retrieves details for a specific audio track, including metadata, playback URL, and user-specific progress/bookmarks if authenticated.
+// @Description The response carries a weak ETag covering everything except the presigned playUrl. A 304 therefore means only that the cached copy is current; its playUrl may have expired.
 // @ID get-track-details
 // @Tags Audio Tracks
 // @Produce json
 // @Param trackId path string true "Audio Track UUID" Format(uuid)
+// @Param If-None-Match header string false "ETag from a previous response; returns 304 if unchanged"
 // @Security BearerAuth // Optional: Indicate that auth affects the response (user data)
 // @Success 200 {object} dto.AudioTrackDetailsResponseDTO "Audio track details found"
+// @Success 304 "Not Modified"
 // @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Track ID Format"
 // @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized (if accessing private track without auth)"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (private track of another user, not shared through a collection)"
 // @Failure 404 {object} httputil.ErrorResponseDTO "Track Not Found"
 // @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
 // @Router /audio/tracks/{trackId} [get]
@@ -70,9 +84,198 @@ func (h *AudioHandler) GetTrackDetails(w http.ResponseWriter, r *http.Request) {
 	// Point 4: Mapping moved here, using a mapper function in dto package
 	resp := dto.MapDomainTrackToDetailsResponseDTO(result) // Pass the result struct to the mapper
 
+	// Progress and bookmarks depend on the caller, so caches must key on the token too.
+	w.Header().Add("Vary", "Authorization")
+	if httputil.NotModified(w, r, trackDetailsETag(resp)) {
+		return
+	}
 	httputil.RespondJSON(w, r, http.StatusOK, resp)
 }
 
+// trackDetailsETag derives a weak ETag from the track version and the caller-specific
+// parts of the response. The presigned playUrl is left out because it changes on every request.
+func trackDetailsETag(resp dto.AudioTrackDetailsResponseDTO) string {
+	resp.PlayURL = ""
+	payload, _ := json.Marshal(resp)
+	sum := sha256.Sum256(payload)
+	return httputil.WeakETag(fmt.Sprintf("%d-%s", resp.Version, hex.EncodeToString(sum[:8])))
+}
+
+// GetTrackChapters handles GET /api/v1/audio/tracks/{trackId}/chapters
+// @Summary Get or export the chapters of a track
+// @Description Returns the chapters of a track as JSON, or with `format` as a chapter file download: Podlove Simple Chapters JSON,
+// @Description a WebVTT chapter track, or an ID3v2.4 tag with CHAP frames that tag editors can apply to the MP3 file.
+// @Description Chapters are visible to anyone who may see the track details.
+// @ID get-track-chapters
+// @Tags Audio Tracks
+// @Produce json,text/vtt,application/octet-stream
+// @Param trackId path string true "Audio Track UUID" Format(uuid)
+// @Param format query string false "Export file format" Enums(podlove, webvtt, id3)
+// @Security BearerAuth
+// @Success 200 {object} dto.TrackChaptersResponseDTO "Chapters found"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Track ID Format / Unsupported format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized (if accessing private track without auth)"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (private track of another user, not shared through a collection)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Track Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/tracks/{trackId}/chapters [get]
+func (h *AudioHandler) GetTrackChapters(w http.ResponseWriter, r *http.Request) {
+	trackID, err := domain.TrackIDFromString(chi.URLParam(r, "trackId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid track ID format", domain.ErrInvalidArgument))
+		return
+	}
+	var format chapters.Format
+	if v := r.URL.Query().Get("format"); v != "" {
+		if format, err = chapters.ParseFormat(v); err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+			return
+		}
+	}
+	trackChapters, err := h.audioUseCase.GetTrackChapters(r.Context(), trackID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	if format == "" {
+		httputil.RespondJSON(w, r, http.StatusOK, dto.TrackChaptersResponseDTO{Chapters: dto.MapDomainChaptersToResponseDTO(trackChapters)})
+		return
+	}
+
+	exported := make([]chapters.Chapter, len(trackChapters))
+	for i, c := range trackChapters {
+		exported[i] = chapters.Chapter{Title: c.Title, Description: c.Description, Start: c.Start, End: c.End}
+	}
+	var buf bytes.Buffer
+	if err := chapters.Encode(&buf, format, exported); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("encoding chapters: %w", err))
+		return
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": trackID.String() + "-chapters." + format.Extension(),
+	}))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// UpdateTrackChapters handles PUT /api/v1/audio/tracks/{trackId}/chapters
+// @Summary Replace the chapters of a track
+// @Description Replaces all chapters of a track uploaded by the caller; an empty list removes them. Chapters are sorted by start time.
+// @Description An omitted end means the start of the next chapter, or the end of the track for the last one. Chapters may leave gaps
+// @Description but must not overlap, and must start before the end of the track.
+// @ID update-track-chapters
+// @Tags Audio Tracks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param trackId path string true "Audio Track UUID" Format(uuid)
+// @Param chapters body dto.UpdateTrackChaptersRequestDTO true "New chapters"
+// @Success 200 {object} dto.TrackChaptersResponseDTO "Chapters as stored"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Overlapping chapters"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not the uploader)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Track Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/tracks/{trackId}/chapters [put]
+func (h *AudioHandler) UpdateTrackChapters(w http.ResponseWriter, r *http.Request) {
+	trackID, err := domain.TrackIDFromString(chi.URLParam(r, "trackId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid track ID format", domain.ErrInvalidArgument))
+		return
+	}
+	var req dto.UpdateTrackChaptersRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+	h.replaceTrackChapters(w, r, trackID, req.ToDomain())
+}
+
+// maxChapterImportSize limits the size of an imported chapter file. ID3 chapters are read from
+// the tag at the start of the body, so a whole MP3 file may be sent if its tag fits.
+const maxChapterImportSize = 16 << 20
+
+// ImportTrackChapters handles POST /api/v1/audio/tracks/{trackId}/chapters/import
+// @Summary Import the chapters of a track
+// @Description Replaces the chapters of a track uploaded by the caller with those of a chapter file sent as the request body:
+// @Description Podlove Simple Chapters JSON (Podcasting 2.0 JSON chapters are accepted too), a WebVTT chapter track, or an
+// @Description ID3v2 tag or MP3 file with CHAP frames. The chapters are validated as in the PUT endpoint.
+// @ID import-track-chapters
+// @Tags Audio Tracks
+// @Accept json,text/vtt,audio/mpeg,application/octet-stream
+// @Produce json
+// @Security BearerAuth
+// @Param trackId path string true "Audio Track UUID" Format(uuid)
+// @Param format query string false "File format; detected from the content if omitted" Enums(podlove, webvtt, id3)
+// @Param chapters body string true "Chapter file"
+// @Success 200 {object} dto.TrackChaptersResponseDTO "Chapters as stored"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid or unrecognized chapter file"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not the uploader)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Track Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/tracks/{trackId}/chapters/import [post]
+func (h *AudioHandler) ImportTrackChapters(w http.ResponseWriter, r *http.Request) {
+	trackID, err := domain.TrackIDFromString(chi.URLParam(r, "trackId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid track ID format", domain.ErrInvalidArgument))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxChapterImportSize)
+	defer r.Body.Close()
+	body := bufio.NewReader(r.Body)
+
+	var format chapters.Format
+	if v := r.URL.Query().Get("format"); v != "" {
+		if format, err = chapters.ParseFormat(v); err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+			return
+		}
+	} else {
+		head, _ := body.Peek(512)
+		detected, ok := chapters.DetectFormat(head)
+		if !ok {
+			httputil.RespondError(w, r, fmt.Errorf("%w: unrecognized chapter file; specify the format", domain.ErrInvalidArgument))
+			return
+		}
+		format = detected
+	}
+	decoded, err := chapters.Decode(body, format)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			err = fmt.Errorf("%w: the chapter file exceeds the maximum size of %d bytes", domain.ErrInvalidArgument, maxChapterImportSize)
+		case errors.Is(err, chapters.ErrInvalid):
+			err = fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err)
+		}
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	imported := make([]domain.TrackChapter, len(decoded))
+	for i, c := range decoded {
+		imported[i] = domain.TrackChapter{Title: c.Title, Description: c.Description, Start: c.Start, End: c.End}
+	}
+	h.replaceTrackChapters(w, r, trackID, imported)
+}
+
+// replaceTrackChapters stores new chapters of a track and responds with them as stored.
+func (h *AudioHandler) replaceTrackChapters(w http.ResponseWriter, r *http.Request, trackID domain.TrackID, trackChapters []domain.TrackChapter) {
+	stored, err := h.audioUseCase.UpdateTrackChapters(r.Context(), trackID, trackChapters)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, dto.TrackChaptersResponseDTO{Chapters: dto.MapDomainChaptersToResponseDTO(stored)})
+}
+
 // Point 6: Refactored parameter parsing into helper function parseListTracksInput
 func parseListTracksInput(r *http.Request) (port.ListTracksInput, error) {
 	q := r.URL.Query()
@@ -181,9 +384,85 @@ func (h *AudioHandler) ListTracks(w http.ResponseWriter, r *http.Request) {
 
 // --- Collection Handlers ---
 
+// parseListCollectionsInput parses the query parameters of the public collection listing.
+func parseListCollectionsInput(r *http.Request) (port.ListCollectionsInput, error) {
+	tracksInput, err := parseListTracksInput(r) // Shares q, lang, level, tags, sorting and pagination
+	if err != nil {
+		return port.ListCollectionsInput{}, err
+	}
+	input := port.ListCollectionsInput{
+		Query:         tracksInput.Query,
+		LanguageCode:  tracksInput.LanguageCode,
+		Level:         tracksInput.Level,
+		Tags:          tracksInput.Tags,
+		SortBy:        tracksInput.SortBy,
+		SortDirection: tracksInput.SortDirection,
+		Page:          tracksInput.Page,
+	}
+	if ownerIDStr := r.URL.Query().Get("ownerId"); ownerIDStr != "" {
+		ownerID, err := domain.UserIDFromString(ownerIDStr)
+		if err != nil {
+			return input, fmt.Errorf("%w: invalid ownerId query parameter", domain.ErrInvalidArgument)
+		}
+		input.OwnerID = &ownerID
+	}
+	return input, nil
+}
+
+// ListCollections handles GET /api/v1/audio/collections
+// @Summary Browse public audio collections
+// @Description Retrieves a paginated list of public collections. Unlisted and private collections are never listed.
+// @Description Language, level and tag filters match collections containing at least one public track with those attributes.
+// @ID list-audio-collections
+// @Tags Audio Collections
+// @Produce json
+// @Param q query string false "Search query (searches title, description)"
+// @Param lang query string false "Filter by language code of the tracks (e.g., en-US)"
+// @Param level query string false "Filter by audio level of the tracks" Enums(A1, A2, B1, B2, C1, C2, NATIVE)
+// @Param tags query []string false "Filter by tags of the tracks (e.g., ?tags=news&tags=podcast)" collectionFormat(multi)
+// @Param ownerId query string false "Filter by owner" Format(uuid)
+// @Param sortBy query string false "Sort field (createdAt, updatedAt, title)" default(createdAt)
+// @Param sortDir query string false "Sort direction (asc or desc)" default(desc) Enums(asc, desc)
+// @Param limit query int false "Pagination limit" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Pagination offset" default(0) minimum(0)
+// @Success 200 {object} dto.PaginatedResponseDTO{data=[]dto.AudioCollectionResponseDTO} "Paginated list of public collections"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Query Parameter Format"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections [get]
+func (h *AudioHandler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	ucInput, err := parseListCollectionsInput(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	collections, total, actualPageInfo, err := h.audioUseCase.ListPublicCollections(r.Context(), ucInput)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	respData := make([]dto.AudioCollectionResponseDTO, len(collections))
+	for i, col := range collections {
+		respData[i] = dto.MapDomainCollectionToResponseDTO(col, nil)
+	}
+
+	paginatedResult := pagination.NewPaginatedResponse(respData, total, actualPageInfo)
+	resp := dto.PaginatedResponseDTO{
+		Data:       paginatedResult.Data,
+		Total:      paginatedResult.Total,
+		Limit:      paginatedResult.Limit,
+		Offset:     paginatedResult.Offset,
+		Page:       paginatedResult.Page,
+		TotalPages: paginatedResult.TotalPages,
+	}
+
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
+
 // ListMyCollections handles GET /api/v1/users/me/collections
 // @Summary List my audio collections
-// @Description Retrieves a paginated list of audio collections owned by the currently authenticated user.
+// @Description Retrieves a paginated list of audio collections the currently authenticated user owns or was invited to, with the user's role in each.
 // @ID list-my-collections
 // @Tags Audio Collections
 // @Produce json
@@ -234,7 +513,8 @@ func (h *AudioHandler) ListMyCollections(w http.ResponseWriter, r *http.Request)
 	respData := make([]dto.AudioCollectionResponseDTO, len(collections))
 	for i, col := range collections {
 		// Pass nil for tracks slice to indicate it's a list view
-		respData[i] = dto.MapDomainCollectionToResponseDTO(col, nil)
+		respData[i] = dto.MapDomainCollectionToResponseDTO(col.Collection, nil)
+		respData[i].Role = col.Role.String()
 	}
 
 	// Construct paginated response using actual page info from use case
@@ -253,14 +533,17 @@ func (h *AudioHandler) ListMyCollections(w http.ResponseWriter, r *http.Request)
 
 // CreateCollection handles POST /api/v1/audio/collections
 // @Summary Create an audio collection
-// @Description Creates a new audio collection (playlist or course) for the authenticated user.
+// @Description Creates a new audio collection (playlist, course or smart collection) for the authenticated user.
+// @Description SMART collections take a smartRule instead of tracks; their contents are evaluated for each viewer when read.
 // @ID create-audio-collection
 // @Tags Audio Collections
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param collection body dto.CreateCollectionRequestDTO true "Collection details"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the original response"
 // @Success 201 {object} dto.AudioCollectionResponseDTO "Collection created successfully"
+// @Header 201 {string} ETag "Collection version; send it as If-Match when updating"
 // @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Track ID Format / Collection Type"
 // @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
 // @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
@@ -289,29 +572,44 @@ func (h *AudioHandler) CreateCollection(w http.ResponseWriter, r *http.Request)
 	}
 
 	collectionType := domain.CollectionType(req.Type)
+	var smartRule *domain.SmartRule
+	if req.SmartRule != nil {
+		rule, err := dto.MapSmartRuleDTOToDomain(*req.SmartRule)
+		if err != nil {
+			httputil.RespondError(w, r, err)
+			return
+		}
+		smartRule = &rule
+	}
 
-	collection, err := h.audioUseCase.CreateCollection(r.Context(), req.Title, req.Description, collectionType, initialTrackIDs)
+	collection, err := h.audioUseCase.CreateCollection(r.Context(), req.Title, req.Description, collectionType, domain.CollectionVisibility(req.Visibility), initialTrackIDs, smartRule)
 	if err != nil {
 		httputil.RespondError(w, r, err)
 		return
 	}
 	// Map the created collection (potentially with initial track IDs, but not full track details)
 	resp := dto.MapDomainCollectionToResponseDTO(collection, nil) // Pass nil for tracks here
+	w.Header().Set("ETag", httputil.VersionETag(collection.Version))
 	httputil.RespondJSON(w, r, http.StatusCreated, resp)
 }
 
 // GetCollectionDetails handles GET /api/v1/audio/collections/{collectionId}
 // @Summary Get audio collection details
-// @Description Retrieves details for a specific audio collection, including its metadata and ordered list of tracks. Backend verifies if user can view it.
+// @Description Retrieves details for a specific audio collection, including its metadata and ordered list of tracks.
+// @Description Private collections are visible only to their owner and members; unlisted and public collections can be read by anyone with the link, including anonymous callers.
+// @Description Viewers other than the owner and members only see the tracks they may access themselves; other users' private tracks are left out.
+// @Description For SMART collections the tracks are the first page of the rule's matches for the caller; use GET /audio/collections/{collectionId}/tracks for more.
 // @ID get-collection-details
 // @Tags Audio Collections
 // @Produce json
 // @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param If-None-Match header string false "ETag from a previous response; returns 304 if unchanged"
 // @Security BearerAuth // Indicate auth might affect response or access
 // @Success 200 {object} dto.AudioCollectionResponseDTO "Audio collection details found"
+// @Header 200 {string} ETag "Collection version and a digest of the response; send it as If-Match when updating"
+// @Success 304 "Not Modified"
 // @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Collection ID Format"
-// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized (if collection is private and not owned)"
-// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (if user cannot access)"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (collection is private and not owned)"
 // @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
 // @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error (e.g., failed to fetch tracks)"
 // @Router /audio/collections/{collectionId} [get]
@@ -329,9 +627,11 @@ func (h *AudioHandler) GetCollectionDetails(w http.ResponseWriter, r *http.Reque
 		httputil.RespondError(w, r, err) // Handles NotFound, PermissionDenied etc.
 		return
 	}
+	// The visible tracks depend on the caller, so caches must key on the token too.
+	w.Header().Add("Vary", "Authorization")
 
 	// Fetch tracks associated with the collection
-	tracks, err := h.audioUseCase.GetCollectionTracks(r.Context(), collectionID)
+	tracks, _, _, err := h.audioUseCase.GetCollectionTracks(r.Context(), collectionID, pagination.Page{})
 	if err != nil {
 		// Log error but might still return collection metadata
 		slog.Default().ErrorContext(r.Context(), "Failed to fetch tracks for collection details", "error", err, "collectionID", collectionID)
@@ -341,24 +641,371 @@ func (h *AudioHandler) GetCollectionDetails(w http.ResponseWriter, r *http.Reque
 
 	// Map collection and its tracks to the response DTO
 	resp := dto.MapDomainCollectionToResponseDTO(collection, tracks)
+	// Embedded tracks change (edits, visibility, deletion) without bumping the collection version,
+	// so the ETag covers the rendered body. Smart collections change with the catalogue and the
+	// caller's progress; they are sent in full with the plain version tag for If-Match.
+	if collection.IsSmart() {
+		w.Header().Set("ETag", httputil.VersionETag(collection.Version))
+	} else if httputil.NotModified(w, r, collectionDetailsETag(resp)) {
+		return
+	}
 	httputil.RespondJSON(w, r, http.StatusOK, resp)
 }
 
+// collectionDetailsETag derives a strong ETag from the collection version and a digest of the
+// response, so it changes when embedded tracks do while still carrying the version for If-Match.
+func collectionDetailsETag(resp dto.AudioCollectionResponseDTO) string {
+	payload, _ := json.Marshal(resp)
+	sum := sha256.Sum256(payload)
+	return httputil.VersionDigestETag(resp.Version, hex.EncodeToString(sum[:8]))
+}
+
+// GetCollectionTracks handles GET /api/v1/audio/collections/{collectionId}/tracks
+// @Summary List the tracks of a collection
+// @Description Retrieves a page of a collection's tracks in collection order, with the same visibility rules as the collection details.
+// @Description SMART collections are evaluated live for the caller: their rule is run against the tracks the caller may access and the caller's listening progress.
+// @ID get-collection-tracks
+// @Tags Audio Collections
+// @Produce json
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param limit query int false "Pagination limit" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Pagination offset" default(0) minimum(0)
+// @Security BearerAuth
+// @Success 200 {object} dto.PaginatedResponseDTO{data=[]dto.AudioTrackResponseDTO} "Paginated list of tracks"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Collection ID Format / Pagination Parameters"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (collection is private and not owned)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/tracks [get]
+func (h *AudioHandler) GetCollectionTracks(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := domain.CollectionIDFromString(chi.URLParam(r, "collectionId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid collection ID format", domain.ErrInvalidArgument))
+		return
+	}
+	page, err := parsePage(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	page = pagination.NewPageFromOffset(page.Limit, page.Offset) // Always paginate, unlike the details endpoint
+
+	tracks, total, actualPageInfo, err := h.audioUseCase.GetCollectionTracks(r.Context(), collectionID, page)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Add("Vary", "Authorization")
+
+	respData := make([]dto.AudioTrackResponseDTO, len(tracks))
+	for i, track := range tracks {
+		respData[i] = dto.MapDomainTrackToResponseDTO(track)
+	}
+	paginatedResult := pagination.NewPaginatedResponse(respData, total, actualPageInfo)
+	resp := dto.PaginatedResponseDTO{
+		Data:       paginatedResult.Data,
+		Total:      paginatedResult.Total,
+		Limit:      paginatedResult.Limit,
+		Offset:     paginatedResult.Offset,
+		Page:       paginatedResult.Page,
+		TotalPages: paginatedResult.TotalPages,
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
+
+// UpdateSmartRule handles PUT /api/v1/audio/collections/{collectionId}/rule
+// @Summary Update the rule of a smart collection
+// @Description Replaces the track query of a SMART collection owned or edited by the authenticated user.
+// @Description Requires the collection's current ETag in If-Match (or "*" to overwrite unconditionally).
+// @ID update-smart-rule
+// @Tags Audio Collections
+// @Accept json
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param If-Match header string true "Current collection ETag"
+// @Param rule body dto.SmartRuleDTO true "New rule"
+// @Success 204 "Rule updated"
+// @Header 204 {string} ETag "New collection version"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Not a smart collection"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner or Editor)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 412 {object} httputil.ErrorResponseDTO "Collection was modified (ETag mismatch)"
+// @Failure 428 {object} httputil.ErrorResponseDTO "If-Match header missing"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/rule [put]
+func (h *AudioHandler) UpdateSmartRule(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := domain.CollectionIDFromString(chi.URLParam(r, "collectionId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid collection ID format", domain.ErrInvalidArgument))
+		return
+	}
+	expectedVersion, err := httputil.IfMatchVersion(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	var req dto.SmartRuleDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+	rule, err := dto.MapSmartRuleDTOToDomain(req)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	newVersion, err := h.audioUseCase.UpdateSmartRule(r.Context(), collectionID, rule, expectedVersion)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", httputil.VersionETag(newVersion))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// FreezeSmartCollection handles POST /api/v1/audio/collections/{collectionId}/freeze
+// @Summary Freeze a smart collection into a playlist
+// @Description Saves the tracks a SMART collection currently selects for the authenticated user as a new private playlist they own.
+// @Description The smart collection itself is left unchanged. Rules matching more than 1000 tracks cannot be frozen.
+// @ID freeze-smart-collection
+// @Tags Audio Collections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param freeze body dto.FreezeSmartCollectionRequestDTO false "Title of the new playlist"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the original response"
+// @Success 201 {object} dto.AudioCollectionResponseDTO "Playlist created"
+// @Header 201 {string} ETag "Playlist version; send it as If-Match when updating"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Not a smart collection / Too many tracks"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (collection is private and not owned)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/freeze [post]
+func (h *AudioHandler) FreezeSmartCollection(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := domain.CollectionIDFromString(chi.URLParam(r, "collectionId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid collection ID format", domain.ErrInvalidArgument))
+		return
+	}
+	var req dto.FreezeSmartCollectionRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) { // The body is optional
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+	playlist, err := h.audioUseCase.FreezeSmartCollection(r.Context(), collectionID, req.Title)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", httputil.VersionETag(playlist.Version))
+	httputil.RespondJSON(w, r, http.StatusCreated, dto.MapDomainCollectionToResponseDTO(playlist, nil))
+}
+
+// ForkCollection handles POST /api/v1/audio/collections/{collectionId}/fork
+// @Summary Fork a collection
+// @Description Copies a collection the caller can read into a new private collection they own, keeping its type, description and track order.
+// @Description Smart collections keep their rule and courses their outline (unpublished). Tracks the caller may not see are left out.
+// @ID fork-collection
+// @Tags Audio Collections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param fork body dto.ForkCollectionRequestDTO false "Title of the copy"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the original response"
+// @Success 201 {object} dto.AudioCollectionResponseDTO "Collection forked"
+// @Header 201 {string} ETag "Version of the copy; send it as If-Match when updating"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (collection is private and not owned)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/fork [post]
+func (h *AudioHandler) ForkCollection(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := domain.CollectionIDFromString(chi.URLParam(r, "collectionId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid collection ID format", domain.ErrInvalidArgument))
+		return
+	}
+	var req dto.ForkCollectionRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) { // The body is optional
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+	fork, err := h.audioUseCase.ForkCollection(r.Context(), collectionID, req.Title)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", httputil.VersionETag(fork.Version))
+	httputil.RespondJSON(w, r, http.StatusCreated, dto.MapDomainCollectionToResponseDTO(fork, nil))
+}
+
+// ExportCollection handles GET /api/v1/audio/collections/{collectionId}/export
+// @Summary Export a collection as a playlist file
+// @Description Downloads the collection's tracks as an M3U8 playlist, an XSPF playlist or a JSON manifest. Entries link to presigned
+// @Description stream URLs, which expire like track play URLs, and carry the track ID and content checksum so the file can be imported again.
+// @Description Smart collections are evaluated for the caller and export at most 1000 tracks.
+// @ID export-collection
+// @Tags Audio Collections
+// @Produce audio/x-mpegurl,application/xspf+xml,json
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param format query string false "File format" Enums(m3u8, xspf, json) default(m3u8)
+// @Success 200 {file} file "Playlist file"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Collection ID / Format"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (collection is private and not owned)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/export [get]
+func (h *AudioHandler) ExportCollection(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := domain.CollectionIDFromString(chi.URLParam(r, "collectionId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid collection ID format", domain.ErrInvalidArgument))
+		return
+	}
+	format := playlist.FormatM3U8
+	if v := r.URL.Query().Get("format"); v != "" {
+		if format, err = playlist.ParseFormat(v); err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+			return
+		}
+	}
+	exported, err := h.audioUseCase.ExportCollection(r.Context(), collectionID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := playlist.Encode(&buf, format, exported); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("encoding playlist: %w", err))
+		return
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": playlistFilename(exported.Title) + "." + format.Extension(),
+	}))
+	w.Header().Set("Cache-Control", "private, no-store") // Stream URLs are presigned for the caller
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// maxPlaylistImportSize limits the size of an imported playlist file.
+const maxPlaylistImportSize = 4 << 20
+
+// ImportPlaylist handles POST /api/v1/audio/collections/import
+// @Summary Import a playlist file
+// @Description Creates a private playlist from an M3U8, XSPF or JSON playlist sent as the request body (at most 4 MiB and 1000 entries).
+// @Description Entries are matched to public tracks and the caller's own by track ID, then by content checksum; entries without
+// @Description a match, and repeats of an earlier track, are skipped and listed as unresolved.
+// @ID import-playlist
+// @Tags Audio Collections
+// @Accept audio/x-mpegurl,application/xspf+xml,json
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "File format; detected from the content if omitted" Enums(m3u8, xspf, json)
+// @Param title query string false "Title of the new playlist (default: the title in the file)"
+// @Param playlist body string true "Playlist file"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the original response"
+// @Success 201 {object} dto.PlaylistImportResponseDTO "Playlist created"
+// @Header 201 {string} ETag "Playlist version; send it as If-Match when updating"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid or unrecognized playlist file"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/import [post]
+func (h *AudioHandler) ImportPlaylist(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxPlaylistImportSize)
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = fmt.Errorf("%w: the playlist exceeds the maximum size of %d bytes", domain.ErrInvalidArgument, maxPlaylistImportSize)
+		}
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	q := r.URL.Query()
+	var format playlist.Format
+	if v := q.Get("format"); v != "" {
+		if format, err = playlist.ParseFormat(v); err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+			return
+		}
+	} else if detected, ok := playlist.DetectFormat(body); ok {
+		format = detected
+	} else {
+		httputil.RespondError(w, r, fmt.Errorf("%w: unrecognized playlist file; specify the format", domain.ErrInvalidArgument))
+		return
+	}
+	decoded, err := playlist.Decode(bytes.NewReader(body), format)
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+
+	result, err := h.audioUseCase.ImportPlaylist(r.Context(), decoded, strings.TrimSpace(q.Get("title")))
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", httputil.VersionETag(result.Collection.Version))
+	httputil.RespondJSON(w, r, http.StatusCreated, dto.MapPlaylistImportToResponseDTO(result))
+}
+
+// playlistFilename turns a collection title into a safe download file name, without extension.
+func playlistFilename(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, strings.TrimSpace(title))
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "playlist"
+	}
+	return name
+}
+
 // UpdateCollectionMetadata handles PUT /api/v1/audio/collections/{collectionId}
 // @Summary Update collection metadata
-// @Description Updates the title and description of an audio collection owned by the authenticated user.
+// @Description Updates the title, description and visibility of an audio collection. Editors may update everything but the visibility.
+// @Description Requires the collection's current ETag in If-Match (or "*" to overwrite unconditionally).
 // @ID update-collection-metadata
 // @Tags Audio Collections
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param If-Match header string true "Current collection ETag"
 // @Param collection body dto.UpdateCollectionRequestDTO true "Updated collection metadata"
 // @Success 204 "Collection metadata updated successfully"
+// @Header 204 {string} ETag "New collection version"
 // @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Collection ID Format"
 // @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
-// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner or Editor)"
 // @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 412 {object} httputil.ErrorResponseDTO "Collection was modified (ETag mismatch)"
+// @Failure 428 {object} httputil.ErrorResponseDTO "If-Match header missing"
 // @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
 // @Router /audio/collections/{collectionId} [put]
 func (h *AudioHandler) UpdateCollectionMetadata(w http.ResponseWriter, r *http.Request) {
@@ -368,6 +1015,11 @@ func (h *AudioHandler) UpdateCollectionMetadata(w http.ResponseWriter, r *http.R
 		httputil.RespondError(w, r, fmt.Errorf("%w: invalid collection ID format", domain.ErrInvalidArgument))
 		return
 	}
+	expectedVersion, err := httputil.IfMatchVersion(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
 	var req dto.UpdateCollectionRequestDTO
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
@@ -378,30 +1030,41 @@ func (h *AudioHandler) UpdateCollectionMetadata(w http.ResponseWriter, r *http.R
 		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
 		return
 	}
+	var visibility *domain.CollectionVisibility
+	if req.Visibility != nil {
+		v := domain.CollectionVisibility(*req.Visibility)
+		visibility = &v
+	}
 	// Use case handles ownership check
-	err = h.audioUseCase.UpdateCollectionMetadata(r.Context(), collectionID, req.Title, req.Description)
+	newVersion, err := h.audioUseCase.UpdateCollectionMetadata(r.Context(), collectionID, req.Title, req.Description, visibility, expectedVersion)
 	if err != nil {
 		httputil.RespondError(w, r, err)
 		return
 	}
+	w.Header().Set("ETag", httputil.VersionETag(newVersion))
 	w.WriteHeader(http.StatusNoContent)
 }
 
 // UpdateCollectionTracks handles PUT /api/v1/audio/collections/{collectionId}/tracks
 // @Summary Update collection tracks
-// @Description Updates the ordered list of tracks within a specific collection owned by the authenticated user. Replaces the entire list.
+// @Description Updates the ordered list of tracks within a specific collection owned or edited by the authenticated user. Replaces the entire list.
+// @Description Requires the collection's current ETag in If-Match (or "*" to overwrite unconditionally).
 // @ID update-collection-tracks
 // @Tags Audio Collections
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param If-Match header string true "Current collection ETag"
 // @Param tracks body dto.UpdateCollectionTracksRequestDTO true "Ordered list of track UUIDs"
 // @Success 204 "Collection tracks updated successfully"
+// @Header 204 {string} ETag "New collection version"
 // @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Collection or Track ID Format"
 // @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
-// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner or Editor)"
 // @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found / Track Not Found"
+// @Failure 412 {object} httputil.ErrorResponseDTO "Collection was modified (ETag mismatch)"
+// @Failure 428 {object} httputil.ErrorResponseDTO "If-Match header missing"
 // @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
 // @Router /audio/collections/{collectionId}/tracks [put]
 func (h *AudioHandler) UpdateCollectionTracks(w http.ResponseWriter, r *http.Request) {
@@ -411,6 +1074,11 @@ func (h *AudioHandler) UpdateCollectionTracks(w http.ResponseWriter, r *http.Req
 		httputil.RespondError(w, r, fmt.Errorf("%w: invalid collection ID format", domain.ErrInvalidArgument))
 		return
 	}
+	expectedVersion, err := httputil.IfMatchVersion(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
 	var req dto.UpdateCollectionTracksRequestDTO
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
@@ -431,14 +1099,184 @@ func (h *AudioHandler) UpdateCollectionTracks(w http.ResponseWriter, r *http.Req
 		orderedTrackIDs = append(orderedTrackIDs, id)
 	}
 	// Use case handles ownership check
-	err = h.audioUseCase.UpdateCollectionTracks(r.Context(), collectionID, orderedTrackIDs)
+	newVersion, err := h.audioUseCase.UpdateCollectionTracks(r.Context(), collectionID, orderedTrackIDs, expectedVersion)
 	if err != nil {
 		httputil.RespondError(w, r, err)
 		return
 	}
+	w.Header().Set("ETag", httputil.VersionETag(newVersion))
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// AddCollectionTrack handles POST /api/v1/audio/collections/{collectionId}/tracks
+// @Summary Add a track to a collection
+// @Description Inserts a single track into a collection owned or edited by the authenticated user, shifting later tracks down.
+// @Description The track must be public or belong to the collection's owner or the authenticated user. Omit position to append.
+// @Description Requires the collection's current ETag in If-Match (or "*" to apply unconditionally).
+// @ID add-collection-track
+// @Tags Audio Collections
+// @Accept json
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param If-Match header string true "Current collection ETag"
+// @Param track body dto.AddCollectionTrackRequestDTO true "Track UUID and optional 0-based position"
+// @Success 204 "Track added"
+// @Header 204 {string} ETag "New collection version"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Track does not exist"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner or Editor)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 409 {object} httputil.ErrorResponseDTO "Track already in collection / Course has an outline"
+// @Failure 412 {object} httputil.ErrorResponseDTO "Collection was modified (ETag mismatch)"
+// @Failure 428 {object} httputil.ErrorResponseDTO "If-Match header missing"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/tracks [post]
+func (h *AudioHandler) AddCollectionTrack(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := domain.CollectionIDFromString(chi.URLParam(r, "collectionId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid collection ID format", domain.ErrInvalidArgument))
+		return
+	}
+	expectedVersion, err := httputil.IfMatchVersion(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	var req dto.AddCollectionTrackRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+	trackID, err := domain.TrackIDFromString(req.TrackID)
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid track ID format", domain.ErrInvalidArgument))
+		return
+	}
+	position := -1 // Append
+	if req.Position != nil {
+		position = *req.Position
+	}
+	newVersion, err := h.audioUseCase.AddCollectionTrack(r.Context(), collectionID, trackID, position, expectedVersion)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", httputil.VersionETag(newVersion))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MoveCollectionTrack handles PUT /api/v1/audio/collections/{collectionId}/tracks/{trackId}/position
+// @Summary Move a track within a collection
+// @Description Moves a track to a new 0-based position in a collection owned or edited by the authenticated user. Positions beyond the end move the track to the end.
+// @Description Requires the collection's current ETag in If-Match (or "*" to apply unconditionally).
+// @ID move-collection-track
+// @Tags Audio Collections
+// @Accept json
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param trackId path string true "Audio Track UUID" Format(uuid)
+// @Param If-Match header string true "Current collection ETag"
+// @Param position body dto.MoveCollectionTrackRequestDTO true "New 0-based position"
+// @Success 204 "Track moved"
+// @Header 204 {string} ETag "New collection version"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner or Editor)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found / Track not in collection"
+// @Failure 409 {object} httputil.ErrorResponseDTO "Course has an outline"
+// @Failure 412 {object} httputil.ErrorResponseDTO "Collection was modified (ETag mismatch)"
+// @Failure 428 {object} httputil.ErrorResponseDTO "If-Match header missing"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/tracks/{trackId}/position [put]
+func (h *AudioHandler) MoveCollectionTrack(w http.ResponseWriter, r *http.Request) {
+	collectionID, trackID, err := parseCollectionTrackIDs(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	expectedVersion, err := httputil.IfMatchVersion(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	var req dto.MoveCollectionTrackRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+	newVersion, err := h.audioUseCase.MoveCollectionTrack(r.Context(), collectionID, trackID, *req.Position, expectedVersion)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", httputil.VersionETag(newVersion))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveCollectionTrack handles DELETE /api/v1/audio/collections/{collectionId}/tracks/{trackId}
+// @Summary Remove a track from a collection
+// @Description Removes a single track from a collection owned or edited by the authenticated user, shifting later tracks up. The track itself is not deleted.
+// @Description Requires the collection's current ETag in If-Match (or "*" to apply unconditionally).
+// @ID remove-collection-track
+// @Tags Audio Collections
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param trackId path string true "Audio Track UUID" Format(uuid)
+// @Param If-Match header string true "Current collection ETag"
+// @Success 204 "Track removed"
+// @Header 204 {string} ETag "New collection version"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner or Editor)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found / Track not in collection"
+// @Failure 409 {object} httputil.ErrorResponseDTO "Course has an outline"
+// @Failure 412 {object} httputil.ErrorResponseDTO "Collection was modified (ETag mismatch)"
+// @Failure 428 {object} httputil.ErrorResponseDTO "If-Match header missing"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/tracks/{trackId} [delete]
+func (h *AudioHandler) RemoveCollectionTrack(w http.ResponseWriter, r *http.Request) {
+	collectionID, trackID, err := parseCollectionTrackIDs(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	expectedVersion, err := httputil.IfMatchVersion(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	newVersion, err := h.audioUseCase.RemoveCollectionTrack(r.Context(), collectionID, trackID, expectedVersion)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", httputil.VersionETag(newVersion))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseCollectionTrackIDs parses the collectionId and trackId URL parameters.
+func parseCollectionTrackIDs(r *http.Request) (domain.CollectionID, domain.TrackID, error) {
+	collectionID, err := domain.CollectionIDFromString(chi.URLParam(r, "collectionId"))
+	if err != nil {
+		return domain.CollectionID{}, domain.TrackID{}, fmt.Errorf("%w: invalid collection ID format", domain.ErrInvalidArgument)
+	}
+	trackID, err := domain.TrackIDFromString(chi.URLParam(r, "trackId"))
+	if err != nil {
+		return domain.CollectionID{}, domain.TrackID{}, fmt.Errorf("%w: invalid track ID format", domain.ErrInvalidArgument)
+	}
+	return collectionID, trackID, nil
+}
+
 // DeleteCollection handles DELETE /api/v1/audio/collections/{collectionId}
 // @Summary Delete an audio collection
 // @Description Deletes an audio collection owned by the authenticated user.
@@ -0,0 +1,126 @@
+// internal/adapter/handler/http/audit_handler.go
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/dto"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+)
+
+// AuditHandler handles HTTP requests for the audit log.
+type AuditHandler struct {
+	auditUseCase port.AuditUseCase
+}
+
+// NewAuditHandler creates a new AuditHandler.
+func NewAuditHandler(uc port.AuditUseCase) *AuditHandler {
+	return &AuditHandler{auditUseCase: uc}
+}
+
+// parseListAuditEventsInput parses query parameters for listing audit events.
+func parseListAuditEventsInput(r *http.Request) (port.ListAuditEventsInput, error) {
+	q := r.URL.Query()
+	input := port.ListAuditEventsInput{}
+
+	if actorIDStr := q.Get("actorId"); actorIDStr != "" {
+		actorID, err := domain.UserIDFromString(actorIDStr)
+		if err != nil {
+			return input, fmt.Errorf("%w: invalid actorId query parameter", domain.ErrInvalidArgument)
+		}
+		input.Filter.ActorID = &actorID
+	}
+	if action := q.Get("action"); action != "" {
+		a := domain.AuditAction(action)
+		input.Filter.Action = &a
+	}
+	if targetType := q.Get("targetType"); targetType != "" {
+		t := domain.AuditTargetType(targetType)
+		input.Filter.TargetType = &t
+	}
+	if targetID := q.Get("targetId"); targetID != "" {
+		input.Filter.TargetID = &targetID
+	}
+	if fromStr := q.Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return input, fmt.Errorf("%w: invalid from query parameter (must be RFC3339)", domain.ErrInvalidArgument)
+		}
+		input.Filter.From = &from
+	}
+	if toStr := q.Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return input, fmt.Errorf("%w: invalid to query parameter (must be RFC3339)", domain.ErrInvalidArgument)
+		}
+		input.Filter.To = &to
+	}
+
+	limitStr := q.Get("limit")
+	offsetStr := q.Get("offset")
+	limit, errLimit := strconv.Atoi(limitStr)
+	offset, errOffset := strconv.Atoi(offsetStr)
+	if (limitStr != "" && errLimit != nil) || (offsetStr != "" && errOffset != nil) {
+		return input, fmt.Errorf("%w: invalid limit or offset query parameter", domain.ErrInvalidArgument)
+	}
+	input.Page = pagination.Page{Limit: limit, Offset: offset}
+
+	return input, nil
+}
+
+// ListAuditEvents handles GET /api/v1/admin/audit-events
+// @Summary List audit events
+// @Description Retrieves a paginated list of audit log entries, newest first. Requires the admin role.
+// @ID list-audit-events
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param actorId query string false "Filter by acting user UUID" Format(uuid)
+// @Param action query string false "Filter by action (e.g., auth.login, collection.delete)"
+// @Param targetType query string false "Filter by target type" Enums(user, collection, track)
+// @Param targetId query string false "Filter by target ID"
+// @Param from query string false "Only events at or after this time (RFC3339)" Format(date-time)
+// @Param to query string false "Only events before this time (RFC3339)" Format(date-time)
+// @Param limit query int false "Pagination limit" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Pagination offset" default(0) minimum(0)
+// @Success 200 {object} dto.PaginatedResponseDTO{data=[]dto.AuditEventResponseDTO} "Paginated list of audit events"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Query Parameter Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/audit-events [get]
+func (h *AuditHandler) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	input, err := parseListAuditEventsInput(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	events, total, actualPageInfo, err := h.auditUseCase.ListAuditEvents(r.Context(), input)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	respData := make([]dto.AuditEventResponseDTO, len(events))
+	for i, event := range events {
+		respData[i] = dto.MapDomainAuditEventToResponseDTO(event)
+	}
+
+	paginatedResult := pagination.NewPaginatedResponse(respData, total, actualPageInfo)
+	resp := dto.PaginatedResponseDTO{
+		Data:       paginatedResult.Data,
+		Total:      paginatedResult.Total,
+		Limit:      paginatedResult.Limit,
+		Offset:     paginatedResult.Offset,
+		Page:       paginatedResult.Page,
+		TotalPages: paginatedResult.TotalPages,
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
@@ -0,0 +1,424 @@
+// internal/adapter/handler/http/collection_sharing_handler.go
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/dto"
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/middleware"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+	"github.com/yvanyang/language-learning-player-api/pkg/validation"
+)
+
+// CollectionSharingHandler handles HTTP requests for collection members, invitations and ownership transfer.
+type CollectionSharingHandler struct {
+	sharingUseCase port.CollectionSharingUseCase
+	validator      *validation.Validator
+}
+
+// NewCollectionSharingHandler creates a new CollectionSharingHandler.
+func NewCollectionSharingHandler(uc port.CollectionSharingUseCase, v *validation.Validator) *CollectionSharingHandler {
+	return &CollectionSharingHandler{
+		sharingUseCase: uc,
+		validator:      v,
+	}
+}
+
+func parseCollectionID(r *http.Request) (domain.CollectionID, error) {
+	id, err := domain.CollectionIDFromString(chi.URLParam(r, "collectionId"))
+	if err != nil {
+		return domain.CollectionID{}, fmt.Errorf("%w: invalid collection ID format", domain.ErrInvalidArgument)
+	}
+	return id, nil
+}
+
+func parseInvitationID(r *http.Request) (domain.InvitationID, error) {
+	id, err := domain.InvitationIDFromString(chi.URLParam(r, "invitationId"))
+	if err != nil {
+		return domain.InvitationID{}, fmt.Errorf("%w: invalid invitation ID format", domain.ErrInvalidArgument)
+	}
+	return id, nil
+}
+
+// InviteCollaborator handles POST /api/v1/audio/collections/{collectionId}/invitations
+// @Summary Invite a collaborator
+// @Description Invites a user, by user ID or email address, to edit or view a collection owned by the authenticated user.
+// @Description Invitations by email can be accepted by whoever signs in with that address, including users who register later.
+// @Description A collection.invited webhook event is published for each invitation.
+// @ID invite-collection-collaborator
+// @Tags Audio Collections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param invitation body dto.InviteCollaboratorRequestDTO true "Invitee and role"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the original response"
+// @Success 201 {object} dto.InvitationResponseDTO "Invitation created"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Unknown User"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 409 {object} httputil.ErrorResponseDTO "Already a member or already invited"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/invitations [post]
+func (h *CollectionSharingHandler) InviteCollaborator(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	var req dto.InviteCollaboratorRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+	if (req.UserID == nil) == (req.Email == "") {
+		httputil.RespondError(w, r, fmt.Errorf("%w: provide either userId or email", domain.ErrInvalidArgument))
+		return
+	}
+
+	input := port.InviteCollaboratorInput{
+		InviterID:    userID,
+		CollectionID: collectionID,
+		Email:        req.Email,
+		Role:         domain.CollectionRole(req.Role),
+	}
+	if req.UserID != nil {
+		inviteeID, err := domain.UserIDFromString(*req.UserID)
+		if err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: invalid userId format", domain.ErrInvalidArgument))
+			return
+		}
+		input.InviteeID = &inviteeID
+	}
+	invitation, err := h.sharingUseCase.InviteCollaborator(r.Context(), input)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusCreated, dto.MapInvitationToResponseDTO(invitation))
+}
+
+// ListCollectionInvitations handles GET /api/v1/audio/collections/{collectionId}/invitations
+// @Summary List pending invitations of a collection
+// @Description Lists the pending invitations of a collection owned by the authenticated user, newest first.
+// @ID list-collection-invitations
+// @Tags Audio Collections
+// @Produce json
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Success 200 {array} dto.InvitationResponseDTO "Pending invitations"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Collection ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/invitations [get]
+func (h *CollectionSharingHandler) ListCollectionInvitations(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	invitations, err := h.sharingUseCase.ListCollectionInvitations(r.Context(), userID, collectionID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, mapInvitations(invitations))
+}
+
+// RevokeInvitation handles DELETE /api/v1/audio/collections/{collectionId}/invitations/{invitationId}
+// @Summary Revoke an invitation
+// @Description Withdraws a pending invitation to a collection owned by the authenticated user.
+// @ID revoke-collection-invitation
+// @Tags Audio Collections
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param invitationId path string true "Invitation UUID" Format(uuid)
+// @Success 204 "Invitation revoked"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection or Invitation Not Found"
+// @Failure 409 {object} httputil.ErrorResponseDTO "Invitation is no longer pending"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/invitations/{invitationId} [delete]
+func (h *CollectionSharingHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	invitationID, err := parseInvitationID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	if err := h.sharingUseCase.RevokeInvitation(r.Context(), userID, collectionID, invitationID); err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListMyInvitations handles GET /api/v1/users/me/invitations
+// @Summary List my pending invitations
+// @Description Lists the pending collection invitations addressed to the authenticated user by user ID or email address.
+// @ID list-my-invitations
+// @Tags Audio Collections
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.InvitationResponseDTO "Pending invitations"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /users/me/invitations [get]
+func (h *CollectionSharingHandler) ListMyInvitations(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	invitations, err := h.sharingUseCase.ListMyInvitations(r.Context(), userID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, mapInvitations(invitations))
+}
+
+// AcceptInvitation handles POST /api/v1/users/me/invitations/{invitationId}/accept
+// @Summary Accept an invitation
+// @Description Joins the collection with the role given in the invitation.
+// @ID accept-collection-invitation
+// @Tags Audio Collections
+// @Produce json
+// @Security BearerAuth
+// @Param invitationId path string true "Invitation UUID" Format(uuid)
+// @Success 200 {object} dto.CollectionMemberResponseDTO "Membership"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Invitation ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Invitation Not Found"
+// @Failure 409 {object} httputil.ErrorResponseDTO "Invitation is no longer pending"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /users/me/invitations/{invitationId}/accept [post]
+func (h *CollectionSharingHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	invitationID, err := parseInvitationID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	member, err := h.sharingUseCase.AcceptInvitation(r.Context(), userID, invitationID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, dto.MapCollectionMemberToResponseDTO(member))
+}
+
+// DeclineInvitation handles POST /api/v1/users/me/invitations/{invitationId}/decline
+// @Summary Decline an invitation
+// @ID decline-collection-invitation
+// @Tags Audio Collections
+// @Security BearerAuth
+// @Param invitationId path string true "Invitation UUID" Format(uuid)
+// @Success 204 "Invitation declined"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Invitation ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Invitation Not Found"
+// @Failure 409 {object} httputil.ErrorResponseDTO "Invitation is no longer pending"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /users/me/invitations/{invitationId}/decline [post]
+func (h *CollectionSharingHandler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	invitationID, err := parseInvitationID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	if err := h.sharingUseCase.DeclineInvitation(r.Context(), userID, invitationID); err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListCollectionMembers handles GET /api/v1/audio/collections/{collectionId}/members
+// @Summary List collection members
+// @Description Lists the owner (first) and the editors and viewers of a collection. Available to the owner and members.
+// @ID list-collection-members
+// @Tags Audio Collections
+// @Produce json
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Success 200 {array} dto.CollectionMemberResponseDTO "Owner and members"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Collection ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not a Member)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/members [get]
+func (h *CollectionSharingHandler) ListCollectionMembers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	members, err := h.sharingUseCase.ListCollectionMembers(r.Context(), userID, collectionID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	resp := make([]dto.CollectionMemberResponseDTO, len(members))
+	for i, m := range members {
+		resp[i] = dto.MapCollectionMemberToResponseDTO(m)
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
+
+// RemoveCollectionMember handles DELETE /api/v1/audio/collections/{collectionId}/members/{userId}
+// @Summary Remove a collection member
+// @Description Removes an editor or viewer from a collection. The owner may remove anyone; members may remove themselves to leave.
+// @ID remove-collection-member
+// @Tags Audio Collections
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param userId path string true "Member's user UUID" Format(uuid)
+// @Success 204 "Member removed"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid ID Format / Cannot remove the owner"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection or Member Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/members/{userId} [delete]
+func (h *CollectionSharingHandler) RemoveCollectionMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	memberID, err := domain.UserIDFromString(chi.URLParam(r, "userId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid user ID format", domain.ErrInvalidArgument))
+		return
+	}
+	if err := h.sharingUseCase.RemoveCollectionMember(r.Context(), userID, collectionID, memberID); err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TransferOwnership handles POST /api/v1/audio/collections/{collectionId}/transfer
+// @Summary Transfer collection ownership
+// @Description Hands a collection owned by the authenticated user over to one of its members. The previous owner stays on as an editor.
+// @Description Requires the collection's current ETag in If-Match (or "*" to transfer unconditionally).
+// @ID transfer-collection-ownership
+// @Tags Audio Collections
+// @Accept json
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param If-Match header string true "Current collection ETag"
+// @Param transfer body dto.TransferOwnershipRequestDTO true "New owner"
+// @Success 204 "Ownership transferred"
+// @Header 204 {string} ETag "New collection version"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / New owner is not a member"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 412 {object} httputil.ErrorResponseDTO "Collection was modified (ETag mismatch)"
+// @Failure 428 {object} httputil.ErrorResponseDTO "If-Match header missing"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/transfer [post]
+func (h *CollectionSharingHandler) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	expectedVersion, err := httputil.IfMatchVersion(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	var req dto.TransferOwnershipRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+	newOwnerID, err := domain.UserIDFromString(req.NewOwnerID)
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid newOwnerId format", domain.ErrInvalidArgument))
+		return
+	}
+	newVersion, err := h.sharingUseCase.TransferOwnership(r.Context(), userID, collectionID, newOwnerID, expectedVersion)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", httputil.VersionETag(newVersion))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func mapInvitations(invitations []*domain.CollectionInvitation) []dto.InvitationResponseDTO {
+	resp := make([]dto.InvitationResponseDTO, len(invitations))
+	for i, inv := range invitations {
+		resp[i] = dto.MapInvitationToResponseDTO(inv)
+	}
+	return resp
+}
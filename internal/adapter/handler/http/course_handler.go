@@ -0,0 +1,457 @@
+// internal/adapter/handler/http/course_handler.go
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/dto"
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/middleware"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+	"github.com/yvanyang/language-learning-player-api/pkg/validation"
+)
+
+// CourseHandler handles HTTP requests for course outlines, publication and enrollments.
+type CourseHandler struct {
+	courseUseCase port.CourseUseCase
+	validator     *validation.Validator
+}
+
+// NewCourseHandler creates a new CourseHandler.
+func NewCourseHandler(uc port.CourseUseCase, v *validation.Validator) *CourseHandler {
+	return &CourseHandler{
+		courseUseCase: uc,
+		validator:     v,
+	}
+}
+
+func parseCourseID(r *http.Request) (domain.CollectionID, error) {
+	id, err := domain.CollectionIDFromString(chi.URLParam(r, "collectionId"))
+	if err != nil {
+		return domain.CollectionID{}, fmt.Errorf("%w: invalid course ID format", domain.ErrInvalidArgument)
+	}
+	return id, nil
+}
+
+// ListCourses handles GET /api/v1/courses
+// @Summary Browse published courses
+// @Description Lists courses that are open for enrollment, most recently published first.
+// @ID list-courses
+// @Tags Courses
+// @Produce json
+// @Param q query string false "Search in title and description"
+// @Param ownerId query string false "Only courses of this author" Format(uuid)
+// @Param limit query int false "Pagination limit" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Pagination offset" default(0) minimum(0)
+// @Success 200 {object} dto.PaginatedResponseDTO{data=[]dto.CourseSummaryResponseDTO} "Paginated list of published courses"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Query Parameter Format"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /courses [get]
+func (h *CourseHandler) ListCourses(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePage(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	input := port.ListCoursesInput{Page: page}
+	if q := strings.TrimSpace(r.URL.Query().Get("q")); q != "" {
+		input.Filter.Query = &q
+	}
+	if ownerStr := r.URL.Query().Get("ownerId"); ownerStr != "" {
+		ownerID, err := domain.UserIDFromString(ownerStr)
+		if err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: invalid ownerId format", domain.ErrInvalidArgument))
+			return
+		}
+		input.Filter.OwnerID = &ownerID
+	}
+
+	courses, total, actualPageInfo, err := h.courseUseCase.ListPublishedCourses(r.Context(), input)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	respData := make([]dto.CourseSummaryResponseDTO, len(courses))
+	for i, c := range courses {
+		respData[i] = dto.MapCourseSummaryToResponseDTO(c)
+	}
+	paginatedResult := pagination.NewPaginatedResponse(respData, total, actualPageInfo)
+	resp := dto.PaginatedResponseDTO{
+		Data:       paginatedResult.Data,
+		Total:      paginatedResult.Total,
+		Limit:      paginatedResult.Limit,
+		Offset:     paginatedResult.Offset,
+		Page:       paginatedResult.Page,
+		TotalPages: paginatedResult.TotalPages,
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
+
+// GetCourse handles GET /api/v1/courses/{collectionId}
+// @Summary Get a course
+// @Description Retrieves a course with its sections and lessons. Published courses are visible to everyone;
+// @Description unpublished courses only to their owner, its members and to learners who are already enrolled.
+// @ID get-course
+// @Tags Courses
+// @Produce json
+// @Security BearerAuth // Optional: signed-in users also see their enrollment
+// @Param collectionId path string true "Course (collection) UUID" Format(uuid)
+// @Success 200 {object} dto.CourseResponseDTO "Course with outline"
+// @Header 200 {string} ETag "Course version; send it as If-Match when updating"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Course ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Invalid token"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Course Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /courses/{collectionId} [get]
+func (h *CourseHandler) GetCourse(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := parseCourseID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	var viewerID *domain.UserID
+	if userID, ok := middleware.GetUserIDFromContext(r.Context()); ok {
+		viewerID = &userID
+	}
+
+	details, err := h.courseUseCase.GetCourse(r.Context(), viewerID, collectionID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Add("Vary", "Authorization")
+	w.Header().Set("ETag", httputil.VersionETag(details.Course.Version))
+	httputil.RespondJSON(w, r, http.StatusOK, dto.MapCourseDetailsToResponseDTO(details))
+}
+
+// UpdateCourseOutline handles PUT /api/v1/courses/{collectionId}/outline
+// @Summary Replace a course outline
+// @Description Replaces the sections and lessons of a course owned or edited by the authenticated user. Sections and lessons
+// @Description without an ID are created; existing ones keep their ID when sent back. The collection's track list is
+// @Description set to the lesson tracks in course order. Lesson tracks must be public or uploaded by the owner or the editing user, and
+// @Description must be public while the course is published.
+// @ID update-course-outline
+// @Tags Courses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param collectionId path string true "Course (collection) UUID" Format(uuid)
+// @Param If-Match header string true "Current course ETag"
+// @Param outline body dto.UpdateCourseOutlineRequestDTO true "Ordered sections with their ordered lessons"
+// @Success 200 {object} dto.CourseResponseDTO "Updated course"
+// @Header 200 {string} ETag "New course version"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner or Editor)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Course Not Found"
+// @Failure 409 {object} httputil.ErrorResponseDTO "A section or lesson ID belongs to another course"
+// @Failure 412 {object} httputil.ErrorResponseDTO "Course was modified (ETag mismatch)"
+// @Failure 428 {object} httputil.ErrorResponseDTO "If-Match header missing"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /courses/{collectionId}/outline [put]
+func (h *CourseHandler) UpdateCourseOutline(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCourseID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	expectedVersion, err := httputil.IfMatchVersion(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	var req dto.UpdateCourseOutlineRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+	sections, err := mapCourseOutlineRequest(req)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	details, err := h.courseUseCase.UpdateCourseOutline(r.Context(), port.UpdateCourseOutlineInput{
+		UserID:          userID,
+		CollectionID:    collectionID,
+		Sections:        sections,
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", httputil.VersionETag(details.Course.Version))
+	httputil.RespondJSON(w, r, http.StatusOK, dto.MapCourseDetailsToResponseDTO(details))
+}
+
+// mapCourseOutlineRequest converts a validated outline request to use case input.
+func mapCourseOutlineRequest(req dto.UpdateCourseOutlineRequestDTO) ([]port.CourseSectionInput, error) {
+	sections := make([]port.CourseSectionInput, len(req.Sections))
+	for i, s := range req.Sections {
+		section := port.CourseSectionInput{Title: s.Title, Description: s.Description}
+		if s.ID != nil {
+			id, err := domain.CourseSectionIDFromString(*s.ID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid section ID format '%s'", domain.ErrInvalidArgument, *s.ID)
+			}
+			section.ID = &id
+		}
+		for _, l := range s.Lessons {
+			trackID, err := domain.TrackIDFromString(l.TrackID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid track ID format '%s'", domain.ErrInvalidArgument, l.TrackID)
+			}
+			lesson := port.CourseLessonInput{TrackID: trackID, Title: l.Title, Notes: l.Notes}
+			if l.ID != nil {
+				id, err := domain.LessonIDFromString(*l.ID)
+				if err != nil {
+					return nil, fmt.Errorf("%w: invalid lesson ID format '%s'", domain.ErrInvalidArgument, *l.ID)
+				}
+				lesson.ID = &id
+			}
+			for _, a := range l.Attachments {
+				lesson.Attachments = append(lesson.Attachments, domain.LessonAttachment{Title: a.Title, URL: a.URL})
+			}
+			section.Lessons = append(section.Lessons, lesson)
+		}
+		sections[i] = section
+	}
+	return sections, nil
+}
+
+// PublishCourse handles POST /api/v1/courses/{collectionId}/publish
+// @Summary Publish a course
+// @Description Opens a course owned by the authenticated user for enrollment. The course needs at least one lesson,
+// @Description and every lesson track must be public. Publishing an already published course has no effect.
+// @ID publish-course
+// @Tags Courses
+// @Produce json
+// @Security BearerAuth
+// @Param collectionId path string true "Course (collection) UUID" Format(uuid)
+// @Param If-Match header string true "Current course ETag"
+// @Success 200 {object} dto.AudioCollectionResponseDTO "Published course"
+// @Header 200 {string} ETag "New course version"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Course has no lessons or uses private tracks"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Course Not Found"
+// @Failure 412 {object} httputil.ErrorResponseDTO "Course was modified (ETag mismatch)"
+// @Failure 428 {object} httputil.ErrorResponseDTO "If-Match header missing"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /courses/{collectionId}/publish [post]
+func (h *CourseHandler) PublishCourse(w http.ResponseWriter, r *http.Request) {
+	h.setPublished(w, r, h.courseUseCase.PublishCourse)
+}
+
+// UnpublishCourse handles DELETE /api/v1/courses/{collectionId}/publish
+// @Summary Unpublish a course
+// @Description Closes a course for new enrollments. Learners who are already enrolled keep access.
+// @ID unpublish-course
+// @Tags Courses
+// @Produce json
+// @Security BearerAuth
+// @Param collectionId path string true "Course (collection) UUID" Format(uuid)
+// @Param If-Match header string true "Current course ETag"
+// @Success 200 {object} dto.AudioCollectionResponseDTO "Unpublished course"
+// @Header 200 {string} ETag "New course version"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Course Not Found"
+// @Failure 412 {object} httputil.ErrorResponseDTO "Course was modified (ETag mismatch)"
+// @Failure 428 {object} httputil.ErrorResponseDTO "If-Match header missing"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /courses/{collectionId}/publish [delete]
+func (h *CourseHandler) UnpublishCourse(w http.ResponseWriter, r *http.Request) {
+	h.setPublished(w, r, h.courseUseCase.UnpublishCourse)
+}
+
+func (h *CourseHandler) setPublished(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID, expectedVersion int) (*domain.AudioCollection, error)) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCourseID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	expectedVersion, err := httputil.IfMatchVersion(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	course, err := apply(r.Context(), userID, collectionID, expectedVersion)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	resp := dto.MapDomainCollectionToResponseDTO(course, nil)
+	resp.Tracks = nil
+	w.Header().Set("ETag", httputil.VersionETag(course.Version))
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
+
+// Enroll handles POST /api/v1/courses/{collectionId}/enrollment
+// @Summary Enroll in a course
+// @Description Enrolls the authenticated user in a published course of another user. Enrolling again returns the existing enrollment.
+// @ID enroll-course
+// @Tags Courses
+// @Produce json
+// @Security BearerAuth
+// @Param collectionId path string true "Course (collection) UUID" Format(uuid)
+// @Success 200 {object} dto.EnrollmentResponseDTO "Enrollment"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Cannot enroll in your own course"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Course Not Found or not published"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /courses/{collectionId}/enrollment [post]
+func (h *CourseHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCourseID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	enrollment, err := h.courseUseCase.Enroll(r.Context(), userID, collectionID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, dto.EnrollmentResponseDTO{
+		CollectionID: enrollment.CollectionID.String(),
+		EnrolledAt:   enrollment.EnrolledAt,
+	})
+}
+
+// Unenroll handles DELETE /api/v1/courses/{collectionId}/enrollment
+// @Summary Leave a course
+// @Description Removes the authenticated user's enrollment. Playback progress on the lesson tracks is kept.
+// @ID unenroll-course
+// @Tags Courses
+// @Security BearerAuth
+// @Param collectionId path string true "Course (collection) UUID" Format(uuid)
+// @Success 204 "Enrollment removed"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Not enrolled"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /courses/{collectionId}/enrollment [delete]
+func (h *CourseHandler) Unenroll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCourseID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	if err := h.courseUseCase.Unenroll(r.Context(), userID, collectionID); err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCourseProgress handles GET /api/v1/courses/{collectionId}/progress
+// @Summary Get my progress through a course
+// @Description Computes the authenticated user's progress from their playback progress on the lesson tracks.
+// @Description A lesson counts as completed once 95% of its track has been played.
+// @ID get-course-progress
+// @Tags Courses
+// @Produce json
+// @Security BearerAuth
+// @Param collectionId path string true "Course (collection) UUID" Format(uuid)
+// @Success 200 {object} dto.CourseProgressResponseDTO "Course progress"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Course Not Found or not enrolled"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /courses/{collectionId}/progress [get]
+func (h *CourseHandler) GetCourseProgress(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCourseID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	progress, err := h.courseUseCase.GetCourseProgress(r.Context(), userID, collectionID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, dto.MapCourseProgressToResponseDTO(progress))
+}
+
+// ListMyEnrollments handles GET /api/v1/users/me/enrollments
+// @Summary List my enrolled courses
+// @Description Lists the courses the authenticated user is enrolled in, most recent enrollment first, with progress.
+// @ID list-my-enrollments
+// @Tags Courses
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Pagination limit" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Pagination offset" default(0) minimum(0)
+// @Success 200 {object} dto.PaginatedResponseDTO{data=[]dto.EnrolledCourseResponseDTO} "Paginated list of enrolled courses"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Query Parameter Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /users/me/enrollments [get]
+func (h *CourseHandler) ListMyEnrollments(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	page, err := parsePage(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	courses, total, actualPageInfo, err := h.courseUseCase.ListEnrollments(r.Context(), userID, page)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	respData := make([]dto.EnrolledCourseResponseDTO, len(courses))
+	for i, c := range courses {
+		respData[i] = dto.MapEnrolledCourseToResponseDTO(c)
+	}
+	paginatedResult := pagination.NewPaginatedResponse(respData, total, actualPageInfo)
+	resp := dto.PaginatedResponseDTO{
+		Data:       paginatedResult.Data,
+		Total:      paginatedResult.Total,
+		Limit:      paginatedResult.Limit,
+		Offset:     paginatedResult.Offset,
+		Page:       paginatedResult.Page,
+		TotalPages: paginatedResult.TotalPages,
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
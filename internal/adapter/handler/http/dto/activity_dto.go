@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
 )
 
 // --- Request DTOs ---
@@ -22,14 +23,41 @@ type CreateBookmarkRequestDTO struct {
 	Note        string `json:"note"`
 }
 
+// LoopRequestDTO defines the JSON body for updating a loop; durations are in milliseconds.
+type LoopRequestDTO struct {
+	StartMs       int64   `json:"startMs" validate:"gte=0" example:"12000"`
+	EndMs         int64   `json:"endMs" validate:"required,gt=0" example:"15500"`
+	Label         string  `json:"label" validate:"max=255"`
+	RepeatCount   int     `json:"repeatCount" validate:"min=0,max=1000" example:"5"`                // 0 repeats until stopped
+	PlaybackSpeed float64 `json:"playbackSpeed" validate:"omitempty,min=0.25,max=4" example:"0.75"` // Omit for normal speed
+}
+
+// ToInput converts the request to the use case input.
+func (req LoopRequestDTO) ToInput() port.LoopInput {
+	return port.LoopInput{
+		Start:         time.Duration(req.StartMs) * time.Millisecond,
+		End:           time.Duration(req.EndMs) * time.Millisecond,
+		Label:         req.Label,
+		RepeatCount:   req.RepeatCount,
+		PlaybackSpeed: req.PlaybackSpeed,
+	}
+}
+
+// CreateLoopRequestDTO defines the JSON body for creating a loop.
+type CreateLoopRequestDTO struct {
+	TrackID string `json:"trackId" validate:"required,uuid"`
+	LoopRequestDTO
+}
+
 // --- Response DTOs ---
 
 // PlaybackProgressResponseDTO defines the JSON representation of playback progress.
 type PlaybackProgressResponseDTO struct {
-	UserID         string    `json:"userId"`
-	TrackID        string    `json:"trackId"`
-	ProgressMs     int64     `json:"progressMs"` // Progress in milliseconds
-	LastListenedAt time.Time `json:"lastListenedAt"`
+	UserID         string                   `json:"userId"`
+	TrackID        string                   `json:"trackId"`
+	ProgressMs     int64                    `json:"progressMs"` // Progress in milliseconds
+	LastListenedAt time.Time                `json:"lastListenedAt"`
+	Chapter        *TrackChapterResponseDTO `json:"chapter,omitempty"` // Chapter containing the position, if the track has chapters
 }
 
 // Point 1: MapDomainProgressToResponseDTO converts domain progress (with time.Duration) to DTO (with int64 ms).
@@ -42,18 +70,20 @@ func MapDomainProgressToResponseDTO(p *domain.PlaybackProgress) PlaybackProgress
 		TrackID:        p.TrackID.String(),
 		ProgressMs:     p.Progress.Milliseconds(), // Convert duration to ms
 		LastListenedAt: p.LastListenedAt,
+		Chapter:        mapOptionalChapter(p.Chapter),
 	}
 }
 
 // BookmarkResponseDTO defines the JSON representation of a bookmark.
 // THIS IS NOW THE SINGLE SOURCE OF TRUTH FOR BOOKMARK RESPONSE DTO.
 type BookmarkResponseDTO struct {
-	ID          string    `json:"id"`
-	UserID      string    `json:"userId"`
-	TrackID     string    `json:"trackId"`
-	TimestampMs int64     `json:"timestampMs"` // Timestamp in milliseconds
-	Note        string    `json:"note,omitempty"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID          string                   `json:"id"`
+	UserID      string                   `json:"userId"`
+	TrackID     string                   `json:"trackId"`
+	TimestampMs int64                    `json:"timestampMs"` // Timestamp in milliseconds
+	Note        string                   `json:"note,omitempty"`
+	CreatedAt   time.Time                `json:"createdAt"`
+	Chapter     *TrackChapterResponseDTO `json:"chapter,omitempty"` // Chapter containing the timestamp, if the track has chapters
 }
 
 // MapDomainBookmarkToResponseDTO converts domain bookmark (with time.Duration) to DTO (with int64 ms).
@@ -68,5 +98,44 @@ func MapDomainBookmarkToResponseDTO(b *domain.Bookmark) BookmarkResponseDTO {
 		TimestampMs: b.Timestamp.Milliseconds(), // Convert duration to ms
 		Note:        b.Note,
 		CreatedAt:   b.CreatedAt,
+		Chapter:     mapOptionalChapter(b.Chapter),
 	}
 }
+
+// LoopResponseDTO defines the JSON representation of a loop.
+type LoopResponseDTO struct {
+	ID            string    `json:"id"`
+	UserID        string    `json:"userId"`
+	TrackID       string    `json:"trackId"`
+	StartMs       int64     `json:"startMs"`
+	EndMs         int64     `json:"endMs"`
+	Label         string    `json:"label,omitempty"`
+	RepeatCount   int       `json:"repeatCount"`
+	PlaybackSpeed float64   `json:"playbackSpeed"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// MapDomainLoopToResponseDTO converts a domain loop to its response DTO.
+func MapDomainLoopToResponseDTO(l *domain.Loop) LoopResponseDTO {
+	if l == nil {
+		return LoopResponseDTO{}
+	}
+	return LoopResponseDTO{
+		ID:            l.ID.String(),
+		UserID:        l.UserID.String(),
+		TrackID:       l.TrackID.String(),
+		StartMs:       l.Start.Milliseconds(),
+		EndMs:         l.End.Milliseconds(),
+		Label:         l.Label,
+		RepeatCount:   l.RepeatCount,
+		PlaybackSpeed: l.PlaybackSpeed,
+		CreatedAt:     l.CreatedAt,
+		UpdatedAt:     l.UpdatedAt,
+	}
+}
+
+// LoopLinkResponseDTO holds the shareable deep link of a loop.
+type LoopLinkResponseDTO struct {
+	URL string `json:"url" example:"https://app.example.com/tracks/0f8fad5b-d9cb-469f-a165-70867728950e?loopEndMs=15500&loopStartMs=12000&repeat=5&speed=0.75"`
+}
@@ -2,6 +2,7 @@
 package dto
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
@@ -25,16 +26,55 @@ type ListTracksRequestDTO struct {
 
 // CreateCollectionRequestDTO defines the JSON body for creating a collection.
 type CreateCollectionRequestDTO struct {
-	Title           string   `json:"title" validate:"required,max=255"`
-	Description     string   `json:"description"`
-	Type            string   `json:"type" validate:"required,oneof=COURSE PLAYLIST"`
-	InitialTrackIDs []string `json:"initialTrackIds" validate:"omitempty,dive,uuid"`
+	Title           string        `json:"title" validate:"required,max=255"`
+	Description     string        `json:"description"`
+	Type            string        `json:"type" validate:"required,oneof=COURSE PLAYLIST SMART"`
+	Visibility      string        `json:"visibility" validate:"omitempty,oneof=PRIVATE UNLISTED PUBLIC"` // Defaults to PRIVATE
+	InitialTrackIDs []string      `json:"initialTrackIds" validate:"omitempty,dive,uuid"`
+	SmartRule       *SmartRuleDTO `json:"smartRule,omitempty"` // Required for SMART collections, rejected otherwise
+}
+
+// SmartRuleDTO is the track query of a SMART collection, used in requests and responses. Omitted fields match anything.
+type SmartRuleDTO struct {
+	LanguageCode  string   `json:"languageCode,omitempty" example:"es-ES"`
+	Level         string   `json:"level,omitempty" validate:"omitempty,oneof=A1 A2 B1 B2 C1 C2 NATIVE" example:"B1"`
+	Tags          []string `json:"tags,omitempty" validate:"omitempty,dive,required" example:"news"` // Tracks must carry all tags
+	MinDurationMs int64    `json:"minDurationMs,omitempty" validate:"min=0"`
+	MaxDurationMs int64    `json:"maxDurationMs,omitempty" validate:"min=0" example:"300000"`
+	UploaderID    *string  `json:"uploaderId,omitempty" validate:"omitempty,uuid"`
+	ListenState   string   `json:"listenState,omitempty" validate:"omitempty,oneof=LISTENED UNLISTENED" example:"UNLISTENED"` // Evaluated for the viewing user
+	SortBy        string   `json:"sortBy,omitempty" validate:"omitempty,oneof=createdAt title durationMs level"`              // Defaults to createdAt
+	SortDirection string   `json:"sortDirection,omitempty" validate:"omitempty,oneof=asc desc"`                               // Defaults to desc
+}
+
+// FreezeSmartCollectionRequestDTO defines the JSON body for freezing a smart collection into a playlist.
+type FreezeSmartCollectionRequestDTO struct {
+	Title string `json:"title" validate:"omitempty,max=255"` // Defaults to the smart collection's title
+}
+
+// ForkCollectionRequestDTO defines the JSON body for forking a collection.
+type ForkCollectionRequestDTO struct {
+	Title string `json:"title" validate:"omitempty,max=255"` // Defaults to the original collection's title
 }
 
 // UpdateCollectionRequestDTO defines the JSON body for updating collection metadata.
 type UpdateCollectionRequestDTO struct {
-	Title       string `json:"title" validate:"required,max=255"`
-	Description string `json:"description"`
+	Title       string  `json:"title" validate:"required,max=255"`
+	Description string  `json:"description"`
+	Visibility  *string `json:"visibility,omitempty" validate:"omitempty,oneof=PRIVATE UNLISTED PUBLIC"` // Omit to keep the current visibility
+}
+
+// ListCollectionsRequestDTO (Documents query parameters for browsing public collections, not used for binding)
+type ListCollectionsRequestDTO struct {
+	Query         *string  `query:"q"`
+	LanguageCode  *string  `query:"lang"`
+	Level         *string  `query:"level"`
+	OwnerID       *string  `query:"ownerId"`
+	Tags          []string `query:"tags"`
+	SortBy        string   `query:"sortBy"`
+	SortDirection string   `query:"sortDir"`
+	Limit         int      `query:"limit"`
+	Offset        int      `query:"offset"`
 }
 
 // UpdateCollectionTracksRequestDTO defines the JSON body for updating tracks in a collection.
@@ -42,8 +82,59 @@ type UpdateCollectionTracksRequestDTO struct {
 	OrderedTrackIDs []string `json:"orderedTrackIds" validate:"omitempty,dive,uuid"`
 }
 
+// AddCollectionTrackRequestDTO defines the JSON body for adding a single track to a collection.
+type AddCollectionTrackRequestDTO struct {
+	TrackID  string `json:"trackId" validate:"required,uuid"`
+	Position *int   `json:"position,omitempty" validate:"omitempty,min=0"` // 0-based; omitted or out of bounds = append
+}
+
+// MoveCollectionTrackRequestDTO defines the JSON body for moving a track within a collection.
+type MoveCollectionTrackRequestDTO struct {
+	Position *int `json:"position" validate:"required,min=0"` // 0-based; out of bounds = move to end
+}
+
+// TrackChapterRequestDTO is one chapter of UpdateTrackChaptersRequestDTO.
+type TrackChapterRequestDTO struct {
+	Title       string `json:"title" validate:"required,max=255"`
+	Description string `json:"description,omitempty" validate:"max=2000"`
+	StartMs     int64  `json:"startMs" validate:"min=0" example:"0"`
+	EndMs       int64  `json:"endMs,omitempty" validate:"min=0" example:"65000"` // Omit to end at the next chapter or the end of the track
+}
+
+// UpdateTrackChaptersRequestDTO defines the JSON body for replacing the chapters of a track.
+type UpdateTrackChaptersRequestDTO struct {
+	Chapters []TrackChapterRequestDTO `json:"chapters" validate:"max=500,dive"` // Empty removes all chapters
+}
+
+// ToDomain converts the request to domain chapters, which are validated by the use case.
+func (req UpdateTrackChaptersRequestDTO) ToDomain() []domain.TrackChapter {
+	chapters := make([]domain.TrackChapter, len(req.Chapters))
+	for i, c := range req.Chapters {
+		chapters[i] = domain.TrackChapter{
+			Title:       c.Title,
+			Description: c.Description,
+			Start:       time.Duration(c.StartMs) * time.Millisecond,
+			End:         time.Duration(c.EndMs) * time.Millisecond,
+		}
+	}
+	return chapters
+}
+
 // --- Response DTOs ---
 
+// TrackChapterResponseDTO defines the JSON representation of a track chapter.
+type TrackChapterResponseDTO struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	StartMs     int64  `json:"startMs" example:"0"`
+	EndMs       int64  `json:"endMs" example:"65000"`
+}
+
+// TrackChaptersResponseDTO lists the chapters of a track, ordered by start time.
+type TrackChaptersResponseDTO struct {
+	Chapters []TrackChapterResponseDTO `json:"chapters"`
+}
+
 // AudioTrackResponseDTO defines the JSON representation of a single audio track's basic info.
 type AudioTrackResponseDTO struct {
 	ID            string    `json:"id"`
@@ -56,16 +147,22 @@ type AudioTrackResponseDTO struct {
 	UploaderID    *string   `json:"uploaderId,omitempty"`
 	IsPublic      bool      `json:"isPublic"`
 	Tags          []string  `json:"tags,omitempty"`
+	ContentSHA256 string    `json:"contentSha256,omitempty"` // SHA-256 of the audio file, hex-encoded; computed shortly after upload
+	SizeBytes     int64     `json:"sizeBytes,omitempty"`
+	Version       int       `json:"version" example:"1"` // Also returned as the ETag header
 	CreatedAt     time.Time `json:"createdAt"`
 	UpdatedAt     time.Time `json:"updatedAt"`
 }
 
 // AudioTrackDetailsResponseDTO includes the track metadata, playback URL, and user-specific info.
 type AudioTrackDetailsResponseDTO struct {
-	AudioTrackResponseDTO                       // Embed basic track info
-	PlayURL               string                `json:"playUrl"`                                  // Presigned URL
-	UserProgressMs        *int64                `json:"userProgressMs,omitempty" example:"45000"` // User progress in ms
-	UserBookmarks         []BookmarkResponseDTO `json:"userBookmarks,omitempty"`                  // Array of user bookmarks for this track
+	AudioTrackResponseDTO                           // Embed basic track info
+	PlayURL               string                    `json:"playUrl"`                                  // Presigned URL
+	UserProgressMs        *int64                    `json:"userProgressMs,omitempty" example:"45000"` // User progress in ms
+	UserBookmarks         []BookmarkResponseDTO     `json:"userBookmarks,omitempty"`                  // Array of user bookmarks for this track
+	UserLoops             []LoopResponseDTO         `json:"userLoops,omitempty"`                      // Array of user loops for this track
+	UserProgressChapter   *TrackChapterResponseDTO  `json:"userProgressChapter,omitempty"`            // Chapter containing the user's progress
+	Chapters              []TrackChapterResponseDTO `json:"chapters"`
 }
 
 // UploaderInfoDTO - embedded within AudioTrackDetailsResponseDTO if needed
@@ -96,6 +193,9 @@ func MapDomainTrackToResponseDTO(track *domain.AudioTrack) AudioTrackResponseDTO
 		UploaderID:    uploaderIDStr,
 		IsPublic:      track.IsPublic,
 		Tags:          track.Tags,
+		ContentSHA256: track.ContentSHA256,
+		SizeBytes:     track.SizeBytes,
+		Version:       track.Version,
 		CreatedAt:     track.CreatedAt,
 		UpdatedAt:     track.UpdatedAt,
 	}
@@ -113,11 +213,14 @@ func MapDomainTrackToDetailsResponseDTO(result *port.GetAudioTrackDetailsResult)
 		PlayURL:               result.PlayURL,
 		UserProgressMs:        nil,
 		UserBookmarks:         make([]BookmarkResponseDTO, 0), // Initialize with correct type
+		UserLoops:             make([]LoopResponseDTO, 0),
+		Chapters:              MapDomainChaptersToResponseDTO(result.Chapters),
 	}
 
 	if result.UserProgress != nil {
 		progressMs := result.UserProgress.Progress.Milliseconds()
 		detailsDTO.UserProgressMs = &progressMs
+		detailsDTO.UserProgressChapter = mapOptionalChapter(result.UserProgress.Chapter)
 	}
 
 	if len(result.UserBookmarks) > 0 {
@@ -129,9 +232,38 @@ func MapDomainTrackToDetailsResponseDTO(result *port.GetAudioTrackDetailsResult)
 		}
 	}
 
+	if len(result.UserLoops) > 0 {
+		detailsDTO.UserLoops = make([]LoopResponseDTO, len(result.UserLoops))
+		for i, l := range result.UserLoops {
+			detailsDTO.UserLoops[i] = MapDomainLoopToResponseDTO(l)
+		}
+	}
+
 	return detailsDTO
 }
 
+// MapDomainChaptersToResponseDTO converts track chapters to their response DTOs; nil becomes an empty slice.
+func MapDomainChaptersToResponseDTO(chapters []domain.TrackChapter) []TrackChapterResponseDTO {
+	resp := make([]TrackChapterResponseDTO, len(chapters))
+	for i, c := range chapters {
+		resp[i] = TrackChapterResponseDTO{
+			Title:       c.Title,
+			Description: c.Description,
+			StartMs:     c.Start.Milliseconds(),
+			EndMs:       c.End.Milliseconds(),
+		}
+	}
+	return resp
+}
+
+// mapOptionalChapter converts the chapter a bookmark or progress record falls in, if any.
+func mapOptionalChapter(c *domain.TrackChapter) *TrackChapterResponseDTO {
+	if c == nil {
+		return nil
+	}
+	return &MapDomainChaptersToResponseDTO([]domain.TrackChapter{*c})[0]
+}
+
 // AudioCollectionResponseDTO defines the JSON representation of a collection.
 type AudioCollectionResponseDTO struct {
 	ID          string                  `json:"id"`
@@ -139,9 +271,14 @@ type AudioCollectionResponseDTO struct {
 	Description string                  `json:"description,omitempty"`
 	OwnerID     string                  `json:"ownerId"`
 	Type        string                  `json:"type"`
+	Visibility  string                  `json:"visibility" example:"PRIVATE"`
+	Role        string                  `json:"role,omitempty" example:"EDITOR"` // The caller's role; set when listing the caller's collections
+	Version     int                     `json:"version" example:"1"`             // Send as If-Match (quoted) when updating
+	PublishedAt *time.Time              `json:"publishedAt,omitempty"`           // Set for courses open for enrollment
+	SmartRule   *SmartRuleDTO           `json:"smartRule,omitempty"`             // Set for SMART collections
 	CreatedAt   time.Time               `json:"createdAt"`
 	UpdatedAt   time.Time               `json:"updatedAt"`
-	Tracks      []AudioTrackResponseDTO `json:"tracks,omitempty"`
+	Tracks      []AudioTrackResponseDTO `json:"tracks,omitempty"` // For SMART collections, the first page of matching tracks
 }
 
 // MapDomainCollectionToResponseDTO converts a domain collection to its response DTO.
@@ -156,6 +293,10 @@ func MapDomainCollectionToResponseDTO(collection *domain.AudioCollection, tracks
 		Description: collection.Description,
 		OwnerID:     collection.OwnerID.String(),
 		Type:        string(collection.Type),
+		Visibility:  string(collection.Visibility),
+		Version:     collection.Version,
+		PublishedAt: collection.PublishedAt,
+		SmartRule:   MapSmartRuleToDTO(collection.SmartRule),
 		CreatedAt:   collection.CreatedAt,
 		UpdatedAt:   collection.UpdatedAt,
 		Tracks:      make([]AudioTrackResponseDTO, 0), // Initialize empty
@@ -168,3 +309,73 @@ func MapDomainCollectionToResponseDTO(collection *domain.AudioCollection, tracks
 	}
 	return dto
 }
+
+// MapSmartRuleToDTO converts a smart rule to its DTO; nil stays nil.
+func MapSmartRuleToDTO(rule *domain.SmartRule) *SmartRuleDTO {
+	if rule == nil {
+		return nil
+	}
+	dto := &SmartRuleDTO{
+		LanguageCode:  rule.LanguageCode,
+		Level:         rule.Level.String(),
+		Tags:          rule.Tags,
+		MinDurationMs: rule.MinDuration.Milliseconds(),
+		MaxDurationMs: rule.MaxDuration.Milliseconds(),
+		ListenState:   rule.ListenState.String(),
+		SortBy:        rule.SortBy,
+		SortDirection: rule.SortDirection,
+	}
+	if rule.UploaderID != nil {
+		uploaderID := rule.UploaderID.String()
+		dto.UploaderID = &uploaderID
+	}
+	return dto
+}
+
+// MapSmartRuleDTOToDomain converts a validated smart rule DTO to the domain rule.
+func MapSmartRuleDTOToDomain(d SmartRuleDTO) (domain.SmartRule, error) {
+	rule := domain.SmartRule{
+		LanguageCode:  d.LanguageCode,
+		Level:         domain.AudioLevel(d.Level),
+		Tags:          d.Tags,
+		MinDuration:   time.Duration(d.MinDurationMs) * time.Millisecond,
+		MaxDuration:   time.Duration(d.MaxDurationMs) * time.Millisecond,
+		ListenState:   domain.ListenState(d.ListenState),
+		SortBy:        d.SortBy,
+		SortDirection: d.SortDirection,
+	}
+	if d.UploaderID != nil {
+		uploaderID, err := domain.UserIDFromString(*d.UploaderID)
+		if err != nil {
+			return domain.SmartRule{}, fmt.Errorf("%w: invalid uploaderId format", domain.ErrInvalidArgument)
+		}
+		rule.UploaderID = &uploaderID
+	}
+	return rule, nil
+}
+
+// PlaylistImportResponseDTO is the result of importing a playlist file.
+type PlaylistImportResponseDTO struct {
+	Collection AudioCollectionResponseDTO   `json:"collection"`
+	Unresolved []UnresolvedPlaylistEntryDTO `json:"unresolved"` // Entries that were not added
+}
+
+// UnresolvedPlaylistEntryDTO describes a playlist entry that matched no usable track.
+type UnresolvedPlaylistEntryDTO struct {
+	Index    int    `json:"index" example:"3"` // 0-based position in the imported file
+	Title    string `json:"title,omitempty"`
+	Location string `json:"location,omitempty"`
+	Reason   string `json:"reason" example:"no accessible track matches the entry's ID or checksum"`
+}
+
+// MapPlaylistImportToResponseDTO converts an import result to its response DTO.
+func MapPlaylistImportToResponseDTO(result *port.PlaylistImportResult) PlaylistImportResponseDTO {
+	dto := PlaylistImportResponseDTO{
+		Collection: MapDomainCollectionToResponseDTO(result.Collection, nil),
+		Unresolved: make([]UnresolvedPlaylistEntryDTO, len(result.Unresolved)),
+	}
+	for i, e := range result.Unresolved {
+		dto.Unresolved[i] = UnresolvedPlaylistEntryDTO{Index: e.Index, Title: e.Title, Location: e.Location, Reason: e.Reason}
+	}
+	return dto
+}
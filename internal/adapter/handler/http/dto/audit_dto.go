@@ -0,0 +1,51 @@
+// internal/adapter/handler/http/dto/audit_dto.go
+package dto
+
+import (
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+)
+
+// FieldChangeDTO holds the before/after value of a single audited field.
+type FieldChangeDTO struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// AuditEventResponseDTO defines the JSON representation of an audit log entry.
+type AuditEventResponseDTO struct {
+	ID         string                    `json:"id"`
+	OccurredAt string                    `json:"occurredAt"` // RFC3339
+	ActorID    *string                   `json:"actorId,omitempty"`
+	Action     string                    `json:"action"`
+	TargetType string                    `json:"targetType"`
+	TargetID   string                    `json:"targetId"`
+	Diff       map[string]FieldChangeDTO `json:"diff,omitempty"`
+	RequestID  string                    `json:"requestId,omitempty"`
+	IPAddress  string                    `json:"ipAddress,omitempty"`
+}
+
+// MapDomainAuditEventToResponseDTO converts a domain audit event to its DTO representation.
+func MapDomainAuditEventToResponseDTO(event *domain.AuditEvent) AuditEventResponseDTO {
+	dto := AuditEventResponseDTO{
+		ID:         event.ID.String(),
+		OccurredAt: event.OccurredAt.Format(time.RFC3339),
+		Action:     string(event.Action),
+		TargetType: string(event.TargetType),
+		TargetID:   event.TargetID,
+		RequestID:  event.RequestID,
+		IPAddress:  event.IPAddress,
+	}
+	if event.ActorID != nil {
+		actorID := event.ActorID.String()
+		dto.ActorID = &actorID
+	}
+	if len(event.Diff) > 0 {
+		dto.Diff = make(map[string]FieldChangeDTO, len(event.Diff))
+		for field, change := range event.Diff {
+			dto.Diff[field] = FieldChangeDTO{Old: change.Old, New: change.New}
+		}
+	}
+	return dto
+}
@@ -0,0 +1,73 @@
+// internal/adapter/handler/http/dto/collection_sharing_dto.go
+package dto
+
+import (
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+)
+
+// --- Request DTOs ---
+
+// InviteCollaboratorRequestDTO defines the JSON body for inviting a user to a collection.
+// Exactly one of userId and email is required.
+type InviteCollaboratorRequestDTO struct {
+	UserID *string `json:"userId,omitempty" validate:"omitempty,uuid"`
+	Email  string  `json:"email,omitempty" validate:"omitempty,email" example:"co.teacher@example.com"`
+	Role   string  `json:"role" validate:"required,oneof=EDITOR VIEWER"`
+}
+
+// TransferOwnershipRequestDTO defines the JSON body for handing a collection over to a member.
+type TransferOwnershipRequestDTO struct {
+	NewOwnerID string `json:"newOwnerId" validate:"required,uuid"`
+}
+
+// --- Response DTOs ---
+
+// InvitationResponseDTO defines the JSON representation of a collection invitation.
+type InvitationResponseDTO struct {
+	ID           string     `json:"id"`
+	CollectionID string     `json:"collectionId"`
+	InviterID    string     `json:"inviterId"`
+	InviteeID    *string    `json:"inviteeId,omitempty"`    // Set if the invitee has an account
+	InviteeEmail string     `json:"inviteeEmail,omitempty"` // Set for invitations by email
+	Role         string     `json:"role" example:"EDITOR"`
+	Status       string     `json:"status" example:"PENDING"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	RespondedAt  *time.Time `json:"respondedAt,omitempty"`
+}
+
+// CollectionMemberResponseDTO defines the JSON representation of a collection member.
+type CollectionMemberResponseDTO struct {
+	UserID  string    `json:"userId"`
+	Role    string    `json:"role" example:"VIEWER"`
+	AddedAt time.Time `json:"addedAt"` // For the owner, when the collection was created
+}
+
+// MapInvitationToResponseDTO converts a collection invitation to its response DTO.
+func MapInvitationToResponseDTO(i *domain.CollectionInvitation) InvitationResponseDTO {
+	resp := InvitationResponseDTO{
+		ID:           i.ID.String(),
+		CollectionID: i.CollectionID.String(),
+		InviterID:    i.InviterID.String(),
+		InviteeEmail: i.InviteeEmail,
+		Role:         i.Role.String(),
+		Status:       string(i.Status),
+		CreatedAt:    i.CreatedAt,
+		RespondedAt:  i.RespondedAt,
+	}
+	if i.InviteeID != nil {
+		inviteeID := i.InviteeID.String()
+		resp.InviteeID = &inviteeID
+	}
+	return resp
+}
+
+// MapCollectionMemberToResponseDTO converts a collection member to its response DTO.
+func MapCollectionMemberToResponseDTO(m *domain.CollectionMember) CollectionMemberResponseDTO {
+	return CollectionMemberResponseDTO{
+		UserID:  m.UserID.String(),
+		Role:    m.Role.String(),
+		AddedAt: m.AddedAt,
+	}
+}
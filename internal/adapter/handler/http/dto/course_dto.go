@@ -0,0 +1,182 @@
+// internal/adapter/handler/http/dto/course_dto.go
+package dto
+
+import (
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// --- Request DTOs ---
+
+// LessonAttachmentDTO links supporting material to a lesson.
+type LessonAttachmentDTO struct {
+	Title string `json:"title" validate:"required,max=255"`
+	URL   string `json:"url" validate:"required,url" example:"https://example.com/transcript.pdf"`
+}
+
+// CourseLessonRequestDTO describes one lesson of a course outline.
+type CourseLessonRequestDTO struct {
+	ID          *string               `json:"id,omitempty" validate:"omitempty,uuid"` // Omit to create a new lesson
+	TrackID     string                `json:"trackId" validate:"required,uuid"`
+	Title       string                `json:"title" validate:"max=255"` // Defaults to the track title
+	Notes       string                `json:"notes"`
+	Attachments []LessonAttachmentDTO `json:"attachments" validate:"omitempty,max=20,dive"`
+}
+
+// CourseSectionRequestDTO describes one section of a course outline.
+type CourseSectionRequestDTO struct {
+	ID          *string                  `json:"id,omitempty" validate:"omitempty,uuid"` // Omit to create a new section
+	Title       string                   `json:"title" validate:"required,max=255"`
+	Description string                   `json:"description"`
+	Lessons     []CourseLessonRequestDTO `json:"lessons" validate:"omitempty,dive"`
+}
+
+// UpdateCourseOutlineRequestDTO defines the JSON body for replacing a course outline.
+type UpdateCourseOutlineRequestDTO struct {
+	Sections []CourseSectionRequestDTO `json:"sections" validate:"omitempty,dive"`
+}
+
+// --- Response DTOs ---
+
+// LessonResponseDTO defines the JSON representation of a lesson.
+type LessonResponseDTO struct {
+	ID          string                `json:"id"`
+	TrackID     string                `json:"trackId"`
+	Title       string                `json:"title"` // Lesson title, or the track title if the lesson has none
+	Notes       string                `json:"notes,omitempty"`
+	Attachments []LessonAttachmentDTO `json:"attachments"`
+	DurationMs  int64                 `json:"durationMs"`
+}
+
+// CourseSectionResponseDTO defines the JSON representation of a course section.
+type CourseSectionResponseDTO struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	Lessons     []LessonResponseDTO `json:"lessons"`
+}
+
+// CourseResponseDTO defines the JSON representation of a course with its outline.
+type CourseResponseDTO struct {
+	AudioCollectionResponseDTO
+	Sections   []CourseSectionResponseDTO `json:"sections"`
+	EnrolledAt *time.Time                 `json:"enrolledAt,omitempty"` // Set if the requesting user is enrolled
+}
+
+// CourseSummaryResponseDTO defines the JSON representation of a course when browsing.
+type CourseSummaryResponseDTO struct {
+	AudioCollectionResponseDTO
+	LessonCount     int `json:"lessonCount"`
+	EnrollmentCount int `json:"enrollmentCount"`
+}
+
+// CourseProgressResponseDTO defines the JSON representation of a learner's progress through a course.
+type CourseProgressResponseDTO struct {
+	LessonsTotal     int        `json:"lessonsTotal"`
+	LessonsCompleted int        `json:"lessonsCompleted"`
+	PercentComplete  int        `json:"percentComplete" example:"40"`
+	NextLessonID     *string    `json:"nextLessonId,omitempty"` // Omitted once every lesson is completed
+	NextTrackID      *string    `json:"nextTrackId,omitempty"`
+	NextSectionID    *string    `json:"nextSectionId,omitempty"`
+	LastListenedAt   *time.Time `json:"lastListenedAt,omitempty"`
+}
+
+// EnrolledCourseResponseDTO defines the JSON representation of a course the user is enrolled in.
+type EnrolledCourseResponseDTO struct {
+	Course     AudioCollectionResponseDTO `json:"course"`
+	EnrolledAt time.Time                  `json:"enrolledAt"`
+	Progress   CourseProgressResponseDTO  `json:"progress"`
+}
+
+// EnrollmentResponseDTO defines the JSON representation of an enrollment.
+type EnrollmentResponseDTO struct {
+	CollectionID string    `json:"collectionId"`
+	EnrolledAt   time.Time `json:"enrolledAt"`
+}
+
+// MapCourseDetailsToResponseDTO converts a course with its outline to its response DTO.
+func MapCourseDetailsToResponseDTO(details *port.CourseDetails) CourseResponseDTO {
+	resp := CourseResponseDTO{
+		AudioCollectionResponseDTO: MapDomainCollectionToResponseDTO(details.Course, nil),
+		Sections:                   make([]CourseSectionResponseDTO, len(details.Sections)),
+	}
+	resp.Tracks = nil // Lessons carry the tracks of a course
+	if details.Enrollment != nil {
+		resp.EnrolledAt = &details.Enrollment.EnrolledAt
+	}
+	for i, section := range details.Sections {
+		sectionDTO := CourseSectionResponseDTO{
+			ID:          section.ID.String(),
+			Title:       section.Title,
+			Description: section.Description,
+			Lessons:     make([]LessonResponseDTO, len(section.Lessons)),
+		}
+		for j, lesson := range section.Lessons {
+			lessonDTO := LessonResponseDTO{
+				ID:          lesson.ID.String(),
+				TrackID:     lesson.TrackID.String(),
+				Title:       lesson.Title,
+				Notes:       lesson.Notes,
+				Attachments: make([]LessonAttachmentDTO, len(lesson.Attachments)),
+			}
+			if track := details.Tracks[lesson.TrackID]; track != nil {
+				if lessonDTO.Title == "" {
+					lessonDTO.Title = track.Title
+				}
+				lessonDTO.DurationMs = track.Duration.Milliseconds()
+			}
+			for k, a := range lesson.Attachments {
+				lessonDTO.Attachments[k] = LessonAttachmentDTO{Title: a.Title, URL: a.URL}
+			}
+			sectionDTO.Lessons[j] = lessonDTO
+		}
+		resp.Sections[i] = sectionDTO
+	}
+	return resp
+}
+
+// MapCourseSummaryToResponseDTO converts a published course summary to its response DTO.
+func MapCourseSummaryToResponseDTO(summary port.CourseSummary) CourseSummaryResponseDTO {
+	resp := CourseSummaryResponseDTO{
+		AudioCollectionResponseDTO: MapDomainCollectionToResponseDTO(summary.Course, nil),
+		LessonCount:                summary.LessonCount,
+		EnrollmentCount:            summary.EnrollmentCount,
+	}
+	resp.Tracks = nil
+	return resp
+}
+
+// MapCourseProgressToResponseDTO converts course progress to its response DTO.
+func MapCourseProgressToResponseDTO(p *domain.CourseProgress) CourseProgressResponseDTO {
+	if p == nil {
+		return CourseProgressResponseDTO{}
+	}
+	resp := CourseProgressResponseDTO{
+		LessonsTotal:     p.LessonsTotal,
+		LessonsCompleted: p.LessonsCompleted,
+		PercentComplete:  p.PercentComplete,
+		LastListenedAt:   p.LastListenedAt,
+	}
+	if p.NextLesson != nil {
+		lessonID, trackID := p.NextLesson.ID.String(), p.NextLesson.TrackID.String()
+		resp.NextLessonID, resp.NextTrackID = &lessonID, &trackID
+	}
+	if p.NextSectionID != nil {
+		sectionID := p.NextSectionID.String()
+		resp.NextSectionID = &sectionID
+	}
+	return resp
+}
+
+// MapEnrolledCourseToResponseDTO converts an enrolled course to its response DTO.
+func MapEnrolledCourseToResponseDTO(c port.EnrolledCourse) EnrolledCourseResponseDTO {
+	course := MapDomainCollectionToResponseDTO(c.Course, nil)
+	course.Tracks = nil
+	return EnrolledCourseResponseDTO{
+		Course:     course,
+		EnrolledAt: c.Enrollment.EnrolledAt,
+		Progress:   MapCourseProgressToResponseDTO(c.Progress),
+	}
+}
@@ -0,0 +1,22 @@
+// internal/adapter/handler/http/dto/feed_dto.go
+package dto
+
+import (
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// FeedTokenResponseDTO is a newly issued secret podcast feed URL.
+type FeedTokenResponseDTO struct {
+	FeedURL   string    `json:"feedUrl" example:"https://api.example.com/api/v1/feeds/private/feed_3f9a..."` // Only returned when issued
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// MapFeedTokenToResponseDTO converts an issued feed token to its response DTO.
+func MapFeedTokenToResponseDTO(result *port.FeedTokenResult) FeedTokenResponseDTO {
+	return FeedTokenResponseDTO{
+		FeedURL:   result.FeedURL,
+		CreatedAt: result.Token.CreatedAt,
+	}
+}
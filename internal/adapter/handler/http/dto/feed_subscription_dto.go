@@ -0,0 +1,92 @@
+// internal/adapter/handler/http/dto/feed_subscription_dto.go
+package dto
+
+import (
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// SubscribeFeedRequestDTO defines the JSON body for subscribing to an external podcast feed.
+type SubscribeFeedRequestDTO struct {
+	FeedURL      string   `json:"feedUrl" validate:"required,url,max=2048"`
+	OwnerID      *string  `json:"ownerId,omitempty" validate:"omitempty,uuid"`        // Default: the requesting admin
+	LanguageCode string   `json:"languageCode,omitempty" validate:"omitempty,max=10"` // Used if the feed declares no language
+	Level        string   `json:"level,omitempty" validate:"omitempty,oneof=A1 A2 B1 B2 C1 C2 NATIVE"`
+	IsPublic     bool     `json:"isPublic"`
+	Tags         []string `json:"tags,omitempty" validate:"omitempty,dive,max=50"`
+}
+
+// FeedSubscriptionResponseDTO defines the JSON representation of a feed subscription.
+type FeedSubscriptionResponseDTO struct {
+	ID            string   `json:"id"`
+	FeedURL       string   `json:"feedUrl"`
+	OwnerID       string   `json:"ownerId"`
+	CollectionID  *string  `json:"collectionId,omitempty"` // Set by the first successful refresh
+	Title         string   `json:"title,omitempty"`
+	LanguageCode  string   `json:"languageCode,omitempty"`
+	Level         string   `json:"level,omitempty"`
+	IsPublic      bool     `json:"isPublic"`
+	Tags          []string `json:"tags"`
+	LastFetchedAt *string  `json:"lastFetchedAt,omitempty"` // RFC3339
+	NextFetchAt   string   `json:"nextFetchAt"`             // RFC3339
+	LastError     string   `json:"lastError,omitempty"`
+	CreatedAt     string   `json:"createdAt"` // RFC3339
+	UpdatedAt     string   `json:"updatedAt"` // RFC3339
+}
+
+// SkippedFeedDTO is a feed of an OPML document that was not subscribed to.
+type SkippedFeedDTO struct {
+	FeedURL string `json:"feedUrl"`
+	Reason  string `json:"reason"`
+}
+
+// OPMLImportResponseDTO reports the subscriptions created from an OPML document.
+type OPMLImportResponseDTO struct {
+	Subscribed []FeedSubscriptionResponseDTO `json:"subscribed"`
+	Skipped    []SkippedFeedDTO              `json:"skipped"`
+}
+
+func MapDomainFeedSubscriptionToResponseDTO(sub *domain.FeedSubscription) FeedSubscriptionResponseDTO {
+	dto := FeedSubscriptionResponseDTO{
+		ID:           sub.ID.String(),
+		FeedURL:      sub.FeedURL,
+		OwnerID:      sub.OwnerID.String(),
+		Title:        sub.Title,
+		LanguageCode: sub.LanguageCode,
+		Level:        string(sub.Level),
+		IsPublic:     sub.IsPublic,
+		Tags:         sub.Tags,
+		NextFetchAt:  sub.NextFetchAt.Format(time.RFC3339),
+		LastError:    sub.LastError,
+		CreatedAt:    sub.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    sub.UpdatedAt.Format(time.RFC3339),
+	}
+	if dto.Tags == nil {
+		dto.Tags = []string{}
+	}
+	if sub.CollectionID != nil {
+		collectionID := sub.CollectionID.String()
+		dto.CollectionID = &collectionID
+	}
+	if sub.LastFetchedAt != nil {
+		lastFetchedAt := sub.LastFetchedAt.Format(time.RFC3339)
+		dto.LastFetchedAt = &lastFetchedAt
+	}
+	return dto
+}
+
+func MapOPMLImportResultToResponseDTO(result *port.OPMLImportResult) OPMLImportResponseDTO {
+	resp := OPMLImportResponseDTO{
+		Subscribed: make([]FeedSubscriptionResponseDTO, len(result.Subscribed)),
+		Skipped:    make([]SkippedFeedDTO, len(result.Skipped)),
+	}
+	for i, sub := range result.Subscribed {
+		resp.Subscribed[i] = MapDomainFeedSubscriptionToResponseDTO(sub)
+	}
+	for i, skipped := range result.Skipped {
+		resp.Skipped[i] = SkippedFeedDTO{FeedURL: skipped.FeedURL, Reason: skipped.Reason}
+	}
+	return resp
+}
@@ -0,0 +1,81 @@
+// internal/adapter/handler/http/dto/import_dto.go
+package dto
+
+import (
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+)
+
+// ImportItemResponseDTO reports the outcome for one audio file of an import.
+type ImportItemResponseDTO struct {
+	Path    string `json:"path"`
+	Status  string `json:"status" enums:"created,exists,failed"`
+	TrackID string `json:"trackId,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ImportReportResponseDTO summarises a finished import.
+type ImportReportResponseDTO struct {
+	TracksCreated      int                     `json:"tracksCreated"`
+	TracksExisting     int                     `json:"tracksExisting"` // Files imported before (same content)
+	TracksFailed       int                     `json:"tracksFailed"`
+	CollectionsCreated int                     `json:"collectionsCreated"`
+	CollectionsUpdated int                     `json:"collectionsUpdated"`
+	Items              []ImportItemResponseDTO `json:"items"`
+}
+
+// ImportRunResponseDTO defines the JSON representation of an archive import.
+type ImportRunResponseDTO struct {
+	ID          string                   `json:"id"`
+	Source      string                   `json:"source"` // Archive file name
+	OwnerID     string                   `json:"ownerId"`
+	RequestedBy *string                  `json:"requestedBy,omitempty"`
+	Status      string                   `json:"status" enums:"pending,running,succeeded,failed"`
+	Report      *ImportReportResponseDTO `json:"report,omitempty"` // Set once the import has finished
+	Error       string                   `json:"error,omitempty"`
+	CreatedAt   string                   `json:"createdAt"`             // RFC3339
+	UpdatedAt   string                   `json:"updatedAt"`             // RFC3339
+	CompletedAt *string                  `json:"completedAt,omitempty"` // RFC3339
+}
+
+// MapDomainImportRunToResponseDTO converts an import run to its DTO representation.
+func MapDomainImportRunToResponseDTO(run *domain.ImportRun) ImportRunResponseDTO {
+	dto := ImportRunResponseDTO{
+		ID:        run.ID.String(),
+		Source:    run.Source,
+		OwnerID:   run.OwnerID.String(),
+		Status:    string(run.Status),
+		Error:     run.Error,
+		CreatedAt: run.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: run.UpdatedAt.Format(time.RFC3339),
+	}
+	if run.RequestedBy != nil {
+		requestedBy := run.RequestedBy.String()
+		dto.RequestedBy = &requestedBy
+	}
+	if run.CompletedAt != nil {
+		completedAt := run.CompletedAt.Format(time.RFC3339)
+		dto.CompletedAt = &completedAt
+	}
+	if run.Report != nil {
+		report := &ImportReportResponseDTO{
+			TracksCreated:      run.Report.TracksCreated,
+			TracksExisting:     run.Report.TracksExisting,
+			TracksFailed:       run.Report.TracksFailed,
+			CollectionsCreated: run.Report.CollectionsCreated,
+			CollectionsUpdated: run.Report.CollectionsUpdated,
+			Items:              make([]ImportItemResponseDTO, len(run.Report.Items)),
+		}
+		for i, item := range run.Report.Items {
+			report.Items[i] = ImportItemResponseDTO{
+				Path:    item.Path,
+				Status:  string(item.Status),
+				TrackID: item.TrackID,
+				Error:   item.Error,
+			}
+		}
+		dto.Report = report
+	}
+	return dto
+}
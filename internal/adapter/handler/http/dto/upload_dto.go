@@ -1,18 +1,24 @@
 // internal/adapter/handler/http/dto/upload_dto.go
 package dto
 
+import (
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
 // === Single Upload DTOs ===
 
 // RequestUploadRequestDTO defines the JSON body for requesting an upload URL.
 type RequestUploadRequestDTO struct {
-	Filename    string `json:"filename" validate:"required"`
-	ContentType string `json:"contentType" validate:"required"` // e.g., "audio/mpeg"
+	Filename      string `json:"filename" validate:"required"`
+	ContentType   string `json:"contentType" validate:"required"`                                 // e.g., "audio/mpeg"
+	ContentSHA256 string `json:"contentSha256,omitempty" validate:"omitempty,len=64,hexadecimal"` // Optional SHA-256 of the file, hex-encoded
 }
 
 // RequestUploadResponseDTO defines the JSON response after requesting an upload URL.
 type RequestUploadResponseDTO struct {
-	UploadURL string `json:"uploadUrl"` // The presigned PUT URL
-	ObjectKey string `json:"objectKey"` // The key the client should use/report back
+	UploadURL     string                 `json:"uploadUrl"`               // The presigned PUT URL; empty if existingTrack is set
+	ObjectKey     string                 `json:"objectKey"`               // The key the client should use/report back
+	ExistingTrack *AudioTrackResponseDTO `json:"existingTrack,omitempty"` // A track you can use that already has the declared content
 }
 
 // CompleteUploadInputDTO defines the JSON body for finalizing an upload
@@ -29,12 +35,26 @@ type CompleteUploadInputDTO struct {
 	CoverImageURL *string  `json:"coverImageUrl" validate:"omitempty,url"`
 }
 
+// DirectUploadFormDTO documents the form fields sent with a file to POST /uploads/audio/direct.
+// They must precede the file part, which is streamed to storage as it arrives.
+type DirectUploadFormDTO struct {
+	Title         string   `json:"title" validate:"required,max=255"`
+	Description   string   `json:"description"`
+	LanguageCode  string   `json:"languageCode" validate:"required"`
+	Level         string   `json:"level" validate:"omitempty,oneof=A1 A2 B1 B2 C1 C2 NATIVE"`
+	DurationMs    int64    `json:"durationMs" validate:"required,gt=0"`
+	IsPublic      bool     `json:"isPublic"`
+	Tags          []string `json:"tags"`
+	CoverImageURL *string  `json:"coverImageUrl" validate:"omitempty,url"`
+}
+
 // === Batch Upload DTOs ===
 
 // BatchRequestUploadInputItemDTO represents a single file in the batch request for URLs.
 type BatchRequestUploadInputItemDTO struct {
-	Filename    string `json:"filename" validate:"required"`
-	ContentType string `json:"contentType" validate:"required"` // e.g., "audio/mpeg"
+	Filename      string `json:"filename" validate:"required"`
+	ContentType   string `json:"contentType" validate:"required"`                                 // e.g., "audio/mpeg"
+	ContentSHA256 string `json:"contentSha256,omitempty" validate:"omitempty,len=64,hexadecimal"` // Optional SHA-256 of the file, hex-encoded
 }
 
 // BatchRequestUploadInputRequestDTO is the request body for requesting multiple upload URLs.
@@ -44,10 +64,11 @@ type BatchRequestUploadInputRequestDTO struct {
 
 // BatchRequestUploadInputResponseItemDTO represents the response for a single file URL request.
 type BatchRequestUploadInputResponseItemDTO struct {
-	OriginalFilename string `json:"originalFilename"` // Helps client match response to request
-	ObjectKey        string `json:"objectKey"`        // The generated object key for this file
-	UploadURL        string `json:"uploadUrl"`        // The presigned PUT URL for this file
-	Error            string `json:"error,omitempty"`  // Error message if URL generation failed for this item
+	OriginalFilename string `json:"originalFilename"`          // Helps client match response to request
+	ObjectKey        string `json:"objectKey"`                 // The generated object key for this file
+	UploadURL        string `json:"uploadUrl"`                 // The presigned PUT URL for this file
+	ExistingTrackID  string `json:"existingTrackId,omitempty"` // Set instead of uploadUrl if a track you can use already has this content
+	Error            string `json:"error,omitempty"`           // Error message if URL generation failed for this item
 }
 
 // BatchRequestUploadInputResponseDTO is the response body containing results for multiple URL requests.
@@ -89,3 +110,25 @@ type BatchCompleteUploadResponseItemDTO struct {
 type BatchCompleteUploadResponseDTO struct {
 	Results []BatchCompleteUploadResponseItemDTO `json:"results"`
 }
+
+// === Duplicate DTOs ===
+
+// DuplicateTrackGroupResponseDTO lists tracks whose audio files have the same content.
+type DuplicateTrackGroupResponseDTO struct {
+	ContentSHA256 string                  `json:"contentSha256"`
+	SizeBytes     int64                   `json:"sizeBytes"`
+	Tracks        []AudioTrackResponseDTO `json:"tracks"` // Oldest first
+}
+
+// MapDuplicateTrackGroupToResponseDTO converts a duplicate group to its response DTO.
+func MapDuplicateTrackGroupToResponseDTO(group port.DuplicateTrackGroup) DuplicateTrackGroupResponseDTO {
+	tracks := make([]AudioTrackResponseDTO, len(group.Tracks))
+	for i, t := range group.Tracks {
+		tracks[i] = MapDomainTrackToResponseDTO(t)
+	}
+	return DuplicateTrackGroupResponseDTO{
+		ContentSHA256: group.ContentSHA256,
+		SizeBytes:     group.SizeBytes,
+		Tracks:        tracks,
+	}
+}
@@ -0,0 +1,108 @@
+// internal/adapter/handler/http/dto/webhook_dto.go
+package dto
+
+import (
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+)
+
+// CreateWebhookRequestDTO defines the JSON body for registering a webhook endpoint.
+type CreateWebhookRequestDTO struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	EventTypes []string `json:"eventTypes" validate:"required,min=1,dive,required"`
+}
+
+// UpdateWebhookRequestDTO defines the JSON body for updating a webhook. Omitted fields are unchanged.
+type UpdateWebhookRequestDTO struct {
+	URL        *string  `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+	EventTypes []string `json:"eventTypes,omitempty" validate:"omitempty,min=1,dive,required"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+// WebhookResponseDTO defines the JSON representation of a webhook subscription.
+type WebhookResponseDTO struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Active     bool     `json:"active"`
+	Secret     string   `json:"secret,omitempty"` // Only returned when the webhook is created
+	CreatedBy  *string  `json:"createdBy,omitempty"`
+	CreatedAt  string   `json:"createdAt"` // RFC3339
+	UpdatedAt  string   `json:"updatedAt"` // RFC3339
+}
+
+// WebhookDeliveryResponseDTO defines the JSON representation of a delivery log entry.
+type WebhookDeliveryResponseDTO struct {
+	ID             string  `json:"id"`
+	WebhookID      string  `json:"webhookId"`
+	EventID        string  `json:"eventId"`
+	EventType      string  `json:"eventType"`
+	Status         string  `json:"status" enums:"pending,succeeded,dead"`
+	Attempts       int     `json:"attempts"`
+	NextAttemptAt  *string `json:"nextAttemptAt,omitempty"` // RFC3339, only while pending
+	LastStatusCode *int    `json:"lastStatusCode,omitempty"`
+	LastError      string  `json:"lastError,omitempty"`
+	DeliveredAt    *string `json:"deliveredAt,omitempty"` // RFC3339
+	CreatedAt      string  `json:"createdAt"`             // RFC3339
+	UpdatedAt      string  `json:"updatedAt"`             // RFC3339
+}
+
+// ToEventTypes converts event type strings to domain values; validation happens in the domain.
+func ToEventTypes(types []string) []domain.EventType {
+	if types == nil {
+		return nil
+	}
+	result := make([]domain.EventType, len(types))
+	for i, t := range types {
+		result[i] = domain.EventType(t)
+	}
+	return result
+}
+
+// MapDomainWebhookToResponseDTO converts a subscription to its DTO. The secret is never included;
+// the create handler sets it explicitly.
+func MapDomainWebhookToResponseDTO(sub *domain.WebhookSubscription) WebhookResponseDTO {
+	types := make([]string, len(sub.EventTypes))
+	for i, t := range sub.EventTypes {
+		types[i] = string(t)
+	}
+	dto := WebhookResponseDTO{
+		ID:         sub.ID.String(),
+		URL:        sub.URL,
+		EventTypes: types,
+		Active:     sub.Active,
+		CreatedAt:  sub.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  sub.UpdatedAt.Format(time.RFC3339),
+	}
+	if sub.CreatedBy != nil {
+		createdBy := sub.CreatedBy.String()
+		dto.CreatedBy = &createdBy
+	}
+	return dto
+}
+
+// MapDomainWebhookDeliveryToResponseDTO converts a delivery to its DTO.
+func MapDomainWebhookDeliveryToResponseDTO(d *domain.WebhookDelivery) WebhookDeliveryResponseDTO {
+	dto := WebhookDeliveryResponseDTO{
+		ID:             d.ID.String(),
+		WebhookID:      d.SubscriptionID.String(),
+		EventID:        d.EventID.String(),
+		EventType:      string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      d.UpdatedAt.Format(time.RFC3339),
+	}
+	if d.Status == domain.DeliveryPending {
+		next := d.NextAttemptAt.Format(time.RFC3339)
+		dto.NextAttemptAt = &next
+	}
+	if d.DeliveredAt != nil {
+		delivered := d.DeliveredAt.Format(time.RFC3339)
+		dto.DeliveredAt = &delivered
+	}
+	return dto
+}
@@ -0,0 +1,212 @@
+// internal/adapter/handler/http/feed_handler.go
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/dto"
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/middleware"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+	"github.com/yvanyang/language-learning-player-api/pkg/podcast"
+)
+
+// FeedHandler handles HTTP requests for the podcast feeds of collections.
+type FeedHandler struct {
+	feedUseCase port.FeedUseCase
+}
+
+// NewFeedHandler creates a new FeedHandler.
+func NewFeedHandler(uc port.FeedUseCase) *FeedHandler {
+	return &FeedHandler{feedUseCase: uc}
+}
+
+// GetPublicFeed handles GET /api/v1/feeds/collections/{collectionId}
+// @Summary Get the podcast feed of a collection
+// @Description Returns an RSS 2.0 feed with iTunes tags for a public or unlisted collection, so that it can be followed in a podcast app.
+// @Description Enclosures are long-lived signed stream links. Supports If-None-Match and If-Modified-Since, except for smart collections.
+// @ID get-public-collection-feed
+// @Tags Feeds
+// @Produce application/rss+xml
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Success 200 {string} string "RSS feed"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Collection ID"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found or Private"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /feeds/collections/{collectionId} [get]
+func (h *FeedHandler) GetPublicFeed(w http.ResponseWriter, r *http.Request) {
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	feed, err := h.feedUseCase.GetPublicFeed(r.Context(), collectionID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeFeed(w, r, feed)
+}
+
+// GetPrivateFeed handles GET /api/v1/feeds/private/{token}
+// @Summary Get a secret podcast feed
+// @Description Returns the feed behind a secret feed URL issued with POST /audio/collections/{collectionId}/feed-token,
+// @Description listing the collection as the user it was issued to sees it. The URL is the credential; no Authorization header is needed.
+// @ID get-private-collection-feed
+// @Tags Feeds
+// @Produce application/rss+xml
+// @Param token path string true "Secret feed token"
+// @Success 200 {string} string "RSS feed"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Unknown or revoked feed URL"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /feeds/private/{token} [get]
+func (h *FeedHandler) GetPrivateFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.feedUseCase.GetPrivateFeed(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	writeFeed(w, r, feed)
+}
+
+// writeFeed answers conditional requests and writes the feed as RSS.
+func writeFeed(w http.ResponseWriter, r *http.Request, feed *port.CollectionFeed) {
+	if !feed.LastModified.IsZero() {
+		etag := httputil.WeakETag(strconv.FormatInt(feed.LastModified.UnixMicro(), 36))
+		if httputil.NotModifiedSince(w, r, etag, feed.LastModified) {
+			return
+		}
+	}
+	var buf bytes.Buffer
+	if err := podcast.Write(&buf, feed.Feed); err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// StreamTrack handles GET /api/v1/feeds/stream/{trackId}
+// @Summary Stream a track from a podcast feed
+// @Description Redirects a signed stream link from a feed to a short-lived URL of the audio. Links from a secret feed stop working when it is revoked.
+// @ID stream-feed-track
+// @Tags Feeds
+// @Param trackId path string true "Audio Track UUID" Format(uuid)
+// @Param exp query int true "Expiry of the link (Unix seconds)"
+// @Param sig query string true "Signature"
+// @Param ft query string false "Secret feed the link belongs to" Format(uuid)
+// @Success 302 "Redirect to the audio"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Malformed Link"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Invalid, expired or revoked link"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Track Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /feeds/stream/{trackId} [get]
+func (h *FeedHandler) StreamTrack(w http.ResponseWriter, r *http.Request) {
+	trackID, err := domain.TrackIDFromString(chi.URLParam(r, "trackId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid track ID format", domain.ErrInvalidArgument))
+		return
+	}
+	q := r.URL.Query()
+	exp, err := strconv.ParseInt(q.Get("exp"), 10, 64)
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid exp query parameter", domain.ErrInvalidArgument))
+		return
+	}
+	input := port.StreamLinkInput{TrackID: trackID, Expires: time.Unix(exp, 0), Signature: q.Get("sig")}
+	if v := q.Get("ft"); v != "" {
+		feedTokenID, err := domain.FeedTokenIDFromString(v)
+		if err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: invalid ft query parameter", domain.ErrInvalidArgument))
+			return
+		}
+		input.FeedTokenID = &feedTokenID
+	}
+	location, err := h.feedUseCase.ResolveStreamLink(r.Context(), input)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store") // The target URL is short-lived
+	http.Redirect(w, r, location, http.StatusFound)
+}
+
+// IssueFeedToken handles POST /api/v1/audio/collections/{collectionId}/feed-token
+// @Summary Issue a secret podcast feed URL
+// @Description Creates a secret feed URL for a collection the authenticated user can read, for podcast apps that cannot sign in.
+// @Description Issuing a new URL revokes the previous one. The URL is only returned once.
+// @ID issue-collection-feed-token
+// @Tags Feeds
+// @Produce json
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the original response"
+// @Success 201 {object} dto.FeedTokenResponseDTO "Feed URL issued"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Collection ID"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (collection is private and not shared)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Collection Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/feed-token [post]
+func (h *FeedHandler) IssueFeedToken(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	result, err := h.feedUseCase.IssueFeedToken(r.Context(), userID, collectionID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	httputil.RespondJSON(w, r, http.StatusCreated, dto.MapFeedTokenToResponseDTO(result))
+}
+
+// RevokeFeedToken handles DELETE /api/v1/audio/collections/{collectionId}/feed-token
+// @Summary Revoke a secret podcast feed URL
+// @Description Revokes the authenticated user's secret feed URL for a collection, and the stream links of private tracks in it.
+// @ID revoke-collection-feed-token
+// @Tags Feeds
+// @Security BearerAuth
+// @Param collectionId path string true "Audio Collection UUID" Format(uuid)
+// @Success 204 "Feed URL revoked"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Collection ID"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 404 {object} httputil.ErrorResponseDTO "No feed URL issued"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /audio/collections/{collectionId}/feed-token [delete]
+func (h *FeedHandler) RevokeFeedToken(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	collectionID, err := parseCollectionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	if err := h.feedUseCase.RevokeFeedToken(r.Context(), userID, collectionID); err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,286 @@
+// internal/adapter/handler/http/feed_subscription_handler.go
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/dto"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+	"github.com/yvanyang/language-learning-player-api/pkg/validation"
+)
+
+// maxOPMLSize bounds the OPML documents accepted by ImportOPML.
+const maxOPMLSize = 1 << 20
+
+// FeedSubscriptionHandler handles HTTP requests for subscriptions to external podcast feeds.
+type FeedSubscriptionHandler struct {
+	feedIngestUseCase port.FeedIngestUseCase
+	validator         *validation.Validator
+}
+
+// NewFeedSubscriptionHandler creates a new FeedSubscriptionHandler.
+func NewFeedSubscriptionHandler(uc port.FeedIngestUseCase, v *validation.Validator) *FeedSubscriptionHandler {
+	return &FeedSubscriptionHandler{
+		feedIngestUseCase: uc,
+		validator:         v,
+	}
+}
+
+func parseFeedSubscriptionID(r *http.Request) (domain.FeedSubscriptionID, error) {
+	id, err := domain.FeedSubscriptionIDFromString(chi.URLParam(r, "feedId"))
+	if err != nil {
+		return domain.FeedSubscriptionID{}, fmt.Errorf("%w: invalid feed ID format", domain.ErrInvalidArgument)
+	}
+	return id, nil
+}
+
+// Subscribe handles POST /api/v1/admin/feeds
+// @Summary Subscribe to a podcast feed
+// @Description Subscribes to an external RSS or Atom podcast feed and queues its first refresh. Requires the admin role.
+// @Description Every episode becomes a track of the owner and the feed a playlist of its episodes, oldest first.
+// @Description Feeds are refreshed in the background at feedIngest.refreshInterval, conditionally (ETag / Last-Modified).
+// @ID subscribe-feed
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param feed body dto.SubscribeFeedRequestDTO true "Feed URL and the values of ingested tracks"
+// @Success 201 {object} dto.FeedSubscriptionResponseDTO "Subscription created"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 409 {object} httputil.ErrorResponseDTO "Already Subscribed"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/feeds [post]
+func (h *FeedSubscriptionHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	var req dto.SubscribeFeedRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+
+	input := port.SubscribeFeedInput{
+		FeedURL: req.FeedURL,
+		Options: port.FeedIngestOptions{
+			LanguageCode: req.LanguageCode,
+			Level:        req.Level,
+			IsPublic:     req.IsPublic,
+			Tags:         req.Tags,
+		},
+	}
+	if req.OwnerID != nil {
+		ownerID, err := domain.UserIDFromString(*req.OwnerID)
+		if err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: invalid ownerId format", domain.ErrInvalidArgument))
+			return
+		}
+		input.OwnerID = &ownerID
+	}
+	sub, err := h.feedIngestUseCase.Subscribe(r.Context(), input)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusCreated, dto.MapDomainFeedSubscriptionToResponseDTO(sub))
+}
+
+// ImportOPML handles POST /api/v1/admin/feeds/opml
+// @Summary Import an OPML subscription list
+// @Description Subscribes to every feed of an OPML document (up to 1 MiB) that the owner is not subscribed to yet. Requires the admin role.
+// @Description The language of an outline, if given, takes precedence over the languageCode query parameter.
+// @ID import-feed-opml
+// @Tags Admin
+// @Accept xml
+// @Produce json
+// @Security BearerAuth
+// @Param document body string true "OPML document"
+// @Param ownerId query string false "Owner of the ingested tracks and collections (default: the requesting admin)" Format(uuid)
+// @Param languageCode query string false "Language of feeds that declare none"
+// @Param level query string false "Level of the ingested tracks" Enums(A1, A2, B1, B2, C1, C2, NATIVE)
+// @Param isPublic query bool false "Visibility of the ingested tracks and collections" default(false)
+// @Param tags query []string false "Tags added to every ingested track" collectionFormat(multi)
+// @Success 201 {object} dto.OPMLImportResponseDTO "Subscriptions created and feeds skipped"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/feeds/opml [post]
+func (h *FeedSubscriptionHandler) ImportOPML(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	input := port.ImportOPMLInput{
+		Document: http.MaxBytesReader(w, r.Body, maxOPMLSize),
+		Options: port.FeedIngestOptions{
+			LanguageCode: q.Get("languageCode"),
+			Level:        q.Get("level"),
+			Tags:         q["tags"],
+		},
+	}
+	defer r.Body.Close()
+	if v := q.Get("ownerId"); v != "" {
+		ownerID, err := domain.UserIDFromString(v)
+		if err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: invalid ownerId format", domain.ErrInvalidArgument))
+			return
+		}
+		input.OwnerID = &ownerID
+	}
+	if v := q.Get("isPublic"); v != "" {
+		isPublic, err := strconv.ParseBool(v)
+		if err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: invalid isPublic query parameter", domain.ErrInvalidArgument))
+			return
+		}
+		input.Options.IsPublic = isPublic
+	}
+
+	result, err := h.feedIngestUseCase.ImportOPML(r.Context(), input)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = fmt.Errorf("%w: the document exceeds the maximum size of %d bytes", domain.ErrInvalidArgument, maxOPMLSize)
+		}
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusCreated, dto.MapOPMLImportResultToResponseDTO(result))
+}
+
+// ListSubscriptions handles GET /api/v1/admin/feeds
+// @Summary List podcast feed subscriptions
+// @Description Retrieves a paginated list of feed subscriptions, with the outcome of their last refresh. Requires the admin role.
+// @ID list-feed-subscriptions
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Pagination limit" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Pagination offset" default(0) minimum(0)
+// @Success 200 {object} dto.PaginatedResponseDTO{data=[]dto.FeedSubscriptionResponseDTO} "Paginated list of feed subscriptions"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Query Parameter Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/feeds [get]
+func (h *FeedSubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePage(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	subs, total, actualPageInfo, err := h.feedIngestUseCase.ListSubscriptions(r.Context(), page)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	respData := make([]dto.FeedSubscriptionResponseDTO, len(subs))
+	for i, sub := range subs {
+		respData[i] = dto.MapDomainFeedSubscriptionToResponseDTO(sub)
+	}
+	paginatedResult := pagination.NewPaginatedResponse(respData, total, actualPageInfo)
+	resp := dto.PaginatedResponseDTO{
+		Data:       paginatedResult.Data,
+		Total:      paginatedResult.Total,
+		Limit:      paginatedResult.Limit,
+		Offset:     paginatedResult.Offset,
+		Page:       paginatedResult.Page,
+		TotalPages: paginatedResult.TotalPages,
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
+
+// GetSubscription handles GET /api/v1/admin/feeds/{feedId}
+// @Summary Get a podcast feed subscription
+// @Description Retrieves a feed subscription. Requires the admin role.
+// @ID get-feed-subscription
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param feedId path string true "Feed subscription UUID" Format(uuid)
+// @Success 200 {object} dto.FeedSubscriptionResponseDTO "Feed subscription details"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Feed ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Feed Subscription Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/feeds/{feedId} [get]
+func (h *FeedSubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := parseFeedSubscriptionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	sub, err := h.feedIngestUseCase.GetSubscription(r.Context(), id)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, dto.MapDomainFeedSubscriptionToResponseDTO(sub))
+}
+
+// RefreshSubscription handles POST /api/v1/admin/feeds/{feedId}/refresh
+// @Summary Refresh a podcast feed
+// @Description Queues a refresh of the feed now, regardless of its schedule. Requires the admin role.
+// @ID refresh-feed-subscription
+// @Tags Admin
+// @Security BearerAuth
+// @Param feedId path string true "Feed subscription UUID" Format(uuid)
+// @Success 202 "Refresh queued"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Feed ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Feed Subscription Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/feeds/{feedId}/refresh [post]
+func (h *FeedSubscriptionHandler) RefreshSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := parseFeedSubscriptionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	if err := h.feedIngestUseCase.RefreshSubscription(r.Context(), id); err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Unsubscribe handles DELETE /api/v1/admin/feeds/{feedId}
+// @Summary Unsubscribe from a podcast feed
+// @Description Stops refreshing a feed. The tracks and collection ingested from it remain. Requires the admin role.
+// @ID unsubscribe-feed
+// @Tags Admin
+// @Security BearerAuth
+// @Param feedId path string true "Feed subscription UUID" Format(uuid)
+// @Success 204 "Subscription deleted"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Feed ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Feed Subscription Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/feeds/{feedId} [delete]
+func (h *FeedSubscriptionHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	id, err := parseFeedSubscriptionID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	if err := h.feedIngestUseCase.Unsubscribe(r.Context(), id); err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,176 @@
+// internal/adapter/handler/http/import_handler.go
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/dto"
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+)
+
+// multipartOverhead allows for the multipart headers around the archive.
+const multipartOverhead = 1 << 20
+
+// ImportHandler handles HTTP requests for bulk imports of audio libraries.
+type ImportHandler struct {
+	importUseCase port.ImportUseCase
+	cfg           config.ImportConfig
+}
+
+// NewImportHandler creates a new ImportHandler.
+func NewImportHandler(uc port.ImportUseCase, cfg config.ImportConfig) *ImportHandler {
+	return &ImportHandler{
+		importUseCase: uc,
+		cfg:           cfg,
+	}
+}
+
+// StartImport handles POST /api/v1/admin/imports
+// @Summary Import an audio library
+// @Description Uploads a ZIP archive of audio files (mp3, ogg, opus, flac, m4a, wav) and queues its import. Requires the admin role.
+// @Description Every file becomes a track and every directory a course. Metadata is read from manifest.yaml, manifest.yml or
+// @Description manifest.csv at the top level, then from ID3/Vorbis tags, then from the defaults given as query parameters.
+// @Description Importing the same archive again only adds what is missing; files are matched by a hash of their content.
+// @ID start-import
+// @Tags Admin
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param archive formData file true "ZIP archive of the library"
+// @Param ownerId query string false "Owner of the imported tracks and courses (default: the requesting admin)" Format(uuid)
+// @Param languageCode query string false "Default language code"
+// @Param level query string false "Default level" Enums(A1, A2, B1, B2, C1, C2, NATIVE)
+// @Param isPublic query bool false "Default visibility of the tracks" default(false)
+// @Param tags query []string false "Tags added to every track (e.g., ?tags=course&tags=2025)" collectionFormat(multi)
+// @Param title query string false "Title of the course for files at the top level (default: archive name)"
+// @Success 202 {object} dto.ImportRunResponseDTO "Import queued"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/imports [post]
+func (h *ImportHandler) StartImport(w http.ResponseWriter, r *http.Request) {
+	input, err := parseImportQuery(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	// The archive may take much longer to receive than server.readTimeout allows.
+	rc := http.NewResponseController(w)
+	deadline := time.Now().Add(h.cfg.UploadTimeout)
+	if err := errors.Join(rc.SetReadDeadline(deadline), rc.SetWriteDeadline(deadline)); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("extending request deadline: %w", err))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, h.cfg.MaxArchiveSize+multipartOverhead)
+	defer r.Body.Close()
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: expected a multipart/form-data request", domain.ErrInvalidArgument))
+		return
+	}
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			httputil.RespondError(w, r, fmt.Errorf("%w: missing form field \"archive\"", domain.ErrInvalidArgument))
+			return
+		}
+		if err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: invalid multipart body", domain.ErrInvalidArgument))
+			return
+		}
+		if part.FormName() != "archive" {
+			continue
+		}
+		input.Archive = part
+		input.Size = -1
+		input.Filename = part.FileName()
+		break
+	}
+
+	// Detached from the request timeout middleware; the deadline above bounds the upload.
+	run, err := h.importUseCase.StartArchiveImport(context.WithoutCancel(r.Context()), input)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = fmt.Errorf("%w: the archive exceeds the maximum size of %d bytes", domain.ErrInvalidArgument, h.cfg.MaxArchiveSize)
+		}
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusAccepted, dto.MapDomainImportRunToResponseDTO(run))
+}
+
+// parseImportQuery reads the import options from the query string.
+func parseImportQuery(r *http.Request) (port.StartImportInput, error) {
+	q := r.URL.Query()
+	input := port.StartImportInput{
+		Options: port.ImportOptions{
+			LanguageCode: q.Get("languageCode"),
+			Level:        q.Get("level"),
+			Tags:         q["tags"],
+			RootTitle:    strings.TrimSpace(q.Get("title")),
+		},
+	}
+	if v := q.Get("ownerId"); v != "" {
+		ownerID, err := domain.UserIDFromString(v)
+		if err != nil {
+			return input, fmt.Errorf("%w: invalid ownerId format", domain.ErrInvalidArgument)
+		}
+		input.OwnerID = &ownerID
+	}
+	if v := q.Get("isPublic"); v != "" {
+		isPublic, err := strconv.ParseBool(v)
+		if err != nil {
+			return input, fmt.Errorf("%w: invalid isPublic query parameter", domain.ErrInvalidArgument)
+		}
+		input.Options.IsPublic = isPublic
+	}
+	if level := domain.AudioLevel(input.Options.Level); level != domain.LevelUnknown && !level.IsValid() {
+		return input, fmt.Errorf("%w: invalid level '%s'", domain.ErrInvalidArgument, input.Options.Level)
+	}
+	return input, nil
+}
+
+// GetImport handles GET /api/v1/admin/imports/{importId}
+// @Summary Get an import
+// @Description Retrieves the status of an archive import and, once finished, its per-file report. Requires the admin role.
+// @ID get-import
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param importId path string true "Import UUID" Format(uuid)
+// @Success 200 {object} dto.ImportRunResponseDTO "Import details"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Import ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Import Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/imports/{importId} [get]
+func (h *ImportHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	id, err := domain.ImportRunIDFromString(chi.URLParam(r, "importId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid import ID format", domain.ErrInvalidArgument))
+		return
+	}
+	run, err := h.importUseCase.GetImportRun(r.Context(), id)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, dto.MapDomainImportRunToResponseDTO(run))
+}
@@ -57,6 +57,22 @@ func Authenticator(secHelper port.SecurityHelper) func(next http.Handler) http.H
 	}
 }
 
+// OptionalAuthenticator creates a middleware for routes that anonymous users may call but that
+// show more to signed-in users. Requests without an Authorization header pass through without a
+// user ID; a header with an invalid token is still rejected.
+func OptionalAuthenticator(secHelper port.SecurityHelper) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		authenticated := Authenticator(secHelper)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authenticated.ServeHTTP(w, r)
+		})
+	}
+}
+
 // GetUserIDFromContext retrieves the UserID from the context.
 // Returns domain.UserID zero value and false if not found or type is wrong.
 func GetUserIDFromContext(ctx context.Context) (domain.UserID, bool) {
@@ -0,0 +1,225 @@
+// internal/adapter/handler/http/middleware/idempotency.go
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+)
+
+const (
+	// HeaderIdempotencyKey is the request header carrying the client-chosen key.
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed is set on responses replayed from storage.
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// replayedHeaders are the response headers stored with a response besides Content-Type, so
+// that a replayed create still tells the client where the resource is and which version it has.
+var replayedHeaders = []string{"ETag", "Location"}
+
+// Idempotency replays stored responses for retried requests that carry an
+// Idempotency-Key header. Keys are scoped to the authenticated user, so the
+// middleware must run after Authenticator. Requests without the header pass through.
+type Idempotency struct {
+	repo            port.IdempotencyRepository
+	ttl             time.Duration
+	cleanupInterval time.Duration
+	lease           time.Duration
+	maxBodyBytes    int64
+	logger          *slog.Logger
+}
+
+// NewIdempotency creates the Idempotency middleware from configuration.
+func NewIdempotency(cfg config.IdempotencyConfig, repo port.IdempotencyRepository, logger *slog.Logger) *Idempotency {
+	return &Idempotency{
+		repo:            repo,
+		ttl:             cfg.TTL,
+		cleanupInterval: cfg.CleanupInterval,
+		lease:           cfg.InProgressLease,
+		maxBodyBytes:    cfg.MaxBodyBytes,
+		logger:          logger.With("middleware", "Idempotency"),
+	}
+}
+
+// captureWriter passes the response through while keeping a copy of the body.
+type captureWriter struct {
+	*ResponseWriterWrapper
+	body bytes.Buffer
+}
+
+func (cw *captureWriter) Write(b []byte) (int, error) {
+	cw.body.Write(b)
+	return cw.ResponseWriterWrapper.Write(b)
+}
+
+// fingerprint identifies the request so that a reused key with a different
+// request can be told apart from a genuine retry.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + "\n" + r.URL.Path + "\n" + r.URL.RawQuery + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Handler is the idempotency middleware.
+func (m *Idempotency) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(HeaderIdempotencyKey)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			httputil.RespondError(w, r, fmt.Errorf("%w: %s header must be at most %d characters", domain.ErrInvalidArgument, HeaderIdempotencyKey, maxIdempotencyKeyLength))
+			return
+		}
+		userID, ok := GetUserIDFromContext(r.Context())
+		if !ok {
+			httputil.RespondError(w, r, domain.ErrUnauthenticated)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, m.maxBodyBytes))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				httputil.RespondError(w, r, fmt.Errorf("%w: request body too large for an idempotent request", domain.ErrInvalidArgument))
+				return
+			}
+			httputil.RespondError(w, r, fmt.Errorf("%w: failed to read request body", domain.ErrInvalidArgument))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		record := &port.IdempotencyRecord{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: fingerprint(r, body),
+			ExpiresAt:   time.Now().Add(m.ttl),
+			Lease:       m.lease,
+		}
+		existing, claimed, err := m.repo.Claim(r.Context(), record)
+		if err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("checking idempotency key: %w", err))
+			return
+		}
+		if !claimed {
+			m.respondExisting(w, r, record, existing)
+			return
+		}
+
+		// Detach from request cancellation (e.g., the timeout middleware) so the
+		// outcome is always recorded once the handler has run.
+		storeCtx := context.WithoutCancel(r.Context())
+		cw := &captureWriter{ResponseWriterWrapper: NewResponseWriterWrapper(w)}
+		completed := false
+		defer func() {
+			if !completed {
+				// Handler panicked; free the key so the client can retry.
+				if err := m.repo.Release(storeCtx, record); err != nil {
+					m.logger.ErrorContext(storeCtx, "Failed to release idempotency key after panic", "error", err, "userID", userID)
+				}
+			}
+		}()
+
+		next.ServeHTTP(cw, r)
+		completed = true
+
+		if cw.statusCode >= http.StatusInternalServerError {
+			// Server errors are not final; let the client retry with the same key.
+			if err := m.repo.Release(storeCtx, record); err != nil {
+				m.logger.ErrorContext(storeCtx, "Failed to release idempotency key", "error", err, "userID", userID)
+			}
+			return
+		}
+		record.Completed = true
+		record.StatusCode = cw.statusCode
+		record.ContentType = cw.Header().Get("Content-Type")
+		for _, name := range replayedHeaders {
+			if value := cw.Header().Get(name); value != "" {
+				if record.Headers == nil {
+					record.Headers = make(map[string]string, len(replayedHeaders))
+				}
+				record.Headers[name] = value
+			}
+		}
+		record.Body = cw.body.Bytes()
+		if err := m.repo.Complete(storeCtx, record); errors.Is(err, domain.ErrNotFound) {
+			// The lease ran out and a retry took the key over; its response is the one kept.
+			m.logger.WarnContext(storeCtx, "Idempotency key was taken over before the response could be stored", "userID", userID, "request_id", httputil.GetReqID(r.Context()))
+		} else if err != nil {
+			m.logger.ErrorContext(storeCtx, "Failed to store idempotent response", "error", err, "userID", userID, "request_id", httputil.GetReqID(r.Context()))
+		}
+	})
+}
+
+// respondExisting handles a key that is already known: replay a finished
+// response, or reject a concurrent or mismatched request with 409.
+func (m *Idempotency) respondExisting(w http.ResponseWriter, r *http.Request, record, existing *port.IdempotencyRecord) {
+	if existing.RequestHash != record.RequestHash {
+		m.logger.WarnContext(r.Context(), "Idempotency key reused with a different request", "userID", record.UserID, "request_id", httputil.GetReqID(r.Context()))
+		httputil.RespondError(w, r, fmt.Errorf("%w: %s was already used for a different request", domain.ErrConflict, HeaderIdempotencyKey))
+		return
+	}
+	if !existing.Completed {
+		httputil.RespondError(w, r, fmt.Errorf("%w: a request with this %s is still being processed", domain.ErrConflict, HeaderIdempotencyKey))
+		return
+	}
+
+	if existing.ContentType != "" {
+		w.Header().Set("Content-Type", existing.ContentType)
+	}
+	for _, name := range replayedHeaders {
+		if value, ok := existing.Headers[name]; ok {
+			w.Header().Set(name, value)
+		}
+	}
+	w.Header().Set(HeaderIdempotentReplayed, "true")
+	w.WriteHeader(existing.StatusCode)
+	if len(existing.Body) > 0 {
+		if _, err := w.Write(existing.Body); err != nil {
+			m.logger.WarnContext(r.Context(), "Failed to write replayed response", "error", err)
+		}
+	}
+}
+
+// CleanUpExpired periodically deletes expired idempotency keys. It blocks until ctx is cancelled.
+func (m *Idempotency) CleanUpExpired(ctx context.Context) {
+	if m.cleanupInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(m.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.logger.Debug("Idempotency key cleanup stopped")
+			return
+		case <-ticker.C:
+			count, err := m.repo.DeleteExpired(ctx)
+			if err != nil {
+				m.logger.WarnContext(ctx, "Idempotency key cleanup failed", "error", err)
+				continue
+			}
+			if count > 0 {
+				m.logger.Debug("Idempotency key cleanup removed entries", "removed_count", count)
+			}
+		}
+	}
+}
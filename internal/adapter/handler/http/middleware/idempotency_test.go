@@ -0,0 +1,292 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// fakeIdempotencyRepo is an in-memory port.IdempotencyRepository with an adjustable clock.
+type fakeIdempotencyRepo struct {
+	mu      sync.Mutex
+	records map[string]port.IdempotencyRecord
+	offset  time.Duration
+}
+
+func newFakeIdempotencyRepo() *fakeIdempotencyRepo {
+	return &fakeIdempotencyRepo{records: make(map[string]port.IdempotencyRecord)}
+}
+
+func (f *fakeIdempotencyRepo) now() time.Time {
+	return time.Now().Add(f.offset)
+}
+
+// advance moves the repository's clock forward, e.g. past a lease.
+func (f *fakeIdempotencyRepo) advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.offset += d
+}
+
+func (f *fakeIdempotencyRepo) id(userID domain.UserID, key string) string {
+	return userID.String() + "|" + key
+}
+
+func (f *fakeIdempotencyRepo) Claim(_ context.Context, record *port.IdempotencyRecord) (*port.IdempotencyRecord, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.id(record.UserID, record.Key)
+	if existing, ok := f.records[id]; ok && existing.ExpiresAt.After(f.now()) &&
+		(existing.Completed || existing.RequestHash != record.RequestHash || f.now().Sub(existing.ClaimedAt) < record.Lease) {
+		return &existing, false, nil
+	}
+	record.ClaimedAt = f.now()
+	f.records[id] = *record
+	return nil, true, nil
+}
+
+// claimHolds reports whether record still holds the in-progress claim on its key.
+func (f *fakeIdempotencyRepo) claimHolds(record *port.IdempotencyRecord) bool {
+	existing, ok := f.records[f.id(record.UserID, record.Key)]
+	return ok && !existing.Completed && existing.ClaimedAt.Equal(record.ClaimedAt)
+}
+
+func (f *fakeIdempotencyRepo) Complete(_ context.Context, record *port.IdempotencyRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.claimHolds(record) {
+		return domain.ErrNotFound
+	}
+	f.records[f.id(record.UserID, record.Key)] = *record
+	return nil
+}
+
+func (f *fakeIdempotencyRepo) Release(_ context.Context, record *port.IdempotencyRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.claimHolds(record) {
+		delete(f.records, f.id(record.UserID, record.Key))
+	}
+	return nil
+}
+
+func (f *fakeIdempotencyRepo) DeleteExpired(context.Context) (int64, error) { return 0, nil }
+
+func newTestIdempotency(repo port.IdempotencyRepository) *Idempotency {
+	cfg := config.IdempotencyConfig{Enabled: true, TTL: time.Hour, MaxBodyBytes: 1024, InProgressLease: time.Minute}
+	return NewIdempotency(cfg, repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func idempotentRequest(userID domain.UserID, key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/bookmarks", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+	return req.WithContext(context.WithValue(req.Context(), UserIDKey, userID))
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	calls := 0
+	handler := newTestIdempotency(newFakeIdempotencyRepo()).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"note":"a"}`, string(body), "Handler must still see the request body")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"1"`)
+		w.Header().Set("Location", "/api/v1/users/me/bookmarks/1")
+		w.Header().Set("X-Not-Replayed", "a")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	userID := domain.NewUserID()
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, idempotentRequest(userID, "key-1", `{"note":"a"}`))
+	second := httptest.NewRecorder()
+	handler.ServeHTTP(second, idempotentRequest(userID, "key-1", `{"note":"a"}`))
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, `{"id":"1"}`, second.Body.String())
+	assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+	assert.Equal(t, `"1"`, second.Header().Get("ETag"), "Clients need the ETag of the created resource for If-Match")
+	assert.Equal(t, "/api/v1/users/me/bookmarks/1", second.Header().Get("Location"))
+	assert.Empty(t, second.Header().Get("X-Not-Replayed"))
+	assert.Equal(t, "true", second.Header().Get(HeaderIdempotentReplayed))
+	assert.Empty(t, first.Header().Get(HeaderIdempotentReplayed))
+
+	// Keys are scoped per user.
+	other := httptest.NewRecorder()
+	handler.ServeHTTP(other, idempotentRequest(domain.NewUserID(), "key-1", `{"note":"a"}`))
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_Conflicts(t *testing.T) {
+	repo := newFakeIdempotencyRepo()
+	handler := newTestIdempotency(repo).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	userID := domain.NewUserID()
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentRequest(userID, "key-1", `{"note":"a"}`))
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	// Same key, different body
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentRequest(userID, "key-1", `{"note":"b"}`))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	// Same key, same body, different query
+	req := idempotentRequest(userID, "key-1", `{"note":"a"}`)
+	req.URL.RawQuery = "dryRun=true"
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	// Same key while the original request is still running
+	_, claimed, err := repo.Claim(context.Background(), &port.IdempotencyRecord{
+		UserID: userID, Key: "key-2", RequestHash: fingerprint(idempotentRequest(userID, "key-2", "{}"), []byte("{}")), ExpiresAt: time.Now().Add(time.Hour), Lease: time.Minute,
+	})
+	require.NoError(t, err)
+	require.True(t, claimed)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentRequest(userID, "key-2", "{}"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestIdempotency_ServerErrorReleasesKey(t *testing.T) {
+	calls := 0
+	handler := newTestIdempotency(newFakeIdempotencyRepo()).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	userID := domain.NewUserID()
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentRequest(userID, "key-1", "{}"))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentRequest(userID, "key-1", "{}"))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_WithoutKeyPassesThrough(t *testing.T) {
+	calls := 0
+	handler := newTestIdempotency(newFakeIdempotencyRepo()).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	userID := domain.NewUserID()
+	for i := 0; i < 2; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest(userID, "", "{}"))
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_AbandonedClaimIsTakenOverAfterLease(t *testing.T) {
+	repo := newFakeIdempotencyRepo()
+	calls := 0
+	handler := newTestIdempotency(repo).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	userID := domain.NewUserID()
+
+	// A claim left behind by a request whose process died before completing or releasing it
+	_, claimed, err := repo.Claim(context.Background(), &port.IdempotencyRecord{
+		UserID: userID, Key: "key-1", RequestHash: fingerprint(idempotentRequest(userID, "key-1", "{}"), []byte("{}")), ExpiresAt: time.Now().Add(time.Hour), Lease: time.Minute,
+	})
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentRequest(userID, "key-1", "{}"))
+	assert.Equal(t, http.StatusConflict, rr.Code, "Within the lease the claim is presumed alive")
+
+	repo.advance(2 * time.Minute)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentRequest(userID, "key-1", `{"note":"b"}`))
+	assert.Equal(t, http.StatusConflict, rr.Code, "Only a retry of the same request may take the key over")
+	assert.Equal(t, 0, calls)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentRequest(userID, "key-1", "{}"))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, 1, calls)
+
+	// A completed response is never taken over, however old
+	repo.advance(2 * time.Minute)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, idempotentRequest(userID, "key-1", "{}"))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "true", rr.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_TakenOverClaimCannotCompleteOrRelease(t *testing.T) {
+	// The original request finishes after a retry took its key over: a server error would
+	// release the key, any other response would be stored over the retry's
+	for _, originalStatus := range []int{http.StatusServiceUnavailable, http.StatusOK} {
+		t.Run(http.StatusText(originalStatus), func(t *testing.T) {
+			repo := newFakeIdempotencyRepo()
+			started := make(chan struct{})
+			unblock := make(chan struct{})
+			calls := 0
+			handler := newTestIdempotency(repo).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls == 1 {
+					// The original request is slow enough for its lease to run out
+					close(started)
+					<-unblock
+					w.WriteHeader(originalStatus)
+					_, _ = w.Write([]byte("original"))
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte("retry"))
+			}))
+			userID := domain.NewUserID()
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest(userID, "key-1", "{}"))
+			}()
+			<-started
+
+			repo.advance(2 * time.Minute)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, idempotentRequest(userID, "key-1", "{}"))
+			require.Equal(t, http.StatusCreated, rr.Code)
+
+			close(unblock)
+			<-done
+
+			rr = httptest.NewRecorder()
+			handler.ServeHTTP(rr, idempotentRequest(userID, "key-1", "{}"))
+			assert.Equal(t, http.StatusCreated, rr.Code)
+			assert.Equal(t, "retry", rr.Body.String())
+			assert.Equal(t, "true", rr.Header().Get(HeaderIdempotentReplayed), "The retry's stored response survives")
+			assert.Equal(t, 2, calls)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// internal/adapter/handler/http/middleware/metrics.go
+package middleware
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+)
+
+// HTTPMetricsRecorder receives one observation per finished request.
+type HTTPMetricsRecorder interface {
+	ObserveHTTPRequest(route, method string, status int, duration time.Duration)
+}
+
+// unmatchedRoute labels requests that did not match any route, keeping label cardinality bounded.
+const unmatchedRoute = "unmatched"
+
+// Metrics records request count and latency labelled by chi route pattern.
+func Metrics(recorder HTTPMetricsRecorder) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			wrappedWriter := NewResponseWriterWrapper(w)
+
+			next.ServeHTTP(wrappedWriter, r)
+
+			// The route pattern is only complete once routing has finished.
+			route := unmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					route = pattern
+				}
+			}
+			recorder.ObserveHTTPRequest(route, r.Method, wrappedWriter.statusCode, time.Since(start))
+		})
+	}
+}
+
+// RequireBearerToken protects a handler with a static bearer token (e.g. /metrics).
+// An empty token disables the check.
+func RequireBearerToken(token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if token == "" {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := bearerToken(r)
+			if provided == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				httputil.RespondError(w, r, fmt.Errorf("%w: valid bearer token required", domain.ErrUnauthenticated))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type observation struct {
+	route, method string
+	status        int
+}
+
+// fakeMetricsRecorder records every observation it receives.
+type fakeMetricsRecorder struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (f *fakeMetricsRecorder) ObserveHTTPRequest(route, method string, status int, _ time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.observations = append(f.observations, observation{route: route, method: method, status: status})
+}
+
+func TestMetrics_LabelsByRoutePattern(t *testing.T) {
+	recorder := &fakeMetricsRecorder{}
+	router := chi.NewRouter()
+	router.Use(Metrics(recorder))
+	router.Route("/api/v1/audio", func(r chi.Router) {
+		r.Get("/tracks/{trackId}", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+		r.Delete("/tracks/{trackId}", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/audio/tracks/a", nil),
+		httptest.NewRequest(http.MethodGet, "/api/v1/audio/tracks/b", nil),
+		httptest.NewRequest(http.MethodDelete, "/api/v1/audio/tracks/c", nil),
+		httptest.NewRequest(http.MethodGet, "/does/not/exist/1", nil),
+		httptest.NewRequest(http.MethodGet, "/does/not/exist/2", nil),
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Raw paths never become labels, so cardinality stays bounded by the route table
+	assert.Equal(t, []observation{
+		{route: "/api/v1/audio/tracks/{trackId}", method: http.MethodGet, status: http.StatusOK},
+		{route: "/api/v1/audio/tracks/{trackId}", method: http.MethodGet, status: http.StatusOK},
+		{route: "/api/v1/audio/tracks/{trackId}", method: http.MethodDelete, status: http.StatusNoContent},
+		{route: unmatchedRoute, method: http.MethodGet, status: http.StatusNotFound},
+		{route: unmatchedRoute, method: http.MethodGet, status: http.StatusNotFound},
+	}, recorder.observations)
+}
+
+func TestRequireBearerToken(t *testing.T) {
+	handler := RequireBearerToken("s3cret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{"valid token", "Bearer s3cret", http.StatusOK},
+		{"case-insensitive scheme", "bearer s3cret", http.StatusOK},
+		{"missing header", "", http.StatusUnauthorized},
+		{"wrong token", "Bearer guess", http.StatusUnauthorized},
+		{"token prefix", "Bearer s3cre", http.StatusUnauthorized},
+		{"basic scheme", "Basic czNjcmV0", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			assert.Equal(t, tt.want, rr.Code)
+		})
+	}
+}
+
+func TestRequireBearerToken_EmptyTokenDisablesCheck(t *testing.T) {
+	handler := RequireBearerToken("")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
@@ -2,145 +2,271 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"math"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/yvanyang/language-learning-player-api/internal/config"
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
 	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
-	"golang.org/x/time/rate"
 )
 
-// limiterEntry stores the limiter and the last seen time.
-// Point 7: Added struct to track lastSeen
-type limiterEntry struct {
-	limiter  *rate.Limiter
-	lastSeen time.Time
+// Standard rate limit response headers (IETF draft "RateLimit header fields for HTTP").
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+// RateLimitHeaders lists the headers clients need exposed via CORS.
+var RateLimitHeaders = []string{HeaderRateLimitLimit, HeaderRateLimitRemaining, HeaderRateLimitReset, HeaderRetryAfter}
+
+// ratePolicy is a resolved rate limit policy. name identifies the bucket
+// namespace so that each route policy gets its own bucket per client.
+type ratePolicy struct {
+	name   string
+	method string
+	prefix bool // pattern ended in "/*" and matches any sub-route
+	policy port.RateLimitPolicy
+	keyBy  string
 }
 
-// IPRateLimiter stores rate limiters for IP addresses.
-type IPRateLimiter struct {
-	ips map[string]*limiterEntry // Point 7: Changed map value type
-	mu  *sync.RWMutex
-	r   rate.Limit
-	b   int
+// RateLimiter applies per-route, per-client token bucket limits.
+// Clients are identified by user ID (when the policy asks for it and the request
+// carries a valid access token) or by IP address. Bucket state lives in a
+// port.RateLimitStore; if the store errors or exceeds storeTimeout the request
+// is let through (fail open).
+type RateLimiter struct {
+	store           port.RateLimitStore
+	storeTimeout    time.Duration
+	defaultPolicy   ratePolicy
+	routes          map[string][]ratePolicy // keyed by normalized pattern (without "/*" for prefix policies)
+	secHelper       port.SecurityHelper
+	cleanupInterval time.Duration
+	maxIdle         time.Duration
+	logger          *slog.Logger
 }
 
-// NewIPRateLimiter creates a new IPRateLimiter.
-func NewIPRateLimiter(r rate.Limit, b int) *IPRateLimiter {
-	limiter := &IPRateLimiter{
-		ips: make(map[string]*limiterEntry),
-		mu:  &sync.RWMutex{},
-		r:   r,
-		b:   b,
+// NewRateLimiter creates a RateLimiter from configuration.
+// secHelper is used to identify the user for "user" keyed policies; it may be nil,
+// in which case every request is keyed by IP.
+func NewRateLimiter(cfg config.RateLimitConfig, store port.RateLimitStore, secHelper port.SecurityHelper, logger *slog.Logger) *RateLimiter {
+	rl := &RateLimiter{
+		store:           store,
+		storeTimeout:    cfg.StoreTimeout,
+		defaultPolicy:   newRatePolicy("default", "", cfg.Default),
+		routes:          make(map[string][]ratePolicy),
+		secHelper:       secHelper,
+		cleanupInterval: cfg.CleanupInterval,
+		maxIdle:         cfg.MaxIdle,
+		logger:          logger.With("middleware", "RateLimiter"),
 	}
-	// Point 7: Start cleanup goroutine
-	go limiter.CleanUpOldLimiters(10*time.Minute, 30*time.Minute) // Example: cleanup every 10min, remove if idle > 30min
+	for _, route := range cfg.Routes {
+		pattern := normalizePattern(route.Pattern)
+		method := strings.ToUpper(route.Method)
+		p := newRatePolicy(strings.TrimSpace(method+" "+pattern), method, route.RateLimitPolicy)
+		if strings.HasSuffix(pattern, "/*") {
+			p.prefix = true
+			pattern = strings.TrimSuffix(pattern, "/*")
+		}
+		rl.routes[pattern] = append(rl.routes[pattern], p)
+	}
+	return rl
+}
 
-	return limiter
+func newRatePolicy(name, method string, p config.RateLimitPolicy) ratePolicy {
+	return ratePolicy{
+		name:   name,
+		method: method,
+		policy: port.RateLimitPolicy{Rate: p.RPS, Burst: p.Burst},
+		keyBy:  p.KeyBy,
+	}
 }
 
-// Point 7: Modified AddIP to use limiterEntry
-func (i *IPRateLimiter) addIP(ip string) *limiterEntry {
-	i.mu.Lock()
-	defer i.mu.Unlock()
+// normalizePattern strips trailing slashes so "/progress/" and "/progress" match.
+func normalizePattern(pattern string) string {
+	if pattern == "/" {
+		return pattern
+	}
+	return strings.TrimRight(pattern, "/")
+}
 
-	// Double check existence inside lock
-	entry, exists := i.ips[ip]
-	if !exists {
-		entry = &limiterEntry{
-			limiter:  rate.NewLimiter(i.r, i.b),
-			lastSeen: time.Now(),
+// policyFor returns the policy for a resolved route pattern and method.
+func (rl *RateLimiter) policyFor(method, pattern string) ratePolicy {
+	pattern = normalizePattern(pattern)
+	if p, ok := matchPolicy(rl.routes[pattern], method, false); ok {
+		return p
+	}
+	// Walk up the pattern looking for prefix ("/*") policies.
+	for candidate := pattern; candidate != ""; {
+		idx := strings.LastIndex(candidate, "/")
+		if idx < 0 {
+			break
+		}
+		candidate = candidate[:idx]
+		if p, ok := matchPolicy(rl.routes[candidate], method, true); ok {
+			return p
 		}
-		i.ips[ip] = entry
-		slog.Debug("Added new rate limiter entry", "ip_address", ip) // Added debug log
 	}
-	return entry
+	return rl.defaultPolicy
 }
 
-// Point 7: Modified GetLimiter to return limiter and update lastSeen
-func (i *IPRateLimiter) GetLimiter(ip string) *rate.Limiter {
-	i.mu.RLock()
-	entry, exists := i.ips[ip]
-	i.mu.RUnlock()
-
-	if !exists {
-		entry = i.addIP(ip) // addIP handles locking
-	} else {
-		// Update lastSeen without full lock if possible, but requires locking for write.
-		// Simple approach: Lock for update.
-		i.mu.Lock()
-		entry.lastSeen = time.Now()
-		i.mu.Unlock()
+func matchPolicy(policies []ratePolicy, method string, prefixOnly bool) (ratePolicy, bool) {
+	for _, p := range policies {
+		if prefixOnly && !p.prefix {
+			continue
+		}
+		if p.method == "" || p.method == method {
+			return p, true
+		}
 	}
-
-	return entry.limiter
+	return ratePolicy{}, false
 }
 
-// RateLimit is the middleware handler.
-func RateLimit(limiter *IPRateLimiter) func(next http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Get IP logic remains the same
-			ip := r.RemoteAddr
-			if realIP := r.Context().Value(http.CanonicalHeaderKey("X-Real-IP")); realIP != nil {
-				if ripStr, ok := realIP.(string); ok {
-					ip = ripStr
-				}
-			} else if forwardedFor := r.Context().Value(http.CanonicalHeaderKey("X-Forwarded-For")); forwardedFor != nil {
-				if fwdStr, ok := forwardedFor.(string); ok {
-					parts := strings.Split(fwdStr, ",")
-					if len(parts) > 0 {
-						ip = strings.TrimSpace(parts[0])
-					}
-				}
-			} else {
-				host, _, err := net.SplitHostPort(r.RemoteAddr)
-				if err == nil {
-					ip = host
+// clientKey identifies the caller for the given policy.
+func (rl *RateLimiter) clientKey(r *http.Request, p ratePolicy) string {
+	if p.keyBy == config.RateLimitKeyByUser {
+		if userID, ok := GetUserIDFromContext(r.Context()); ok {
+			return "user:" + userID.String()
+		}
+		// The limiter runs before Authenticator, so peek at the bearer token.
+		// Invalid tokens are not rejected here; Authenticator will do that.
+		if rl.secHelper != nil {
+			if token := bearerToken(r); token != "" {
+				if userID, err := rl.secHelper.VerifyJWT(r.Context(), token); err == nil {
+					return "user:" + userID.String()
 				}
 			}
+		}
+	}
+	return "ip:" + clientIP(r)
+}
 
-			// Get the rate limiter for the current IP address.
-			l := limiter.GetLimiter(ip) // This now also updates lastSeen
+func bearerToken(r *http.Request) string {
+	parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
 
-			if !l.Allow() {
-				logger := slog.Default()
-				logger.WarnContext(r.Context(), "Rate limit exceeded", "ip_address", ip, "request_id", httputil.GetReqID(r.Context()))
+// clientIP returns the client IP. chimiddleware.RealIP must run earlier in the
+// chain so that RemoteAddr already reflects X-Real-IP / X-Forwarded-For.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
 
-				// Use specific rate limit error message
-				err := fmt.Errorf("%w: rate limit exceeded", domain.ErrPermissionDenied)
-				httputil.RespondError(w, r, err) // httputil maps this to 429
-				return
-			}
+// routePattern resolves the chi route pattern for the request without executing it.
+// Falls back to the raw path when the router cannot be consulted.
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return r.URL.Path
+	}
+	path := r.URL.Path
+	if pattern := rctx.Routes.Find(chi.NewRouteContext(), r.Method, path); pattern != "" {
+		return pattern
+	}
+	if trimmed := normalizePattern(path); trimmed != path {
+		if pattern := rctx.Routes.Find(chi.NewRouteContext(), r.Method, trimmed); pattern != "" {
+			return pattern
+		}
+	}
+	return path
+}
+
+// Handler is the rate limiting middleware.
+func (rl *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := rl.policyFor(r.Method, routePattern(r))
+		client := rl.clientKey(r, p)
 
+		result, err := rl.allow(r.Context(), p.name+"|"+client, p)
+		if err != nil {
+			// Fail open: a slow or unavailable store must not take the API down.
+			rl.logger.WarnContext(r.Context(), "Rate limit check failed, allowing request",
+				"error", err, "policy", p.name, "client", client, "request_id", httputil.GetReqID(r.Context()))
 			next.ServeHTTP(w, r)
-		})
+			return
+		}
+		setRateLimitHeaders(w, p, result)
+
+		if !result.Allowed {
+			retryAfter := ceilSeconds(result.RetryAfter)
+			w.Header().Set(HeaderRetryAfter, strconv.Itoa(retryAfter))
+			rl.logger.WarnContext(r.Context(), "Rate limit exceeded",
+				"policy", p.name, "client", client, "request_id", httputil.GetReqID(r.Context()))
+
+			err := fmt.Errorf("%w: retry in %ds", domain.ErrRateLimited, retryAfter)
+			httputil.RespondError(w, r, err) // httputil maps this to 429
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (rl *RateLimiter) allow(ctx context.Context, key string, p ratePolicy) (port.RateLimitResult, error) {
+	if rl.storeTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rl.storeTimeout)
+		defer cancel()
 	}
+	return rl.store.Allow(ctx, key, p.policy)
 }
 
-// Point 7: Implemented CleanUpOldLimiters logic
-func (i *IPRateLimiter) CleanUpOldLimiters(interval time.Duration, maxAge time.Duration) {
-	ticker := time.NewTicker(interval)
+// setRateLimitHeaders writes RateLimit-* headers for the current bucket state.
+// Reset is the number of seconds until the bucket is full again.
+func setRateLimitHeaders(w http.ResponseWriter, p ratePolicy, result port.RateLimitResult) {
+	w.Header().Set(HeaderRateLimitLimit, strconv.Itoa(p.policy.Burst))
+	w.Header().Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+	w.Header().Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(result.ResetAfter)))
+}
+
+// ceilSeconds rounds a duration up to whole seconds, as required by the headers.
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
+
+// CleanUpOldLimiters periodically evicts buckets that have been idle longer than
+// the configured maxIdle. It blocks until ctx is cancelled.
+func (rl *RateLimiter) CleanUpOldLimiters(ctx context.Context) {
+	if rl.cleanupInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(rl.cleanupInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		i.mu.Lock()
-		count := 0
-		now := time.Now()
-		for ip, entry := range i.ips {
-			if now.Sub(entry.lastSeen) > maxAge {
-				delete(i.ips, ip)
-				count++
+	for {
+		select {
+		case <-ctx.Done():
+			rl.logger.Debug("Rate limiter cleanup stopped")
+			return
+		case <-ticker.C:
+			count, err := rl.store.CleanUp(ctx, rl.maxIdle)
+			if err != nil {
+				rl.logger.WarnContext(ctx, "Rate limiter cleanup failed", "error", err)
+				continue
+			}
+			if count > 0 {
+				rl.logger.Debug("Rate limiter cleanup removed entries", "removed_count", count)
 			}
 		}
-		if count > 0 {
-			slog.Debug("Rate limiter cleanup removed entries", "removed_count", count, "current_size", len(i.ips))
-		}
-		i.mu.Unlock()
 	}
 }
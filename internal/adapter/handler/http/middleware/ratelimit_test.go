@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// fakeRateLimitStore allows policy.Burst requests per key and then refuses them.
+type fakeRateLimitStore struct {
+	mu     sync.Mutex
+	counts map[string]int
+	err    error
+}
+
+func newFakeRateLimitStore() *fakeRateLimitStore {
+	return &fakeRateLimitStore{counts: make(map[string]int)}
+}
+
+func (f *fakeRateLimitStore) Allow(_ context.Context, key string, policy port.RateLimitPolicy) (port.RateLimitResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return port.RateLimitResult{}, f.err
+	}
+	f.counts[key]++
+	used := f.counts[key]
+	if used > policy.Burst {
+		return port.RateLimitResult{Allowed: false, ResetAfter: 3 * time.Second, RetryAfter: 1500 * time.Millisecond}, nil
+	}
+	return port.RateLimitResult{Allowed: true, Remaining: policy.Burst - used, ResetAfter: 1200 * time.Millisecond}, nil
+}
+
+func (f *fakeRateLimitStore) CleanUp(context.Context, time.Duration) (int, error) { return 0, nil }
+
+func (f *fakeRateLimitStore) keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.counts))
+	for k := range f.counts {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// fakeTokenVerifier accepts the single token "valid" as userID.
+type fakeTokenVerifier struct {
+	port.SecurityHelper
+	userID domain.UserID
+}
+
+func (f fakeTokenVerifier) VerifyJWT(_ context.Context, token string) (domain.UserID, error) {
+	if token != "valid" {
+		return domain.UserID{}, errors.New("invalid token")
+	}
+	return f.userID, nil
+}
+
+func testRateLimitConfig() config.RateLimitConfig {
+	return config.RateLimitConfig{
+		Enabled: true,
+		Default: config.RateLimitPolicy{RPS: 10, Burst: 10, KeyBy: config.RateLimitKeyByIP},
+		Routes: []config.RouteRateLimitPolicy{
+			{Method: "POST", Pattern: "/api/v1/auth/login", RateLimitPolicy: config.RateLimitPolicy{RPS: 1, Burst: 2, KeyBy: config.RateLimitKeyByIP}},
+			{Pattern: "/api/v1/audio/*", RateLimitPolicy: config.RateLimitPolicy{RPS: 5, Burst: 5, KeyBy: config.RateLimitKeyByUser}},
+			{Method: "get", Pattern: "/api/v1/audio/tracks/{trackId}/", RateLimitPolicy: config.RateLimitPolicy{RPS: 7, Burst: 7, KeyBy: config.RateLimitKeyByUser}},
+		},
+	}
+}
+
+func newTestRateLimiter(store port.RateLimitStore, secHelper port.SecurityHelper) *RateLimiter {
+	return NewRateLimiter(testRateLimitConfig(), store, secHelper, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestRateLimiter_PolicyFor(t *testing.T) {
+	rl := newTestRateLimiter(newFakeRateLimitStore(), nil)
+
+	tests := []struct {
+		method, pattern, want string
+	}{
+		{"POST", "/api/v1/auth/login", "POST /api/v1/auth/login"},
+		{"POST", "/api/v1/auth/login/", "POST /api/v1/auth/login"}, // Trailing slash
+		{"GET", "/api/v1/auth/login", "default"},                   // Method must match
+		{"GET", "/api/v1/audio/tracks/{trackId}", "GET /api/v1/audio/tracks/{trackId}"},
+		{"DELETE", "/api/v1/audio/tracks/{trackId}", "/api/v1/audio/*"}, // Falls back to the prefix policy
+		{"GET", "/api/v1/audio/collections/{collectionId}/tracks", "/api/v1/audio/*"},
+		{"GET", "/api/v1/audio", "/api/v1/audio/*"},
+		{"GET", "/api/v1/audiobooks", "default"}, // Prefixes match whole segments only
+		{"GET", "/api/v1/users/me", "default"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, rl.policyFor(tt.method, tt.pattern).name, "%s %s", tt.method, tt.pattern)
+	}
+}
+
+func TestMatchPolicy(t *testing.T) {
+	exact := ratePolicy{name: "exact", method: "GET"}
+	anyMethod := ratePolicy{name: "any"}
+	prefix := ratePolicy{name: "prefix", prefix: true}
+
+	p, ok := matchPolicy([]ratePolicy{exact, anyMethod}, "GET", false)
+	require.True(t, ok)
+	assert.Equal(t, "exact", p.name)
+
+	p, ok = matchPolicy([]ratePolicy{exact, anyMethod}, "POST", false)
+	require.True(t, ok)
+	assert.Equal(t, "any", p.name, "An empty method matches any method")
+
+	_, ok = matchPolicy([]ratePolicy{exact, anyMethod}, "GET", true)
+	assert.False(t, ok, "Only prefix policies apply to sub-routes")
+
+	p, ok = matchPolicy([]ratePolicy{exact, prefix}, "GET", true)
+	require.True(t, ok)
+	assert.Equal(t, "prefix", p.name)
+
+	_, ok = matchPolicy(nil, "GET", false)
+	assert.False(t, ok)
+}
+
+func TestRateLimiter_ClientKey(t *testing.T) {
+	userID := domain.NewUserID()
+	rl := newTestRateLimiter(newFakeRateLimitStore(), fakeTokenVerifier{userID: userID})
+	byUser := rl.policyFor("GET", "/api/v1/audio/tracks/{trackId}")
+	byIP := rl.policyFor("POST", "/api/v1/auth/login")
+
+	newRequest := func(authorization string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/audio/tracks/1", nil)
+		req.RemoteAddr = "203.0.113.7:51234"
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return req
+	}
+
+	assert.Equal(t, "user:"+userID.String(), rl.clientKey(newRequest("Bearer valid"), byUser))
+	assert.Equal(t, "user:"+userID.String(), rl.clientKey(newRequest("bearer  valid "), byUser), "Scheme is case-insensitive")
+	assert.Equal(t, "ip:203.0.113.7", rl.clientKey(newRequest("Bearer forged"), byUser), "Invalid tokens fall back to the IP")
+	assert.Equal(t, "ip:203.0.113.7", rl.clientKey(newRequest("Basic dXNlcjpwYXNz"), byUser))
+	assert.Equal(t, "ip:203.0.113.7", rl.clientKey(newRequest(""), byUser))
+	assert.Equal(t, "ip:203.0.113.7", rl.clientKey(newRequest("Bearer valid"), byIP), "IP policies ignore the token")
+
+	// A user already authenticated earlier in the chain is used as is
+	authenticated := newRequest("")
+	authenticated = authenticated.WithContext(context.WithValue(authenticated.Context(), UserIDKey, userID))
+	assert.Equal(t, "user:"+userID.String(), rl.clientKey(authenticated, byUser))
+
+	// Without a SecurityHelper every request is keyed by IP
+	assert.Equal(t, "ip:203.0.113.7", newTestRateLimiter(newFakeRateLimitStore(), nil).clientKey(newRequest("Bearer valid"), byUser))
+}
+
+func TestRateLimiter_Handler(t *testing.T) {
+	store := newFakeRateLimitStore()
+	rl := newTestRateLimiter(store, nil)
+	router := chi.NewRouter()
+	router.Use(rl.Handler)
+	router.Post("/api/v1/auth/login", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	router.Get("/api/v1/audio/tracks/{trackId}", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	send := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "203.0.113.7:51234"
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := send(http.MethodPost, "/api/v1/auth/login")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "1", rr.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "2", rr.Header().Get(HeaderRateLimitReset), "Reset is rounded up to whole seconds")
+	assert.Empty(t, rr.Header().Get(HeaderRetryAfter))
+
+	send(http.MethodPost, "/api/v1/auth/login")
+	rr = send(http.MethodPost, "/api/v1/auth/login")
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get(HeaderRetryAfter))
+	assert.Equal(t, "0", rr.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "3", rr.Header().Get(HeaderRateLimitReset))
+
+	// Buckets are per route pattern, not per raw path
+	send(http.MethodGet, "/api/v1/audio/tracks/a")
+	send(http.MethodGet, "/api/v1/audio/tracks/b")
+	assert.ElementsMatch(t, []string{
+		"POST /api/v1/auth/login|ip:203.0.113.7",
+		"GET /api/v1/audio/tracks/{trackId}|ip:203.0.113.7",
+	}, store.keys())
+}
+
+func TestRateLimiter_FailsOpen(t *testing.T) {
+	store := newFakeRateLimitStore()
+	store.err = errors.New("store unavailable")
+	calls := 0
+	handler := newTestRateLimiter(store, nil).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i := 0; i < 5; i++ {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get(HeaderRateLimitLimit), "No bucket state to report")
+	}
+	assert.Equal(t, 5, calls)
+}
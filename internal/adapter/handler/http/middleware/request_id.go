@@ -35,3 +35,13 @@ func RequestID(next http.Handler) http.Handler {
 func GetReqID(ctx context.Context) string {
 	return httputil.GetReqID(ctx)
 }
+
+// ClientIP stores the caller's IP address in the request context so that
+// lower layers (e.g., the audit log) can record it. chimiddleware.RealIP
+// must run earlier in the chain so that RemoteAddr reflects proxy headers.
+func ClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), httputil.ClientIPKey, clientIP(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
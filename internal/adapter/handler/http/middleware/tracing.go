@@ -0,0 +1,48 @@
+// internal/adapter/handler/http/middleware/tracing.go
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing starts a server span per request, continuing any W3C trace context
+// sent by the caller. It must run after RequestID so the ID can be attached.
+func Tracing(tp trace.TracerProvider, propagator propagation.TextMapPropagator) func(next http.Handler) http.Handler {
+	tracer := tp.Tracer("github.com/yvanyang/language-learning-player-api/http")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, "HTTP "+r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.URLPath(r.URL.Path),
+					attribute.String("http.request_id", GetReqID(r.Context())),
+				),
+			)
+			defer span.End()
+
+			wrappedWriter := NewResponseWriterWrapper(w)
+			next.ServeHTTP(wrappedWriter, r.WithContext(ctx))
+
+			// Name the span after the route pattern once routing has finished.
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					span.SetName(r.Method + " " + pattern)
+					span.SetAttributes(semconv.HTTPRoute(pattern))
+				}
+			}
+			span.SetAttributes(semconv.HTTPResponseStatusCode(wrappedWriter.statusCode))
+			if wrappedWriter.statusCode >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(wrappedWriter.statusCode))
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+// internal/adapter/handler/http/tus_handler.go
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/middleware"
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/apierrors"
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+	"github.com/yvanyang/language-learning-player-api/pkg/validation"
+)
+
+const (
+	tusVersion     = "1.0.0"
+	tusExtensions  = "creation,expiration,termination"
+	tusContentType = "application/offset+octet-stream"
+
+	HeaderTusResumable   = "Tus-Resumable"
+	HeaderUploadLength   = "Upload-Length"
+	HeaderUploadOffset   = "Upload-Offset"
+	HeaderUploadMetadata = "Upload-Metadata"
+	HeaderUploadExpires  = "Upload-Expires"
+	// HeaderTrackID carries the ID of the track created once an upload is complete.
+	HeaderTrackID = "X-Track-ID"
+)
+
+// TusRequestHeaders lists the request headers tus clients need allowed via CORS.
+var TusRequestHeaders = []string{HeaderTusResumable, HeaderUploadLength, HeaderUploadOffset, HeaderUploadMetadata, "Upload-Defer-Length"}
+
+// TusResponseHeaders lists the response headers tus clients need exposed via CORS.
+var TusResponseHeaders = []string{
+	"Location", HeaderTusResumable, "Tus-Version", "Tus-Extension", "Tus-Max-Size",
+	HeaderUploadLength, HeaderUploadOffset, HeaderUploadMetadata, HeaderUploadExpires, HeaderTrackID,
+}
+
+// TusHandler serves resumable audio uploads with the tus 1.0 protocol
+// (core, creation, expiration and termination; see https://tus.io/protocols/resumable-upload).
+// The Upload-Metadata of a new upload carries the file name and type (filename, filetype) and the
+// same track fields as a direct upload (title, languageCode, durationMs, ...). Tags are comma-separated.
+type TusHandler struct {
+	uploadUseCase port.ResumableUploadUseCase
+	cfg           config.UploadConfig
+	validator     *validation.Validator
+}
+
+// NewTusHandler creates a new TusHandler.
+func NewTusHandler(uc port.ResumableUploadUseCase, cfg config.UploadConfig, v *validation.Validator) *TusHandler {
+	return &TusHandler{
+		uploadUseCase: uc,
+		cfg:           cfg,
+		validator:     v,
+	}
+}
+
+// Options handles OPTIONS /api/v1/uploads/tus
+// @Summary Describe the tus server
+// @Description Returns the supported tus version, extensions and maximum upload size in the Tus-* headers.
+// @ID tus-options
+// @Tags Uploads
+// @Success 204 "Server capabilities in Tus-Version, Tus-Extension and Tus-Max-Size"
+// @Router /uploads/tus [options]
+func (h *TusHandler) Options(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(HeaderTusResumable, tusVersion)
+	w.Header().Set("Tus-Version", tusVersion)
+	w.Header().Set("Tus-Extension", tusExtensions)
+	w.Header().Set("Tus-Max-Size", strconv.FormatInt(h.cfg.MaxFileSize, 10))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateUpload handles POST /api/v1/uploads/tus
+// @Summary Create a resumable upload
+// @Description Creates an upload of Upload-Length bytes (tus creation extension). The Location header is the URL to send the chunks to.
+// @Description Upload-Metadata must contain filename, title, languageCode and durationMs; the track is created once all bytes have been received.
+// @ID tus-create-upload
+// @Tags Uploads
+// @Security BearerAuth
+// @Param Tus-Resumable header string true "Protocol version (1.0.0)"
+// @Param Upload-Length header integer true "Size of the file in bytes"
+// @Param Upload-Metadata header string true "Comma-separated key and base64 value pairs"
+// @Success 201 "Upload created; its URL is in Location and its expiry in Upload-Expires"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid length or metadata"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 412 {object} httputil.ErrorResponseDTO "Unsupported tus version"
+// @Router /uploads/tus [post]
+func (h *TusHandler) CreateUpload(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	if r.Header.Get("Upload-Defer-Length") != "" {
+		httputil.RespondError(w, r, fmt.Errorf("%w: Upload-Defer-Length is not supported", domain.ErrInvalidArgument))
+		return
+	}
+	length, err := strconv.ParseInt(r.Header.Get(HeaderUploadLength), 10, 64)
+	if err != nil || length < 0 {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid or missing Upload-Length header", domain.ErrInvalidArgument))
+		return
+	}
+
+	metadata := r.Header.Get(HeaderUploadMetadata)
+	fields, err := parseTusMetadata(metadata)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	form, err := parseDirectUploadForm(fields)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	if err := h.validator.ValidateStruct(form); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+
+	input := port.CreateResumableUploadInput{
+		Length:      length,
+		Filename:    firstNonEmpty(fields.Get("filename"), fields.Get("name")),
+		ContentType: firstNonEmpty(fields.Get("filetype"), fields.Get("type")),
+		Metadata:    metadata,
+		Track: port.CompleteUploadInput{
+			Title:         form.Title,
+			Description:   form.Description,
+			LanguageCode:  form.LanguageCode,
+			Level:         form.Level,
+			Duration:      time.Duration(form.DurationMs) * time.Millisecond,
+			IsPublic:      form.IsPublic,
+			Tags:          form.Tags,
+			CoverImageURL: form.CoverImageURL,
+		},
+	}
+	upload, err := h.uploadUseCase.CreateUpload(r.Context(), userID, input)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", strings.TrimSuffix(r.URL.Path, "/")+"/"+upload.ID.String())
+	w.Header().Set(HeaderUploadExpires, upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// GetUploadOffset handles HEAD /api/v1/uploads/tus/{uploadId}
+// @Summary Get the offset of a resumable upload
+// @Description Returns how many bytes have been received in Upload-Offset. Once the upload is complete, X-Track-ID holds the created track.
+// @ID tus-get-upload-offset
+// @Tags Uploads
+// @Security BearerAuth
+// @Param uploadId path string true "Upload ID" format(uuid)
+// @Param Tus-Resumable header string true "Protocol version (1.0.0)"
+// @Success 200 "Upload-Offset, Upload-Length, Upload-Metadata and Upload-Expires headers"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Upload not found or expired"
+// @Router /uploads/tus/{uploadId} [head]
+func (h *TusHandler) GetUploadOffset(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	uploadID, ok := parseUploadID(w, r)
+	if !ok {
+		return
+	}
+	upload, err := h.uploadUseCase.GetUpload(r.Context(), userID, uploadID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set(HeaderUploadLength, strconv.FormatInt(upload.Length, 10))
+	if upload.Metadata != "" {
+		w.Header().Set(HeaderUploadMetadata, upload.Metadata)
+	}
+	setUploadProgressHeaders(w, upload)
+	w.WriteHeader(http.StatusOK)
+}
+
+// WriteChunk handles PATCH /api/v1/uploads/tus/{uploadId}
+// @Summary Upload a chunk of a resumable upload
+// @Description Appends the request body at Upload-Offset, which must be the current offset of the upload. Bytes received before a connection
+// @Description is lost are kept; query the offset and resume from there. The chunk completing the upload creates the track (X-Track-ID).
+// @ID tus-write-chunk
+// @Tags Uploads
+// @Accept application/offset+octet-stream
+// @Security BearerAuth
+// @Param uploadId path string true "Upload ID" format(uuid)
+// @Param Tus-Resumable header string true "Protocol version (1.0.0)"
+// @Param Upload-Offset header integer true "Offset of the chunk"
+// @Success 204 "Chunk stored; the new offset is in Upload-Offset"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid offset or chunk too long"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Upload not found or expired"
+// @Failure 409 {object} httputil.ErrorResponseDTO "Offset does not match, or another request is writing to the upload"
+// @Failure 415 {object} httputil.ErrorResponseDTO "Content-Type is not application/offset+octet-stream"
+// @Router /uploads/tus/{uploadId} [patch]
+func (h *TusHandler) WriteChunk(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	uploadID, ok := parseUploadID(w, r)
+	if !ok {
+		return
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != tusContentType {
+		respondTusError(w, r, http.StatusUnsupportedMediaType, "Content-Type must be "+tusContentType)
+		return
+	}
+	offset, err := strconv.ParseInt(r.Header.Get(HeaderUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid or missing Upload-Offset header", domain.ErrInvalidArgument))
+		return
+	}
+
+	// A chunk may take much longer to receive than server.readTimeout allows.
+	rc := http.NewResponseController(w)
+	deadline := time.Now().Add(h.cfg.Timeout)
+	if err := errors.Join(rc.SetReadDeadline(deadline), rc.SetWriteDeadline(deadline)); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("extending request deadline: %w", err))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, h.cfg.MaxFileSize)
+	defer r.Body.Close()
+
+	// Detached from the request timeout middleware; the deadline above bounds the chunk.
+	upload, err := h.uploadUseCase.WriteChunk(context.WithoutCancel(r.Context()), userID, uploadID, offset, r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = fmt.Errorf("%w: the chunk exceeds the maximum size of %d bytes", domain.ErrInvalidArgument, h.cfg.MaxFileSize)
+		}
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	setUploadProgressHeaders(w, upload)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TerminateUpload handles DELETE /api/v1/uploads/tus/{uploadId}
+// @Summary Terminate a resumable upload
+// @Description Deletes the upload and the bytes received so far (tus termination extension). The track of a completed upload is kept.
+// @ID tus-terminate-upload
+// @Tags Uploads
+// @Security BearerAuth
+// @Param uploadId path string true "Upload ID" format(uuid)
+// @Param Tus-Resumable header string true "Protocol version (1.0.0)"
+// @Success 204 "Upload terminated"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Upload not found or expired"
+// @Failure 409 {object} httputil.ErrorResponseDTO "Another request is writing to the upload"
+// @Router /uploads/tus/{uploadId} [delete]
+func (h *TusHandler) TerminateUpload(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	uploadID, ok := parseUploadID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.uploadUseCase.TerminateUpload(r.Context(), userID, uploadID); err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// begin sets the Tus-Resumable response header, checks the protocol version of the request
+// and returns the authenticated user.
+func (h *TusHandler) begin(w http.ResponseWriter, r *http.Request) (domain.UserID, bool) {
+	w.Header().Set(HeaderTusResumable, tusVersion)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return domain.UserID{}, false
+	}
+	if r.Header.Get(HeaderTusResumable) != tusVersion {
+		w.Header().Set("Tus-Version", tusVersion)
+		respondTusError(w, r, http.StatusPreconditionFailed, "Unsupported tus version; send Tus-Resumable: "+tusVersion)
+		return domain.UserID{}, false
+	}
+	return userID, true
+}
+
+func parseUploadID(w http.ResponseWriter, r *http.Request) (domain.ResumableUploadID, bool) {
+	uploadID, err := domain.ResumableUploadIDFromString(chi.URLParam(r, "uploadId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid upload ID format", domain.ErrInvalidArgument))
+		return domain.ResumableUploadID{}, false
+	}
+	return uploadID, true
+}
+
+func setUploadProgressHeaders(w http.ResponseWriter, upload *domain.ResumableUpload) {
+	w.Header().Set(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+	w.Header().Set(HeaderUploadExpires, upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	if upload.TrackID != nil {
+		w.Header().Set(HeaderTrackID, upload.TrackID.String())
+	}
+}
+
+// respondTusError reports a protocol error that has no domain error equivalent.
+func respondTusError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	httputil.RespondJSON(w, r, status, httputil.ErrorResponseDTO{
+		Code:      apierrors.CodeInvalidInput,
+		Message:   message,
+		RequestID: httputil.GetReqID(r.Context()),
+	})
+}
+
+// parseTusMetadata decodes an Upload-Metadata header: comma-separated pairs of a key and a
+// base64-encoded value. The value of "tags" is a comma-separated list.
+func parseTusMetadata(header string) (url.Values, error) {
+	fields := url.Values{}
+	if strings.TrimSpace(header) == "" {
+		return fields, nil
+	}
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, fmt.Errorf("%w: invalid Upload-Metadata header", domain.ErrInvalidArgument)
+		}
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid Upload-Metadata value for %q", domain.ErrInvalidArgument, key)
+		}
+		if key == "tags" {
+			for _, tag := range strings.Split(string(value), ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					fields.Add(key, tag)
+				}
+			}
+			continue
+		}
+		fields.Set(key, string(value))
+	}
+	return fields, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -2,30 +2,43 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	// Assuming module path is updated
 	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/dto"
 	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/middleware"
+	"github.com/yvanyang/language-learning-player-api/internal/config"
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
 	"github.com/yvanyang/language-learning-player-api/internal/port" // Import port package
 	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
 	"github.com/yvanyang/language-learning-player-api/pkg/validation"
 )
 
+// maxFormFieldSize bounds each metadata field of a direct upload.
+const maxFormFieldSize = 64 << 10
+
 // UploadHandler handles HTTP requests related to file uploads.
 type UploadHandler struct {
 	uploadUseCase port.UploadUseCase // Use interface from port package
+	cfg           config.UploadConfig
 	validator     *validation.Validator
 }
 
 // NewUploadHandler creates a new UploadHandler.
-func NewUploadHandler(uc port.UploadUseCase, v *validation.Validator) *UploadHandler {
+func NewUploadHandler(uc port.UploadUseCase, cfg config.UploadConfig, v *validation.Validator) *UploadHandler {
 	return &UploadHandler{
 		uploadUseCase: uc,
+		cfg:           cfg,
 		validator:     v,
 	}
 }
@@ -33,13 +46,15 @@ func NewUploadHandler(uc port.UploadUseCase, v *validation.Validator) *UploadHan
 // RequestUpload handles POST /api/v1/uploads/audio/request
 // @Summary Request presigned URL for audio upload
 // @Description Requests a presigned URL from the object storage (MinIO/S3) that can be used by the client to directly upload an audio file.
+// @Description If `contentSha256` is given and a track you own or a public track already has that content, the response contains
+// @Description `existingTrack` and no upload URL; use that track instead of uploading the file again.
 // @ID request-audio-upload
 // @Tags Uploads
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param uploadRequest body dto.RequestUploadRequestDTO true "Upload Request Info (filename, content type)"
-// @Success 200 {object} dto.RequestUploadResponseDTO "Presigned URL and object key generated"
+// @Param uploadRequest body dto.RequestUploadRequestDTO true "Upload Request Info (filename, content type, optional content hash)"
+// @Success 200 {object} dto.RequestUploadResponseDTO "Presigned URL and object key generated, or the existing track with the same content"
 // @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input"
 // @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
 // @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error (e.g., failed to generate URL)"
@@ -64,7 +79,11 @@ func (h *UploadHandler) RequestUpload(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Call use case - returns port.RequestUploadResult
-	result, err := h.uploadUseCase.RequestUpload(r.Context(), userID, req.Filename, req.ContentType)
+	result, err := h.uploadUseCase.RequestUpload(r.Context(), userID, port.RequestUploadInput{
+		Filename:      req.Filename,
+		ContentType:   req.ContentType,
+		ContentSHA256: req.ContentSHA256,
+	})
 	if err != nil {
 		httputil.RespondError(w, r, err)
 		return
@@ -75,6 +94,10 @@ func (h *UploadHandler) RequestUpload(w http.ResponseWriter, r *http.Request) {
 		UploadURL: result.UploadURL,
 		ObjectKey: result.ObjectKey,
 	}
+	if result.ExistingTrack != nil {
+		existing := dto.MapDomainTrackToResponseDTO(result.ExistingTrack)
+		resp.ExistingTrack = &existing
+	}
 	httputil.RespondJSON(w, r, http.StatusOK, resp)
 }
 
@@ -87,6 +110,7 @@ func (h *UploadHandler) RequestUpload(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Security BearerAuth
 // @Param completeUpload body dto.CompleteUploadInputDTO true "Track metadata and object key"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the original response"
 // @Success 201 {object} dto.AudioTrackResponseDTO "Track metadata created successfully"
 // @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input (e.g., validation errors, object key not found, file not in storage)"
 // @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
@@ -138,11 +162,149 @@ func (h *UploadHandler) CompleteUploadAndCreateTrack(w http.ResponseWriter, r *h
 	httputil.RespondJSON(w, r, http.StatusCreated, resp)
 }
 
+// UploadDirect handles POST /api/v1/uploads/audio/direct
+// @Summary Upload an audio file through the API
+// @Description Streams an audio file through the API to storage and creates its track, for clients that cannot reach the storage
+// @Description service with a presigned URL. The metadata fields must come before the `file` part in the multipart body.
+// @ID upload-audio-direct
+// @Tags Uploads
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param title formData string true "Track title"
+// @Param description formData string false "Track description"
+// @Param languageCode formData string true "Language code (e.g., en-US)"
+// @Param level formData string false "Audio level" Enums(A1, A2, B1, B2, C1, C2, NATIVE)
+// @Param durationMs formData integer true "Duration in milliseconds"
+// @Param isPublic formData bool false "Whether the track is public" default(false)
+// @Param tags formData []string false "Tags (repeat the field for several)" collectionFormat(multi)
+// @Param coverImageUrl formData string false "Cover image URL"
+// @Param file formData file true "Audio file (sent last)"
+// @Success 201 {object} dto.AudioTrackResponseDTO "Track created"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input (e.g., validation errors, unsupported content type, file too large)"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /uploads/audio/direct [post]
+func (h *UploadHandler) UploadDirect(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+
+	// The file may take much longer to receive than server.readTimeout allows.
+	rc := http.NewResponseController(w)
+	deadline := time.Now().Add(h.cfg.Timeout)
+	if err := errors.Join(rc.SetReadDeadline(deadline), rc.SetWriteDeadline(deadline)); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("extending request deadline: %w", err))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, h.cfg.MaxFileSize+multipartOverhead)
+	defer r.Body.Close()
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: expected a multipart/form-data request", domain.ErrInvalidArgument))
+		return
+	}
+	fields := url.Values{}
+	var file *multipart.Part
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			httputil.RespondError(w, r, fmt.Errorf("%w: missing form field \"file\"", domain.ErrInvalidArgument))
+			return
+		}
+		if err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: invalid multipart body", domain.ErrInvalidArgument))
+			return
+		}
+		if part.FormName() == "file" {
+			file = part
+			break
+		}
+		value, err := io.ReadAll(io.LimitReader(part, maxFormFieldSize+1))
+		if err != nil || len(value) > maxFormFieldSize {
+			httputil.RespondError(w, r, fmt.Errorf("%w: invalid form field %q", domain.ErrInvalidArgument, part.FormName()))
+			return
+		}
+		fields.Add(part.FormName(), string(value))
+	}
+
+	form, err := parseDirectUploadForm(fields)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	if err := h.validator.ValidateStruct(form); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+
+	input := port.DirectUploadInput{
+		File:        file,
+		Size:        -1,
+		Filename:    file.FileName(),
+		ContentType: file.Header.Get("Content-Type"),
+		Track: port.CompleteUploadInput{
+			Title:         form.Title,
+			Description:   form.Description,
+			LanguageCode:  form.LanguageCode,
+			Level:         form.Level,
+			Duration:      time.Duration(form.DurationMs) * time.Millisecond,
+			IsPublic:      form.IsPublic,
+			Tags:          form.Tags,
+			CoverImageURL: form.CoverImageURL,
+		},
+	}
+	// Detached from the request timeout middleware; the deadline above bounds the upload.
+	track, err := h.uploadUseCase.UploadDirect(context.WithoutCancel(r.Context()), userID, input)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = fmt.Errorf("%w: the file exceeds the maximum size of %d bytes", domain.ErrInvalidArgument, h.cfg.MaxFileSize)
+		}
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusCreated, dto.MapDomainTrackToResponseDTO(track))
+}
+
+// parseDirectUploadForm converts the form fields of a direct upload.
+func parseDirectUploadForm(fields url.Values) (dto.DirectUploadFormDTO, error) {
+	form := dto.DirectUploadFormDTO{
+		Title:        fields.Get("title"),
+		Description:  fields.Get("description"),
+		LanguageCode: fields.Get("languageCode"),
+		Level:        fields.Get("level"),
+		Tags:         fields["tags"],
+	}
+	if v := fields.Get("durationMs"); v != "" {
+		durationMs, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return form, fmt.Errorf("%w: invalid durationMs", domain.ErrInvalidArgument)
+		}
+		form.DurationMs = durationMs
+	}
+	if v := fields.Get("isPublic"); v != "" {
+		isPublic, err := strconv.ParseBool(v)
+		if err != nil {
+			return form, fmt.Errorf("%w: invalid isPublic", domain.ErrInvalidArgument)
+		}
+		form.IsPublic = isPublic
+	}
+	if v := fields.Get("coverImageUrl"); v != "" {
+		form.CoverImageURL = &v
+	}
+	return form, nil
+}
+
 // --- Batch Upload Handlers ---
 
 // RequestBatchUpload handles POST /api/v1/uploads/audio/batch/request
 // @Summary Request presigned URLs for batch audio upload
 // @Description Requests multiple presigned URLs for uploading several audio files in parallel.
+// @Description Items with a `contentSha256` matching a track you own or a public track get `existingTrackId` instead of an upload URL.
 // @ID request-batch-audio-upload
 // @Tags Uploads
 // @Accept json
@@ -177,8 +339,9 @@ func (h *UploadHandler) RequestBatchUpload(w http.ResponseWriter, r *http.Reques
 	portReq := port.BatchRequestUploadInput{Files: make([]port.BatchRequestUploadInputItem, len(req.Files))}
 	for i, f := range req.Files {
 		portReq.Files[i] = port.BatchRequestUploadInputItem{
-			Filename:    f.Filename,
-			ContentType: f.ContentType,
+			Filename:      f.Filename,
+			ContentType:   f.ContentType,
+			ContentSHA256: f.ContentSHA256,
 		}
 	}
 
@@ -196,6 +359,7 @@ func (h *UploadHandler) RequestBatchUpload(w http.ResponseWriter, r *http.Reques
 			OriginalFilename: res.OriginalFilename,
 			ObjectKey:        res.ObjectKey,
 			UploadURL:        res.UploadURL,
+			ExistingTrackID:  res.ExistingTrackID,
 			Error:            res.Error,
 		}
 	}
@@ -212,6 +376,7 @@ func (h *UploadHandler) RequestBatchUpload(w http.ResponseWriter, r *http.Reques
 // @Produce json
 // @Security BearerAuth
 // @Param batchCompleteUpload body dto.BatchCompleteUploadInputDTO true "List of track metadata and object keys for uploaded files"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the original response"
 // @Success 201 {object} dto.BatchCompleteUploadResponseDTO "Batch processing attempted. Results indicate success/failure per item. If overall transaction succeeded, status is 201."
 // @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input (e.g., validation errors in items, files not in storage)"
 // @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
@@ -279,3 +444,83 @@ func (h *UploadHandler) CompleteBatchUploadAndCreateTracks(w http.ResponseWriter
 	// Pre-checks passed and transaction committed (even if some DB inserts failed within the rolled-back tx)
 	httputil.RespondJSON(w, r, http.StatusCreated, resp) // Use 201 Created
 }
+
+// --- Duplicate Handlers ---
+
+// ListDuplicates handles GET /api/v1/uploads/duplicates
+// @Summary List possible duplicate uploads
+// @Description Lists groups of tracks whose audio files have the same content (SHA-256), most recent first.
+// @Description Only groups containing one of your tracks are listed, with your own and public tracks in them.
+// @Description Checksums are computed shortly after each upload, so a new track may not be listed yet.
+// @ID list-duplicate-uploads
+// @Tags Uploads
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Pagination limit" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Pagination offset" default(0) minimum(0)
+// @Success 200 {object} dto.PaginatedResponseDTO{data=[]dto.DuplicateTrackGroupResponseDTO} "Paginated list of duplicate groups"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Query Parameter Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /uploads/duplicates [get]
+func (h *UploadHandler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	h.listDuplicates(w, r, false)
+}
+
+// ListAllDuplicates handles GET /api/v1/admin/uploads/duplicates
+// @Summary List all possible duplicate uploads
+// @Description Lists groups of tracks whose audio files have the same content (SHA-256) across all uploaders, most recent first. Requires the admin role.
+// @ID list-all-duplicate-uploads
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Pagination limit" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Pagination offset" default(0) minimum(0)
+// @Success 200 {object} dto.PaginatedResponseDTO{data=[]dto.DuplicateTrackGroupResponseDTO} "Paginated list of duplicate groups"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Query Parameter Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/uploads/duplicates [get]
+func (h *UploadHandler) ListAllDuplicates(w http.ResponseWriter, r *http.Request) {
+	h.listDuplicates(w, r, true)
+}
+
+func (h *UploadHandler) listDuplicates(w http.ResponseWriter, r *http.Request, allUploaders bool) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+	page, err := parsePage(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	groups, total, actualPageInfo, err := h.uploadUseCase.ListDuplicates(r.Context(), port.ListDuplicatesInput{
+		UserID:       userID,
+		AllUploaders: allUploaders,
+		Page:         page,
+	})
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	respData := make([]dto.DuplicateTrackGroupResponseDTO, len(groups))
+	for i, group := range groups {
+		respData[i] = dto.MapDuplicateTrackGroupToResponseDTO(group)
+	}
+
+	paginatedResult := pagination.NewPaginatedResponse(respData, total, actualPageInfo)
+	resp := dto.PaginatedResponseDTO{
+		Data:       paginatedResult.Data,
+		Total:      paginatedResult.Total,
+		Limit:      paginatedResult.Limit,
+		Offset:     paginatedResult.Offset,
+		Page:       paginatedResult.Page,
+		TotalPages: paginatedResult.TotalPages,
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
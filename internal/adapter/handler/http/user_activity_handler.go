@@ -197,6 +197,7 @@ func (h *UserActivityHandler) ListProgress(w http.ResponseWriter, r *http.Reques
 // @Produce json
 // @Security BearerAuth
 // @Param bookmark body dto.CreateBookmarkRequestDTO true "Bookmark details"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the original response"
 // @Success 201 {object} dto.BookmarkResponseDTO "Bookmark created successfully"
 // @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Track ID Format"
 // @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
@@ -352,3 +353,265 @@ func (h *UserActivityHandler) DeleteBookmark(w http.ResponseWriter, r *http.Requ
 
 	w.WriteHeader(http.StatusNoContent) // 204 No Content
 }
+
+// --- Loop Handlers ---
+
+// CreateLoop handles POST /api/v1/users/me/loops
+// @Summary Create a loop
+// @Description Saves an A-B loop region of an audio track for the authenticated user. The region must lie within the track.
+// @ID create-loop
+// @Tags User Activity
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param loop body dto.CreateLoopRequestDTO true "Loop details"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the original response"
+// @Success 201 {object} dto.LoopResponseDTO "Loop created successfully"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Region Outside Track"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Track Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /users/me/loops [post]
+func (h *UserActivityHandler) CreateLoop(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+
+	var req dto.CreateLoopRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+
+	trackID, err := domain.TrackIDFromString(req.TrackID)
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid track ID format", domain.ErrInvalidArgument))
+		return
+	}
+
+	loop, err := h.activityUseCase.CreateLoop(r.Context(), userID, trackID, req.LoopRequestDTO.ToInput())
+	if err != nil {
+		httputil.RespondError(w, r, err) // Handles NotFound (track), InvalidArgument (region)
+		return
+	}
+
+	httputil.RespondJSON(w, r, http.StatusCreated, dto.MapDomainLoopToResponseDTO(loop))
+}
+
+// ListLoops handles GET /api/v1/users/me/loops
+// @Summary List user's loops
+// @Description Retrieves a paginated list of loops for the authenticated user, optionally filtered by track ID.
+// @ID list-loops
+// @Tags User Activity
+// @Produce json
+// @Security BearerAuth
+// @Param trackId query string false "Filter by Audio Track UUID" Format(uuid)
+// @Param limit query int false "Pagination limit" default(50) minimum(1) maximum(100)
+// @Param offset query int false "Pagination offset" default(0) minimum(0)
+// @Success 200 {object} dto.PaginatedResponseDTO{data=[]dto.LoopResponseDTO} "Paginated list of loops"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Track ID Format (if provided)"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /users/me/loops [get]
+func (h *UserActivityHandler) ListLoops(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return
+	}
+
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	offset, _ := strconv.Atoi(q.Get("offset"))
+
+	var trackIDFilter *domain.TrackID
+	if trackIDStr := q.Get("trackId"); trackIDStr != "" {
+		tid, err := domain.TrackIDFromString(trackIDStr)
+		if err != nil {
+			httputil.RespondError(w, r, fmt.Errorf("%w: invalid trackId query parameter format", domain.ErrInvalidArgument))
+			return
+		}
+		trackIDFilter = &tid
+	}
+
+	loops, total, actualPageInfo, err := h.activityUseCase.ListLoops(r.Context(), port.ListLoopsInput{
+		UserID:        userID,
+		TrackIDFilter: trackIDFilter,
+		Page:          pagination.NewPageFromOffset(limit, offset),
+	})
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	respData := make([]dto.LoopResponseDTO, len(loops))
+	for i, l := range loops {
+		respData[i] = dto.MapDomainLoopToResponseDTO(l)
+	}
+
+	paginatedResult := pagination.NewPaginatedResponse(respData, total, actualPageInfo)
+	resp := dto.PaginatedResponseDTO{
+		Data:       paginatedResult.Data,
+		Total:      paginatedResult.Total,
+		Limit:      paginatedResult.Limit,
+		Offset:     paginatedResult.Offset,
+		Page:       paginatedResult.Page,
+		TotalPages: paginatedResult.TotalPages,
+	}
+
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
+
+// GetLoop handles GET /api/v1/users/me/loops/{loopId}
+// @Summary Get a loop
+// @Description Retrieves a specific loop owned by the current user.
+// @ID get-loop
+// @Tags User Activity
+// @Produce json
+// @Security BearerAuth
+// @Param loopId path string true "Loop UUID" Format(uuid)
+// @Success 200 {object} dto.LoopResponseDTO "Loop details"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Loop ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Loop Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /users/me/loops/{loopId} [get]
+func (h *UserActivityHandler) GetLoop(w http.ResponseWriter, r *http.Request) {
+	userID, loopID, ok := h.loopRequestIDs(w, r)
+	if !ok {
+		return
+	}
+
+	loop, err := h.activityUseCase.GetLoop(r.Context(), userID, loopID)
+	if err != nil {
+		httputil.RespondError(w, r, err) // Handles NotFound, PermissionDenied
+		return
+	}
+
+	httputil.RespondJSON(w, r, http.StatusOK, dto.MapDomainLoopToResponseDTO(loop))
+}
+
+// UpdateLoop handles PUT /api/v1/users/me/loops/{loopId}
+// @Summary Update a loop
+// @Description Replaces the region, label, repeat count and playback speed of a loop owned by the current user.
+// @ID update-loop
+// @Tags User Activity
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param loopId path string true "Loop UUID" Format(uuid)
+// @Param loop body dto.LoopRequestDTO true "Updated loop details"
+// @Success 200 {object} dto.LoopResponseDTO "Loop updated successfully"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Region Outside Track"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Loop Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /users/me/loops/{loopId} [put]
+func (h *UserActivityHandler) UpdateLoop(w http.ResponseWriter, r *http.Request) {
+	userID, loopID, ok := h.loopRequestIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.LoopRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+
+	loop, err := h.activityUseCase.UpdateLoop(r.Context(), userID, loopID, req.ToInput())
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, r, http.StatusOK, dto.MapDomainLoopToResponseDTO(loop))
+}
+
+// DeleteLoop handles DELETE /api/v1/users/me/loops/{loopId}
+// @Summary Delete a loop
+// @Description Deletes a specific loop owned by the current user.
+// @ID delete-loop
+// @Tags User Activity
+// @Produce json
+// @Security BearerAuth
+// @Param loopId path string true "Loop UUID" Format(uuid)
+// @Success 204 "Loop deleted successfully"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Loop Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /users/me/loops/{loopId} [delete]
+func (h *UserActivityHandler) DeleteLoop(w http.ResponseWriter, r *http.Request) {
+	userID, loopID, ok := h.loopRequestIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.activityUseCase.DeleteLoop(r.Context(), userID, loopID); err != nil {
+		httputil.RespondError(w, r, err) // Handles NotFound, PermissionDenied
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetLoopLink handles GET /api/v1/users/me/loops/{loopId}/link
+// @Summary Get a shareable loop link
+// @Description Returns a deep link that opens the loop's track in the apps with the loop's region, repeat count and speed preset. The link carries no credentials, so recipients still need access to the track.
+// @ID get-loop-link
+// @Tags User Activity
+// @Produce json
+// @Security BearerAuth
+// @Param loopId path string true "Loop UUID" Format(uuid)
+// @Success 200 {object} dto.LoopLinkResponseDTO "Shareable deep link"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (Not Owner)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Loop Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /users/me/loops/{loopId}/link [get]
+func (h *UserActivityHandler) GetLoopLink(w http.ResponseWriter, r *http.Request) {
+	userID, loopID, ok := h.loopRequestIDs(w, r)
+	if !ok {
+		return
+	}
+
+	link, err := h.activityUseCase.LoopLink(r.Context(), userID, loopID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, r, http.StatusOK, dto.LoopLinkResponseDTO{URL: link})
+}
+
+// loopRequestIDs reads the authenticated user and the loopId path parameter, responding with an error if either is missing or invalid.
+func (h *UserActivityHandler) loopRequestIDs(w http.ResponseWriter, r *http.Request) (domain.UserID, domain.LoopID, bool) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.RespondError(w, r, domain.ErrUnauthenticated)
+		return domain.UserID{}, domain.LoopID{}, false
+	}
+	loopID, err := domain.LoopIDFromString(chi.URLParam(r, "loopId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid loop ID format", domain.ErrInvalidArgument))
+		return domain.UserID{}, domain.LoopID{}, false
+	}
+	return userID, loopID, true
+}
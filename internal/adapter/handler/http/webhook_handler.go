@@ -0,0 +1,332 @@
+// internal/adapter/handler/http/webhook_handler.go
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/dto"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+	"github.com/yvanyang/language-learning-player-api/pkg/validation"
+)
+
+// WebhookHandler handles HTTP requests for webhook subscriptions.
+type WebhookHandler struct {
+	webhookUseCase port.WebhookUseCase
+	validator      *validation.Validator
+}
+
+// NewWebhookHandler creates a new WebhookHandler.
+func NewWebhookHandler(uc port.WebhookUseCase, v *validation.Validator) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: uc,
+		validator:      v,
+	}
+}
+
+func parseWebhookID(r *http.Request) (domain.WebhookID, error) {
+	id, err := domain.WebhookIDFromString(chi.URLParam(r, "webhookId"))
+	if err != nil {
+		return domain.WebhookID{}, fmt.Errorf("%w: invalid webhook ID format", domain.ErrInvalidArgument)
+	}
+	return id, nil
+}
+
+func parsePage(r *http.Request) (pagination.Page, error) {
+	limitStr := r.URL.Query().Get("limit")
+	offsetStr := r.URL.Query().Get("offset")
+	limit, errLimit := strconv.Atoi(limitStr)
+	offset, errOffset := strconv.Atoi(offsetStr)
+	if (limitStr != "" && errLimit != nil) || (offsetStr != "" && errOffset != nil) {
+		return pagination.Page{}, fmt.Errorf("%w: invalid limit or offset query parameter", domain.ErrInvalidArgument)
+	}
+	return pagination.Page{Limit: limit, Offset: offset}, nil
+}
+
+// CreateWebhook handles POST /api/v1/admin/webhooks
+// @Summary Register a webhook
+// @Description Registers an endpoint to receive signed event notifications. Requires the admin role.
+// @Description The signing secret is only returned in this response. Each delivery carries an
+// @Description X-Webhook-Signature header "t=<unix>,v1=<hex>" holding the HMAC-SHA256 of "<unix>.<body>".
+// @ID create-webhook
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook body dto.CreateWebhookRequestDTO true "Webhook endpoint and event types (track.published, upload.completed, track.completed, course.published, course.enrolled)"
+// @Success 201 {object} dto.WebhookResponseDTO "Webhook created, including its signing secret"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWebhookRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+
+	sub, err := h.webhookUseCase.CreateWebhook(r.Context(), port.CreateWebhookInput{
+		URL:        req.URL,
+		EventTypes: dto.ToEventTypes(req.EventTypes),
+	})
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	resp := dto.MapDomainWebhookToResponseDTO(sub)
+	resp.Secret = sub.Secret
+	httputil.RespondJSON(w, r, http.StatusCreated, resp)
+}
+
+// ListWebhooks handles GET /api/v1/admin/webhooks
+// @Summary List webhooks
+// @Description Retrieves a paginated list of webhook subscriptions. Requires the admin role.
+// @ID list-webhooks
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Pagination limit" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Pagination offset" default(0) minimum(0)
+// @Success 200 {object} dto.PaginatedResponseDTO{data=[]dto.WebhookResponseDTO} "Paginated list of webhooks"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Query Parameter Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePage(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	subs, total, actualPageInfo, err := h.webhookUseCase.ListWebhooks(r.Context(), page)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	respData := make([]dto.WebhookResponseDTO, len(subs))
+	for i, sub := range subs {
+		respData[i] = dto.MapDomainWebhookToResponseDTO(sub)
+	}
+	paginatedResult := pagination.NewPaginatedResponse(respData, total, actualPageInfo)
+	resp := dto.PaginatedResponseDTO{
+		Data:       paginatedResult.Data,
+		Total:      paginatedResult.Total,
+		Limit:      paginatedResult.Limit,
+		Offset:     paginatedResult.Offset,
+		Page:       paginatedResult.Page,
+		TotalPages: paginatedResult.TotalPages,
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
+
+// GetWebhook handles GET /api/v1/admin/webhooks/{webhookId}
+// @Summary Get a webhook
+// @Description Retrieves a webhook subscription. Requires the admin role.
+// @ID get-webhook
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param webhookId path string true "Webhook UUID" Format(uuid)
+// @Success 200 {object} dto.WebhookResponseDTO "Webhook details"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Webhook ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Webhook Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/webhooks/{webhookId} [get]
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := parseWebhookID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	sub, err := h.webhookUseCase.GetWebhook(r.Context(), id)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, dto.MapDomainWebhookToResponseDTO(sub))
+}
+
+// UpdateWebhook handles PUT /api/v1/admin/webhooks/{webhookId}
+// @Summary Update a webhook
+// @Description Changes a webhook's URL, event types or active flag. Omitted fields are unchanged. Requires the admin role.
+// @ID update-webhook
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhookId path string true "Webhook UUID" Format(uuid)
+// @Param webhook body dto.UpdateWebhookRequestDTO true "Fields to update"
+// @Success 200 {object} dto.WebhookResponseDTO "Updated webhook"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Input / Webhook ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Webhook Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/webhooks/{webhookId} [put]
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := parseWebhookID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	var req dto.UpdateWebhookRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid request body", domain.ErrInvalidArgument))
+		return
+	}
+	defer r.Body.Close()
+	if err := h.validator.ValidateStruct(req); err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+
+	sub, err := h.webhookUseCase.UpdateWebhook(r.Context(), id, port.UpdateWebhookInput{
+		URL:        req.URL,
+		EventTypes: dto.ToEventTypes(req.EventTypes),
+		Active:     req.Active,
+	})
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, dto.MapDomainWebhookToResponseDTO(sub))
+}
+
+// DeleteWebhook handles DELETE /api/v1/admin/webhooks/{webhookId}
+// @Summary Delete a webhook
+// @Description Deletes a webhook subscription and its delivery log. Requires the admin role.
+// @ID delete-webhook
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param webhookId path string true "Webhook UUID" Format(uuid)
+// @Success 204 "Webhook deleted successfully"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Webhook ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Webhook Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/webhooks/{webhookId} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := parseWebhookID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	if err := h.webhookUseCase.DeleteWebhook(r.Context(), id); err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /api/v1/admin/webhooks/{webhookId}/deliveries
+// @Summary List webhook deliveries
+// @Description Retrieves the delivery log of a webhook, newest first. Dead-lettered deliveries have status "dead". Requires the admin role.
+// @ID list-webhook-deliveries
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param webhookId path string true "Webhook UUID" Format(uuid)
+// @Param status query string false "Filter by delivery status" Enums(pending, succeeded, dead)
+// @Param limit query int false "Pagination limit" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Pagination offset" default(0) minimum(0)
+// @Success 200 {object} dto.PaginatedResponseDTO{data=[]dto.WebhookDeliveryResponseDTO} "Paginated delivery log"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid Query Parameter / Webhook ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Webhook Not Found"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/webhooks/{webhookId}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := parseWebhookID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	page, err := parsePage(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	input := port.ListWebhookDeliveriesInput{WebhookID: id, Page: page}
+	if status := r.URL.Query().Get("status"); status != "" {
+		s := domain.DeliveryStatus(status)
+		input.Filter.Status = &s
+	}
+
+	deliveries, total, actualPageInfo, err := h.webhookUseCase.ListDeliveries(r.Context(), input)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+
+	respData := make([]dto.WebhookDeliveryResponseDTO, len(deliveries))
+	for i, d := range deliveries {
+		respData[i] = dto.MapDomainWebhookDeliveryToResponseDTO(d)
+	}
+	paginatedResult := pagination.NewPaginatedResponse(respData, total, actualPageInfo)
+	resp := dto.PaginatedResponseDTO{
+		Data:       paginatedResult.Data,
+		Total:      paginatedResult.Total,
+		Limit:      paginatedResult.Limit,
+		Offset:     paginatedResult.Offset,
+		Page:       paginatedResult.Page,
+		TotalPages: paginatedResult.TotalPages,
+	}
+	httputil.RespondJSON(w, r, http.StatusOK, resp)
+}
+
+// RedeliverDelivery handles POST /api/v1/admin/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver
+// @Summary Redeliver a webhook delivery
+// @Description Schedules a succeeded or dead-lettered delivery to be sent again with a fresh set of attempts. Requires the admin role.
+// @ID redeliver-webhook-delivery
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param webhookId path string true "Webhook UUID" Format(uuid)
+// @Param deliveryId path string true "Delivery UUID" Format(uuid)
+// @Success 202 {object} dto.WebhookDeliveryResponseDTO "Delivery requeued"
+// @Failure 400 {object} httputil.ErrorResponseDTO "Invalid ID Format"
+// @Failure 401 {object} httputil.ErrorResponseDTO "Unauthorized"
+// @Failure 403 {object} httputil.ErrorResponseDTO "Forbidden (not an admin)"
+// @Failure 404 {object} httputil.ErrorResponseDTO "Delivery Not Found"
+// @Failure 409 {object} httputil.ErrorResponseDTO "Delivery is already pending"
+// @Failure 500 {object} httputil.ErrorResponseDTO "Internal Server Error"
+// @Router /admin/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) RedeliverDelivery(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := parseWebhookID(r)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	deliveryID, err := domain.WebhookDeliveryIDFromString(chi.URLParam(r, "deliveryId"))
+	if err != nil {
+		httputil.RespondError(w, r, fmt.Errorf("%w: invalid delivery ID format", domain.ErrInvalidArgument))
+		return
+	}
+	delivery, err := h.webhookUseCase.RedeliverDelivery(r.Context(), webhookID, deliveryID)
+	if err != nil {
+		httputil.RespondError(w, r, err)
+		return
+	}
+	httputil.RespondJSON(w, r, http.StatusAccepted, dto.MapDomainWebhookDeliveryToResponseDTO(delivery))
+}
@@ -0,0 +1,41 @@
+// internal/adapter/health/checks.go
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DatabasePing checks that a connection can be acquired and pinged.
+func DatabasePing(pool *pgxpool.Pool) CheckFunc {
+	return func(ctx context.Context) error {
+		return pool.Ping(ctx)
+	}
+}
+
+// MigrationVersion checks that the golang-migrate schema_migrations table is at
+// least at the expected version and not left dirty by a failed migration.
+func MigrationVersion(pool *pgxpool.Pool, expected uint) CheckFunc {
+	return func(ctx context.Context) error {
+		var version int64
+		var dirty bool
+		err := pool.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("no migrations applied, expected version %d", expected)
+			}
+			return fmt.Errorf("reading migration version: %w", err)
+		}
+		if dirty {
+			return fmt.Errorf("migration version %d is dirty", version)
+		}
+		if version < int64(expected) {
+			return fmt.Errorf("migration version %d is behind expected %d", version, expected)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,150 @@
+// internal/adapter/health/health.go
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+)
+
+// Check status values reported in the readiness response.
+const (
+	StatusUp        = "up"
+	StatusDown      = "down"
+	StatusReady     = "ready"
+	StatusNotReady  = "not_ready"
+	StatusShutdown  = "shutting_down"
+	defaultTimeout  = 2 * time.Second
+	defaultCacheTTL = 5 * time.Second
+)
+
+// CheckFunc returns nil when the dependency is healthy.
+type CheckFunc func(ctx context.Context) error
+
+// Checker is a named dependency check.
+// Timeout bounds a single run; results are reused for CacheTTL so that frequent
+// probes don't hammer the dependency.
+type Checker struct {
+	Name     string
+	Check    CheckFunc
+	Timeout  time.Duration
+	CacheTTL time.Duration
+}
+
+// CheckResult is the outcome of a single checker.
+type CheckResult struct {
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+	CheckedAt  time.Time `json:"checkedAt"`
+}
+
+// ReadinessResponse is the JSON body returned by /readyz.
+type ReadinessResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type registeredChecker struct {
+	Checker
+	mu     sync.Mutex
+	last   CheckResult
+	cached bool
+}
+
+// Readiness runs registered checkers and reports whether the instance can serve traffic.
+type Readiness struct {
+	checkers     []*registeredChecker
+	shuttingDown atomic.Bool
+	logger       *slog.Logger
+}
+
+// NewReadiness creates an empty Readiness with no checkers.
+func NewReadiness(logger *slog.Logger) *Readiness {
+	return &Readiness{logger: logger.With("component", "Readiness")}
+}
+
+// Register adds a checker. Zero Timeout/CacheTTL fall back to defaults;
+// a negative CacheTTL disables caching. Not safe to call once serving.
+func (r *Readiness) Register(c Checker) {
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.CacheTTL == 0 {
+		c.CacheTTL = defaultCacheTTL
+	}
+	r.checkers = append(r.checkers, &registeredChecker{Checker: c})
+}
+
+// SetShuttingDown marks the instance as not ready. Call it before srv.Shutdown so
+// load balancers stop routing new traffic while in-flight requests drain.
+func (r *Readiness) SetShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+// Check runs every checker (concurrently, honouring caches) and returns the breakdown.
+func (r *Readiness) Check(ctx context.Context) ReadinessResponse {
+	resp := ReadinessResponse{Status: StatusReady, Checks: make(map[string]CheckResult, len(r.checkers))}
+
+	results := make([]CheckResult, len(r.checkers))
+	var wg sync.WaitGroup
+	for i, c := range r.checkers {
+		wg.Add(1)
+		go func(i int, c *registeredChecker) {
+			defer wg.Done()
+			results[i] = c.run(ctx)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for i, c := range r.checkers {
+		resp.Checks[c.Name] = results[i]
+		if results[i].Status != StatusUp {
+			resp.Status = StatusNotReady
+		}
+	}
+	if r.shuttingDown.Load() {
+		resp.Status = StatusShutdown
+	}
+	return resp
+}
+
+func (c *registeredChecker) run(ctx context.Context) CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.cached && c.CacheTTL > 0 && now.Sub(c.last.CheckedAt) < c.CacheTTL {
+		return c.last
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	result := CheckResult{Status: StatusUp, CheckedAt: now}
+	if err := c.Check(checkCtx); err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	result.DurationMs = time.Since(now).Milliseconds()
+
+	c.last = result
+	c.cached = true
+	return result
+}
+
+// Handler serves the readiness breakdown: 200 when ready, 503 otherwise.
+func (r *Readiness) Handler(w http.ResponseWriter, req *http.Request) {
+	resp := r.Check(req.Context())
+	status := http.StatusOK
+	if resp.Status != StatusReady {
+		status = http.StatusServiceUnavailable
+		r.logger.WarnContext(req.Context(), "Readiness check failed", "status", resp.Status, "checks", resp.Checks)
+	}
+	httputil.RespondJSON(w, req, status, resp)
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		checkErr   error
+		shutdown   bool
+		wantCode   int
+		wantStatus string
+	}{
+		{"All Up", nil, false, http.StatusOK, StatusReady},
+		{"Check Down", errors.New("connection refused"), false, http.StatusServiceUnavailable, StatusNotReady},
+		{"Shutting Down", nil, true, http.StatusServiceUnavailable, StatusShutdown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReadiness(slog.Default())
+			r.Register(Checker{Name: "ok", Check: func(ctx context.Context) error { return nil }})
+			r.Register(Checker{Name: "dep", Check: func(ctx context.Context) error { return tt.checkErr }})
+			if tt.shutdown {
+				r.SetShuttingDown()
+			}
+
+			rr := httptest.NewRecorder()
+			r.Handler(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			assert.Equal(t, tt.wantCode, rr.Code)
+			var body ReadinessResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantStatus, body.Status)
+			assert.Equal(t, StatusUp, body.Checks["ok"].Status)
+			if tt.checkErr != nil {
+				assert.Equal(t, StatusDown, body.Checks["dep"].Status)
+				assert.Equal(t, tt.checkErr.Error(), body.Checks["dep"].Error)
+			}
+		})
+	}
+}
+
+func TestReadinessCheckTimeout(t *testing.T) {
+	r := NewReadiness(slog.Default())
+	r.Register(Checker{
+		Name:    "slow",
+		Timeout: 10 * time.Millisecond,
+		Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	resp := r.Check(context.Background())
+	assert.Equal(t, StatusNotReady, resp.Status)
+	assert.Contains(t, resp.Checks["slow"].Error, context.DeadlineExceeded.Error())
+}
+
+func TestReadinessCaching(t *testing.T) {
+	var calls atomic.Int32
+	check := func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}
+
+	r := NewReadiness(slog.Default())
+	r.Register(Checker{Name: "cached", Check: check, CacheTTL: time.Hour})
+	r.Register(Checker{Name: "uncached", Check: check, CacheTTL: -1})
+
+	r.Check(context.Background())
+	r.Check(context.Background())
+
+	// cached runs once, uncached runs twice
+	assert.Equal(t, int32(3), calls.Load())
+}
@@ -0,0 +1,145 @@
+// internal/adapter/metrics/metrics.go
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+const namespace = "llp"
+
+// Metrics owns the Prometheus registry and every collector exposed on /metrics.
+// It implements port.MetricsRecorder for business counters and is used by the
+// HTTP middleware and the storage decorator for technical metrics.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	storageDuration *prometheus.HistogramVec
+	storageErrors   *prometheus.CounterVec
+
+	uploadsCompleted prometheus.Counter
+	progressUpdates  prometheus.Counter
+	bookmarksCreated prometheus.Counter
+	logins           *prometheus.CounterVec
+}
+
+// New creates a Metrics instance with its own registry, including Go runtime
+// and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Total number of HTTP requests by route pattern, method and status.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route pattern, method and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		storageDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "storage_operation_duration_seconds",
+			Help:      "Object storage call latency by operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		storageErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "storage_operation_errors_total",
+			Help:      "Object storage call failures by operation.",
+		}, []string{"operation"}),
+		uploadsCompleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "uploads_completed_total",
+			Help:      "Tracks created from completed uploads.",
+		}),
+		progressUpdates: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "progress_updates_total",
+			Help:      "Playback progress updates recorded.",
+		}),
+		bookmarksCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bookmarks_created_total",
+			Help:      "Bookmarks created.",
+		}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Successful logins by auth provider.",
+		}, []string{"provider"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.storageDuration,
+		m.storageErrors,
+		m.uploadsCompleted,
+		m.progressUpdates,
+		m.bookmarksCreated,
+		m.logins,
+	)
+	return m
+}
+
+// Register adds an extra collector (e.g. the pgxpool collector) to the registry.
+func (m *Metrics) Register(c prometheus.Collector) error {
+	return m.registry.Register(c)
+}
+
+// Handler returns the HTTP handler serving the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records a finished HTTP request.
+func (m *Metrics) ObserveHTTPRequest(route, method string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(route, method, statusLabel).Inc()
+	m.httpDuration.WithLabelValues(route, method, statusLabel).Observe(duration.Seconds())
+}
+
+// ObserveStorageOperation records a finished object storage call.
+func (m *Metrics) ObserveStorageOperation(operation string, duration time.Duration, err error) {
+	m.storageDuration.WithLabelValues(operation).Observe(duration.Seconds())
+	if err != nil {
+		m.storageErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// --- port.MetricsRecorder ---
+
+func (m *Metrics) UploadsCompleted(count int) {
+	m.uploadsCompleted.Add(float64(count))
+}
+
+func (m *Metrics) ProgressUpdated() {
+	m.progressUpdates.Inc()
+}
+
+func (m *Metrics) BookmarkCreated() {
+	m.bookmarksCreated.Inc()
+}
+
+func (m *Metrics) LoginSucceeded(provider domain.AuthProvider) {
+	m.logins.WithLabelValues(string(provider)).Inc()
+}
+
+// Compile-time check to ensure Metrics satisfies the port.MetricsRecorder interface
+var _ port.MetricsRecorder = (*Metrics)(nil)
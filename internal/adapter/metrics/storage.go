@@ -0,0 +1,75 @@
+// internal/adapter/metrics/storage.go
+package metrics
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// InstrumentedFileStorage decorates a port.FileStorageService with latency and
+// error metrics. ObjectExists returning false is not counted as an error.
+type InstrumentedFileStorage struct {
+	next    port.FileStorageService
+	metrics *Metrics
+}
+
+// NewInstrumentedFileStorage wraps next so every call is measured.
+func NewInstrumentedFileStorage(next port.FileStorageService, m *Metrics) *InstrumentedFileStorage {
+	return &InstrumentedFileStorage{next: next, metrics: m}
+}
+
+func (s *InstrumentedFileStorage) GetPresignedGetURL(ctx context.Context, bucket, objectKey string, expiry time.Duration) (string, error) {
+	start := time.Now()
+	url, err := s.next.GetPresignedGetURL(ctx, bucket, objectKey, expiry)
+	s.metrics.ObserveStorageOperation("presign_get", time.Since(start), err)
+	return url, err
+}
+
+func (s *InstrumentedFileStorage) GetPresignedPutURL(ctx context.Context, bucket, objectKey string, expiry time.Duration) (string, error) {
+	start := time.Now()
+	url, err := s.next.GetPresignedPutURL(ctx, bucket, objectKey, expiry)
+	s.metrics.ObserveStorageOperation("presign_put", time.Since(start), err)
+	return url, err
+}
+
+func (s *InstrumentedFileStorage) DeleteObject(ctx context.Context, bucket, objectKey string) error {
+	start := time.Now()
+	err := s.next.DeleteObject(ctx, bucket, objectKey)
+	s.metrics.ObserveStorageOperation("delete_object", time.Since(start), err)
+	return err
+}
+
+func (s *InstrumentedFileStorage) ObjectExists(ctx context.Context, bucket, objectKey string) (bool, error) {
+	start := time.Now()
+	exists, err := s.next.ObjectExists(ctx, bucket, objectKey)
+	s.metrics.ObserveStorageOperation("object_exists", time.Since(start), err)
+	return exists, err
+}
+
+func (s *InstrumentedFileStorage) PutObject(ctx context.Context, bucket, objectKey string, reader io.Reader, size int64, contentType string) error {
+	start := time.Now()
+	err := s.next.PutObject(ctx, bucket, objectKey, reader, size, contentType)
+	s.metrics.ObserveStorageOperation("put_object", time.Since(start), err)
+	return err
+}
+
+// GetObject measures opening the object, not reading it.
+func (s *InstrumentedFileStorage) GetObject(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error) {
+	start := time.Now()
+	obj, err := s.next.GetObject(ctx, bucket, objectKey)
+	s.metrics.ObserveStorageOperation("get_object", time.Since(start), err)
+	return obj, err
+}
+
+func (s *InstrumentedFileStorage) ListObjects(ctx context.Context, bucket, prefix string) ([]port.StorageObject, error) {
+	start := time.Now()
+	objects, err := s.next.ListObjects(ctx, bucket, prefix)
+	s.metrics.ObserveStorageOperation("list_objects", time.Since(start), err)
+	return objects, err
+}
+
+// Compile-time check to ensure InstrumentedFileStorage satisfies the port.FileStorageService interface
+var _ port.FileStorageService = (*InstrumentedFileStorage)(nil)
@@ -3,15 +3,17 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
-
-	// "github.com/jackc/pgx/v5/pgconn" // Needed only if checking specific pg error codes
+	"github.com/lib/pq"
 
 	"github.com/yvanyang/language-learning-player-api/internal/domain" // Adjust import path
 	"github.com/yvanyang/language-learning-player-api/internal/port"   // Adjust import path
@@ -42,12 +44,22 @@ func NewAudioCollectionRepository(db *pgxpool.Pool, logger *slog.Logger) *AudioC
 func (r *AudioCollectionRepository) Create(ctx context.Context, collection *domain.AudioCollection) error {
 	q := r.getQuerier(ctx) // Get appropriate querier (pool or tx)
 	query := `
-        INSERT INTO audio_collections (id, title, description, owner_id, type, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        INSERT INTO audio_collections (id, title, description, owner_id, type, visibility, version, smart_rule, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
     `
-	_, err := q.Exec(ctx, query,
+	if collection.Version == 0 {
+		collection.Version = 1
+	}
+	if collection.Visibility == "" {
+		collection.Visibility = domain.VisibilityPrivate
+	}
+	smartRule, err := encodeSmartRule(collection.SmartRule)
+	if err != nil {
+		return err
+	}
+	_, err = q.Exec(ctx, query,
 		collection.ID, collection.Title, collection.Description, collection.OwnerID,
-		collection.Type, collection.CreatedAt, collection.UpdatedAt,
+		collection.Type, collection.Visibility, collection.Version, smartRule, collection.CreatedAt, collection.UpdatedAt,
 	)
 	if err != nil {
 		r.logger.ErrorContext(ctx, "Error creating audio collection", "error", err, "collectionID", collection.ID, "ownerID", collection.OwnerID)
@@ -61,7 +73,7 @@ func (r *AudioCollectionRepository) Create(ctx context.Context, collection *doma
 func (r *AudioCollectionRepository) FindByID(ctx context.Context, id domain.CollectionID) (*domain.AudioCollection, error) {
 	q := r.getQuerier(ctx)
 	query := `
-        SELECT id, title, description, owner_id, type, created_at, updated_at
+        SELECT id, title, description, owner_id, type, visibility, version, published_at, smart_rule, created_at, updated_at
         FROM audio_collections
         WHERE id = $1
     `
@@ -91,7 +103,7 @@ func (r *AudioCollectionRepository) FindWithTracks(ctx context.Context, id domai
         SELECT track_id
         FROM collection_tracks
         WHERE collection_id = $1
-        ORDER BY position ASC
+        ORDER BY position ASC, track_id ASC -- Same order as renumberTracks
     `
 	rows, err := q.Query(ctx, queryTracks, id)
 	if err != nil {
@@ -124,7 +136,7 @@ func (r *AudioCollectionRepository) ListByOwner(ctx context.Context, ownerID dom
 	argID := 2 // Start arg numbering after ownerID ($1)
 	baseQuery := ` FROM audio_collections WHERE owner_id = $1 `
 	countQuery := `SELECT count(*) ` + baseQuery
-	selectQuery := `SELECT id, title, description, owner_id, type, created_at, updated_at ` + baseQuery
+	selectQuery := `SELECT id, title, description, owner_id, type, visibility, version, published_at, smart_rule, created_at, updated_at ` + baseQuery
 
 	var total int
 	err := q.QueryRow(ctx, countQuery, args...).Scan(&total)
@@ -164,46 +176,239 @@ func (r *AudioCollectionRepository) ListByOwner(ctx context.Context, ownerID dom
 	return collections, total, nil
 }
 
-// UpdateMetadata updates only title and description. Ownership check via WHERE clause.
+// ListAccessible lists the collections the user owns or is a member of, with the user's role in each.
+func (r *AudioCollectionRepository) ListAccessible(ctx context.Context, userID domain.UserID, sortBy, sortDirection string, page pagination.Page) ([]port.UserCollection, int, error) {
+	q := r.getQuerier(ctx)
+	baseQuery := `
+        FROM audio_collections c
+        LEFT JOIN collection_members m ON m.collection_id = c.id AND m.user_id = $1
+        WHERE c.owner_id = $1 OR m.user_id IS NOT NULL `
+	countQuery := `SELECT count(*) ` + baseQuery
+	selectQuery := `SELECT c.id, c.title, c.description, c.owner_id, c.type, c.visibility, c.version, c.published_at, c.smart_rule, c.created_at, c.updated_at,
+               CASE WHEN c.owner_id = $1 THEN 'OWNER' ELSE m.role END ` + baseQuery
+
+	var total int
+	if err := q.QueryRow(ctx, countQuery, userID).Scan(&total); err != nil {
+		r.logger.ErrorContext(ctx, "Error counting accessible collections", "error", err, "userID", userID)
+		return nil, 0, fmt.Errorf("counting accessible collections: %w", err)
+	}
+	if total == 0 {
+		return []port.UserCollection{}, 0, nil
+	}
+
+	orderByClause := " ORDER BY c.created_at DESC"
+	if sortBy != "" {
+		allowedSorts := map[string]string{"createdAt": "c.created_at", "updatedAt": "c.updated_at", "title": "c.title"}
+		if dbColumn, ok := allowedSorts[sortBy]; ok {
+			direction := " ASC"
+			if strings.ToLower(sortDirection) == "desc" {
+				direction = " DESC"
+			}
+			orderByClause = fmt.Sprintf(" ORDER BY %s%s", dbColumn, direction)
+		} else {
+			r.logger.WarnContext(ctx, "Invalid sort field requested", "sortBy", sortBy)
+		}
+	}
+	finalQuery := selectQuery + orderByClause + ", c.id LIMIT $2 OFFSET $3"
+
+	rows, err := q.Query(ctx, finalQuery, userID, page.Limit, page.Offset)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing accessible collections", "error", err, "userID", userID)
+		return nil, 0, fmt.Errorf("listing accessible collections: %w", err)
+	}
+	defer rows.Close()
+	collections := make([]port.UserCollection, 0, page.Limit)
+	for rows.Next() {
+		var collection domain.AudioCollection
+		var role domain.CollectionRole
+		var smartRule []byte
+		if err := rows.Scan(
+			&collection.ID, &collection.Title, &collection.Description, &collection.OwnerID,
+			&collection.Type, &collection.Visibility, &collection.Version, &collection.PublishedAt, &smartRule, &collection.CreatedAt, &collection.UpdatedAt,
+			&role,
+		); err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning collection in ListAccessible", "error", err)
+			continue
+		}
+		if collection.SmartRule, err = decodeSmartRule(smartRule); err != nil {
+			r.logger.ErrorContext(ctx, "Error decoding smart rule in ListAccessible", "error", err, "collectionID", collection.ID)
+			continue
+		}
+		collection.TrackIDs = make([]domain.TrackID, 0)
+		collections = append(collections, port.UserCollection{Collection: &collection, Role: role})
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating collection rows in ListAccessible", "error", err)
+		return nil, 0, fmt.Errorf("iterating collection rows: %w", err)
+	}
+	return collections, total, nil
+}
+
+func (r *AudioCollectionRepository) HasAccessibleWithTrack(ctx context.Context, userID domain.UserID, trackID domain.TrackID) (bool, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        SELECT EXISTS (
+            SELECT 1
+            FROM collection_tracks ct
+            JOIN audio_collections c ON c.id = ct.collection_id
+            JOIN audio_tracks t ON t.id = ct.track_id
+            LEFT JOIN collection_members m ON m.collection_id = c.id AND m.user_id = $1
+            WHERE ct.track_id = $2 AND (c.owner_id = $1 OR m.user_id IS NOT NULL)
+              AND (t.is_public OR t.uploader_id = c.owner_id OR EXISTS (
+                  SELECT 1 FROM collection_members e
+                  WHERE e.collection_id = c.id AND e.user_id = t.uploader_id AND e.role = $3
+              ))
+        )
+    `
+	var exists bool
+	if err := q.QueryRow(ctx, query, userID, trackID, domain.CollectionRoleEditor).Scan(&exists); err != nil {
+		r.logger.ErrorContext(ctx, "Error checking accessible collections for track", "error", err, "userID", userID, "trackID", trackID)
+		return false, fmt.Errorf("checking accessible collections for track: %w", err)
+	}
+	return exists, nil
+}
+
+// ListPublic lists PUBLIC collections. Track filters match collections with at least one public track satisfying all of them.
+func (r *AudioCollectionRepository) ListPublic(ctx context.Context, filters port.ListCollectionsFilters, page pagination.Page) ([]*domain.AudioCollection, int, error) {
+	q := r.getQuerier(ctx)
+	args := []interface{}{domain.VisibilityPublic}
+	argID := 2
+	baseQuery := ` FROM audio_collections c `
+	countQuery := `SELECT count(*) ` + baseQuery
+	selectQuery := `SELECT c.id, c.title, c.description, c.owner_id, c.type, c.visibility, c.version, c.published_at, c.smart_rule, c.created_at, c.updated_at ` + baseQuery
+	whereClause := " WHERE c.visibility = $1"
+
+	if filters.Query != nil && *filters.Query != "" {
+		whereClause += fmt.Sprintf(" AND (c.title ILIKE $%d OR c.description ILIKE $%d)", argID, argID)
+		args = append(args, "%"+*filters.Query+"%")
+		argID++
+	}
+	if filters.OwnerID != nil {
+		whereClause += fmt.Sprintf(" AND c.owner_id = $%d", argID)
+		args = append(args, *filters.OwnerID)
+		argID++
+	}
+	// Track filters must all hold for one public track of the collection
+	var trackConditions []string
+	if filters.LanguageCode != nil && *filters.LanguageCode != "" {
+		trackConditions = append(trackConditions, fmt.Sprintf("t.language_code = $%d", argID))
+		args = append(args, *filters.LanguageCode)
+		argID++
+	}
+	if filters.Level != nil && *filters.Level != "" {
+		trackConditions = append(trackConditions, fmt.Sprintf("t.level = $%d", argID))
+		args = append(args, *filters.Level)
+		argID++
+	}
+	if len(filters.Tags) > 0 {
+		trackConditions = append(trackConditions, fmt.Sprintf("t.tags @> $%d", argID))
+		args = append(args, pq.Array(filters.Tags))
+		argID++
+	}
+	if len(trackConditions) > 0 {
+		whereClause += ` AND EXISTS (
+            SELECT 1 FROM collection_tracks ct JOIN audio_tracks t ON t.id = ct.track_id
+            WHERE ct.collection_id = c.id AND t.is_public AND ` + strings.Join(trackConditions, " AND ") + `)`
+	}
+
+	var total int
+	err := q.QueryRow(ctx, countQuery+whereClause, args...).Scan(&total)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error counting public collections", "error", err, "filters", filters)
+		return nil, 0, fmt.Errorf("counting public collections: %w", err)
+	}
+	if total == 0 {
+		return []*domain.AudioCollection{}, 0, nil
+	}
+
+	orderByClause := " ORDER BY c.created_at DESC"
+	if filters.SortBy != "" {
+		allowedSorts := map[string]string{"createdAt": "c.created_at", "updatedAt": "c.updated_at", "title": "c.title"}
+		if dbColumn, ok := allowedSorts[filters.SortBy]; ok {
+			direction := " ASC"
+			if strings.ToLower(filters.SortDirection) == "desc" {
+				direction = " DESC"
+			}
+			orderByClause = fmt.Sprintf(" ORDER BY %s%s", dbColumn, direction)
+		} else {
+			r.logger.WarnContext(ctx, "Invalid sort field requested", "sortBy", filters.SortBy)
+		}
+	}
+	paginationClause := fmt.Sprintf(" LIMIT $%d OFFSET $%d", argID, argID+1)
+	args = append(args, page.Limit, page.Offset)
+	finalQuery := selectQuery + whereClause + orderByClause + ", c.id" + paginationClause
+
+	rows, err := q.Query(ctx, finalQuery, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing public collections", "error", err, "filters", filters, "page", page)
+		return nil, 0, fmt.Errorf("listing public collections: %w", err)
+	}
+	defer rows.Close()
+	collections := make([]*domain.AudioCollection, 0, page.Limit)
+	for rows.Next() {
+		collection, err := r.scanCollection(ctx, rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning collection in ListPublic", "error", err)
+			continue
+		}
+		collection.TrackIDs = make([]domain.TrackID, 0)
+		collections = append(collections, collection)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating collection rows in ListPublic", "error", err)
+		return nil, 0, fmt.Errorf("iterating collection rows: %w", err)
+	}
+	return collections, total, nil
+}
+
+// UpdateMetadata updates title, description and visibility. Ownership check via WHERE clause.
+// collection.Version is the version the caller expects (0 skips the check); on success
+// it is set to the new version. A version mismatch returns domain.ErrPreconditionFailed.
 func (r *AudioCollectionRepository) UpdateMetadata(ctx context.Context, collection *domain.AudioCollection) error {
 	q := r.getQuerier(ctx)
 	collection.UpdatedAt = time.Now()
 	query := `
         UPDATE audio_collections SET
-            title = $2, description = $3, updated_at = $4
+            title = $2, description = $3, visibility = $7, updated_at = $4, version = version + 1
         WHERE id = $1 AND owner_id = $5 -- Ensure owner matches
+          AND ($6 = 0 OR version = $6)
+        RETURNING version
     `
-	cmdTag, err := q.Exec(ctx, query,
-		collection.ID, collection.Title, collection.Description, collection.UpdatedAt, collection.OwnerID,
-	)
+	err := q.QueryRow(ctx, query,
+		collection.ID, collection.Title, collection.Description, collection.UpdatedAt, collection.OwnerID, collection.Version, collection.Visibility,
+	).Scan(&collection.Version)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return r.explainNoUpdate(ctx, collection.ID, &collection.OwnerID)
+		}
 		r.logger.ErrorContext(ctx, "Error updating collection metadata", "error", err, "collectionID", collection.ID)
 		return fmt.Errorf("updating collection metadata: %w", err)
 	}
-	if cmdTag.RowsAffected() == 0 {
-		exists, _ := r.exists(ctx, collection.ID) // Check existence
-		if !exists {
-			return domain.ErrNotFound
-		}
-		return domain.ErrPermissionDenied // Assume owner mismatch if exists but not updated
-	}
-	r.logger.InfoContext(ctx, "Collection metadata updated", "collectionID", collection.ID)
+	r.logger.InfoContext(ctx, "Collection metadata updated", "collectionID", collection.ID, "version", collection.Version)
 	return nil
 }
 
-// ManageTracks replaces the entire set of tracks associated with a collection.
+// ManageTracks replaces the entire set of tracks associated with a collection and returns
+// the collection's new version. expectedVersion guards against concurrent edits (0 skips
+// the check); a mismatch returns domain.ErrPreconditionFailed.
 // This method now expects to run within a transaction context provided by the Usecase.
-func (r *AudioCollectionRepository) ManageTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID) error {
+func (r *AudioCollectionRepository) ManageTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID, expectedVersion int) (int, error) {
 	q := r.getQuerier(ctx) // Gets Tx if called within TxManager.Execute, otherwise Pool
 
-	// 1. Delete existing tracks for the collection
+	// 1. Bump the version and timestamp first; the row lock serializes concurrent track updates
+	newVersion, err := r.bumpVersion(ctx, collectionID, expectedVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. Delete existing tracks for the collection
 	deleteQuery := `DELETE FROM collection_tracks WHERE collection_id = $1`
 	if _, err := q.Exec(ctx, deleteQuery, collectionID); err != nil {
 		r.logger.ErrorContext(ctx, "Failed to delete old tracks in ManageTracks", "error", err, "collectionID", collectionID)
-		return fmt.Errorf("deleting old tracks: %w", err)
+		return 0, fmt.Errorf("deleting old tracks: %w", err)
 	}
 
-	// 2. Insert new tracks with correct positions
+	// 3. Insert new tracks with correct positions
 	if len(orderedTrackIDs) > 0 {
 		insertQuery := `
             INSERT INTO collection_tracks (collection_id, track_id, position)
@@ -224,20 +429,181 @@ func (r *AudioCollectionRepository) ManageTracks(ctx context.Context, collection
 			if err != nil {
 				r.logger.ErrorContext(ctx, "Failed to insert track in ManageTracks batch", "error", err, "collectionID", collectionID, "position", i)
 				// Consider checking for FK violation on track_id here
-				return fmt.Errorf("inserting track at position %d: %w", i, err)
+				return 0, fmt.Errorf("inserting track at position %d: %w", i, err)
 			}
 		}
 	}
 
-	// 3. Update the collection's updated_at timestamp
-	updateTsQuery := `UPDATE audio_collections SET updated_at = $1 WHERE id = $2`
-	if _, err := q.Exec(ctx, updateTsQuery, time.Now(), collectionID); err != nil {
-		r.logger.ErrorContext(ctx, "Failed to update collection timestamp in ManageTracks", "error", err, "collectionID", collectionID)
-		return fmt.Errorf("updating collection timestamp: %w", err)
+	r.logger.DebugContext(ctx, "ManageTracks repo operations completed (within transaction)", "collectionID", collectionID, "trackCount", len(orderedTrackIDs), "version", newVersion)
+	return newVersion, nil // Usecase layer handles commit/rollback
+}
+
+// renumberTracks makes the positions of a collection's tracks dense (0..n-1) in their current
+// order and returns n. Deleting a track removes its rows by cascade and leaves a gap, which would
+// otherwise make "the end" of the collection ambiguous for InsertTrack and MoveTrack.
+func (r *AudioCollectionRepository) renumberTracks(ctx context.Context, collectionID domain.CollectionID) (int, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        UPDATE collection_tracks ct SET position = n.new_position
+        FROM (
+            SELECT track_id, row_number() OVER (ORDER BY position, track_id) - 1 AS new_position
+            FROM collection_tracks WHERE collection_id = $1
+        ) n
+        WHERE ct.collection_id = $1 AND ct.track_id = n.track_id AND ct.position <> n.new_position
+    `
+	if _, err := q.Exec(ctx, query, collectionID); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to renumber collection tracks", "error", err, "collectionID", collectionID)
+		return 0, fmt.Errorf("renumbering collection tracks: %w", err)
+	}
+	var count int
+	if err := q.QueryRow(ctx, `SELECT COUNT(*) FROM collection_tracks WHERE collection_id = $1`, collectionID).Scan(&count); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to count collection tracks", "error", err, "collectionID", collectionID)
+		return 0, fmt.Errorf("counting collection tracks: %w", err)
+	}
+	return count, nil
+}
+
+// InsertTrack adds a track at a 0-based position, shifting the tracks at and after it down by one,
+// and returns the collection's new version. An out-of-bounds position appends the track.
+// A track already in the collection returns domain.ErrConflict.
+func (r *AudioCollectionRepository) InsertTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error) {
+	q := r.getQuerier(ctx)
+	newVersion, err := r.bumpVersion(ctx, collectionID, expectedVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := r.renumberTracks(ctx, collectionID)
+	if err != nil {
+		return 0, err
+	}
+	if position < 0 || position > count {
+		position = count
+	}
+
+	shiftQuery := `UPDATE collection_tracks SET position = position + 1 WHERE collection_id = $1 AND position >= $2`
+	if _, err := q.Exec(ctx, shiftQuery, collectionID, position); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to shift collection tracks", "error", err, "collectionID", collectionID, "position", position)
+		return 0, fmt.Errorf("shifting collection tracks: %w", err)
+	}
+	insertQuery := `INSERT INTO collection_tracks (collection_id, track_id, position) VALUES ($1, $2, $3)`
+	if _, err := q.Exec(ctx, insertQuery, collectionID, trackID, position); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case UniqueViolation:
+				return 0, fmt.Errorf("%w: track %s is already in the collection", domain.ErrConflict, trackID)
+			case ForeignKeyViolation:
+				return 0, fmt.Errorf("%w: track %s does not exist", domain.ErrInvalidArgument, trackID)
+			}
+		}
+		r.logger.ErrorContext(ctx, "Failed to insert collection track", "error", err, "collectionID", collectionID, "trackID", trackID)
+		return 0, fmt.Errorf("inserting collection track: %w", err)
+	}
+	return newVersion, nil
+}
+
+// MoveTrack moves a track to a 0-based position, shifting the tracks in between by one,
+// and returns the collection's new version. An out-of-bounds position moves the track to the end.
+// A track not in the collection returns domain.ErrNotFound.
+func (r *AudioCollectionRepository) MoveTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error) {
+	q := r.getQuerier(ctx)
+	newVersion, err := r.bumpVersion(ctx, collectionID, expectedVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := r.renumberTracks(ctx, collectionID)
+	if err != nil {
+		return 0, err
+	}
+	var from int
+	positionQuery := `SELECT position FROM collection_tracks WHERE collection_id = $1 AND track_id = $2`
+	if err := q.QueryRow(ctx, positionQuery, collectionID, trackID).Scan(&from); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%w: track %s is not part of the collection", domain.ErrNotFound, trackID)
+		}
+		r.logger.ErrorContext(ctx, "Failed to find collection track position", "error", err, "collectionID", collectionID, "trackID", trackID)
+		return 0, fmt.Errorf("finding collection track position: %w", err)
+	}
+	if position < 0 || position >= count {
+		position = count - 1
+	}
+	if position == from {
+		return newVersion, nil
+	}
+
+	// Shift the tracks between the old and new position towards the gap, then place the track
+	shiftQuery := `UPDATE collection_tracks SET position = position - 1 WHERE collection_id = $1 AND position > $2 AND position <= $3`
+	shiftArgs := []any{collectionID, from, position}
+	if position < from {
+		shiftQuery = `UPDATE collection_tracks SET position = position + 1 WHERE collection_id = $1 AND position >= $3 AND position < $2`
+	}
+	if _, err := q.Exec(ctx, shiftQuery, shiftArgs...); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to shift collection tracks", "error", err, "collectionID", collectionID, "from", from, "to", position)
+		return 0, fmt.Errorf("shifting collection tracks: %w", err)
+	}
+	moveQuery := `UPDATE collection_tracks SET position = $3 WHERE collection_id = $1 AND track_id = $2`
+	if _, err := q.Exec(ctx, moveQuery, collectionID, trackID, position); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to move collection track", "error", err, "collectionID", collectionID, "trackID", trackID)
+		return 0, fmt.Errorf("moving collection track: %w", err)
+	}
+	return newVersion, nil
+}
+
+// RemoveTrack removes a track, closing the gap it leaves, and returns the collection's new version.
+// A track not in the collection returns domain.ErrNotFound.
+func (r *AudioCollectionRepository) RemoveTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, expectedVersion int) (int, error) {
+	q := r.getQuerier(ctx)
+	newVersion, err := r.bumpVersion(ctx, collectionID, expectedVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := r.renumberTracks(ctx, collectionID); err != nil {
+		return 0, err
+	}
+	var removedPosition int
+	deleteQuery := `DELETE FROM collection_tracks WHERE collection_id = $1 AND track_id = $2 RETURNING position`
+	if err := q.QueryRow(ctx, deleteQuery, collectionID, trackID).Scan(&removedPosition); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%w: track %s is not part of the collection", domain.ErrNotFound, trackID)
+		}
+		r.logger.ErrorContext(ctx, "Failed to remove collection track", "error", err, "collectionID", collectionID, "trackID", trackID)
+		return 0, fmt.Errorf("removing collection track: %w", err)
+	}
+	shiftQuery := `UPDATE collection_tracks SET position = position - 1 WHERE collection_id = $1 AND position > $2`
+	if _, err := q.Exec(ctx, shiftQuery, collectionID, removedPosition); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to shift collection tracks", "error", err, "collectionID", collectionID, "position", removedPosition)
+		return 0, fmt.Errorf("shifting collection tracks: %w", err)
 	}
+	return newVersion, nil
+}
 
-	r.logger.DebugContext(ctx, "ManageTracks repo operations completed (within transaction)", "collectionID", collectionID, "trackCount", len(orderedTrackIDs))
-	return nil // Usecase layer handles commit/rollback
+// UpdateSmartRule replaces the rule of a smart collection and returns the collection's new version.
+// expectedVersion guards against concurrent edits (0 skips the check); a mismatch returns
+// domain.ErrPreconditionFailed.
+func (r *AudioCollectionRepository) UpdateSmartRule(ctx context.Context, collectionID domain.CollectionID, rule domain.SmartRule, expectedVersion int) (int, error) {
+	q := r.getQuerier(ctx)
+	encoded, err := encodeSmartRule(&rule)
+	if err != nil {
+		return 0, err
+	}
+	query := `
+        UPDATE audio_collections SET smart_rule = $2, updated_at = $3, version = version + 1
+        WHERE id = $1 AND type = 'SMART' AND ($4 = 0 OR version = $4)
+        RETURNING version
+    `
+	var newVersion int
+	if err := q.QueryRow(ctx, query, collectionID, encoded, time.Now(), expectedVersion).Scan(&newVersion); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, r.explainNoUpdate(ctx, collectionID, nil)
+		}
+		r.logger.ErrorContext(ctx, "Error updating smart rule", "error", err, "collectionID", collectionID)
+		return 0, fmt.Errorf("updating smart rule: %w", err)
+	}
+	r.logger.InfoContext(ctx, "Smart rule updated", "collectionID", collectionID, "version", newVersion)
+	return newVersion, nil
 }
 
 func (r *AudioCollectionRepository) Delete(ctx context.Context, id domain.CollectionID) error {
@@ -257,34 +623,147 @@ func (r *AudioCollectionRepository) Delete(ctx context.Context, id domain.Collec
 	return nil
 }
 
+// TransferOwnership sets a new owner and returns the new version. expectedVersion guards
+// against concurrent edits (0 skips the check); a mismatch returns domain.ErrPreconditionFailed.
+// Memberships are managed by the caller.
+func (r *AudioCollectionRepository) TransferOwnership(ctx context.Context, collectionID domain.CollectionID, newOwnerID domain.UserID, expectedVersion int) (int, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        UPDATE audio_collections SET owner_id = $2, updated_at = $3, version = version + 1
+        WHERE id = $1 AND ($4 = 0 OR version = $4)
+        RETURNING version
+    `
+	var newVersion int
+	if err := q.QueryRow(ctx, query, collectionID, newOwnerID, time.Now(), expectedVersion).Scan(&newVersion); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, r.explainNoUpdate(ctx, collectionID, nil)
+		}
+		r.logger.ErrorContext(ctx, "Error transferring collection ownership", "error", err, "collectionID", collectionID)
+		return 0, fmt.Errorf("transferring collection ownership: %w", err)
+	}
+	r.logger.InfoContext(ctx, "Collection ownership transferred", "collectionID", collectionID, "newOwnerID", newOwnerID, "version", newVersion)
+	return newVersion, nil
+}
+
 // --- Helper Methods ---
 
-// exists checks if a collection with the given ID exists.
-func (r *AudioCollectionRepository) exists(ctx context.Context, id domain.CollectionID) (bool, error) {
+// bumpVersion increments the version and timestamp of a collection at expectedVersion (0 skips the
+// check) and returns the new version. The row lock it takes serializes concurrent track updates.
+func (r *AudioCollectionRepository) bumpVersion(ctx context.Context, id domain.CollectionID, expectedVersion int) (int, error) {
 	q := r.getQuerier(ctx)
-	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM audio_collections WHERE id = $1)`
-	err := q.QueryRow(ctx, query, id).Scan(&exists)
+	query := `
+        UPDATE audio_collections SET updated_at = $2, version = version + 1
+        WHERE id = $1 AND ($3 = 0 OR version = $3)
+        RETURNING version
+    `
+	var newVersion int
+	if err := q.QueryRow(ctx, query, id, time.Now(), expectedVersion).Scan(&newVersion); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, r.explainNoUpdate(ctx, id, nil)
+		}
+		r.logger.ErrorContext(ctx, "Failed to update collection version", "error", err, "collectionID", id)
+		return 0, fmt.Errorf("updating collection version: %w", err)
+	}
+	return newVersion, nil
+}
+
+// explainNoUpdate determines why a conditional update matched no row: the collection
+// is missing, owned by someone else (when ownerID is given), or at a different version.
+func (r *AudioCollectionRepository) explainNoUpdate(ctx context.Context, id domain.CollectionID, ownerID *domain.UserID) error {
+	current, err := r.FindByID(ctx, id)
 	if err != nil {
-		r.logger.ErrorContext(ctx, "Error checking collection existence", "error", err, "collectionID", id)
-		return false, fmt.Errorf("checking collection existence: %w", err)
+		return err // Handles ErrNotFound
 	}
-	return exists, nil
+	if ownerID != nil && current.OwnerID != *ownerID {
+		return domain.ErrPermissionDenied
+	}
+	return fmt.Errorf("%w: collection %s is at version %d", domain.ErrPreconditionFailed, id, current.Version)
 }
 
 // scanCollection scans a single row into a domain.AudioCollection.
 // CHANGED: Accepts RowScanner interface
 func (r *AudioCollectionRepository) scanCollection(ctx context.Context, row RowScanner) (*domain.AudioCollection, error) {
 	var collection domain.AudioCollection
+	var smartRule []byte
 	err := row.Scan(
 		&collection.ID, &collection.Title, &collection.Description, &collection.OwnerID,
-		&collection.Type, &collection.CreatedAt, &collection.UpdatedAt,
+		&collection.Type, &collection.Visibility, &collection.Version, &collection.PublishedAt, &smartRule, &collection.CreatedAt, &collection.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if collection.SmartRule, err = decodeSmartRule(smartRule); err != nil {
+		return nil, err
+	}
 	return &collection, nil
 }
 
+// smartRuleRecord is the JSON form of a domain.SmartRule stored in audio_collections.smart_rule.
+type smartRuleRecord struct {
+	LanguageCode  string   `json:"languageCode,omitempty"`
+	Level         string   `json:"level,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	MinDurationMs int64    `json:"minDurationMs,omitempty"`
+	MaxDurationMs int64    `json:"maxDurationMs,omitempty"`
+	UploaderID    string   `json:"uploaderId,omitempty"`
+	ListenState   string   `json:"listenState,omitempty"`
+	SortBy        string   `json:"sortBy,omitempty"`
+	SortDirection string   `json:"sortDirection,omitempty"`
+}
+
+// encodeSmartRule returns the JSONB value for a rule, or nil for collections without one.
+func encodeSmartRule(rule *domain.SmartRule) ([]byte, error) {
+	if rule == nil {
+		return nil, nil
+	}
+	record := smartRuleRecord{
+		LanguageCode:  rule.LanguageCode,
+		Level:         rule.Level.String(),
+		Tags:          rule.Tags,
+		MinDurationMs: rule.MinDuration.Milliseconds(),
+		MaxDurationMs: rule.MaxDuration.Milliseconds(),
+		ListenState:   rule.ListenState.String(),
+		SortBy:        rule.SortBy,
+		SortDirection: rule.SortDirection,
+	}
+	if rule.UploaderID != nil {
+		record.UploaderID = rule.UploaderID.String()
+	}
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("encoding smart rule: %w", err)
+	}
+	return encoded, nil
+}
+
+// decodeSmartRule parses a JSONB smart_rule value; NULL yields nil.
+func decodeSmartRule(data []byte) (*domain.SmartRule, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var record smartRuleRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("decoding smart rule: %w", err)
+	}
+	rule := &domain.SmartRule{
+		LanguageCode:  record.LanguageCode,
+		Level:         domain.AudioLevel(record.Level),
+		Tags:          record.Tags,
+		MinDuration:   time.Duration(record.MinDurationMs) * time.Millisecond,
+		MaxDuration:   time.Duration(record.MaxDurationMs) * time.Millisecond,
+		ListenState:   domain.ListenState(record.ListenState),
+		SortBy:        record.SortBy,
+		SortDirection: record.SortDirection,
+	}
+	if record.UploaderID != "" {
+		uploaderID, err := domain.UserIDFromString(record.UploaderID)
+		if err != nil {
+			return nil, fmt.Errorf("decoding smart rule: %w", err)
+		}
+		rule.UploaderID = &uploaderID
+	}
+	return rule, nil
+}
+
 // Ensure implementation satisfies the interface
 var _ port.AudioCollectionRepository = (*AudioCollectionRepository)(nil)
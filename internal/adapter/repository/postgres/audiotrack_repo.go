@@ -45,10 +45,19 @@ func (r *AudioTrackRepository) Create(ctx context.Context, track *domain.AudioTr
 		INSERT INTO audio_tracks
 			(id, title, description, language_code, level, duration_ms,
 			 minio_bucket, minio_object_key, cover_image_url, uploader_id,
-			 is_public, tags, created_at, updated_at)
+			 is_public, tags, content_sha256, size_bytes, version, created_at, updated_at)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 	`
+	if track.Version == 0 {
+		track.Version = 1
+	}
+	// The checksum is usually computed after creation (see SetContentChecksum).
+	var contentSHA256 *string
+	var sizeBytes *int64
+	if track.ContentSHA256 != "" {
+		contentSHA256, sizeBytes = &track.ContentSHA256, &track.SizeBytes
+	}
 	_, err := q.Exec(ctx, query,
 		track.ID,
 		track.Title,
@@ -62,6 +71,9 @@ func (r *AudioTrackRepository) Create(ctx context.Context, track *domain.AudioTr
 		track.UploaderID,
 		track.IsPublic,
 		pq.Array(track.Tags),
+		contentSHA256,
+		sizeBytes,
+		track.Version,
 		track.CreatedAt,
 		track.UpdatedAt,
 	)
@@ -90,7 +102,7 @@ func (r *AudioTrackRepository) FindByID(ctx context.Context, id domain.TrackID)
 	query := `
         SELECT id, title, description, language_code, level, duration_ms,
                minio_bucket, minio_object_key, cover_image_url, uploader_id,
-               is_public, tags, created_at, updated_at
+               is_public, tags, content_sha256, size_bytes, version, created_at, updated_at
         FROM audio_tracks
         WHERE id = $1
     `
@@ -105,6 +117,27 @@ func (r *AudioTrackRepository) FindByID(ctx context.Context, id domain.TrackID)
 	return track, nil
 }
 
+// FindByObjectKey retrieves the track stored under the given object key.
+func (r *AudioTrackRepository) FindByObjectKey(ctx context.Context, objectKey string) (*domain.AudioTrack, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        SELECT id, title, description, language_code, level, duration_ms,
+               minio_bucket, minio_object_key, cover_image_url, uploader_id,
+               is_public, tags, content_sha256, size_bytes, version, created_at, updated_at
+        FROM audio_tracks
+        WHERE minio_object_key = $1
+    `
+	track, err := r.scanTrack(ctx, q.QueryRow(ctx, query, objectKey))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding audio track by object key", "error", err, "objectKey", objectKey)
+		return nil, fmt.Errorf("finding audio track by object key: %w", err)
+	}
+	return track, nil
+}
+
 func (r *AudioTrackRepository) ListByIDs(ctx context.Context, ids []domain.TrackID) ([]*domain.AudioTrack, error) {
 	q := r.getQuerier(ctx)
 	if len(ids) == 0 {
@@ -117,7 +150,7 @@ func (r *AudioTrackRepository) ListByIDs(ctx context.Context, ids []domain.Track
 	querySimple := `
         SELECT id, title, description, language_code, level, duration_ms,
                minio_bucket, minio_object_key, cover_image_url, uploader_id,
-               is_public, tags, created_at, updated_at
+               is_public, tags, content_sha256, size_bytes, version, created_at, updated_at
         FROM audio_tracks
         WHERE id = ANY($1)
     `
@@ -156,7 +189,7 @@ func (r *AudioTrackRepository) List(ctx context.Context, filters port.ListTracks
 	argID := 1
 	baseQuery := ` FROM audio_tracks `
 	countQuery := `SELECT count(*) ` + baseQuery
-	selectQuery := `SELECT id, title, description, language_code, level, duration_ms, minio_bucket, minio_object_key, cover_image_url, uploader_id, is_public, tags, created_at, updated_at ` + baseQuery
+	selectQuery := `SELECT id, title, description, language_code, level, duration_ms, minio_bucket, minio_object_key, cover_image_url, uploader_id, is_public, tags, content_sha256, size_bytes, version, created_at, updated_at ` + baseQuery
 	whereClause := " WHERE 1=1"
 
 	// Apply filters from ListTracksFilters
@@ -190,6 +223,33 @@ func (r *AudioTrackRepository) List(ctx context.Context, filters port.ListTracks
 		args = append(args, pq.Array(filters.Tags))
 		argID++
 	}
+	if filters.MinDuration != nil {
+		whereClause += fmt.Sprintf(" AND duration_ms >= $%d", argID)
+		args = append(args, *filters.MinDuration)
+		argID++
+	}
+	if filters.MaxDuration != nil {
+		whereClause += fmt.Sprintf(" AND duration_ms <= $%d", argID)
+		args = append(args, *filters.MaxDuration)
+		argID++
+	}
+	if filters.VisibleTo != nil {
+		whereClause += fmt.Sprintf(" AND (is_public OR uploader_id = $%d)", argID)
+		args = append(args, *filters.VisibleTo)
+		argID++
+	}
+	if filters.ListenedBy != nil && filters.Listened != nil {
+		// Semi-join with playback_progress; a track counts as listened once the progress reaches the completion threshold
+		listened := fmt.Sprintf(`EXISTS (SELECT 1 FROM playback_progress p
+            WHERE p.track_id = audio_tracks.id AND p.user_id = $%d
+              AND audio_tracks.duration_ms > interval '0' AND p.progress_ms >= audio_tracks.duration_ms * $%d::float8)`, argID, argID+1)
+		if !*filters.Listened {
+			listened = "NOT " + listened
+		}
+		whereClause += " AND " + listened
+		args = append(args, *filters.ListenedBy, domain.CompletionThreshold)
+		argID += 2
+	}
 
 	var total int
 	err := q.QueryRow(ctx, countQuery+whereClause, args...).Scan(&total)
@@ -242,6 +302,9 @@ func (r *AudioTrackRepository) List(ctx context.Context, filters port.ListTracks
 	return tracks, total, nil
 }
 
+// Update saves all track fields. track.Version is the version the caller expects (0 skips
+// the check); on success it is set to the new version. A version mismatch returns
+// domain.ErrPreconditionFailed.
 func (r *AudioTrackRepository) Update(ctx context.Context, track *domain.AudioTrack) error {
 	q := r.getQuerier(ctx)
 	track.UpdatedAt = time.Now()
@@ -249,18 +312,26 @@ func (r *AudioTrackRepository) Update(ctx context.Context, track *domain.AudioTr
 		UPDATE audio_tracks SET
 			title = $2, description = $3, language_code = $4, level = $5, duration_ms = $6,
 			minio_bucket = $7, minio_object_key = $8, cover_image_url = $9, uploader_id = $10,
-			is_public = $11, tags = $12, updated_at = $13
-		WHERE id = $1
+			is_public = $11, tags = $12, updated_at = $13, version = version + 1
+		WHERE id = $1 AND ($14 = 0 OR version = $14)
+		RETURNING version
 	`
-	cmdTag, err := q.Exec(ctx, query,
+	err := q.QueryRow(ctx, query,
 		track.ID, track.Title, track.Description,
 		track.Language.Code(),
 		track.Level,
 		track.Duration, // Use time.Duration directly, pgx handles INTERVAL
 		track.MinioBucket, track.MinioObjectKey, track.CoverImageURL, track.UploaderID,
-		track.IsPublic, pq.Array(track.Tags), track.UpdatedAt,
-	)
+		track.IsPublic, pq.Array(track.Tags), track.UpdatedAt, track.Version,
+	).Scan(&track.Version)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			current, findErr := r.FindByID(ctx, track.ID)
+			if findErr != nil {
+				return findErr // Handles ErrNotFound
+			}
+			return fmt.Errorf("%w: track %s is at version %d", domain.ErrPreconditionFailed, track.ID, current.Version)
+		}
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == UniqueViolation {
 			if strings.Contains(pgErr.ConstraintName, "audio_tracks_minio_object_key_key") {
@@ -272,13 +343,125 @@ func (r *AudioTrackRepository) Update(ctx context.Context, track *domain.AudioTr
 		r.logger.ErrorContext(ctx, "Error updating audio track", "error", err, "trackID", track.ID)
 		return fmt.Errorf("updating audio track: %w", err)
 	}
+	r.logger.InfoContext(ctx, "Audio track updated successfully", "trackID", track.ID, "version", track.Version)
+	return nil
+}
+
+// SetContentChecksum records the checksum and size of the track's audio object. It does not
+// change the track version, since clients cannot edit these fields.
+func (r *AudioTrackRepository) SetContentChecksum(ctx context.Context, id domain.TrackID, contentSHA256 string, sizeBytes int64) error {
+	q := r.getQuerier(ctx)
+	query := `UPDATE audio_tracks SET content_sha256 = $2, size_bytes = $3 WHERE id = $1`
+	cmdTag, err := q.Exec(ctx, query, id, contentSHA256, sizeBytes)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error setting audio track checksum", "error", err, "trackID", id)
+		return fmt.Errorf("setting audio track checksum: %w", err)
+	}
 	if cmdTag.RowsAffected() == 0 {
 		return domain.ErrNotFound
 	}
-	r.logger.InfoContext(ctx, "Audio track updated successfully", "trackID", track.ID)
 	return nil
 }
 
+// ListByContentHash retrieves every track whose audio object has the given checksum, oldest first.
+func (r *AudioTrackRepository) ListByContentHash(ctx context.Context, contentSHA256 string) ([]*domain.AudioTrack, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        SELECT id, title, description, language_code, level, duration_ms,
+               minio_bucket, minio_object_key, cover_image_url, uploader_id,
+               is_public, tags, content_sha256, size_bytes, version, created_at, updated_at
+        FROM audio_tracks
+        WHERE content_sha256 = $1
+        ORDER BY created_at
+    `
+	rows, err := q.Query(ctx, query, contentSHA256)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing audio tracks by content hash", "error", err)
+		return nil, fmt.Errorf("listing audio tracks by content hash: %w", err)
+	}
+	defer rows.Close()
+	tracks := make([]*domain.AudioTrack, 0)
+	for rows.Next() {
+		track, err := r.scanTrack(ctx, rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning track in ListByContentHash", "error", err)
+			continue
+		}
+		tracks = append(tracks, track)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating track rows in ListByContentHash", "error", err)
+		return nil, fmt.Errorf("iterating track rows: %w", err)
+	}
+	return tracks, nil
+}
+
+// ListDuplicates groups tracks that share a checksum, most recently uploaded group first.
+// With filter.ViewerID set, only groups containing one of the viewer's tracks are listed and
+// only the viewer's own and public tracks are included in them.
+func (r *AudioTrackRepository) ListDuplicates(ctx context.Context, filter port.DuplicateTracksFilter, page pagination.Page) ([]port.DuplicateTrackGroup, int, error) {
+	q := r.getQuerier(ctx)
+	var args []interface{}
+	visible := func(alias string) string { return "" }
+	having := ""
+	if filter.ViewerID != nil {
+		args = append(args, *filter.ViewerID)
+		visible = func(alias string) string {
+			return fmt.Sprintf(" AND (%[1]suploader_id = $1 OR %[1]sis_public)", alias)
+		}
+		having = " AND bool_or(uploader_id = $1)"
+	}
+	groupsQuery := `
+        SELECT content_sha256, max(created_at) AS last_created_at
+        FROM audio_tracks
+        WHERE content_sha256 IS NOT NULL` + visible("") + `
+        GROUP BY content_sha256
+        HAVING count(*) > 1` + having
+
+	var total int
+	if err := q.QueryRow(ctx, `SELECT count(*) FROM (`+groupsQuery+`) g`, args...).Scan(&total); err != nil {
+		r.logger.ErrorContext(ctx, "Error counting duplicate tracks", "error", err)
+		return nil, 0, fmt.Errorf("counting duplicate tracks: %w", err)
+	}
+	if total == 0 {
+		return []port.DuplicateTrackGroup{}, 0, nil
+	}
+
+	pageArgs := append(args, page.Limit, page.Offset)
+	query := fmt.Sprintf(`
+        SELECT t.id, t.title, t.description, t.language_code, t.level, t.duration_ms,
+               t.minio_bucket, t.minio_object_key, t.cover_image_url, t.uploader_id,
+               t.is_public, t.tags, t.content_sha256, t.size_bytes, t.version, t.created_at, t.updated_at
+        FROM (%s ORDER BY last_created_at DESC, content_sha256 LIMIT $%d OFFSET $%d) g
+        JOIN audio_tracks t ON t.content_sha256 = g.content_sha256%s
+        ORDER BY g.last_created_at DESC, g.content_sha256, t.created_at
+    `, groupsQuery, len(args)+1, len(args)+2, visible("t."))
+
+	rows, err := q.Query(ctx, query, pageArgs...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing duplicate tracks", "error", err, "page", page)
+		return nil, 0, fmt.Errorf("listing duplicate tracks: %w", err)
+	}
+	defer rows.Close()
+	groups := make([]port.DuplicateTrackGroup, 0, page.Limit)
+	for rows.Next() {
+		track, err := r.scanTrack(ctx, rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning track in ListDuplicates", "error", err)
+			continue
+		}
+		if n := len(groups); n == 0 || groups[n-1].ContentSHA256 != track.ContentSHA256 {
+			groups = append(groups, port.DuplicateTrackGroup{ContentSHA256: track.ContentSHA256, SizeBytes: track.SizeBytes})
+		}
+		groups[len(groups)-1].Tracks = append(groups[len(groups)-1].Tracks, track)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating track rows in ListDuplicates", "error", err)
+		return nil, 0, fmt.Errorf("iterating track rows: %w", err)
+	}
+	return groups, total, nil
+}
+
 func (r *AudioTrackRepository) Delete(ctx context.Context, id domain.TrackID) error {
 	q := r.getQuerier(ctx)
 	query := `DELETE FROM audio_tracks WHERE id = $1`
@@ -314,6 +497,8 @@ func (r *AudioTrackRepository) scanTrack(ctx context.Context, row RowScanner) (*
 	// var duration time.Duration // Scan directly into domain field
 	var tags pq.StringArray
 	var uploaderID uuid.NullUUID
+	var contentSHA256 *string
+	var sizeBytes *int64
 
 	err := row.Scan(
 		&track.ID, &track.Title, &track.Description,
@@ -322,7 +507,7 @@ func (r *AudioTrackRepository) scanTrack(ctx context.Context, row RowScanner) (*
 		&track.Duration, // Scan INTERVAL directly into time.Duration
 		&track.MinioBucket, &track.MinioObjectKey, &track.CoverImageURL,
 		&uploaderID,
-		&track.IsPublic, &tags, &track.CreatedAt, &track.UpdatedAt,
+		&track.IsPublic, &tags, &contentSHA256, &sizeBytes, &track.Version, &track.CreatedAt, &track.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -342,6 +527,12 @@ func (r *AudioTrackRepository) scanTrack(ctx context.Context, row RowScanner) (*
 	// Convert scanned milliseconds back to time.Duration
 	// track.Duration = time.Duration(durationMs) * time.Millisecond
 	track.Tags = tags
+	if contentSHA256 != nil {
+		track.ContentSHA256 = *contentSHA256
+	}
+	if sizeBytes != nil {
+		track.SizeBytes = *sizeBytes
+	}
 
 	if uploaderID.Valid {
 		uid := domain.UserID(uploaderID.UUID)
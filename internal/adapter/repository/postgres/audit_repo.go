@@ -0,0 +1,167 @@
+// internal/adapter/repository/postgres/audit_repo.go
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+)
+
+// AuditEventRepository stores audit events in the append-only audit_events table.
+// The table rejects UPDATE/DELETE via trigger, so only inserts and reads are offered.
+type AuditEventRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewAuditEventRepository creates a new AuditEventRepository.
+func NewAuditEventRepository(db *pgxpool.Pool, logger *slog.Logger) *AuditEventRepository {
+	repo := &AuditEventRepository{
+		db:     db,
+		logger: logger.With("repository", "AuditEventRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+// Append inserts an event. Inside TransactionManager.Execute it is written in the
+// caller's transaction, so the event commits or rolls back with the change it describes.
+func (r *AuditEventRepository) Append(ctx context.Context, event *domain.AuditEvent) error {
+	q := r.getQuerier(ctx)
+	var diffJSON []byte
+	if len(event.Diff) > 0 {
+		var err error
+		diffJSON, err = json.Marshal(event.Diff)
+		if err != nil {
+			return fmt.Errorf("marshalling audit diff: %w", err)
+		}
+	}
+
+	query := `
+        INSERT INTO audit_events (id, occurred_at, actor_id, action, target_type, target_id, diff, request_id, ip_address)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `
+	_, err := q.Exec(ctx, query,
+		event.ID, event.OccurredAt, event.ActorID, event.Action, event.TargetType,
+		event.TargetID, diffJSON, event.RequestID, event.IPAddress,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error appending audit event", "error", err, "action", event.Action, "targetID", event.TargetID)
+		return fmt.Errorf("appending audit event: %w", err)
+	}
+	return nil
+}
+
+// List retrieves audit events matching the filter, newest first.
+func (r *AuditEventRepository) List(ctx context.Context, filter port.AuditEventFilter, page pagination.Page) ([]*domain.AuditEvent, int, error) {
+	q := r.getQuerier(ctx)
+	var args []interface{}
+	argID := 1
+	countQuery := `SELECT count(*) FROM audit_events`
+	selectQuery := `SELECT id, occurred_at, actor_id, action, target_type, target_id, diff, request_id, ip_address FROM audit_events`
+	whereClause := " WHERE 1=1"
+
+	if filter.ActorID != nil {
+		whereClause += fmt.Sprintf(" AND actor_id = $%d", argID)
+		args = append(args, *filter.ActorID)
+		argID++
+	}
+	if filter.Action != nil && *filter.Action != "" {
+		whereClause += fmt.Sprintf(" AND action = $%d", argID)
+		args = append(args, *filter.Action)
+		argID++
+	}
+	if filter.TargetType != nil && *filter.TargetType != "" {
+		whereClause += fmt.Sprintf(" AND target_type = $%d", argID)
+		args = append(args, *filter.TargetType)
+		argID++
+	}
+	if filter.TargetID != nil && *filter.TargetID != "" {
+		whereClause += fmt.Sprintf(" AND target_id = $%d", argID)
+		args = append(args, *filter.TargetID)
+		argID++
+	}
+	if filter.From != nil {
+		whereClause += fmt.Sprintf(" AND occurred_at >= $%d", argID)
+		args = append(args, *filter.From)
+		argID++
+	}
+	if filter.To != nil {
+		whereClause += fmt.Sprintf(" AND occurred_at < $%d", argID)
+		args = append(args, *filter.To)
+		argID++
+	}
+
+	var total int
+	if err := q.QueryRow(ctx, countQuery+whereClause, args...).Scan(&total); err != nil {
+		r.logger.ErrorContext(ctx, "Error counting audit events", "error", err, "filter", filter)
+		return nil, 0, fmt.Errorf("counting audit events: %w", err)
+	}
+	if total == 0 {
+		return []*domain.AuditEvent{}, 0, nil
+	}
+
+	paginationClause := fmt.Sprintf(" ORDER BY occurred_at DESC, id LIMIT $%d OFFSET $%d", argID, argID+1)
+	args = append(args, page.Limit, page.Offset)
+
+	rows, err := q.Query(ctx, selectQuery+whereClause+paginationClause, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing audit events", "error", err, "filter", filter, "page", page)
+		return nil, 0, fmt.Errorf("listing audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*domain.AuditEvent, 0, page.Limit)
+	for rows.Next() {
+		event, err := r.scanAuditEvent(rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning audit event", "error", err)
+			return nil, 0, fmt.Errorf("scanning audit event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating audit event rows", "error", err)
+		return nil, 0, fmt.Errorf("iterating audit event rows: %w", err)
+	}
+	return events, total, nil
+}
+
+// scanAuditEvent scans a row into a domain.AuditEvent, decoding the JSONB diff.
+func (r *AuditEventRepository) scanAuditEvent(row RowScanner) (*domain.AuditEvent, error) {
+	var event domain.AuditEvent
+	var actorID pgtype.UUID
+	var diffJSON []byte
+
+	err := row.Scan(
+		&event.ID, &event.OccurredAt, &actorID, &event.Action, &event.TargetType,
+		&event.TargetID, &diffJSON, &event.RequestID, &event.IPAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if actorID.Valid {
+		id := domain.UserID(actorID.Bytes)
+		event.ActorID = &id
+	}
+	if len(diffJSON) > 0 {
+		if err := json.Unmarshal(diffJSON, &event.Diff); err != nil {
+			return nil, fmt.Errorf("decoding audit diff for event %s: %w", event.ID, err)
+		}
+	}
+	return &event, nil
+}
+
+// Compile-time check to ensure AuditEventRepository satisfies the port.AuditEventRepository interface
+var _ port.AuditEventRepository = (*AuditEventRepository)(nil)
@@ -0,0 +1,240 @@
+// internal/adapter/repository/postgres/collection_member_repo.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// CollectionMemberRepository stores collection memberships in collection_members and
+// invitations in collection_invitations.
+type CollectionMemberRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewCollectionMemberRepository creates a new CollectionMemberRepository.
+func NewCollectionMemberRepository(db *pgxpool.Pool, logger *slog.Logger) *CollectionMemberRepository {
+	repo := &CollectionMemberRepository{
+		db:     db,
+		logger: logger.With("repository", "CollectionMemberRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+const invitationColumns = `id, collection_id, inviter_id, invitee_id, invitee_email, role, status, created_at, responded_at`
+
+// --- Members ---
+
+func (r *CollectionMemberRepository) FindMember(ctx context.Context, collectionID domain.CollectionID, userID domain.UserID) (*domain.CollectionMember, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        SELECT collection_id, user_id, role, added_at
+        FROM collection_members
+        WHERE collection_id = $1 AND user_id = $2
+    `
+	member, err := r.scanMember(q.QueryRow(ctx, query, collectionID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding collection member", "error", err, "collectionID", collectionID, "userID", userID)
+		return nil, fmt.Errorf("finding collection member: %w", err)
+	}
+	return member, nil
+}
+
+func (r *CollectionMemberRepository) ListMembers(ctx context.Context, collectionID domain.CollectionID) ([]*domain.CollectionMember, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        SELECT collection_id, user_id, role, added_at
+        FROM collection_members
+        WHERE collection_id = $1
+        ORDER BY added_at ASC, user_id
+    `
+	rows, err := q.Query(ctx, query, collectionID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing collection members", "error", err, "collectionID", collectionID)
+		return nil, fmt.Errorf("listing collection members: %w", err)
+	}
+	defer rows.Close()
+	members := make([]*domain.CollectionMember, 0)
+	for rows.Next() {
+		member, err := r.scanMember(rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning collection member", "error", err, "collectionID", collectionID)
+			return nil, fmt.Errorf("scanning collection member: %w", err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating collection members: %w", err)
+	}
+	return members, nil
+}
+
+func (r *CollectionMemberRepository) SaveMember(ctx context.Context, member *domain.CollectionMember) error {
+	q := r.getQuerier(ctx)
+	query := `
+        INSERT INTO collection_members (collection_id, user_id, role, added_at)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (collection_id, user_id) DO UPDATE SET role = EXCLUDED.role
+    `
+	if _, err := q.Exec(ctx, query, member.CollectionID, member.UserID, member.Role, member.AddedAt); err != nil {
+		r.logger.ErrorContext(ctx, "Error saving collection member", "error", err, "collectionID", member.CollectionID, "userID", member.UserID)
+		return fmt.Errorf("saving collection member: %w", err)
+	}
+	return nil
+}
+
+func (r *CollectionMemberRepository) RemoveMember(ctx context.Context, collectionID domain.CollectionID, userID domain.UserID) error {
+	q := r.getQuerier(ctx)
+	cmdTag, err := q.Exec(ctx, `DELETE FROM collection_members WHERE collection_id = $1 AND user_id = $2`, collectionID, userID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error removing collection member", "error", err, "collectionID", collectionID, "userID", userID)
+		return fmt.Errorf("removing collection member: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// --- Invitations ---
+
+func (r *CollectionMemberRepository) CreateInvitation(ctx context.Context, invitation *domain.CollectionInvitation) error {
+	q := r.getQuerier(ctx)
+	query := `
+        INSERT INTO collection_invitations (` + invitationColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `
+	_, err := q.Exec(ctx, query,
+		invitation.ID, invitation.CollectionID, invitation.InviterID, invitation.InviteeID, invitation.InviteeEmail,
+		invitation.Role, invitation.Status, invitation.CreatedAt, invitation.RespondedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == UniqueViolation {
+			return fmt.Errorf("%w: this user already has a pending invitation to the collection", domain.ErrConflict)
+		}
+		r.logger.ErrorContext(ctx, "Error creating collection invitation", "error", err, "collectionID", invitation.CollectionID)
+		return fmt.Errorf("creating collection invitation: %w", err)
+	}
+	return nil
+}
+
+func (r *CollectionMemberRepository) FindInvitation(ctx context.Context, id domain.InvitationID) (*domain.CollectionInvitation, error) {
+	q := r.getQuerier(ctx)
+	query := `SELECT ` + invitationColumns + ` FROM collection_invitations WHERE id = $1`
+	invitation, err := r.scanInvitation(q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding collection invitation", "error", err, "invitationID", id)
+		return nil, fmt.Errorf("finding collection invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+func (r *CollectionMemberRepository) UpdateInvitation(ctx context.Context, invitation *domain.CollectionInvitation) error {
+	q := r.getQuerier(ctx)
+	query := `
+        UPDATE collection_invitations SET status = $2, invitee_id = $3, responded_at = $4
+        WHERE id = $1
+    `
+	cmdTag, err := q.Exec(ctx, query, invitation.ID, invitation.Status, invitation.InviteeID, invitation.RespondedAt)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error updating collection invitation", "error", err, "invitationID", invitation.ID)
+		return fmt.Errorf("updating collection invitation: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *CollectionMemberRepository) ListPendingInvitations(ctx context.Context, collectionID domain.CollectionID) ([]*domain.CollectionInvitation, error) {
+	query := `
+        SELECT ` + invitationColumns + ` FROM collection_invitations
+        WHERE collection_id = $1 AND status = 'PENDING'
+        ORDER BY created_at DESC, id
+    `
+	return r.listInvitations(ctx, query, collectionID)
+}
+
+func (r *CollectionMemberRepository) ListPendingInvitationsFor(ctx context.Context, userID domain.UserID, email string) ([]*domain.CollectionInvitation, error) {
+	query := `
+        SELECT ` + invitationColumns + ` FROM collection_invitations
+        WHERE status = 'PENDING' AND (invitee_id = $1 OR (invitee_id IS NULL AND invitee_email = lower($2)))
+        ORDER BY created_at DESC, id
+    `
+	return r.listInvitations(ctx, query, userID, email)
+}
+
+// --- Helper Methods ---
+
+func (r *CollectionMemberRepository) listInvitations(ctx context.Context, query string, args ...any) ([]*domain.CollectionInvitation, error) {
+	q := r.getQuerier(ctx)
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing collection invitations", "error", err)
+		return nil, fmt.Errorf("listing collection invitations: %w", err)
+	}
+	defer rows.Close()
+	invitations := make([]*domain.CollectionInvitation, 0)
+	for rows.Next() {
+		invitation, err := r.scanInvitation(rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning collection invitation", "error", err)
+			return nil, fmt.Errorf("scanning collection invitation: %w", err)
+		}
+		invitations = append(invitations, invitation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating collection invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (r *CollectionMemberRepository) scanMember(row RowScanner) (*domain.CollectionMember, error) {
+	var member domain.CollectionMember
+	if err := row.Scan(&member.CollectionID, &member.UserID, &member.Role, &member.AddedAt); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *CollectionMemberRepository) scanInvitation(row RowScanner) (*domain.CollectionInvitation, error) {
+	var invitation domain.CollectionInvitation
+	var inviteeID uuid.NullUUID
+	err := row.Scan(
+		&invitation.ID, &invitation.CollectionID, &invitation.InviterID, &inviteeID, &invitation.InviteeEmail,
+		&invitation.Role, &invitation.Status, &invitation.CreatedAt, &invitation.RespondedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if inviteeID.Valid {
+		id := domain.UserID(inviteeID.UUID)
+		invitation.InviteeID = &id
+	}
+	return &invitation, nil
+}
+
+// Compile-time check to ensure CollectionMemberRepository satisfies the port.CollectionMemberRepository interface
+var _ port.CollectionMemberRepository = (*CollectionMemberRepository)(nil)
@@ -0,0 +1,315 @@
+// internal/adapter/repository/postgres/course_repo.go
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+)
+
+// CourseRepository stores course outlines in course_sections and course_lessons, and
+// enrollments in course_enrollments.
+type CourseRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewCourseRepository creates a new CourseRepository.
+func NewCourseRepository(db *pgxpool.Pool, logger *slog.Logger) *CourseRepository {
+	repo := &CourseRepository{
+		db:     db,
+		logger: logger.With("repository", "CourseRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+const courseCollectionColumns = `c.id, c.title, c.description, c.owner_id, c.type, c.visibility, c.version, c.published_at, c.created_at, c.updated_at`
+
+func (r *CourseRepository) GetOutline(ctx context.Context, collectionID domain.CollectionID) ([]domain.CourseSection, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        SELECT s.id, s.title, s.description, l.id, l.track_id, l.title, l.notes, l.attachments
+        FROM course_sections s
+        LEFT JOIN course_lessons l ON l.section_id = s.id
+        WHERE s.collection_id = $1
+        ORDER BY s.position ASC, l.position ASC
+    `
+	rows, err := q.Query(ctx, query, collectionID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error loading course outline", "error", err, "collectionID", collectionID)
+		return nil, fmt.Errorf("loading course outline: %w", err)
+	}
+	defer rows.Close()
+
+	sections := make([]domain.CourseSection, 0)
+	for rows.Next() {
+		var section domain.CourseSection
+		var lessonID, trackID uuid.NullUUID
+		var lessonTitle, notes *string
+		var attachments []byte
+		if err := rows.Scan(&section.ID, &section.Title, &section.Description, &lessonID, &trackID, &lessonTitle, &notes, &attachments); err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning course outline row", "error", err, "collectionID", collectionID)
+			return nil, fmt.Errorf("scanning course outline: %w", err)
+		}
+		if n := len(sections); n == 0 || sections[n-1].ID != section.ID {
+			sections = append(sections, section)
+		}
+		if !lessonID.Valid {
+			continue // Section without lessons
+		}
+		lesson := domain.Lesson{ID: domain.LessonID(lessonID.UUID), TrackID: domain.TrackID(trackID.UUID), Title: *lessonTitle, Notes: *notes}
+		if err := json.Unmarshal(attachments, &lesson.Attachments); err != nil {
+			return nil, fmt.Errorf("decoding lesson attachments: %w", err)
+		}
+		current := &sections[len(sections)-1]
+		current.Lessons = append(current.Lessons, lesson)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating course outline rows", "error", err, "collectionID", collectionID)
+		return nil, fmt.Errorf("iterating course outline: %w", err)
+	}
+	return sections, nil
+}
+
+func (r *CourseRepository) ReplaceOutline(ctx context.Context, collectionID domain.CollectionID, sections []domain.CourseSection) error {
+	q := r.getQuerier(ctx)
+
+	// Lessons are removed with their sections (ON DELETE CASCADE)
+	if _, err := q.Exec(ctx, `DELETE FROM course_sections WHERE collection_id = $1`, collectionID); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to delete old course outline", "error", err, "collectionID", collectionID)
+		return fmt.Errorf("deleting old course outline: %w", err)
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for i, section := range sections {
+		batch.Queue(`
+            INSERT INTO course_sections (id, collection_id, position, title, description)
+            VALUES ($1, $2, $3, $4, $5)
+        `, section.ID, collectionID, i, section.Title, section.Description)
+		for j, lesson := range section.Lessons {
+			attachments := lesson.Attachments
+			if attachments == nil {
+				attachments = []domain.LessonAttachment{}
+			}
+			encoded, err := json.Marshal(attachments)
+			if err != nil {
+				return fmt.Errorf("encoding lesson attachments: %w", err)
+			}
+			batch.Queue(`
+                INSERT INTO course_lessons (id, section_id, collection_id, track_id, position, title, notes, attachments)
+                VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+            `, lesson.ID, section.ID, collectionID, lesson.TrackID, j, lesson.Title, lesson.Notes, encoded)
+		}
+	}
+
+	br := q.SendBatch(ctx, batch)
+	defer br.Close()
+	for i := 0; i < batch.Len(); i++ {
+		if _, err := br.Exec(); err != nil {
+			r.logger.ErrorContext(ctx, "Failed to insert course outline row", "error", err, "collectionID", collectionID, "row", i)
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == UniqueViolation {
+				return fmt.Errorf("%w: a section or lesson ID is already used by another course", domain.ErrConflict)
+			}
+			return fmt.Errorf("inserting course outline: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *CourseRepository) SetPublished(ctx context.Context, collectionID domain.CollectionID, publishedAt *time.Time, expectedVersion int) (int, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        UPDATE audio_collections SET published_at = $2, updated_at = $3, version = version + 1
+        WHERE id = $1 AND ($4 = 0 OR version = $4)
+        RETURNING version
+    `
+	var newVersion int
+	err := q.QueryRow(ctx, query, collectionID, publishedAt, time.Now(), expectedVersion).Scan(&newVersion)
+	if err == nil {
+		return newVersion, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		r.logger.ErrorContext(ctx, "Error updating course publication", "error", err, "collectionID", collectionID)
+		return 0, fmt.Errorf("updating course publication: %w", err)
+	}
+
+	var current int
+	if err := q.QueryRow(ctx, `SELECT version FROM audio_collections WHERE id = $1`, collectionID).Scan(&current); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.ErrNotFound
+		}
+		return 0, fmt.Errorf("checking collection version: %w", err)
+	}
+	return 0, fmt.Errorf("%w: collection %s is at version %d", domain.ErrPreconditionFailed, collectionID, current)
+}
+
+func (r *CourseRepository) ListPublished(ctx context.Context, filter port.CourseFilter, page pagination.Page) ([]port.CourseSummary, int, error) {
+	q := r.getQuerier(ctx)
+	args := []interface{}{domain.TypeCourse}
+	whereClause := ` WHERE c.type = $1 AND c.published_at IS NOT NULL`
+	if filter.Query != nil && *filter.Query != "" {
+		args = append(args, "%"+*filter.Query+"%")
+		whereClause += fmt.Sprintf(" AND (c.title ILIKE $%d OR c.description ILIKE $%d)", len(args), len(args))
+	}
+	if filter.OwnerID != nil {
+		args = append(args, *filter.OwnerID)
+		whereClause += fmt.Sprintf(" AND c.owner_id = $%d", len(args))
+	}
+
+	var total int
+	if err := q.QueryRow(ctx, `SELECT count(*) FROM audio_collections c`+whereClause, args...).Scan(&total); err != nil {
+		r.logger.ErrorContext(ctx, "Error counting published courses", "error", err)
+		return nil, 0, fmt.Errorf("counting published courses: %w", err)
+	}
+	if total == 0 {
+		return []port.CourseSummary{}, 0, nil
+	}
+
+	query := `
+        SELECT ` + courseCollectionColumns + `,
+               (SELECT count(*) FROM course_lessons l WHERE l.collection_id = c.id),
+               (SELECT count(*) FROM course_enrollments e WHERE e.collection_id = c.id)
+        FROM audio_collections c` + whereClause +
+		fmt.Sprintf(" ORDER BY c.published_at DESC, c.id ASC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, page.Limit, page.Offset)
+
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing published courses", "error", err)
+		return nil, 0, fmt.Errorf("listing published courses: %w", err)
+	}
+	defer rows.Close()
+
+	courses := make([]port.CourseSummary, 0, page.Limit)
+	for rows.Next() {
+		var summary port.CourseSummary
+		var c domain.AudioCollection
+		err := rows.Scan(
+			&c.ID, &c.Title, &c.Description, &c.OwnerID, &c.Type, &c.Visibility, &c.Version, &c.PublishedAt, &c.CreatedAt, &c.UpdatedAt,
+			&summary.LessonCount, &summary.EnrollmentCount,
+		)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning published course", "error", err)
+			return nil, 0, fmt.Errorf("scanning published course: %w", err)
+		}
+		summary.Course = &c
+		courses = append(courses, summary)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating published courses", "error", err)
+		return nil, 0, fmt.Errorf("iterating published courses: %w", err)
+	}
+	return courses, total, nil
+}
+
+func (r *CourseRepository) Enroll(ctx context.Context, enrollment *domain.Enrollment) (bool, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        INSERT INTO course_enrollments (user_id, collection_id, enrolled_at)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (user_id, collection_id) DO NOTHING
+    `
+	cmdTag, err := q.Exec(ctx, query, enrollment.UserID, enrollment.CollectionID, enrollment.EnrolledAt)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error creating enrollment", "error", err, "userID", enrollment.UserID, "collectionID", enrollment.CollectionID)
+		return false, fmt.Errorf("creating enrollment: %w", err)
+	}
+	return cmdTag.RowsAffected() > 0, nil
+}
+
+func (r *CourseRepository) Unenroll(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) error {
+	q := r.getQuerier(ctx)
+	cmdTag, err := q.Exec(ctx, `DELETE FROM course_enrollments WHERE user_id = $1 AND collection_id = $2`, userID, collectionID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting enrollment", "error", err, "userID", userID, "collectionID", collectionID)
+		return fmt.Errorf("deleting enrollment: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *CourseRepository) FindEnrollment(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) (*domain.Enrollment, error) {
+	q := r.getQuerier(ctx)
+	query := `SELECT user_id, collection_id, enrolled_at FROM course_enrollments WHERE user_id = $1 AND collection_id = $2`
+	var e domain.Enrollment
+	if err := q.QueryRow(ctx, query, userID, collectionID).Scan(&e.UserID, &e.CollectionID, &e.EnrolledAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding enrollment", "error", err, "userID", userID, "collectionID", collectionID)
+		return nil, fmt.Errorf("finding enrollment: %w", err)
+	}
+	return &e, nil
+}
+
+func (r *CourseRepository) ListEnrollments(ctx context.Context, userID domain.UserID, page pagination.Page) ([]port.EnrolledCourse, int, error) {
+	q := r.getQuerier(ctx)
+	var total int
+	if err := q.QueryRow(ctx, `SELECT count(*) FROM course_enrollments WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		r.logger.ErrorContext(ctx, "Error counting enrollments", "error", err, "userID", userID)
+		return nil, 0, fmt.Errorf("counting enrollments: %w", err)
+	}
+	if total == 0 {
+		return []port.EnrolledCourse{}, 0, nil
+	}
+
+	query := `
+        SELECT e.user_id, e.collection_id, e.enrolled_at, ` + courseCollectionColumns + `
+        FROM course_enrollments e
+        JOIN audio_collections c ON c.id = e.collection_id
+        WHERE e.user_id = $1
+        ORDER BY e.enrolled_at DESC, e.collection_id ASC
+        LIMIT $2 OFFSET $3
+    `
+	rows, err := q.Query(ctx, query, userID, page.Limit, page.Offset)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing enrollments", "error", err, "userID", userID)
+		return nil, 0, fmt.Errorf("listing enrollments: %w", err)
+	}
+	defer rows.Close()
+
+	courses := make([]port.EnrolledCourse, 0, page.Limit)
+	for rows.Next() {
+		var e domain.Enrollment
+		var c domain.AudioCollection
+		err := rows.Scan(
+			&e.UserID, &e.CollectionID, &e.EnrolledAt,
+			&c.ID, &c.Title, &c.Description, &c.OwnerID, &c.Type, &c.Visibility, &c.Version, &c.PublishedAt, &c.CreatedAt, &c.UpdatedAt,
+		)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning enrollment", "error", err, "userID", userID)
+			return nil, 0, fmt.Errorf("scanning enrollment: %w", err)
+		}
+		courses = append(courses, port.EnrolledCourse{Enrollment: &e, Course: &c})
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating enrollments", "error", err, "userID", userID)
+		return nil, 0, fmt.Errorf("iterating enrollments: %w", err)
+	}
+	return courses, total, nil
+}
+
+// Compile-time check to ensure CourseRepository satisfies the port.CourseRepository interface
+var _ port.CourseRepository = (*CourseRepository)(nil)
@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/yvanyang/language-learning-player-api/internal/config" // Adjust import path
 )
 
 // NewPgxPool creates a new PostgreSQL connection pool.
-func NewPgxPool(ctx context.Context, cfg config.DatabaseConfig, logger *slog.Logger) (*pgxpool.Pool, error) {
+// tracer is optional; when set, every query is reported to it (e.g. for tracing spans).
+func NewPgxPool(ctx context.Context, cfg config.DatabaseConfig, tracer pgx.QueryTracer, logger *slog.Logger) (*pgxpool.Pool, error) {
 	if cfg.DSN == "" {
 		return nil, fmt.Errorf("database DSN is required")
 	}
@@ -27,8 +29,10 @@ func NewPgxPool(ctx context.Context, cfg config.DatabaseConfig, logger *slog.Log
 	pgxConfig.MaxConnLifetime = cfg.ConnMaxLifetime
 	pgxConfig.MaxConnIdleTime = cfg.ConnMaxIdleTime
 
-	// Optional: Add logging hook for pgx
-	// pgxConfig.ConnConfig.Logger = pgxLogger // Requires implementing pgx logging interface
+	// Optional: Query tracer hook (tracing spans per query)
+	if tracer != nil {
+		pgxConfig.ConnConfig.Tracer = tracer
+	}
 
 	logger.Info("Connecting to PostgreSQL", "host", pgxConfig.ConnConfig.Host, "port", pgxConfig.ConnConfig.Port, "db", pgxConfig.ConnConfig.Database)
 
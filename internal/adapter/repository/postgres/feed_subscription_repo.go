@@ -0,0 +1,260 @@
+// internal/adapter/repository/postgres/feed_subscription_repo.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lib/pq"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+)
+
+// FeedSubscriptionRepository stores external podcast feeds in feed_subscriptions and the
+// episodes ingested from them in feed_episodes.
+type FeedSubscriptionRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewFeedSubscriptionRepository creates a new FeedSubscriptionRepository.
+func NewFeedSubscriptionRepository(db *pgxpool.Pool, logger *slog.Logger) *FeedSubscriptionRepository {
+	repo := &FeedSubscriptionRepository{
+		db:     db,
+		logger: logger.With("repository", "FeedSubscriptionRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+const feedSubscriptionColumns = `id, feed_url, owner_id, collection_id, title, language_code, level, is_public, tags,
+               etag, last_modified, last_fetched_at, next_fetch_at, last_error, created_at, updated_at`
+
+func (r *FeedSubscriptionRepository) Create(ctx context.Context, sub *domain.FeedSubscription) error {
+	q := r.getQuerier(ctx)
+	query := `
+        INSERT INTO feed_subscriptions (` + feedSubscriptionColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+    `
+	_, err := q.Exec(ctx, query,
+		sub.ID, sub.FeedURL, sub.OwnerID, sub.CollectionID, sub.Title, sub.LanguageCode, sub.Level, sub.IsPublic, pq.Array(sub.Tags),
+		sub.ETag, sub.LastModified, sub.LastFetchedAt, sub.NextFetchAt, sub.LastError, sub.CreatedAt, sub.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == UniqueViolation {
+			return fmt.Errorf("%w: the owner is already subscribed to this feed", domain.ErrConflict)
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == ForeignKeyViolation {
+			return fmt.Errorf("creating feed subscription: %w: owner not found", domain.ErrInvalidArgument)
+		}
+		r.logger.ErrorContext(ctx, "Error creating feed subscription", "error", err, "feedURL", sub.FeedURL)
+		return fmt.Errorf("creating feed subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *FeedSubscriptionRepository) FindByID(ctx context.Context, id domain.FeedSubscriptionID) (*domain.FeedSubscription, error) {
+	q := r.getQuerier(ctx)
+	query := `SELECT ` + feedSubscriptionColumns + ` FROM feed_subscriptions WHERE id = $1`
+	sub, err := r.scanSubscription(q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding feed subscription by ID", "error", err, "feedID", id)
+		return nil, fmt.Errorf("finding feed subscription by ID: %w", err)
+	}
+	return sub, nil
+}
+
+func (r *FeedSubscriptionRepository) List(ctx context.Context, page pagination.Page) ([]*domain.FeedSubscription, int, error) {
+	q := r.getQuerier(ctx)
+	var total int
+	if err := q.QueryRow(ctx, `SELECT count(*) FROM feed_subscriptions`).Scan(&total); err != nil {
+		r.logger.ErrorContext(ctx, "Error counting feed subscriptions", "error", err)
+		return nil, 0, fmt.Errorf("counting feed subscriptions: %w", err)
+	}
+	if total == 0 {
+		return []*domain.FeedSubscription{}, 0, nil
+	}
+
+	query := `
+        SELECT ` + feedSubscriptionColumns + `
+        FROM feed_subscriptions
+        ORDER BY created_at DESC, id
+        LIMIT $1 OFFSET $2
+    `
+	subs, err := r.query(ctx, query, page.Limit, page.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return subs, total, nil
+}
+
+func (r *FeedSubscriptionRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.FeedSubscription, error) {
+	query := `
+        SELECT ` + feedSubscriptionColumns + `
+        FROM feed_subscriptions
+        WHERE next_fetch_at <= $1
+        ORDER BY next_fetch_at, id
+        LIMIT $2
+    `
+	return r.query(ctx, query, now, limit)
+}
+
+func (r *FeedSubscriptionRepository) Update(ctx context.Context, sub *domain.FeedSubscription) error {
+	q := r.getQuerier(ctx)
+	query := `
+        UPDATE feed_subscriptions
+        SET collection_id = $2, title = $3, language_code = $4, level = $5, is_public = $6, tags = $7,
+            etag = $8, last_modified = $9, last_fetched_at = $10, next_fetch_at = $11, last_error = $12, updated_at = $13
+        WHERE id = $1
+    `
+	cmdTag, err := q.Exec(ctx, query,
+		sub.ID, sub.CollectionID, sub.Title, sub.LanguageCode, sub.Level, sub.IsPublic, pq.Array(sub.Tags),
+		sub.ETag, sub.LastModified, sub.LastFetchedAt, sub.NextFetchAt, sub.LastError, sub.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error updating feed subscription", "error", err, "feedID", sub.ID)
+		return fmt.Errorf("updating feed subscription: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *FeedSubscriptionRepository) Delete(ctx context.Context, id domain.FeedSubscriptionID) error {
+	q := r.getQuerier(ctx)
+	cmdTag, err := q.Exec(ctx, `DELETE FROM feed_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting feed subscription", "error", err, "feedID", id)
+		return fmt.Errorf("deleting feed subscription: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *FeedSubscriptionRepository) ListEpisodes(ctx context.Context, id domain.FeedSubscriptionID) ([]*domain.FeedEpisode, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        SELECT subscription_id, guid, track_id, enclosure_url, published_at, created_at, updated_at
+        FROM feed_episodes
+        WHERE subscription_id = $1
+        ORDER BY created_at, guid
+    `
+	rows, err := q.Query(ctx, query, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing feed episodes", "error", err, "feedID", id)
+		return nil, fmt.Errorf("listing feed episodes: %w", err)
+	}
+	defer rows.Close()
+
+	var episodes []*domain.FeedEpisode
+	for rows.Next() {
+		var ep domain.FeedEpisode
+		var trackID uuid.NullUUID
+		if err := rows.Scan(&ep.SubscriptionID, &ep.GUID, &trackID, &ep.EnclosureURL, &ep.PublishedAt, &ep.CreatedAt, &ep.UpdatedAt); err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning feed episode", "error", err)
+			return nil, fmt.Errorf("scanning feed episode: %w", err)
+		}
+		if trackID.Valid {
+			tid := domain.TrackID(trackID.UUID)
+			ep.TrackID = &tid
+		}
+		episodes = append(episodes, &ep)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating feed episode rows", "error", err)
+		return nil, fmt.Errorf("iterating feed episode rows: %w", err)
+	}
+	return episodes, nil
+}
+
+func (r *FeedSubscriptionRepository) SaveEpisode(ctx context.Context, ep *domain.FeedEpisode) error {
+	q := r.getQuerier(ctx)
+	query := `
+        INSERT INTO feed_episodes (subscription_id, guid, track_id, enclosure_url, published_at, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (subscription_id, guid) DO UPDATE
+        SET track_id = EXCLUDED.track_id, enclosure_url = EXCLUDED.enclosure_url,
+            published_at = EXCLUDED.published_at, updated_at = EXCLUDED.updated_at
+    `
+	_, err := q.Exec(ctx, query, ep.SubscriptionID, ep.GUID, ep.TrackID, ep.EnclosureURL, ep.PublishedAt, ep.CreatedAt, ep.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == ForeignKeyViolation {
+			return fmt.Errorf("saving feed episode: %w: subscription or track not found", domain.ErrNotFound)
+		}
+		r.logger.ErrorContext(ctx, "Error saving feed episode", "error", err, "feedID", ep.SubscriptionID, "guid", ep.GUID)
+		return fmt.Errorf("saving feed episode: %w", err)
+	}
+	return nil
+}
+
+// --- Helper Methods ---
+
+func (r *FeedSubscriptionRepository) query(ctx context.Context, query string, args ...any) ([]*domain.FeedSubscription, error) {
+	q := r.getQuerier(ctx)
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing feed subscriptions", "error", err)
+		return nil, fmt.Errorf("listing feed subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := []*domain.FeedSubscription{}
+	for rows.Next() {
+		sub, err := r.scanSubscription(rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning feed subscription", "error", err)
+			return nil, fmt.Errorf("scanning feed subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating feed subscription rows", "error", err)
+		return nil, fmt.Errorf("iterating feed subscription rows: %w", err)
+	}
+	return subs, nil
+}
+
+func (r *FeedSubscriptionRepository) scanSubscription(row RowScanner) (*domain.FeedSubscription, error) {
+	var sub domain.FeedSubscription
+	var collectionID uuid.NullUUID
+	var level string
+	var tags pq.StringArray
+
+	err := row.Scan(
+		&sub.ID, &sub.FeedURL, &sub.OwnerID, &collectionID, &sub.Title, &sub.LanguageCode, &level, &sub.IsPublic, &tags,
+		&sub.ETag, &sub.LastModified, &sub.LastFetchedAt, &sub.NextFetchAt, &sub.LastError, &sub.CreatedAt, &sub.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if collectionID.Valid {
+		cid := domain.CollectionID(collectionID.UUID)
+		sub.CollectionID = &cid
+	}
+	sub.Level = domain.AudioLevel(level)
+	sub.Tags = tags
+	return &sub, nil
+}
+
+// Compile-time check to ensure FeedSubscriptionRepository satisfies the port.FeedSubscriptionRepository interface
+var _ port.FeedSubscriptionRepository = (*FeedSubscriptionRepository)(nil)
@@ -0,0 +1,95 @@
+// internal/adapter/repository/postgres/feed_token_repo.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// FeedTokenRepository stores secret podcast feed tokens in collection_feed_tokens.
+type FeedTokenRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewFeedTokenRepository creates a new FeedTokenRepository.
+func NewFeedTokenRepository(db *pgxpool.Pool, logger *slog.Logger) *FeedTokenRepository {
+	repo := &FeedTokenRepository{
+		db:     db,
+		logger: logger.With("repository", "FeedTokenRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+const feedTokenColumns = `id, collection_id, user_id, token_hash, created_at`
+
+func (r *FeedTokenRepository) Save(ctx context.Context, token *domain.FeedToken) error {
+	q := r.getQuerier(ctx)
+	query := `
+        INSERT INTO collection_feed_tokens (` + feedTokenColumns + `)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (collection_id, user_id) DO UPDATE
+        SET id = EXCLUDED.id, token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at
+    `
+	if _, err := q.Exec(ctx, query, token.ID, token.CollectionID, token.UserID, token.TokenHash, token.CreatedAt); err != nil {
+		r.logger.ErrorContext(ctx, "Error saving feed token", "error", err, "collectionID", token.CollectionID, "userID", token.UserID)
+		return fmt.Errorf("saving feed token: %w", err)
+	}
+	return nil
+}
+
+func (r *FeedTokenRepository) FindByID(ctx context.Context, id domain.FeedTokenID) (*domain.FeedToken, error) {
+	return r.findOne(ctx, `SELECT `+feedTokenColumns+` FROM collection_feed_tokens WHERE id = $1`, id)
+}
+
+func (r *FeedTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.FeedToken, error) {
+	return r.findOne(ctx, `SELECT `+feedTokenColumns+` FROM collection_feed_tokens WHERE token_hash = $1`, tokenHash)
+}
+
+func (r *FeedTokenRepository) FindByCollectionAndUser(ctx context.Context, collectionID domain.CollectionID, userID domain.UserID) (*domain.FeedToken, error) {
+	return r.findOne(ctx, `SELECT `+feedTokenColumns+` FROM collection_feed_tokens WHERE collection_id = $1 AND user_id = $2`, collectionID, userID)
+}
+
+func (r *FeedTokenRepository) Delete(ctx context.Context, collectionID domain.CollectionID, userID domain.UserID) error {
+	q := r.getQuerier(ctx)
+	cmdTag, err := q.Exec(ctx, `DELETE FROM collection_feed_tokens WHERE collection_id = $1 AND user_id = $2`, collectionID, userID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting feed token", "error", err, "collectionID", collectionID, "userID", userID)
+		return fmt.Errorf("deleting feed token: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// --- Helper Methods ---
+
+func (r *FeedTokenRepository) findOne(ctx context.Context, query string, args ...any) (*domain.FeedToken, error) {
+	q := r.getQuerier(ctx)
+	var token domain.FeedToken
+	err := q.QueryRow(ctx, query, args...).Scan(&token.ID, &token.CollectionID, &token.UserID, &token.TokenHash, &token.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding feed token", "error", err)
+		return nil, fmt.Errorf("finding feed token: %w", err)
+	}
+	return &token, nil
+}
+
+// Compile-time check to ensure FeedTokenRepository satisfies the port.FeedTokenRepository interface
+var _ port.FeedTokenRepository = (*FeedTokenRepository)(nil)
@@ -0,0 +1,149 @@
+// internal/adapter/repository/postgres/idempotency_repo.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// claimAttempts bounds the claim/lookup loop when a key is released concurrently
+// between the failed insert and the lookup of the existing row.
+const claimAttempts = 3
+
+// IdempotencyRepository stores Idempotency-Key records in PostgreSQL.
+// Records are written outside any business transaction: the claim must be visible
+// to concurrent retries while the original request is still running.
+type IdempotencyRepository struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NewIdempotencyRepository(db *pgxpool.Pool, logger *slog.Logger) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db:     db,
+		logger: logger.With("repository", "IdempotencyRepository"),
+	}
+}
+
+// Claim inserts an in-progress record. An expired record with the same key is
+// replaced, as is an in-progress one for the same request whose lease has run out;
+// any other is returned as existing, so a different request still gets a conflict.
+func (r *IdempotencyRepository) Claim(ctx context.Context, record *port.IdempotencyRecord) (*port.IdempotencyRecord, bool, error) {
+	claimQuery := `
+        INSERT INTO idempotency_keys AS k (user_id, key, request_hash, completed, expires_at)
+        VALUES ($1, $2, $3, false, $4)
+        ON CONFLICT (user_id, key) DO UPDATE SET
+            request_hash = EXCLUDED.request_hash,
+            completed = false,
+            response_status = NULL,
+            response_content_type = NULL,
+            response_headers = NULL,
+            response_body = NULL,
+            created_at = now(),
+            expires_at = EXCLUDED.expires_at
+        WHERE k.expires_at < now()
+           OR (NOT k.completed AND k.created_at < now() - $5::interval AND k.request_hash = EXCLUDED.request_hash)
+        RETURNING k.created_at
+    `
+	for attempt := 0; attempt < claimAttempts; attempt++ {
+		var claimedAt time.Time
+		err := r.db.QueryRow(ctx, claimQuery, record.UserID, record.Key, record.RequestHash, record.ExpiresAt, record.Lease).Scan(&claimedAt)
+		if err == nil {
+			record.ClaimedAt = claimedAt
+			return nil, true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			r.logger.ErrorContext(ctx, "Error claiming idempotency key", "error", err, "userID", record.UserID)
+			return nil, false, fmt.Errorf("claiming idempotency key: %w", err)
+		}
+
+		// Conflict with an unexpired record or a live claim: load it.
+		existing, err := r.find(ctx, record.UserID, record.Key)
+		if err == nil {
+			return existing, false, nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, false, err
+		}
+		// Released in the meantime; try to claim again.
+	}
+	return nil, false, fmt.Errorf("claiming idempotency key: record changed concurrently")
+}
+
+func (r *IdempotencyRepository) find(ctx context.Context, userID domain.UserID, key string) (*port.IdempotencyRecord, error) {
+	query := `
+        SELECT user_id, key, request_hash, completed, response_status, response_content_type, response_headers, response_body, expires_at
+        FROM idempotency_keys
+        WHERE user_id = $1 AND key = $2
+    `
+	var rec port.IdempotencyRecord
+	var status *int32
+	var contentType *string
+	err := r.db.QueryRow(ctx, query, userID, key).Scan(
+		&rec.UserID, &rec.Key, &rec.RequestHash, &rec.Completed, &status, &contentType, &rec.Headers, &rec.Body, &rec.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding idempotency key", "error", err, "userID", userID)
+		return nil, fmt.Errorf("finding idempotency key: %w", err)
+	}
+	if status != nil {
+		rec.StatusCode = int(*status)
+	}
+	if contentType != nil {
+		rec.ContentType = *contentType
+	}
+	return &rec, nil
+}
+
+// Complete stores the final response for a claimed key, unless the claim was taken over.
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *port.IdempotencyRecord) error {
+	query := `
+        UPDATE idempotency_keys
+        SET completed = true, response_status = $3, response_content_type = $4, response_headers = $5, response_body = $6
+        WHERE user_id = $1 AND key = $2 AND created_at = $7 AND completed = false
+    `
+	cmdTag, err := r.db.Exec(ctx, query, record.UserID, record.Key, record.StatusCode, record.ContentType, record.Headers, record.Body, record.ClaimedAt)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error completing idempotency key", "error", err, "userID", record.UserID)
+		return fmt.Errorf("completing idempotency key: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Release removes an in-progress key so that the client can retry, unless the claim was taken over.
+func (r *IdempotencyRepository) Release(ctx context.Context, record *port.IdempotencyRecord) error {
+	query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2 AND created_at = $3 AND completed = false`
+	if _, err := r.db.Exec(ctx, query, record.UserID, record.Key, record.ClaimedAt); err != nil {
+		r.logger.ErrorContext(ctx, "Error releasing idempotency key", "error", err, "userID", record.UserID)
+		return fmt.Errorf("releasing idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes records past their expiry.
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at < now()`)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting expired idempotency keys", "error", err)
+		return 0, fmt.Errorf("deleting expired idempotency keys: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// Compile-time check to ensure IdempotencyRepository satisfies the port.IdempotencyRepository interface
+var _ port.IdempotencyRepository = (*IdempotencyRepository)(nil)
@@ -0,0 +1,143 @@
+// internal/adapter/repository/postgres/import_run_repo.go
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// ImportRunRepository stores archive imports in the import_runs table.
+type ImportRunRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewImportRunRepository creates a new ImportRunRepository.
+func NewImportRunRepository(db *pgxpool.Pool, logger *slog.Logger) *ImportRunRepository {
+	repo := &ImportRunRepository{
+		db:     db,
+		logger: logger.With("repository", "ImportRunRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+const importRunColumns = `id, requested_by, owner_id, source, status, report, error, created_at, updated_at, completed_at`
+
+func (r *ImportRunRepository) Create(ctx context.Context, run *domain.ImportRun) error {
+	q := r.getQuerier(ctx)
+	report, errMsg, err := importRunOutcome(run)
+	if err != nil {
+		return err
+	}
+	query := `
+        INSERT INTO import_runs (` + importRunColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+    `
+	_, err = q.Exec(ctx, query,
+		run.ID, run.RequestedBy, run.OwnerID, run.Source, run.Status, report, errMsg,
+		run.CreatedAt, run.UpdatedAt, run.CompletedAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error creating import run", "error", err, "importID", run.ID)
+		return fmt.Errorf("creating import run: %w", err)
+	}
+	return nil
+}
+
+func (r *ImportRunRepository) FindByID(ctx context.Context, id domain.ImportRunID) (*domain.ImportRun, error) {
+	q := r.getQuerier(ctx)
+	query := `SELECT ` + importRunColumns + ` FROM import_runs WHERE id = $1`
+	run, err := r.scanImportRun(q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding import run by ID", "error", err, "importID", id)
+		return nil, fmt.Errorf("finding import run by ID: %w", err)
+	}
+	return run, nil
+}
+
+func (r *ImportRunRepository) Update(ctx context.Context, run *domain.ImportRun) error {
+	q := r.getQuerier(ctx)
+	report, errMsg, err := importRunOutcome(run)
+	if err != nil {
+		return err
+	}
+	query := `
+        UPDATE import_runs
+        SET status = $2, report = $3, error = $4, updated_at = $5, completed_at = $6
+        WHERE id = $1
+    `
+	cmdTag, err := q.Exec(ctx, query, run.ID, run.Status, report, errMsg, run.UpdatedAt, run.CompletedAt)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error updating import run", "error", err, "importID", run.ID)
+		return fmt.Errorf("updating import run: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// importRunOutcome returns the nullable report and error columns of a run.
+func importRunOutcome(run *domain.ImportRun) ([]byte, *string, error) {
+	var report []byte
+	if run.Report != nil {
+		var err error
+		if report, err = json.Marshal(run.Report); err != nil {
+			return nil, nil, fmt.Errorf("encoding import report: %w", err)
+		}
+	}
+	var errMsg *string
+	if run.Error != "" {
+		errMsg = &run.Error
+	}
+	return report, errMsg, nil
+}
+
+func (r *ImportRunRepository) scanImportRun(row RowScanner) (*domain.ImportRun, error) {
+	var run domain.ImportRun
+	var requestedBy uuid.NullUUID
+	var report []byte
+	var errMsg *string
+
+	err := row.Scan(
+		&run.ID, &requestedBy, &run.OwnerID, &run.Source, &run.Status, &report, &errMsg,
+		&run.CreatedAt, &run.UpdatedAt, &run.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if requestedBy.Valid {
+		uid := domain.UserID(requestedBy.UUID)
+		run.RequestedBy = &uid
+	}
+	if report != nil {
+		run.Report = &domain.ImportReport{}
+		if err := json.Unmarshal(report, run.Report); err != nil {
+			return nil, fmt.Errorf("decoding import report: %w", err)
+		}
+	}
+	if errMsg != nil {
+		run.Error = *errMsg
+	}
+	return &run, nil
+}
+
+// Compile-time check to ensure ImportRunRepository satisfies the port.ImportRunRepository interface
+var _ port.ImportRunRepository = (*ImportRunRepository)(nil)
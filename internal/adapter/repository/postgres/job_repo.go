@@ -0,0 +1,157 @@
+// internal/adapter/repository/postgres/job_repo.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// JobRepository stores background jobs in the jobs table. Jobs are claimed with
+// FOR UPDATE SKIP LOCKED, so any number of workers can poll the same table.
+type JobRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewJobRepository creates a new JobRepository.
+func NewJobRepository(db *pgxpool.Pool, logger *slog.Logger) *JobRepository {
+	repo := &JobRepository{
+		db:     db,
+		logger: logger.With("repository", "JobRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+// Enqueue inserts a job. A duplicate unique key is reported as domain.ErrConflict.
+func (r *JobRepository) Enqueue(ctx context.Context, job *domain.Job) error {
+	q := r.getQuerier(ctx)
+	query := `
+        INSERT INTO jobs (id, job_type, payload, status, attempts, max_attempts, run_at, unique_key, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        ON CONFLICT (unique_key) DO NOTHING
+    `
+	cmdTag, err := q.Exec(ctx, query,
+		job.ID, job.Type, job.Payload, job.Status, job.Attempts, job.MaxAttempts,
+		job.RunAt, job.UniqueKey, job.CreatedAt, job.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error enqueueing job", "error", err, "jobType", job.Type)
+		return fmt.Errorf("enqueueing job: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 && job.UniqueKey != nil {
+		return fmt.Errorf("%w: job with unique key '%s' already exists", domain.ErrConflict, *job.UniqueKey)
+	}
+	return nil
+}
+
+// Claim leases due jobs, oldest first, and counts the attempt.
+func (r *JobRepository) Claim(ctx context.Context, limit int, visibility time.Duration) ([]*domain.Job, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        WITH due AS (
+            SELECT id
+            FROM jobs
+            WHERE (status = 'queued' AND run_at <= now())
+               OR (status = 'running' AND locked_until < now())
+            ORDER BY run_at
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        UPDATE jobs j
+        SET status = 'running', attempts = j.attempts + 1,
+            locked_until = now() + make_interval(secs => $2), updated_at = now()
+        FROM due
+        WHERE j.id = due.id
+        RETURNING j.id, j.job_type, j.payload, j.status, j.attempts, j.max_attempts, j.run_at, j.locked_until,
+                  j.last_error, j.unique_key, j.created_at, j.updated_at, j.completed_at
+    `
+	rows, err := q.Query(ctx, query, limit, visibility.Seconds())
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error claiming jobs", "error", err)
+		return nil, fmt.Errorf("claiming jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*domain.Job
+	for rows.Next() {
+		job, err := r.scanJob(rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning claimed job", "error", err)
+			return nil, fmt.Errorf("scanning claimed job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating claimed job rows", "error", err)
+		return nil, fmt.Errorf("iterating claimed job rows: %w", err)
+	}
+	return jobs, nil
+}
+
+// Save stores the job state after an attempt. The update only applies if the job has not
+// been claimed again since (its visibility timeout expired); otherwise domain.ErrConflict is returned.
+func (r *JobRepository) Save(ctx context.Context, job *domain.Job) error {
+	q := r.getQuerier(ctx)
+	query := `
+        UPDATE jobs
+        SET status = $2, attempts = $3, run_at = $4, locked_until = $5, last_error = $6,
+            completed_at = $7, updated_at = $8
+        WHERE id = $1 AND attempts = $3
+    `
+	var lastError *string
+	if job.LastError != "" {
+		lastError = &job.LastError
+	}
+	cmdTag, err := q.Exec(ctx, query,
+		job.ID, job.Status, job.Attempts, job.RunAt, job.LockedUntil, lastError, job.CompletedAt, job.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error saving job", "error", err, "jobID", job.ID)
+		return fmt.Errorf("saving job: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: job %s was claimed again or removed", domain.ErrConflict, job.ID)
+	}
+	return nil
+}
+
+// DeleteFinished removes completed jobs older than before.
+func (r *JobRepository) DeleteFinished(ctx context.Context, before time.Time) (int64, error) {
+	q := r.getQuerier(ctx)
+	cmdTag, err := q.Exec(ctx, `DELETE FROM jobs WHERE status IN ('succeeded', 'dead') AND completed_at < $1`, before)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting finished jobs", "error", err)
+		return 0, fmt.Errorf("deleting finished jobs: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+func (r *JobRepository) scanJob(row RowScanner) (*domain.Job, error) {
+	var job domain.Job
+	var lastError *string
+	err := row.Scan(
+		&job.ID, &job.Type, &job.Payload, &job.Status, &job.Attempts, &job.MaxAttempts, &job.RunAt, &job.LockedUntil,
+		&lastError, &job.UniqueKey, &job.CreatedAt, &job.UpdatedAt, &job.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if lastError != nil {
+		job.LastError = *lastError
+	}
+	return &job, nil
+}
+
+// Compile-time check to ensure JobRepository satisfies the port.JobRepository interface
+var _ port.JobRepository = (*JobRepository)(nil)
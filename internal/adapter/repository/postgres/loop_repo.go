@@ -0,0 +1,175 @@
+// internal/adapter/repository/postgres/loop_repo.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+)
+
+// LoopRepository implements port.LoopRepository using PostgreSQL.
+type LoopRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewLoopRepository creates a new LoopRepository.
+func NewLoopRepository(db *pgxpool.Pool, logger *slog.Logger) *LoopRepository {
+	repo := &LoopRepository{
+		db:     db,
+		logger: logger.With("repository", "LoopRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+const loopColumns = `id, user_id, track_id, start_ms, end_ms, label, repeat_count, playback_speed, created_at, updated_at`
+
+func (r *LoopRepository) Create(ctx context.Context, loop *domain.Loop) error {
+	q := r.getQuerier(ctx)
+	query := `
+        INSERT INTO loops (` + loopColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+    `
+	_, err := q.Exec(ctx, query,
+		loop.ID, loop.UserID, loop.TrackID, loop.Start, loop.End, loop.Label, loop.RepeatCount, loop.PlaybackSpeed, loop.CreatedAt, loop.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == ForeignKeyViolation {
+			return fmt.Errorf("%w: track not found", domain.ErrNotFound) // Track deleted concurrently
+		}
+		r.logger.ErrorContext(ctx, "Error creating loop", "error", err, "userID", loop.UserID, "trackID", loop.TrackID)
+		return fmt.Errorf("creating loop: %w", err)
+	}
+	return nil
+}
+
+func (r *LoopRepository) FindByID(ctx context.Context, id domain.LoopID) (*domain.Loop, error) {
+	q := r.getQuerier(ctx)
+	query := `SELECT ` + loopColumns + ` FROM loops WHERE id = $1`
+	loop, err := r.scanLoop(q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding loop by ID", "error", err, "loopID", id)
+		return nil, fmt.Errorf("finding loop by ID: %w", err)
+	}
+	return loop, nil
+}
+
+func (r *LoopRepository) ListByUserAndTrack(ctx context.Context, userID domain.UserID, trackID domain.TrackID) ([]*domain.Loop, error) {
+	query := `
+        SELECT ` + loopColumns + `
+        FROM loops
+        WHERE user_id = $1 AND track_id = $2
+        ORDER BY start_ms ASC, created_at ASC
+    `
+	return r.query(ctx, query, userID, trackID)
+}
+
+func (r *LoopRepository) ListByUser(ctx context.Context, userID domain.UserID, page pagination.Page) ([]*domain.Loop, int, error) {
+	q := r.getQuerier(ctx)
+	var total int
+	if err := q.QueryRow(ctx, `SELECT count(*) FROM loops WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		r.logger.ErrorContext(ctx, "Error counting loops by user", "error", err, "userID", userID)
+		return nil, 0, fmt.Errorf("counting loops by user: %w", err)
+	}
+	if total == 0 {
+		return []*domain.Loop{}, 0, nil
+	}
+
+	query := `
+        SELECT ` + loopColumns + `
+        FROM loops
+        WHERE user_id = $1
+        ORDER BY created_at DESC, id
+        LIMIT $2 OFFSET $3
+    `
+	loops, err := r.query(ctx, query, userID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return loops, total, nil
+}
+
+func (r *LoopRepository) Update(ctx context.Context, loop *domain.Loop) error {
+	q := r.getQuerier(ctx)
+	query := `
+        UPDATE loops
+        SET start_ms = $2, end_ms = $3, label = $4, repeat_count = $5, playback_speed = $6, updated_at = $7
+        WHERE id = $1
+    `
+	cmdTag, err := q.Exec(ctx, query, loop.ID, loop.Start, loop.End, loop.Label, loop.RepeatCount, loop.PlaybackSpeed, loop.UpdatedAt)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error updating loop", "error", err, "loopID", loop.ID)
+		return fmt.Errorf("updating loop: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *LoopRepository) Delete(ctx context.Context, id domain.LoopID) error {
+	q := r.getQuerier(ctx)
+	cmdTag, err := q.Exec(ctx, `DELETE FROM loops WHERE id = $1`, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting loop", "error", err, "loopID", id)
+		return fmt.Errorf("deleting loop: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *LoopRepository) query(ctx context.Context, query string, args ...any) ([]*domain.Loop, error) {
+	q := r.getQuerier(ctx)
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing loops", "error", err)
+		return nil, fmt.Errorf("listing loops: %w", err)
+	}
+	defer rows.Close()
+
+	loops := make([]*domain.Loop, 0)
+	for rows.Next() {
+		loop, err := r.scanLoop(rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning loop", "error", err)
+			return nil, fmt.Errorf("scanning loop: %w", err)
+		}
+		loops = append(loops, loop)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating loop rows", "error", err)
+		return nil, fmt.Errorf("iterating loops: %w", err)
+	}
+	return loops, nil
+}
+
+func (r *LoopRepository) scanLoop(row RowScanner) (*domain.Loop, error) {
+	var l domain.Loop
+	err := row.Scan(&l.ID, &l.UserID, &l.TrackID, &l.Start, &l.End, &l.Label, &l.RepeatCount, &l.PlaybackSpeed, &l.CreatedAt, &l.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// Compile-time check to ensure LoopRepository satisfies the port.LoopRepository interface.
+var _ port.LoopRepository = (*LoopRepository)(nil)
@@ -0,0 +1,189 @@
+// internal/adapter/repository/postgres/migrator.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/migrations"
+)
+
+// migrationLockID is the advisory lock key held while migrating, so that two
+// processes never migrate the same database concurrently.
+const migrationLockID = 7263548192
+
+// MigrationStatus describes the schema version of the database.
+type MigrationStatus struct {
+	Version uint // 0 if no migration has been applied
+	Dirty   bool // A migration failed part-way (only possible with non-transactional tools)
+	Latest  uint
+	Pending []migrations.Migration
+}
+
+// Migrator applies the embedded SQL migrations. It keeps its state in the same
+// schema_migrations table as golang-migrate, so the two can be used interchangeably.
+// Each migration runs in its own transaction together with the version update.
+type Migrator struct {
+	db         *pgxpool.Pool
+	migrations []migrations.Migration
+	logger     *slog.Logger
+}
+
+// NewMigrator creates a Migrator for the migrations embedded in the binary.
+func NewMigrator(db *pgxpool.Pool, logger *slog.Logger) (*Migrator, error) {
+	list, err := migrations.List()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:         db,
+		migrations: list,
+		logger:     logger.With("repository", "Migrator"),
+	}, nil
+}
+
+// Status reports the current version and the migrations not yet applied.
+func (m *Migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	var status *MigrationStatus
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		version, dirty, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+		status = m.status(version, dirty)
+		return nil
+	})
+	return status, err
+}
+
+// Up applies all pending migrations and returns the versions applied.
+func (m *Migrator) Up(ctx context.Context) ([]uint, error) {
+	var applied []uint
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		version, dirty, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("database is dirty at version %d; fix the schema manually and force the version", version)
+		}
+		for _, mig := range m.status(version, dirty).Pending {
+			m.logger.InfoContext(ctx, "Applying migration", "version", mig.Version, "name", mig.Name)
+			if err := m.apply(ctx, conn, mig.Up, mig.Version); err != nil {
+				return fmt.Errorf("applying migration %d (%s): %w", mig.Version, mig.Name, err)
+			}
+			applied = append(applied, mig.Version)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the given number of migrations, newest first, and returns the versions reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]uint, error) {
+	var reverted []uint
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		version, dirty, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("database is dirty at version %d; fix the schema manually and force the version", version)
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			mig := m.migrations[i]
+			if mig.Version > version {
+				continue
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("migration %d (%s) has no down file", mig.Version, mig.Name)
+			}
+			var previous uint
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+			m.logger.InfoContext(ctx, "Reverting migration", "version", mig.Version, "name", mig.Name)
+			if err := m.apply(ctx, conn, mig.Down, previous); err != nil {
+				return fmt.Errorf("reverting migration %d (%s): %w", mig.Version, mig.Name, err)
+			}
+			reverted = append(reverted, mig.Version)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+func (m *Migrator) status(version uint, dirty bool) *MigrationStatus {
+	status := &MigrationStatus{Version: version, Dirty: dirty}
+	for _, mig := range m.migrations {
+		if mig.Version > version {
+			status.Pending = append(status.Pending, mig)
+		}
+		status.Latest = mig.Version
+	}
+	return status
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			m.logger.ErrorContext(ctx, "Failed to release migration lock", "error", err)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`); err != nil {
+		return fmt.Errorf("creating schema_migrations table: %w", err)
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) version(ctx context.Context, conn *pgxpool.Conn) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("reading migration version: %w", err)
+	}
+	return uint(version), dirty, nil
+}
+
+// apply runs a migration script and records newVersion (0 = none) in one transaction.
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, script string, newVersion uint) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }() // No-op once committed
+
+	// Without arguments, pgx uses the simple protocol, which allows multiple statements.
+	if _, err := tx.Exec(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return fmt.Errorf("clearing migration version: %w", err)
+	}
+	if newVersion > 0 {
+		if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, int64(newVersion)); err != nil {
+			return fmt.Errorf("recording migration version: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
+}
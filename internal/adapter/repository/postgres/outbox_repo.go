@@ -0,0 +1,50 @@
+// internal/adapter/repository/postgres/outbox_repo.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// OutboxRepository writes domain events to the outbox_events table.
+type OutboxRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewOutboxRepository creates a new OutboxRepository.
+func NewOutboxRepository(db *pgxpool.Pool, logger *slog.Logger) *OutboxRepository {
+	repo := &OutboxRepository{
+		db:     db,
+		logger: logger.With("repository", "OutboxRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+// Append inserts an event. Inside TransactionManager.Execute it is written in the
+// caller's transaction, so the event is only dispatched if the change is committed.
+func (r *OutboxRepository) Append(ctx context.Context, event *domain.OutboxEvent) error {
+	q := r.getQuerier(ctx)
+	query := `
+        INSERT INTO outbox_events (id, event_type, payload, occurred_at)
+        VALUES ($1, $2, $3, $4)
+    `
+	if _, err := q.Exec(ctx, query, event.ID, event.Type, event.Payload, event.OccurredAt); err != nil {
+		r.logger.ErrorContext(ctx, "Error appending outbox event", "error", err, "eventType", event.Type)
+		return fmt.Errorf("appending outbox event: %w", err)
+	}
+	return nil
+}
+
+// Compile-time check to ensure OutboxRepository satisfies the port.OutboxRepository interface
+var _ port.OutboxRepository = (*OutboxRepository)(nil)
@@ -121,6 +121,43 @@ func (r *PlaybackProgressRepository) ListByUser(ctx context.Context, userID doma
 	return progressList, total, nil
 }
 
+func (r *PlaybackProgressRepository) ListByUserAndTracks(ctx context.Context, userID domain.UserID, trackIDs []domain.TrackID) ([]*domain.PlaybackProgress, error) {
+	q := r.getQuerier(ctx)
+	if len(trackIDs) == 0 {
+		return []*domain.PlaybackProgress{}, nil
+	}
+	uuidStrs := make([]string, len(trackIDs))
+	for i, id := range trackIDs {
+		uuidStrs[i] = id.String()
+	}
+	query := `
+        SELECT user_id, track_id, progress_ms, last_listened_at
+        FROM playback_progress
+        WHERE user_id = $1 AND track_id = ANY($2)
+    `
+	rows, err := q.Query(ctx, query, userID, uuidStrs)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing progress by user and tracks", "error", err, "userID", userID)
+		return nil, fmt.Errorf("listing progress by user and tracks: %w", err)
+	}
+	defer rows.Close()
+
+	progressList := make([]*domain.PlaybackProgress, 0, len(trackIDs))
+	for rows.Next() {
+		progress, err := r.scanProgress(ctx, rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning progress in ListByUserAndTracks", "error", err)
+			continue
+		}
+		progressList = append(progressList, progress)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating progress rows in ListByUserAndTracks", "error", err)
+		return nil, fmt.Errorf("iterating progress rows: %w", err)
+	}
+	return progressList, nil
+}
+
 // Point 1: Updated scanProgress
 func (r *PlaybackProgressRepository) scanProgress(ctx context.Context, row RowScanner) (*domain.PlaybackProgress, error) {
 	var p domain.PlaybackProgress
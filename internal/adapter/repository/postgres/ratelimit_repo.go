@@ -0,0 +1,94 @@
+// internal/adapter/repository/postgres/ratelimit_repo.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// RateLimitRepository stores rate limiter buckets in PostgreSQL so that all API
+// replicas share the same limits. It implements GCRA (generic cell rate algorithm):
+// each bucket is a single "theoretical arrival time" (tat) updated by one atomic upsert.
+type RateLimitRepository struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NewRateLimitRepository(db *pgxpool.Pool, logger *slog.Logger) *RateLimitRepository {
+	return &RateLimitRepository{
+		db:     db,
+		logger: logger.With("repository", "RateLimitRepository"),
+	}
+}
+
+// Allow consumes one request from the bucket identified by key.
+// $2 is the emission interval (seconds per request) and $3 the burst tolerance
+// (emission interval * burst). A request is allowed when max(tat, now) + interval
+// stays within now + tolerance. All SET expressions see the old row, so allowed
+// and tat are computed from the same state.
+func (r *RateLimitRepository) Allow(ctx context.Context, key string, policy port.RateLimitPolicy) (port.RateLimitResult, error) {
+	if policy.Rate <= 0 || policy.Burst <= 0 {
+		return port.RateLimitResult{}, fmt.Errorf("invalid rate limit policy: rate=%v burst=%d", policy.Rate, policy.Burst)
+	}
+	interval := 1 / policy.Rate
+	tolerance := interval * float64(policy.Burst)
+
+	query := `
+        INSERT INTO rate_limit_buckets AS b (key, tat, allowed, updated_at)
+        VALUES ($1, now() + make_interval(secs => $2), true, now())
+        ON CONFLICT (key) DO UPDATE SET
+            allowed = GREATEST(b.tat, now()) + make_interval(secs => $2) <= now() + make_interval(secs => $3),
+            tat = CASE
+                WHEN GREATEST(b.tat, now()) + make_interval(secs => $2) <= now() + make_interval(secs => $3)
+                THEN GREATEST(b.tat, now()) + make_interval(secs => $2)
+                ELSE b.tat
+            END,
+            updated_at = now()
+        RETURNING allowed, EXTRACT(EPOCH FROM (tat - now()))::float8
+    `
+	var allowed bool
+	var tatOffset float64 // seconds from now until tat
+	err := r.db.QueryRow(ctx, query, key, interval, tolerance).Scan(&allowed, &tatOffset)
+	if err != nil {
+		// Logged at warn level by the caller, which fails open.
+		return port.RateLimitResult{}, fmt.Errorf("checking rate limit bucket: %w", err)
+	}
+
+	result := port.RateLimitResult{
+		Allowed:    allowed,
+		Remaining:  int(math.Max(0, math.Floor((tolerance-tatOffset)/interval))),
+		ResetAfter: secondsToDuration(tatOffset),
+	}
+	if !allowed {
+		result.RetryAfter = secondsToDuration(tatOffset + interval - tolerance)
+	}
+	return result, nil
+}
+
+// CleanUp removes buckets that have been full (idle) for longer than maxIdle.
+func (r *RateLimitRepository) CleanUp(ctx context.Context, maxIdle time.Duration) (int, error) {
+	query := `DELETE FROM rate_limit_buckets WHERE tat < now() - make_interval(secs => $1)`
+	cmdTag, err := r.db.Exec(ctx, query, maxIdle.Seconds())
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error cleaning up rate limit buckets", "error", err)
+		return 0, fmt.Errorf("cleaning up rate limit buckets: %w", err)
+	}
+	return int(cmdTag.RowsAffected()), nil
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// Compile-time check to ensure RateLimitRepository implements port.RateLimitStore
+var _ port.RateLimitStore = (*RateLimitRepository)(nil)
@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/service/ratelimit/ratelimittest"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// TestRateLimitRepository runs the rate limit store expectations against a migrated database
+// given by TEST_DATABASE_URL, e.g. the one started by `make deps-run` after `make migrate-up`.
+func TestRateLimitRepository(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	pool, err := pgxpool.New(context.Background(), dsn)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	require.NoError(t, pool.Ping(context.Background()))
+
+	repo := NewRateLimitRepository(pool, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ratelimittest.TestStore(t, func(*testing.T) port.RateLimitStore { return repo })
+}
@@ -0,0 +1,162 @@
+// internal/adapter/repository/postgres/resumable_upload_repo.go
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// ResumableUploadRepository stores tus uploads in the resumable_uploads table.
+type ResumableUploadRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewResumableUploadRepository creates a new ResumableUploadRepository.
+func NewResumableUploadRepository(db *pgxpool.Pool, logger *slog.Logger) *ResumableUploadRepository {
+	repo := &ResumableUploadRepository{
+		db:     db,
+		logger: logger.With("repository", "ResumableUploadRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+const resumableUploadColumns = `id, user_id, object_key, filename, content_type, length, "offset", metadata, track, track_id, expires_at, created_at, updated_at`
+
+func (r *ResumableUploadRepository) Create(ctx context.Context, upload *domain.ResumableUpload) error {
+	q := r.getQuerier(ctx)
+	track, err := json.Marshal(upload.Track)
+	if err != nil {
+		return fmt.Errorf("encoding upload track details: %w", err)
+	}
+	query := `
+        INSERT INTO resumable_uploads (` + resumableUploadColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+    `
+	_, err = q.Exec(ctx, query,
+		upload.ID, upload.UserID, upload.ObjectKey, upload.Filename, upload.ContentType, upload.Length, upload.Offset,
+		upload.Metadata, track, upload.TrackID, upload.ExpiresAt, upload.CreatedAt, upload.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error creating resumable upload", "error", err, "uploadID", upload.ID)
+		return fmt.Errorf("creating resumable upload: %w", err)
+	}
+	return nil
+}
+
+func (r *ResumableUploadRepository) FindByID(ctx context.Context, id domain.ResumableUploadID) (*domain.ResumableUpload, error) {
+	q := r.getQuerier(ctx)
+	query := `SELECT ` + resumableUploadColumns + ` FROM resumable_uploads WHERE id = $1`
+	upload, err := r.scanResumableUpload(q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding resumable upload by ID", "error", err, "uploadID", id)
+		return nil, fmt.Errorf("finding resumable upload by ID: %w", err)
+	}
+	return upload, nil
+}
+
+func (r *ResumableUploadRepository) Update(ctx context.Context, upload *domain.ResumableUpload) error {
+	q := r.getQuerier(ctx)
+	query := `
+        UPDATE resumable_uploads
+        SET "offset" = $2, track_id = $3, expires_at = $4, updated_at = $5
+        WHERE id = $1
+    `
+	cmdTag, err := q.Exec(ctx, query, upload.ID, upload.Offset, upload.TrackID, upload.ExpiresAt, upload.UpdatedAt)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error updating resumable upload", "error", err, "uploadID", upload.ID)
+		return fmt.Errorf("updating resumable upload: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ResumableUploadRepository) Delete(ctx context.Context, id domain.ResumableUploadID) error {
+	q := r.getQuerier(ctx)
+	cmdTag, err := q.Exec(ctx, `DELETE FROM resumable_uploads WHERE id = $1`, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting resumable upload", "error", err, "uploadID", id)
+		return fmt.Errorf("deleting resumable upload: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ResumableUploadRepository) ListExpired(ctx context.Context, before time.Time, limit int) ([]*domain.ResumableUpload, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        SELECT ` + resumableUploadColumns + `
+        FROM resumable_uploads
+        WHERE expires_at <= $1
+        ORDER BY expires_at ASC
+        LIMIT $2
+    `
+	rows, err := q.Query(ctx, query, before, limit)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing expired resumable uploads", "error", err)
+		return nil, fmt.Errorf("listing expired resumable uploads: %w", err)
+	}
+	defer rows.Close()
+
+	var uploads []*domain.ResumableUpload
+	for rows.Next() {
+		upload, err := r.scanResumableUpload(rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning resumable upload", "error", err)
+			return nil, fmt.Errorf("scanning resumable upload: %w", err)
+		}
+		uploads = append(uploads, upload)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating expired resumable uploads", "error", err)
+		return nil, fmt.Errorf("iterating expired resumable uploads: %w", err)
+	}
+	return uploads, nil
+}
+
+func (r *ResumableUploadRepository) scanResumableUpload(row RowScanner) (*domain.ResumableUpload, error) {
+	var upload domain.ResumableUpload
+	var track []byte
+	var trackID uuid.NullUUID
+
+	err := row.Scan(
+		&upload.ID, &upload.UserID, &upload.ObjectKey, &upload.Filename, &upload.ContentType, &upload.Length, &upload.Offset,
+		&upload.Metadata, &track, &trackID, &upload.ExpiresAt, &upload.CreatedAt, &upload.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(track, &upload.Track); err != nil {
+		return nil, fmt.Errorf("decoding upload track details: %w", err)
+	}
+	if trackID.Valid {
+		tid := domain.TrackID(trackID.UUID)
+		upload.TrackID = &tid
+	}
+	return &upload, nil
+}
+
+// Compile-time check to ensure ResumableUploadRepository satisfies the port.ResumableUploadRepository interface
+var _ port.ResumableUploadRepository = (*ResumableUploadRepository)(nil)
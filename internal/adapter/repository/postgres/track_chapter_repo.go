@@ -0,0 +1,124 @@
+// internal/adapter/repository/postgres/track_chapter_repo.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// TrackChapterRepository implements port.TrackChapterRepository using the track_chapters table.
+type TrackChapterRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewTrackChapterRepository creates a new TrackChapterRepository.
+func NewTrackChapterRepository(db *pgxpool.Pool, logger *slog.Logger) *TrackChapterRepository {
+	repo := &TrackChapterRepository{
+		db:     db,
+		logger: logger.With("repository", "TrackChapterRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+func (r *TrackChapterRepository) ListByTrack(ctx context.Context, trackID domain.TrackID) ([]domain.TrackChapter, error) {
+	byTrack, err := r.ListByTracks(ctx, []domain.TrackID{trackID})
+	if err != nil {
+		return nil, err
+	}
+	if chapters := byTrack[trackID]; chapters != nil {
+		return chapters, nil
+	}
+	return []domain.TrackChapter{}, nil
+}
+
+func (r *TrackChapterRepository) ListByTracks(ctx context.Context, trackIDs []domain.TrackID) (map[domain.TrackID][]domain.TrackChapter, error) {
+	result := make(map[domain.TrackID][]domain.TrackChapter)
+	if len(trackIDs) == 0 {
+		return result, nil
+	}
+	uuidStrs := make([]string, len(trackIDs))
+	for i, id := range trackIDs {
+		uuidStrs[i] = id.String()
+	}
+
+	q := r.getQuerier(ctx)
+	query := `
+        SELECT track_id, title, description, start_ms, end_ms
+        FROM track_chapters
+        WHERE track_id = ANY($1)
+        ORDER BY track_id, position
+    `
+	rows, err := q.Query(ctx, query, uuidStrs)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing track chapters", "error", err, "trackCount", len(trackIDs))
+		return nil, fmt.Errorf("listing track chapters: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trackID domain.TrackID
+		var c domain.TrackChapter
+		if err := rows.Scan(&trackID, &c.Title, &c.Description, &c.Start, &c.End); err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning track chapter", "error", err)
+			return nil, fmt.Errorf("scanning track chapter: %w", err)
+		}
+		result[trackID] = append(result[trackID], c)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating track chapter rows", "error", err)
+		return nil, fmt.Errorf("iterating track chapters: %w", err)
+	}
+	return result, nil
+}
+
+func (r *TrackChapterRepository) Replace(ctx context.Context, trackID domain.TrackID, chapters []domain.TrackChapter) error {
+	q := r.getQuerier(ctx) // Gets Tx if called within TxManager.Execute, otherwise Pool
+
+	if _, err := q.Exec(ctx, `DELETE FROM track_chapters WHERE track_id = $1`, trackID); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to delete old track chapters", "error", err, "trackID", trackID)
+		return fmt.Errorf("deleting old track chapters: %w", err)
+	}
+	if len(chapters) == 0 {
+		return nil
+	}
+
+	insertQuery := `
+        INSERT INTO track_chapters (track_id, position, title, description, start_ms, end_ms)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	batch := &pgx.Batch{}
+	for i, c := range chapters {
+		batch.Queue(insertQuery, trackID, i, c.Title, c.Description, c.Start, c.End)
+	}
+	br := q.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := range chapters {
+		if _, err := br.Exec(); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == ForeignKeyViolation {
+				return domain.ErrNotFound // Track was deleted concurrently
+			}
+			r.logger.ErrorContext(ctx, "Failed to insert track chapter", "error", err, "trackID", trackID, "position", i)
+			return fmt.Errorf("inserting track chapter at position %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Compile-time check to ensure TrackChapterRepository satisfies the port.TrackChapterRepository interface.
+var _ port.TrackChapterRepository = (*TrackChapterRepository)(nil)
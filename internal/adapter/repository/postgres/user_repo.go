@@ -18,32 +18,42 @@ import (
 )
 
 type UserRepository struct {
-	db     *pgxpool.Pool
-	logger *slog.Logger
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
 }
 
 // NewUserRepository creates a new instance of UserRepository.
 func NewUserRepository(db *pgxpool.Pool, logger *slog.Logger) *UserRepository {
-	return &UserRepository{
+	repo := &UserRepository{
 		db:     db,
 		logger: logger.With("repository", "UserRepository"), // Add context to logger
 	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
 }
 
 // --- Interface Implementation ---
 
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-        INSERT INTO users (id, email, name, password_hash, google_id, auth_provider, profile_image_url, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        INSERT INTO users (id, email, name, password_hash, google_id, auth_provider, role, profile_image_url, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
     `
-	_, err := r.db.Exec(ctx, query,
+	role := user.Role
+	if role == "" {
+		role = domain.RoleUser
+	}
+	_, err := r.getQuerier(ctx).Exec(ctx, query,
 		user.ID,
 		user.Email.String(), // Use string representation of value object
 		user.Name,
 		user.HashedPassword,
 		user.GoogleID,
 		user.AuthProvider,
+		role,
 		user.ProfileImageURL,
 		user.CreatedAt,
 		user.UpdatedAt,
@@ -77,11 +87,11 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 
 func (r *UserRepository) FindByID(ctx context.Context, id domain.UserID) (*domain.User, error) {
 	query := `
-        SELECT id, email, name, password_hash, google_id, auth_provider, profile_image_url, created_at, updated_at
+        SELECT id, email, name, password_hash, google_id, auth_provider, role, profile_image_url, created_at, updated_at
         FROM users
         WHERE id = $1
     `
-	user, err := r.scanUser(ctx, r.db.QueryRow(ctx, query, id))
+	user, err := r.scanUser(ctx, r.getQuerier(ctx).QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound // Map to domain error
@@ -94,11 +104,11 @@ func (r *UserRepository) FindByID(ctx context.Context, id domain.UserID) (*domai
 
 func (r *UserRepository) FindByEmail(ctx context.Context, email domain.Email) (*domain.User, error) {
 	query := `
-        SELECT id, email, name, password_hash, google_id, auth_provider, profile_image_url, created_at, updated_at
+        SELECT id, email, name, password_hash, google_id, auth_provider, role, profile_image_url, created_at, updated_at
         FROM users
         WHERE email = $1
     `
-	user, err := r.scanUser(ctx, r.db.QueryRow(ctx, query, email.String()))
+	user, err := r.scanUser(ctx, r.getQuerier(ctx).QueryRow(ctx, query, email.String()))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound // Map to domain error
@@ -116,11 +126,11 @@ func (r *UserRepository) FindByProviderID(ctx context.Context, provider domain.A
 	}
 
 	query := `
-        SELECT id, email, name, password_hash, google_id, auth_provider, profile_image_url, created_at, updated_at
+        SELECT id, email, name, password_hash, google_id, auth_provider, role, profile_image_url, created_at, updated_at
         FROM users
         WHERE google_id = $1 AND auth_provider = $2
     `
-	user, err := r.scanUser(ctx, r.db.QueryRow(ctx, query, providerUserID, provider))
+	user, err := r.scanUser(ctx, r.getQuerier(ctx).QueryRow(ctx, query, providerUserID, provider))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound // Map to domain error
@@ -137,10 +147,11 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 
 	query := `
         UPDATE users
-        SET email = $2, name = $3, password_hash = $4, google_id = $5, auth_provider = $6, profile_image_url = $7, updated_at = $8
+        SET email = $2, name = $3, password_hash = $4, google_id = $5, auth_provider = $6, profile_image_url = $7, updated_at = $8,
+            role = COALESCE(NULLIF($9, ''), role)
         WHERE id = $1
     `
-	cmdTag, err := r.db.Exec(ctx, query,
+	cmdTag, err := r.getQuerier(ctx).Exec(ctx, query,
 		user.ID,
 		user.Email.String(),
 		user.Name,
@@ -149,6 +160,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 		user.AuthProvider,
 		user.ProfileImageURL,
 		user.UpdatedAt,
+		string(user.Role),
 	)
 
 	if err != nil {
@@ -182,7 +194,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 func (r *UserRepository) EmailExists(ctx context.Context, email domain.Email) (bool, error) {
 	var exists bool
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`
-	err := r.db.QueryRow(ctx, query, email.String()).Scan(&exists)
+	err := r.getQuerier(ctx).QueryRow(ctx, query, email.String()).Scan(&exists)
 	if err != nil {
 		// Don't treat pgx.ErrNoRows as an error here, EXISTS correctly returns false
 		// Log other potential errors
@@ -207,6 +219,7 @@ func (r *UserRepository) scanUser(ctx context.Context, row pgx.Row) (*domain.Use
 		&user.HashedPassword, // Directly scans into *string (handles NULL)
 		&user.GoogleID,       // Directly scans into *string (handles NULL)
 		&user.AuthProvider,
+		&user.Role,
 		&user.ProfileImageURL, // Directly scans into *string (handles NULL)
 		&user.CreatedAt,
 		&user.UpdatedAt,
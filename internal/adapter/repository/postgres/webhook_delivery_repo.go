@@ -0,0 +1,247 @@
+// internal/adapter/repository/postgres/webhook_delivery_repo.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+)
+
+// WebhookDeliveryRepository manages the webhook delivery queue. Rows are claimed with
+// FOR UPDATE SKIP LOCKED so that several API replicas can run the dispatcher concurrently.
+type WebhookDeliveryRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewWebhookDeliveryRepository creates a new WebhookDeliveryRepository.
+func NewWebhookDeliveryRepository(db *pgxpool.Pool, logger *slog.Logger) *WebhookDeliveryRepository {
+	repo := &WebhookDeliveryRepository{
+		db:     db,
+		logger: logger.With("repository", "WebhookDeliveryRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+// webhookDeliveryColumns selects a delivery joined with its outbox event (alias e).
+const webhookDeliveryColumns = `d.id, d.subscription_id, d.event_id, e.event_type, d.status, d.attempts, d.next_attempt_at,
+               d.last_status_code, d.last_error, d.delivered_at, d.created_at, d.updated_at`
+
+// FanOut marks a batch of undispatched events as dispatched and creates a pending
+// delivery for each active subscription to the event type, in a single statement.
+// Events without subscribers are simply marked as dispatched.
+func (r *WebhookDeliveryRepository) FanOut(ctx context.Context, limit int) (int, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        WITH events AS (
+            SELECT id, event_type
+            FROM outbox_events
+            WHERE dispatched_at IS NULL
+            ORDER BY occurred_at
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        ), marked AS (
+            UPDATE outbox_events o
+            SET dispatched_at = now()
+            FROM events
+            WHERE o.id = events.id
+            RETURNING o.id
+        ), inserted AS (
+            INSERT INTO webhook_deliveries (id, subscription_id, event_id, status, next_attempt_at)
+            SELECT gen_random_uuid(), s.id, events.id, 'pending', now()
+            FROM events
+            JOIN webhook_subscriptions s ON s.active AND events.event_type = ANY(s.event_types)
+            ON CONFLICT (subscription_id, event_id) DO NOTHING
+        )
+        SELECT count(*) FROM marked
+    `
+	var count int
+	if err := q.QueryRow(ctx, query, limit).Scan(&count); err != nil {
+		r.logger.ErrorContext(ctx, "Error fanning out outbox events", "error", err)
+		return 0, fmt.Errorf("fanning out outbox events: %w", err)
+	}
+	return count, nil
+}
+
+// ClaimDue leases up to limit due deliveries by pushing their next_attempt_at forward
+// by lease. If the dispatcher dies mid-send, the delivery becomes due again once the
+// lease expires.
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*port.PendingWebhookDelivery, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        WITH due AS (
+            SELECT d.id
+            FROM webhook_deliveries d
+            JOIN webhook_subscriptions s ON s.id = d.subscription_id
+            WHERE d.status = 'pending' AND d.next_attempt_at <= now() AND s.active
+            ORDER BY d.next_attempt_at
+            LIMIT $1
+            FOR UPDATE OF d SKIP LOCKED
+        )
+        UPDATE webhook_deliveries d
+        SET next_attempt_at = now() + make_interval(secs => $2)
+        FROM due, webhook_subscriptions s, outbox_events e
+        WHERE d.id = due.id AND s.id = d.subscription_id AND e.id = d.event_id
+        RETURNING ` + webhookDeliveryColumns + `, s.url, s.secret, e.payload, e.occurred_at
+    `
+	rows, err := q.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error claiming due webhook deliveries", "error", err)
+		return nil, fmt.Errorf("claiming due webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var claimed []*port.PendingWebhookDelivery
+	for rows.Next() {
+		var pending port.PendingWebhookDelivery
+		var event domain.OutboxEvent
+		delivery, err := r.scanDelivery(rows, &pending.URL, &pending.Secret, &event.Payload, &event.OccurredAt)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning claimed webhook delivery", "error", err)
+			return nil, fmt.Errorf("scanning claimed webhook delivery: %w", err)
+		}
+		event.ID = delivery.EventID
+		event.Type = delivery.EventType
+		pending.Delivery = delivery
+		pending.Event = &event
+		claimed = append(claimed, &pending)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating claimed webhook delivery rows", "error", err)
+		return nil, fmt.Errorf("iterating claimed webhook delivery rows: %w", err)
+	}
+	return claimed, nil
+}
+
+// SaveAttempt stores the delivery state after an attempt or a requeue.
+func (r *WebhookDeliveryRepository) SaveAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	q := r.getQuerier(ctx)
+	query := `
+        UPDATE webhook_deliveries
+        SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5,
+            last_error = $6, delivered_at = $7, updated_at = $8
+        WHERE id = $1
+    `
+	var lastError *string
+	if delivery.LastError != "" {
+		lastError = &delivery.LastError
+	}
+	cmdTag, err := q.Exec(ctx, query,
+		delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastStatusCode,
+		lastError, delivery.DeliveredAt, delivery.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error saving webhook delivery attempt", "error", err, "deliveryID", delivery.ID)
+		return fmt.Errorf("saving webhook delivery attempt: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) FindByID(ctx context.Context, id domain.WebhookDeliveryID) (*domain.WebhookDelivery, error) {
+	q := r.getQuerier(ctx)
+	query := `
+        SELECT ` + webhookDeliveryColumns + `
+        FROM webhook_deliveries d
+        JOIN outbox_events e ON e.id = d.event_id
+        WHERE d.id = $1
+    `
+	delivery, err := r.scanDelivery(q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding webhook delivery by ID", "error", err, "deliveryID", id)
+		return nil, fmt.Errorf("finding webhook delivery by ID: %w", err)
+	}
+	return delivery, nil
+}
+
+// ListBySubscription retrieves a subscription's deliveries, newest first.
+func (r *WebhookDeliveryRepository) ListBySubscription(ctx context.Context, subID domain.WebhookID, filter port.WebhookDeliveryFilter, page pagination.Page) ([]*domain.WebhookDelivery, int, error) {
+	q := r.getQuerier(ctx)
+	args := []interface{}{subID}
+	argID := 2
+	whereClause := " WHERE d.subscription_id = $1"
+	if filter.Status != nil && *filter.Status != "" {
+		whereClause += fmt.Sprintf(" AND d.status = $%d", argID)
+		args = append(args, *filter.Status)
+		argID++
+	}
+
+	var total int
+	if err := q.QueryRow(ctx, `SELECT count(*) FROM webhook_deliveries d`+whereClause, args...).Scan(&total); err != nil {
+		r.logger.ErrorContext(ctx, "Error counting webhook deliveries", "error", err, "webhookID", subID)
+		return nil, 0, fmt.Errorf("counting webhook deliveries: %w", err)
+	}
+	if total == 0 {
+		return []*domain.WebhookDelivery{}, 0, nil
+	}
+
+	selectQuery := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries d JOIN outbox_events e ON e.id = d.event_id`
+	paginationClause := fmt.Sprintf(" ORDER BY d.created_at DESC, d.id LIMIT $%d OFFSET $%d", argID, argID+1)
+	args = append(args, page.Limit, page.Offset)
+
+	rows, err := q.Query(ctx, selectQuery+whereClause+paginationClause, args...)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing webhook deliveries", "error", err, "webhookID", subID, "page", page)
+		return nil, 0, fmt.Errorf("listing webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*domain.WebhookDelivery, 0, page.Limit)
+	for rows.Next() {
+		delivery, err := r.scanDelivery(rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning webhook delivery", "error", err)
+			return nil, 0, fmt.Errorf("scanning webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating webhook delivery rows", "error", err)
+		return nil, 0, fmt.Errorf("iterating webhook delivery rows: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// scanDelivery scans webhookDeliveryColumns followed by any extra destinations.
+func (r *WebhookDeliveryRepository) scanDelivery(row RowScanner, extra ...interface{}) (*domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	var lastStatusCode *int32
+	var lastError *string
+
+	dest := []interface{}{
+		&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&lastStatusCode, &lastError, &d.DeliveredAt, &d.CreatedAt, &d.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if lastStatusCode != nil {
+		code := int(*lastStatusCode)
+		d.LastStatusCode = &code
+	}
+	if lastError != nil {
+		d.LastError = *lastError
+	}
+	return &d, nil
+}
+
+// Compile-time check to ensure WebhookDeliveryRepository satisfies the port.WebhookDeliveryRepository interface
+var _ port.WebhookDeliveryRepository = (*WebhookDeliveryRepository)(nil)
@@ -0,0 +1,177 @@
+// internal/adapter/repository/postgres/webhook_repo.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lib/pq"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+)
+
+// WebhookSubscriptionRepository stores webhook endpoint registrations.
+type WebhookSubscriptionRepository struct {
+	db         *pgxpool.Pool
+	logger     *slog.Logger
+	getQuerier func(ctx context.Context) Querier
+}
+
+// NewWebhookSubscriptionRepository creates a new WebhookSubscriptionRepository.
+func NewWebhookSubscriptionRepository(db *pgxpool.Pool, logger *slog.Logger) *WebhookSubscriptionRepository {
+	repo := &WebhookSubscriptionRepository{
+		db:     db,
+		logger: logger.With("repository", "WebhookSubscriptionRepository"),
+	}
+	repo.getQuerier = func(ctx context.Context) Querier {
+		return getQuerier(ctx, repo.db)
+	}
+	return repo
+}
+
+const webhookSubscriptionColumns = `id, url, secret, event_types, active, created_by, created_at, updated_at`
+
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, sub *domain.WebhookSubscription) error {
+	q := r.getQuerier(ctx)
+	query := `
+        INSERT INTO webhook_subscriptions (` + webhookSubscriptionColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	_, err := q.Exec(ctx, query,
+		sub.ID, sub.URL, sub.Secret, pq.Array(eventTypeStrings(sub.EventTypes)), sub.Active,
+		sub.CreatedBy, sub.CreatedAt, sub.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error creating webhook subscription", "error", err, "webhookID", sub.ID)
+		return fmt.Errorf("creating webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id domain.WebhookID) (*domain.WebhookSubscription, error) {
+	q := r.getQuerier(ctx)
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+	sub, err := r.scanSubscription(q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.ErrorContext(ctx, "Error finding webhook subscription by ID", "error", err, "webhookID", id)
+		return nil, fmt.Errorf("finding webhook subscription by ID: %w", err)
+	}
+	return sub, nil
+}
+
+// List retrieves subscriptions, newest first.
+func (r *WebhookSubscriptionRepository) List(ctx context.Context, page pagination.Page) ([]*domain.WebhookSubscription, int, error) {
+	q := r.getQuerier(ctx)
+	var total int
+	if err := q.QueryRow(ctx, `SELECT count(*) FROM webhook_subscriptions`).Scan(&total); err != nil {
+		r.logger.ErrorContext(ctx, "Error counting webhook subscriptions", "error", err)
+		return nil, 0, fmt.Errorf("counting webhook subscriptions: %w", err)
+	}
+	if total == 0 {
+		return []*domain.WebhookSubscription{}, 0, nil
+	}
+
+	query := `
+        SELECT ` + webhookSubscriptionColumns + `
+        FROM webhook_subscriptions
+        ORDER BY created_at DESC, id
+        LIMIT $1 OFFSET $2
+    `
+	rows, err := q.Query(ctx, query, page.Limit, page.Offset)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error listing webhook subscriptions", "error", err, "page", page)
+		return nil, 0, fmt.Errorf("listing webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := make([]*domain.WebhookSubscription, 0, page.Limit)
+	for rows.Next() {
+		sub, err := r.scanSubscription(rows)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Error scanning webhook subscription", "error", err)
+			return nil, 0, fmt.Errorf("scanning webhook subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.ErrorContext(ctx, "Error iterating webhook subscription rows", "error", err)
+		return nil, 0, fmt.Errorf("iterating webhook subscription rows: %w", err)
+	}
+	return subs, total, nil
+}
+
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, sub *domain.WebhookSubscription) error {
+	q := r.getQuerier(ctx)
+	query := `
+        UPDATE webhook_subscriptions
+        SET url = $2, event_types = $3, active = $4, updated_at = $5
+        WHERE id = $1
+    `
+	cmdTag, err := q.Exec(ctx, query, sub.ID, sub.URL, pq.Array(eventTypeStrings(sub.EventTypes)), sub.Active, sub.UpdatedAt)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error updating webhook subscription", "error", err, "webhookID", sub.ID)
+		return fmt.Errorf("updating webhook subscription: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a subscription. Its deliveries are removed by ON DELETE CASCADE.
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id domain.WebhookID) error {
+	q := r.getQuerier(ctx)
+	cmdTag, err := q.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Error deleting webhook subscription", "error", err, "webhookID", id)
+		return fmt.Errorf("deleting webhook subscription: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) scanSubscription(row RowScanner) (*domain.WebhookSubscription, error) {
+	var sub domain.WebhookSubscription
+	var eventTypes pq.StringArray
+	var createdBy uuid.NullUUID
+
+	err := row.Scan(
+		&sub.ID, &sub.URL, &sub.Secret, &eventTypes, &sub.Active,
+		&createdBy, &sub.CreatedAt, &sub.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	sub.EventTypes = make([]domain.EventType, len(eventTypes))
+	for i, t := range eventTypes {
+		sub.EventTypes[i] = domain.EventType(t)
+	}
+	if createdBy.Valid {
+		uid := domain.UserID(createdBy.UUID)
+		sub.CreatedBy = &uid
+	}
+	return &sub, nil
+}
+
+func eventTypeStrings(types []domain.EventType) []string {
+	strs := make([]string, len(types))
+	for i, t := range types {
+		strs[i] = string(t)
+	}
+	return strs
+}
+
+// Compile-time check to ensure WebhookSubscriptionRepository satisfies the port.WebhookSubscriptionRepository interface
+var _ port.WebhookSubscriptionRepository = (*WebhookSubscriptionRepository)(nil)
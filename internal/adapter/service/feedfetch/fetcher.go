@@ -0,0 +1,203 @@
+// internal/adapter/service/feedfetch/fetcher.go
+package feedfetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+const (
+	userAgent    = "language-learning-player-feeds/1.0"
+	acceptHeader = "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.1"
+	maxRedirects = 10
+)
+
+// ErrDisallowedTarget is returned when a feed or enclosure URL, or a redirect, points at a
+// non-HTTP scheme or at an address the server must not reach on behalf of feed content.
+var ErrDisallowedTarget = errors.New("feed target not allowed")
+
+// Fetcher downloads podcast feeds and episodes over HTTP.
+type Fetcher struct {
+	feedClient     *http.Client
+	downloadClient *http.Client
+	maxFeedSize    int64
+	maxEpisodeSize int64
+	logger         *slog.Logger
+}
+
+// NewFetcher creates a Fetcher with the timeouts and size limits of cfg. Feed URLs and enclosure
+// URLs come from third-party content, so both clients only speak http(s), refuse to connect to
+// loopback, private, link-local and unspecified addresses outside cfg.AllowedPrivateNetworks, and
+// apply the same rules to every redirect.
+func NewFetcher(cfg config.FeedIngestConfig, logger *slog.Logger) *Fetcher {
+	logger = logger.With("service", "FeedFetcher")
+	allowed := make([]netip.Prefix, 0, len(cfg.AllowedPrivateNetworks))
+	for _, network := range cfg.AllowedPrivateNetworks {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			logger.Warn("Ignoring invalid allowed private network", "network", network, "error", err)
+			continue
+		}
+		allowed = append(allowed, prefix.Masked())
+	}
+	return &Fetcher{
+		feedClient:     newClient(cfg.RequestTimeout, allowed),
+		downloadClient: newClient(cfg.DownloadTimeout, allowed),
+		maxFeedSize:    cfg.MaxFeedSize,
+		maxEpisodeSize: cfg.MaxEpisodeSize,
+		logger:         logger,
+	}
+}
+
+func newClient(timeout time.Duration, allowed []netip.Prefix) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		// Control runs after name resolution for every address dialed, so a host name that
+		// resolves to an internal address is refused as well.
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrDisallowedTarget, address)
+			}
+			if !isPublicAddr(addrPort.Addr().Unmap(), allowed) {
+				return fmt.Errorf("%w: %s is not a public address", ErrDisallowedTarget, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // A proxy would dial the targets itself, bypassing the address check
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return checkScheme(req.URL)
+		},
+	}
+}
+
+// isPublicAddr reports whether addr may be dialed: it is not loopback, private, link-local,
+// multicast or unspecified, or it falls in one of the allowed networks.
+func isPublicAddr(addr netip.Addr, allowed []netip.Prefix) bool {
+	for _, prefix := range allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
+
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported URL scheme %q", ErrDisallowedTarget, u.Scheme)
+	}
+	return nil
+}
+
+// FetchFeed downloads a feed. Redirects are followed by the HTTP client, subject to the target checks.
+func (f *Fetcher) FetchFeed(ctx context.Context, feedURL string, validators port.FeedValidators) (*port.FetchedFeed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building feed request: %w", err)
+	}
+	if err := checkScheme(req.URL); err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", acceptHeader)
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+
+	resp, err := f.feedClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching feed: %w", err)
+	}
+	defer drainAndClose(resp.Body)
+
+	received := port.FeedValidators{ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+	if resp.StatusCode == http.StatusNotModified {
+		return &port.FetchedFeed{NotModified: true, Validators: received}, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("feed server responded with status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > f.maxFeedSize {
+		return nil, fmt.Errorf("feed exceeds the maximum size of %d bytes", f.maxFeedSize)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxFeedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading feed: %w", err)
+	}
+	if int64(len(body)) > f.maxFeedSize {
+		return nil, fmt.Errorf("feed exceeds the maximum size of %d bytes", f.maxFeedSize)
+	}
+	return &port.FetchedFeed{Body: body, Validators: received}, nil
+}
+
+// DownloadEnclosure writes an episode's audio to w.
+func (f *Fetcher) DownloadEnclosure(ctx context.Context, enclosureURL string, w io.Writer) (string, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, enclosureURL, nil)
+	if err != nil {
+		return "", 0, fmt.Errorf("building download request: %w", err)
+	}
+	if err := checkScheme(req.URL); err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := f.downloadClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("downloading episode: %w", err)
+	}
+	defer drainAndClose(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", 0, fmt.Errorf("episode server responded with status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > f.maxEpisodeSize {
+		return "", 0, fmt.Errorf("episode exceeds the maximum size of %d bytes", f.maxEpisodeSize)
+	}
+	n, err := io.Copy(w, io.LimitReader(resp.Body, f.maxEpisodeSize+1))
+	if err != nil {
+		return "", n, fmt.Errorf("downloading episode: %w", err)
+	}
+	if n > f.maxEpisodeSize {
+		return "", n, fmt.Errorf("episode exceeds the maximum size of %d bytes", f.maxEpisodeSize)
+	}
+	contentType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		contentType = ""
+	}
+	return strings.ToLower(contentType), n, nil
+}
+
+// drainAndClose discards a bounded remainder of the body so the connection can be reused.
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, 4096))
+	_ = body.Close()
+}
+
+var _ port.FeedFetcher = (*Fetcher)(nil)
@@ -0,0 +1,175 @@
+package feedfetch
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/podcast"
+)
+
+const testFeed = `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Test</title>
+<item><guid>1</guid><title>One</title><enclosure url="%s/audio/1.mp3" type="audio/mpeg" length="5"/></item>
+</channel></rss>`
+
+// newTestFetcher returns a fetcher that may reach the loopback httptest servers.
+func newTestFetcher(maxSize int64) *Fetcher {
+	return NewFetcher(config.FeedIngestConfig{
+		RequestTimeout:         5 * time.Second,
+		DownloadTimeout:        5 * time.Second,
+		MaxFeedSize:            maxSize,
+		MaxEpisodeSize:         maxSize,
+		AllowedPrivateNetworks: []string{"127.0.0.0/8"},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// newFeedServer serves a feed with an ETag and Last-Modified and its audio, answering
+// conditional requests like a static file server.
+func newFeedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	lastModified := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed.xml":
+			assert.Equal(t, userAgent, r.Header.Get("User-Agent"))
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Content-Type", "application/rss+xml")
+			http.ServeContent(w, r, "feed.xml", lastModified, strings.NewReader(strings.ReplaceAll(testFeed, "%s", srv.URL)))
+		case "/moved.xml":
+			http.Redirect(w, r, "/feed.xml", http.StatusMovedPermanently)
+		case "/metadata.mp3":
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+		case "/file.mp3":
+			http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+		case "/audio/1.mp3":
+			w.Header().Set("Content-Type", "audio/mpeg; charset=binary")
+			_, _ = w.Write([]byte("ID3ab"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetcher_FetchFeedConditionally(t *testing.T) {
+	srv := newFeedServer(t)
+	f := newTestFetcher(1 << 20)
+
+	first, err := f.FetchFeed(context.Background(), srv.URL+"/moved.xml", port.FeedValidators{})
+	require.NoError(t, err)
+	assert.False(t, first.NotModified)
+	assert.Equal(t, `"v1"`, first.Validators.ETag)
+	assert.Equal(t, "Thu, 01 May 2025 10:00:00 GMT", first.Validators.LastModified)
+
+	feed, err := podcast.Parse(bytes.NewReader(first.Body))
+	require.NoError(t, err)
+	require.Len(t, feed.Items, 1)
+
+	second, err := f.FetchFeed(context.Background(), srv.URL+"/feed.xml", first.Validators)
+	require.NoError(t, err)
+	assert.True(t, second.NotModified)
+	assert.Empty(t, second.Body)
+
+	// Only Last-Modified known
+	third, err := f.FetchFeed(context.Background(), srv.URL+"/feed.xml", port.FeedValidators{LastModified: first.Validators.LastModified})
+	require.NoError(t, err)
+	assert.True(t, third.NotModified)
+
+	// Changed since
+	fourth, err := f.FetchFeed(context.Background(), srv.URL+"/feed.xml", port.FeedValidators{ETag: `"v0"`})
+	require.NoError(t, err)
+	assert.False(t, fourth.NotModified)
+	assert.Equal(t, first.Body, fourth.Body)
+
+	// The enclosure of the parsed feed can be downloaded
+	var audio bytes.Buffer
+	contentType, size, err := f.DownloadEnclosure(context.Background(), feed.Items[0].EnclosureURL, &audio)
+	require.NoError(t, err)
+	assert.Equal(t, "audio/mpeg", contentType)
+	assert.Equal(t, int64(5), size)
+	assert.Equal(t, "ID3ab", audio.String())
+}
+
+func TestFetcher_Errors(t *testing.T) {
+	srv := newFeedServer(t)
+
+	_, err := newTestFetcher(1<<20).FetchFeed(context.Background(), srv.URL+"/missing.xml", port.FeedValidators{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+
+	_, err = newTestFetcher(10).FetchFeed(context.Background(), srv.URL+"/feed.xml", port.FeedValidators{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maximum size")
+
+	_, _, err = newTestFetcher(4).DownloadEnclosure(context.Background(), srv.URL+"/audio/1.mp3", io.Discard)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maximum size")
+
+	_, _, err = newTestFetcher(1<<20).DownloadEnclosure(context.Background(), srv.URL+"/audio/2.mp3", io.Discard)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
+
+func TestFetcher_RefusesInternalTargets(t *testing.T) {
+	srv := newFeedServer(t)
+
+	// Without the allowlist the loopback test server itself is off limits
+	strict := NewFetcher(config.FeedIngestConfig{
+		RequestTimeout:  5 * time.Second,
+		DownloadTimeout: 5 * time.Second,
+		MaxFeedSize:     1 << 20,
+		MaxEpisodeSize:  1 << 20,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	_, err := strict.FetchFeed(context.Background(), srv.URL+"/feed.xml", port.FeedValidators{})
+	assert.ErrorIs(t, err, ErrDisallowedTarget)
+	for _, target := range []string{"http://169.254.169.254/latest/meta-data/", "http://10.0.0.5:9000/audio/bucket", "http://[::1]:5432/", "http://0.0.0.0/"} {
+		_, _, err = strict.DownloadEnclosure(context.Background(), target, io.Discard)
+		assert.ErrorIs(t, err, ErrDisallowedTarget, target)
+	}
+
+	f := newTestFetcher(1 << 20)
+	_, _, err = f.DownloadEnclosure(context.Background(), "file:///etc/passwd", io.Discard)
+	assert.ErrorIs(t, err, ErrDisallowedTarget)
+
+	// Redirects are checked too
+	_, _, err = f.DownloadEnclosure(context.Background(), srv.URL+"/metadata.mp3", io.Discard)
+	assert.ErrorIs(t, err, ErrDisallowedTarget)
+	_, _, err = f.DownloadEnclosure(context.Background(), srv.URL+"/file.mp3", io.Discard)
+	assert.ErrorIs(t, err, ErrDisallowedTarget)
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	allowed := []netip.Prefix{netip.MustParsePrefix("10.20.0.0/16")}
+	for addr, want := range map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"10.0.0.1":        false,
+		"10.20.3.4":       true, // Allowed network
+		"172.16.5.5":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"fe80::1":         false,
+		"fd00::1":         false,
+		"0.0.0.0":         false,
+		"::":              false,
+		"224.0.0.1":       false,
+	} {
+		assert.Equal(t, want, isPublicAddr(netip.MustParseAddr(addr), allowed), addr)
+	}
+}
@@ -4,6 +4,7 @@ package minioadapter // Use a distinct name like minioadapter or minioservice
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/url"
 	"time"
@@ -12,9 +13,13 @@ import (
 	"github.com/minio/minio-go/v7/pkg/credentials"
 
 	"github.com/yvanyang/language-learning-player-api/internal/config" // Adjust import path
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
 	"github.com/yvanyang/language-learning-player-api/internal/port"   // Adjust import path
 )
 
+// unknownSizePartSize is the multipart chunk size used when PutObject is called without a size.
+const unknownSizePartSize = 16 << 20
+
 // MinioStorageService implements the port.FileStorageService interface using MinIO.
 type MinioStorageService struct {
 	client        *minio.Client
@@ -149,5 +154,80 @@ func (s *MinioStorageService) ObjectExists(ctx context.Context, bucket, objectKe
 	return true, nil
 }
 
+// PutObject uploads an object, streaming it in parts if size is unknown (-1).
+func (s *MinioStorageService) PutObject(ctx context.Context, bucket, objectKey string, reader io.Reader, size int64, contentType string) error {
+	if bucket == "" {
+		bucket = s.defaultBucket
+	}
+
+	opts := minio.PutObjectOptions{ContentType: contentType}
+	if size < 0 {
+		// Without a size the client would buffer parts sized for a 5 TiB object.
+		opts.PartSize = unknownSizePartSize
+	}
+	info, err := s.client.PutObject(ctx, bucket, objectKey, reader, size, opts)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to upload object to MinIO", "error", err, "bucket", bucket, "key", objectKey)
+		return fmt.Errorf("failed to upload object %s/%s: %w", bucket, objectKey, err)
+	}
+
+	s.logger.DebugContext(ctx, "Uploaded object to MinIO", "bucket", bucket, "key", objectKey, "size", info.Size)
+	return nil
+}
+
+// GetObject opens an object for reading. The caller must close the returned reader.
+func (s *MinioStorageService) GetObject(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error) {
+	if bucket == "" {
+		bucket = s.defaultBucket
+	}
+
+	obj, err := s.client.GetObject(ctx, bucket, objectKey, minio.GetObjectOptions{})
+	if err == nil {
+		// GetObject is lazy; Stat surfaces a missing object before the first read.
+		_, err = obj.Stat()
+	}
+	if err != nil {
+		if obj != nil {
+			obj.Close()
+		}
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("%w: object %s/%s", domain.ErrNotFound, bucket, objectKey)
+		}
+		s.logger.ErrorContext(ctx, "Failed to get object from MinIO", "error", err, "bucket", bucket, "key", objectKey)
+		return nil, fmt.Errorf("failed to get object %s/%s: %w", bucket, objectKey, err)
+	}
+	return obj, nil
+}
+
+// ListObjects lists all objects under prefix in the bucket, recursively.
+func (s *MinioStorageService) ListObjects(ctx context.Context, bucket, prefix string) ([]port.StorageObject, error) {
+	if bucket == "" {
+		bucket = s.defaultBucket
+	}
+
+	var objects []port.StorageObject
+	for info := range s.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if info.Err != nil {
+			s.logger.ErrorContext(ctx, "Failed to list objects", "error", info.Err, "bucket", bucket, "prefix", prefix)
+			return nil, fmt.Errorf("failed to list objects in %s/%s: %w", bucket, prefix, info.Err)
+		}
+		objects = append(objects, port.StorageObject{Key: info.Key, Size: info.Size, LastModified: info.LastModified})
+	}
+	return objects, nil
+}
+
+// CheckBucket verifies that MinIO is reachable and the default bucket exists.
+// Used by the readiness probe.
+func (s *MinioStorageService) CheckBucket(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.defaultBucket)
+	if err != nil {
+		return fmt.Errorf("checking bucket %q: %w", s.defaultBucket, err)
+	}
+	if !exists {
+		return fmt.Errorf("bucket %q does not exist", s.defaultBucket)
+	}
+	return nil
+}
+
 // Compile-time check to ensure MinioStorageService satisfies the port.FileStorageService interface
 var _ port.FileStorageService = (*MinioStorageService)(nil)
@@ -0,0 +1,97 @@
+// internal/adapter/service/ratelimit/memory_store.go
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// limiterEntry stores the limiter and the last seen time.
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// MemoryStore keeps token buckets in process memory.
+// Limits are per instance and reset on restart; use the Postgres store when
+// running several replicas.
+type MemoryStore struct {
+	entries map[string]*limiterEntry
+	mu      sync.Mutex
+	logger  *slog.Logger
+}
+
+// NewMemoryStore creates an empty in-memory rate limit store.
+func NewMemoryStore(logger *slog.Logger) *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*limiterEntry),
+		logger:  logger.With("service", "RateLimitMemoryStore"),
+	}
+}
+
+// Allow consumes one token from the bucket identified by key.
+func (s *MemoryStore) Allow(_ context.Context, key string, policy port.RateLimitPolicy) (port.RateLimitResult, error) {
+	now := time.Now()
+	l := s.getLimiter(key, policy, now)
+
+	allowed := l.AllowN(now, 1)
+	tokens := l.TokensAt(now)
+
+	result := port.RateLimitResult{
+		Allowed:    allowed,
+		Remaining:  int(math.Max(0, math.Floor(tokens))),
+		ResetAfter: durationFor(float64(policy.Burst)-tokens, policy.Rate),
+	}
+	if !allowed {
+		result.RetryAfter = durationFor(1-tokens, policy.Rate)
+	}
+	return result, nil
+}
+
+func (s *MemoryStore) getLimiter(key string, policy port.RateLimitPolicy, now time.Time) *rate.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.entries[key]
+	if !exists {
+		entry = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(policy.Rate), policy.Burst)}
+		s.entries[key] = entry
+		s.logger.Debug("Added new rate limiter entry", "key", key)
+	}
+	entry.lastSeen = now
+	return entry.limiter
+}
+
+// CleanUp evicts limiters that have not been used for longer than maxIdle.
+func (s *MemoryStore) CleanUp(_ context.Context, maxIdle time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	now := time.Now()
+	for key, entry := range s.entries {
+		if now.Sub(entry.lastSeen) > maxIdle {
+			delete(s.entries, key)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// durationFor returns how long it takes to accumulate the missing tokens at the given rate.
+func durationFor(missing float64, ratePerSecond float64) time.Duration {
+	if missing <= 0 || ratePerSecond <= 0 {
+		return 0
+	}
+	return time.Duration(missing / ratePerSecond * float64(time.Second))
+}
+
+// Compile-time check to ensure MemoryStore implements port.RateLimitStore
+var _ port.RateLimitStore = (*MemoryStore)(nil)
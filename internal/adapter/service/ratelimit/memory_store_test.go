@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/service/ratelimit/ratelimittest"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+func newTestStore(*testing.T) port.RateLimitStore {
+	return NewMemoryStore(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestMemoryStore(t *testing.T) {
+	ratelimittest.TestStore(t, newTestStore)
+}
+
+func TestMemoryStore_CleanUpKeepsRecentlySeenBuckets(t *testing.T) {
+	store := NewMemoryStore(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+	policy := port.RateLimitPolicy{Rate: 1, Burst: 1}
+
+	_, err := store.Allow(ctx, "idle", policy)
+	require.NoError(t, err)
+	time.Sleep(30 * time.Millisecond)
+	_, err = store.Allow(ctx, "active", policy)
+	require.NoError(t, err)
+
+	removed, err := store.CleanUp(ctx, 20*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Contains(t, store.entries, "active")
+	assert.NotContains(t, store.entries, "idle")
+}
+
+func TestDurationFor(t *testing.T) {
+	assert.Equal(t, 500*time.Millisecond, durationFor(1, 2))
+	assert.Zero(t, durationFor(0, 2), "nothing missing")
+	assert.Zero(t, durationFor(-1, 2))
+	assert.Zero(t, durationFor(1, 0), "no refill")
+}
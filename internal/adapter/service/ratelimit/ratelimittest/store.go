@@ -0,0 +1,119 @@
+// Package ratelimittest checks port.RateLimitStore implementations against the behaviour the
+// rate limiting middleware relies on, so that the in-memory and PostgreSQL stores stay interchangeable.
+package ratelimittest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// policy refills a token every 20ms so that tests can wait for refills.
+var policy = port.RateLimitPolicy{Rate: 50, Burst: 3}
+
+const interval = 20 * time.Millisecond
+
+// TestStore runs the shared expectations against a store. newStore must return an empty store;
+// keys are unique per test run, so stores backed by a shared database can be reused.
+func TestStore(t *testing.T, newStore func(t *testing.T) port.RateLimitStore) {
+	t.Run("Burst", func(t *testing.T) { testBurst(t, newStore(t)) })
+	t.Run("Refill", func(t *testing.T) { testRefill(t, newStore(t)) })
+	t.Run("KeysAreIndependent", func(t *testing.T) { testKeysAreIndependent(t, newStore(t)) })
+	t.Run("CleanUp", func(t *testing.T) { testCleanUp(t, newStore(t)) })
+}
+
+func uniqueKey(t *testing.T, name string) string {
+	return t.Name() + "|" + name + "|" + time.Now().Format(time.RFC3339Nano)
+}
+
+func testBurst(t *testing.T, store port.RateLimitStore) {
+	ctx := context.Background()
+	key := uniqueKey(t, "client")
+
+	for i := 0; i < policy.Burst; i++ {
+		result, err := store.Allow(ctx, key, policy)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed, "request %d is within the burst", i+1)
+		assert.Equal(t, policy.Burst-1-i, result.Remaining, "request %d", i+1)
+		assert.Zero(t, result.RetryAfter)
+		assert.Positive(t, result.ResetAfter)
+		assert.LessOrEqual(t, result.ResetAfter, time.Duration(policy.Burst)*interval)
+	}
+
+	result, err := store.Allow(ctx, key, policy)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed, "the burst is used up")
+	assert.Zero(t, result.Remaining)
+	assert.Positive(t, result.RetryAfter)
+	assert.LessOrEqual(t, result.RetryAfter, interval, "one token is missing")
+	assert.Greater(t, result.ResetAfter, time.Duration(policy.Burst-1)*interval)
+}
+
+func testRefill(t *testing.T, store port.RateLimitStore) {
+	ctx := context.Background()
+	key := uniqueKey(t, "client")
+
+	for i := 0; i < policy.Burst; i++ {
+		_, err := store.Allow(ctx, key, policy)
+		require.NoError(t, err)
+	}
+	result, err := store.Allow(ctx, key, policy)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+
+	time.Sleep(result.RetryAfter + 5*time.Millisecond)
+	result, err = store.Allow(ctx, key, policy)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "a token was added after RetryAfter")
+
+	// Refused requests do not consume tokens, so the bucket refills fully after ResetAfter
+	time.Sleep(time.Duration(policy.Burst)*interval + 10*time.Millisecond)
+	for i := 0; i < policy.Burst; i++ {
+		result, err = store.Allow(ctx, key, policy)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed, "request %d after a full refill", i+1)
+	}
+}
+
+func testKeysAreIndependent(t *testing.T, store port.RateLimitStore) {
+	ctx := context.Background()
+	first, second := uniqueKey(t, "first"), uniqueKey(t, "second")
+
+	for i := 0; i <= policy.Burst; i++ {
+		_, err := store.Allow(ctx, first, policy)
+		require.NoError(t, err)
+	}
+	result, err := store.Allow(ctx, second, policy)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, policy.Burst-1, result.Remaining)
+}
+
+func testCleanUp(t *testing.T, store port.RateLimitStore) {
+	ctx := context.Background()
+	key := uniqueKey(t, "client")
+
+	for i := 0; i < policy.Burst; i++ {
+		_, err := store.Allow(ctx, key, policy)
+		require.NoError(t, err)
+	}
+	removed, err := store.CleanUp(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Zero(t, removed, "buckets in use are kept")
+
+	// Idle long enough for the bucket to be full and then idle for maxIdle
+	time.Sleep(time.Duration(policy.Burst)*interval + 30*time.Millisecond)
+	removed, err = store.CleanUp(ctx, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, removed, 1)
+
+	result, err := store.Allow(ctx, key, policy)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, policy.Burst-1, result.Remaining, "a removed bucket starts full")
+}
@@ -0,0 +1,128 @@
+// internal/adapter/service/staging/filesystem_store.go
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// FilesystemStore keeps the bytes of each resumable upload in a file of a local directory.
+// Uploads must be resumed on the instance that created them; use a shared volume when
+// running several replicas.
+type FilesystemStore struct {
+	dir    string
+	logger *slog.Logger
+}
+
+// NewFilesystemStore creates a FilesystemStore in dir, creating the directory if needed.
+func NewFilesystemStore(dir string, logger *slog.Logger) (*FilesystemStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("creating upload staging directory: %w", err)
+	}
+	return &FilesystemStore{
+		dir:    dir,
+		logger: logger.With("service", "FilesystemStagingStore"),
+	}, nil
+}
+
+// path returns the staging file of an upload. IDs must be UUIDs so they cannot escape dir.
+func (s *FilesystemStore) path(id string) (string, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return "", fmt.Errorf("%w: invalid upload ID", domain.ErrInvalidArgument)
+	}
+	return filepath.Join(s.dir, id+".part"), nil
+}
+
+func (s *FilesystemStore) Create(ctx context.Context, id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return domain.ErrConflict
+		}
+		return fmt.Errorf("creating staging file: %w", err)
+	}
+	return f.Close()
+}
+
+func (s *FilesystemStore) Append(ctx context.Context, id string, offset int64, r io.Reader) (int64, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return 0, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, domain.ErrNotFound
+		}
+		return 0, fmt.Errorf("opening staging file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("reading staging file size: %w", err)
+	}
+	if info.Size() < offset {
+		s.logger.ErrorContext(ctx, "Staging file is shorter than the recorded offset", "uploadID", id, "size", info.Size(), "offset", offset)
+		return 0, fmt.Errorf("staging file of upload %s is missing data", id)
+	}
+	// Drop bytes written by an earlier request that were never recorded.
+	if err := f.Truncate(offset); err != nil {
+		return 0, fmt.Errorf("truncating staging file: %w", err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("seeking in staging file: %w", err)
+	}
+	n, err := io.Copy(f, r)
+	if err != nil {
+		return n, err
+	}
+	if err := f.Sync(); err != nil {
+		return 0, fmt.Errorf("syncing staging file: %w", err)
+	}
+	return n, nil
+}
+
+func (s *FilesystemStore) Open(ctx context.Context, id string) (io.ReadCloser, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("opening staging file: %w", err)
+	}
+	return f, nil
+}
+
+func (s *FilesystemStore) Delete(ctx context.Context, id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("deleting staging file: %w", err)
+	}
+	return nil
+}
+
+// Compile-time check to ensure FilesystemStore satisfies the port.UploadStagingStore interface
+var _ port.UploadStagingStore = (*FilesystemStore)(nil)
@@ -0,0 +1,82 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+)
+
+func newTestStore(t *testing.T) *FilesystemStore {
+	store, err := NewFilesystemStore(t.TempDir(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	return store
+}
+
+func readAll(t *testing.T, store *FilesystemStore, id string) string {
+	rc, err := store.Open(context.Background(), id)
+	require.NoError(t, err)
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestFilesystemStore_AppendAndOpen(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	id := domain.NewResumableUploadID().String()
+
+	require.NoError(t, store.Create(ctx, id))
+	assert.ErrorIs(t, store.Create(ctx, id), domain.ErrConflict)
+
+	n, err := store.Append(ctx, id, 0, strings.NewReader("hello "))
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), n)
+	n, err = store.Append(ctx, id, 6, strings.NewReader("world"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+	assert.Equal(t, "hello world", readAll(t, store, id))
+
+	require.NoError(t, store.Delete(ctx, id))
+	require.NoError(t, store.Delete(ctx, id))
+	_, err = store.Open(ctx, id)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = store.Append(ctx, id, 0, strings.NewReader("x"))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestFilesystemStore_AppendDiscardsUnrecordedBytes(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	id := domain.NewResumableUploadID().String()
+	require.NoError(t, store.Create(ctx, id))
+
+	// An interrupted request reports the bytes it wrote before failing.
+	n, err := store.Append(ctx, id, 0, io.MultiReader(strings.NewReader("abc"), failingReader{}))
+	require.Error(t, err)
+	assert.Equal(t, int64(3), n)
+
+	// Only two of them were recorded; the retry overwrites the rest.
+	_, err = store.Append(ctx, id, 2, strings.NewReader("XYZ"))
+	require.NoError(t, err)
+	assert.Equal(t, "abXYZ", readAll(t, store, id))
+
+	_, err = store.Append(ctx, id, 10, strings.NewReader("gap"))
+	assert.Error(t, err)
+}
+
+func TestFilesystemStore_RejectsInvalidIDs(t *testing.T) {
+	store := newTestStore(t)
+	assert.ErrorIs(t, store.Create(context.Background(), "../escape"), domain.ErrInvalidArgument)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
@@ -0,0 +1,132 @@
+// internal/adapter/service/webhook/sender.go
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+const (
+	// HeaderSignature carries "t=<unix seconds>,v1=<hex HMAC-SHA256>".
+	HeaderSignature = "X-Webhook-Signature"
+	// HeaderDeliveryID identifies the delivery; it is stable across retries so receivers can deduplicate.
+	HeaderDeliveryID = "X-Webhook-Id"
+	// HeaderEvent names the event type.
+	HeaderEvent = "X-Webhook-Event"
+
+	userAgent = "language-learning-player-webhooks/1.0"
+	// maxResponseBytes bounds how much of a receiver's response body is read for error reporting.
+	maxResponseBytes = 1024
+)
+
+// Sender posts signed webhook payloads over HTTP.
+type Sender struct {
+	client *http.Client
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewSender creates a Sender whose requests time out after timeout.
+func NewSender(timeout time.Duration, logger *slog.Logger) *Sender {
+	return &Sender{
+		client: &http.Client{Timeout: timeout},
+		logger: logger.With("service", "WebhookSender"),
+		now:    time.Now,
+	}
+}
+
+// Send delivers req. Redirects are followed by the HTTP client; only a final 2xx counts as success.
+func (s *Sender) Send(ctx context.Context, req port.WebhookRequest) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, fmt.Errorf("building webhook request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", userAgent)
+	httpReq.Header.Set(HeaderDeliveryID, req.DeliveryID)
+	httpReq.Header.Set(HeaderEvent, string(req.EventType))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, s.now(), req.Body))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("sending webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	_, _ = io.Copy(io.Discard, resp.Body) // Drain so the connection can be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value for body sent at timestamp t.
+// The signed message is "<unix seconds>.<body>", which lets receivers reject replays.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + computeMAC(secret, ts, body)
+}
+
+// VerifySignature checks a signature header produced by Sign. Signatures older
+// than tolerance (relative to now) are rejected; a zero tolerance disables the check.
+func VerifySignature(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			sig = value
+		}
+	}
+	if ts == "" || sig == "" {
+		return fmt.Errorf("malformed signature header")
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("malformed signature timestamp: %w", err)
+	}
+	if tolerance > 0 {
+		age := now.Sub(time.Unix(unix, 0))
+		if age > tolerance || age < -tolerance {
+			return fmt.Errorf("signature timestamp outside tolerance")
+		}
+	}
+	expected, err := hex.DecodeString(computeMAC(secret, ts, body))
+	if err != nil {
+		return err
+	}
+	given, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(expected, given) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func computeMAC(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Compile-time check to ensure Sender satisfies the port.WebhookSender interface
+var _ port.WebhookSender = (*Sender)(nil)
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+func newTestSender() *Sender {
+	return NewSender(5*time.Second, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestSender_SendsSignedRequest(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"type":"track.published"}`)
+
+	var got *http.Request
+	var gotBody []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	status, err := newTestSender().Send(context.Background(), port.WebhookRequest{
+		URL: receiver.URL, Secret: secret, DeliveryID: "d-1", EventType: domain.EventTrackPublished, Body: body,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	require.NotNil(t, got)
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+	assert.Equal(t, "d-1", got.Header.Get(HeaderDeliveryID))
+	assert.Equal(t, "track.published", got.Header.Get(HeaderEvent))
+	assert.Equal(t, body, gotBody)
+	assert.NoError(t, VerifySignature(secret, got.Header.Get(HeaderSignature), gotBody, time.Now(), 5*time.Minute))
+	assert.Error(t, VerifySignature("other-secret", got.Header.Get(HeaderSignature), gotBody, time.Now(), 5*time.Minute))
+}
+
+func TestSender_NonSuccessStatusIsError(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "try later", http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	status, err := newTestSender().Send(context.Background(), port.WebhookRequest{URL: receiver.URL, Body: []byte("{}")})
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+	assert.Contains(t, err.Error(), "try later")
+}
+
+func TestSender_ConnectionErrorHasNoStatus(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	url := receiver.URL
+	receiver.Close()
+
+	status, err := newTestSender().Send(context.Background(), port.WebhookRequest{URL: url, Body: []byte("{}")})
+	assert.Equal(t, 0, status)
+	assert.Error(t, err)
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"a":1}`)
+	signedAt := time.Unix(1700000000, 0)
+	header := Sign("secret", signedAt, body)
+
+	assert.NoError(t, VerifySignature("secret", header, body, signedAt.Add(time.Minute), 5*time.Minute))
+	assert.Error(t, VerifySignature("secret", header, []byte(`{"a":2}`), signedAt, 5*time.Minute), "tampered body")
+	assert.Error(t, VerifySignature("secret", header, body, signedAt.Add(time.Hour), 5*time.Minute), "stale timestamp")
+	assert.NoError(t, VerifySignature("secret", header, body, signedAt.Add(time.Hour), 0), "tolerance disabled")
+	assert.Error(t, VerifySignature("secret", "garbage", body, signedAt, 0))
+}
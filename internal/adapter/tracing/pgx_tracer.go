@@ -0,0 +1,51 @@
+// internal/adapter/tracing/pgx_tracer.go
+package tracing
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxStatementLength caps the SQL recorded on spans.
+const maxStatementLength = 2048
+
+// PgxTracer implements pgx.QueryTracer, creating a child span for every query.
+// Arguments are never recorded, only the SQL text.
+type PgxTracer struct {
+	tracer trace.Tracer
+}
+
+// NewPgxTracer creates a query tracer using the given provider.
+func NewPgxTracer(tp trace.TracerProvider) *PgxTracer {
+	return &PgxTracer{tracer: tp.Tracer(InstrumentationName + "/pgx")}
+}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (t *PgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	sql := data.SQL
+	if len(sql) > maxStatementLength {
+		sql = sql[:maxStatementLength]
+	}
+	ctx, _ = t.tracer.Start(ctx, "db.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBQueryText(sql)),
+	)
+	return ctx
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *PgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil && data.Err != pgx.ErrNoRows {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+	span.End()
+}
+
+// Compile-time check to ensure PgxTracer satisfies the pgx.QueryTracer interface
+var _ pgx.QueryTracer = (*PgxTracer)(nil)
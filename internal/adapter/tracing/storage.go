@@ -0,0 +1,99 @@
+// internal/adapter/tracing/storage.go
+package tracing
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// TracedFileStorage decorates a port.FileStorageService with a span per call.
+type TracedFileStorage struct {
+	next   port.FileStorageService
+	tracer trace.Tracer
+}
+
+// NewTracedFileStorage wraps next so every call gets a child span.
+func NewTracedFileStorage(next port.FileStorageService, tp trace.TracerProvider) *TracedFileStorage {
+	return &TracedFileStorage{next: next, tracer: tp.Tracer(InstrumentationName + "/storage")}
+}
+
+func (s *TracedFileStorage) start(ctx context.Context, operation, bucket, objectKey string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "storage."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("storage.bucket", bucket),
+			attribute.String("storage.object_key", objectKey),
+		),
+	)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (s *TracedFileStorage) GetPresignedGetURL(ctx context.Context, bucket, objectKey string, expiry time.Duration) (string, error) {
+	ctx, span := s.start(ctx, "presign_get", bucket, objectKey)
+	url, err := s.next.GetPresignedGetURL(ctx, bucket, objectKey, expiry)
+	endSpan(span, err)
+	return url, err
+}
+
+func (s *TracedFileStorage) GetPresignedPutURL(ctx context.Context, bucket, objectKey string, expiry time.Duration) (string, error) {
+	ctx, span := s.start(ctx, "presign_put", bucket, objectKey)
+	url, err := s.next.GetPresignedPutURL(ctx, bucket, objectKey, expiry)
+	endSpan(span, err)
+	return url, err
+}
+
+func (s *TracedFileStorage) DeleteObject(ctx context.Context, bucket, objectKey string) error {
+	ctx, span := s.start(ctx, "delete_object", bucket, objectKey)
+	err := s.next.DeleteObject(ctx, bucket, objectKey)
+	endSpan(span, err)
+	return err
+}
+
+func (s *TracedFileStorage) ObjectExists(ctx context.Context, bucket, objectKey string) (bool, error) {
+	ctx, span := s.start(ctx, "object_exists", bucket, objectKey)
+	exists, err := s.next.ObjectExists(ctx, bucket, objectKey)
+	span.SetAttributes(attribute.Bool("storage.exists", exists))
+	endSpan(span, err)
+	return exists, err
+}
+
+func (s *TracedFileStorage) PutObject(ctx context.Context, bucket, objectKey string, reader io.Reader, size int64, contentType string) error {
+	ctx, span := s.start(ctx, "put_object", bucket, objectKey)
+	span.SetAttributes(attribute.Int64("storage.size", size))
+	err := s.next.PutObject(ctx, bucket, objectKey, reader, size, contentType)
+	endSpan(span, err)
+	return err
+}
+
+// GetObject traces opening the object, not reading it.
+func (s *TracedFileStorage) GetObject(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error) {
+	ctx, span := s.start(ctx, "get_object", bucket, objectKey)
+	obj, err := s.next.GetObject(ctx, bucket, objectKey)
+	endSpan(span, err)
+	return obj, err
+}
+
+func (s *TracedFileStorage) ListObjects(ctx context.Context, bucket, prefix string) ([]port.StorageObject, error) {
+	ctx, span := s.start(ctx, "list_objects", bucket, prefix)
+	objects, err := s.next.ListObjects(ctx, bucket, prefix)
+	span.SetAttributes(attribute.Int("storage.object_count", len(objects)))
+	endSpan(span, err)
+	return objects, err
+}
+
+// Compile-time check to ensure TracedFileStorage satisfies the port.FileStorageService interface
+var _ port.FileStorageService = (*TracedFileStorage)(nil)
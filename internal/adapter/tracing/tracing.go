@@ -0,0 +1,66 @@
+// internal/adapter/tracing/tracing.go
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+)
+
+// InstrumentationName identifies spans created by this application.
+const InstrumentationName = "github.com/yvanyang/language-learning-player-api"
+
+// ShutdownFunc flushes pending spans and stops the exporter.
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup configures the global tracer provider and the W3C trace context propagator.
+// When tracing is disabled a no-op provider is installed, so instrumented code
+// needs no special casing. The returned ShutdownFunc must be called on exit.
+func Setup(ctx context.Context, cfg config.TracingConfig, logger *slog.Logger) (trace.TracerProvider, ShutdownFunc, error) {
+	// Always propagate incoming trace context, even when we don't export our own spans.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if !cfg.Enabled {
+		tp := noop.NewTracerProvider()
+		otel.SetTracerProvider(tp)
+		logger.Info("Tracing is disabled")
+		return tp, func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	tp := NewTracerProvider(cfg, sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tp)
+	logger.Info("Tracing enabled", "endpoint", cfg.Endpoint, "serviceName", cfg.ServiceName, "sampleRatio", cfg.SampleRatio)
+	return tp, tp.Shutdown, nil
+}
+
+// NewTracerProvider builds an SDK tracer provider with the configured service name
+// and sampler. Tests pass sdktrace.WithSyncer(tracetest.NewInMemoryExporter()).
+func NewTracerProvider(cfg config.TracingConfig, opts ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	res := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName))
+	opts = append([]sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		// Respect the caller's sampling decision; sample new traces by ratio.
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}, opts...)
+	return sdktrace.NewTracerProvider(opts...)
+}
@@ -0,0 +1,114 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/middleware"
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+func newTestProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := NewTracerProvider(config.TracingConfig{ServiceName: "test", SampleRatio: 1}, sdktrace.WithSyncer(exporter))
+	return tp, exporter
+}
+
+// fakeStorage is a minimal port.FileStorageService for decorator tests.
+type fakeStorage struct {
+	err error
+}
+
+func (f *fakeStorage) GetPresignedGetURL(ctx context.Context, bucket, objectKey string, expiry time.Duration) (string, error) {
+	return "http://example.com/" + objectKey, f.err
+}
+func (f *fakeStorage) GetPresignedPutURL(ctx context.Context, bucket, objectKey string, expiry time.Duration) (string, error) {
+	return "", f.err
+}
+func (f *fakeStorage) DeleteObject(ctx context.Context, bucket, objectKey string) error {
+	return f.err
+}
+func (f *fakeStorage) ObjectExists(ctx context.Context, bucket, objectKey string) (bool, error) {
+	return f.err == nil, f.err
+}
+func (f *fakeStorage) PutObject(ctx context.Context, bucket, objectKey string, reader io.Reader, size int64, contentType string) error {
+	return f.err
+}
+func (f *fakeStorage) GetObject(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("")), f.err
+}
+func (f *fakeStorage) ListObjects(ctx context.Context, bucket, prefix string) ([]port.StorageObject, error) {
+	return nil, f.err
+}
+
+func TestTracedFileStorage(t *testing.T) {
+	tp, exporter := newTestProvider()
+
+	ok := NewTracedFileStorage(&fakeStorage{}, tp)
+	_, err := ok.GetPresignedGetURL(context.Background(), "bucket", "key.mp3", time.Minute)
+	require.NoError(t, err)
+
+	failing := NewTracedFileStorage(&fakeStorage{err: errors.New("boom")}, tp)
+	err = failing.DeleteObject(context.Background(), "bucket", "key.mp3")
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "storage.presign_get", spans[0].Name)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, "storage.delete_object", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	tp, exporter := newTestProvider()
+	propagator := propagation.TraceContext{}
+
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(middleware.Tracing(tp, propagator))
+	router.Get("/tracks/{trackId}", func(w http.ResponseWriter, r *http.Request) {
+		// Child spans started from the request context join the server span.
+		_, child := tp.Tracer("test").Start(r.Context(), "child")
+		child.End()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/tracks/abc", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	child, server := spans[0], spans[1]
+	assert.Equal(t, "GET /tracks/{trackId}", server.Name)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String(), "should continue incoming trace")
+	assert.Equal(t, server.SpanContext.SpanID(), child.Parent.SpanID())
+
+	attrs := map[string]string{}
+	for _, kv := range server.Attributes {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	assert.Equal(t, "req-123", attrs["http.request_id"])
+	assert.Equal(t, "/tracks/{trackId}", attrs["http.route"])
+	assert.Equal(t, "204", attrs["http.response.status_code"])
+}
@@ -5,8 +5,10 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -15,14 +17,25 @@ import (
 
 // Config holds all configuration for the application.
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	JWT      JWTConfig      `mapstructure:"jwt"`
-	Minio    MinioConfig    `mapstructure:"minio"`
-	Google   GoogleConfig   `mapstructure:"google"`
-	Log      LogConfig      `mapstructure:"log"`
-	Cors     CorsConfig     `mapstructure:"cors"`
-	CDN      CDNConfig      `mapstructure:"cdn"`
+	Server      ServerConfig      `mapstructure:"server"`
+	Database    DatabaseConfig    `mapstructure:"database"`
+	JWT         JWTConfig         `mapstructure:"jwt"`
+	Minio       MinioConfig       `mapstructure:"minio"`
+	Google      GoogleConfig      `mapstructure:"google"`
+	Log         LogConfig         `mapstructure:"log"`
+	Cors        CorsConfig        `mapstructure:"cors"`
+	CDN         CDNConfig         `mapstructure:"cdn"`
+	RateLimit   RateLimitConfig   `mapstructure:"rateLimit"`
+	Idempotency IdempotencyConfig `mapstructure:"idempotency"`
+	Webhook     WebhookConfig     `mapstructure:"webhook"`
+	Jobs        JobsConfig        `mapstructure:"jobs"`
+	Upload      UploadConfig      `mapstructure:"upload"`
+	Import      ImportConfig      `mapstructure:"import"`
+	Feed        FeedConfig        `mapstructure:"feed"`
+	FeedIngest  FeedIngestConfig  `mapstructure:"feedIngest"`
+	DeepLink    DeepLinkConfig    `mapstructure:"deepLink"`
+	Metrics     MetricsConfig     `mapstructure:"metrics"`
+	Tracing     TracingConfig     `mapstructure:"tracing"`
 }
 
 // ServerConfig holds server specific configuration.
@@ -31,6 +44,9 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `mapstructure:"readTimeout"`
 	WriteTimeout time.Duration `mapstructure:"writeTimeout"`
 	IdleTimeout  time.Duration `mapstructure:"idleTimeout"`
+	// ShutdownDrainDelay is how long /readyz reports not-ready before the server
+	// stops accepting connections, giving load balancers time to react.
+	ShutdownDrainDelay time.Duration `mapstructure:"shutdownDrainDelay"`
 }
 
 // DatabaseConfig holds database connection configuration.
@@ -85,6 +101,144 @@ type CDNConfig struct {
 	BaseURL string `mapstructure:"baseUrl"`
 }
 
+// MetricsConfig holds Prometheus metrics endpoint configuration.
+// If AdminPort is set the endpoint is served on its own listener instead of the API port;
+// otherwise Token is required.
+type MetricsConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	Path      string `mapstructure:"path"`
+	Token     string `mapstructure:"token"`     // Optional bearer token required to scrape
+	AdminPort string `mapstructure:"adminPort"` // Optional separate port, e.g. "9090"
+}
+
+// IdempotencyConfig holds configuration for Idempotency-Key handling on mutating endpoints.
+type IdempotencyConfig struct {
+	Enabled         bool          `mapstructure:"enabled"`
+	TTL             time.Duration `mapstructure:"ttl"`             // How long a stored response can be replayed
+	CleanupInterval time.Duration `mapstructure:"cleanupInterval"` // How often expired keys are deleted
+	MaxBodyBytes    int64         `mapstructure:"maxBodyBytes"`    // Largest request body that will be fingerprinted
+	InProgressLease time.Duration `mapstructure:"inProgressLease"` // How long a key whose request never finished (e.g., crash) blocks retries
+}
+
+// WebhookConfig holds configuration for delivering outbox events to webhook subscribers.
+// Events are always recorded in the outbox; Enabled only controls the dispatcher worker.
+type WebhookConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	PollInterval   time.Duration `mapstructure:"pollInterval"`   // How often the outbox and due deliveries are checked
+	BatchSize      int           `mapstructure:"batchSize"`      // Max events fanned out and deliveries sent per poll
+	RequestTimeout time.Duration `mapstructure:"requestTimeout"` // Timeout for a single delivery attempt
+	MaxAttempts    int           `mapstructure:"maxAttempts"`    // Attempts before a delivery is dead-lettered
+	InitialBackoff time.Duration `mapstructure:"initialBackoff"` // Delay before the first retry; doubles per attempt
+	MaxBackoff     time.Duration `mapstructure:"maxBackoff"`     // Upper bound for the retry delay
+}
+
+// JobsConfig holds configuration for the background job worker, which runs either
+// inside the API process (RunInAPI) or as the separate cmd/worker binary.
+type JobsConfig struct {
+	RunInAPI          bool          `mapstructure:"runInApi"`          // Also process jobs in the API server
+	Concurrency       int           `mapstructure:"concurrency"`       // Max jobs executed at once per process
+	PollInterval      time.Duration `mapstructure:"pollInterval"`      // How often due jobs are checked
+	VisibilityTimeout time.Duration `mapstructure:"visibilityTimeout"` // How long a claimed job may run before it is retried elsewhere
+	InitialBackoff    time.Duration `mapstructure:"initialBackoff"`    // Delay before the first retry; doubles per attempt
+	MaxBackoff        time.Duration `mapstructure:"maxBackoff"`        // Upper bound for the retry delay
+	ShutdownTimeout   time.Duration `mapstructure:"shutdownTimeout"`   // How long running jobs may finish after SIGTERM
+	Retention         time.Duration `mapstructure:"retention"`         // How long finished jobs are kept
+}
+
+// UploadConfig holds limits for audio files uploaded through the API (POST /uploads/audio/direct
+// and the tus endpoint /uploads/tus) rather than directly to storage with a presigned URL.
+type UploadConfig struct {
+	MaxFileSize         int64         `mapstructure:"maxFileSize"`         // Largest audio file accepted, in bytes
+	Timeout             time.Duration `mapstructure:"timeout"`             // Time allowed to receive a file or chunk (instead of server.readTimeout)
+	AllowedContentTypes []string      `mapstructure:"allowedContentTypes"` // Media types accepted for audio files
+	// StagingDir keeps the bytes of resumable uploads until they are complete. Uploads must be
+	// resumed on the same instance unless the directory is shared between replicas.
+	StagingDir          string        `mapstructure:"stagingDir"`
+	ResumableExpiration time.Duration `mapstructure:"resumableExpiration"` // Resumable uploads without progress for this long are deleted
+}
+
+// ImportConfig holds limits for bulk imports of audio libraries (admin API and llpctl import).
+// Archive imports run as background jobs; jobs.visibilityTimeout must cover the largest archive.
+type ImportConfig struct {
+	MaxArchiveSize int64         `mapstructure:"maxArchiveSize"` // Largest ZIP accepted by the admin API, in bytes
+	MaxFileSize    int64         `mapstructure:"maxFileSize"`    // Largest single audio file imported, in bytes
+	UploadTimeout  time.Duration `mapstructure:"uploadTimeout"`  // Time allowed to receive an archive (instead of server.readTimeout)
+}
+
+// FeedConfig holds configuration for the podcast feeds of collections.
+// Feeds link to stream URLs signed with SigningKey, which redirect to short-lived presigned storage URLs.
+type FeedConfig struct {
+	PublicBaseURL    string        `mapstructure:"publicBaseUrl"`    // Externally reachable base URL of the API, used for links in feeds
+	SigningKey       string        `mapstructure:"signingKey"`       // HMAC key for stream links; defaults to jwt.secretKey
+	StreamLinkExpiry time.Duration `mapstructure:"streamLinkExpiry"` // How long the stream links in a feed stay valid
+}
+
+// FeedIngestConfig holds configuration for ingesting external podcast feeds (admin API).
+// A recurring job queues a refresh of every feed that is due; see jobs for the worker.
+type FeedIngestConfig struct {
+	RefreshSchedule       string        `mapstructure:"refreshSchedule"`       // Cron spec of the job that looks for due feeds
+	RefreshInterval       time.Duration `mapstructure:"refreshInterval"`       // Time between two fetches of the same feed
+	RequestTimeout        time.Duration `mapstructure:"requestTimeout"`        // Time allowed to download a feed
+	DownloadTimeout       time.Duration `mapstructure:"downloadTimeout"`       // Time allowed to download one episode
+	MaxFeedSize           int64         `mapstructure:"maxFeedSize"`           // Largest feed document accepted, in bytes
+	MaxEpisodeSize        int64         `mapstructure:"maxEpisodeSize"`        // Largest episode downloaded, in bytes
+	MaxEpisodesPerRefresh int           `mapstructure:"maxEpisodesPerRefresh"` // New episodes downloaded per refresh; the rest follow with the next ones
+	// Feeds and enclosures are never fetched from loopback, private, link-local or unspecified
+	// addresses unless they fall in one of these CIDR ranges (e.g. an intranet feed server).
+	AllowedPrivateNetworks []string `mapstructure:"allowedPrivateNetworks"`
+}
+
+// DeepLinkConfig holds configuration for links that open content in the client apps, such as shared loops.
+type DeepLinkConfig struct {
+	BaseURL string `mapstructure:"baseUrl"` // Web app URL or custom app scheme (e.g. "langplayer://") that deep links start with
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration.
+// Spans are exported via OTLP/HTTP to Endpoint (e.g. an OpenTelemetry Collector).
+type TracingConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	ServiceName string  `mapstructure:"serviceName"`
+	Endpoint    string  `mapstructure:"endpoint"`    // host:port of the OTLP/HTTP receiver
+	Insecure    bool    `mapstructure:"insecure"`    // Use plain HTTP instead of HTTPS
+	SampleRatio float64 `mapstructure:"sampleRatio"` // Fraction of new traces to sample (0..1)
+}
+
+// RateLimitConfig holds request rate limiting configuration.
+// Requests are matched against Routes first (by method and chi route pattern);
+// anything not matched falls back to Default.
+type RateLimitConfig struct {
+	Enabled         bool                   `mapstructure:"enabled"`
+	Backend         string                 `mapstructure:"backend"`         // "memory" (per instance) or "postgres" (shared by all replicas)
+	StoreTimeout    time.Duration          `mapstructure:"storeTimeout"`    // Requests are allowed (fail open) if the backend takes longer
+	CleanupInterval time.Duration          `mapstructure:"cleanupInterval"` // How often idle limiters are evicted
+	MaxIdle         time.Duration          `mapstructure:"maxIdle"`         // Evict limiters not used for this long
+	Default         RateLimitPolicy        `mapstructure:"default"`
+	Routes          []RouteRateLimitPolicy `mapstructure:"routes"`
+}
+
+// RateLimitPolicy describes a token bucket: RPS tokens are added per second up to Burst.
+type RateLimitPolicy struct {
+	RPS   float64 `mapstructure:"rps"`
+	Burst int     `mapstructure:"burst"`
+	KeyBy string  `mapstructure:"keyBy"` // "ip" or "user" (falls back to IP for anonymous requests)
+}
+
+// RouteRateLimitPolicy applies a RateLimitPolicy to a single route.
+type RouteRateLimitPolicy struct {
+	Method          string `mapstructure:"method"`  // Optional; empty matches any method
+	Pattern         string `mapstructure:"pattern"` // chi route pattern, e.g. "/api/v1/auth/login"
+	RateLimitPolicy `mapstructure:",squash"`
+}
+
+// Rate limit key strategies.
+const (
+	RateLimitKeyByIP   = "ip"
+	RateLimitKeyByUser = "user"
+
+	RateLimitBackendMemory   = "memory"
+	RateLimitBackendPostgres = "postgres"
+)
+
 // LoadConfig reads configuration from file or environment variables.
 func LoadConfig(path string) (config Config, err error) {
 	v := viper.New()
@@ -150,15 +304,120 @@ func LoadConfig(path string) (config Config, err error) {
 		return config, fmt.Errorf("jwt.refreshTokenExpiry must be longer than jwt.accessTokenExpiry")
 	}
 
+	if config.Metrics.Enabled && config.Metrics.Token == "" && config.Metrics.AdminPort == "" {
+		return config, fmt.Errorf("metrics.token or metrics.adminPort must be set when metrics are enabled, so the endpoint is not public")
+	}
+
+	if config.Idempotency.Enabled && (config.Idempotency.TTL <= 0 || config.Idempotency.MaxBodyBytes <= 0 || config.Idempotency.InProgressLease <= 0) {
+		return config, fmt.Errorf("idempotency.ttl, idempotency.maxBodyBytes and idempotency.inProgressLease must be positive")
+	}
+
+	if config.Webhook.Enabled {
+		if config.Webhook.PollInterval <= 0 || config.Webhook.RequestTimeout <= 0 || config.Webhook.BatchSize <= 0 {
+			return config, fmt.Errorf("webhook.pollInterval, webhook.requestTimeout and webhook.batchSize must be positive")
+		}
+		if config.Webhook.MaxAttempts <= 0 || config.Webhook.InitialBackoff <= 0 || config.Webhook.MaxBackoff < config.Webhook.InitialBackoff {
+			return config, fmt.Errorf("webhook.maxAttempts and webhook.initialBackoff must be positive and webhook.maxBackoff at least webhook.initialBackoff")
+		}
+	}
+
+	if config.Jobs.Concurrency <= 0 || config.Jobs.PollInterval <= 0 || config.Jobs.VisibilityTimeout <= 0 {
+		return config, fmt.Errorf("jobs.concurrency, jobs.pollInterval and jobs.visibilityTimeout must be positive")
+	}
+	if config.Jobs.InitialBackoff <= 0 || config.Jobs.MaxBackoff < config.Jobs.InitialBackoff {
+		return config, fmt.Errorf("jobs.initialBackoff must be positive and jobs.maxBackoff at least jobs.initialBackoff")
+	}
+
+	if config.Upload.MaxFileSize <= 0 || config.Upload.Timeout <= 0 || len(config.Upload.AllowedContentTypes) == 0 {
+		return config, fmt.Errorf("upload.maxFileSize and upload.timeout must be positive and upload.allowedContentTypes not empty")
+	}
+	if config.Upload.StagingDir == "" || config.Upload.ResumableExpiration <= 0 {
+		return config, fmt.Errorf("upload.stagingDir must be set and upload.resumableExpiration must be positive")
+	}
+
+	if config.Import.MaxArchiveSize <= 0 || config.Import.MaxFileSize <= 0 || config.Import.UploadTimeout <= 0 {
+		return config, fmt.Errorf("import.maxArchiveSize, import.maxFileSize and import.uploadTimeout must be positive")
+	}
+
+	if u, parseErr := url.Parse(config.Feed.PublicBaseURL); parseErr != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return config, fmt.Errorf("feed.publicBaseUrl must be an absolute http(s) URL")
+	}
+	if config.Feed.StreamLinkExpiry <= 0 {
+		return config, fmt.Errorf("feed.streamLinkExpiry must be positive")
+	}
+	if config.Feed.SigningKey == "" {
+		config.Feed.SigningKey = config.JWT.SecretKey
+	}
+
+	if u, parseErr := url.Parse(config.DeepLink.BaseURL); parseErr != nil || u.Scheme == "" || ((u.Scheme == "http" || u.Scheme == "https") && u.Host == "") {
+		return config, fmt.Errorf("deepLink.baseUrl must be an absolute URL")
+	}
+
+	if config.FeedIngest.RefreshSchedule == "" || config.FeedIngest.RefreshInterval <= 0 {
+		return config, fmt.Errorf("feedIngest.refreshSchedule must be set and feedIngest.refreshInterval must be positive")
+	}
+	if config.FeedIngest.RequestTimeout <= 0 || config.FeedIngest.DownloadTimeout <= 0 || config.FeedIngest.MaxFeedSize <= 0 ||
+		config.FeedIngest.MaxEpisodeSize <= 0 || config.FeedIngest.MaxEpisodesPerRefresh <= 0 {
+		return config, fmt.Errorf("feedIngest.requestTimeout, downloadTimeout, maxFeedSize, maxEpisodeSize and maxEpisodesPerRefresh must be positive")
+	}
+	for _, network := range config.FeedIngest.AllowedPrivateNetworks {
+		if _, parseErr := netip.ParsePrefix(network); parseErr != nil {
+			return config, fmt.Errorf("feedIngest.allowedPrivateNetworks contains an invalid CIDR %q: %w", network, parseErr)
+		}
+	}
+
+	if config.Tracing.Enabled && (config.Tracing.SampleRatio < 0 || config.Tracing.SampleRatio > 1) {
+		return config, fmt.Errorf("tracing.sampleRatio must be between 0 and 1")
+	}
+
+	if err := validateRateLimitConfig(config.RateLimit); err != nil {
+		return config, err
+	}
+
 	return config, nil
 }
 
+func validateRateLimitConfig(cfg RateLimitConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Backend != RateLimitBackendMemory && cfg.Backend != RateLimitBackendPostgres {
+		return fmt.Errorf("rateLimit.backend must be %q or %q", RateLimitBackendMemory, RateLimitBackendPostgres)
+	}
+	if err := validateRateLimitPolicy("rateLimit.default", cfg.Default); err != nil {
+		return err
+	}
+	for i, route := range cfg.Routes {
+		if route.Pattern == "" {
+			return fmt.Errorf("rateLimit.routes[%d].pattern is required", i)
+		}
+		if err := validateRateLimitPolicy(fmt.Sprintf("rateLimit.routes[%d]", i), route.RateLimitPolicy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRateLimitPolicy(name string, p RateLimitPolicy) error {
+	if p.RPS <= 0 {
+		return fmt.Errorf("%s.rps must be positive", name)
+	}
+	if p.Burst <= 0 {
+		return fmt.Errorf("%s.burst must be positive", name)
+	}
+	if p.KeyBy != RateLimitKeyByIP && p.KeyBy != RateLimitKeyByUser {
+		return fmt.Errorf("%s.keyBy must be %q or %q", name, RateLimitKeyByIP, RateLimitKeyByUser)
+	}
+	return nil
+}
+
 func setDefaultValues(v *viper.Viper) {
 	// Server Defaults
 	v.SetDefault("server.port", "8080")
 	v.SetDefault("server.readTimeout", "5s")
 	v.SetDefault("server.writeTimeout", "10s")
 	v.SetDefault("server.idleTimeout", "120s")
+	v.SetDefault("server.shutdownDrainDelay", "5s")
 
 	// Database Defaults
 	v.SetDefault("database.maxOpenConns", 25)
@@ -189,13 +448,97 @@ func setDefaultValues(v *viper.Viper) {
 
 	// CORS Defaults
 	v.SetDefault("cors.allowedOrigins", []string{"http://localhost:3000", "http://127.0.0.1:3000"})
-	v.SetDefault("cors.allowedMethods", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"})
+	v.SetDefault("cors.allowedMethods", []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
 	v.SetDefault("cors.allowedHeaders", []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"})
 	v.SetDefault("cors.allowCredentials", true)
 	v.SetDefault("cors.maxAge", 300)
 
 	// CDN Default
 	v.SetDefault("cdn.baseUrl", "")
+
+	// Metrics Defaults
+	v.SetDefault("metrics.enabled", false)
+	v.SetDefault("metrics.path", "/metrics")
+	v.SetDefault("metrics.token", "")
+	v.SetDefault("metrics.adminPort", "")
+
+	// Tracing Defaults
+	v.SetDefault("tracing.enabled", false)
+	v.SetDefault("tracing.serviceName", "language-learning-player-api")
+	v.SetDefault("tracing.endpoint", "localhost:4318")
+	v.SetDefault("tracing.insecure", true)
+	v.SetDefault("tracing.sampleRatio", 1.0)
+
+	// Idempotency Defaults
+	v.SetDefault("idempotency.enabled", true)
+	v.SetDefault("idempotency.ttl", "24h")
+	v.SetDefault("idempotency.cleanupInterval", "1h")
+	v.SetDefault("idempotency.maxBodyBytes", 1<<20) // 1 MiB
+	v.SetDefault("idempotency.inProgressLease", "5m")
+
+	// Webhook Defaults
+	v.SetDefault("webhook.enabled", true)
+	v.SetDefault("webhook.pollInterval", "5s")
+	v.SetDefault("webhook.batchSize", 50)
+	v.SetDefault("webhook.requestTimeout", "10s")
+	v.SetDefault("webhook.maxAttempts", 8)
+	v.SetDefault("webhook.initialBackoff", "30s")
+	v.SetDefault("webhook.maxBackoff", "6h")
+
+	// Jobs Defaults
+	v.SetDefault("jobs.runInApi", true)
+	v.SetDefault("jobs.concurrency", 4)
+	v.SetDefault("jobs.pollInterval", "2s")
+	v.SetDefault("jobs.visibilityTimeout", "5m")
+	v.SetDefault("jobs.initialBackoff", "10s")
+	v.SetDefault("jobs.maxBackoff", "1h")
+	v.SetDefault("jobs.shutdownTimeout", "30s")
+	v.SetDefault("jobs.retention", "168h")
+
+	// Upload Defaults
+	v.SetDefault("upload.maxFileSize", 512<<20) // 512 MiB
+	v.SetDefault("upload.timeout", "15m")
+	v.SetDefault("upload.stagingDir", filepath.Join(os.TempDir(), "llp-uploads"))
+	v.SetDefault("upload.resumableExpiration", "24h")
+	v.SetDefault("upload.allowedContentTypes", []string{"audio/mpeg", "audio/mp4", "audio/x-m4a", "audio/aac", "audio/ogg", "audio/opus", "audio/webm", "audio/flac", "audio/x-flac", "audio/wav", "audio/x-wav", "audio/wave"})
+
+	// Import Defaults
+	v.SetDefault("import.maxArchiveSize", 2<<30) // 2 GiB
+	v.SetDefault("import.maxFileSize", 512<<20)  // 512 MiB
+	v.SetDefault("import.uploadTimeout", "30m")
+
+	// Feed Defaults
+	v.SetDefault("feed.publicBaseUrl", "http://localhost:8080")
+	v.SetDefault("feed.signingKey", "")
+	v.SetDefault("feed.streamLinkExpiry", "8760h") // 1 year; podcast apps keep episodes for a long time
+
+	// Feed Ingestion Defaults
+	v.SetDefault("feedIngest.refreshSchedule", "@every 5m")
+	v.SetDefault("feedIngest.refreshInterval", "1h")
+	v.SetDefault("feedIngest.requestTimeout", "30s")
+	v.SetDefault("feedIngest.downloadTimeout", "10m")
+	v.SetDefault("feedIngest.maxFeedSize", 10<<20)     // 10 MiB
+	v.SetDefault("feedIngest.maxEpisodeSize", 512<<20) // 512 MiB
+	v.SetDefault("feedIngest.maxEpisodesPerRefresh", 20)
+	v.SetDefault("feedIngest.allowedPrivateNetworks", []string{})
+
+	// Deep Link Defaults
+	v.SetDefault("deepLink.baseUrl", "http://localhost:3000")
+
+	// Rate Limit Defaults
+	v.SetDefault("rateLimit.enabled", true)
+	v.SetDefault("rateLimit.backend", RateLimitBackendMemory)
+	v.SetDefault("rateLimit.storeTimeout", "50ms")
+	v.SetDefault("rateLimit.cleanupInterval", "10m")
+	v.SetDefault("rateLimit.maxIdle", "30m")
+	v.SetDefault("rateLimit.default.rps", 10)
+	v.SetDefault("rateLimit.default.burst", 20)
+	v.SetDefault("rateLimit.default.keyBy", RateLimitKeyByIP)
+	v.SetDefault("rateLimit.routes", []map[string]interface{}{
+		{"method": "POST", "pattern": "/api/v1/auth/login", "rps": 0.2, "burst": 5, "keyBy": RateLimitKeyByIP},
+		{"method": "POST", "pattern": "/api/v1/auth/register", "rps": 0.05, "burst": 3, "keyBy": RateLimitKeyByIP},
+		{"method": "POST", "pattern": "/api/v1/users/me/progress", "rps": 5, "burst": 30, "keyBy": RateLimitKeyByUser},
+	})
 }
 
 func GetConfig() (Config, error) {
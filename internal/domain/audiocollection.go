@@ -36,7 +36,11 @@ type AudioCollection struct {
 	Description string
 	OwnerID     UserID // The user who owns/created the collection
 	Type        CollectionType // Value object (COURSE or PLAYLIST)
+	Visibility  CollectionVisibility // Who besides the owner may read the collection
 	TrackIDs    []TrackID // Ordered list of TrackIDs in the collection
+	Version     int       // Incremented on every update; used for optimistic concurrency control
+	PublishedAt *time.Time // Courses only: when the course was opened for enrollment; nil if unpublished
+	SmartRule   *SmartRule // Smart collections only: the query that selects the tracks; TrackIDs stays empty
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -58,7 +62,9 @@ func NewAudioCollection(title, description string, ownerID UserID, colType Colle
 		Description: description,
 		OwnerID:     ownerID,
 		Type:        colType,
+		Visibility:  VisibilityPrivate,
 		TrackIDs:    make([]TrackID, 0), // Initialize empty slice
+		Version:     1,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}, nil
@@ -97,6 +103,25 @@ func (c *AudioCollection) RemoveTrack(trackID TrackID) bool {
 	return removed
 }
 
+// MoveTrack moves a track ID already in the collection to a new 0-based position.
+// If position is out of bounds, the track is moved to the end.
+func (c *AudioCollection) MoveTrack(trackID TrackID, position int) error {
+	from := slices.Index(c.TrackIDs, trackID)
+	if from < 0 {
+		return fmt.Errorf("%w: track %s is not part of collection %s", ErrNotFound, trackID, c.ID)
+	}
+	if position < 0 || position >= len(c.TrackIDs) {
+		position = len(c.TrackIDs) - 1 // Move to end if out of bounds
+	}
+	if position == from {
+		return nil
+	}
+
+	c.TrackIDs = slices.Insert(slices.Delete(c.TrackIDs, from, from+1), position, trackID)
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
 // ReorderTracks sets the track order to the provided list of IDs.
 // It ensures all provided IDs were already present in the collection.
 func (c *AudioCollection) ReorderTracks(orderedTrackIDs []TrackID) error {
@@ -123,4 +148,17 @@ func (c *AudioCollection) ReorderTracks(orderedTrackIDs []TrackID) error {
 	c.TrackIDs = orderedTrackIDs
 	c.UpdatedAt = time.Now()
 	return nil
-}
\ No newline at end of file
+}
+
+// IsPublished reports whether the collection is a course open for enrollment.
+func (c *AudioCollection) IsPublished() bool {
+	return c.Type == TypeCourse && c.PublishedAt != nil
+}
+
+// IsVisibleTo reports whether a viewer may read the collection. viewerID is nil for anonymous viewers.
+func (c *AudioCollection) IsVisibleTo(viewerID *UserID) bool {
+	if viewerID != nil && *viewerID == c.OwnerID {
+		return true
+	}
+	return c.Visibility == VisibilityUnlisted || c.Visibility == VisibilityPublic
+}
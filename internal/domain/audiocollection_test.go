@@ -68,6 +68,7 @@ func TestNewCollection(t *testing.T) {
 				assert.Equal(t, tt.colType, got.Type)
 				assert.NotNil(t, got.TrackIDs) // Should be initialized
 				assert.Empty(t, got.TrackIDs)  // Should be empty initially
+				assert.Equal(t, 1, got.Version)
 				assert.WithinDuration(t, start, got.CreatedAt, end.Sub(start)+time.Millisecond)
 				assert.WithinDuration(t, start, got.UpdatedAt, end.Sub(start)+time.Millisecond)
 			}
@@ -147,6 +148,42 @@ func TestAudioCollection_TrackManagement(t *testing.T) {
 		assert.True(t, collection.UpdatedAt.After(timeAfterRemove2))
 	})
 
+	// --- MoveTrack ---
+	t.Run("MoveTrack", func(t *testing.T) {
+		collection.TrackIDs = []TrackID{track1, track2, track3}
+		timeBeforeMove := time.Now()
+		collection.UpdatedAt = timeBeforeMove
+
+		// Move first track to the middle
+		err := collection.MoveTrack(track1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []TrackID{track2, track1, track3}, collection.TrackIDs)
+		assert.True(t, collection.UpdatedAt.After(timeBeforeMove))
+
+		// Move last track to the beginning
+		err = collection.MoveTrack(track3, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []TrackID{track3, track2, track1}, collection.TrackIDs)
+
+		// Move to end (position out of bounds)
+		err = collection.MoveTrack(track3, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []TrackID{track2, track1, track3}, collection.TrackIDs)
+		timeAfterMoves := collection.UpdatedAt
+
+		// Move to current position (no change)
+		err = collection.MoveTrack(track1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []TrackID{track2, track1, track3}, collection.TrackIDs)
+		assert.Equal(t, timeAfterMoves, collection.UpdatedAt)
+
+		// Move track not in collection (should error)
+		err = collection.MoveTrack(NewTrackID(), 0)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Equal(t, []TrackID{track2, track1, track3}, collection.TrackIDs)
+		assert.Equal(t, timeAfterMoves, collection.UpdatedAt)
+	})
+
 	// --- ReorderTracks ---
 	t.Run("ReorderTracks", func(t *testing.T) {
 		// Reset state
@@ -207,3 +244,20 @@ func TestAudioCollection_TrackManagement(t *testing.T) {
 		// assert.True(t, collection.UpdatedAt.After(timeBeforeEmptyReorder)) // debatable if timestamp should change here
 	})
 }
+
+func TestAudioCollection_IsVisibleTo(t *testing.T) {
+	ownerID, otherID := NewUserID(), NewUserID()
+	collection, err := NewAudioCollection("Class 3B", "", ownerID, TypePlaylist)
+	assert.NoError(t, err)
+	assert.Equal(t, VisibilityPrivate, collection.Visibility)
+
+	assert.True(t, collection.IsVisibleTo(&ownerID))
+	assert.False(t, collection.IsVisibleTo(&otherID))
+	assert.False(t, collection.IsVisibleTo(nil))
+
+	for _, v := range []CollectionVisibility{VisibilityUnlisted, VisibilityPublic} {
+		collection.Visibility = v
+		assert.True(t, collection.IsVisibleTo(&otherID), v.String())
+		assert.True(t, collection.IsVisibleTo(nil), v.String())
+	}
+}
@@ -2,7 +2,9 @@
 package domain
 
 import (
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -41,6 +43,9 @@ type AudioTrack struct {
 	UploaderID      *UserID // Optional link to the user who uploaded it
 	IsPublic        bool
 	Tags            []string
+	ContentSHA256   string // Hex SHA-256 of the audio object; empty until computed after the upload
+	SizeBytes       int64  // Size of the audio object; 0 until computed
+	Version         int    // Incremented on every update; used for optimistic concurrency control
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	// TranscriptionID *TranscriptionID // Optional if transcriptions are separate entities
@@ -83,7 +88,22 @@ func NewAudioTrack(
 		IsPublic:       isPublic,
 		Tags:           tags,
 		CoverImageURL:  coverURL,
+		Version:        1,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}, nil
-}
\ No newline at end of file
+}
+// IsVisibleTo reports whether a viewer may see the track: public tracks are visible to everyone,
+// private tracks only to their uploader. viewerID is nil for anonymous viewers.
+func (t *AudioTrack) IsVisibleTo(viewerID *UserID) bool {
+	return t.IsPublic || (viewerID != nil && t.UploaderID != nil && *t.UploaderID == *viewerID)
+}
+
+// ParseContentHash validates a hex-encoded SHA-256 digest and returns it in lower case.
+func ParseContentHash(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if b, err := hex.DecodeString(s); err != nil || len(b) != 32 {
+		return "", fmt.Errorf("%w: content hash must be a hex-encoded SHA-256 digest", ErrInvalidArgument)
+	}
+	return s, nil
+}
@@ -63,6 +63,7 @@ func TestNewAudioTrack(t *testing.T) {
 				assert.Equal(t, tt.uploaderID, got.UploaderID)
 				assert.Equal(t, tt.isPublic, got.IsPublic)
 				assert.Equal(t, tt.tags, got.Tags) // Check slice equality
+				assert.Equal(t, 1, got.Version)
 				assert.Equal(t, tt.coverURL, got.CoverImageURL)
 				assert.WithinDuration(t, start, got.CreatedAt, end.Sub(start)+time.Millisecond)
 				assert.WithinDuration(t, start, got.UpdatedAt, end.Sub(start)+time.Millisecond)
@@ -70,3 +71,32 @@ func TestNewAudioTrack(t *testing.T) {
 		})
 	}
 }
+
+func TestParseContentHash(t *testing.T) {
+	const sum = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	got, err := ParseContentHash(" 9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08 ")
+	assert.NoError(t, err)
+	assert.Equal(t, sum, got)
+
+	for _, invalid := range []string{"", "abc", sum[:62], sum + "00", "zz" + sum[2:]} {
+		_, err := ParseContentHash(invalid)
+		assert.ErrorIs(t, err, ErrInvalidArgument, invalid)
+	}
+}
+
+func TestAudioTrack_IsVisibleTo(t *testing.T) {
+	uploaderID, otherID := NewUserID(), NewUserID()
+	track := &AudioTrack{ID: NewTrackID(), UploaderID: &uploaderID}
+
+	assert.True(t, track.IsVisibleTo(&uploaderID))
+	assert.False(t, track.IsVisibleTo(&otherID))
+	assert.False(t, track.IsVisibleTo(nil))
+
+	track.IsPublic = true
+	assert.True(t, track.IsVisibleTo(&otherID))
+	assert.True(t, track.IsVisibleTo(nil))
+
+	track.IsPublic, track.UploaderID = false, nil
+	assert.False(t, track.IsVisibleTo(&uploaderID))
+}
@@ -0,0 +1,130 @@
+// internal/domain/audit.go
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEventID is the unique identifier for an AuditEvent.
+type AuditEventID uuid.UUID
+
+func NewAuditEventID() AuditEventID {
+	return AuditEventID(uuid.New())
+}
+
+func (aid AuditEventID) String() string {
+	return uuid.UUID(aid).String()
+}
+
+// AuditAction identifies what happened in an audit event.
+type AuditAction string
+
+const (
+	AuditActionUserRegister           AuditAction = "user.register"
+	AuditActionUserCreate             AuditAction = "user.create"
+	AuditActionUserRoleChange         AuditAction = "user.role_change"
+	AuditActionPasswordReset          AuditAction = "user.password_reset"
+	AuditActionSessionsRevoke         AuditAction = "user.sessions_revoke"
+	AuditActionLoginSuccess           AuditAction = "auth.login"
+	AuditActionLoginFailure           AuditAction = "auth.login_failed"
+	AuditActionTokenRefresh           AuditAction = "auth.token_refresh"
+	AuditActionLogout                 AuditAction = "auth.logout"
+	AuditActionCollectionCreate       AuditAction = "collection.create"
+	AuditActionCollectionUpdate       AuditAction = "collection.update"
+	AuditActionCollectionDelete       AuditAction = "collection.delete"
+	AuditActionCollectionInvite       AuditAction = "collection.invite"
+	AuditActionCollectionMemberAdd    AuditAction = "collection.member_add"
+	AuditActionCollectionMemberRemove AuditAction = "collection.member_remove"
+	AuditActionCollectionTransfer     AuditAction = "collection.transfer"
+	AuditActionCoursePublish          AuditAction = "course.publish"
+	AuditActionCourseUnpublish        AuditAction = "course.unpublish"
+	AuditActionTrackCreate            AuditAction = "track.create"
+	AuditActionTrackUpdate            AuditAction = "track.update"
+	AuditActionTrackDelete            AuditAction = "track.delete"
+	AuditActionWebhookCreate          AuditAction = "webhook.create"
+	AuditActionWebhookUpdate          AuditAction = "webhook.update"
+	AuditActionWebhookDelete          AuditAction = "webhook.delete"
+	AuditActionImportStart            AuditAction = "import.start"
+	AuditActionFeedSubscribe          AuditAction = "feed.subscribe"
+	AuditActionFeedUnsubscribe        AuditAction = "feed.unsubscribe"
+)
+
+// AuditTargetType identifies the kind of entity an audit event refers to.
+type AuditTargetType string
+
+const (
+	AuditTargetUser       AuditTargetType = "user"
+	AuditTargetCollection AuditTargetType = "collection"
+	AuditTargetTrack      AuditTargetType = "track"
+	AuditTargetWebhook    AuditTargetType = "webhook"
+	AuditTargetImport     AuditTargetType = "import"
+	AuditTargetFeed       AuditTargetType = "feed"
+)
+
+// FieldChange records the old and new value of a single field.
+// A nil Old means the field was set; a nil New means it was cleared.
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// AuditDiff maps field names to their changes.
+type AuditDiff map[string]FieldChange
+
+// AuditEvent is an immutable record of a security-relevant or content-changing action.
+type AuditEvent struct {
+	ID         AuditEventID
+	OccurredAt time.Time
+	ActorID    *UserID // Nil for anonymous actions (e.g., failed login)
+	Action     AuditAction
+	TargetType AuditTargetType
+	TargetID   string
+	Diff       AuditDiff // Optional before/after values
+	RequestID  string
+	IPAddress  string
+}
+
+// NewAuditEvent creates a new audit event occurring now.
+func NewAuditEvent(actorID *UserID, action AuditAction, targetType AuditTargetType, targetID string, diff AuditDiff) (*AuditEvent, error) {
+	if action == "" {
+		return nil, fmt.Errorf("%w: audit action cannot be empty", ErrInvalidArgument)
+	}
+	if targetType == "" {
+		return nil, fmt.Errorf("%w: audit target type cannot be empty", ErrInvalidArgument)
+	}
+	return &AuditEvent{
+		ID:         NewAuditEventID(),
+		OccurredAt: time.Now(),
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Diff:       diff,
+	}, nil
+}
+
+// DiffFields compares two snapshots of an entity and returns the fields that differ.
+// Keys present in only one snapshot are reported with a nil value on the other side.
+// Returns nil when nothing changed.
+func DiffFields(before, after map[string]any) AuditDiff {
+	diff := make(AuditDiff)
+	for key, oldVal := range before {
+		newVal, ok := after[key]
+		if !ok || !reflect.DeepEqual(oldVal, newVal) {
+			diff[key] = FieldChange{Old: oldVal, New: newVal}
+		}
+	}
+	for key, newVal := range after {
+		if _, ok := before[key]; !ok {
+			diff[key] = FieldChange{Old: nil, New: newVal}
+		}
+	}
+	if len(diff) == 0 {
+		return nil
+	}
+	return diff
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditEvent(t *testing.T) {
+	actor := NewUserID()
+
+	tests := []struct {
+		name       string
+		actorID    *UserID
+		action     AuditAction
+		targetType AuditTargetType
+		wantErr    bool
+	}{
+		{"Valid with actor", &actor, AuditActionCollectionCreate, AuditTargetCollection, false},
+		{"Valid anonymous", nil, AuditActionLoginFailure, AuditTargetUser, false},
+		{"Empty action", &actor, "", AuditTargetUser, true},
+		{"Empty target type", &actor, AuditActionLogout, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAuditEvent(tt.actorID, tt.action, tt.targetType, "target-1", nil)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidArgument)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEqual(t, AuditEventID{}, got.ID)
+			assert.Equal(t, tt.actorID, got.ActorID)
+			assert.Equal(t, tt.action, got.Action)
+			assert.Equal(t, tt.targetType, got.TargetType)
+			assert.Equal(t, "target-1", got.TargetID)
+			assert.False(t, got.OccurredAt.IsZero())
+		})
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	before := map[string]any{"title": "Old", "description": "Same", "tracks": []string{"a", "b"}, "removed": 1}
+	after := map[string]any{"title": "New", "description": "Same", "tracks": []string{"a", "b"}, "added": true}
+
+	diff := DiffFields(before, after)
+	assert.Equal(t, AuditDiff{
+		"title":   {Old: "Old", New: "New"},
+		"removed": {Old: 1, New: nil},
+		"added":   {Old: nil, New: true},
+	}, diff)
+
+	assert.Nil(t, DiffFields(before, before), "Identical snapshots should produce no diff")
+	assert.Equal(t, AuditDiff{"title": {Old: nil, New: "T"}}, DiffFields(nil, map[string]any{"title": "T"}))
+}
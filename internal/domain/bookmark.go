@@ -35,6 +35,7 @@ type Bookmark struct {
 	Timestamp time.Duration // Position in the audio track where the bookmark is placed
 	Note      string        // Optional user note about the bookmark
 	CreatedAt time.Time
+	Chapter   *TrackChapter // Chapter containing Timestamp; resolved on read, not stored
 }
 
 // NewBookmark creates a new bookmark instance.
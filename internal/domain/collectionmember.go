@@ -0,0 +1,138 @@
+// internal/domain/collectionmember.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvitationID is the unique identifier for a CollectionInvitation.
+type InvitationID uuid.UUID
+
+func NewInvitationID() InvitationID {
+	return InvitationID(uuid.New())
+}
+
+func InvitationIDFromString(s string) (InvitationID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return InvitationID{}, fmt.Errorf("invalid InvitationID format: %w", err)
+	}
+	return InvitationID(id), nil
+}
+
+func (iid InvitationID) String() string {
+	return uuid.UUID(iid).String()
+}
+
+// CollectionMember grants a user other than the owner access to a collection.
+// The owner is recorded on the collection itself and never has a membership.
+type CollectionMember struct {
+	CollectionID CollectionID
+	UserID       UserID
+	Role         CollectionRole // EDITOR or VIEWER
+	AddedAt      time.Time
+}
+
+// NewCollectionMember adds a user to a collection with the given role.
+func NewCollectionMember(collectionID CollectionID, userID UserID, role CollectionRole) (*CollectionMember, error) {
+	if role != CollectionRoleEditor && role != CollectionRoleViewer {
+		return nil, fmt.Errorf("%w: members can only be editors or viewers", ErrInvalidArgument)
+	}
+	return &CollectionMember{CollectionID: collectionID, UserID: userID, Role: role, AddedAt: time.Now()}, nil
+}
+
+// InvitationStatus is the state of a CollectionInvitation.
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "PENDING"
+	InvitationAccepted InvitationStatus = "ACCEPTED"
+	InvitationDeclined InvitationStatus = "DECLINED"
+	InvitationRevoked  InvitationStatus = "REVOKED" // Withdrawn by the collection owner
+)
+
+// CollectionInvitation invites a user, known by ID or by email address, to join a collection.
+// Invitations by email can be accepted by whoever signs in with that address, including
+// users who register after being invited.
+type CollectionInvitation struct {
+	ID           InvitationID
+	CollectionID CollectionID
+	InviterID    UserID
+	InviteeID    *UserID // Set if the invitee had an account when invited
+	InviteeEmail string  // Set for invitations by email; lower case
+	Role         CollectionRole
+	Status       InvitationStatus
+	CreatedAt    time.Time
+	RespondedAt  *time.Time
+}
+
+// NewCollectionInvitation creates a pending invitation. At least one of inviteeID and inviteeEmail is required.
+func NewCollectionInvitation(collectionID CollectionID, inviterID UserID, inviteeID *UserID, inviteeEmail string, role CollectionRole) (*CollectionInvitation, error) {
+	if role != CollectionRoleEditor && role != CollectionRoleViewer {
+		return nil, fmt.Errorf("%w: invitations can only be for editors or viewers", ErrInvalidArgument)
+	}
+	if inviteeEmail != "" {
+		email, err := NewEmail(inviteeEmail)
+		if err != nil {
+			return nil, err
+		}
+		inviteeEmail = strings.ToLower(email.String())
+	}
+	if inviteeID == nil && inviteeEmail == "" {
+		return nil, fmt.Errorf("%w: an invitation needs a user ID or an email address", ErrInvalidArgument)
+	}
+	if inviteeID != nil && *inviteeID == inviterID {
+		return nil, fmt.Errorf("%w: cannot invite yourself", ErrInvalidArgument)
+	}
+	return &CollectionInvitation{
+		ID:           NewInvitationID(),
+		CollectionID: collectionID,
+		InviterID:    inviterID,
+		InviteeID:    inviteeID,
+		InviteeEmail: inviteeEmail,
+		Role:         role,
+		Status:       InvitationPending,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+// IsAddressedTo reports whether the invitation was sent to the given user, by ID or by email address.
+func (i *CollectionInvitation) IsAddressedTo(user *User) bool {
+	if i.InviteeID != nil {
+		return *i.InviteeID == user.ID
+	}
+	return i.InviteeEmail != "" && strings.EqualFold(i.InviteeEmail, user.Email.String())
+}
+
+// Accept marks a pending invitation as accepted by the given user and returns the resulting membership.
+func (i *CollectionInvitation) Accept(userID UserID) (*CollectionMember, error) {
+	if err := i.respond(InvitationAccepted); err != nil {
+		return nil, err
+	}
+	i.InviteeID = &userID
+	return NewCollectionMember(i.CollectionID, userID, i.Role)
+}
+
+// Decline marks a pending invitation as declined.
+func (i *CollectionInvitation) Decline() error {
+	return i.respond(InvitationDeclined)
+}
+
+// Revoke withdraws a pending invitation.
+func (i *CollectionInvitation) Revoke() error {
+	return i.respond(InvitationRevoked)
+}
+
+func (i *CollectionInvitation) respond(status InvitationStatus) error {
+	if i.Status != InvitationPending {
+		return fmt.Errorf("%w: invitation is already %s", ErrConflict, strings.ToLower(string(i.Status)))
+	}
+	now := time.Now()
+	i.Status = status
+	i.RespondedAt = &now
+	return nil
+}
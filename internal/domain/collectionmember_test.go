@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCollectionInvitation(t *testing.T) {
+	collectionID, inviterID, inviteeID := NewCollectionID(), NewUserID(), NewUserID()
+
+	inv, err := NewCollectionInvitation(collectionID, inviterID, nil, "Co.Teacher@Example.com", CollectionRoleEditor)
+	require.NoError(t, err)
+	assert.Equal(t, "co.teacher@example.com", inv.InviteeEmail)
+	assert.Equal(t, InvitationPending, inv.Status)
+	assert.Nil(t, inv.RespondedAt)
+
+	_, err = NewCollectionInvitation(collectionID, inviterID, &inviteeID, "", CollectionRoleViewer)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		inviteeID *UserID
+		email     string
+		role      CollectionRole
+	}{
+		{"no invitee", nil, "", CollectionRoleViewer},
+		{"invalid email", nil, "not-an-email", CollectionRoleViewer},
+		{"owner role", &inviteeID, "", CollectionRoleOwner},
+		{"self", &inviterID, "", CollectionRoleEditor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCollectionInvitation(collectionID, inviterID, tt.inviteeID, tt.email, tt.role)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+}
+
+func TestCollectionInvitation_IsAddressedTo(t *testing.T) {
+	user, err := NewLocalUser("learner@example.com", "Learner", "hash")
+	require.NoError(t, err)
+	other, err := NewLocalUser("other@example.com", "Other", "hash")
+	require.NoError(t, err)
+
+	byEmail, err := NewCollectionInvitation(NewCollectionID(), NewUserID(), nil, "LEARNER@example.com", CollectionRoleViewer)
+	require.NoError(t, err)
+	assert.True(t, byEmail.IsAddressedTo(user))
+	assert.False(t, byEmail.IsAddressedTo(other))
+
+	byID, err := NewCollectionInvitation(NewCollectionID(), NewUserID(), &other.ID, "", CollectionRoleViewer)
+	require.NoError(t, err)
+	assert.True(t, byID.IsAddressedTo(other))
+	assert.False(t, byID.IsAddressedTo(user))
+}
+
+func TestCollectionInvitation_Respond(t *testing.T) {
+	inv, err := NewCollectionInvitation(NewCollectionID(), NewUserID(), nil, "learner@example.com", CollectionRoleEditor)
+	require.NoError(t, err)
+	userID := NewUserID()
+
+	member, err := inv.Accept(userID)
+	require.NoError(t, err)
+	assert.Equal(t, InvitationAccepted, inv.Status)
+	assert.NotNil(t, inv.RespondedAt)
+	assert.Equal(t, &userID, inv.InviteeID)
+	assert.Equal(t, inv.CollectionID, member.CollectionID)
+	assert.Equal(t, userID, member.UserID)
+	assert.Equal(t, CollectionRoleEditor, member.Role)
+
+	// Only pending invitations can be answered
+	assert.ErrorIs(t, inv.Decline(), ErrConflict)
+	assert.ErrorIs(t, inv.Revoke(), ErrConflict)
+	_, err = inv.Accept(userID)
+	assert.ErrorIs(t, err, ErrConflict)
+
+	declined, err := NewCollectionInvitation(NewCollectionID(), NewUserID(), nil, "learner@example.com", CollectionRoleViewer)
+	require.NoError(t, err)
+	require.NoError(t, declined.Decline())
+	assert.Equal(t, InvitationDeclined, declined.Status)
+}
+
+func TestNewCollectionMember(t *testing.T) {
+	_, err := NewCollectionMember(NewCollectionID(), NewUserID(), CollectionRoleViewer)
+	assert.NoError(t, err)
+	_, err = NewCollectionMember(NewCollectionID(), NewUserID(), CollectionRoleOwner)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
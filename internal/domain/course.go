@@ -0,0 +1,198 @@
+// internal/domain/course.go
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CourseSectionID is the unique identifier for a CourseSection.
+type CourseSectionID uuid.UUID
+
+func NewCourseSectionID() CourseSectionID {
+	return CourseSectionID(uuid.New())
+}
+
+func CourseSectionIDFromString(s string) (CourseSectionID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return CourseSectionID{}, fmt.Errorf("invalid CourseSectionID format: %w", err)
+	}
+	return CourseSectionID(id), nil
+}
+
+func (sid CourseSectionID) String() string {
+	return uuid.UUID(sid).String()
+}
+
+// LessonID is the unique identifier for a Lesson.
+type LessonID uuid.UUID
+
+func NewLessonID() LessonID {
+	return LessonID(uuid.New())
+}
+
+func LessonIDFromString(s string) (LessonID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return LessonID{}, fmt.Errorf("invalid LessonID format: %w", err)
+	}
+	return LessonID(id), nil
+}
+
+func (lid LessonID) String() string {
+	return uuid.UUID(lid).String()
+}
+
+// MaxLessonAttachments limits the attachments of a single lesson.
+const MaxLessonAttachments = 20
+
+// LessonAttachment links supporting material (transcript, worksheet, ...) to a lesson.
+type LessonAttachment struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// Lesson is one step of a course: a track with optional notes and attachments.
+type Lesson struct {
+	ID          LessonID
+	TrackID     TrackID
+	Title       string // Optional; the track title is used when empty
+	Notes       string
+	Attachments []LessonAttachment
+}
+
+// CourseSection groups the ordered lessons of a course.
+type CourseSection struct {
+	ID          CourseSectionID
+	Title       string
+	Description string
+	Lessons     []Lesson
+}
+
+// ValidateCourseOutline checks the ordered sections of a course. Every track may appear in
+// only one lesson, since a course's tracks are also its collection track list.
+func ValidateCourseOutline(sections []CourseSection) error {
+	sectionIDs := make(map[CourseSectionID]struct{}, len(sections))
+	lessonIDs := make(map[LessonID]struct{})
+	trackIDs := make(map[TrackID]struct{})
+	for i, section := range sections {
+		if section.Title == "" {
+			return fmt.Errorf("%w: section %d has no title", ErrInvalidArgument, i+1)
+		}
+		if _, dup := sectionIDs[section.ID]; dup {
+			return fmt.Errorf("%w: section %s appears more than once", ErrInvalidArgument, section.ID)
+		}
+		sectionIDs[section.ID] = struct{}{}
+		for j, lesson := range section.Lessons {
+			if _, dup := lessonIDs[lesson.ID]; dup {
+				return fmt.Errorf("%w: lesson %s appears more than once", ErrInvalidArgument, lesson.ID)
+			}
+			lessonIDs[lesson.ID] = struct{}{}
+			if _, dup := trackIDs[lesson.TrackID]; dup {
+				return fmt.Errorf("%w: track %s is used by more than one lesson", ErrInvalidArgument, lesson.TrackID)
+			}
+			trackIDs[lesson.TrackID] = struct{}{}
+			if len(lesson.Attachments) > MaxLessonAttachments {
+				return fmt.Errorf("%w: lesson %d of section %d has more than %d attachments", ErrInvalidArgument, j+1, i+1, MaxLessonAttachments)
+			}
+			for _, attachment := range lesson.Attachments {
+				if attachment.Title == "" {
+					return fmt.Errorf("%w: attachment of lesson %d in section %d has no title", ErrInvalidArgument, j+1, i+1)
+				}
+				if u, err := url.Parse(attachment.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+					return fmt.Errorf("%w: attachment URL '%s' must be an absolute http(s) URL", ErrInvalidArgument, attachment.URL)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// CourseTrackIDs returns the tracks of all lessons in course order.
+func CourseTrackIDs(sections []CourseSection) []TrackID {
+	var trackIDs []TrackID
+	for _, section := range sections {
+		for _, lesson := range section.Lessons {
+			trackIDs = append(trackIDs, lesson.TrackID)
+		}
+	}
+	return trackIDs
+}
+
+// CopyCourseOutline returns a copy of the outline with new section and lesson IDs, keeping only the
+// lessons whose track satisfies keep. Sections left without lessons are kept.
+func CopyCourseOutline(sections []CourseSection, keep func(TrackID) bool) []CourseSection {
+	copied := make([]CourseSection, 0, len(sections))
+	for _, section := range sections {
+		lessons := make([]Lesson, 0, len(section.Lessons))
+		for _, lesson := range section.Lessons {
+			if !keep(lesson.TrackID) {
+				continue
+			}
+			lesson.ID = NewLessonID()
+			lesson.Attachments = append([]LessonAttachment(nil), lesson.Attachments...)
+			lessons = append(lessons, lesson)
+		}
+		section.ID = NewCourseSectionID()
+		section.Lessons = lessons
+		copied = append(copied, section)
+	}
+	return copied
+}
+
+// Enrollment records that a learner follows a course.
+type Enrollment struct {
+	UserID       UserID
+	CollectionID CollectionID
+	EnrolledAt   time.Time
+}
+
+// NewEnrollment enrolls a learner in a course now.
+func NewEnrollment(userID UserID, collectionID CollectionID) *Enrollment {
+	return &Enrollment{UserID: userID, CollectionID: collectionID, EnrolledAt: time.Now()}
+}
+
+// CourseProgress summarises a learner's progress through a course.
+type CourseProgress struct {
+	LessonsTotal     int
+	LessonsCompleted int
+	PercentComplete  int              // 0-100, rounded down
+	NextLesson       *Lesson          // First lesson not yet completed; nil once the course is finished
+	NextSectionID    *CourseSectionID // Section of NextLesson
+	LastListenedAt   *time.Time       // Most recent progress on any lesson
+}
+
+// ComputeCourseProgress derives the progress through a course from the learner's playback
+// progress. durations holds the duration of each lesson track; progress may omit tracks the
+// learner has not played.
+func ComputeCourseProgress(sections []CourseSection, durations map[TrackID]time.Duration, progress map[TrackID]*PlaybackProgress) CourseProgress {
+	var result CourseProgress
+	for _, section := range sections {
+		for i := range section.Lessons {
+			lesson := &section.Lessons[i]
+			result.LessonsTotal++
+			p := progress[lesson.TrackID]
+			if p != nil && (result.LastListenedAt == nil || p.LastListenedAt.After(*result.LastListenedAt)) {
+				last := p.LastListenedAt
+				result.LastListenedAt = &last
+			}
+			if p != nil && p.CompletesTrack(durations[lesson.TrackID]) {
+				result.LessonsCompleted++
+				continue
+			}
+			if result.NextLesson == nil {
+				sectionID := section.ID
+				result.NextLesson = lesson
+				result.NextSectionID = &sectionID
+			}
+		}
+	}
+	if result.LessonsTotal > 0 {
+		result.PercentComplete = result.LessonsCompleted * 100 / result.LessonsTotal
+	}
+	return result
+}
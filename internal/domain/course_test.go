@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLesson(trackID TrackID) Lesson {
+	return Lesson{ID: NewLessonID(), TrackID: trackID}
+}
+
+func TestValidateCourseOutline(t *testing.T) {
+	t1, t2 := NewTrackID(), NewTrackID()
+	valid := []CourseSection{
+		{ID: NewCourseSectionID(), Title: "Basics", Lessons: []Lesson{newTestLesson(t1)}},
+		{ID: NewCourseSectionID(), Title: "Next steps", Lessons: []Lesson{{
+			ID: NewLessonID(), TrackID: t2,
+			Attachments: []LessonAttachment{{Title: "Transcript", URL: "https://example.com/t.pdf"}},
+		}}},
+	}
+	require.NoError(t, ValidateCourseOutline(valid))
+	require.NoError(t, ValidateCourseOutline(nil))
+	assert.Equal(t, []TrackID{t1, t2}, CourseTrackIDs(valid))
+
+	tests := []struct {
+		name     string
+		sections []CourseSection
+	}{
+		{"untitled section", []CourseSection{{ID: NewCourseSectionID()}}},
+		{"duplicate track", []CourseSection{{ID: NewCourseSectionID(), Title: "A", Lessons: []Lesson{newTestLesson(t1), newTestLesson(t1)}}}},
+		{"duplicate section", []CourseSection{valid[0], valid[0]}},
+		{"relative attachment URL", []CourseSection{{ID: NewCourseSectionID(), Title: "A", Lessons: []Lesson{{
+			ID: NewLessonID(), TrackID: t1, Attachments: []LessonAttachment{{Title: "Notes", URL: "/notes.pdf"}},
+		}}}}},
+		{"untitled attachment", []CourseSection{{ID: NewCourseSectionID(), Title: "A", Lessons: []Lesson{{
+			ID: NewLessonID(), TrackID: t1, Attachments: []LessonAttachment{{URL: "https://example.com/a"}},
+		}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, ValidateCourseOutline(tt.sections), ErrInvalidArgument)
+		})
+	}
+}
+
+func TestCopyCourseOutline(t *testing.T) {
+	t1, t2 := NewTrackID(), NewTrackID()
+	original := []CourseSection{
+		{ID: NewCourseSectionID(), Title: "Basics", Lessons: []Lesson{newTestLesson(t1), {
+			ID: NewLessonID(), TrackID: t2, Notes: "Repeat twice",
+			Attachments: []LessonAttachment{{Title: "Transcript", URL: "https://example.com/t.pdf"}},
+		}}},
+		{ID: NewCourseSectionID(), Title: "Extra", Lessons: []Lesson{}},
+	}
+
+	copied := CopyCourseOutline(original, func(id TrackID) bool { return id == t2 })
+	require.Len(t, copied, 2)
+	assert.NotEqual(t, original[0].ID, copied[0].ID)
+	assert.Equal(t, "Basics", copied[0].Title)
+	require.Len(t, copied[0].Lessons, 1)
+	assert.NotEqual(t, original[0].Lessons[1].ID, copied[0].Lessons[0].ID)
+	assert.Equal(t, t2, copied[0].Lessons[0].TrackID)
+	assert.Equal(t, "Repeat twice", copied[0].Lessons[0].Notes)
+	assert.Equal(t, original[0].Lessons[1].Attachments, copied[0].Lessons[0].Attachments)
+	assert.Empty(t, copied[1].Lessons)
+	require.NoError(t, ValidateCourseOutline(copied))
+}
+
+func TestComputeCourseProgress(t *testing.T) {
+	t1, t2, t3 := NewTrackID(), NewTrackID(), NewTrackID()
+	s1, s2 := NewCourseSectionID(), NewCourseSectionID()
+	sections := []CourseSection{
+		{ID: s1, Title: "One", Lessons: []Lesson{newTestLesson(t1), newTestLesson(t2)}},
+		{ID: s2, Title: "Two", Lessons: []Lesson{newTestLesson(t3)}},
+	}
+	durations := map[TrackID]time.Duration{t1: time.Minute, t2: time.Minute, t3: time.Minute}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	empty := ComputeCourseProgress(sections, durations, nil)
+	assert.Equal(t, 3, empty.LessonsTotal)
+	assert.Zero(t, empty.LessonsCompleted)
+	require.NotNil(t, empty.NextLesson)
+	assert.Equal(t, t1, empty.NextLesson.TrackID)
+	assert.Nil(t, empty.LastListenedAt)
+
+	progress := map[TrackID]*PlaybackProgress{
+		t1: {TrackID: t1, Progress: time.Minute, LastListenedAt: now},
+		t2: {TrackID: t2, Progress: 10 * time.Second, LastListenedAt: now.Add(time.Hour)},
+		t3: {TrackID: t3, Progress: time.Minute, LastListenedAt: now},
+	}
+	got := ComputeCourseProgress(sections, durations, progress)
+	assert.Equal(t, 2, got.LessonsCompleted)
+	assert.Equal(t, 66, got.PercentComplete)
+	require.NotNil(t, got.NextLesson)
+	assert.Equal(t, t2, got.NextLesson.TrackID)
+	assert.Equal(t, s1, *got.NextSectionID)
+	assert.Equal(t, now.Add(time.Hour), *got.LastListenedAt)
+
+	progress[t2].Progress = time.Minute
+	done := ComputeCourseProgress(sections, durations, progress)
+	assert.Equal(t, 100, done.PercentComplete)
+	assert.Nil(t, done.NextLesson)
+
+	assert.Zero(t, ComputeCourseProgress(nil, nil, nil).PercentComplete)
+}
+
+func TestAudioCollection_IsPublished(t *testing.T) {
+	course, err := NewAudioCollection("Spanish A1", "", NewUserID(), TypeCourse)
+	require.NoError(t, err)
+	assert.False(t, course.IsPublished())
+	now := time.Now()
+	course.PublishedAt = &now
+	assert.True(t, course.IsPublished())
+
+	playlist, err := NewAudioCollection("Favourites", "", NewUserID(), TypePlaylist)
+	require.NoError(t, err)
+	playlist.PublishedAt = &now
+	assert.False(t, playlist.IsPublished())
+}
@@ -17,4 +17,10 @@ var (
 	ErrAuthenticationFailed = errors.New("authentication failed")
 	// ErrUnauthenticated indicates the user needs to be authenticated.
 	ErrUnauthenticated = errors.New("unauthenticated") // Could be used by middleware later
-)
\ No newline at end of file
+	// ErrRateLimited indicates the caller has exceeded its request quota.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrPreconditionFailed indicates the resource changed since the version the client last saw.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrPreconditionRequired indicates a conditional request (If-Match) is required for the action.
+	ErrPreconditionRequired = errors.New("precondition required")
+)
@@ -0,0 +1,72 @@
+// internal/domain/event.go
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventID is the unique identifier for an OutboxEvent.
+type EventID uuid.UUID
+
+func NewEventID() EventID {
+	return EventID(uuid.New())
+}
+
+func (eid EventID) String() string {
+	return uuid.UUID(eid).String()
+}
+
+// EventType identifies the kind of domain event published to webhook subscribers.
+type EventType string
+
+const (
+	EventTrackPublished    EventType = "track.published"    // A public track became available
+	EventUploadCompleted   EventType = "upload.completed"   // An upload was finalized into a track
+	EventTrackCompleted    EventType = "track.completed"    // A learner listened to the end of a track
+	EventCoursePublished   EventType = "course.published"   // A course was opened for enrollment
+	EventCourseEnrolled    EventType = "course.enrolled"    // A learner enrolled in a course
+	EventCollectionInvited EventType = "collection.invited" // A user was invited to collaborate on a collection
+)
+
+// EventTypes lists every event type that webhooks can subscribe to.
+var EventTypes = []EventType{EventTrackPublished, EventUploadCompleted, EventTrackCompleted, EventCoursePublished, EventCourseEnrolled, EventCollectionInvited}
+
+// IsValid checks if the event type is one of the published types.
+func (t EventType) IsValid() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// OutboxEvent is a domain event recorded in the same transaction as the change it
+// describes, for asynchronous delivery to webhook subscribers.
+type OutboxEvent struct {
+	ID         EventID
+	Type       EventType
+	Payload    []byte // JSON-encoded event data
+	OccurredAt time.Time
+}
+
+// NewOutboxEvent creates an event occurring now. data is encoded as JSON.
+func NewOutboxEvent(eventType EventType, data any) (*OutboxEvent, error) {
+	if !eventType.IsValid() {
+		return nil, fmt.Errorf("%w: unknown event type '%s'", ErrInvalidArgument, eventType)
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s event data: %w", eventType, err)
+	}
+	return &OutboxEvent{
+		ID:         NewEventID(),
+		Type:       eventType,
+		Payload:    payload,
+		OccurredAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOutboxEvent(t *testing.T) {
+	event, err := NewOutboxEvent(EventTrackPublished, map[string]string{"trackId": "t-1"})
+	require.NoError(t, err)
+	assert.Equal(t, EventTrackPublished, event.Type)
+	assert.JSONEq(t, `{"trackId":"t-1"}`, string(event.Payload))
+	assert.NotEqual(t, EventID{}, event.ID)
+	assert.False(t, event.OccurredAt.IsZero())
+
+	_, err = NewOutboxEvent("track.deleted", nil)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
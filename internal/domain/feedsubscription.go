@@ -0,0 +1,139 @@
+// internal/domain/feedsubscription.go
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedSubscriptionID is the unique identifier for a FeedSubscription.
+type FeedSubscriptionID uuid.UUID
+
+func NewFeedSubscriptionID() FeedSubscriptionID {
+	return FeedSubscriptionID(uuid.New())
+}
+
+func FeedSubscriptionIDFromString(s string) (FeedSubscriptionID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return FeedSubscriptionID{}, fmt.Errorf("invalid FeedSubscriptionID format: %w", err)
+	}
+	return FeedSubscriptionID(id), nil
+}
+
+func (fid FeedSubscriptionID) String() string {
+	return uuid.UUID(fid).String()
+}
+
+// FeedSubscription is an external podcast feed (RSS or Atom) whose episodes are ingested as
+// tracks of OwnerID and kept in sync with one PLAYLIST collection. The feed is fetched again
+// from NextFetchAt, conditionally with the validators of the last successful response.
+type FeedSubscription struct {
+	ID            FeedSubscriptionID
+	FeedURL       string
+	OwnerID       UserID        // Owner of the ingested tracks and collection
+	CollectionID  *CollectionID // Created by the first refresh; nil until then or after it was deleted
+	Title         string        // Title of the feed as of the last refresh
+	LanguageCode  string        // Used for feeds that declare no language
+	Level         AudioLevel    // Level of the ingested tracks; may be LevelUnknown
+	IsPublic      bool          // Visibility of the ingested tracks and collection
+	Tags          []string      // Added to every ingested track
+	ETag          string        // Validators of the last successful response, for conditional requests
+	LastModified  string
+	LastFetchedAt *time.Time // Last successful fetch, including 304 responses
+	NextFetchAt   time.Time
+	LastError     string // Why the last refresh failed; empty after a successful one
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewFeedSubscription creates a subscription that is due for its first refresh immediately.
+func NewFeedSubscription(feedURL string, ownerID UserID, languageCode string, level AudioLevel, isPublic bool, tags []string) (*FeedSubscription, error) {
+	if err := ValidateFeedURL(feedURL); err != nil {
+		return nil, err
+	}
+	if level != LevelUnknown && !level.IsValid() {
+		return nil, fmt.Errorf("%w: invalid audio level '%s'", ErrInvalidArgument, level)
+	}
+	now := time.Now()
+	return &FeedSubscription{
+		ID:           NewFeedSubscriptionID(),
+		FeedURL:      feedURL,
+		OwnerID:      ownerID,
+		LanguageCode: languageCode,
+		Level:        level,
+		IsPublic:     isPublic,
+		Tags:         tags,
+		NextFetchAt:  now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// ValidateFeedURL checks that a feed URL is an absolute http(s) URL.
+func ValidateFeedURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("%w: feed URL must be an absolute http or https URL", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// RecordFetch records a successful refresh and schedules the next one after interval.
+// Empty validators (as on a 304 response without them) keep the previous ones.
+func (s *FeedSubscription) RecordFetch(etag, lastModified string, now time.Time, interval time.Duration) {
+	if etag != "" || lastModified != "" {
+		s.ETag, s.LastModified = etag, lastModified
+	}
+	s.LastFetchedAt = &now
+	s.LastError = ""
+	s.NextFetchAt = now.Add(interval)
+	s.UpdatedAt = now
+}
+
+// RecordFailure records why a refresh failed and schedules the next attempt after interval.
+func (s *FeedSubscription) RecordFailure(errMsg string, now time.Time, interval time.Duration) {
+	s.LastError = errMsg
+	s.NextFetchAt = now.Add(interval)
+	s.UpdatedAt = now
+}
+
+// FeedEpisode links an item of a subscribed feed, identified by its GUID, to the track it was
+// ingested as. The episode outlives its track so that a deleted track is not ingested again.
+type FeedEpisode struct {
+	SubscriptionID FeedSubscriptionID
+	GUID           string
+	TrackID        *TrackID   // Nil once the track has been deleted
+	EnclosureURL   string     // URL the audio was downloaded from
+	PublishedAt    *time.Time // Publication date from the feed; nil if the feed has none
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// FeedEpisodeTrackOrder returns the tracks of the episodes in publication order, oldest first.
+// Episodes without a publication date follow in the order they were ingested; episodes whose
+// track was deleted are left out.
+func FeedEpisodeTrackOrder(episodes []*FeedEpisode) []TrackID {
+	sorted := slices.DeleteFunc(slices.Clone(episodes), func(ep *FeedEpisode) bool { return ep.TrackID == nil })
+	slices.SortStableFunc(sorted, func(a, b *FeedEpisode) int {
+		switch {
+		case a.PublishedAt == nil && b.PublishedAt == nil:
+			return a.CreatedAt.Compare(b.CreatedAt)
+		case a.PublishedAt == nil:
+			return 1
+		case b.PublishedAt == nil:
+			return -1
+		}
+		return cmp.Or(a.PublishedAt.Compare(*b.PublishedAt), a.CreatedAt.Compare(b.CreatedAt))
+	})
+	ids := make([]TrackID, len(sorted))
+	for i, ep := range sorted {
+		ids[i] = *ep.TrackID
+	}
+	return ids
+}
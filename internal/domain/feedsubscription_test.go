@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFeedSubscription(t *testing.T) {
+	ownerID := NewUserID()
+	sub, err := NewFeedSubscription("https://example.com/feed.xml", ownerID, "es", LevelA2, true, []string{"news"})
+	require.NoError(t, err)
+	assert.Equal(t, ownerID, sub.OwnerID)
+	assert.Nil(t, sub.CollectionID)
+	assert.False(t, sub.NextFetchAt.After(time.Now()), "due immediately")
+
+	for _, u := range []string{"", "example.com/feed", "ftp://example.com/feed", "file:///etc/passwd"} {
+		_, err := NewFeedSubscription(u, ownerID, "", LevelUnknown, false, nil)
+		assert.ErrorIs(t, err, ErrInvalidArgument, u)
+	}
+	_, err = NewFeedSubscription("https://example.com/feed.xml", ownerID, "", AudioLevel("Z9"), false, nil)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestFeedSubscription_RecordFetchAndFailure(t *testing.T) {
+	sub, err := NewFeedSubscription("https://example.com/feed.xml", NewUserID(), "", LevelUnknown, false, nil)
+	require.NoError(t, err)
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	sub.RecordFetch(`"v1"`, "Thu, 01 May 2025 10:00:00 GMT", now, time.Hour)
+	assert.Equal(t, `"v1"`, sub.ETag)
+	assert.Equal(t, now.Add(time.Hour), sub.NextFetchAt)
+	require.NotNil(t, sub.LastFetchedAt)
+
+	sub.RecordFailure("status 503", now.Add(time.Hour), time.Hour)
+	assert.Equal(t, "status 503", sub.LastError)
+	assert.Equal(t, now, *sub.LastFetchedAt)
+
+	// A 304 without validators keeps the previous ones and clears the error
+	sub.RecordFetch("", "", now.Add(2*time.Hour), time.Hour)
+	assert.Equal(t, `"v1"`, sub.ETag)
+	assert.Equal(t, "Thu, 01 May 2025 10:00:00 GMT", sub.LastModified)
+	assert.Empty(t, sub.LastError)
+}
+
+func TestFeedEpisodeTrackOrder(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		t := base.AddDate(0, 0, days)
+		return &t
+	}
+	ids := []TrackID{NewTrackID(), NewTrackID(), NewTrackID(), NewTrackID()}
+	episodes := []*FeedEpisode{
+		{TrackID: &ids[0], CreatedAt: base},
+		{TrackID: &ids[1], PublishedAt: at(5), CreatedAt: base},
+		{TrackID: &ids[2], PublishedAt: at(1), CreatedAt: base.Add(time.Minute)},
+		{TrackID: &ids[3], CreatedAt: base.Add(-time.Minute)},
+		{TrackID: nil, PublishedAt: at(0), CreatedAt: base}, // Track deleted
+	}
+	assert.Equal(t, []TrackID{ids[2], ids[1], ids[3], ids[0]}, FeedEpisodeTrackOrder(episodes))
+	assert.Equal(t, ids[0], *episodes[0].TrackID, "input is not reordered")
+}
@@ -0,0 +1,53 @@
+// internal/domain/feedtoken.go
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedTokenID is the unique identifier for a FeedToken.
+type FeedTokenID uuid.UUID
+
+func NewFeedTokenID() FeedTokenID {
+	return FeedTokenID(uuid.New())
+}
+
+func FeedTokenIDFromString(s string) (FeedTokenID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return FeedTokenID{}, fmt.Errorf("invalid FeedTokenID format: %w", err)
+	}
+	return FeedTokenID(id), nil
+}
+
+func (fid FeedTokenID) String() string {
+	return uuid.UUID(fid).String()
+}
+
+// FeedToken lets a user subscribe to the podcast feed of a collection that is not public, using a
+// secret URL. Only a hash of the secret is stored. Each user has at most one token per collection;
+// issuing a new one replaces it, and deleting it revokes the URL and the stream links in the feed.
+type FeedToken struct {
+	ID           FeedTokenID
+	CollectionID CollectionID
+	UserID       UserID
+	TokenHash    string // Hex SHA-256 of the secret in the feed URL
+	CreatedAt    time.Time
+}
+
+// NewFeedToken creates a token for the user's feed of a collection.
+func NewFeedToken(collectionID CollectionID, userID UserID, tokenHash string) (*FeedToken, error) {
+	if tokenHash == "" {
+		return nil, fmt.Errorf("%w: feed token hash cannot be empty", ErrInvalidArgument)
+	}
+	return &FeedToken{
+		ID:           NewFeedTokenID(),
+		CollectionID: collectionID,
+		UserID:       userID,
+		TokenHash:    tokenHash,
+		CreatedAt:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFeedToken(t *testing.T) {
+	collectionID, userID := NewCollectionID(), NewUserID()
+
+	token, err := NewFeedToken(collectionID, userID, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, collectionID, token.CollectionID)
+	assert.Equal(t, userID, token.UserID)
+	assert.Equal(t, "abc123", token.TokenHash)
+	assert.False(t, token.CreatedAt.IsZero())
+
+	other, err := NewFeedToken(collectionID, userID, "def456")
+	require.NoError(t, err)
+	assert.NotEqual(t, token.ID, other.ID)
+
+	_, err = NewFeedToken(collectionID, userID, "")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
@@ -0,0 +1,140 @@
+// internal/domain/importrun.go
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportRunID is the unique identifier for an ImportRun.
+type ImportRunID uuid.UUID
+
+func NewImportRunID() ImportRunID {
+	return ImportRunID(uuid.New())
+}
+
+func ImportRunIDFromString(s string) (ImportRunID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return ImportRunID{}, fmt.Errorf("invalid ImportRunID format: %w", err)
+	}
+	return ImportRunID(id), nil
+}
+
+func (iid ImportRunID) String() string {
+	return uuid.UUID(iid).String()
+}
+
+// ImportStatus is the state of a bulk import.
+type ImportStatus string
+
+const (
+	ImportPending   ImportStatus = "pending"   // Archive staged, waiting for a worker
+	ImportRunning   ImportStatus = "running"   // A worker is importing the archive
+	ImportSucceeded ImportStatus = "succeeded" // Finished; individual files may still have failed (see Report)
+	ImportFailed    ImportStatus = "failed"    // The archive could not be imported at all
+)
+
+// IsValid checks if the status is one of the defined statuses.
+func (s ImportStatus) IsValid() bool {
+	switch s {
+	case ImportPending, ImportRunning, ImportSucceeded, ImportFailed:
+		return true
+	}
+	return false
+}
+
+// ImportItemStatus is the outcome for a single file of an import.
+type ImportItemStatus string
+
+const (
+	ImportItemCreated ImportItemStatus = "created" // A new track was created
+	ImportItemExists  ImportItemStatus = "exists"  // A track with the same content was imported before
+	ImportItemFailed  ImportItemStatus = "failed"
+)
+
+// ImportItem reports what happened to one audio file.
+type ImportItem struct {
+	Path    string           `json:"path"`
+	Status  ImportItemStatus `json:"status"`
+	TrackID string           `json:"trackId,omitempty"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// ImportReport summarises an import. It is stored as JSON with the ImportRun.
+type ImportReport struct {
+	TracksCreated      int          `json:"tracksCreated"`
+	TracksExisting     int          `json:"tracksExisting"`
+	TracksFailed       int          `json:"tracksFailed"`
+	CollectionsCreated int          `json:"collectionsCreated"`
+	CollectionsUpdated int          `json:"collectionsUpdated"`
+	Items              []ImportItem `json:"items"`
+}
+
+// AddItem appends an item and updates the track counters.
+func (r *ImportReport) AddItem(item ImportItem) {
+	switch item.Status {
+	case ImportItemCreated:
+		r.TracksCreated++
+	case ImportItemExists:
+		r.TracksExisting++
+	case ImportItemFailed:
+		r.TracksFailed++
+	}
+	r.Items = append(r.Items, item)
+}
+
+// ImportRun tracks an archive import requested through the admin API.
+type ImportRun struct {
+	ID          ImportRunID
+	RequestedBy *UserID // Nil once the requesting user has been deleted
+	OwnerID     UserID  // Owner of the imported tracks and courses
+	Source      string  // Original archive file name
+	Status      ImportStatus
+	Report      *ImportReport // Set once the run has finished
+	Error       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// NewImportRun creates a pending import run.
+func NewImportRun(requestedBy, ownerID UserID, source string) *ImportRun {
+	now := time.Now()
+	return &ImportRun{
+		ID:          NewImportRunID(),
+		RequestedBy: &requestedBy,
+		OwnerID:     ownerID,
+		Source:      source,
+		Status:      ImportPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Start marks the run as being processed. A retried run clears the previous outcome.
+func (r *ImportRun) Start(now time.Time) {
+	r.Status = ImportRunning
+	r.Error = ""
+	r.UpdatedAt = now
+}
+
+// Succeed records the report of a finished run.
+func (r *ImportRun) Succeed(report *ImportReport, now time.Time) {
+	r.Status = ImportSucceeded
+	r.Report = report
+	r.Error = ""
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+}
+
+// Fail records why the run could not be completed. report may be partial or nil.
+func (r *ImportRun) Fail(errMsg string, report *ImportReport, now time.Time) {
+	r.Status = ImportFailed
+	r.Report = report
+	r.Error = errMsg
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+}
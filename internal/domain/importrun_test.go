@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportReport_AddItem(t *testing.T) {
+	report := &ImportReport{}
+	trackID := NewTrackID().String()
+	report.AddItem(ImportItem{Path: "a.mp3", Status: ImportItemCreated, TrackID: trackID})
+	report.AddItem(ImportItem{Path: "b.mp3", Status: ImportItemExists, TrackID: trackID})
+	report.AddItem(ImportItem{Path: "c.mp3", Status: ImportItemFailed, Error: "not audio"})
+
+	assert.Equal(t, 1, report.TracksCreated)
+	assert.Equal(t, 1, report.TracksExisting)
+	assert.Equal(t, 1, report.TracksFailed)
+	assert.Len(t, report.Items, 3)
+}
+
+func TestImportRun_Lifecycle(t *testing.T) {
+	admin, owner := NewUserID(), NewUserID()
+	run := NewImportRun(admin, owner, "lessons.zip")
+	assert.Equal(t, ImportPending, run.Status)
+	assert.Equal(t, owner, run.OwnerID)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	run.Start(now)
+	assert.Equal(t, ImportRunning, run.Status)
+
+	run.Fail("corrupt archive", nil, now)
+	assert.Equal(t, ImportFailed, run.Status)
+	assert.Equal(t, "corrupt archive", run.Error)
+	require.NotNil(t, run.CompletedAt)
+
+	// A retry clears the previous failure.
+	run.Start(now.Add(time.Minute))
+	assert.Empty(t, run.Error)
+
+	report := &ImportReport{TracksCreated: 2}
+	run.Succeed(report, now.Add(2*time.Minute))
+	assert.Equal(t, ImportSucceeded, run.Status)
+	assert.Same(t, report, run.Report)
+	assert.Equal(t, now.Add(2*time.Minute), *run.CompletedAt)
+}
@@ -0,0 +1,117 @@
+// internal/domain/job.go
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultJobMaxAttempts is the number of attempts a job gets unless the caller overrides it.
+const DefaultJobMaxAttempts = 5
+
+// JobID is the unique identifier for a Job.
+type JobID uuid.UUID
+
+func NewJobID() JobID {
+	return JobID(uuid.New())
+}
+
+func (jid JobID) String() string {
+	return uuid.UUID(jid).String()
+}
+
+// JobStatus is the state of a background job.
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"    // Waiting until RunAt
+	JobRunning   JobStatus = "running"   // Claimed by a worker until LockedUntil
+	JobSucceeded JobStatus = "succeeded" // Handler completed without error
+	JobDead      JobStatus = "dead"      // Gave up after MaxAttempts or a permanent failure
+)
+
+// IsValid checks if the status is one of the defined statuses.
+func (s JobStatus) IsValid() bool {
+	switch s {
+	case JobQueued, JobRunning, JobSucceeded, JobDead:
+		return true
+	}
+	return false
+}
+
+// Job is a unit of background work executed by a handler registered for its Type.
+type Job struct {
+	ID          JobID
+	Type        string
+	Payload     []byte // JSON-encoded handler input
+	Status      JobStatus
+	Attempts    int // Attempts started so far, including the current one while running
+	MaxAttempts int
+	RunAt       time.Time  // Earliest time the job may (next) run
+	LockedUntil *time.Time // Visibility timeout of the current attempt
+	LastError   string
+	UniqueKey   *string // Optional; at most one job per key is ever stored
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// NewJob creates a queued job that becomes due at runAt. data is encoded as JSON.
+func NewJob(jobType string, data any, runAt time.Time) (*Job, error) {
+	if jobType == "" {
+		return nil, fmt.Errorf("%w: job type cannot be empty", ErrInvalidArgument)
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s job payload: %w", jobType, err)
+	}
+	now := time.Now()
+	if runAt.IsZero() {
+		runAt = now
+	}
+	return &Job{
+		ID:          NewJobID(),
+		Type:        jobType,
+		Payload:     payload,
+		Status:      JobQueued,
+		MaxAttempts: DefaultJobMaxAttempts,
+		RunAt:       runAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// MarkSucceeded records that the current attempt completed.
+func (j *Job) MarkSucceeded(now time.Time) {
+	j.Status = JobSucceeded
+	j.LockedUntil = nil
+	j.LastError = ""
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+}
+
+// MarkFailed records a failed attempt and schedules a retry using the policy's
+// backoff, or marks the job dead once MaxAttempts attempts have been made.
+func (j *Job) MarkFailed(errMsg string, now time.Time, policy RetryPolicy) {
+	if j.Attempts >= j.MaxAttempts {
+		j.MarkDead(errMsg, now)
+		return
+	}
+	j.Status = JobQueued
+	j.LockedUntil = nil
+	j.LastError = errMsg
+	j.RunAt = now.Add(policy.Backoff(j.Attempts))
+	j.UpdatedAt = now
+}
+
+// MarkDead records a failure that will not be retried.
+func (j *Job) MarkDead(errMsg string, now time.Time) {
+	j.Status = JobDead
+	j.LockedUntil = nil
+	j.LastError = errMsg
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+}
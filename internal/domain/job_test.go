@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJob(t *testing.T) {
+	job, err := NewJob("email.send", map[string]string{"to": "a@example.com"}, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, JobQueued, job.Status)
+	assert.Equal(t, DefaultJobMaxAttempts, job.MaxAttempts)
+	assert.JSONEq(t, `{"to":"a@example.com"}`, string(job.Payload))
+	assert.False(t, job.RunAt.IsZero(), "Zero runAt means now")
+
+	later := time.Now().Add(time.Hour)
+	job, err = NewJob("email.send", nil, later)
+	require.NoError(t, err)
+	assert.Equal(t, later, job.RunAt)
+
+	_, err = NewJob("", nil, time.Time{})
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestJob_Lifecycle(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Minute, MaxBackoff: time.Hour}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	lock := now.Add(time.Minute)
+
+	job, err := NewJob("export.build", nil, now)
+	require.NoError(t, err)
+	job.MaxAttempts = 2
+
+	// First attempt fails: retried after the initial backoff.
+	job.Status, job.Attempts, job.LockedUntil = JobRunning, 1, &lock
+	job.MarkFailed("boom", now, policy)
+	assert.Equal(t, JobQueued, job.Status)
+	assert.Equal(t, now.Add(time.Minute), job.RunAt)
+	assert.Nil(t, job.LockedUntil)
+	assert.Equal(t, "boom", job.LastError)
+
+	// Second (last) attempt fails: dead.
+	job.Status, job.Attempts = JobRunning, 2
+	job.MarkFailed("boom again", now, policy)
+	assert.Equal(t, JobDead, job.Status)
+	require.NotNil(t, job.CompletedAt)
+
+	job, err = NewJob("export.build", nil, now)
+	require.NoError(t, err)
+	job.Status, job.Attempts, job.LastError = JobRunning, 1, "earlier failure"
+	job.MarkSucceeded(now)
+	assert.Equal(t, JobSucceeded, job.Status)
+	assert.Empty(t, job.LastError)
+	assert.Equal(t, &now, job.CompletedAt)
+}
@@ -0,0 +1,107 @@
+// internal/domain/loop.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MinLoopSpeed and MaxLoopSpeed bound the playback speed of a loop.
+	MinLoopSpeed = 0.25
+	MaxLoopSpeed = 4.0
+	// MaxLoopRepeatCount bounds the repeat count of a loop; 0 repeats until stopped.
+	MaxLoopRepeatCount = 1000
+
+	maxLoopLabelLength = 255
+)
+
+// LoopID is the unique identifier for a Loop.
+type LoopID uuid.UUID
+
+func NewLoopID() LoopID {
+	return LoopID(uuid.New())
+}
+
+func LoopIDFromString(s string) (LoopID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return LoopID{}, fmt.Errorf("invalid LoopID format: %w", err)
+	}
+	return LoopID(id), nil
+}
+
+func (lid LoopID) String() string {
+	return uuid.UUID(lid).String()
+}
+
+// Loop is an A-B loop region of an audio track saved by a user: the player repeats
+// [Start, End) RepeatCount times at PlaybackSpeed.
+type Loop struct {
+	ID            LoopID
+	UserID        UserID
+	TrackID       TrackID
+	Start         time.Duration
+	End           time.Duration
+	Label         string  // Optional, e.g. the sentence being practised
+	RepeatCount   int     // 0 repeats until stopped
+	PlaybackSpeed float64 // 1 is normal speed
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewLoop creates a loop on a track of the given duration (0 if unknown). A zero speed
+// means normal speed.
+func NewLoop(userID UserID, trackID TrackID, start, end time.Duration, label string, repeatCount int, speed float64, trackDuration time.Duration) (*Loop, error) {
+	now := time.Now()
+	loop := &Loop{
+		ID:        NewLoopID(),
+		UserID:    userID,
+		TrackID:   trackID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := loop.Update(start, end, label, repeatCount, speed, trackDuration); err != nil {
+		return nil, err
+	}
+	return loop, nil
+}
+
+// Update replaces the region and playback settings of the loop, with the same rules as NewLoop.
+func (l *Loop) Update(start, end time.Duration, label string, repeatCount int, speed float64, trackDuration time.Duration) error {
+	// Positions are stored with millisecond precision.
+	start, end = start.Truncate(time.Millisecond), end.Truncate(time.Millisecond)
+	label = strings.TrimSpace(label)
+	if speed == 0 {
+		speed = 1
+	}
+	if start < 0 {
+		return fmt.Errorf("%w: loop start cannot be negative", ErrInvalidArgument)
+	}
+	if end <= start {
+		return fmt.Errorf("%w: loop end must be after its start", ErrInvalidArgument)
+	}
+	if trackDuration > 0 && end > trackDuration {
+		return fmt.Errorf("%w: loop end exceeds the track duration of %d ms", ErrInvalidArgument, trackDuration.Milliseconds())
+	}
+	if utf8.RuneCountInString(label) > maxLoopLabelLength {
+		return fmt.Errorf("%w: loop label exceeds %d characters", ErrInvalidArgument, maxLoopLabelLength)
+	}
+	if repeatCount < 0 || repeatCount > MaxLoopRepeatCount {
+		return fmt.Errorf("%w: repeat count must be between 0 and %d", ErrInvalidArgument, MaxLoopRepeatCount)
+	}
+	if speed < MinLoopSpeed || speed > MaxLoopSpeed {
+		return fmt.Errorf("%w: playback speed must be between %g and %g", ErrInvalidArgument, MinLoopSpeed, MaxLoopSpeed)
+	}
+
+	l.Start, l.End = start, end
+	l.Label = label
+	l.RepeatCount = repeatCount
+	l.PlaybackSpeed = speed
+	l.UpdatedAt = time.Now()
+	return nil
+}
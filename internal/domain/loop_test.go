@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoopIDFromString(t *testing.T) {
+	id := NewLoopID()
+	parsed, err := LoopIDFromString(id.String())
+	require.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	_, err = LoopIDFromString("not-a-uuid")
+	assert.Error(t, err)
+}
+
+func TestNewLoop(t *testing.T) {
+	userID, trackID := NewUserID(), NewTrackID()
+
+	loop, err := NewLoop(userID, trackID, 1500*time.Millisecond, 4*time.Second, " ¿Dónde está? ", 5, 0, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, userID, loop.UserID)
+	assert.Equal(t, 1500*time.Millisecond, loop.Start)
+	assert.Equal(t, "¿Dónde está?", loop.Label)
+	assert.Equal(t, 1.0, loop.PlaybackSpeed, "zero speed means normal speed")
+
+	// The loop may end exactly at the end of the track
+	_, err = NewLoop(userID, trackID, 50*time.Second, time.Minute, "", 0, 0.75, time.Minute)
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		start, end time.Duration
+		label      string
+		repeat     int
+		speed      float64
+	}{
+		"negative start":   {start: -time.Second, end: time.Second},
+		"end before start": {start: 2 * time.Second, end: time.Second},
+		"empty region":     {start: time.Second, end: time.Second},
+		"past track end":   {start: 0, end: 61 * time.Second},
+		"long label":       {end: time.Second, label: strings.Repeat("a", 256)},
+		"negative repeat":  {end: time.Second, repeat: -1},
+		"too many repeats": {end: time.Second, repeat: MaxLoopRepeatCount + 1},
+		"too slow":         {end: time.Second, speed: 0.1},
+		"too fast":         {end: time.Second, speed: 4.5},
+		"negative speed":   {end: time.Second, speed: -1},
+	}
+	for name, tt := range tests {
+		_, err := NewLoop(userID, trackID, tt.start, tt.end, tt.label, tt.repeat, tt.speed, time.Minute)
+		assert.ErrorIs(t, err, ErrInvalidArgument, name)
+	}
+
+	// Without a known track duration only the region itself is checked
+	_, err = NewLoop(userID, trackID, 0, time.Hour, "", 0, 0, 0)
+	assert.NoError(t, err)
+}
+
+func TestLoop_Update(t *testing.T) {
+	loop, err := NewLoop(NewUserID(), NewTrackID(), 0, time.Second, "A", 1, 1, time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, loop.Update(2*time.Second, 3*time.Second, "B", 0, 0.5, time.Minute))
+	assert.Equal(t, 2*time.Second, loop.Start)
+	assert.Equal(t, "B", loop.Label)
+	assert.Equal(t, 0.5, loop.PlaybackSpeed)
+
+	// A failed update leaves the loop unchanged
+	assert.ErrorIs(t, loop.Update(0, 2*time.Minute, "C", 0, 1, time.Minute), ErrInvalidArgument)
+	assert.Equal(t, "B", loop.Label)
+	assert.Equal(t, 3*time.Second, loop.End)
+}
@@ -13,6 +13,7 @@ type PlaybackProgress struct {
 	TrackID        TrackID
 	Progress       time.Duration // Current listening position
 	LastListenedAt time.Time     // When the progress was last updated
+	Chapter        *TrackChapter // Chapter containing Progress; resolved on read, not stored
 }
 
 // NewOrUpdatePlaybackProgress creates or updates a playback progress record.
@@ -28,4 +29,15 @@ func NewOrUpdatePlaybackProgress(userID UserID, trackID TrackID, progress time.D
 		Progress:       progress,
 		LastListenedAt: time.Now(),
 	}, nil
-}
\ No newline at end of file
+}
+
+// CompletionThreshold is the fraction of a track a learner must reach for it to count as completed.
+const CompletionThreshold = 0.95
+
+// CompletesTrack reports whether this progress counts as finishing a track of the given duration.
+func (p *PlaybackProgress) CompletesTrack(duration time.Duration) bool {
+	if duration <= 0 {
+		return false
+	}
+	return float64(p.Progress) >= float64(duration)*CompletionThreshold
+}
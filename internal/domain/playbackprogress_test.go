@@ -45,3 +45,10 @@ func TestNewOrUpdatePlaybackProgress(t *testing.T) {
 		})
 	}
 }
+
+func TestPlaybackProgress_CompletesTrack(t *testing.T) {
+	progress := &PlaybackProgress{Progress: 95 * time.Second}
+	assert.True(t, progress.CompletesTrack(100*time.Second))
+	assert.False(t, progress.CompletesTrack(101*time.Second))
+	assert.False(t, progress.CompletesTrack(0), "Tracks without a known duration never complete")
+}
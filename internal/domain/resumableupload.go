@@ -0,0 +1,110 @@
+// internal/domain/resumableupload.go
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResumableUploadID is the unique identifier for a ResumableUpload.
+type ResumableUploadID uuid.UUID
+
+func NewResumableUploadID() ResumableUploadID {
+	return ResumableUploadID(uuid.New())
+}
+
+func ResumableUploadIDFromString(s string) (ResumableUploadID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return ResumableUploadID{}, fmt.Errorf("invalid ResumableUploadID format: %w", err)
+	}
+	return ResumableUploadID(id), nil
+}
+
+func (rid ResumableUploadID) String() string {
+	return uuid.UUID(rid).String()
+}
+
+// ResumableUploadTrack holds the track details declared when a resumable upload is created.
+// They are used to create the track once all bytes have been received.
+type ResumableUploadTrack struct {
+	Title         string   `json:"title"`
+	Description   string   `json:"description,omitempty"`
+	LanguageCode  string   `json:"languageCode"`
+	Level         string   `json:"level,omitempty"`
+	DurationMs    int64    `json:"durationMs"`
+	IsPublic      bool     `json:"isPublic"`
+	Tags          []string `json:"tags,omitempty"`
+	CoverImageURL *string  `json:"coverImageUrl,omitempty"`
+}
+
+// ResumableUpload is an audio file uploaded in chunks (tus protocol). The bytes received so far
+// are kept in a staging area until Offset reaches Length.
+type ResumableUpload struct {
+	ID          ResumableUploadID
+	UserID      UserID
+	ObjectKey   string // Storage key of the assembled file
+	Filename    string
+	ContentType string
+	Length      int64  // Total size in bytes, declared by the client
+	Offset      int64  // Bytes received so far
+	Metadata    string // Upload-Metadata header as sent by the client
+	Track       ResumableUploadTrack
+	TrackID     *TrackID // Set once the upload has been completed
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewResumableUpload creates an empty upload of length bytes that expires after ttl without progress.
+func NewResumableUpload(userID UserID, objectKey, filename, contentType string, length int64, metadata string, track ResumableUploadTrack, ttl time.Duration) (*ResumableUpload, error) {
+	if length < 0 {
+		return nil, fmt.Errorf("%w: upload length cannot be negative", ErrInvalidArgument)
+	}
+	if filename == "" {
+		return nil, fmt.Errorf("%w: filename cannot be empty", ErrInvalidArgument)
+	}
+	now := time.Now()
+	return &ResumableUpload{
+		ID:          NewResumableUploadID(),
+		UserID:      userID,
+		ObjectKey:   objectKey,
+		Filename:    filename,
+		ContentType: contentType,
+		Length:      length,
+		Metadata:    metadata,
+		Track:       track,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// IsComplete reports whether all bytes have been received.
+func (u *ResumableUpload) IsComplete() bool {
+	return u.Offset == u.Length
+}
+
+// IsExpired reports whether the upload may no longer be resumed.
+func (u *ResumableUpload) IsExpired(now time.Time) bool {
+	return !now.Before(u.ExpiresAt)
+}
+
+// Advance records n more received bytes and extends the expiry.
+func (u *ResumableUpload) Advance(n int64, now time.Time, ttl time.Duration) error {
+	if n < 0 || u.Offset+n > u.Length {
+		return fmt.Errorf("%w: upload would exceed its length of %d bytes", ErrInvalidArgument, u.Length)
+	}
+	u.Offset += n
+	u.ExpiresAt = now.Add(ttl)
+	u.UpdatedAt = now
+	return nil
+}
+
+// Complete records the track created from the upload.
+func (u *ResumableUpload) Complete(trackID TrackID, now time.Time) {
+	u.TrackID = &trackID
+	u.UpdatedAt = now
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResumableUpload(t *testing.T) {
+	userID := NewUserID()
+	upload, err := NewResumableUpload(userID, "user-uploads/u/a.mp3", "lesson.mp3", "audio/mpeg", 100, "filename bGVzc29uLm1wMw==", ResumableUploadTrack{Title: "Lesson"}, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, userID, upload.UserID)
+	assert.Zero(t, upload.Offset)
+	assert.False(t, upload.IsComplete())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), upload.ExpiresAt, time.Minute)
+
+	_, err = NewResumableUpload(userID, "user-uploads/u/a.mp3", "lesson.mp3", "audio/mpeg", -1, "", ResumableUploadTrack{}, time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = NewResumableUpload(userID, "user-uploads/u/a.mp3", "", "audio/mpeg", 100, "", ResumableUploadTrack{}, time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	empty, err := NewResumableUpload(userID, "user-uploads/u/a.mp3", "silence.mp3", "audio/mpeg", 0, "", ResumableUploadTrack{}, time.Hour)
+	require.NoError(t, err)
+	assert.True(t, empty.IsComplete())
+}
+
+func TestResumableUpload_Advance(t *testing.T) {
+	upload, err := NewResumableUpload(NewUserID(), "user-uploads/u/a.mp3", "lesson.mp3", "audio/mpeg", 100, "", ResumableUploadTrack{}, time.Hour)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, upload.Advance(60, now, time.Hour))
+	assert.Equal(t, int64(60), upload.Offset)
+	assert.Equal(t, now.Add(time.Hour), upload.ExpiresAt)
+	assert.False(t, upload.IsExpired(now))
+	assert.True(t, upload.IsExpired(now.Add(time.Hour)))
+
+	assert.ErrorIs(t, upload.Advance(41, now, time.Hour), ErrInvalidArgument)
+	assert.Equal(t, int64(60), upload.Offset)
+
+	require.NoError(t, upload.Advance(40, now, time.Hour))
+	assert.True(t, upload.IsComplete())
+
+	trackID := NewTrackID()
+	upload.Complete(trackID, now)
+	require.NotNil(t, upload.TrackID)
+	assert.Equal(t, trackID, *upload.TrackID)
+}
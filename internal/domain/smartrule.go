@@ -0,0 +1,86 @@
+// internal/domain/smartrule.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SmartRule is the stored track query behind a SMART collection. Its tracks are not stored;
+// they are evaluated for the viewing user whenever the collection is read. Zero values match anything.
+type SmartRule struct {
+	LanguageCode  string        // e.g., "es-ES"
+	Level         AudioLevel    // e.g., "B1"
+	Tags          []string      // Tracks must carry all of these tags
+	MinDuration   time.Duration // Inclusive lower bound; 0 = none
+	MaxDuration   time.Duration // Inclusive upper bound; 0 = none
+	UploaderID    *UserID       // Only tracks uploaded by this user
+	ListenState   ListenState   // Whether the viewing user has finished the track
+	SortBy        string        // "createdAt" (default), "title", "durationMs" or "level"
+	SortDirection string        // "asc" or "desc" (default)
+}
+
+// smartRuleSorts are the SortBy values a SmartRule accepts.
+var smartRuleSorts = map[string]bool{"": true, "createdAt": true, "title": true, "durationMs": true, "level": true}
+
+// Validate checks that the rule can be evaluated.
+func (r SmartRule) Validate() error {
+	if !r.Level.IsValid() {
+		return fmt.Errorf("%w: invalid level '%s'", ErrInvalidArgument, r.Level)
+	}
+	if !r.ListenState.IsValid() {
+		return fmt.Errorf("%w: invalid listen state '%s'", ErrInvalidArgument, r.ListenState)
+	}
+	if r.MinDuration < 0 || r.MaxDuration < 0 {
+		return fmt.Errorf("%w: duration bounds cannot be negative", ErrInvalidArgument)
+	}
+	if r.MaxDuration > 0 && r.MaxDuration < r.MinDuration {
+		return fmt.Errorf("%w: maximum duration is less than minimum duration", ErrInvalidArgument)
+	}
+	for _, tag := range r.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("%w: tags cannot be empty", ErrInvalidArgument)
+		}
+	}
+	if !smartRuleSorts[r.SortBy] {
+		return fmt.Errorf("%w: invalid sort field '%s'", ErrInvalidArgument, r.SortBy)
+	}
+	switch strings.ToLower(r.SortDirection) {
+	case "", "asc", "desc":
+	default:
+		return fmt.Errorf("%w: invalid sort direction '%s'", ErrInvalidArgument, r.SortDirection)
+	}
+	return nil
+}
+
+// NewSmartCollection creates a new SMART collection whose tracks are selected by rule.
+func NewSmartCollection(title, description string, ownerID UserID, rule SmartRule) (*AudioCollection, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	collection, err := NewAudioCollection(title, description, ownerID, TypeSmart)
+	if err != nil {
+		return nil, err
+	}
+	collection.SmartRule = &rule
+	return collection, nil
+}
+
+// SetSmartRule replaces the rule of a SMART collection.
+func (c *AudioCollection) SetSmartRule(rule SmartRule) error {
+	if c.Type != TypeSmart {
+		return fmt.Errorf("%w: only smart collections have a rule", ErrInvalidArgument)
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	c.SmartRule = &rule
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsSmart reports whether the collection's tracks come from a rule rather than a stored list.
+func (c *AudioCollection) IsSmart() bool {
+	return c.Type == TypeSmart
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSmartRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    SmartRule
+		wantErr bool
+	}{
+		{"Empty rule matches everything", SmartRule{}, false},
+		{"Full rule", SmartRule{LanguageCode: "es-ES", Level: LevelB1, Tags: []string{"news"}, MaxDuration: 5 * time.Minute, ListenState: ListenStateUnlistened, SortBy: "title", SortDirection: "ASC"}, false},
+		{"Min equals max", SmartRule{MinDuration: time.Minute, MaxDuration: time.Minute}, false},
+		{"Min without max", SmartRule{MinDuration: time.Minute}, false},
+		{"Invalid level", SmartRule{Level: "Z9"}, true},
+		{"Invalid listen state", SmartRule{ListenState: "HALF"}, true},
+		{"Negative duration", SmartRule{MinDuration: -time.Second}, true},
+		{"Max below min", SmartRule{MinDuration: 2 * time.Minute, MaxDuration: time.Minute}, true},
+		{"Blank tag", SmartRule{Tags: []string{"news", " "}}, true},
+		{"Invalid sort field", SmartRule{SortBy: "uploader"}, true},
+		{"Invalid sort direction", SmartRule{SortDirection: "up"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidArgument)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewSmartCollection(t *testing.T) {
+	ownerID := NewUserID()
+	rule := SmartRule{Level: LevelB1, ListenState: ListenStateUnlistened}
+
+	collection, err := NewSmartCollection("B1 news", "", ownerID, rule)
+	require.NoError(t, err)
+	assert.Equal(t, TypeSmart, collection.Type)
+	assert.True(t, collection.IsSmart())
+	assert.Equal(t, &rule, collection.SmartRule)
+	assert.Empty(t, collection.TrackIDs)
+
+	_, err = NewSmartCollection("B1 news", "", ownerID, SmartRule{Level: "Z9"})
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = NewSmartCollection("", "", ownerID, rule)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestAudioCollection_SetSmartRule(t *testing.T) {
+	ownerID := NewUserID()
+	collection, err := NewSmartCollection("Smart", "", ownerID, SmartRule{})
+	require.NoError(t, err)
+	before := collection.UpdatedAt
+
+	err = collection.SetSmartRule(SmartRule{LanguageCode: "fr-FR"})
+	require.NoError(t, err)
+	assert.Equal(t, "fr-FR", collection.SmartRule.LanguageCode)
+	assert.False(t, collection.UpdatedAt.Before(before))
+
+	err = collection.SetSmartRule(SmartRule{ListenState: "HALF"})
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	assert.Equal(t, "fr-FR", collection.SmartRule.LanguageCode) // Unchanged
+
+	playlist, _ := NewAudioCollection("Playlist", "", ownerID, TypePlaylist)
+	assert.False(t, playlist.IsSmart())
+	err = playlist.SetSmartRule(SmartRule{})
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	assert.Nil(t, playlist.SmartRule)
+}
@@ -0,0 +1,98 @@
+// internal/domain/trackchapter.go
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxTrackChapters limits the number of chapters of one track.
+const MaxTrackChapters = 500
+
+const (
+	maxChapterTitleLength       = 255
+	maxChapterDescriptionLength = 2000
+)
+
+// TrackChapter is a named segment [Start, End) of an audio track, set by its uploader.
+type TrackChapter struct {
+	Title       string
+	Description string // Optional
+	Start       time.Duration
+	End         time.Duration
+}
+
+// NewTrackChapters validates and normalises the chapters of a track of the given duration
+// (0 if unknown). Chapters are sorted by start time; a zero End is filled in with the start
+// of the next chapter, or the track duration for the last one. Ends past the duration are
+// clamped to it. Chapters may leave gaps but must not overlap.
+func NewTrackChapters(chapters []TrackChapter, trackDuration time.Duration) ([]TrackChapter, error) {
+	if len(chapters) > MaxTrackChapters {
+		return nil, fmt.Errorf("%w: a track can have at most %d chapters", ErrInvalidArgument, MaxTrackChapters)
+	}
+	result := make([]TrackChapter, len(chapters))
+	for i, c := range chapters {
+		c.Title = strings.TrimSpace(c.Title)
+		c.Description = strings.TrimSpace(c.Description)
+		if c.Title == "" {
+			return nil, fmt.Errorf("%w: chapter title cannot be empty", ErrInvalidArgument)
+		}
+		if utf8.RuneCountInString(c.Title) > maxChapterTitleLength {
+			return nil, fmt.Errorf("%w: chapter title exceeds %d characters", ErrInvalidArgument, maxChapterTitleLength)
+		}
+		if utf8.RuneCountInString(c.Description) > maxChapterDescriptionLength {
+			return nil, fmt.Errorf("%w: chapter description exceeds %d characters", ErrInvalidArgument, maxChapterDescriptionLength)
+		}
+		if c.Start < 0 || c.End < 0 {
+			return nil, fmt.Errorf("%w: chapter times cannot be negative", ErrInvalidArgument)
+		}
+		// Chapters are stored with millisecond precision.
+		c.Start = c.Start.Truncate(time.Millisecond)
+		c.End = c.End.Truncate(time.Millisecond)
+		result[i] = c
+	}
+	slices.SortStableFunc(result, func(a, b TrackChapter) int { return cmp.Compare(a.Start, b.Start) })
+
+	for i := range result {
+		c := &result[i]
+		if trackDuration > 0 && c.Start >= trackDuration {
+			return nil, fmt.Errorf("%w: chapter '%s' starts after the end of the track", ErrInvalidArgument, c.Title)
+		}
+		if c.End == 0 {
+			if i+1 < len(result) {
+				c.End = result[i+1].Start
+			} else if trackDuration > 0 {
+				c.End = trackDuration
+			} else {
+				return nil, fmt.Errorf("%w: the end of chapter '%s' is required when the track duration is unknown", ErrInvalidArgument, c.Title)
+			}
+		}
+		if trackDuration > 0 && c.End > trackDuration {
+			c.End = trackDuration
+		}
+		if c.End <= c.Start {
+			return nil, fmt.Errorf("%w: chapter '%s' must end after it starts", ErrInvalidArgument, c.Title)
+		}
+		if i+1 < len(result) && c.End > result[i+1].Start {
+			return nil, fmt.Errorf("%w: chapters '%s' and '%s' overlap", ErrInvalidArgument, c.Title, result[i+1].Title)
+		}
+	}
+	return result, nil
+}
+
+// ChapterAt returns the chapter containing position, or nil if it falls in no chapter.
+// chapters must be sorted and non-overlapping, as returned by NewTrackChapters.
+func ChapterAt(chapters []TrackChapter, position time.Duration) *TrackChapter {
+	i, _ := slices.BinarySearchFunc(chapters, position, func(c TrackChapter, p time.Duration) int {
+		return cmp.Compare(c.Start, p+1) // Index of the first chapter starting after position
+	})
+	if i == 0 || position >= chapters[i-1].End {
+		return nil
+	}
+	c := chapters[i-1]
+	return &c
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTrackChapters(t *testing.T) {
+	chapters, err := NewTrackChapters([]TrackChapter{
+		{Title: " Review ", Start: 4 * time.Minute},
+		{Title: "Intro", Start: 0, End: 30 * time.Second},
+		{Title: "Dialogue", Description: "At the market", Start: time.Minute},
+	}, 5*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []TrackChapter{
+		{Title: "Intro", Start: 0, End: 30 * time.Second},
+		{Title: "Dialogue", Description: "At the market", Start: time.Minute, End: 4 * time.Minute}, // Gap before it is kept
+		{Title: "Review", Start: 4 * time.Minute, End: 5 * time.Minute},
+	}, chapters)
+
+	// Ends past the track are clamped
+	chapters, err = NewTrackChapters([]TrackChapter{{Title: "All", End: time.Hour}}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, chapters[0].End)
+
+	empty, err := NewTrackChapters(nil, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	invalid := map[string][]TrackChapter{
+		"no title":         {{Start: 0, End: time.Second}},
+		"long title":       {{Title: strings.Repeat("a", 256), End: time.Second}},
+		"negative":         {{Title: "A", Start: -time.Second, End: time.Second}},
+		"after track end":  {{Title: "A", Start: 2 * time.Minute}},
+		"end before start": {{Title: "A", Start: 10 * time.Second, End: 5 * time.Second}},
+		"overlap":          {{Title: "A", Start: 0, End: 20 * time.Second}, {Title: "B", Start: 10 * time.Second}},
+		"same start":       {{Title: "A", Start: 0}, {Title: "B", Start: 0}},
+	}
+	for name, input := range invalid {
+		_, err := NewTrackChapters(input, time.Minute)
+		assert.ErrorIs(t, err, ErrInvalidArgument, name)
+	}
+
+	// Without a track duration the last chapter needs an explicit end
+	_, err = NewTrackChapters([]TrackChapter{{Title: "A"}}, 0)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestChapterAt(t *testing.T) {
+	chapters := []TrackChapter{
+		{Title: "Intro", Start: 0, End: 30 * time.Second},
+		{Title: "Dialogue", Start: time.Minute, End: 2 * time.Minute},
+	}
+	assert.Equal(t, "Intro", ChapterAt(chapters, 0).Title)
+	assert.Equal(t, "Intro", ChapterAt(chapters, 29*time.Second).Title)
+	assert.Nil(t, ChapterAt(chapters, 30*time.Second), "in the gap")
+	assert.Equal(t, "Dialogue", ChapterAt(chapters, time.Minute).Title)
+	assert.Nil(t, ChapterAt(chapters, 2*time.Minute))
+	assert.Nil(t, ChapterAt(nil, time.Second))
+}
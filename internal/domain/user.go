@@ -37,6 +37,14 @@ const (
 	// Add other providers like Facebook, Apple etc. here
 )
 
+// UserRole controls access to administrative functionality.
+type UserRole string
+
+const (
+	RoleUser  UserRole = "user"
+	RoleAdmin UserRole = "admin"
+)
+
 // User represents a user in the system.
 type User struct {
 	ID              UserID
@@ -45,6 +53,7 @@ type User struct {
 	HashedPassword  *string // Pointer allows null for external auth users
 	GoogleID        *string // Unique ID from Google (subject claim)
 	AuthProvider    AuthProvider
+	Role            UserRole
 	ProfileImageURL *string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
@@ -68,6 +77,7 @@ func NewLocalUser(emailAddr, name, hashedPassword string) (*User, error) {
 		HashedPassword: &hashedPassword, // Store the already hashed password
 		GoogleID:       nil,
 		AuthProvider:   AuthProviderLocal,
+		Role:           RoleUser,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}, nil
@@ -91,12 +101,50 @@ func NewGoogleUser(emailAddr, name, googleID string, profileImageURL *string) (*
 		HashedPassword:  nil, // No password for Google users initially
 		GoogleID:        &googleID,
 		AuthProvider:    AuthProviderGoogle,
+		Role:            RoleUser,
 		ProfileImageURL: profileImageURL,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}, nil
 }
 
+// IsValid checks if the role is one of the defined roles.
+func (r UserRole) IsValid() bool {
+	switch r {
+	case RoleUser, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// IsAdmin reports whether the user has the admin role.
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// SetRole changes the user's role.
+func (u *User) SetRole(role UserRole) error {
+	if !role.IsValid() {
+		return fmt.Errorf("%w: invalid user role '%s'", ErrInvalidArgument, role)
+	}
+	u.Role = role
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetPasswordHash replaces the password of a local user with an already hashed password.
+func (u *User) SetPasswordHash(hashedPassword string) error {
+	if u.AuthProvider != AuthProviderLocal {
+		return fmt.Errorf("%w: cannot set a password for a %s user", ErrInvalidArgument, u.AuthProvider)
+	}
+	if hashedPassword == "" {
+		return fmt.Errorf("%w: password hash cannot be empty", ErrInvalidArgument)
+	}
+	u.HashedPassword = &hashedPassword
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
 // ValidatePassword checks if the provided plain password matches the user's hashed password.
 // Returns true if the password matches or if the user is not a local user (no password set).
 // Returns false if the password does not match or an error occurred during comparison.
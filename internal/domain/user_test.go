@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -46,6 +47,7 @@ func TestNewLocalUser(t *testing.T) {
 				assert.Equal(t, tt.hashedPassword, *got.HashedPassword)
 				assert.Nil(t, got.GoogleID)
 				assert.Equal(t, AuthProviderLocal, got.AuthProvider)
+				assert.Equal(t, RoleUser, got.Role)
 				assert.Nil(t, got.ProfileImageURL)
 				assert.WithinDuration(t, start, got.CreatedAt, end.Sub(start)+time.Millisecond)
 				assert.WithinDuration(t, start, got.UpdatedAt, end.Sub(start)+time.Millisecond)
@@ -93,6 +95,7 @@ func TestNewGoogleUser(t *testing.T) {
 				assert.NotNil(t, got.GoogleID)
 				assert.Equal(t, tt.googleID, *got.GoogleID)
 				assert.Equal(t, AuthProviderGoogle, got.AuthProvider)
+				assert.Equal(t, RoleUser, got.Role)
 				if tt.profileURL != nil {
 					assert.NotNil(t, got.ProfileImageURL)
 					assert.Equal(t, *tt.profileURL, *got.ProfileImageURL)
@@ -164,4 +167,29 @@ func TestUser_ValidatePassword(t *testing.T) {
 	}
 }
 
+func TestUser_SetRole(t *testing.T) {
+	user, err := NewLocalUser("role@example.com", "Role Test", "hash")
+	require.NoError(t, err)
+
+	require.NoError(t, user.SetRole(RoleAdmin))
+	assert.True(t, user.IsAdmin())
+
+	err = user.SetRole(UserRole("owner"))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	assert.Equal(t, RoleAdmin, user.Role)
+}
+
+func TestUser_SetPasswordHash(t *testing.T) {
+	localUser, err := NewLocalUser("reset@example.com", "Reset Test", "old-hash")
+	require.NoError(t, err)
+	require.NoError(t, localUser.SetPasswordHash("new-hash"))
+	assert.Equal(t, "new-hash", *localUser.HashedPassword)
+	assert.ErrorIs(t, localUser.SetPasswordHash(""), ErrInvalidArgument)
+
+	googleUser, err := NewGoogleUser("google@example.com", "Google Test", "google-123", nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, googleUser.SetPasswordHash("hash"), ErrInvalidArgument)
+	assert.Nil(t, googleUser.HashedPassword)
+}
+
 // Add tests for UpdateProfile, LinkGoogleID if needed
@@ -55,12 +55,13 @@ type CollectionType string // e.g., "COURSE", "PLAYLIST"
 const (
 	TypeCourse   CollectionType = "COURSE"
 	TypePlaylist CollectionType = "PLAYLIST"
-	TypeUnknown  CollectionType = "" // Or "UNKNOWN"
+	TypeSmart    CollectionType = "SMART" // Tracks come from a SmartRule instead of a stored list
+	TypeUnknown  CollectionType = ""      // Or "UNKNOWN"
 )
 
 func (t CollectionType) IsValid() bool {
 	switch t {
-	case TypeCourse, TypePlaylist, TypeUnknown:
+	case TypeCourse, TypePlaylist, TypeSmart, TypeUnknown:
 		return true
 	default:
 		return false
@@ -68,6 +69,68 @@ func (t CollectionType) IsValid() bool {
 }
 func (t CollectionType) String() string { return string(t) }
 
+// CollectionVisibility controls who can read an audio collection. Immutable.
+type CollectionVisibility string
+
+const (
+	VisibilityPrivate  CollectionVisibility = "PRIVATE"  // Only the owner
+	VisibilityUnlisted CollectionVisibility = "UNLISTED" // Anyone with the link; not listed when browsing
+	VisibilityPublic   CollectionVisibility = "PUBLIC"   // Anyone; listed when browsing
+)
+
+func (v CollectionVisibility) IsValid() bool {
+	switch v {
+	case VisibilityPrivate, VisibilityUnlisted, VisibilityPublic:
+		return true
+	default:
+		return false
+	}
+}
+func (v CollectionVisibility) String() string { return string(v) }
+
+// CollectionRole is a user's role in an audio collection. Immutable.
+type CollectionRole string
+
+const (
+	CollectionRoleOwner  CollectionRole = "OWNER"  // Full control, including sharing, transfer and deletion
+	CollectionRoleEditor CollectionRole = "EDITOR" // May change metadata and tracks
+	CollectionRoleViewer CollectionRole = "VIEWER" // May read the collection even if it is private
+)
+
+func (r CollectionRole) IsValid() bool {
+	switch r {
+	case CollectionRoleOwner, CollectionRoleEditor, CollectionRoleViewer:
+		return true
+	default:
+		return false
+	}
+}
+func (r CollectionRole) String() string { return string(r) }
+
+// CanEdit reports whether the role allows changing the collection's metadata and tracks.
+func (r CollectionRole) CanEdit() bool {
+	return r == CollectionRoleOwner || r == CollectionRoleEditor
+}
+
+// ListenState selects tracks by whether a user has finished them (see CompletionThreshold). Immutable.
+type ListenState string
+
+const (
+	ListenStateAny        ListenState = ""
+	ListenStateListened   ListenState = "LISTENED"   // Finished by the user
+	ListenStateUnlistened ListenState = "UNLISTENED" // Not finished, including never started
+)
+
+func (s ListenState) IsValid() bool {
+	switch s {
+	case ListenStateAny, ListenStateListened, ListenStateUnlistened:
+		return true
+	default:
+		return false
+	}
+}
+func (s ListenState) String() string { return string(s) }
+
 // --- Email Value Object (Example with Validation) ---
 
 // Email represents a validated email address. Immutable.
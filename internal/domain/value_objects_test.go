@@ -75,6 +75,25 @@ func TestCollectionType_IsValid(t *testing.T) {
 	}
 }
 
+func TestCollectionVisibility_IsValid(t *testing.T) {
+	for _, v := range []CollectionVisibility{VisibilityPrivate, VisibilityUnlisted, VisibilityPublic} {
+		assert.True(t, v.IsValid(), v.String())
+	}
+	assert.False(t, CollectionVisibility("").IsValid())
+	assert.False(t, CollectionVisibility("public").IsValid())
+}
+
+func TestCollectionRole(t *testing.T) {
+	for _, r := range []CollectionRole{CollectionRoleOwner, CollectionRoleEditor, CollectionRoleViewer} {
+		assert.True(t, r.IsValid(), r.String())
+	}
+	assert.False(t, CollectionRole("").IsValid())
+	assert.True(t, CollectionRoleOwner.CanEdit())
+	assert.True(t, CollectionRoleEditor.CanEdit())
+	assert.False(t, CollectionRoleViewer.CanEdit())
+	assert.False(t, CollectionRole("").CanEdit())
+}
+
 func TestNewEmail(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,247 @@
+// internal/domain/webhook.go
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookID is the unique identifier for a WebhookSubscription.
+type WebhookID uuid.UUID
+
+func NewWebhookID() WebhookID {
+	return WebhookID(uuid.New())
+}
+
+func WebhookIDFromString(s string) (WebhookID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return WebhookID{}, fmt.Errorf("invalid WebhookID format: %w", err)
+	}
+	return WebhookID(id), nil
+}
+
+func (wid WebhookID) String() string {
+	return uuid.UUID(wid).String()
+}
+
+// WebhookDeliveryID is the unique identifier for a WebhookDelivery.
+type WebhookDeliveryID uuid.UUID
+
+func NewWebhookDeliveryID() WebhookDeliveryID {
+	return WebhookDeliveryID(uuid.New())
+}
+
+func WebhookDeliveryIDFromString(s string) (WebhookDeliveryID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return WebhookDeliveryID{}, fmt.Errorf("invalid WebhookDeliveryID format: %w", err)
+	}
+	return WebhookDeliveryID(id), nil
+}
+
+func (did WebhookDeliveryID) String() string {
+	return uuid.UUID(did).String()
+}
+
+// WebhookSubscription registers an external endpoint for a set of event types.
+// Deliveries are signed with Secret using HMAC-SHA256.
+type WebhookSubscription struct {
+	ID         WebhookID
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	Active     bool
+	CreatedBy  *UserID // Nil if the creating admin was deleted
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewWebhookSubscription creates an active subscription.
+func NewWebhookSubscription(rawURL string, eventTypes []EventType, secret string, createdBy UserID) (*WebhookSubscription, error) {
+	if err := ValidateWebhookURL(rawURL); err != nil {
+		return nil, err
+	}
+	types, err := normalizeEventTypes(eventTypes)
+	if err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("%w: webhook secret cannot be empty", ErrInvalidArgument)
+	}
+	now := time.Now()
+	return &WebhookSubscription{
+		ID:         NewWebhookID(),
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: types,
+		Active:     true,
+		CreatedBy:  &createdBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// Update changes the endpoint, event types and active flag. Nil arguments keep the current value.
+func (s *WebhookSubscription) Update(rawURL *string, eventTypes []EventType, active *bool) error {
+	if rawURL != nil {
+		if err := ValidateWebhookURL(*rawURL); err != nil {
+			return err
+		}
+		s.URL = *rawURL
+	}
+	if eventTypes != nil {
+		types, err := normalizeEventTypes(eventTypes)
+		if err != nil {
+			return err
+		}
+		s.EventTypes = types
+	}
+	if active != nil {
+		s.Active = *active
+	}
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Subscribes reports whether the subscription receives events of the given type.
+func (s *WebhookSubscription) Subscribes(eventType EventType) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWebhookURL checks that rawURL is an absolute http(s) URL.
+func ValidateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("%w: webhook URL must be an absolute http or https URL", ErrInvalidArgument)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: webhook URL must not contain credentials", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// normalizeEventTypes validates event types and removes duplicates, keeping order.
+func normalizeEventTypes(eventTypes []EventType) ([]EventType, error) {
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("%w: at least one event type is required", ErrInvalidArgument)
+	}
+	seen := make(map[EventType]bool, len(eventTypes))
+	types := make([]EventType, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		if !t.IsValid() {
+			return nil, fmt.Errorf("%w: unknown event type '%s'", ErrInvalidArgument, t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
+
+// DeliveryStatus is the state of a webhook delivery.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"   // Waiting for its first or next attempt
+	DeliverySucceeded DeliveryStatus = "succeeded" // Receiver answered with a 2xx status
+	DeliveryDead      DeliveryStatus = "dead"      // Gave up after the maximum number of attempts
+)
+
+// IsValid checks if the status is one of the defined statuses.
+func (s DeliveryStatus) IsValid() bool {
+	switch s {
+	case DeliveryPending, DeliverySucceeded, DeliveryDead:
+		return true
+	}
+	return false
+}
+
+// RetryPolicy controls exponential backoff and dead-lettering of failed deliveries.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Backoff returns the delay before the next attempt after the given number of
+// failed attempts: InitialBackoff doubled per further failure, capped at MaxBackoff.
+func (p RetryPolicy) Backoff(failedAttempts int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < failedAttempts; i++ {
+		delay *= 2
+		if delay >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+// WebhookDelivery tracks sending one event to one subscription, including retries.
+type WebhookDelivery struct {
+	ID             WebhookDeliveryID
+	SubscriptionID WebhookID
+	EventID        EventID
+	EventType      EventType
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int   // HTTP status of the last attempt, if a response was received
+	LastError      string // Error of the last failed attempt
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// MarkSucceeded records a successful attempt.
+func (d *WebhookDelivery) MarkSucceeded(statusCode int, now time.Time) {
+	d.Attempts++
+	d.Status = DeliverySucceeded
+	d.LastStatusCode = &statusCode
+	d.LastError = ""
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// MarkFailed records a failed attempt and schedules a retry, or dead-letters the
+// delivery once the policy's attempts are used up. statusCode is 0 if no response was received.
+func (d *WebhookDelivery) MarkFailed(statusCode int, errMsg string, now time.Time, policy RetryPolicy) {
+	d.Attempts++
+	d.LastStatusCode = nil
+	if statusCode != 0 {
+		d.LastStatusCode = &statusCode
+	}
+	d.LastError = errMsg
+	d.UpdatedAt = now
+	if d.Attempts >= policy.MaxAttempts {
+		d.Status = DeliveryDead
+		return
+	}
+	d.Status = DeliveryPending
+	d.NextAttemptAt = now.Add(policy.Backoff(d.Attempts))
+}
+
+// Requeue schedules a finished (succeeded or dead-lettered) delivery to be sent again
+// with a fresh set of attempts.
+func (d *WebhookDelivery) Requeue(now time.Time) error {
+	if d.Status == DeliveryPending {
+		return fmt.Errorf("%w: delivery %s is already pending", ErrConflict, d.ID)
+	}
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.UpdatedAt = now
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
 	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
 )
 
@@ -310,20 +311,29 @@ func (_c *MockAudioCollectionRepository_ListByOwner_Call) RunAndReturn(run func(
 }
 
 // ManageTracks provides a mock function for the type MockAudioCollectionRepository
-func (_mock *MockAudioCollectionRepository) ManageTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID) error {
-	ret := _mock.Called(ctx, collectionID, orderedTrackIDs)
+func (_mock *MockAudioCollectionRepository) ManageTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, orderedTrackIDs, expectedVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ManageTracks")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, []domain.TrackID) error); ok {
-		r0 = returnFunc(ctx, collectionID, orderedTrackIDs)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, []domain.TrackID, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, orderedTrackIDs, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, []domain.TrackID, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, orderedTrackIDs, expectedVersion)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, []domain.TrackID, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, orderedTrackIDs, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockAudioCollectionRepository_ManageTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ManageTracks'
@@ -335,23 +345,24 @@ type MockAudioCollectionRepository_ManageTracks_Call struct {
 //   - ctx
 //   - collectionID
 //   - orderedTrackIDs
-func (_e *MockAudioCollectionRepository_Expecter) ManageTracks(ctx interface{}, collectionID interface{}, orderedTrackIDs interface{}) *MockAudioCollectionRepository_ManageTracks_Call {
-	return &MockAudioCollectionRepository_ManageTracks_Call{Call: _e.mock.On("ManageTracks", ctx, collectionID, orderedTrackIDs)}
+//   - expectedVersion
+func (_e *MockAudioCollectionRepository_Expecter) ManageTracks(ctx interface{}, collectionID interface{}, orderedTrackIDs interface{}, expectedVersion interface{}) *MockAudioCollectionRepository_ManageTracks_Call {
+	return &MockAudioCollectionRepository_ManageTracks_Call{Call: _e.mock.On("ManageTracks", ctx, collectionID, orderedTrackIDs, expectedVersion)}
 }
 
-func (_c *MockAudioCollectionRepository_ManageTracks_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID)) *MockAudioCollectionRepository_ManageTracks_Call {
+func (_c *MockAudioCollectionRepository_ManageTracks_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID, expectedVersion int)) *MockAudioCollectionRepository_ManageTracks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].([]domain.TrackID))
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].([]domain.TrackID), args[3].(int))
 	})
 	return _c
 }
 
-func (_c *MockAudioCollectionRepository_ManageTracks_Call) Return(err error) *MockAudioCollectionRepository_ManageTracks_Call {
-	_c.Call.Return(err)
+func (_c *MockAudioCollectionRepository_ManageTracks_Call) Return(newVersion int, err error) *MockAudioCollectionRepository_ManageTracks_Call {
+	_c.Call.Return(newVersion, err)
 	return _c
 }
 
-func (_c *MockAudioCollectionRepository_ManageTracks_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID) error) *MockAudioCollectionRepository_ManageTracks_Call {
+func (_c *MockAudioCollectionRepository_ManageTracks_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID, expectedVersion int) (int, error)) *MockAudioCollectionRepository_ManageTracks_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -401,3 +412,476 @@ func (_c *MockAudioCollectionRepository_UpdateMetadata_Call) RunAndReturn(run fu
 	_c.Call.Return(run)
 	return _c
 }
+
+// ListPublic provides a mock function for the type MockAudioCollectionRepository
+func (_mock *MockAudioCollectionRepository) ListPublic(ctx context.Context, filters port.ListCollectionsFilters, page pagination.Page) ([]*domain.AudioCollection, int, error) {
+	ret := _mock.Called(ctx, filters, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPublic")
+	}
+
+	var r0 []*domain.AudioCollection
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.ListCollectionsFilters, pagination.Page) ([]*domain.AudioCollection, int, error)); ok {
+		return returnFunc(ctx, filters, page)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.ListCollectionsFilters, pagination.Page) []*domain.AudioCollection); ok {
+		r0 = returnFunc(ctx, filters, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AudioCollection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, port.ListCollectionsFilters, pagination.Page) int); ok {
+		r1 = returnFunc(ctx, filters, page)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, port.ListCollectionsFilters, pagination.Page) error); ok {
+		r2 = returnFunc(ctx, filters, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAudioCollectionRepository_ListPublic_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublic'
+type MockAudioCollectionRepository_ListPublic_Call struct {
+	*mock.Call
+}
+
+// ListPublic is a helper method to define mock.On call
+//   - ctx
+//   - filters
+//   - page
+func (_e *MockAudioCollectionRepository_Expecter) ListPublic(ctx interface{}, filters interface{}, page interface{}) *MockAudioCollectionRepository_ListPublic_Call {
+	return &MockAudioCollectionRepository_ListPublic_Call{Call: _e.mock.On("ListPublic", ctx, filters, page)}
+}
+
+func (_c *MockAudioCollectionRepository_ListPublic_Call) Run(run func(ctx context.Context, filters port.ListCollectionsFilters, page pagination.Page)) *MockAudioCollectionRepository_ListPublic_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(port.ListCollectionsFilters), args[2].(pagination.Page))
+	})
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_ListPublic_Call) Return(audioCollection []*domain.AudioCollection, int int, err error) *MockAudioCollectionRepository_ListPublic_Call {
+	_c.Call.Return(audioCollection, int, err)
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_ListPublic_Call) RunAndReturn(run func(ctx context.Context, filters port.ListCollectionsFilters, page pagination.Page) ([]*domain.AudioCollection, int, error)) *MockAudioCollectionRepository_ListPublic_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccessible provides a mock function for the type MockAudioCollectionRepository
+func (_mock *MockAudioCollectionRepository) ListAccessible(ctx context.Context, userID domain.UserID, sortBy string, sortDirection string, page pagination.Page) ([]port.UserCollection, int, error) {
+	ret := _mock.Called(ctx, userID, sortBy, sortDirection, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccessible")
+	}
+
+	var r0 []port.UserCollection
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, string, string, pagination.Page) ([]port.UserCollection, int, error)); ok {
+		return returnFunc(ctx, userID, sortBy, sortDirection, page)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, string, string, pagination.Page) []port.UserCollection); ok {
+		r0 = returnFunc(ctx, userID, sortBy, sortDirection, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]port.UserCollection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserID, string, string, pagination.Page) int); ok {
+		r1 = returnFunc(ctx, userID, sortBy, sortDirection, page)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.UserID, string, string, pagination.Page) error); ok {
+		r2 = returnFunc(ctx, userID, sortBy, sortDirection, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAudioCollectionRepository_ListAccessible_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccessible'
+type MockAudioCollectionRepository_ListAccessible_Call struct {
+	*mock.Call
+}
+
+// ListAccessible is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - sortBy
+//   - sortDirection
+//   - page
+func (_e *MockAudioCollectionRepository_Expecter) ListAccessible(ctx interface{}, userID interface{}, sortBy interface{}, sortDirection interface{}, page interface{}) *MockAudioCollectionRepository_ListAccessible_Call {
+	return &MockAudioCollectionRepository_ListAccessible_Call{Call: _e.mock.On("ListAccessible", ctx, userID, sortBy, sortDirection, page)}
+}
+
+func (_c *MockAudioCollectionRepository_ListAccessible_Call) Run(run func(ctx context.Context, userID domain.UserID, sortBy string, sortDirection string, page pagination.Page)) *MockAudioCollectionRepository_ListAccessible_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(string), args[3].(string), args[4].(pagination.Page))
+	})
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_ListAccessible_Call) Return(userCollection []port.UserCollection, int int, err error) *MockAudioCollectionRepository_ListAccessible_Call {
+	_c.Call.Return(userCollection, int, err)
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_ListAccessible_Call) RunAndReturn(run func(ctx context.Context, userID domain.UserID, sortBy string, sortDirection string, page pagination.Page) ([]port.UserCollection, int, error)) *MockAudioCollectionRepository_ListAccessible_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransferOwnership provides a mock function for the type MockAudioCollectionRepository
+func (_mock *MockAudioCollectionRepository) TransferOwnership(ctx context.Context, collectionID domain.CollectionID, newOwnerID domain.UserID, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, newOwnerID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferOwnership")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.UserID, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, newOwnerID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.UserID, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, newOwnerID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, domain.UserID, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, newOwnerID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioCollectionRepository_TransferOwnership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferOwnership'
+type MockAudioCollectionRepository_TransferOwnership_Call struct {
+	*mock.Call
+}
+
+// TransferOwnership is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+//   - newOwnerID
+//   - expectedVersion
+func (_e *MockAudioCollectionRepository_Expecter) TransferOwnership(ctx interface{}, collectionID interface{}, newOwnerID interface{}, expectedVersion interface{}) *MockAudioCollectionRepository_TransferOwnership_Call {
+	return &MockAudioCollectionRepository_TransferOwnership_Call{Call: _e.mock.On("TransferOwnership", ctx, collectionID, newOwnerID, expectedVersion)}
+}
+
+func (_c *MockAudioCollectionRepository_TransferOwnership_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, newOwnerID domain.UserID, expectedVersion int)) *MockAudioCollectionRepository_TransferOwnership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(domain.UserID), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_TransferOwnership_Call) Return(int int, err error) *MockAudioCollectionRepository_TransferOwnership_Call {
+	_c.Call.Return(int, err)
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_TransferOwnership_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, newOwnerID domain.UserID, expectedVersion int) (int, error)) *MockAudioCollectionRepository_TransferOwnership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertTrack provides a mock function for the type MockAudioCollectionRepository
+func (_mock *MockAudioCollectionRepository) InsertTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, trackID, position, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertTrack")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioCollectionRepository_InsertTrack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertTrack'
+type MockAudioCollectionRepository_InsertTrack_Call struct {
+	*mock.Call
+}
+
+// InsertTrack is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+//   - trackID
+//   - position
+//   - expectedVersion
+func (_e *MockAudioCollectionRepository_Expecter) InsertTrack(ctx interface{}, collectionID interface{}, trackID interface{}, position interface{}, expectedVersion interface{}) *MockAudioCollectionRepository_InsertTrack_Call {
+	return &MockAudioCollectionRepository_InsertTrack_Call{Call: _e.mock.On("InsertTrack", ctx, collectionID, trackID, position, expectedVersion)}
+}
+
+func (_c *MockAudioCollectionRepository_InsertTrack_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int)) *MockAudioCollectionRepository_InsertTrack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(domain.TrackID), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_InsertTrack_Call) Return(int int, err error) *MockAudioCollectionRepository_InsertTrack_Call {
+	_c.Call.Return(int, err)
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_InsertTrack_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error)) *MockAudioCollectionRepository_InsertTrack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveTrack provides a mock function for the type MockAudioCollectionRepository
+func (_mock *MockAudioCollectionRepository) MoveTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, trackID, position, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveTrack")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioCollectionRepository_MoveTrack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveTrack'
+type MockAudioCollectionRepository_MoveTrack_Call struct {
+	*mock.Call
+}
+
+// MoveTrack is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+//   - trackID
+//   - position
+//   - expectedVersion
+func (_e *MockAudioCollectionRepository_Expecter) MoveTrack(ctx interface{}, collectionID interface{}, trackID interface{}, position interface{}, expectedVersion interface{}) *MockAudioCollectionRepository_MoveTrack_Call {
+	return &MockAudioCollectionRepository_MoveTrack_Call{Call: _e.mock.On("MoveTrack", ctx, collectionID, trackID, position, expectedVersion)}
+}
+
+func (_c *MockAudioCollectionRepository_MoveTrack_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int)) *MockAudioCollectionRepository_MoveTrack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(domain.TrackID), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_MoveTrack_Call) Return(int int, err error) *MockAudioCollectionRepository_MoveTrack_Call {
+	_c.Call.Return(int, err)
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_MoveTrack_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error)) *MockAudioCollectionRepository_MoveTrack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveTrack provides a mock function for the type MockAudioCollectionRepository
+func (_mock *MockAudioCollectionRepository) RemoveTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, trackID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTrack")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, trackID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, trackID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, domain.TrackID, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, trackID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioCollectionRepository_RemoveTrack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTrack'
+type MockAudioCollectionRepository_RemoveTrack_Call struct {
+	*mock.Call
+}
+
+// RemoveTrack is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+//   - trackID
+//   - expectedVersion
+func (_e *MockAudioCollectionRepository_Expecter) RemoveTrack(ctx interface{}, collectionID interface{}, trackID interface{}, expectedVersion interface{}) *MockAudioCollectionRepository_RemoveTrack_Call {
+	return &MockAudioCollectionRepository_RemoveTrack_Call{Call: _e.mock.On("RemoveTrack", ctx, collectionID, trackID, expectedVersion)}
+}
+
+func (_c *MockAudioCollectionRepository_RemoveTrack_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, expectedVersion int)) *MockAudioCollectionRepository_RemoveTrack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(domain.TrackID), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_RemoveTrack_Call) Return(int int, err error) *MockAudioCollectionRepository_RemoveTrack_Call {
+	_c.Call.Return(int, err)
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_RemoveTrack_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, expectedVersion int) (int, error)) *MockAudioCollectionRepository_RemoveTrack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSmartRule provides a mock function for the type MockAudioCollectionRepository
+func (_mock *MockAudioCollectionRepository) UpdateSmartRule(ctx context.Context, collectionID domain.CollectionID, rule domain.SmartRule, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, rule, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSmartRule")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.SmartRule, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, rule, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.SmartRule, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, rule, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, domain.SmartRule, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, rule, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioCollectionRepository_UpdateSmartRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSmartRule'
+type MockAudioCollectionRepository_UpdateSmartRule_Call struct {
+	*mock.Call
+}
+
+// UpdateSmartRule is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+//   - rule
+//   - expectedVersion
+func (_e *MockAudioCollectionRepository_Expecter) UpdateSmartRule(ctx interface{}, collectionID interface{}, rule interface{}, expectedVersion interface{}) *MockAudioCollectionRepository_UpdateSmartRule_Call {
+	return &MockAudioCollectionRepository_UpdateSmartRule_Call{Call: _e.mock.On("UpdateSmartRule", ctx, collectionID, rule, expectedVersion)}
+}
+
+func (_c *MockAudioCollectionRepository_UpdateSmartRule_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, rule domain.SmartRule, expectedVersion int)) *MockAudioCollectionRepository_UpdateSmartRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(domain.SmartRule), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_UpdateSmartRule_Call) Return(int int, err error) *MockAudioCollectionRepository_UpdateSmartRule_Call {
+	_c.Call.Return(int, err)
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_UpdateSmartRule_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, rule domain.SmartRule, expectedVersion int) (int, error)) *MockAudioCollectionRepository_UpdateSmartRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasAccessibleWithTrack provides a mock function for the type MockAudioCollectionRepository
+func (_mock *MockAudioCollectionRepository) HasAccessibleWithTrack(ctx context.Context, userID domain.UserID, trackID domain.TrackID) (bool, error) {
+	ret := _mock.Called(ctx, userID, trackID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasAccessibleWithTrack")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.TrackID) (bool, error)); ok {
+		return returnFunc(ctx, userID, trackID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.TrackID) bool); ok {
+		r0 = returnFunc(ctx, userID, trackID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserID, domain.TrackID) error); ok {
+		r1 = returnFunc(ctx, userID, trackID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioCollectionRepository_HasAccessibleWithTrack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasAccessibleWithTrack'
+type MockAudioCollectionRepository_HasAccessibleWithTrack_Call struct {
+	*mock.Call
+}
+
+// HasAccessibleWithTrack is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - trackID
+func (_e *MockAudioCollectionRepository_Expecter) HasAccessibleWithTrack(ctx interface{}, userID interface{}, trackID interface{}) *MockAudioCollectionRepository_HasAccessibleWithTrack_Call {
+	return &MockAudioCollectionRepository_HasAccessibleWithTrack_Call{Call: _e.mock.On("HasAccessibleWithTrack", ctx, userID, trackID)}
+}
+
+func (_c *MockAudioCollectionRepository_HasAccessibleWithTrack_Call) Run(run func(ctx context.Context, userID domain.UserID, trackID domain.TrackID)) *MockAudioCollectionRepository_HasAccessibleWithTrack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(domain.TrackID))
+	})
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_HasAccessibleWithTrack_Call) Return(bool bool, err error) *MockAudioCollectionRepository_HasAccessibleWithTrack_Call {
+	_c.Call.Return(bool, err)
+	return _c
+}
+
+func (_c *MockAudioCollectionRepository_HasAccessibleWithTrack_Call) RunAndReturn(run func(ctx context.Context, userID domain.UserID, trackID domain.TrackID) (bool, error)) *MockAudioCollectionRepository_HasAccessibleWithTrack_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
 	"github.com/yvanyang/language-learning-player-api/internal/port"
 	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+	"github.com/yvanyang/language-learning-player-api/pkg/playlist"
 )
 
 // NewMockAudioContentUseCase creates a new instance of MockAudioContentUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -41,8 +42,8 @@ func (_m *MockAudioContentUseCase) EXPECT() *MockAudioContentUseCase_Expecter {
 }
 
 // CreateCollection provides a mock function for the type MockAudioContentUseCase
-func (_mock *MockAudioContentUseCase) CreateCollection(ctx context.Context, title string, description string, colType domain.CollectionType, initialTrackIDs []domain.TrackID) (*domain.AudioCollection, error) {
-	ret := _mock.Called(ctx, title, description, colType, initialTrackIDs)
+func (_mock *MockAudioContentUseCase) CreateCollection(ctx context.Context, title string, description string, colType domain.CollectionType, visibility domain.CollectionVisibility, initialTrackIDs []domain.TrackID, smartRule *domain.SmartRule) (*domain.AudioCollection, error) {
+	ret := _mock.Called(ctx, title, description, colType, visibility, initialTrackIDs, smartRule)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateCollection")
@@ -50,18 +51,18 @@ func (_mock *MockAudioContentUseCase) CreateCollection(ctx context.Context, titl
 
 	var r0 *domain.AudioCollection
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, domain.CollectionType, []domain.TrackID) (*domain.AudioCollection, error)); ok {
-		return returnFunc(ctx, title, description, colType, initialTrackIDs)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, domain.CollectionType, domain.CollectionVisibility, []domain.TrackID, *domain.SmartRule) (*domain.AudioCollection, error)); ok {
+		return returnFunc(ctx, title, description, colType, visibility, initialTrackIDs, smartRule)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, domain.CollectionType, []domain.TrackID) *domain.AudioCollection); ok {
-		r0 = returnFunc(ctx, title, description, colType, initialTrackIDs)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, domain.CollectionType, domain.CollectionVisibility, []domain.TrackID, *domain.SmartRule) *domain.AudioCollection); ok {
+		r0 = returnFunc(ctx, title, description, colType, visibility, initialTrackIDs, smartRule)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.AudioCollection)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, domain.CollectionType, []domain.TrackID) error); ok {
-		r1 = returnFunc(ctx, title, description, colType, initialTrackIDs)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, domain.CollectionType, domain.CollectionVisibility, []domain.TrackID, *domain.SmartRule) error); ok {
+		r1 = returnFunc(ctx, title, description, colType, visibility, initialTrackIDs, smartRule)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -78,14 +79,16 @@ type MockAudioContentUseCase_CreateCollection_Call struct {
 //   - title
 //   - description
 //   - colType
+//   - visibility
 //   - initialTrackIDs
-func (_e *MockAudioContentUseCase_Expecter) CreateCollection(ctx interface{}, title interface{}, description interface{}, colType interface{}, initialTrackIDs interface{}) *MockAudioContentUseCase_CreateCollection_Call {
-	return &MockAudioContentUseCase_CreateCollection_Call{Call: _e.mock.On("CreateCollection", ctx, title, description, colType, initialTrackIDs)}
+//   - smartRule
+func (_e *MockAudioContentUseCase_Expecter) CreateCollection(ctx interface{}, title interface{}, description interface{}, colType interface{}, visibility interface{}, initialTrackIDs interface{}, smartRule interface{}) *MockAudioContentUseCase_CreateCollection_Call {
+	return &MockAudioContentUseCase_CreateCollection_Call{Call: _e.mock.On("CreateCollection", ctx, title, description, colType, visibility, initialTrackIDs, smartRule)}
 }
 
-func (_c *MockAudioContentUseCase_CreateCollection_Call) Run(run func(ctx context.Context, title string, description string, colType domain.CollectionType, initialTrackIDs []domain.TrackID)) *MockAudioContentUseCase_CreateCollection_Call {
+func (_c *MockAudioContentUseCase_CreateCollection_Call) Run(run func(ctx context.Context, title string, description string, colType domain.CollectionType, visibility domain.CollectionVisibility, initialTrackIDs []domain.TrackID, smartRule *domain.SmartRule)) *MockAudioContentUseCase_CreateCollection_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(domain.CollectionType), args[4].([]domain.TrackID))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(domain.CollectionType), args[4].(domain.CollectionVisibility), args[5].([]domain.TrackID), args[6].(*domain.SmartRule))
 	})
 	return _c
 }
@@ -95,7 +98,7 @@ func (_c *MockAudioContentUseCase_CreateCollection_Call) Return(audioCollection
 	return _c
 }
 
-func (_c *MockAudioContentUseCase_CreateCollection_Call) RunAndReturn(run func(ctx context.Context, title string, description string, colType domain.CollectionType, initialTrackIDs []domain.TrackID) (*domain.AudioCollection, error)) *MockAudioContentUseCase_CreateCollection_Call {
+func (_c *MockAudioContentUseCase_CreateCollection_Call) RunAndReturn(run func(ctx context.Context, title string, description string, colType domain.CollectionType, visibility domain.CollectionVisibility, initialTrackIDs []domain.TrackID, smartRule *domain.SmartRule) (*domain.AudioCollection, error)) *MockAudioContentUseCase_CreateCollection_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -261,31 +264,43 @@ func (_c *MockAudioContentUseCase_GetCollectionDetails_Call) RunAndReturn(run fu
 }
 
 // GetCollectionTracks provides a mock function for the type MockAudioContentUseCase
-func (_mock *MockAudioContentUseCase) GetCollectionTracks(ctx context.Context, collectionID domain.CollectionID) ([]*domain.AudioTrack, error) {
-	ret := _mock.Called(ctx, collectionID)
+func (_mock *MockAudioContentUseCase) GetCollectionTracks(ctx context.Context, collectionID domain.CollectionID, page pagination.Page) ([]*domain.AudioTrack, int, pagination.Page, error) {
+	ret := _mock.Called(ctx, collectionID, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCollectionTracks")
 	}
 
 	var r0 []*domain.AudioTrack
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID) ([]*domain.AudioTrack, error)); ok {
-		return returnFunc(ctx, collectionID)
+	var r1 int
+	var r2 pagination.Page
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, pagination.Page) ([]*domain.AudioTrack, int, pagination.Page, error)); ok {
+		return returnFunc(ctx, collectionID, page)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID) []*domain.AudioTrack); ok {
-		r0 = returnFunc(ctx, collectionID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, pagination.Page) []*domain.AudioTrack); ok {
+		r0 = returnFunc(ctx, collectionID, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.AudioTrack)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID) error); ok {
-		r1 = returnFunc(ctx, collectionID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, pagination.Page) int); ok {
+		r1 = returnFunc(ctx, collectionID, page)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.CollectionID, pagination.Page) pagination.Page); ok {
+		r2 = returnFunc(ctx, collectionID, page)
+	} else {
+		r2 = ret.Get(2).(pagination.Page)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, domain.CollectionID, pagination.Page) error); ok {
+		r3 = returnFunc(ctx, collectionID, page)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
 }
 
 // MockAudioContentUseCase_GetCollectionTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCollectionTracks'
@@ -296,23 +311,24 @@ type MockAudioContentUseCase_GetCollectionTracks_Call struct {
 // GetCollectionTracks is a helper method to define mock.On call
 //   - ctx
 //   - collectionID
-func (_e *MockAudioContentUseCase_Expecter) GetCollectionTracks(ctx interface{}, collectionID interface{}) *MockAudioContentUseCase_GetCollectionTracks_Call {
-	return &MockAudioContentUseCase_GetCollectionTracks_Call{Call: _e.mock.On("GetCollectionTracks", ctx, collectionID)}
+//   - page
+func (_e *MockAudioContentUseCase_Expecter) GetCollectionTracks(ctx interface{}, collectionID interface{}, page interface{}) *MockAudioContentUseCase_GetCollectionTracks_Call {
+	return &MockAudioContentUseCase_GetCollectionTracks_Call{Call: _e.mock.On("GetCollectionTracks", ctx, collectionID, page)}
 }
 
-func (_c *MockAudioContentUseCase_GetCollectionTracks_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID)) *MockAudioContentUseCase_GetCollectionTracks_Call {
+func (_c *MockAudioContentUseCase_GetCollectionTracks_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, page pagination.Page)) *MockAudioContentUseCase_GetCollectionTracks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(domain.CollectionID))
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(pagination.Page))
 	})
 	return _c
 }
 
-func (_c *MockAudioContentUseCase_GetCollectionTracks_Call) Return(audioTracks []*domain.AudioTrack, err error) *MockAudioContentUseCase_GetCollectionTracks_Call {
-	_c.Call.Return(audioTracks, err)
+func (_c *MockAudioContentUseCase_GetCollectionTracks_Call) Return(audioTrack []*domain.AudioTrack, int int, page pagination.Page, err error) *MockAudioContentUseCase_GetCollectionTracks_Call {
+	_c.Call.Return(audioTrack, int, page, err)
 	return _c
 }
 
-func (_c *MockAudioContentUseCase_GetCollectionTracks_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID) ([]*domain.AudioTrack, error)) *MockAudioContentUseCase_GetCollectionTracks_Call {
+func (_c *MockAudioContentUseCase_GetCollectionTracks_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, page pagination.Page) ([]*domain.AudioTrack, int, pagination.Page, error)) *MockAudioContentUseCase_GetCollectionTracks_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -387,20 +403,29 @@ func (_c *MockAudioContentUseCase_ListTracks_Call) RunAndReturn(run func(ctx con
 }
 
 // UpdateCollectionMetadata provides a mock function for the type MockAudioContentUseCase
-func (_mock *MockAudioContentUseCase) UpdateCollectionMetadata(ctx context.Context, collectionID domain.CollectionID, title string, description string) error {
-	ret := _mock.Called(ctx, collectionID, title, description)
+func (_mock *MockAudioContentUseCase) UpdateCollectionMetadata(ctx context.Context, collectionID domain.CollectionID, title string, description string, visibility *domain.CollectionVisibility, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, title, description, visibility, expectedVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateCollectionMetadata")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, string, string) error); ok {
-		r0 = returnFunc(ctx, collectionID, title, description)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, string, string, *domain.CollectionVisibility, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, title, description, visibility, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, string, string, *domain.CollectionVisibility, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, title, description, visibility, expectedVersion)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, string, string, *domain.CollectionVisibility, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, title, description, visibility, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockAudioContentUseCase_UpdateCollectionMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCollectionMetadata'
@@ -413,42 +438,53 @@ type MockAudioContentUseCase_UpdateCollectionMetadata_Call struct {
 //   - collectionID
 //   - title
 //   - description
-func (_e *MockAudioContentUseCase_Expecter) UpdateCollectionMetadata(ctx interface{}, collectionID interface{}, title interface{}, description interface{}) *MockAudioContentUseCase_UpdateCollectionMetadata_Call {
-	return &MockAudioContentUseCase_UpdateCollectionMetadata_Call{Call: _e.mock.On("UpdateCollectionMetadata", ctx, collectionID, title, description)}
+//   - visibility
+//   - expectedVersion
+func (_e *MockAudioContentUseCase_Expecter) UpdateCollectionMetadata(ctx interface{}, collectionID interface{}, title interface{}, description interface{}, visibility interface{}, expectedVersion interface{}) *MockAudioContentUseCase_UpdateCollectionMetadata_Call {
+	return &MockAudioContentUseCase_UpdateCollectionMetadata_Call{Call: _e.mock.On("UpdateCollectionMetadata", ctx, collectionID, title, description, visibility, expectedVersion)}
 }
 
-func (_c *MockAudioContentUseCase_UpdateCollectionMetadata_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, title string, description string)) *MockAudioContentUseCase_UpdateCollectionMetadata_Call {
+func (_c *MockAudioContentUseCase_UpdateCollectionMetadata_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, title string, description string, visibility *domain.CollectionVisibility, expectedVersion int)) *MockAudioContentUseCase_UpdateCollectionMetadata_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(string), args[3].(string), args[4].(*domain.CollectionVisibility), args[5].(int))
 	})
 	return _c
 }
 
-func (_c *MockAudioContentUseCase_UpdateCollectionMetadata_Call) Return(err error) *MockAudioContentUseCase_UpdateCollectionMetadata_Call {
-	_c.Call.Return(err)
+func (_c *MockAudioContentUseCase_UpdateCollectionMetadata_Call) Return(int int, err error) *MockAudioContentUseCase_UpdateCollectionMetadata_Call {
+	_c.Call.Return(int, err)
 	return _c
 }
 
-func (_c *MockAudioContentUseCase_UpdateCollectionMetadata_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, title string, description string) error) *MockAudioContentUseCase_UpdateCollectionMetadata_Call {
+func (_c *MockAudioContentUseCase_UpdateCollectionMetadata_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, title string, description string, visibility *domain.CollectionVisibility, expectedVersion int) (int, error)) *MockAudioContentUseCase_UpdateCollectionMetadata_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateCollectionTracks provides a mock function for the type MockAudioContentUseCase
-func (_mock *MockAudioContentUseCase) UpdateCollectionTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID) error {
-	ret := _mock.Called(ctx, collectionID, orderedTrackIDs)
+func (_mock *MockAudioContentUseCase) UpdateCollectionTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, orderedTrackIDs, expectedVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateCollectionTracks")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, []domain.TrackID) error); ok {
-		r0 = returnFunc(ctx, collectionID, orderedTrackIDs)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, []domain.TrackID, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, orderedTrackIDs, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, []domain.TrackID, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, orderedTrackIDs, expectedVersion)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, []domain.TrackID, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, orderedTrackIDs, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockAudioContentUseCase_UpdateCollectionTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCollectionTracks'
@@ -460,23 +496,669 @@ type MockAudioContentUseCase_UpdateCollectionTracks_Call struct {
 //   - ctx
 //   - collectionID
 //   - orderedTrackIDs
-func (_e *MockAudioContentUseCase_Expecter) UpdateCollectionTracks(ctx interface{}, collectionID interface{}, orderedTrackIDs interface{}) *MockAudioContentUseCase_UpdateCollectionTracks_Call {
-	return &MockAudioContentUseCase_UpdateCollectionTracks_Call{Call: _e.mock.On("UpdateCollectionTracks", ctx, collectionID, orderedTrackIDs)}
+//   - expectedVersion
+func (_e *MockAudioContentUseCase_Expecter) UpdateCollectionTracks(ctx interface{}, collectionID interface{}, orderedTrackIDs interface{}, expectedVersion interface{}) *MockAudioContentUseCase_UpdateCollectionTracks_Call {
+	return &MockAudioContentUseCase_UpdateCollectionTracks_Call{Call: _e.mock.On("UpdateCollectionTracks", ctx, collectionID, orderedTrackIDs, expectedVersion)}
 }
 
-func (_c *MockAudioContentUseCase_UpdateCollectionTracks_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID)) *MockAudioContentUseCase_UpdateCollectionTracks_Call {
+func (_c *MockAudioContentUseCase_UpdateCollectionTracks_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID, expectedVersion int)) *MockAudioContentUseCase_UpdateCollectionTracks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].([]domain.TrackID))
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].([]domain.TrackID), args[3].(int))
 	})
 	return _c
 }
 
-func (_c *MockAudioContentUseCase_UpdateCollectionTracks_Call) Return(err error) *MockAudioContentUseCase_UpdateCollectionTracks_Call {
-	_c.Call.Return(err)
+func (_c *MockAudioContentUseCase_UpdateCollectionTracks_Call) Return(n int, err error) *MockAudioContentUseCase_UpdateCollectionTracks_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_UpdateCollectionTracks_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID, expectedVersion int) (int, error)) *MockAudioContentUseCase_UpdateCollectionTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPublicCollections provides a mock function for the type MockAudioContentUseCase
+func (_mock *MockAudioContentUseCase) ListPublicCollections(ctx context.Context, input port.ListCollectionsInput) ([]*domain.AudioCollection, int, pagination.Page, error) {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPublicCollections")
+	}
+
+	var r0 []*domain.AudioCollection
+	var r1 int
+	var r2 pagination.Page
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.ListCollectionsInput) ([]*domain.AudioCollection, int, pagination.Page, error)); ok {
+		return returnFunc(ctx, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.ListCollectionsInput) []*domain.AudioCollection); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AudioCollection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, port.ListCollectionsInput) int); ok {
+		r1 = returnFunc(ctx, input)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, port.ListCollectionsInput) pagination.Page); ok {
+		r2 = returnFunc(ctx, input)
+	} else {
+		r2 = ret.Get(2).(pagination.Page)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, port.ListCollectionsInput) error); ok {
+		r3 = returnFunc(ctx, input)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockAudioContentUseCase_ListPublicCollections_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicCollections'
+type MockAudioContentUseCase_ListPublicCollections_Call struct {
+	*mock.Call
+}
+
+// ListPublicCollections is a helper method to define mock.On call
+//   - ctx
+//   - input
+func (_e *MockAudioContentUseCase_Expecter) ListPublicCollections(ctx interface{}, input interface{}) *MockAudioContentUseCase_ListPublicCollections_Call {
+	return &MockAudioContentUseCase_ListPublicCollections_Call{Call: _e.mock.On("ListPublicCollections", ctx, input)}
+}
+
+func (_c *MockAudioContentUseCase_ListPublicCollections_Call) Run(run func(ctx context.Context, input port.ListCollectionsInput)) *MockAudioContentUseCase_ListPublicCollections_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(port.ListCollectionsInput))
+	})
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_ListPublicCollections_Call) Return(audioCollection []*domain.AudioCollection, int int, page pagination.Page, err error) *MockAudioContentUseCase_ListPublicCollections_Call {
+	_c.Call.Return(audioCollection, int, page, err)
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_ListPublicCollections_Call) RunAndReturn(run func(ctx context.Context, input port.ListCollectionsInput) ([]*domain.AudioCollection, int, pagination.Page, error)) *MockAudioContentUseCase_ListPublicCollections_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddCollectionTrack provides a mock function for the type MockAudioContentUseCase
+func (_mock *MockAudioContentUseCase) AddCollectionTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, trackID, position, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCollectionTrack")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioContentUseCase_AddCollectionTrack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCollectionTrack'
+type MockAudioContentUseCase_AddCollectionTrack_Call struct {
+	*mock.Call
+}
+
+// AddCollectionTrack is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+//   - trackID
+//   - position
+//   - expectedVersion
+func (_e *MockAudioContentUseCase_Expecter) AddCollectionTrack(ctx interface{}, collectionID interface{}, trackID interface{}, position interface{}, expectedVersion interface{}) *MockAudioContentUseCase_AddCollectionTrack_Call {
+	return &MockAudioContentUseCase_AddCollectionTrack_Call{Call: _e.mock.On("AddCollectionTrack", ctx, collectionID, trackID, position, expectedVersion)}
+}
+
+func (_c *MockAudioContentUseCase_AddCollectionTrack_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int)) *MockAudioContentUseCase_AddCollectionTrack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(domain.TrackID), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_AddCollectionTrack_Call) Return(int int, err error) *MockAudioContentUseCase_AddCollectionTrack_Call {
+	_c.Call.Return(int, err)
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_AddCollectionTrack_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error)) *MockAudioContentUseCase_AddCollectionTrack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveCollectionTrack provides a mock function for the type MockAudioContentUseCase
+func (_mock *MockAudioContentUseCase) MoveCollectionTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, trackID, position, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveCollectionTrack")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, domain.TrackID, int, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, trackID, position, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioContentUseCase_MoveCollectionTrack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveCollectionTrack'
+type MockAudioContentUseCase_MoveCollectionTrack_Call struct {
+	*mock.Call
+}
+
+// MoveCollectionTrack is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+//   - trackID
+//   - position
+//   - expectedVersion
+func (_e *MockAudioContentUseCase_Expecter) MoveCollectionTrack(ctx interface{}, collectionID interface{}, trackID interface{}, position interface{}, expectedVersion interface{}) *MockAudioContentUseCase_MoveCollectionTrack_Call {
+	return &MockAudioContentUseCase_MoveCollectionTrack_Call{Call: _e.mock.On("MoveCollectionTrack", ctx, collectionID, trackID, position, expectedVersion)}
+}
+
+func (_c *MockAudioContentUseCase_MoveCollectionTrack_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int)) *MockAudioContentUseCase_MoveCollectionTrack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(domain.TrackID), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_MoveCollectionTrack_Call) Return(int int, err error) *MockAudioContentUseCase_MoveCollectionTrack_Call {
+	_c.Call.Return(int, err)
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_MoveCollectionTrack_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error)) *MockAudioContentUseCase_MoveCollectionTrack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveCollectionTrack provides a mock function for the type MockAudioContentUseCase
+func (_mock *MockAudioContentUseCase) RemoveCollectionTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, trackID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCollectionTrack")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, trackID, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.TrackID, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, trackID, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, domain.TrackID, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, trackID, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioContentUseCase_RemoveCollectionTrack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveCollectionTrack'
+type MockAudioContentUseCase_RemoveCollectionTrack_Call struct {
+	*mock.Call
+}
+
+// RemoveCollectionTrack is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+//   - trackID
+//   - expectedVersion
+func (_e *MockAudioContentUseCase_Expecter) RemoveCollectionTrack(ctx interface{}, collectionID interface{}, trackID interface{}, expectedVersion interface{}) *MockAudioContentUseCase_RemoveCollectionTrack_Call {
+	return &MockAudioContentUseCase_RemoveCollectionTrack_Call{Call: _e.mock.On("RemoveCollectionTrack", ctx, collectionID, trackID, expectedVersion)}
+}
+
+func (_c *MockAudioContentUseCase_RemoveCollectionTrack_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, expectedVersion int)) *MockAudioContentUseCase_RemoveCollectionTrack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(domain.TrackID), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_RemoveCollectionTrack_Call) Return(int int, err error) *MockAudioContentUseCase_RemoveCollectionTrack_Call {
+	_c.Call.Return(int, err)
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_RemoveCollectionTrack_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, expectedVersion int) (int, error)) *MockAudioContentUseCase_RemoveCollectionTrack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSmartRule provides a mock function for the type MockAudioContentUseCase
+func (_mock *MockAudioContentUseCase) UpdateSmartRule(ctx context.Context, collectionID domain.CollectionID, rule domain.SmartRule, expectedVersion int) (int, error) {
+	ret := _mock.Called(ctx, collectionID, rule, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSmartRule")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.SmartRule, int) (int, error)); ok {
+		return returnFunc(ctx, collectionID, rule, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, domain.SmartRule, int) int); ok {
+		r0 = returnFunc(ctx, collectionID, rule, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, domain.SmartRule, int) error); ok {
+		r1 = returnFunc(ctx, collectionID, rule, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioContentUseCase_UpdateSmartRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSmartRule'
+type MockAudioContentUseCase_UpdateSmartRule_Call struct {
+	*mock.Call
+}
+
+// UpdateSmartRule is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+//   - rule
+//   - expectedVersion
+func (_e *MockAudioContentUseCase_Expecter) UpdateSmartRule(ctx interface{}, collectionID interface{}, rule interface{}, expectedVersion interface{}) *MockAudioContentUseCase_UpdateSmartRule_Call {
+	return &MockAudioContentUseCase_UpdateSmartRule_Call{Call: _e.mock.On("UpdateSmartRule", ctx, collectionID, rule, expectedVersion)}
+}
+
+func (_c *MockAudioContentUseCase_UpdateSmartRule_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, rule domain.SmartRule, expectedVersion int)) *MockAudioContentUseCase_UpdateSmartRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(domain.SmartRule), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_UpdateSmartRule_Call) Return(int int, err error) *MockAudioContentUseCase_UpdateSmartRule_Call {
+	_c.Call.Return(int, err)
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_UpdateSmartRule_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, rule domain.SmartRule, expectedVersion int) (int, error)) *MockAudioContentUseCase_UpdateSmartRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FreezeSmartCollection provides a mock function for the type MockAudioContentUseCase
+func (_mock *MockAudioContentUseCase) FreezeSmartCollection(ctx context.Context, collectionID domain.CollectionID, title string) (*domain.AudioCollection, error) {
+	ret := _mock.Called(ctx, collectionID, title)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FreezeSmartCollection")
+	}
+
+	var r0 *domain.AudioCollection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, string) (*domain.AudioCollection, error)); ok {
+		return returnFunc(ctx, collectionID, title)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, string) *domain.AudioCollection); ok {
+		r0 = returnFunc(ctx, collectionID, title)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AudioCollection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, string) error); ok {
+		r1 = returnFunc(ctx, collectionID, title)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioContentUseCase_FreezeSmartCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FreezeSmartCollection'
+type MockAudioContentUseCase_FreezeSmartCollection_Call struct {
+	*mock.Call
+}
+
+// FreezeSmartCollection is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+//   - title
+func (_e *MockAudioContentUseCase_Expecter) FreezeSmartCollection(ctx interface{}, collectionID interface{}, title interface{}) *MockAudioContentUseCase_FreezeSmartCollection_Call {
+	return &MockAudioContentUseCase_FreezeSmartCollection_Call{Call: _e.mock.On("FreezeSmartCollection", ctx, collectionID, title)}
+}
+
+func (_c *MockAudioContentUseCase_FreezeSmartCollection_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, title string)) *MockAudioContentUseCase_FreezeSmartCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_FreezeSmartCollection_Call) Return(audioCollection *domain.AudioCollection, err error) *MockAudioContentUseCase_FreezeSmartCollection_Call {
+	_c.Call.Return(audioCollection, err)
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_FreezeSmartCollection_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, title string) (*domain.AudioCollection, error)) *MockAudioContentUseCase_FreezeSmartCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForkCollection provides a mock function for the type MockAudioContentUseCase
+func (_mock *MockAudioContentUseCase) ForkCollection(ctx context.Context, collectionID domain.CollectionID, title string) (*domain.AudioCollection, error) {
+	ret := _mock.Called(ctx, collectionID, title)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForkCollection")
+	}
+
+	var r0 *domain.AudioCollection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, string) (*domain.AudioCollection, error)); ok {
+		return returnFunc(ctx, collectionID, title)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID, string) *domain.AudioCollection); ok {
+		r0 = returnFunc(ctx, collectionID, title)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AudioCollection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID, string) error); ok {
+		r1 = returnFunc(ctx, collectionID, title)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioContentUseCase_ForkCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForkCollection'
+type MockAudioContentUseCase_ForkCollection_Call struct {
+	*mock.Call
+}
+
+// ForkCollection is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+//   - title
+func (_e *MockAudioContentUseCase_Expecter) ForkCollection(ctx interface{}, collectionID interface{}, title interface{}) *MockAudioContentUseCase_ForkCollection_Call {
+	return &MockAudioContentUseCase_ForkCollection_Call{Call: _e.mock.On("ForkCollection", ctx, collectionID, title)}
+}
+
+func (_c *MockAudioContentUseCase_ForkCollection_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID, title string)) *MockAudioContentUseCase_ForkCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_ForkCollection_Call) Return(audioCollection *domain.AudioCollection, err error) *MockAudioContentUseCase_ForkCollection_Call {
+	_c.Call.Return(audioCollection, err)
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_ForkCollection_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, title string) (*domain.AudioCollection, error)) *MockAudioContentUseCase_ForkCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportCollection provides a mock function for the type MockAudioContentUseCase
+func (_mock *MockAudioContentUseCase) ExportCollection(ctx context.Context, collectionID domain.CollectionID) (*playlist.Playlist, error) {
+	ret := _mock.Called(ctx, collectionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportCollection")
+	}
+
+	var r0 *playlist.Playlist
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID) (*playlist.Playlist, error)); ok {
+		return returnFunc(ctx, collectionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CollectionID) *playlist.Playlist); ok {
+		r0 = returnFunc(ctx, collectionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*playlist.Playlist)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CollectionID) error); ok {
+		r1 = returnFunc(ctx, collectionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioContentUseCase_ExportCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportCollection'
+type MockAudioContentUseCase_ExportCollection_Call struct {
+	*mock.Call
+}
+
+// ExportCollection is a helper method to define mock.On call
+//   - ctx
+//   - collectionID
+func (_e *MockAudioContentUseCase_Expecter) ExportCollection(ctx interface{}, collectionID interface{}) *MockAudioContentUseCase_ExportCollection_Call {
+	return &MockAudioContentUseCase_ExportCollection_Call{Call: _e.mock.On("ExportCollection", ctx, collectionID)}
+}
+
+func (_c *MockAudioContentUseCase_ExportCollection_Call) Run(run func(ctx context.Context, collectionID domain.CollectionID)) *MockAudioContentUseCase_ExportCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CollectionID))
+	})
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_ExportCollection_Call) Return(playlist *playlist.Playlist, err error) *MockAudioContentUseCase_ExportCollection_Call {
+	_c.Call.Return(playlist, err)
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_ExportCollection_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID) (*playlist.Playlist, error)) *MockAudioContentUseCase_ExportCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportPlaylist provides a mock function for the type MockAudioContentUseCase
+func (_mock *MockAudioContentUseCase) ImportPlaylist(ctx context.Context, p *playlist.Playlist, title string) (*port.PlaylistImportResult, error) {
+	ret := _mock.Called(ctx, p, title)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportPlaylist")
+	}
+
+	var r0 *port.PlaylistImportResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *playlist.Playlist, string) (*port.PlaylistImportResult, error)); ok {
+		return returnFunc(ctx, p, title)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *playlist.Playlist, string) *port.PlaylistImportResult); ok {
+		r0 = returnFunc(ctx, p, title)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*port.PlaylistImportResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *playlist.Playlist, string) error); ok {
+		r1 = returnFunc(ctx, p, title)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioContentUseCase_ImportPlaylist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportPlaylist'
+type MockAudioContentUseCase_ImportPlaylist_Call struct {
+	*mock.Call
+}
+
+// ImportPlaylist is a helper method to define mock.On call
+//   - ctx
+//   - p
+//   - title
+func (_e *MockAudioContentUseCase_Expecter) ImportPlaylist(ctx interface{}, p interface{}, title interface{}) *MockAudioContentUseCase_ImportPlaylist_Call {
+	return &MockAudioContentUseCase_ImportPlaylist_Call{Call: _e.mock.On("ImportPlaylist", ctx, p, title)}
+}
+
+func (_c *MockAudioContentUseCase_ImportPlaylist_Call) Run(run func(ctx context.Context, p *playlist.Playlist, title string)) *MockAudioContentUseCase_ImportPlaylist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*playlist.Playlist), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_ImportPlaylist_Call) Return(playlistImportResult *port.PlaylistImportResult, err error) *MockAudioContentUseCase_ImportPlaylist_Call {
+	_c.Call.Return(playlistImportResult, err)
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_ImportPlaylist_Call) RunAndReturn(run func(ctx context.Context, p *playlist.Playlist, title string) (*port.PlaylistImportResult, error)) *MockAudioContentUseCase_ImportPlaylist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrackChapters provides a mock function for the type MockAudioContentUseCase
+func (_mock *MockAudioContentUseCase) GetTrackChapters(ctx context.Context, trackID domain.TrackID) ([]domain.TrackChapter, error) {
+	ret := _mock.Called(ctx, trackID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrackChapters")
+	}
+
+	var r0 []domain.TrackChapter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TrackID) ([]domain.TrackChapter, error)); ok {
+		return returnFunc(ctx, trackID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TrackID) []domain.TrackChapter); ok {
+		r0 = returnFunc(ctx, trackID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TrackChapter)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.TrackID) error); ok {
+		r1 = returnFunc(ctx, trackID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioContentUseCase_GetTrackChapters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrackChapters'
+type MockAudioContentUseCase_GetTrackChapters_Call struct {
+	*mock.Call
+}
+
+// GetTrackChapters is a helper method to define mock.On call
+//   - ctx
+//   - trackID
+func (_e *MockAudioContentUseCase_Expecter) GetTrackChapters(ctx interface{}, trackID interface{}) *MockAudioContentUseCase_GetTrackChapters_Call {
+	return &MockAudioContentUseCase_GetTrackChapters_Call{Call: _e.mock.On("GetTrackChapters", ctx, trackID)}
+}
+
+func (_c *MockAudioContentUseCase_GetTrackChapters_Call) Run(run func(ctx context.Context, trackID domain.TrackID)) *MockAudioContentUseCase_GetTrackChapters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.TrackID))
+	})
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_GetTrackChapters_Call) Return(trackChapter []domain.TrackChapter, err error) *MockAudioContentUseCase_GetTrackChapters_Call {
+	_c.Call.Return(trackChapter, err)
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_GetTrackChapters_Call) RunAndReturn(run func(ctx context.Context, trackID domain.TrackID) ([]domain.TrackChapter, error)) *MockAudioContentUseCase_GetTrackChapters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTrackChapters provides a mock function for the type MockAudioContentUseCase
+func (_mock *MockAudioContentUseCase) UpdateTrackChapters(ctx context.Context, trackID domain.TrackID, chapters []domain.TrackChapter) ([]domain.TrackChapter, error) {
+	ret := _mock.Called(ctx, trackID, chapters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTrackChapters")
+	}
+
+	var r0 []domain.TrackChapter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TrackID, []domain.TrackChapter) ([]domain.TrackChapter, error)); ok {
+		return returnFunc(ctx, trackID, chapters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TrackID, []domain.TrackChapter) []domain.TrackChapter); ok {
+		r0 = returnFunc(ctx, trackID, chapters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TrackChapter)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.TrackID, []domain.TrackChapter) error); ok {
+		r1 = returnFunc(ctx, trackID, chapters)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioContentUseCase_UpdateTrackChapters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTrackChapters'
+type MockAudioContentUseCase_UpdateTrackChapters_Call struct {
+	*mock.Call
+}
+
+// UpdateTrackChapters is a helper method to define mock.On call
+//   - ctx
+//   - trackID
+//   - chapters
+func (_e *MockAudioContentUseCase_Expecter) UpdateTrackChapters(ctx interface{}, trackID interface{}, chapters interface{}) *MockAudioContentUseCase_UpdateTrackChapters_Call {
+	return &MockAudioContentUseCase_UpdateTrackChapters_Call{Call: _e.mock.On("UpdateTrackChapters", ctx, trackID, chapters)}
+}
+
+func (_c *MockAudioContentUseCase_UpdateTrackChapters_Call) Run(run func(ctx context.Context, trackID domain.TrackID, chapters []domain.TrackChapter)) *MockAudioContentUseCase_UpdateTrackChapters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.TrackID), args[2].([]domain.TrackChapter))
+	})
+	return _c
+}
+
+func (_c *MockAudioContentUseCase_UpdateTrackChapters_Call) Return(trackChapter []domain.TrackChapter, err error) *MockAudioContentUseCase_UpdateTrackChapters_Call {
+	_c.Call.Return(trackChapter, err)
 	return _c
 }
 
-func (_c *MockAudioContentUseCase_UpdateCollectionTracks_Call) RunAndReturn(run func(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID) error) *MockAudioContentUseCase_UpdateCollectionTracks_Call {
+func (_c *MockAudioContentUseCase_UpdateTrackChapters_Call) RunAndReturn(run func(ctx context.Context, trackID domain.TrackID, chapters []domain.TrackChapter) ([]domain.TrackChapter, error)) *MockAudioContentUseCase_UpdateTrackChapters_Call {
 	_c.Call.Return(run)
 	return _c
 }
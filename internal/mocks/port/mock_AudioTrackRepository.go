@@ -410,3 +410,229 @@ func (_c *MockAudioTrackRepository_Update_Call) RunAndReturn(run func(ctx contex
 	_c.Call.Return(run)
 	return _c
 }
+
+// FindByObjectKey provides a mock function for the type MockAudioTrackRepository
+func (_mock *MockAudioTrackRepository) FindByObjectKey(ctx context.Context, objectKey string) (*domain.AudioTrack, error) {
+	ret := _mock.Called(ctx, objectKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByObjectKey")
+	}
+
+	var r0 *domain.AudioTrack
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.AudioTrack, error)); ok {
+		return returnFunc(ctx, objectKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.AudioTrack); ok {
+		r0 = returnFunc(ctx, objectKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AudioTrack)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, objectKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioTrackRepository_FindByObjectKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByObjectKey'
+type MockAudioTrackRepository_FindByObjectKey_Call struct {
+	*mock.Call
+}
+
+// FindByObjectKey is a helper method to define mock.On call
+//   - ctx
+//   - objectKey
+func (_e *MockAudioTrackRepository_Expecter) FindByObjectKey(ctx interface{}, objectKey interface{}) *MockAudioTrackRepository_FindByObjectKey_Call {
+	return &MockAudioTrackRepository_FindByObjectKey_Call{Call: _e.mock.On("FindByObjectKey", ctx, objectKey)}
+}
+
+func (_c *MockAudioTrackRepository_FindByObjectKey_Call) Run(run func(ctx context.Context, objectKey string)) *MockAudioTrackRepository_FindByObjectKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAudioTrackRepository_FindByObjectKey_Call) Return(audioTrack *domain.AudioTrack, err error) *MockAudioTrackRepository_FindByObjectKey_Call {
+	_c.Call.Return(audioTrack, err)
+	return _c
+}
+
+func (_c *MockAudioTrackRepository_FindByObjectKey_Call) RunAndReturn(run func(ctx context.Context, objectKey string) (*domain.AudioTrack, error)) *MockAudioTrackRepository_FindByObjectKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetContentChecksum provides a mock function for the type MockAudioTrackRepository
+func (_mock *MockAudioTrackRepository) SetContentChecksum(ctx context.Context, id domain.TrackID, contentSHA256 string, sizeBytes int64) error {
+	ret := _mock.Called(ctx, id, contentSHA256, sizeBytes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetContentChecksum")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TrackID, string, int64) error); ok {
+		r0 = returnFunc(ctx, id, contentSHA256, sizeBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAudioTrackRepository_SetContentChecksum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetContentChecksum'
+type MockAudioTrackRepository_SetContentChecksum_Call struct {
+	*mock.Call
+}
+
+// SetContentChecksum is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - contentSHA256
+//   - sizeBytes
+func (_e *MockAudioTrackRepository_Expecter) SetContentChecksum(ctx interface{}, id interface{}, contentSHA256 interface{}, sizeBytes interface{}) *MockAudioTrackRepository_SetContentChecksum_Call {
+	return &MockAudioTrackRepository_SetContentChecksum_Call{Call: _e.mock.On("SetContentChecksum", ctx, id, contentSHA256, sizeBytes)}
+}
+
+func (_c *MockAudioTrackRepository_SetContentChecksum_Call) Run(run func(ctx context.Context, id domain.TrackID, contentSHA256 string, sizeBytes int64)) *MockAudioTrackRepository_SetContentChecksum_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.TrackID), args[2].(string), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAudioTrackRepository_SetContentChecksum_Call) Return(err error) *MockAudioTrackRepository_SetContentChecksum_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAudioTrackRepository_SetContentChecksum_Call) RunAndReturn(run func(ctx context.Context, id domain.TrackID, contentSHA256 string, sizeBytes int64) error) *MockAudioTrackRepository_SetContentChecksum_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByContentHash provides a mock function for the type MockAudioTrackRepository
+func (_mock *MockAudioTrackRepository) ListByContentHash(ctx context.Context, contentSHA256 string) ([]*domain.AudioTrack, error) {
+	ret := _mock.Called(ctx, contentSHA256)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByContentHash")
+	}
+
+	var r0 []*domain.AudioTrack
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*domain.AudioTrack, error)); ok {
+		return returnFunc(ctx, contentSHA256)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*domain.AudioTrack); ok {
+		r0 = returnFunc(ctx, contentSHA256)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AudioTrack)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, contentSHA256)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAudioTrackRepository_ListByContentHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByContentHash'
+type MockAudioTrackRepository_ListByContentHash_Call struct {
+	*mock.Call
+}
+
+// ListByContentHash is a helper method to define mock.On call
+//   - ctx
+//   - contentSHA256
+func (_e *MockAudioTrackRepository_Expecter) ListByContentHash(ctx interface{}, contentSHA256 interface{}) *MockAudioTrackRepository_ListByContentHash_Call {
+	return &MockAudioTrackRepository_ListByContentHash_Call{Call: _e.mock.On("ListByContentHash", ctx, contentSHA256)}
+}
+
+func (_c *MockAudioTrackRepository_ListByContentHash_Call) Run(run func(ctx context.Context, contentSHA256 string)) *MockAudioTrackRepository_ListByContentHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAudioTrackRepository_ListByContentHash_Call) Return(audioTrack []*domain.AudioTrack, err error) *MockAudioTrackRepository_ListByContentHash_Call {
+	_c.Call.Return(audioTrack, err)
+	return _c
+}
+
+func (_c *MockAudioTrackRepository_ListByContentHash_Call) RunAndReturn(run func(ctx context.Context, contentSHA256 string) ([]*domain.AudioTrack, error)) *MockAudioTrackRepository_ListByContentHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDuplicates provides a mock function for the type MockAudioTrackRepository
+func (_mock *MockAudioTrackRepository) ListDuplicates(ctx context.Context, filter port.DuplicateTracksFilter, page pagination.Page) ([]port.DuplicateTrackGroup, int, error) {
+	ret := _mock.Called(ctx, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDuplicates")
+	}
+
+	var r0 []port.DuplicateTrackGroup
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.DuplicateTracksFilter, pagination.Page) ([]port.DuplicateTrackGroup, int, error)); ok {
+		return returnFunc(ctx, filter, page)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.DuplicateTracksFilter, pagination.Page) []port.DuplicateTrackGroup); ok {
+		r0 = returnFunc(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]port.DuplicateTrackGroup)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, port.DuplicateTracksFilter, pagination.Page) int); ok {
+		r1 = returnFunc(ctx, filter, page)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, port.DuplicateTracksFilter, pagination.Page) error); ok {
+		r2 = returnFunc(ctx, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAudioTrackRepository_ListDuplicates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDuplicates'
+type MockAudioTrackRepository_ListDuplicates_Call struct {
+	*mock.Call
+}
+
+// ListDuplicates is a helper method to define mock.On call
+//   - ctx
+//   - filter
+//   - page
+func (_e *MockAudioTrackRepository_Expecter) ListDuplicates(ctx interface{}, filter interface{}, page interface{}) *MockAudioTrackRepository_ListDuplicates_Call {
+	return &MockAudioTrackRepository_ListDuplicates_Call{Call: _e.mock.On("ListDuplicates", ctx, filter, page)}
+}
+
+func (_c *MockAudioTrackRepository_ListDuplicates_Call) Run(run func(ctx context.Context, filter port.DuplicateTracksFilter, page pagination.Page)) *MockAudioTrackRepository_ListDuplicates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(port.DuplicateTracksFilter), args[2].(pagination.Page))
+	})
+	return _c
+}
+
+func (_c *MockAudioTrackRepository_ListDuplicates_Call) Return(duplicateTrackGroup []port.DuplicateTrackGroup, int int, err error) *MockAudioTrackRepository_ListDuplicates_Call {
+	_c.Call.Return(duplicateTrackGroup, int, err)
+	return _c
+}
+
+func (_c *MockAudioTrackRepository_ListDuplicates_Call) RunAndReturn(run func(ctx context.Context, filter port.DuplicateTracksFilter, page pagination.Page) ([]port.DuplicateTrackGroup, int, error)) *MockAudioTrackRepository_ListDuplicates_Call {
+	_c.Call.Return(run)
+	return _c
+}
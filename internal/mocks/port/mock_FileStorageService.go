@@ -6,9 +6,11 @@ package mocks
 
 import (
 	"context"
+	"io"
 	"time"
 
 	mock "github.com/stretchr/testify/mock"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
 )
 
 // NewMockFileStorageService creates a new instance of MockFileStorageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -254,3 +256,167 @@ func (_c *MockFileStorageService_ObjectExists_Call) RunAndReturn(run func(ctx co
 	_c.Call.Return(run)
 	return _c
 }
+
+// ListObjects provides a mock function for the type MockFileStorageService
+func (_mock *MockFileStorageService) ListObjects(ctx context.Context, bucket string, prefix string) ([]port.StorageObject, error) {
+	ret := _mock.Called(ctx, bucket, prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListObjects")
+	}
+
+	var r0 []port.StorageObject
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]port.StorageObject, error)); ok {
+		return returnFunc(ctx, bucket, prefix)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []port.StorageObject); ok {
+		r0 = returnFunc(ctx, bucket, prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]port.StorageObject)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, bucket, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileStorageService_ListObjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListObjects'
+type MockFileStorageService_ListObjects_Call struct {
+	*mock.Call
+}
+
+// ListObjects is a helper method to define mock.On call
+//   - ctx
+//   - bucket
+//   - prefix
+func (_e *MockFileStorageService_Expecter) ListObjects(ctx interface{}, bucket interface{}, prefix interface{}) *MockFileStorageService_ListObjects_Call {
+	return &MockFileStorageService_ListObjects_Call{Call: _e.mock.On("ListObjects", ctx, bucket, prefix)}
+}
+
+func (_c *MockFileStorageService_ListObjects_Call) Run(run func(ctx context.Context, bucket string, prefix string)) *MockFileStorageService_ListObjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileStorageService_ListObjects_Call) Return(storageObject []port.StorageObject, err error) *MockFileStorageService_ListObjects_Call {
+	_c.Call.Return(storageObject, err)
+	return _c
+}
+
+func (_c *MockFileStorageService_ListObjects_Call) RunAndReturn(run func(ctx context.Context, bucket string, prefix string) ([]port.StorageObject, error)) *MockFileStorageService_ListObjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutObject provides a mock function for the type MockFileStorageService
+func (_mock *MockFileStorageService) PutObject(ctx context.Context, bucket string, objectKey string, reader io.Reader, size int64, contentType string) error {
+	ret := _mock.Called(ctx, bucket, objectKey, reader, size, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutObject")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, io.Reader, int64, string) error); ok {
+		r0 = returnFunc(ctx, bucket, objectKey, reader, size, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileStorageService_PutObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutObject'
+type MockFileStorageService_PutObject_Call struct {
+	*mock.Call
+}
+
+// PutObject is a helper method to define mock.On call
+//   - ctx
+//   - bucket
+//   - objectKey
+//   - reader
+//   - size
+//   - contentType
+func (_e *MockFileStorageService_Expecter) PutObject(ctx interface{}, bucket interface{}, objectKey interface{}, reader interface{}, size interface{}, contentType interface{}) *MockFileStorageService_PutObject_Call {
+	return &MockFileStorageService_PutObject_Call{Call: _e.mock.On("PutObject", ctx, bucket, objectKey, reader, size, contentType)}
+}
+
+func (_c *MockFileStorageService_PutObject_Call) Run(run func(ctx context.Context, bucket string, objectKey string, reader io.Reader, size int64, contentType string)) *MockFileStorageService_PutObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(io.Reader), args[4].(int64), args[5].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileStorageService_PutObject_Call) Return(err error) *MockFileStorageService_PutObject_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileStorageService_PutObject_Call) RunAndReturn(run func(ctx context.Context, bucket string, objectKey string, reader io.Reader, size int64, contentType string) error) *MockFileStorageService_PutObject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetObject provides a mock function for the type MockFileStorageService
+func (_mock *MockFileStorageService) GetObject(ctx context.Context, bucket string, objectKey string) (io.ReadCloser, error) {
+	ret := _mock.Called(ctx, bucket, objectKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObject")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (io.ReadCloser, error)); ok {
+		return returnFunc(ctx, bucket, objectKey)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) io.ReadCloser); ok {
+		r0 = returnFunc(ctx, bucket, objectKey)
+	} else {
+		r0 = ret.Get(0).(io.ReadCloser)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, bucket, objectKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileStorageService_GetObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetObject'
+type MockFileStorageService_GetObject_Call struct {
+	*mock.Call
+}
+
+// GetObject is a helper method to define mock.On call
+//   - ctx
+//   - bucket
+//   - objectKey
+func (_e *MockFileStorageService_Expecter) GetObject(ctx interface{}, bucket interface{}, objectKey interface{}) *MockFileStorageService_GetObject_Call {
+	return &MockFileStorageService_GetObject_Call{Call: _e.mock.On("GetObject", ctx, bucket, objectKey)}
+}
+
+func (_c *MockFileStorageService_GetObject_Call) Run(run func(ctx context.Context, bucket string, objectKey string)) *MockFileStorageService_GetObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockFileStorageService_GetObject_Call) Return(readCloser io.ReadCloser, err error) *MockFileStorageService_GetObject_Call {
+	_c.Call.Return(readCloser, err)
+	return _c
+}
+
+func (_c *MockFileStorageService_GetObject_Call) RunAndReturn(run func(ctx context.Context, bucket string, objectKey string) (io.ReadCloser, error)) *MockFileStorageService_GetObject_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -206,3 +206,61 @@ func (_c *MockPlaybackProgressRepository_Upsert_Call) RunAndReturn(run func(ctx
 	_c.Call.Return(run)
 	return _c
 }
+
+// ListByUserAndTracks provides a mock function for the type MockPlaybackProgressRepository
+func (_mock *MockPlaybackProgressRepository) ListByUserAndTracks(ctx context.Context, userID domain.UserID, trackIDs []domain.TrackID) ([]*domain.PlaybackProgress, error) {
+	ret := _mock.Called(ctx, userID, trackIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUserAndTracks")
+	}
+
+	var r0 []*domain.PlaybackProgress
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, []domain.TrackID) ([]*domain.PlaybackProgress, error)); ok {
+		return returnFunc(ctx, userID, trackIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, []domain.TrackID) []*domain.PlaybackProgress); ok {
+		r0 = returnFunc(ctx, userID, trackIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.PlaybackProgress)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserID, []domain.TrackID) error); ok {
+		r1 = returnFunc(ctx, userID, trackIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPlaybackProgressRepository_ListByUserAndTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUserAndTracks'
+type MockPlaybackProgressRepository_ListByUserAndTracks_Call struct {
+	*mock.Call
+}
+
+// ListByUserAndTracks is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - trackIDs
+func (_e *MockPlaybackProgressRepository_Expecter) ListByUserAndTracks(ctx interface{}, userID interface{}, trackIDs interface{}) *MockPlaybackProgressRepository_ListByUserAndTracks_Call {
+	return &MockPlaybackProgressRepository_ListByUserAndTracks_Call{Call: _e.mock.On("ListByUserAndTracks", ctx, userID, trackIDs)}
+}
+
+func (_c *MockPlaybackProgressRepository_ListByUserAndTracks_Call) Run(run func(ctx context.Context, userID domain.UserID, trackIDs []domain.TrackID)) *MockPlaybackProgressRepository_ListByUserAndTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].([]domain.TrackID))
+	})
+	return _c
+}
+
+func (_c *MockPlaybackProgressRepository_ListByUserAndTracks_Call) Return(playbackProgress []*domain.PlaybackProgress, err error) *MockPlaybackProgressRepository_ListByUserAndTracks_Call {
+	_c.Call.Return(playbackProgress, err)
+	return _c
+}
+
+func (_c *MockPlaybackProgressRepository_ListByUserAndTracks_Call) RunAndReturn(run func(ctx context.Context, userID domain.UserID, trackIDs []domain.TrackID) ([]*domain.PlaybackProgress, error)) *MockPlaybackProgressRepository_ListByUserAndTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -10,6 +10,7 @@ import (
 	mock "github.com/stretchr/testify/mock"
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
 	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
 )
 
 // NewMockUploadUseCase creates a new instance of MockUploadUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -214,8 +215,8 @@ func (_c *MockUploadUseCase_RequestBatchUpload_Call) RunAndReturn(run func(ctx c
 }
 
 // RequestUpload provides a mock function for the type MockUploadUseCase
-func (_mock *MockUploadUseCase) RequestUpload(ctx context.Context, userID domain.UserID, filename string, contentType string) (*port.RequestUploadResult, error) {
-	ret := _mock.Called(ctx, userID, filename, contentType)
+func (_mock *MockUploadUseCase) RequestUpload(ctx context.Context, userID domain.UserID, req port.RequestUploadInput) (*port.RequestUploadResult, error) {
+	ret := _mock.Called(ctx, userID, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RequestUpload")
@@ -223,18 +224,18 @@ func (_mock *MockUploadUseCase) RequestUpload(ctx context.Context, userID domain
 
 	var r0 *port.RequestUploadResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, string, string) (*port.RequestUploadResult, error)); ok {
-		return returnFunc(ctx, userID, filename, contentType)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, port.RequestUploadInput) (*port.RequestUploadResult, error)); ok {
+		return returnFunc(ctx, userID, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, string, string) *port.RequestUploadResult); ok {
-		r0 = returnFunc(ctx, userID, filename, contentType)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, port.RequestUploadInput) *port.RequestUploadResult); ok {
+		r0 = returnFunc(ctx, userID, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*port.RequestUploadResult)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserID, string, string) error); ok {
-		r1 = returnFunc(ctx, userID, filename, contentType)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserID, port.RequestUploadInput) error); ok {
+		r1 = returnFunc(ctx, userID, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -249,15 +250,14 @@ type MockUploadUseCase_RequestUpload_Call struct {
 // RequestUpload is a helper method to define mock.On call
 //   - ctx
 //   - userID
-//   - filename
-//   - contentType
-func (_e *MockUploadUseCase_Expecter) RequestUpload(ctx interface{}, userID interface{}, filename interface{}, contentType interface{}) *MockUploadUseCase_RequestUpload_Call {
-	return &MockUploadUseCase_RequestUpload_Call{Call: _e.mock.On("RequestUpload", ctx, userID, filename, contentType)}
+//   - req
+func (_e *MockUploadUseCase_Expecter) RequestUpload(ctx interface{}, userID interface{}, req interface{}) *MockUploadUseCase_RequestUpload_Call {
+	return &MockUploadUseCase_RequestUpload_Call{Call: _e.mock.On("RequestUpload", ctx, userID, req)}
 }
 
-func (_c *MockUploadUseCase_RequestUpload_Call) Run(run func(ctx context.Context, userID domain.UserID, filename string, contentType string)) *MockUploadUseCase_RequestUpload_Call {
+func (_c *MockUploadUseCase_RequestUpload_Call) Run(run func(ctx context.Context, userID domain.UserID, req port.RequestUploadInput)) *MockUploadUseCase_RequestUpload_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(port.RequestUploadInput))
 	})
 	return _c
 }
@@ -267,7 +267,134 @@ func (_c *MockUploadUseCase_RequestUpload_Call) Return(requestUploadResult *port
 	return _c
 }
 
-func (_c *MockUploadUseCase_RequestUpload_Call) RunAndReturn(run func(ctx context.Context, userID domain.UserID, filename string, contentType string) (*port.RequestUploadResult, error)) *MockUploadUseCase_RequestUpload_Call {
+func (_c *MockUploadUseCase_RequestUpload_Call) RunAndReturn(run func(ctx context.Context, userID domain.UserID, req port.RequestUploadInput) (*port.RequestUploadResult, error)) *MockUploadUseCase_RequestUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDuplicates provides a mock function for the type MockUploadUseCase
+func (_mock *MockUploadUseCase) ListDuplicates(ctx context.Context, input port.ListDuplicatesInput) ([]port.DuplicateTrackGroup, int, pagination.Page, error) {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDuplicates")
+	}
+
+	var r0 []port.DuplicateTrackGroup
+	var r1 int
+	var r2 pagination.Page
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.ListDuplicatesInput) ([]port.DuplicateTrackGroup, int, pagination.Page, error)); ok {
+		return returnFunc(ctx, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.ListDuplicatesInput) []port.DuplicateTrackGroup); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]port.DuplicateTrackGroup)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, port.ListDuplicatesInput) int); ok {
+		r1 = returnFunc(ctx, input)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, port.ListDuplicatesInput) pagination.Page); ok {
+		r2 = returnFunc(ctx, input)
+	} else {
+		r2 = ret.Get(2).(pagination.Page)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, port.ListDuplicatesInput) error); ok {
+		r3 = returnFunc(ctx, input)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockUploadUseCase_ListDuplicates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDuplicates'
+type MockUploadUseCase_ListDuplicates_Call struct {
+	*mock.Call
+}
+
+// ListDuplicates is a helper method to define mock.On call
+//   - ctx
+//   - input
+func (_e *MockUploadUseCase_Expecter) ListDuplicates(ctx interface{}, input interface{}) *MockUploadUseCase_ListDuplicates_Call {
+	return &MockUploadUseCase_ListDuplicates_Call{Call: _e.mock.On("ListDuplicates", ctx, input)}
+}
+
+func (_c *MockUploadUseCase_ListDuplicates_Call) Run(run func(ctx context.Context, input port.ListDuplicatesInput)) *MockUploadUseCase_ListDuplicates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(port.ListDuplicatesInput))
+	})
+	return _c
+}
+
+func (_c *MockUploadUseCase_ListDuplicates_Call) Return(duplicateTrackGroup []port.DuplicateTrackGroup, int int, page pagination.Page, err error) *MockUploadUseCase_ListDuplicates_Call {
+	_c.Call.Return(duplicateTrackGroup, int, page, err)
+	return _c
+}
+
+func (_c *MockUploadUseCase_ListDuplicates_Call) RunAndReturn(run func(ctx context.Context, input port.ListDuplicatesInput) ([]port.DuplicateTrackGroup, int, pagination.Page, error)) *MockUploadUseCase_ListDuplicates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadDirect provides a mock function for the type MockUploadUseCase
+func (_mock *MockUploadUseCase) UploadDirect(ctx context.Context, userID domain.UserID, req port.DirectUploadInput) (*domain.AudioTrack, error) {
+	ret := _mock.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadDirect")
+	}
+
+	var r0 *domain.AudioTrack
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, port.DirectUploadInput) (*domain.AudioTrack, error)); ok {
+		return returnFunc(ctx, userID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, port.DirectUploadInput) *domain.AudioTrack); ok {
+		r0 = returnFunc(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AudioTrack)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserID, port.DirectUploadInput) error); ok {
+		r1 = returnFunc(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUploadUseCase_UploadDirect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadDirect'
+type MockUploadUseCase_UploadDirect_Call struct {
+	*mock.Call
+}
+
+// UploadDirect is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - req
+func (_e *MockUploadUseCase_Expecter) UploadDirect(ctx interface{}, userID interface{}, req interface{}) *MockUploadUseCase_UploadDirect_Call {
+	return &MockUploadUseCase_UploadDirect_Call{Call: _e.mock.On("UploadDirect", ctx, userID, req)}
+}
+
+func (_c *MockUploadUseCase_UploadDirect_Call) Run(run func(ctx context.Context, userID domain.UserID, req port.DirectUploadInput)) *MockUploadUseCase_UploadDirect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(port.DirectUploadInput))
+	})
+	return _c
+}
+
+func (_c *MockUploadUseCase_UploadDirect_Call) Return(audioTrack *domain.AudioTrack, err error) *MockUploadUseCase_UploadDirect_Call {
+	_c.Call.Return(audioTrack, err)
+	return _c
+}
+
+func (_c *MockUploadUseCase_UploadDirect_Call) RunAndReturn(run func(ctx context.Context, userID domain.UserID, req port.DirectUploadInput) (*domain.AudioTrack, error)) *MockUploadUseCase_UploadDirect_Call {
 	_c.Call.Return(run)
 	return _c
 }
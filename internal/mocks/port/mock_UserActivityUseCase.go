@@ -391,3 +391,351 @@ func (_c *MockUserActivityUseCase_RecordPlaybackProgress_Call) RunAndReturn(run
 	_c.Call.Return(run)
 	return _c
 }
+
+// CreateLoop provides a mock function for the type MockUserActivityUseCase
+func (_mock *MockUserActivityUseCase) CreateLoop(ctx context.Context, userID domain.UserID, trackID domain.TrackID, input port.LoopInput) (*domain.Loop, error) {
+	ret := _mock.Called(ctx, userID, trackID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLoop")
+	}
+
+	var r0 *domain.Loop
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.TrackID, port.LoopInput) (*domain.Loop, error)); ok {
+		return returnFunc(ctx, userID, trackID, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.TrackID, port.LoopInput) *domain.Loop); ok {
+		r0 = returnFunc(ctx, userID, trackID, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Loop)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserID, domain.TrackID, port.LoopInput) error); ok {
+		r1 = returnFunc(ctx, userID, trackID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserActivityUseCase_CreateLoop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLoop'
+type MockUserActivityUseCase_CreateLoop_Call struct {
+	*mock.Call
+}
+
+// CreateLoop is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - trackID
+//   - input
+func (_e *MockUserActivityUseCase_Expecter) CreateLoop(ctx interface{}, userID interface{}, trackID interface{}, input interface{}) *MockUserActivityUseCase_CreateLoop_Call {
+	return &MockUserActivityUseCase_CreateLoop_Call{Call: _e.mock.On("CreateLoop", ctx, userID, trackID, input)}
+}
+
+func (_c *MockUserActivityUseCase_CreateLoop_Call) Run(run func(ctx context.Context, userID domain.UserID, trackID domain.TrackID, input port.LoopInput)) *MockUserActivityUseCase_CreateLoop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(domain.TrackID), args[3].(port.LoopInput))
+	})
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_CreateLoop_Call) Return(loop *domain.Loop, err error) *MockUserActivityUseCase_CreateLoop_Call {
+	_c.Call.Return(loop, err)
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_CreateLoop_Call) RunAndReturn(run func(ctx context.Context, userID domain.UserID, trackID domain.TrackID, input port.LoopInput) (*domain.Loop, error)) *MockUserActivityUseCase_CreateLoop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoop provides a mock function for the type MockUserActivityUseCase
+func (_mock *MockUserActivityUseCase) GetLoop(ctx context.Context, userID domain.UserID, loopID domain.LoopID) (*domain.Loop, error) {
+	ret := _mock.Called(ctx, userID, loopID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoop")
+	}
+
+	var r0 *domain.Loop
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.LoopID) (*domain.Loop, error)); ok {
+		return returnFunc(ctx, userID, loopID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.LoopID) *domain.Loop); ok {
+		r0 = returnFunc(ctx, userID, loopID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Loop)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserID, domain.LoopID) error); ok {
+		r1 = returnFunc(ctx, userID, loopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserActivityUseCase_GetLoop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoop'
+type MockUserActivityUseCase_GetLoop_Call struct {
+	*mock.Call
+}
+
+// GetLoop is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - loopID
+func (_e *MockUserActivityUseCase_Expecter) GetLoop(ctx interface{}, userID interface{}, loopID interface{}) *MockUserActivityUseCase_GetLoop_Call {
+	return &MockUserActivityUseCase_GetLoop_Call{Call: _e.mock.On("GetLoop", ctx, userID, loopID)}
+}
+
+func (_c *MockUserActivityUseCase_GetLoop_Call) Run(run func(ctx context.Context, userID domain.UserID, loopID domain.LoopID)) *MockUserActivityUseCase_GetLoop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(domain.LoopID))
+	})
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_GetLoop_Call) Return(loop *domain.Loop, err error) *MockUserActivityUseCase_GetLoop_Call {
+	_c.Call.Return(loop, err)
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_GetLoop_Call) RunAndReturn(run func(ctx context.Context, userID domain.UserID, loopID domain.LoopID) (*domain.Loop, error)) *MockUserActivityUseCase_GetLoop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLoops provides a mock function for the type MockUserActivityUseCase
+func (_mock *MockUserActivityUseCase) ListLoops(ctx context.Context, params port.ListLoopsInput) ([]*domain.Loop, int, pagination.Page, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLoops")
+	}
+
+	var r0 []*domain.Loop
+	var r1 int
+	var r2 pagination.Page
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.ListLoopsInput) ([]*domain.Loop, int, pagination.Page, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.ListLoopsInput) []*domain.Loop); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Loop)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, port.ListLoopsInput) int); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, port.ListLoopsInput) pagination.Page); ok {
+		r2 = returnFunc(ctx, params)
+	} else {
+		r2 = ret.Get(2).(pagination.Page)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, port.ListLoopsInput) error); ok {
+		r3 = returnFunc(ctx, params)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockUserActivityUseCase_ListLoops_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLoops'
+type MockUserActivityUseCase_ListLoops_Call struct {
+	*mock.Call
+}
+
+// ListLoops is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockUserActivityUseCase_Expecter) ListLoops(ctx interface{}, params interface{}) *MockUserActivityUseCase_ListLoops_Call {
+	return &MockUserActivityUseCase_ListLoops_Call{Call: _e.mock.On("ListLoops", ctx, params)}
+}
+
+func (_c *MockUserActivityUseCase_ListLoops_Call) Run(run func(ctx context.Context, params port.ListLoopsInput)) *MockUserActivityUseCase_ListLoops_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(port.ListLoopsInput))
+	})
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_ListLoops_Call) Return(loop []*domain.Loop, int int, page pagination.Page, err error) *MockUserActivityUseCase_ListLoops_Call {
+	_c.Call.Return(loop, int, page, err)
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_ListLoops_Call) RunAndReturn(run func(ctx context.Context, params port.ListLoopsInput) ([]*domain.Loop, int, pagination.Page, error)) *MockUserActivityUseCase_ListLoops_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLoop provides a mock function for the type MockUserActivityUseCase
+func (_mock *MockUserActivityUseCase) UpdateLoop(ctx context.Context, userID domain.UserID, loopID domain.LoopID, input port.LoopInput) (*domain.Loop, error) {
+	ret := _mock.Called(ctx, userID, loopID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoop")
+	}
+
+	var r0 *domain.Loop
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.LoopID, port.LoopInput) (*domain.Loop, error)); ok {
+		return returnFunc(ctx, userID, loopID, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.LoopID, port.LoopInput) *domain.Loop); ok {
+		r0 = returnFunc(ctx, userID, loopID, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Loop)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserID, domain.LoopID, port.LoopInput) error); ok {
+		r1 = returnFunc(ctx, userID, loopID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserActivityUseCase_UpdateLoop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLoop'
+type MockUserActivityUseCase_UpdateLoop_Call struct {
+	*mock.Call
+}
+
+// UpdateLoop is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - loopID
+//   - input
+func (_e *MockUserActivityUseCase_Expecter) UpdateLoop(ctx interface{}, userID interface{}, loopID interface{}, input interface{}) *MockUserActivityUseCase_UpdateLoop_Call {
+	return &MockUserActivityUseCase_UpdateLoop_Call{Call: _e.mock.On("UpdateLoop", ctx, userID, loopID, input)}
+}
+
+func (_c *MockUserActivityUseCase_UpdateLoop_Call) Run(run func(ctx context.Context, userID domain.UserID, loopID domain.LoopID, input port.LoopInput)) *MockUserActivityUseCase_UpdateLoop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(domain.LoopID), args[3].(port.LoopInput))
+	})
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_UpdateLoop_Call) Return(loop *domain.Loop, err error) *MockUserActivityUseCase_UpdateLoop_Call {
+	_c.Call.Return(loop, err)
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_UpdateLoop_Call) RunAndReturn(run func(ctx context.Context, userID domain.UserID, loopID domain.LoopID, input port.LoopInput) (*domain.Loop, error)) *MockUserActivityUseCase_UpdateLoop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteLoop provides a mock function for the type MockUserActivityUseCase
+func (_mock *MockUserActivityUseCase) DeleteLoop(ctx context.Context, userID domain.UserID, loopID domain.LoopID) error {
+	ret := _mock.Called(ctx, userID, loopID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLoop")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.LoopID) error); ok {
+		r0 = returnFunc(ctx, userID, loopID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserActivityUseCase_DeleteLoop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLoop'
+type MockUserActivityUseCase_DeleteLoop_Call struct {
+	*mock.Call
+}
+
+// DeleteLoop is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - loopID
+func (_e *MockUserActivityUseCase_Expecter) DeleteLoop(ctx interface{}, userID interface{}, loopID interface{}) *MockUserActivityUseCase_DeleteLoop_Call {
+	return &MockUserActivityUseCase_DeleteLoop_Call{Call: _e.mock.On("DeleteLoop", ctx, userID, loopID)}
+}
+
+func (_c *MockUserActivityUseCase_DeleteLoop_Call) Run(run func(ctx context.Context, userID domain.UserID, loopID domain.LoopID)) *MockUserActivityUseCase_DeleteLoop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(domain.LoopID))
+	})
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_DeleteLoop_Call) Return(err error) *MockUserActivityUseCase_DeleteLoop_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_DeleteLoop_Call) RunAndReturn(run func(ctx context.Context, userID domain.UserID, loopID domain.LoopID) error) *MockUserActivityUseCase_DeleteLoop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoopLink provides a mock function for the type MockUserActivityUseCase
+func (_mock *MockUserActivityUseCase) LoopLink(ctx context.Context, userID domain.UserID, loopID domain.LoopID) (string, error) {
+	ret := _mock.Called(ctx, userID, loopID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoopLink")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.LoopID) (string, error)); ok {
+		return returnFunc(ctx, userID, loopID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.LoopID) string); ok {
+		r0 = returnFunc(ctx, userID, loopID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserID, domain.LoopID) error); ok {
+		r1 = returnFunc(ctx, userID, loopID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserActivityUseCase_LoopLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoopLink'
+type MockUserActivityUseCase_LoopLink_Call struct {
+	*mock.Call
+}
+
+// LoopLink is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - loopID
+func (_e *MockUserActivityUseCase_Expecter) LoopLink(ctx interface{}, userID interface{}, loopID interface{}) *MockUserActivityUseCase_LoopLink_Call {
+	return &MockUserActivityUseCase_LoopLink_Call{Call: _e.mock.On("LoopLink", ctx, userID, loopID)}
+}
+
+func (_c *MockUserActivityUseCase_LoopLink_Call) Run(run func(ctx context.Context, userID domain.UserID, loopID domain.LoopID)) *MockUserActivityUseCase_LoopLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(domain.LoopID))
+	})
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_LoopLink_Call) Return(string string, err error) *MockUserActivityUseCase_LoopLink_Call {
+	_c.Call.Return(string, err)
+	return _c
+}
+
+func (_c *MockUserActivityUseCase_LoopLink_Call) RunAndReturn(run func(ctx context.Context, userID domain.UserID, loopID domain.LoopID) (string, error)) *MockUserActivityUseCase_LoopLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
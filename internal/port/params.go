@@ -4,10 +4,12 @@
 package port
 
 import (
+	"io"
 	"time"
 
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
 	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+	"github.com/yvanyang/language-learning-player-api/pkg/podcast"
 )
 
 // === Use Case Layer Input/Result Structs ===
@@ -34,6 +36,29 @@ type ListUserCollectionsParams struct {
 	Page          pagination.Page
 }
 
+// InviteCollaboratorInput defines the parameters for inviting a user to a collection.
+// Exactly one of InviteeID and Email is set.
+type InviteCollaboratorInput struct {
+	InviterID    domain.UserID
+	CollectionID domain.CollectionID
+	InviteeID    *domain.UserID
+	Email        string
+	Role         domain.CollectionRole // EDITOR or VIEWER
+}
+
+// ListCollectionsInput defines parameters for browsing public collections at the use case layer.
+// The track filters match collections holding a public track that satisfies all of them.
+type ListCollectionsInput struct {
+	Query         *string            // Search in title and description
+	LanguageCode  *string            // Filter by track language code
+	Level         *domain.AudioLevel // Filter by track level
+	OwnerID       *domain.UserID     // Filter by owner
+	Tags          []string           // Filter by track tags
+	SortBy        string             // e.g., "createdAt", "title", "updatedAt"
+	SortDirection string             // "asc" or "desc"
+	Page          pagination.Page
+}
+
 // ListProgressInput defines parameters for listing user progress at the use case layer.
 type ListProgressInput struct {
 	UserID domain.UserID
@@ -47,10 +72,36 @@ type ListBookmarksInput struct {
 	Page          pagination.Page
 }
 
-// RequestUploadResult holds the result of requesting an upload URL.
+// ListLoopsInput defines parameters for listing user loops at the use case layer.
+type ListLoopsInput struct {
+	UserID        domain.UserID
+	TrackIDFilter *domain.TrackID // Optional filter by track; returns all of the track's loops
+	Page          pagination.Page
+}
+
+// LoopInput holds the region and playback settings of a loop to create or update.
+type LoopInput struct {
+	Start         time.Duration
+	End           time.Duration
+	Label         string
+	RepeatCount   int     // 0 repeats until stopped
+	PlaybackSpeed float64 // 0 means normal speed
+}
+
+// RequestUploadInput holds the data needed to request an upload URL.
+type RequestUploadInput struct {
+	Filename      string
+	ContentType   string
+	ContentSHA256 string // Optional hex SHA-256 declared by the client, used to find existing content
+}
+
+// RequestUploadResult holds the result of requesting an upload URL. If ExistingTrack is set,
+// a track accessible to the user already has the declared content: UploadURL is empty and
+// ObjectKey is that track's object key.
 type RequestUploadResult struct {
-	UploadURL string
-	ObjectKey string
+	UploadURL     string
+	ObjectKey     string
+	ExistingTrack *domain.AudioTrack
 }
 
 // CompleteUploadInput holds the data needed to finalize an upload and create a track record.
@@ -66,10 +117,29 @@ type CompleteUploadInput struct {
 	CoverImageURL *string
 }
 
+// DirectUploadInput holds a file streamed through the API and the metadata of its track.
+type DirectUploadInput struct {
+	File        io.Reader
+	Size        int64 // -1 if unknown
+	Filename    string
+	ContentType string              // As declared by the client; derived from the file name if generic
+	Track       CompleteUploadInput // ObjectKey is ignored; the key is generated
+}
+
+// CreateResumableUploadInput starts a resumable (tus) upload of an audio file.
+type CreateResumableUploadInput struct {
+	Length      int64 // Total size in bytes
+	Filename    string
+	ContentType string              // As declared by the client; derived from the file name if generic
+	Metadata    string              // Upload-Metadata header, returned unchanged when the upload is queried
+	Track       CompleteUploadInput // ObjectKey is ignored; the key is generated once the upload is complete
+}
+
 // --- Batch Request Input ---
 type BatchRequestUploadInputItem struct {
-	Filename    string
-	ContentType string
+	Filename      string
+	ContentType   string
+	ContentSHA256 string // Optional, see RequestUploadInput
 }
 type BatchRequestUploadInput struct {
 	Files []BatchRequestUploadInputItem
@@ -80,6 +150,7 @@ type BatchURLResultItem struct {
 	OriginalFilename string
 	ObjectKey        string
 	UploadURL        string
+	ExistingTrackID  string // Set instead of UploadURL when matching content already exists
 	// Using string for Error is simpler for JSON marshalling in batch results,
 	// though less type-safe internally. Acknowledge this trade-off.
 	Error string
@@ -119,4 +190,181 @@ type GetAudioTrackDetailsResult struct {
 	PlayURL       string
 	UserProgress  *domain.PlaybackProgress // Nil if user not logged in or no progress
 	UserBookmarks []*domain.Bookmark       // Empty slice if user not logged in or no bookmarks
+	UserLoops     []*domain.Loop           // Empty slice if user not logged in or no loops
+	Chapters      []domain.TrackChapter    // Empty slice if the track has no chapters
+}
+
+// === Playlist Import Result (Used by AudioContentUseCase) ===
+
+// PlaylistImportResult is the playlist created by ImportPlaylist and the entries that could not be added.
+type PlaylistImportResult struct {
+	Collection *domain.AudioCollection
+	Unresolved []UnresolvedPlaylistEntry
+}
+
+// UnresolvedPlaylistEntry describes a playlist entry that matched no usable track.
+type UnresolvedPlaylistEntry struct {
+	Index    int // 0-based position in the imported playlist
+	Title    string
+	Location string
+	Reason   string
+}
+
+// === Podcast Feed Params (Used by FeedUseCase) ===
+
+// CollectionFeed is the podcast feed of a collection.
+type CollectionFeed struct {
+	Feed *podcast.Feed
+	// LastModified is the later of the collection's updated_at and the start of the window its
+	// stream links were issued in, for conditional requests. It is zero for smart collections,
+	// whose tracks change without the collection being updated.
+	LastModified time.Time
+}
+
+// FeedTokenResult is a newly issued secret feed URL. Only a hash is stored, so the URL cannot be retrieved again.
+type FeedTokenResult struct {
+	Token   *domain.FeedToken
+	FeedURL string
+}
+
+// StreamLinkInput is a signed stream link taken from a podcast feed.
+type StreamLinkInput struct {
+	TrackID     domain.TrackID
+	FeedTokenID *domain.FeedTokenID // Set for links from a secret feed
+	Expires     time.Time
+	Signature   string
+}
+
+// === Feed Ingestion Params (Used by FeedIngestUseCase) ===
+
+// FeedIngestOptions provides the values of ingested tracks that feeds do not set.
+type FeedIngestOptions struct {
+	LanguageCode string // Used for feeds that declare no language
+	Level        string
+	IsPublic     bool
+	Tags         []string
+}
+
+// SubscribeFeedInput subscribes to an external podcast feed.
+type SubscribeFeedInput struct {
+	FeedURL string
+	OwnerID *domain.UserID // Owner of the ingested content; defaults to the requesting admin
+	Options FeedIngestOptions
+}
+
+// ImportOPMLInput subscribes to every feed of an OPML document.
+type ImportOPMLInput struct {
+	Document io.Reader
+	OwnerID  *domain.UserID // Owner of the ingested content; defaults to the requesting admin
+	Options  FeedIngestOptions
+}
+
+// SkippedFeed is a feed of an OPML document that was not subscribed to.
+type SkippedFeed struct {
+	FeedURL string
+	Reason  string
+}
+
+// OPMLImportResult reports the subscriptions created from an OPML document.
+type OPMLImportResult struct {
+	Subscribed []*domain.FeedSubscription
+	Skipped    []SkippedFeed
+}
+
+// === Audit Log Params (Used by AuditUseCase) ===
+
+// ListAuditEventsInput defines parameters for querying the audit log.
+type ListAuditEventsInput struct {
+	Filter AuditEventFilter
+	Page   pagination.Page
+}
+
+// === Webhook Params (Used by WebhookUseCase) ===
+
+// CreateWebhookInput holds the data for registering a webhook endpoint.
+type CreateWebhookInput struct {
+	URL        string
+	EventTypes []domain.EventType
+}
+
+// UpdateWebhookInput holds a partial update; nil/empty fields are left unchanged.
+type UpdateWebhookInput struct {
+	URL        *string
+	EventTypes []domain.EventType
+	Active     *bool
+}
+
+// ListWebhookDeliveriesInput defines parameters for querying a webhook's delivery log.
+type ListWebhookDeliveriesInput struct {
+	WebhookID domain.WebhookID
+	Filter    WebhookDeliveryFilter
+	Page      pagination.Page
+}
+
+// === Import Params (Used by ImportUseCase) ===
+
+// ImportOptions provides defaults for values that neither the manifest nor the file tags set.
+type ImportOptions struct {
+	LanguageCode string
+	Level        string
+	IsPublic     bool
+	Tags         []string
+	RootTitle    string // Title of the course for files at the top level of the library
+}
+
+// StartImportInput holds an uploaded archive to import.
+type StartImportInput struct {
+	Archive  io.Reader
+	Size     int64          // -1 if unknown
+	Filename string         // Original file name, recorded as the run's source
+	OwnerID  *domain.UserID // Owner of the imported content; defaults to the requesting admin
+	Options  ImportOptions
+}
+
+// ListDuplicatesInput defines parameters for listing possible duplicate uploads.
+type ListDuplicatesInput struct {
+	UserID       domain.UserID
+	AllUploaders bool // Admin only: include every uploader's tracks
+	Page         pagination.Page
+}
+
+// === Course Params (Used by CourseUseCase) ===
+
+// CourseLessonInput describes one lesson of a course outline. A nil ID creates a new lesson.
+type CourseLessonInput struct {
+	ID          *domain.LessonID
+	TrackID     domain.TrackID
+	Title       string
+	Notes       string
+	Attachments []domain.LessonAttachment
+}
+
+// CourseSectionInput describes one section of a course outline. A nil ID creates a new section.
+type CourseSectionInput struct {
+	ID          *domain.CourseSectionID
+	Title       string
+	Description string
+	Lessons     []CourseLessonInput
+}
+
+// UpdateCourseOutlineInput replaces the sections and lessons of a course.
+type UpdateCourseOutlineInput struct {
+	UserID          domain.UserID
+	CollectionID    domain.CollectionID
+	Sections        []CourseSectionInput
+	ExpectedVersion int // 0 = unconditional
+}
+
+// ListCoursesInput defines parameters for browsing published courses.
+type ListCoursesInput struct {
+	Filter CourseFilter
+	Page   pagination.Page
+}
+
+// CourseDetails is a course with its outline, as seen by one viewer.
+type CourseDetails struct {
+	Course     *domain.AudioCollection
+	Sections   []domain.CourseSection
+	Tracks     map[domain.TrackID]*domain.AudioTrack // Lesson tracks, for titles and durations
+	Enrollment *domain.Enrollment                    // The viewer's enrollment, if any
 }
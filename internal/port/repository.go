@@ -47,21 +47,62 @@ type ListTracksFilters struct {
 	IsPublic      *bool              // Filter by public status
 	UploaderID    *domain.UserID     // Filter by uploader
 	Tags          []string           // Filter by tags (match any)
+	MinDuration   *time.Duration     // Filter by minimum duration (inclusive)
+	MaxDuration   *time.Duration     // Filter by maximum duration (inclusive)
+	VisibleTo     *domain.UserID     // Only public tracks and tracks uploaded by this user
+	ListenedBy    *domain.UserID     // With Listened, filter by whether this user finished the track
+	Listened      *bool              // true = finished by ListenedBy (see domain.CompletionThreshold), false = not finished
 	SortBy        string             // e.g., "createdAt", "title", "durationMs" (DB column name might differ)
 	SortDirection string             // "asc" or "desc"
 }
 
+// ListCollectionsFilters defines parameters for browsing public collections at the repository layer.
+// The track filters match collections holding at least one public track that satisfies all of them.
+type ListCollectionsFilters struct {
+	Query         *string            // Search in title and description
+	LanguageCode  *string            // Filter by track language code
+	Level         *domain.AudioLevel // Filter by track level
+	OwnerID       *domain.UserID     // Filter by owner
+	Tags          []string           // Filter by track tags (match all)
+	SortBy        string             // "createdAt", "updatedAt" or "title"
+	SortDirection string             // "asc" or "desc"
+}
+
+// UserCollection is a collection together with a user's role in it.
+type UserCollection struct {
+	Collection *domain.AudioCollection
+	Role       domain.CollectionRole
+}
+
+// DuplicateTracksFilter narrows a duplicate track query.
+type DuplicateTracksFilter struct {
+	ViewerID *domain.UserID // If set, only groups with a track of this user, limited to their own and public tracks
+}
+
+// DuplicateTrackGroup is a set of tracks whose audio objects have the same checksum.
+type DuplicateTrackGroup struct {
+	ContentSHA256 string
+	SizeBytes     int64
+	Tracks        []*domain.AudioTrack // Oldest first
+}
+
 // AudioTrackRepository defines the persistence operations for AudioTrack entities.
 type AudioTrackRepository interface {
 	FindByID(ctx context.Context, id domain.TrackID) (*domain.AudioTrack, error)
+	FindByObjectKey(ctx context.Context, objectKey string) (*domain.AudioTrack, error)
 	ListByIDs(ctx context.Context, ids []domain.TrackID) ([]*domain.AudioTrack, error)
 	// List retrieves a paginated list of tracks based on filter and sort parameters.
 	// RENAMED params type to ListTracksFilters
 	List(ctx context.Context, filters ListTracksFilters, page pagination.Page) (tracks []*domain.AudioTrack, total int, err error)
 	Create(ctx context.Context, track *domain.AudioTrack) error
+	// Update treats track.Version as the expected version (0 = unconditional) and sets it to the new version.
 	Update(ctx context.Context, track *domain.AudioTrack) error
 	Delete(ctx context.Context, id domain.TrackID) error
 	Exists(ctx context.Context, id domain.TrackID) (bool, error)
+	// SetContentChecksum records the checksum and size of the audio object without changing the version.
+	SetContentChecksum(ctx context.Context, id domain.TrackID, contentSHA256 string, sizeBytes int64) error
+	ListByContentHash(ctx context.Context, contentSHA256 string) ([]*domain.AudioTrack, error)
+	ListDuplicates(ctx context.Context, filter DuplicateTracksFilter, page pagination.Page) (groups []DuplicateTrackGroup, total int, err error)
 }
 
 // AudioCollectionRepository defines the persistence operations for AudioCollection entities.
@@ -69,10 +110,29 @@ type AudioCollectionRepository interface {
 	FindByID(ctx context.Context, id domain.CollectionID) (*domain.AudioCollection, error)
 	FindWithTracks(ctx context.Context, id domain.CollectionID) (*domain.AudioCollection, error)
 	ListByOwner(ctx context.Context, ownerID domain.UserID, page pagination.Page) (collections []*domain.AudioCollection, total int, err error)
+	// ListAccessible returns the collections the user owns or is a member of, with the user's role in each.
+	ListAccessible(ctx context.Context, userID domain.UserID, sortBy, sortDirection string, page pagination.Page) (collections []UserCollection, total int, err error)
+	// HasAccessibleWithTrack reports whether a collection the user owns or is a member of contains the track.
+	// Only tracks that are public or were uploaded by the collection's owner or one of its editors count,
+	// so a track added to a collection by someone who could not read it grants no access.
+	HasAccessibleWithTrack(ctx context.Context, userID domain.UserID, trackID domain.TrackID) (bool, error)
+	// ListPublic returns collections with PUBLIC visibility.
+	ListPublic(ctx context.Context, filters ListCollectionsFilters, page pagination.Page) (collections []*domain.AudioCollection, total int, err error)
 	Create(ctx context.Context, collection *domain.AudioCollection) error
+	// UpdateMetadata sets title, description and visibility. It treats collection.Version as the expected version (0 = unconditional) and sets it to the new version.
 	UpdateMetadata(ctx context.Context, collection *domain.AudioCollection) error
-	ManageTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID) error
+	// ManageTracks replaces the ordered track list if the collection is at expectedVersion (0 = unconditional) and returns the new version.
+	ManageTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID, expectedVersion int) (newVersion int, err error)
+	// InsertTrack, MoveTrack and RemoveTrack change a single track, renumbering the others, if the collection
+	// is at expectedVersion (0 = unconditional) and return the new version. Out-of-bounds positions mean the end.
+	InsertTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (newVersion int, err error)
+	MoveTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (newVersion int, err error)
+	RemoveTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, expectedVersion int) (newVersion int, err error)
+	// UpdateSmartRule replaces the rule of a SMART collection if it is at expectedVersion (0 = unconditional) and returns the new version.
+	UpdateSmartRule(ctx context.Context, collectionID domain.CollectionID, rule domain.SmartRule, expectedVersion int) (newVersion int, err error)
 	Delete(ctx context.Context, id domain.CollectionID) error
+	// TransferOwnership makes newOwnerID the owner if the collection is at expectedVersion (0 = unconditional) and returns the new version.
+	TransferOwnership(ctx context.Context, collectionID domain.CollectionID, newOwnerID domain.UserID, expectedVersion int) (newVersion int, err error)
 }
 
 // PlaybackProgressRepository defines the persistence operations for PlaybackProgress entities.
@@ -80,6 +140,8 @@ type PlaybackProgressRepository interface {
 	Find(ctx context.Context, userID domain.UserID, trackID domain.TrackID) (*domain.PlaybackProgress, error)
 	Upsert(ctx context.Context, progress *domain.PlaybackProgress) error
 	ListByUser(ctx context.Context, userID domain.UserID, page pagination.Page) (progressList []*domain.PlaybackProgress, total int, err error)
+	// ListByUserAndTracks returns the user's progress on the given tracks; unplayed tracks are omitted.
+	ListByUserAndTracks(ctx context.Context, userID domain.UserID, trackIDs []domain.TrackID) ([]*domain.PlaybackProgress, error)
 }
 
 // BookmarkRepository defines the persistence operations for Bookmark entities.
@@ -91,6 +153,274 @@ type BookmarkRepository interface {
 	Delete(ctx context.Context, id domain.BookmarkID) error
 }
 
+// LoopRepository defines the persistence operations for A-B loop regions.
+type LoopRepository interface {
+	FindByID(ctx context.Context, id domain.LoopID) (*domain.Loop, error)
+	// ListByUserAndTrack returns all loops of a user on a track, ordered by start.
+	ListByUserAndTrack(ctx context.Context, userID domain.UserID, trackID domain.TrackID) ([]*domain.Loop, error)
+	// ListByUser returns a page of the user's loops, most recently created first.
+	ListByUser(ctx context.Context, userID domain.UserID, page pagination.Page) (loops []*domain.Loop, total int, err error)
+	Create(ctx context.Context, loop *domain.Loop) error
+	// Update stores the region and playback settings of a loop.
+	Update(ctx context.Context, loop *domain.Loop) error
+	Delete(ctx context.Context, id domain.LoopID) error
+}
+
+// AuditEventFilter narrows an audit event query. Nil/empty fields are ignored.
+type AuditEventFilter struct {
+	ActorID    *domain.UserID
+	Action     *domain.AuditAction
+	TargetType *domain.AuditTargetType
+	TargetID   *string
+	From       *time.Time // Inclusive lower bound on OccurredAt
+	To         *time.Time // Exclusive upper bound on OccurredAt
+}
+
+// AuditEventRepository persists the append-only audit log.
+// Append participates in the caller's transaction when called inside TransactionManager.Execute.
+type AuditEventRepository interface {
+	Append(ctx context.Context, event *domain.AuditEvent) error
+	// List returns matching events, newest first.
+	List(ctx context.Context, filter AuditEventFilter, page pagination.Page) (events []*domain.AuditEvent, total int, err error)
+}
+
+// IdempotencyRecord is the stored state of a request sent with an Idempotency-Key.
+type IdempotencyRecord struct {
+	UserID      domain.UserID
+	Key         string
+	RequestHash string // Fingerprint of the original request
+	Completed   bool   // False while the original request is still running
+	StatusCode  int
+	ContentType string
+	Headers     map[string]string // Other response headers to replay, e.g. ETag and Location
+	Body        []byte
+	ExpiresAt   time.Time
+	// Lease is how long an in-progress record blocks retries. Past it the original request is
+	// presumed lost (e.g., the process crashed) and a retry may claim the key again.
+	Lease time.Duration
+	// ClaimedAt is set by a successful Claim and identifies that claim, so that a request whose
+	// key was taken over cannot complete or release the new claim.
+	ClaimedAt time.Time
+}
+
+// IdempotencyRepository stores Idempotency-Key records.
+type IdempotencyRepository interface {
+	// Claim inserts record as in-progress unless an unexpired record with the same
+	// user and key exists; an in-progress record for the same request claimed more than
+	// record.Lease ago is taken over. If claimed is true, record.ClaimedAt identifies the claim; if false, existing
+	// holds the stored record.
+	Claim(ctx context.Context, record *IdempotencyRecord) (existing *IdempotencyRecord, claimed bool, err error)
+	// Complete stores the final response for the claim identified by record.ClaimedAt. It returns
+	// domain.ErrNotFound if that claim no longer holds the key.
+	Complete(ctx context.Context, record *IdempotencyRecord) error
+	// Release removes the claim identified by record.ClaimedAt so the request can be retried
+	// (e.g., after a server error). A key that was taken over in the meantime is left alone.
+	Release(ctx context.Context, record *IdempotencyRecord) error
+	// DeleteExpired removes records past their expiry and returns how many were removed.
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// OutboxRepository stores domain events for asynchronous delivery (transactional outbox).
+// Append participates in the caller's transaction when called inside TransactionManager.Execute.
+type OutboxRepository interface {
+	Append(ctx context.Context, event *domain.OutboxEvent) error
+}
+
+// WebhookSubscriptionRepository manages webhook endpoint registrations.
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, sub *domain.WebhookSubscription) error
+	FindByID(ctx context.Context, id domain.WebhookID) (*domain.WebhookSubscription, error)
+	List(ctx context.Context, page pagination.Page) (subs []*domain.WebhookSubscription, total int, err error)
+	Update(ctx context.Context, sub *domain.WebhookSubscription) error
+	Delete(ctx context.Context, id domain.WebhookID) error
+}
+
+// PendingWebhookDelivery is a claimed delivery together with what is needed to send it.
+type PendingWebhookDelivery struct {
+	Delivery *domain.WebhookDelivery
+	URL      string
+	Secret   string
+	Event    *domain.OutboxEvent
+}
+
+// WebhookDeliveryFilter defines parameters for filtering the delivery log.
+type WebhookDeliveryFilter struct {
+	Status *domain.DeliveryStatus
+}
+
+// WebhookDeliveryRepository manages the delivery queue and log for webhooks.
+type WebhookDeliveryRepository interface {
+	// FanOut moves up to limit undispatched outbox events into the delivery queue,
+	// creating one pending delivery per active subscription for the event type.
+	// It returns the number of events dispatched.
+	FanOut(ctx context.Context, limit int) (int, error)
+	// ClaimDue returns up to limit pending deliveries that are due and leases them
+	// for the given duration so that concurrent dispatchers skip them.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*PendingWebhookDelivery, error)
+	// SaveAttempt stores the outcome of a delivery attempt (status, attempts, schedule).
+	SaveAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error
+	FindByID(ctx context.Context, id domain.WebhookDeliveryID) (*domain.WebhookDelivery, error)
+	// ListBySubscription returns a subscription's deliveries, newest first.
+	ListBySubscription(ctx context.Context, subID domain.WebhookID, filter WebhookDeliveryFilter, page pagination.Page) (deliveries []*domain.WebhookDelivery, total int, err error)
+}
+
+// JobRepository stores the background job queue.
+type JobRepository interface {
+	// Enqueue inserts a queued job. Inside TransactionManager.Execute it is written in the
+	// caller's transaction. Returns domain.ErrConflict if a job with the same UniqueKey exists.
+	Enqueue(ctx context.Context, job *domain.Job) error
+	// Claim marks up to limit due jobs as running until now+visibility and returns them.
+	// Running jobs whose visibility timeout expired are claimable again.
+	Claim(ctx context.Context, limit int, visibility time.Duration) ([]*domain.Job, error)
+	// Save stores the outcome of an attempt (status, schedule, error). Returns
+	// domain.ErrConflict if the job was claimed again after its visibility timeout.
+	Save(ctx context.Context, job *domain.Job) error
+	// DeleteFinished removes succeeded and dead jobs completed before the given time.
+	DeleteFinished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ImportRunRepository stores the state of archive imports.
+type ImportRunRepository interface {
+	Create(ctx context.Context, run *domain.ImportRun) error
+	FindByID(ctx context.Context, id domain.ImportRunID) (*domain.ImportRun, error)
+	// Update stores the status, report and error of a run.
+	Update(ctx context.Context, run *domain.ImportRun) error
+}
+
+// CourseFilter narrows a listing of published courses.
+type CourseFilter struct {
+	Query   *string // Case-insensitive match on title or description
+	OwnerID *domain.UserID
+}
+
+// CourseSummary is a published course with the counts shown when browsing courses.
+type CourseSummary struct {
+	Course          *domain.AudioCollection
+	LessonCount     int
+	EnrollmentCount int
+}
+
+// EnrolledCourse is a course a learner is enrolled in. Progress is filled in by the use case.
+type EnrolledCourse struct {
+	Enrollment *domain.Enrollment
+	Course     *domain.AudioCollection
+	Progress   *domain.CourseProgress
+}
+
+// CourseRepository stores the outline, publication and enrollments of courses
+// (audio collections of type COURSE).
+type CourseRepository interface {
+	// GetOutline returns the ordered sections of a course with their ordered lessons.
+	GetOutline(ctx context.Context, collectionID domain.CollectionID) ([]domain.CourseSection, error)
+	// ReplaceOutline replaces all sections and lessons of a course. It does not change the
+	// collection's track list or version; use AudioCollectionRepository.ManageTracks in the same transaction.
+	ReplaceOutline(ctx context.Context, collectionID domain.CollectionID, sections []domain.CourseSection) error
+	// SetPublished sets (or clears, if nil) the publication time if the collection is at
+	// expectedVersion (0 = unconditional) and returns the new version.
+	SetPublished(ctx context.Context, collectionID domain.CollectionID, publishedAt *time.Time, expectedVersion int) (newVersion int, err error)
+	// ListPublished returns published courses, most recently published first.
+	ListPublished(ctx context.Context, filter CourseFilter, page pagination.Page) (courses []CourseSummary, total int, err error)
+	// Enroll records an enrollment. Enrolling again keeps the original one and returns created = false.
+	Enroll(ctx context.Context, enrollment *domain.Enrollment) (created bool, err error)
+	Unenroll(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) error
+	FindEnrollment(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) (*domain.Enrollment, error)
+	// ListEnrollments returns the courses the user is enrolled in, most recent enrollment first.
+	ListEnrollments(ctx context.Context, userID domain.UserID, page pagination.Page) (courses []EnrolledCourse, total int, err error)
+}
+
+// CollectionMemberRepository stores the members of shared collections and the invitations to join them.
+type CollectionMemberRepository interface {
+	FindMember(ctx context.Context, collectionID domain.CollectionID, userID domain.UserID) (*domain.CollectionMember, error)
+	// ListMembers returns the members of a collection, oldest first. The owner is not included.
+	ListMembers(ctx context.Context, collectionID domain.CollectionID) ([]*domain.CollectionMember, error)
+	// SaveMember adds a member or changes the role of an existing one.
+	SaveMember(ctx context.Context, member *domain.CollectionMember) error
+	RemoveMember(ctx context.Context, collectionID domain.CollectionID, userID domain.UserID) error
+	// CreateInvitation stores a new invitation. A pending invitation for the same invitee returns domain.ErrConflict.
+	CreateInvitation(ctx context.Context, invitation *domain.CollectionInvitation) error
+	FindInvitation(ctx context.Context, id domain.InvitationID) (*domain.CollectionInvitation, error)
+	// UpdateInvitation stores the status, invitee and response time of an invitation.
+	UpdateInvitation(ctx context.Context, invitation *domain.CollectionInvitation) error
+	// ListPendingInvitations returns the pending invitations of a collection, newest first.
+	ListPendingInvitations(ctx context.Context, collectionID domain.CollectionID) ([]*domain.CollectionInvitation, error)
+	// ListPendingInvitationsFor returns the pending invitations addressed to a user by ID or by email address, newest first.
+	ListPendingInvitationsFor(ctx context.Context, userID domain.UserID, email string) ([]*domain.CollectionInvitation, error)
+}
+
+// FeedTokenRepository stores the secret podcast feed tokens of collections.
+type FeedTokenRepository interface {
+	// Save stores a token, replacing any existing token of the same user for the collection.
+	Save(ctx context.Context, token *domain.FeedToken) error
+	FindByID(ctx context.Context, id domain.FeedTokenID) (*domain.FeedToken, error)
+	FindByHash(ctx context.Context, tokenHash string) (*domain.FeedToken, error)
+	FindByCollectionAndUser(ctx context.Context, collectionID domain.CollectionID, userID domain.UserID) (*domain.FeedToken, error)
+	// Delete removes the user's token for the collection; domain.ErrNotFound if there is none.
+	Delete(ctx context.Context, collectionID domain.CollectionID, userID domain.UserID) error
+}
+
+// FeedSubscriptionRepository stores subscriptions to external podcast feeds and the episodes
+// ingested from them.
+type FeedSubscriptionRepository interface {
+	// Create returns domain.ErrConflict if the owner is already subscribed to the feed URL.
+	Create(ctx context.Context, sub *domain.FeedSubscription) error
+	FindByID(ctx context.Context, id domain.FeedSubscriptionID) (*domain.FeedSubscription, error)
+	List(ctx context.Context, page pagination.Page) (subs []*domain.FeedSubscription, total int, err error)
+	// ListDue returns up to limit subscriptions whose NextFetchAt is not after now, longest overdue first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.FeedSubscription, error)
+	// Update stores everything but the feed URL, owner and creation time.
+	Update(ctx context.Context, sub *domain.FeedSubscription) error
+	// Delete removes a subscription and its episode records; the ingested tracks and collection remain.
+	Delete(ctx context.Context, id domain.FeedSubscriptionID) error
+	// ListEpisodes returns every episode ingested from a feed, including those whose track was deleted.
+	ListEpisodes(ctx context.Context, id domain.FeedSubscriptionID) ([]*domain.FeedEpisode, error)
+	// SaveEpisode inserts an episode or updates the one with the same subscription and GUID.
+	SaveEpisode(ctx context.Context, episode *domain.FeedEpisode) error
+}
+
+// TrackChapterRepository stores the chapters of audio tracks. Chapters are returned sorted
+// by start time.
+type TrackChapterRepository interface {
+	ListByTrack(ctx context.Context, trackID domain.TrackID) ([]domain.TrackChapter, error)
+	// ListByTracks returns the chapters of each of the tracks that has any.
+	ListByTracks(ctx context.Context, trackIDs []domain.TrackID) (map[domain.TrackID][]domain.TrackChapter, error)
+	// Replace sets the chapters of a track; an empty list removes them.
+	Replace(ctx context.Context, trackID domain.TrackID, chapters []domain.TrackChapter) error
+}
+
+// ResumableUploadRepository stores the state of tus uploads in progress.
+type ResumableUploadRepository interface {
+	Create(ctx context.Context, upload *domain.ResumableUpload) error
+	FindByID(ctx context.Context, id domain.ResumableUploadID) (*domain.ResumableUpload, error)
+	// Update stores the offset, expiry and track of an upload.
+	Update(ctx context.Context, upload *domain.ResumableUpload) error
+	Delete(ctx context.Context, id domain.ResumableUploadID) error
+	// ListExpired returns up to limit uploads that expired before the given time, oldest first.
+	ListExpired(ctx context.Context, before time.Time, limit int) ([]*domain.ResumableUpload, error)
+}
+
+// RateLimitPolicy describes a token bucket: Rate tokens per second, up to Burst.
+type RateLimitPolicy struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitResult reports the outcome of a rate limit check.
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int           // Requests left in the current burst
+	ResetAfter time.Duration // Time until the bucket is full again
+	RetryAfter time.Duration // Time until the next request would be allowed (zero if Allowed)
+}
+
+// RateLimitStore holds rate limiter bucket state. Implementations may be
+// process-local or shared between API replicas.
+type RateLimitStore interface {
+	// Allow consumes one request from the bucket identified by key.
+	Allow(ctx context.Context, key string, policy RateLimitPolicy) (RateLimitResult, error)
+	// CleanUp removes buckets that have been idle longer than maxIdle and returns how many were removed.
+	CleanUp(ctx context.Context, maxIdle time.Duration) (int, error)
+}
+
 // --- Transaction Management ---
 
 type Tx interface{}
@@ -3,6 +3,7 @@ package port
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/yvanyang/language-learning-player-api/internal/domain" // Adjust import path
@@ -27,9 +28,71 @@ type FileStorageService interface {
 	// ADDED: ObjectExists method
 	ObjectExists(ctx context.Context, bucket, objectKey string) (bool, error)
 
+	// PutObject uploads the content of reader. size may be -1 if unknown.
+	PutObject(ctx context.Context, bucket, objectKey string, reader io.Reader, size int64, contentType string) error
+
+	// GetObject opens an object for reading. Returns domain.ErrNotFound if it does not exist.
+	GetObject(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error)
+
+	// ListObjects returns every object in the bucket whose key starts with prefix.
+	ListObjects(ctx context.Context, bucket, prefix string) ([]StorageObject, error)
+
 	// TODO: Consider adding methods for getting metadata, etc. if needed.
 }
 
+// StorageObject describes an object in storage.
+type StorageObject struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// UploadStagingStore holds the bytes of resumable uploads until they are complete.
+// Uploads are identified by the string form of their domain.ResumableUploadID.
+type UploadStagingStore interface {
+	// Create prepares an empty staging file for an upload.
+	Create(ctx context.Context, id string) error
+	// Append writes the content of r at offset and returns the number of bytes written,
+	// which may be non-zero even when an error is returned. Bytes after offset from an
+	// earlier, interrupted write are discarded.
+	Append(ctx context.Context, id string, offset int64, r io.Reader) (int64, error)
+	// Open opens the staged bytes for reading. Returns domain.ErrNotFound if they do not exist.
+	Open(ctx context.Context, id string) (io.ReadCloser, error)
+	// Delete removes the staged bytes. Deleting a missing upload is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+// LinkSigner signs expiring links so that they can be verified without being stored.
+type LinkSigner interface {
+	// Sign returns a URL-safe signature of resource, valid until expires.
+	Sign(resource string, expires time.Time) string
+	// Verify reports whether signature matches resource and expires, and expires is still in the future.
+	Verify(resource string, expires time.Time, signature string) bool
+}
+
+// FeedValidators are the cache validators of a previous feed response.
+type FeedValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// FetchedFeed is the response to a feed request.
+type FetchedFeed struct {
+	NotModified bool   // The feed is unchanged since the validators were issued; Body is empty
+	Body        []byte // The feed document
+	Validators  FeedValidators
+}
+
+// FeedFetcher downloads external podcast feeds and their audio over HTTP.
+type FeedFetcher interface {
+	// FetchFeed downloads a feed, conditionally if validators are given. Responses other than
+	// 2xx and 304, and feeds larger than the configured limit, are returned as errors.
+	FetchFeed(ctx context.Context, feedURL string, validators FeedValidators) (*FetchedFeed, error)
+	// DownloadEnclosure writes the audio at enclosureURL to w and returns its media type and size.
+	// Files larger than the configured limit are returned as an error.
+	DownloadEnclosure(ctx context.Context, enclosureURL string, w io.Writer) (contentType string, size int64, err error)
+}
+
 // ExternalUserInfo contains standardized user info retrieved from an external identity provider.
 type ExternalUserInfo struct {
 	Provider        domain.AuthProvider // e.g., "google"
@@ -49,6 +112,22 @@ type ExternalAuthService interface {
 	// Add methods for other providers if needed (e.g., VerifyFacebookToken, VerifyAppleToken)
 }
 
+// WebhookRequest is a single signed webhook delivery.
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	DeliveryID string
+	EventType  domain.EventType
+	Body       []byte
+}
+
+// WebhookSender posts signed webhook payloads to subscriber endpoints.
+type WebhookSender interface {
+	// Send delivers req and returns the HTTP status code (0 if no response was received).
+	// A non-2xx response is returned as an error along with its status code.
+	Send(ctx context.Context, req WebhookRequest) (statusCode int, err error)
+}
+
 // --- Internal Helper Service Interfaces ---
 
 // SecurityHelper defines cryptographic operations needed by use cases.
@@ -67,4 +146,16 @@ type SecurityHelper interface {
 	HashRefreshTokenValue(tokenValue string) string // ADDED
 }
 
+// MetricsRecorder records business-level events for monitoring.
+type MetricsRecorder interface {
+	// UploadsCompleted records tracks created from completed uploads.
+	UploadsCompleted(count int)
+	// ProgressUpdated records a playback progress update.
+	ProgressUpdated()
+	// BookmarkCreated records a new bookmark.
+	BookmarkCreated()
+	// LoginSucceeded records a successful login for the given auth provider.
+	LoginSucceeded(provider domain.AuthProvider)
+}
+
 // REMOVED UserUseCase interface from here
@@ -5,10 +5,12 @@ package port
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
 	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+	"github.com/yvanyang/language-learning-player-api/pkg/playlist"
 )
 
 // AuthResult holds both access and refresh tokens, and registration status.
@@ -44,15 +46,83 @@ type AuthUseCase interface {
 // AudioContentUseCase defines the methods for the Audio Content use case layer.
 type AudioContentUseCase interface {
 	GetAudioTrackDetails(ctx context.Context, trackID domain.TrackID) (*GetAudioTrackDetailsResult, error)
+	// GetTrackChapters returns the chapters of a track to anyone who may see its details.
+	// UpdateTrackChapters replaces them (see domain.NewTrackChapters) and is limited to the uploader.
+	GetTrackChapters(ctx context.Context, trackID domain.TrackID) ([]domain.TrackChapter, error)
+	UpdateTrackChapters(ctx context.Context, trackID domain.TrackID, chapters []domain.TrackChapter) ([]domain.TrackChapter, error)
 	ListTracks(ctx context.Context, input ListTracksInput) ([]*domain.AudioTrack, int, pagination.Page, error)
-	CreateCollection(ctx context.Context, title, description string, colType domain.CollectionType, initialTrackIDs []domain.TrackID) (*domain.AudioCollection, error)
+	// CreateCollection creates a collection of the authenticated user. An empty visibility means private.
+	// SMART collections require a smartRule and take no initial tracks; other types take no rule.
+	CreateCollection(ctx context.Context, title, description string, colType domain.CollectionType, visibility domain.CollectionVisibility, initialTrackIDs []domain.TrackID, smartRule *domain.SmartRule) (*domain.AudioCollection, error)
+	// GetCollectionDetails and GetCollectionTracks are available to members and to anyone the collection's
+	// visibility allows. Viewers other than the owner and members do not see private tracks of other users.
+	// Metadata and tracks can be changed by the owner and editors; only the owner may change the
+	// visibility or delete the collection.
 	GetCollectionDetails(ctx context.Context, collectionID domain.CollectionID) (*domain.AudioCollection, error)
-	GetCollectionTracks(ctx context.Context, collectionID domain.CollectionID) ([]*domain.AudioTrack, error)
-	UpdateCollectionMetadata(ctx context.Context, collectionID domain.CollectionID, title, description string) error
-	UpdateCollectionTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID) error
+	// GetCollectionTracks returns a page of tracks in collection order; a zero page.Limit returns all tracks of
+	// a regular collection. Smart collections are evaluated for the viewer on every call and always paginated.
+	GetCollectionTracks(ctx context.Context, collectionID domain.CollectionID, page pagination.Page) ([]*domain.AudioTrack, int, pagination.Page, error)
+	// ListPublicCollections lists collections with PUBLIC visibility.
+	ListPublicCollections(ctx context.Context, input ListCollectionsInput) ([]*domain.AudioCollection, int, pagination.Page, error)
+	// UpdateCollectionMetadata and UpdateCollectionTracks apply only if the collection is at
+	// expectedVersion (0 = unconditional) and return the collection's new version.
+	// A nil visibility leaves the visibility unchanged.
+	UpdateCollectionMetadata(ctx context.Context, collectionID domain.CollectionID, title, description string, visibility *domain.CollectionVisibility, expectedVersion int) (int, error)
+	UpdateCollectionTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID, expectedVersion int) (int, error)
+	// AddCollectionTrack, MoveCollectionTrack and RemoveCollectionTrack change a single track with the same
+	// permissions and version check as UpdateCollectionTracks. Positions are 0-based; a negative or
+	// out-of-bounds position means the end of the list. Added tracks must be public or belong to the
+	// collection's owner or the editing user.
+	AddCollectionTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error)
+	MoveCollectionTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error)
+	RemoveCollectionTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, expectedVersion int) (int, error)
 	DeleteCollection(ctx context.Context, collectionID domain.CollectionID) error
-	// ADDED: List collections for the authenticated user
-	ListUserCollections(ctx context.Context, params ListUserCollectionsParams) ([]*domain.AudioCollection, int, pagination.Page, error)
+	// UpdateSmartRule replaces the rule of a smart collection, with the same permissions and version check as
+	// UpdateCollectionMetadata. FreezeSmartCollection saves the tracks a smart collection currently selects
+	// for the authenticated user as a new private playlist they own; an empty title keeps the original title.
+	UpdateSmartRule(ctx context.Context, collectionID domain.CollectionID, rule domain.SmartRule, expectedVersion int) (int, error)
+	FreezeSmartCollection(ctx context.Context, collectionID domain.CollectionID, title string) (*domain.AudioCollection, error)
+	// ForkCollection copies a collection the authenticated user can read into a new private collection of the
+	// same type they own: metadata, track order, and the rule or outline of smart collections and courses.
+	// Tracks the user may not see are left out; an empty title keeps the original title.
+	ForkCollection(ctx context.Context, collectionID domain.CollectionID, title string) (*domain.AudioCollection, error)
+	// ExportCollection returns the tracks of a readable collection as a playlist with presigned stream URLs.
+	// ImportPlaylist creates a private playlist from the entries that match, by track ID or content checksum,
+	// a track the authenticated user may use; an empty title uses the playlist's own title.
+	ExportCollection(ctx context.Context, collectionID domain.CollectionID) (*playlist.Playlist, error)
+	ImportPlaylist(ctx context.Context, p *playlist.Playlist, title string) (*PlaylistImportResult, error)
+	// ListUserCollections lists the collections the user owns or was invited to, with the user's role in each.
+	ListUserCollections(ctx context.Context, params ListUserCollectionsParams) ([]UserCollection, int, pagination.Page, error)
+}
+
+// FeedUseCase serves collections as podcast feeds. Enclosures point at long-lived signed stream
+// links, which ResolveStreamLink exchanges for short-lived storage URLs.
+type FeedUseCase interface {
+	// GetPublicFeed returns the feed of a collection anonymous users can see (public or unlisted).
+	GetPublicFeed(ctx context.Context, collectionID domain.CollectionID) (*CollectionFeed, error)
+	// GetPrivateFeed returns the feed behind a secret feed URL, as the user it was issued to sees the collection.
+	GetPrivateFeed(ctx context.Context, secret string) (*CollectionFeed, error)
+	// IssueFeedToken creates a secret feed URL for a collection the user can read, replacing their previous one.
+	// RevokeFeedToken deletes it; the stream links in the feed stop working as well.
+	IssueFeedToken(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) (*FeedTokenResult, error)
+	RevokeFeedToken(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) error
+	// ResolveStreamLink verifies a stream link and returns a presigned URL of the audio.
+	ResolveStreamLink(ctx context.Context, input StreamLinkInput) (string, error)
+}
+
+// FeedIngestUseCase ingests external podcast feeds into the library (admin only). Each feed's
+// episodes become tracks and are kept in sync with one collection by a recurring background job.
+type FeedIngestUseCase interface {
+	// Subscribe adds a feed and queues its first refresh.
+	Subscribe(ctx context.Context, input SubscribeFeedInput) (*domain.FeedSubscription, error)
+	// ImportOPML subscribes to every feed listed in an OPML document that is not subscribed to yet.
+	ImportOPML(ctx context.Context, input ImportOPMLInput) (*OPMLImportResult, error)
+	ListSubscriptions(ctx context.Context, page pagination.Page) ([]*domain.FeedSubscription, int, pagination.Page, error)
+	GetSubscription(ctx context.Context, id domain.FeedSubscriptionID) (*domain.FeedSubscription, error)
+	// RefreshSubscription queues a refresh of the feed now, regardless of its schedule.
+	RefreshSubscription(ctx context.Context, id domain.FeedSubscriptionID) error
+	// Unsubscribe stops refreshing a feed. The tracks and collection ingested from it remain.
+	Unsubscribe(ctx context.Context, id domain.FeedSubscriptionID) error
 }
 
 // UserActivityUseCase defines the methods for the User Activity use case layer.
@@ -63,6 +133,15 @@ type UserActivityUseCase interface {
 	CreateBookmark(ctx context.Context, userID domain.UserID, trackID domain.TrackID, timestamp time.Duration, note string) (*domain.Bookmark, error)
 	ListBookmarks(ctx context.Context, params ListBookmarksInput) ([]*domain.Bookmark, int, pagination.Page, error)
 	DeleteBookmark(ctx context.Context, userID domain.UserID, bookmarkID domain.BookmarkID) error
+	// Loops are A-B regions of a track validated against its duration. Only their owner may read,
+	// change or share them. LoopLink returns a deep link that opens the track in the client apps
+	// with the loop's region and settings; it carries no credentials.
+	CreateLoop(ctx context.Context, userID domain.UserID, trackID domain.TrackID, input LoopInput) (*domain.Loop, error)
+	GetLoop(ctx context.Context, userID domain.UserID, loopID domain.LoopID) (*domain.Loop, error)
+	ListLoops(ctx context.Context, params ListLoopsInput) ([]*domain.Loop, int, pagination.Page, error)
+	UpdateLoop(ctx context.Context, userID domain.UserID, loopID domain.LoopID, input LoopInput) (*domain.Loop, error)
+	DeleteLoop(ctx context.Context, userID domain.UserID, loopID domain.LoopID) error
+	LoopLink(ctx context.Context, userID domain.UserID, loopID domain.LoopID) (string, error)
 }
 
 // UserUseCase defines the interface for user-related operations (e.g., profile)
@@ -72,8 +151,90 @@ type UserUseCase interface {
 
 // UploadUseCase defines the methods for the Upload use case layer.
 type UploadUseCase interface {
-	RequestUpload(ctx context.Context, userID domain.UserID, filename string, contentType string) (*RequestUploadResult, error)
+	RequestUpload(ctx context.Context, userID domain.UserID, req RequestUploadInput) (*RequestUploadResult, error)
 	CompleteUpload(ctx context.Context, userID domain.UserID, req CompleteUploadInput) (*domain.AudioTrack, error)
+	// UploadDirect stores a file received by the API and creates its track, for clients that cannot reach storage.
+	UploadDirect(ctx context.Context, userID domain.UserID, req DirectUploadInput) (*domain.AudioTrack, error)
 	RequestBatchUpload(ctx context.Context, userID domain.UserID, req BatchRequestUploadInput) ([]BatchURLResultItem, error)
 	CompleteBatchUpload(ctx context.Context, userID domain.UserID, req BatchCompleteInput) ([]BatchCompleteResultItem, error)
+	// ListDuplicates lists groups of tracks with the same content, as far as it is visible to the user.
+	ListDuplicates(ctx context.Context, input ListDuplicatesInput) ([]DuplicateTrackGroup, int, pagination.Page, error)
+}
+
+// ResumableUploadUseCase handles audio files uploaded in chunks with the tus protocol.
+type ResumableUploadUseCase interface {
+	CreateUpload(ctx context.Context, userID domain.UserID, input CreateResumableUploadInput) (*domain.ResumableUpload, error)
+	// GetUpload returns an upload of the user. Expired uploads are not found.
+	GetUpload(ctx context.Context, userID domain.UserID, id domain.ResumableUploadID) (*domain.ResumableUpload, error)
+	// WriteChunk appends chunk at offset, which must be the current offset of the upload. Once the
+	// last byte has been received the track is created and its ID recorded in the upload.
+	WriteChunk(ctx context.Context, userID domain.UserID, id domain.ResumableUploadID, offset int64, chunk io.Reader) (*domain.ResumableUpload, error)
+	// TerminateUpload deletes an upload and the bytes received so far.
+	TerminateUpload(ctx context.Context, userID domain.UserID, id domain.ResumableUploadID) error
+}
+
+// AuditUseCase defines read access to the audit log (admin only).
+type AuditUseCase interface {
+	ListAuditEvents(ctx context.Context, input ListAuditEventsInput) ([]*domain.AuditEvent, int, pagination.Page, error)
+}
+
+// WebhookUseCase manages webhook subscriptions and their delivery log (admin only).
+type WebhookUseCase interface {
+	// CreateWebhook registers an endpoint. The returned subscription carries the
+	// generated signing secret; it is not returned by any other method.
+	CreateWebhook(ctx context.Context, input CreateWebhookInput) (*domain.WebhookSubscription, error)
+	ListWebhooks(ctx context.Context, page pagination.Page) ([]*domain.WebhookSubscription, int, pagination.Page, error)
+	GetWebhook(ctx context.Context, id domain.WebhookID) (*domain.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, id domain.WebhookID, input UpdateWebhookInput) (*domain.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id domain.WebhookID) error
+	ListDeliveries(ctx context.Context, input ListWebhookDeliveriesInput) ([]*domain.WebhookDelivery, int, pagination.Page, error)
+	// RedeliverDelivery schedules a finished delivery to be sent again immediately.
+	RedeliverDelivery(ctx context.Context, webhookID domain.WebhookID, deliveryID domain.WebhookDeliveryID) (*domain.WebhookDelivery, error)
+}
+
+// ImportUseCase imports audio libraries uploaded as ZIP archives (admin only).
+type ImportUseCase interface {
+	// StartArchiveImport stores the archive and queues its import. The returned run is pending.
+	StartArchiveImport(ctx context.Context, input StartImportInput) (*domain.ImportRun, error)
+	GetImportRun(ctx context.Context, id domain.ImportRunID) (*domain.ImportRun, error)
+}
+
+// CollectionSharingUseCase manages the members of shared collections, invitations and ownership transfer.
+type CollectionSharingUseCase interface {
+	// InviteCollaborator invites a user, by ID or email address, to join a collection owned by inviterID.
+	InviteCollaborator(ctx context.Context, input InviteCollaboratorInput) (*domain.CollectionInvitation, error)
+	ListCollectionInvitations(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) ([]*domain.CollectionInvitation, error)
+	RevokeInvitation(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID, invitationID domain.InvitationID) error
+	// ListMyInvitations lists the pending invitations addressed to the user.
+	ListMyInvitations(ctx context.Context, userID domain.UserID) ([]*domain.CollectionInvitation, error)
+	AcceptInvitation(ctx context.Context, userID domain.UserID, invitationID domain.InvitationID) (*domain.CollectionMember, error)
+	DeclineInvitation(ctx context.Context, userID domain.UserID, invitationID domain.InvitationID) error
+	// ListCollectionMembers lists the owner (first) and members of a collection to any of them.
+	ListCollectionMembers(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) ([]*domain.CollectionMember, error)
+	// RemoveCollectionMember removes a member. The owner may remove anyone; members may remove themselves.
+	RemoveCollectionMember(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID, memberID domain.UserID) error
+	// TransferOwnership hands a collection over to one of its members, who must have accepted an invitation.
+	// The previous owner stays on as an editor. It applies only if the collection is at expectedVersion (0 = unconditional).
+	TransferOwnership(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID, newOwnerID domain.UserID, expectedVersion int) (int, error)
+}
+
+// CourseUseCase manages the outline, publication and enrollments of courses.
+type CourseUseCase interface {
+	// GetCourse returns a course and its outline. Unpublished courses are visible only to their owner,
+	// its members and to learners enrolled before the course was unpublished. viewerID is nil for anonymous requests.
+	GetCourse(ctx context.Context, viewerID *domain.UserID, collectionID domain.CollectionID) (*CourseDetails, error)
+	// UpdateCourseOutline replaces the outline of a course the user owns or edits. The collection's
+	// track list is set to the lesson tracks in course order.
+	UpdateCourseOutline(ctx context.Context, input UpdateCourseOutlineInput) (*CourseDetails, error)
+	// PublishCourse and UnpublishCourse open or close a course for enrollment. They apply only
+	// if the course is at expectedVersion (0 = unconditional).
+	PublishCourse(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID, expectedVersion int) (*domain.AudioCollection, error)
+	UnpublishCourse(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID, expectedVersion int) (*domain.AudioCollection, error)
+	ListPublishedCourses(ctx context.Context, input ListCoursesInput) ([]CourseSummary, int, pagination.Page, error)
+	// Enroll enrolls the user in a published course. Enrolling again returns the existing enrollment.
+	Enroll(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) (*domain.Enrollment, error)
+	Unenroll(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) error
+	// ListEnrollments lists the user's enrolled courses with their progress.
+	ListEnrollments(ctx context.Context, userID domain.UserID, page pagination.Page) ([]EnrolledCourse, int, pagination.Page, error)
+	GetCourseProgress(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) (*domain.CourseProgress, error)
 }
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/middleware"
@@ -16,6 +17,7 @@ import (
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
 	"github.com/yvanyang/language-learning-player-api/internal/port"
 	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+	"github.com/yvanyang/language-learning-player-api/pkg/playlist"
 )
 
 // AudioContentUseCase handles business logic related to audio tracks and collections.
@@ -24,9 +26,14 @@ type AudioContentUseCase struct {
 	collectionRepo port.AudioCollectionRepository
 	storageService port.FileStorageService
 	txManager      port.TransactionManager
+	auditRepo      port.AuditEventRepository
+	courseRepo     port.CourseRepository
+	memberRepo     port.CollectionMemberRepository
 	// ADDED: Inject activity repo to fetch user specific data in GetAudioTrackDetails
 	progressRepo  port.PlaybackProgressRepository
 	bookmarkRepo  port.BookmarkRepository
+	chapterRepo   port.TrackChapterRepository
+	loopRepo      port.LoopRepository
 	presignExpiry time.Duration
 	cdnBaseURL    *url.URL
 	logger        *slog.Logger
@@ -40,8 +47,13 @@ func NewAudioContentUseCase(
 	cr port.AudioCollectionRepository,
 	ss port.FileStorageService,
 	tm port.TransactionManager,
+	ar port.AuditEventRepository,
+	cor port.CourseRepository,
+	mr port.CollectionMemberRepository,
 	pr port.PlaybackProgressRepository, // Added
 	br port.BookmarkRepository, // Added
+	chr port.TrackChapterRepository,
+	lr port.LoopRepository,
 	log *slog.Logger,
 ) *AudioContentUseCase {
 	if tm == nil {
@@ -64,8 +76,13 @@ func NewAudioContentUseCase(
 		collectionRepo: cr,
 		storageService: ss,
 		txManager:      tm,
+		auditRepo:      ar,
+		courseRepo:     cor,
+		memberRepo:     mr,
 		progressRepo:   pr, // Added
 		bookmarkRepo:   br, // Added
+		chapterRepo:    chr,
+		loopRepo:       lr,
 		presignExpiry:  cfg.Minio.PresignExpiry,
 		cdnBaseURL:     parsedCdnBaseURL,
 		logger:         log.With("usecase", "AudioContentUseCase"),
@@ -76,6 +93,9 @@ func NewAudioContentUseCase(
 
 // Point 4: GetAudioTrackDetails retrieves details and user-specific info, returns result struct.
 func (uc *AudioContentUseCase) GetAudioTrackDetails(ctx context.Context, trackID domain.TrackID) (*port.GetAudioTrackDetailsResult, error) {
+	ctx, span := tracer.Start(ctx, "AudioContentUseCase.GetAudioTrackDetails")
+	defer span.End()
+
 	userID, userAuthenticated := middleware.GetUserIDFromContext(ctx) // Check if user is logged in
 
 	track, err := uc.trackRepo.FindByID(ctx, trackID)
@@ -88,12 +108,9 @@ func (uc *AudioContentUseCase) GetAudioTrackDetails(ctx context.Context, trackID
 		return nil, err // Propagate error (NotFound or Internal)
 	}
 
-	// Authorization check (Example: only public tracks accessible anonymously)
-	if !track.IsPublic && !userAuthenticated {
-		uc.logger.WarnContext(ctx, "Anonymous user attempted to access private track", "trackID", trackID)
-		return nil, domain.ErrUnauthenticated // Require login for private tracks
+	if err := uc.authorizeTrackRead(ctx, track); err != nil {
+		return nil, err
 	}
-	// Further checks (ownership, subscription) could go here if needed
 
 	// Generate Presigned URL
 	presignedURLStr, err := uc.storageService.GetPresignedGetURL(ctx, track.MinioBucket, track.MinioObjectKey, uc.presignExpiry)
@@ -105,10 +122,17 @@ func (uc *AudioContentUseCase) GetAudioTrackDetails(ctx context.Context, trackID
 	// Rewrite URL if CDN is configured
 	finalPlayURL := uc.rewriteURLForCDN(ctx, presignedURLStr)
 
+	chapters, err := uc.chapterRepo.ListByTrack(ctx, trackID)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to get chapters for track details", "error", err, "trackID", trackID)
+		chapters = []domain.TrackChapter{} // Continue without chapters, error is logged
+	}
+
 	result := &port.GetAudioTrackDetailsResult{
-		Track:   track,
-		PlayURL: finalPlayURL,
-		// UserProgress and UserBookmarks will be filled below if user is authenticated
+		Track:    track,
+		PlayURL:  finalPlayURL,
+		Chapters: chapters,
+		// UserProgress, UserBookmarks and UserLoops will be filled below if user is authenticated
 	}
 
 	// Fetch user-specific data if authenticated
@@ -119,6 +143,7 @@ func (uc *AudioContentUseCase) GetAudioTrackDetails(ctx context.Context, trackID
 			uc.logger.ErrorContext(ctx, "Failed to get user progress for track details", "error", errProg, "trackID", trackID, "userID", userID)
 			// Continue without progress, error is logged
 		} else if errProg == nil {
+			progress.Chapter = domain.ChapterAt(chapters, progress.Progress)
 			result.UserProgress = progress
 		}
 
@@ -128,14 +153,126 @@ func (uc *AudioContentUseCase) GetAudioTrackDetails(ctx context.Context, trackID
 			uc.logger.ErrorContext(ctx, "Failed to get user bookmarks for track details", "error", errBook, "trackID", trackID, "userID", userID)
 			// Continue without bookmarks, error is logged
 		} else {
+			for _, b := range bookmarks {
+				b.Chapter = domain.ChapterAt(chapters, b.Timestamp)
+			}
 			result.UserBookmarks = bookmarks // Assign even if empty slice
 		}
+
+		// Fetch Loops
+		loops, errLoop := uc.loopRepo.ListByUserAndTrack(ctx, userID, trackID)
+		if errLoop != nil {
+			uc.logger.ErrorContext(ctx, "Failed to get user loops for track details", "error", errLoop, "trackID", trackID, "userID", userID)
+			// Continue without loops, error is logged
+		} else {
+			result.UserLoops = loops
+		}
 	}
 
 	uc.logger.InfoContext(ctx, "Successfully retrieved audio track details", "trackID", trackID, "authenticated", userAuthenticated)
 	return result, nil
 }
 
+// authorizeTrackRead checks that the caller may read a track directly: public tracks are open to
+// everyone, private ones to their uploader and to owners and members of a collection containing
+// them, which is what collection reads show, as long as the track was uploaded by that
+// collection's owner or one of its editors. Anonymous callers of a private track get
+// domain.ErrUnauthenticated, other callers domain.ErrPermissionDenied.
+func (uc *AudioContentUseCase) authorizeTrackRead(ctx context.Context, track *domain.AudioTrack) error {
+	userID, userAuthenticated := middleware.GetUserIDFromContext(ctx)
+	if !userAuthenticated {
+		if !track.IsVisibleTo(nil) {
+			uc.logger.WarnContext(ctx, "Anonymous user attempted to access private track", "trackID", track.ID)
+			return domain.ErrUnauthenticated // Require login for private tracks
+		}
+		return nil
+	}
+	if track.IsVisibleTo(&userID) {
+		return nil
+	}
+	shared, err := uc.collectionRepo.HasAccessibleWithTrack(ctx, userID, track.ID)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to check collection access to private track", "error", err, "trackID", track.ID, "userID", userID)
+		return fmt.Errorf("failed to verify permissions: %w", err)
+	}
+	if !shared {
+		uc.logger.WarnContext(ctx, "User attempted to access private track of another user", "trackID", track.ID, "userID", userID)
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+// GetTrackChapters returns the chapters of a track, with the same access rules as GetAudioTrackDetails.
+func (uc *AudioContentUseCase) GetTrackChapters(ctx context.Context, trackID domain.TrackID) ([]domain.TrackChapter, error) {
+	track, err := uc.trackRepo.FindByID(ctx, trackID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "Failed to get audio track for chapters", "error", err, "trackID", trackID)
+		}
+		return nil, err
+	}
+	if err := uc.authorizeTrackRead(ctx, track); err != nil {
+		return nil, err
+	}
+
+	chapters, err := uc.chapterRepo.ListByTrack(ctx, trackID)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to list track chapters", "error", err, "trackID", trackID)
+		return nil, fmt.Errorf("failed to retrieve chapters: %w", err)
+	}
+	return chapters, nil
+}
+
+// UpdateTrackChapters replaces the chapters of a track uploaded by the authenticated user and
+// returns them as stored: sorted, with missing ends filled in.
+func (uc *AudioContentUseCase) UpdateTrackChapters(ctx context.Context, trackID domain.TrackID, chapters []domain.TrackChapter) ([]domain.TrackChapter, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	track, err := uc.trackRepo.FindByID(ctx, trackID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "Failed to get audio track for chapter update", "error", err, "trackID", trackID)
+		}
+		return nil, err
+	}
+	if track.UploaderID == nil || *track.UploaderID != userID {
+		uc.logger.WarnContext(ctx, "Attempt to edit chapters of a track not uploaded by the user", "trackID", trackID, "userID", userID)
+		return nil, domain.ErrPermissionDenied
+	}
+
+	normalized, err := domain.NewTrackChapters(chapters, track.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		current, err := uc.chapterRepo.ListByTrack(txCtx, trackID)
+		if err != nil {
+			return err
+		}
+		diff := domain.DiffFields(chapterAuditFields(current), chapterAuditFields(normalized))
+		if len(diff) == 0 {
+			return nil
+		}
+		if err := uc.chapterRepo.Replace(txCtx, trackID, normalized); err != nil {
+			return err
+		}
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionTrackUpdate, domain.AuditTargetTrack, trackID.String(), diff)
+	})
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "Failed to update track chapters", "error", err, "trackID", trackID)
+		}
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "Track chapters updated", "trackID", trackID, "userID", userID, "chapterCount", len(normalized))
+	return normalized, nil
+}
+
 // rewriteURLForCDN is a helper to rewrite presigned URL if CDN is configured.
 func (uc *AudioContentUseCase) rewriteURLForCDN(ctx context.Context, originalURL string) string {
 	if uc.cdnBaseURL == nil || originalURL == "" {
@@ -186,9 +323,33 @@ func (uc *AudioContentUseCase) ListTracks(ctx context.Context, input port.ListTr
 	return tracks, total, pageParams, nil
 }
 
+// ListPublicCollections lists public collections, applying the same catalogue filters as ListTracks.
+// Track-level filters match collections containing at least one public track with those attributes.
+func (uc *AudioContentUseCase) ListPublicCollections(ctx context.Context, input port.ListCollectionsInput) ([]*domain.AudioCollection, int, pagination.Page, error) {
+	pageParams := pagination.NewPageFromOffset(input.Page.Limit, input.Page.Offset)
+
+	repoFilters := port.ListCollectionsFilters{
+		Query:         input.Query,
+		LanguageCode:  input.LanguageCode,
+		Level:         input.Level,
+		OwnerID:       input.OwnerID,
+		Tags:          input.Tags,
+		SortBy:        input.SortBy,
+		SortDirection: input.SortDirection,
+	}
+
+	collections, total, err := uc.collectionRepo.ListPublic(ctx, repoFilters, pageParams)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to list public collections from repository", "error", err, "filters", repoFilters, "page", pageParams)
+		return nil, 0, pageParams, fmt.Errorf("failed to retrieve collection list: %w", err)
+	}
+	uc.logger.InfoContext(ctx, "Successfully listed public collections", "count", len(collections), "total", total, "input", input)
+	return collections, total, pageParams, nil
+}
+
 // --- Collection Use Cases --- (No changes needed for the requested points in these methods)
 
-func (uc *AudioContentUseCase) CreateCollection(ctx context.Context, title, description string, colType domain.CollectionType, initialTrackIDs []domain.TrackID) (*domain.AudioCollection, error) {
+func (uc *AudioContentUseCase) CreateCollection(ctx context.Context, title, description string, colType domain.CollectionType, visibility domain.CollectionVisibility, initialTrackIDs []domain.TrackID, smartRule *domain.SmartRule) (*domain.AudioCollection, error) {
 	userID, ok := middleware.GetUserIDFromContext(ctx)
 	if !ok {
 		return nil, domain.ErrUnauthenticated
@@ -197,10 +358,29 @@ func (uc *AudioContentUseCase) CreateCollection(ctx context.Context, title, desc
 		return nil, fmt.Errorf("internal configuration error: transaction manager not available")
 	}
 
-	collection, err := domain.NewAudioCollection(title, description, userID, colType)
+	var collection *domain.AudioCollection
+	var err error
+	switch {
+	case colType == domain.TypeSmart && smartRule == nil:
+		return nil, fmt.Errorf("%w: a smart collection requires a rule", domain.ErrInvalidArgument)
+	case colType == domain.TypeSmart && len(initialTrackIDs) > 0:
+		return nil, fmt.Errorf("%w: the tracks of a smart collection come from its rule", domain.ErrInvalidArgument)
+	case colType != domain.TypeSmart && smartRule != nil:
+		return nil, fmt.Errorf("%w: only smart collections have a rule", domain.ErrInvalidArgument)
+	case colType == domain.TypeSmart:
+		collection, err = domain.NewSmartCollection(title, description, userID, *smartRule)
+	default:
+		collection, err = domain.NewAudioCollection(title, description, userID, colType)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if visibility != "" {
+		if !visibility.IsValid() {
+			return nil, fmt.Errorf("%w: invalid collection visibility '%s'", domain.ErrInvalidArgument, visibility)
+		}
+		collection.Visibility = visibility
+	}
 
 	finalErr := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
 		if err := uc.collectionRepo.Create(txCtx, collection); err != nil {
@@ -214,12 +394,20 @@ func (uc *AudioContentUseCase) CreateCollection(ctx context.Context, title, desc
 			if !exists {
 				return fmt.Errorf("%w: one or more initial track IDs do not exist", domain.ErrInvalidArgument)
 			}
-			if err := uc.collectionRepo.ManageTracks(txCtx, collection.ID, initialTrackIDs); err != nil {
+			for _, trackID := range initialTrackIDs {
+				if err := uc.checkTrackAccessible(txCtx, collection, userID, trackID); err != nil {
+					return err
+				}
+			}
+			newVersion, err := uc.collectionRepo.ManageTracks(txCtx, collection.ID, initialTrackIDs, collection.Version)
+			if err != nil {
 				return fmt.Errorf("adding initial tracks: %w", err)
 			}
 			collection.TrackIDs = initialTrackIDs
+			collection.Version = newVersion
 		}
-		return nil
+		diff := domain.DiffFields(nil, collectionAuditFields(collection))
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCollectionCreate, domain.AuditTargetCollection, collection.ID.String(), diff)
 	})
 
 	if finalErr != nil {
@@ -230,19 +418,15 @@ func (uc *AudioContentUseCase) CreateCollection(ctx context.Context, title, desc
 	return collection, nil
 }
 
-// ListUserCollections retrieves collections owned by a specific user.
+// ListUserCollections retrieves the collections a user owns or is a member of.
 // ADDED: New method implementation
-func (uc *AudioContentUseCase) ListUserCollections(ctx context.Context, params port.ListUserCollectionsParams) ([]*domain.AudioCollection, int, pagination.Page, error) {
+func (uc *AudioContentUseCase) ListUserCollections(ctx context.Context, params port.ListUserCollectionsParams) ([]port.UserCollection, int, pagination.Page, error) {
 	// Validate/default pagination
 	pageParams := pagination.NewPageFromOffset(params.Page.Limit, params.Page.Offset)
 
-	// TODO: Implement sorting logic if needed based on params.SortBy/SortDirection
-	// This would involve mapping use case sort fields to repository sort fields if different.
-	// For now, we rely on the repository's default sort (likely creation date).
-
-	collections, total, err := uc.collectionRepo.ListByOwner(ctx, params.UserID, pageParams)
+	collections, total, err := uc.collectionRepo.ListAccessible(ctx, params.UserID, params.SortBy, params.SortDirection, pageParams)
 	if err != nil {
-		uc.logger.ErrorContext(ctx, "Failed to list collections by owner", "error", err, "ownerID", params.UserID, "page", pageParams)
+		uc.logger.ErrorContext(ctx, "Failed to list accessible collections", "error", err, "userID", params.UserID, "page", pageParams)
 		return nil, 0, pageParams, fmt.Errorf("failed to retrieve collection list: %w", err)
 	}
 
@@ -261,96 +445,572 @@ func (uc *AudioContentUseCase) GetCollectionDetails(ctx context.Context, collect
 		}
 		return nil, err
 	}
-	// Check ownership AFTER fetching
-	if !userAuthenticated || collection.OwnerID != userID {
+	// Check visibility AFTER fetching
+	viewerID := viewerFromContext(userID, userAuthenticated)
+	role, err := uc.viewerRole(ctx, collection, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" && !collection.IsVisibleTo(viewerID) {
 		uc.logger.WarnContext(ctx, "Permission denied for accessing collection details", "collectionID", collectionID, "ownerID", collection.OwnerID, "requestUserID", userID, "authenticated", userAuthenticated)
-		return nil, domain.ErrPermissionDenied // Return PermissionDenied instead of NotFound if found but not owner
+		return nil, domain.ErrPermissionDenied // Return PermissionDenied instead of NotFound if found but not visible
+	}
+	if role == "" {
+		// Do not reveal other users' private tracks to viewers of a shared collection
+		visible, err := uc.visibleTracks(ctx, collection.TrackIDs, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		collection.TrackIDs = make([]domain.TrackID, len(visible))
+		for i, t := range visible {
+			collection.TrackIDs[i] = t.ID
+		}
 	}
 	uc.logger.InfoContext(ctx, "Successfully retrieved collection details", "collectionID", collectionID, "trackCount", len(collection.TrackIDs))
 	return collection, nil
 }
 
-func (uc *AudioContentUseCase) GetCollectionTracks(ctx context.Context, collectionID domain.CollectionID) ([]*domain.AudioTrack, error) {
-	// First, verify the requesting user owns the collection before fetching tracks
+// GetCollectionTracks returns a page of the collection's tracks in order. A zero page.Limit returns all
+// tracks of a regular collection; smart collections are always paginated, using the default page size.
+func (uc *AudioContentUseCase) GetCollectionTracks(ctx context.Context, collectionID domain.CollectionID, page pagination.Page) ([]*domain.AudioTrack, int, pagination.Page, error) {
+	// First, verify the requesting user may read the collection before fetching tracks
 	userID, userAuthenticated := middleware.GetUserIDFromContext(ctx)
-	collection, err := uc.collectionRepo.FindByID(ctx, collectionID) // Fetch metadata only for ownership check
+	viewerID := viewerFromContext(userID, userAuthenticated)
+	collection, err := uc.collectionRepo.FindByID(ctx, collectionID) // Fetch metadata only for visibility check
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			uc.logger.WarnContext(ctx, "Collection not found for track listing", "collectionID", collectionID)
 		} else {
 			uc.logger.ErrorContext(ctx, "Failed to get collection metadata for track listing", "error", err, "collectionID", collectionID)
 		}
-		return nil, err
+		return nil, 0, page, err
+	}
+	role, err := uc.viewerRole(ctx, collection, viewerID)
+	if err != nil {
+		return nil, 0, page, err
 	}
-	if !userAuthenticated || collection.OwnerID != userID {
+	if role == "" && !collection.IsVisibleTo(viewerID) {
 		uc.logger.WarnContext(ctx, "Permission denied for listing collection tracks", "collectionID", collectionID, "ownerID", collection.OwnerID, "requestUserID", userID, "authenticated", userAuthenticated)
-		return nil, domain.ErrPermissionDenied
+		return nil, 0, page, domain.ErrPermissionDenied
+	}
+
+	if collection.IsSmart() {
+		pageParams := pagination.NewPageFromOffset(page.Limit, page.Offset)
+		tracks, total, err := uc.evaluateSmartRule(ctx, collection, viewerID, pageParams)
+		if err != nil {
+			return nil, 0, pageParams, err
+		}
+		uc.logger.InfoContext(ctx, "Successfully evaluated smart collection", "collectionID", collectionID, "trackCount", len(tracks), "total", total)
+		return tracks, total, pageParams, nil
 	}
 
 	// Now fetch the tracks using the IDs from the collection object (or refetch with FindWithTracks)
 	collectionWithTracks, err := uc.collectionRepo.FindWithTracks(ctx, collectionID)
 	if err != nil {
 		// This shouldn't fail if the previous FindByID succeeded, but handle defensively
-		uc.logger.ErrorContext(ctx, "Failed to get track IDs for collection after ownership check", "error", err, "collectionID", collectionID)
-		return nil, fmt.Errorf("failed to retrieve track IDs for collection: %w", err)
+		uc.logger.ErrorContext(ctx, "Failed to get track IDs for collection after visibility check", "error", err, "collectionID", collectionID)
+		return nil, 0, page, fmt.Errorf("failed to retrieve track IDs for collection: %w", err)
 	}
 
 	if len(collectionWithTracks.TrackIDs) == 0 {
-		return []*domain.AudioTrack{}, nil
+		return []*domain.AudioTrack{}, 0, page, nil
 	}
 
 	tracks, err := uc.trackRepo.ListByIDs(ctx, collectionWithTracks.TrackIDs)
 	if err != nil {
 		uc.logger.ErrorContext(ctx, "Failed to list track details for collection", "error", err, "collectionID", collectionID)
-		return nil, fmt.Errorf("failed to retrieve track details for collection: %w", err)
+		return nil, 0, page, fmt.Errorf("failed to retrieve track details for collection: %w", err)
 	}
-	uc.logger.InfoContext(ctx, "Successfully retrieved tracks for collection", "collectionID", collectionID, "trackCount", len(tracks))
-	return tracks, nil
+	if role == "" {
+		tracks = slices.DeleteFunc(tracks, func(t *domain.AudioTrack) bool { return !t.IsVisibleTo(viewerID) })
+	}
+	total := len(tracks)
+	if page.Limit > 0 {
+		page = pagination.NewPageFromOffset(page.Limit, page.Offset)
+		start, end := min(page.Offset, total), min(page.Offset+page.Limit, total)
+		tracks = tracks[start:end]
+	}
+	uc.logger.InfoContext(ctx, "Successfully retrieved tracks for collection", "collectionID", collectionID, "trackCount", len(tracks), "total", total)
+	return tracks, total, page, nil
 }
 
-func (uc *AudioContentUseCase) UpdateCollectionMetadata(ctx context.Context, collectionID domain.CollectionID, title, description string) error {
+// UpdateSmartRule replaces the rule of a smart collection if it is still at expectedVersion
+// (0 skips the check) and returns the new version. Owners and editors may change the rule.
+func (uc *AudioContentUseCase) UpdateSmartRule(ctx context.Context, collectionID domain.CollectionID, rule domain.SmartRule, expectedVersion int) (int, error) {
 	userID, ok := middleware.GetUserIDFromContext(ctx)
 	if !ok {
-		return domain.ErrUnauthenticated
+		return 0, domain.ErrUnauthenticated
+	}
+	if uc.txManager == nil {
+		return 0, fmt.Errorf("internal configuration error: transaction manager not available")
+	}
+
+	var newVersion int
+	finalErr := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		collection, err := uc.collectionRepo.FindByID(txCtx, collectionID)
+		if err != nil {
+			return err // Handles NotFound
+		}
+		role, err := collectionRole(txCtx, uc.memberRepo, collection, userID)
+		if err != nil {
+			return err
+		}
+		if !role.CanEdit() {
+			return domain.ErrPermissionDenied
+		}
+		before := collectionAuditFields(collection)
+		if err := collection.SetSmartRule(rule); err != nil {
+			return err // Not a smart collection or invalid rule
+		}
+		newVersion, err = uc.collectionRepo.UpdateSmartRule(txCtx, collectionID, rule, expectedVersion)
+		if err != nil {
+			return fmt.Errorf("updating smart rule in repository: %w", err)
+		}
+		diff := domain.DiffFields(before, collectionAuditFields(collection))
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCollectionUpdate, domain.AuditTargetCollection, collectionID.String(), diff)
+	})
+	if finalErr != nil {
+		if errors.Is(finalErr, domain.ErrNotFound) || errors.Is(finalErr, domain.ErrPermissionDenied) ||
+			errors.Is(finalErr, domain.ErrInvalidArgument) || errors.Is(finalErr, domain.ErrPreconditionFailed) {
+			uc.logger.WarnContext(ctx, "Update smart rule rejected", "collectionID", collectionID, "userID", userID, "error", finalErr)
+		} else {
+			uc.logger.ErrorContext(ctx, "Transaction failed during smart rule update", "error", finalErr, "collectionID", collectionID, "userID", userID)
+		}
+		return 0, fmt.Errorf("failed to update smart rule: %w", finalErr)
+	}
+	uc.logger.InfoContext(ctx, "Smart rule updated", "collectionID", collectionID, "userID", userID, "version", newVersion)
+	return newVersion, nil
+}
+
+// maxFrozenTracks caps the number of tracks FreezeSmartCollection copies into a playlist.
+const maxFrozenTracks = 1000
+
+// FreezeSmartCollection evaluates a smart collection for the authenticated user and saves the result
+// as a new private playlist they own. An empty title reuses the smart collection's title.
+func (uc *AudioContentUseCase) FreezeSmartCollection(ctx context.Context, collectionID domain.CollectionID, title string) (*domain.AudioCollection, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthenticated
+	}
+	collection, err := uc.collectionRepo.FindByID(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := uc.viewerRole(ctx, collection, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" && !collection.IsVisibleTo(&userID) {
+		return nil, domain.ErrPermissionDenied
+	}
+	if !collection.IsSmart() {
+		return nil, fmt.Errorf("%w: only smart collections can be frozen", domain.ErrInvalidArgument)
+	}
+
+	tracks, total, err := uc.evaluateSmartRule(ctx, collection, &userID, pagination.Page{Limit: maxFrozenTracks})
+	if err != nil {
+		return nil, err
+	}
+	if total > maxFrozenTracks {
+		return nil, fmt.Errorf("%w: the rule matches %d tracks; narrow it to at most %d to freeze it", domain.ErrInvalidArgument, total, maxFrozenTracks)
+	}
+	trackIDs := make([]domain.TrackID, len(tracks))
+	for i, t := range tracks {
+		trackIDs[i] = t.ID
+	}
+	if title == "" {
+		title = collection.Title
+	}
+	playlist, err := uc.CreateCollection(ctx, title, collection.Description, domain.TypePlaylist, domain.VisibilityPrivate, trackIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "Smart collection frozen into playlist", "collectionID", collectionID, "playlistID", playlist.ID, "userID", userID, "trackCount", len(trackIDs))
+	return playlist, nil
+}
+
+// ForkCollection copies a collection the authenticated user can read into a new private collection they
+// own. The copy keeps the type, description and track order, and the rule of a smart collection or the
+// outline of a course (with new section and lesson IDs). Tracks the user may not see are left out, and a
+// forked course starts unpublished. An empty title reuses the original title.
+func (uc *AudioContentUseCase) ForkCollection(ctx context.Context, collectionID domain.CollectionID, title string) (*domain.AudioCollection, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthenticated
+	}
+	if uc.txManager == nil {
+		return nil, fmt.Errorf("internal configuration error: transaction manager not available")
+	}
+	source, err := uc.collectionRepo.FindWithTracks(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := uc.viewerRole(ctx, source, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" && !source.IsVisibleTo(&userID) {
+		return nil, domain.ErrPermissionDenied
 	}
 	if title == "" {
-		return fmt.Errorf("%w: collection title cannot be empty", domain.ErrInvalidArgument)
+		title = source.Title
+	}
+
+	var fork *domain.AudioCollection
+	if source.IsSmart() {
+		fork, err = domain.NewSmartCollection(title, source.Description, userID, *source.SmartRule)
+	} else {
+		fork, err = domain.NewAudioCollection(title, source.Description, userID, source.Type)
+	}
+	if err != nil {
+		return nil, err
 	}
-	// The repository layer `UpdateMetadata` now includes an ownership check in the WHERE clause.
-	// We pass the ownerID from the context to ensure only the owner can update.
-	tempCollection := &domain.AudioCollection{ID: collectionID, OwnerID: userID, Title: title, Description: description}
-	err := uc.collectionRepo.UpdateMetadata(ctx, tempCollection)
+
+	// The fork belongs to the user, so it may only hold tracks they could add themselves
+	visible, err := uc.visibleTracks(ctx, source.TrackIDs, &userID)
+	if err != nil {
+		return nil, err
+	}
+	trackIDs := make([]domain.TrackID, len(visible))
+	for i, t := range visible {
+		trackIDs[i] = t.ID
+	}
+	var sections []domain.CourseSection
+	if source.Type == domain.TypeCourse {
+		outline, err := uc.courseRepo.GetOutline(ctx, source.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(outline) > 0 {
+			sections = domain.CopyCourseOutline(outline, func(id domain.TrackID) bool { return slices.Contains(trackIDs, id) })
+			trackIDs = domain.CourseTrackIDs(sections)
+		}
+	}
+
+	finalErr := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := uc.collectionRepo.Create(txCtx, fork); err != nil {
+			return fmt.Errorf("saving forked collection: %w", err)
+		}
+		if len(trackIDs) > 0 {
+			newVersion, err := uc.collectionRepo.ManageTracks(txCtx, fork.ID, trackIDs, fork.Version)
+			if err != nil {
+				return fmt.Errorf("copying tracks: %w", err)
+			}
+			fork.TrackIDs = trackIDs
+			fork.Version = newVersion
+		}
+		if len(sections) > 0 {
+			if err := uc.courseRepo.ReplaceOutline(txCtx, fork.ID, sections); err != nil {
+				return fmt.Errorf("copying course outline: %w", err)
+			}
+		}
+		after := collectionAuditFields(fork)
+		after["forkedFrom"] = source.ID.String()
+		diff := domain.DiffFields(nil, after)
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCollectionCreate, domain.AuditTargetCollection, fork.ID.String(), diff)
+	})
+	if finalErr != nil {
+		uc.logger.ErrorContext(ctx, "Transaction failed during collection fork", "error", finalErr, "collectionID", collectionID, "userID", userID)
+		return nil, fmt.Errorf("failed to fork collection: %w", finalErr)
+	}
+	uc.logger.InfoContext(ctx, "Collection forked", "collectionID", collectionID, "forkID", fork.ID, "userID", userID, "trackCount", len(fork.TrackIDs))
+	return fork, nil
+}
+
+// ExportCollection returns the tracks of a collection the viewer can read as a playlist. Locations are
+// presigned stream URLs (rewritten for the CDN if configured) that expire like track play URLs; track IDs
+// and checksums are included so that the playlist can be imported again after they expire. Smart
+// collections export at most maxFrozenTracks tracks.
+func (uc *AudioContentUseCase) ExportCollection(ctx context.Context, collectionID domain.CollectionID) (*playlist.Playlist, error) {
+	collection, err := uc.GetCollectionDetails(ctx, collectionID) // Checks access; drops tracks the viewer may not see
+	if err != nil {
+		return nil, err
+	}
+	var tracks []*domain.AudioTrack
+	if collection.IsSmart() {
+		userID, userAuthenticated := middleware.GetUserIDFromContext(ctx)
+		tracks, _, err = uc.evaluateSmartRule(ctx, collection, viewerFromContext(userID, userAuthenticated), pagination.Page{Limit: maxFrozenTracks})
+		if err != nil {
+			return nil, err
+		}
+	} else if len(collection.TrackIDs) > 0 {
+		tracks, err = uc.trackRepo.ListByIDs(ctx, collection.TrackIDs)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "Failed to list track details for export", "error", err, "collectionID", collectionID)
+			return nil, fmt.Errorf("failed to retrieve track details for collection: %w", err)
+		}
+	}
+
+	result := &playlist.Playlist{
+		Title:       collection.Title,
+		Description: collection.Description,
+		Entries:     make([]playlist.Entry, 0, len(tracks)),
+	}
+	for _, t := range tracks {
+		presignedURL, err := uc.storageService.GetPresignedGetURL(ctx, t.MinioBucket, t.MinioObjectKey, uc.presignExpiry)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "Failed to generate presigned URL for export", "error", err, "collectionID", collectionID, "trackID", t.ID)
+			return nil, fmt.Errorf("failed to generate stream URL for track %s: %w", t.ID, err)
+		}
+		result.Entries = append(result.Entries, playlist.Entry{
+			ID:       t.ID.String(),
+			Title:    t.Title,
+			Location: uc.rewriteURLForCDN(ctx, presignedURL),
+			Duration: t.Duration,
+			SHA256:   t.ContentSHA256,
+		})
+	}
+	uc.logger.InfoContext(ctx, "Collection exported", "collectionID", collectionID, "trackCount", len(result.Entries))
+	return result, nil
+}
+
+// Reasons reported for playlist entries that ImportPlaylist could not add.
+const (
+	importReasonNoReference = "entry has no track ID or checksum"
+	importReasonNotFound    = "no accessible track matches the entry's ID or checksum"
+	importReasonDuplicate   = "track already appears earlier in the playlist"
+)
+
+// ImportPlaylist creates a private playlist owned by the authenticated user from a decoded playlist file.
+// Each entry is matched by track ID first, then by content checksum, preferring the user's own uploads;
+// only public tracks and the user's own are used. Entries without a match, and repeats of an earlier
+// track, are reported as unresolved. An empty title uses the playlist's title.
+func (uc *AudioContentUseCase) ImportPlaylist(ctx context.Context, p *playlist.Playlist, title string) (*port.PlaylistImportResult, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthenticated
+	}
+	if len(p.Entries) > maxFrozenTracks {
+		return nil, fmt.Errorf("%w: a playlist can import at most %d entries", domain.ErrInvalidArgument, maxFrozenTracks)
+	}
+	if title == "" {
+		title = p.Title
+	}
+	if title == "" {
+		return nil, fmt.Errorf("%w: the playlist has no title; provide one", domain.ErrInvalidArgument)
+	}
+
+	// Load all tracks referenced by ID in one query
+	referenced := make([]domain.TrackID, 0, len(p.Entries))
+	for _, e := range p.Entries {
+		if id, err := domain.TrackIDFromString(e.ID); err == nil {
+			referenced = append(referenced, id)
+		}
+	}
+	byID := make(map[domain.TrackID]*domain.AudioTrack, len(referenced))
+	if len(referenced) > 0 {
+		tracks, err := uc.trackRepo.ListByIDs(ctx, referenced)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "Failed to load referenced tracks for import", "error", err)
+			return nil, fmt.Errorf("failed to match playlist entries: %w", err)
+		}
+		for _, t := range tracks {
+			byID[t.ID] = t
+		}
+	}
+
+	trackIDs := make([]domain.TrackID, 0, len(p.Entries))
+	added := make(map[domain.TrackID]struct{}, len(p.Entries))
+	unresolved := make([]port.UnresolvedPlaylistEntry, 0)
+	for i, e := range p.Entries {
+		track, reason, err := uc.matchPlaylistEntry(ctx, e, byID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if track != nil {
+			if _, dup := added[track.ID]; !dup {
+				added[track.ID] = struct{}{}
+				trackIDs = append(trackIDs, track.ID)
+				continue
+			}
+			reason = importReasonDuplicate
+		}
+		unresolved = append(unresolved, port.UnresolvedPlaylistEntry{Index: i, Title: e.Title, Location: e.Location, Reason: reason})
+	}
+
+	collection, err := uc.CreateCollection(ctx, title, p.Description, domain.TypePlaylist, domain.VisibilityPrivate, trackIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "Playlist imported", "collectionID", collection.ID, "userID", userID, "entries", len(p.Entries), "unresolved", len(unresolved))
+	return &port.PlaylistImportResult{Collection: collection, Unresolved: unresolved}, nil
+}
+
+// matchPlaylistEntry finds the track an imported entry refers to, or returns the reason there is none.
+func (uc *AudioContentUseCase) matchPlaylistEntry(ctx context.Context, e playlist.Entry, byID map[domain.TrackID]*domain.AudioTrack, userID domain.UserID) (*domain.AudioTrack, string, error) {
+	if id, err := domain.TrackIDFromString(e.ID); err == nil {
+		if t, found := byID[id]; found && t.IsVisibleTo(&userID) {
+			return t, "", nil
+		}
+	}
+	hash, err := domain.ParseContentHash(e.SHA256)
+	if err != nil {
+		if e.ID == "" {
+			return nil, importReasonNoReference, nil
+		}
+		return nil, importReasonNotFound, nil
+	}
+	candidates, err := uc.trackRepo.ListByContentHash(ctx, hash)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to look up tracks by checksum for import", "error", err)
+		return nil, "", fmt.Errorf("failed to match playlist entries: %w", err)
+	}
+	var match *domain.AudioTrack
+	for _, t := range candidates {
+		if t.UploaderID != nil && *t.UploaderID == userID {
+			return t, "", nil
+		}
+		if match == nil && t.IsPublic {
+			match = t
+		}
+	}
+	if match == nil {
+		return nil, importReasonNotFound, nil
+	}
+	return match, "", nil
+}
+
+// evaluateSmartRule lists the tracks a smart collection's rule selects for the viewer: public tracks
+// and the viewer's own, filtered by the viewer's listening progress. Anonymous viewers have finished nothing.
+func (uc *AudioContentUseCase) evaluateSmartRule(ctx context.Context, collection *domain.AudioCollection, viewerID *domain.UserID, page pagination.Page) ([]*domain.AudioTrack, int, error) {
+	rule := collection.SmartRule
+	if rule == nil {
+		return nil, 0, fmt.Errorf("smart collection %s has no rule", collection.ID)
+	}
+	filters := port.ListTracksFilters{
+		UploaderID:    rule.UploaderID,
+		Tags:          rule.Tags,
+		SortBy:        rule.SortBy,
+		SortDirection: rule.SortDirection,
+	}
+	if rule.LanguageCode != "" {
+		filters.LanguageCode = &rule.LanguageCode
+	}
+	if rule.Level != "" {
+		filters.Level = &rule.Level
+	}
+	if rule.MinDuration > 0 {
+		filters.MinDuration = &rule.MinDuration
+	}
+	if rule.MaxDuration > 0 {
+		filters.MaxDuration = &rule.MaxDuration
+	}
+	if filters.SortBy == "" {
+		filters.SortBy = "createdAt"
+	}
+	if filters.SortDirection == "" {
+		filters.SortDirection = "desc"
+	}
+	if viewerID != nil {
+		filters.VisibleTo = viewerID
+	} else {
+		isPublic := true
+		filters.IsPublic = &isPublic
+	}
+	if rule.ListenState != domain.ListenStateAny {
+		listened := rule.ListenState == domain.ListenStateListened
+		if viewerID == nil {
+			if listened {
+				return []*domain.AudioTrack{}, 0, nil
+			}
+		} else {
+			filters.ListenedBy, filters.Listened = viewerID, &listened
+		}
+	}
+
+	tracks, total, err := uc.trackRepo.List(ctx, filters, page)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to evaluate smart collection rule", "error", err, "collectionID", collection.ID)
+		return nil, 0, fmt.Errorf("failed to evaluate smart collection: %w", err)
+	}
+	return tracks, total, nil
+}
+
+// UpdateCollectionMetadata updates title, description and visibility if the collection is still at
+// expectedVersion (0 skips the check) and returns the new version. Editors may change everything
+// but the visibility.
+func (uc *AudioContentUseCase) UpdateCollectionMetadata(ctx context.Context, collectionID domain.CollectionID, title, description string, visibility *domain.CollectionVisibility, expectedVersion int) (int, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return 0, domain.ErrUnauthenticated
+	}
+	if title == "" {
+		return 0, fmt.Errorf("%w: collection title cannot be empty", domain.ErrInvalidArgument)
+	}
+	if visibility != nil && !visibility.IsValid() {
+		return 0, fmt.Errorf("%w: invalid collection visibility '%s'", domain.ErrInvalidArgument, *visibility)
+	}
+	if uc.txManager == nil {
+		return 0, fmt.Errorf("internal configuration error: transaction manager not available")
+	}
+	var newVersion int
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		before, err := uc.collectionRepo.FindByID(txCtx, collectionID)
+		if err != nil {
+			return err // Handles NotFound
+		}
+		role, err := collectionRole(txCtx, uc.memberRepo, before, userID)
+		if err != nil {
+			return err
+		}
+		if !role.CanEdit() {
+			return domain.ErrPermissionDenied
+		}
+		if visibility != nil && *visibility != before.Visibility && role != domain.CollectionRoleOwner {
+			return fmt.Errorf("%w: only the owner can change the visibility of a collection", domain.ErrPermissionDenied)
+		}
+		// The repository layer `UpdateMetadata` also checks ownership in the WHERE clause;
+		// pass the current owner so that editors can update too.
+		tempCollection := &domain.AudioCollection{ID: collectionID, OwnerID: before.OwnerID, Title: title, Description: description, Visibility: before.Visibility, Version: expectedVersion}
+		if visibility != nil {
+			tempCollection.Visibility = *visibility
+		}
+		if err := uc.collectionRepo.UpdateMetadata(txCtx, tempCollection); err != nil {
+			return err
+		}
+		newVersion = tempCollection.Version
+		diff := domain.DiffFields(
+			map[string]any{"title": before.Title, "description": before.Description, "visibility": before.Visibility.String()},
+			map[string]any{"title": title, "description": description, "visibility": tempCollection.Visibility.String()},
+		)
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCollectionUpdate, domain.AuditTargetCollection, collectionID.String(), diff)
+	})
 	if err != nil {
 		// Repository maps "0 rows affected" to domain.ErrNotFound or domain.ErrPermissionDenied
 		if errors.Is(err, domain.ErrNotFound) {
 			uc.logger.WarnContext(ctx, "Update collection metadata failed: Not found", "collectionID", collectionID, "userID", userID)
 		} else if errors.Is(err, domain.ErrPermissionDenied) {
 			uc.logger.WarnContext(ctx, "Update collection metadata failed: Permission denied", "collectionID", collectionID, "userID", userID)
+		} else if errors.Is(err, domain.ErrPreconditionFailed) {
+			uc.logger.WarnContext(ctx, "Update collection metadata failed: Version mismatch", "collectionID", collectionID, "userID", userID, "expectedVersion", expectedVersion)
 		} else {
 			uc.logger.ErrorContext(ctx, "Failed to update collection metadata in repository", "error", err, "collectionID", collectionID, "userID", userID)
 		}
-		return err
+		return 0, err
 	}
-	uc.logger.InfoContext(ctx, "Collection metadata updated", "collectionID", collectionID, "userID", userID)
-	return nil
+	uc.logger.InfoContext(ctx, "Collection metadata updated", "collectionID", collectionID, "userID", userID, "version", newVersion)
+	return newVersion, nil
 }
 
-func (uc *AudioContentUseCase) UpdateCollectionTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID) error {
+// UpdateCollectionTracks replaces the ordered track list if the collection is still at
+// expectedVersion (0 skips the check) and returns the new version.
+func (uc *AudioContentUseCase) UpdateCollectionTracks(ctx context.Context, collectionID domain.CollectionID, orderedTrackIDs []domain.TrackID, expectedVersion int) (int, error) {
 	userID, ok := middleware.GetUserIDFromContext(ctx)
 	if !ok {
-		return domain.ErrUnauthenticated
+		return 0, domain.ErrUnauthenticated
 	}
 	if uc.txManager == nil {
-		return fmt.Errorf("internal configuration error: transaction manager not available")
+		return 0, fmt.Errorf("internal configuration error: transaction manager not available")
 	}
 
+	var newVersion int
 	finalErr := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
-		collection, err := uc.collectionRepo.FindByID(txCtx, collectionID)
+		collection, err := uc.collectionRepo.FindWithTracks(txCtx, collectionID)
 		if err != nil {
 			return err // Handles NotFound
 		}
-		if collection.OwnerID != userID {
-			return domain.ErrPermissionDenied
+		if err := uc.checkTracksEditable(txCtx, collection, userID); err != nil {
+			return err
 		}
 		if len(orderedTrackIDs) > 0 {
 			exists, validateErr := uc.validateTrackIDsExist(txCtx, orderedTrackIDs)
@@ -360,12 +1020,25 @@ func (uc *AudioContentUseCase) UpdateCollectionTracks(ctx context.Context, colle
 			if !exists {
 				return fmt.Errorf("%w: one or more track IDs do not exist", domain.ErrInvalidArgument)
 			}
+			// Tracks already in the collection were checked when they were added
+			for _, trackID := range orderedTrackIDs {
+				if slices.Contains(collection.TrackIDs, trackID) {
+					continue
+				}
+				if err := uc.checkTrackAccessible(txCtx, collection, userID, trackID); err != nil {
+					return err
+				}
+			}
 		}
-		// ManageTracks handles delete/insert/timestamp update within the transaction context
-		if err := uc.collectionRepo.ManageTracks(txCtx, collectionID, orderedTrackIDs); err != nil {
+		// ManageTracks handles the version check and delete/insert/timestamp update within the transaction context
+		newVersion, err = uc.collectionRepo.ManageTracks(txCtx, collectionID, orderedTrackIDs, expectedVersion)
+		if err != nil {
 			return fmt.Errorf("updating collection tracks in repository: %w", err)
 		}
-		return nil
+		before := collectionAuditFields(collection)
+		collection.TrackIDs = orderedTrackIDs
+		diff := domain.DiffFields(before, collectionAuditFields(collection))
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCollectionUpdate, domain.AuditTargetCollection, collectionID.String(), diff)
 	})
 
 	if finalErr != nil {
@@ -375,13 +1048,89 @@ func (uc *AudioContentUseCase) UpdateCollectionTracks(ctx context.Context, colle
 			uc.logger.WarnContext(ctx, "Update collection tracks failed: Permission denied", "collectionID", collectionID, "userID", userID)
 		} else if errors.Is(finalErr, domain.ErrInvalidArgument) {
 			uc.logger.WarnContext(ctx, "Update collection tracks failed: Invalid argument", "collectionID", collectionID, "userID", userID, "error", finalErr)
+		} else if errors.Is(finalErr, domain.ErrPreconditionFailed) {
+			uc.logger.WarnContext(ctx, "Update collection tracks failed: Version mismatch", "collectionID", collectionID, "userID", userID, "expectedVersion", expectedVersion)
 		} else {
 			uc.logger.ErrorContext(ctx, "Transaction failed during collection tracks update", "error", finalErr, "collectionID", collectionID, "userID", userID)
 		}
-		return fmt.Errorf("failed to update collection tracks: %w", finalErr)
+		return 0, fmt.Errorf("failed to update collection tracks: %w", finalErr)
 	}
-	uc.logger.InfoContext(ctx, "Collection tracks updated", "collectionID", collectionID, "userID", userID, "trackCount", len(orderedTrackIDs))
-	return nil
+	uc.logger.InfoContext(ctx, "Collection tracks updated", "collectionID", collectionID, "userID", userID, "trackCount", len(orderedTrackIDs), "version", newVersion)
+	return newVersion, nil
+}
+
+func (uc *AudioContentUseCase) AddCollectionTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error) {
+	return uc.changeCollectionTrack(ctx, collectionID, trackID, expectedVersion, "add", func(txCtx context.Context, collection *domain.AudioCollection, userID domain.UserID) (int, error) {
+		if err := collection.AddTrack(trackID, position); err != nil {
+			return 0, err // Track already in the collection
+		}
+		if err := uc.checkTrackAccessible(txCtx, collection, userID, trackID); err != nil {
+			return 0, err
+		}
+		return uc.collectionRepo.InsertTrack(txCtx, collectionID, trackID, position, expectedVersion)
+	})
+}
+
+func (uc *AudioContentUseCase) MoveCollectionTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, position int, expectedVersion int) (int, error) {
+	return uc.changeCollectionTrack(ctx, collectionID, trackID, expectedVersion, "move", func(txCtx context.Context, collection *domain.AudioCollection, _ domain.UserID) (int, error) {
+		if err := collection.MoveTrack(trackID, position); err != nil {
+			return 0, err // Track not in the collection
+		}
+		return uc.collectionRepo.MoveTrack(txCtx, collectionID, trackID, position, expectedVersion)
+	})
+}
+
+func (uc *AudioContentUseCase) RemoveCollectionTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, expectedVersion int) (int, error) {
+	return uc.changeCollectionTrack(ctx, collectionID, trackID, expectedVersion, "remove", func(txCtx context.Context, collection *domain.AudioCollection, _ domain.UserID) (int, error) {
+		if !collection.RemoveTrack(trackID) {
+			return 0, fmt.Errorf("%w: track %s is not part of the collection", domain.ErrNotFound, trackID)
+		}
+		return uc.collectionRepo.RemoveTrack(txCtx, collectionID, trackID, expectedVersion)
+	})
+}
+
+// changeCollectionTrack runs a single-track change within a transaction after checking that the
+// user may edit the collection's tracks, and records the resulting track list in the audit log.
+// change applies the edit to the loaded collection and persists it, returning the new version.
+func (uc *AudioContentUseCase) changeCollectionTrack(ctx context.Context, collectionID domain.CollectionID, trackID domain.TrackID, expectedVersion int, op string, change func(txCtx context.Context, collection *domain.AudioCollection, userID domain.UserID) (int, error)) (int, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return 0, domain.ErrUnauthenticated
+	}
+	if uc.txManager == nil {
+		return 0, fmt.Errorf("internal configuration error: transaction manager not available")
+	}
+
+	var newVersion int
+	finalErr := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		collection, err := uc.collectionRepo.FindWithTracks(txCtx, collectionID)
+		if err != nil {
+			return err // Handles NotFound
+		}
+		if err := uc.checkTracksEditable(txCtx, collection, userID); err != nil {
+			return err
+		}
+		before := collectionAuditFields(collection)
+		newVersion, err = change(txCtx, collection, userID)
+		if err != nil {
+			return err
+		}
+		diff := domain.DiffFields(before, collectionAuditFields(collection))
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCollectionUpdate, domain.AuditTargetCollection, collectionID.String(), diff)
+	})
+
+	if finalErr != nil {
+		if errors.Is(finalErr, domain.ErrNotFound) || errors.Is(finalErr, domain.ErrPermissionDenied) ||
+			errors.Is(finalErr, domain.ErrInvalidArgument) || errors.Is(finalErr, domain.ErrConflict) ||
+			errors.Is(finalErr, domain.ErrPreconditionFailed) {
+			uc.logger.WarnContext(ctx, "Collection track change rejected", "op", op, "collectionID", collectionID, "trackID", trackID, "userID", userID, "error", finalErr)
+		} else {
+			uc.logger.ErrorContext(ctx, "Transaction failed during collection track change", "op", op, "error", finalErr, "collectionID", collectionID, "trackID", trackID, "userID", userID)
+		}
+		return 0, fmt.Errorf("failed to %s collection track: %w", op, finalErr)
+	}
+	uc.logger.InfoContext(ctx, "Collection track changed", "op", op, "collectionID", collectionID, "trackID", trackID, "userID", userID, "version", newVersion)
+	return newVersion, nil
 }
 
 func (uc *AudioContentUseCase) DeleteCollection(ctx context.Context, collectionID domain.CollectionID) error {
@@ -389,30 +1138,111 @@ func (uc *AudioContentUseCase) DeleteCollection(ctx context.Context, collectionI
 	if !ok {
 		return domain.ErrUnauthenticated
 	}
-	// Check ownership BEFORE attempting delete
-	collection, err := uc.collectionRepo.FindByID(ctx, collectionID)
-	if err != nil {
-		// Log appropriately but return the original error (NotFound or other)
-		if !errors.Is(err, domain.ErrNotFound) {
-			uc.logger.ErrorContext(ctx, "Failed to find collection for deletion check", "error", err, "collectionID", collectionID, "userID", userID)
+	if uc.txManager == nil {
+		return fmt.Errorf("internal configuration error: transaction manager not available")
+	}
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		// Check ownership BEFORE attempting delete
+		collection, err := uc.collectionRepo.FindWithTracks(txCtx, collectionID)
+		if err != nil {
+			// Log appropriately but return the original error (NotFound or other)
+			if !errors.Is(err, domain.ErrNotFound) {
+				uc.logger.ErrorContext(ctx, "Failed to find collection for deletion check", "error", err, "collectionID", collectionID, "userID", userID)
+			}
+			return err
+		}
+		if collection.OwnerID != userID { // Editors cannot delete a collection
+			uc.logger.WarnContext(ctx, "Permission denied for deleting collection", "collectionID", collectionID, "ownerID", collection.OwnerID, "userID", userID)
+			return domain.ErrPermissionDenied
+		}
+
+		// If ownership confirmed, proceed with delete
+		if err := uc.collectionRepo.Delete(txCtx, collectionID); err != nil {
+			// Log if not NotFound (e.g., unexpected DB error during delete)
+			if !errors.Is(err, domain.ErrNotFound) {
+				uc.logger.ErrorContext(ctx, "Failed to delete collection from repository", "error", err, "collectionID", collectionID, "userID", userID)
+			}
+			return err // Return NotFound if it happened (e.g., deleted between check and delete)
 		}
+		diff := domain.DiffFields(collectionAuditFields(collection), nil)
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCollectionDelete, domain.AuditTargetCollection, collectionID.String(), diff)
+	})
+	if err != nil {
 		return err
 	}
-	if collection.OwnerID != userID {
-		uc.logger.WarnContext(ctx, "Permission denied for deleting collection", "collectionID", collectionID, "ownerID", collection.OwnerID, "userID", userID)
-		return domain.ErrPermissionDenied
+	uc.logger.InfoContext(ctx, "Collection deleted", "collectionID", collectionID, "userID", userID)
+	return nil
+}
+
+// viewerFromContext returns the requesting user as a viewer, or nil for anonymous requests.
+func viewerFromContext(userID domain.UserID, authenticated bool) *domain.UserID {
+	if !authenticated {
+		return nil
+	}
+	return &userID
+}
+
+// viewerRole returns the viewer's role in the collection, or "" for anonymous viewers and non-members.
+func (uc *AudioContentUseCase) viewerRole(ctx context.Context, collection *domain.AudioCollection, viewerID *domain.UserID) (domain.CollectionRole, error) {
+	if viewerID == nil {
+		return "", nil
+	}
+	return collectionRole(ctx, uc.memberRepo, collection, *viewerID)
+}
+
+// visibleTracks loads the given tracks in order, dropping those the viewer may not see.
+func (uc *AudioContentUseCase) visibleTracks(ctx context.Context, trackIDs []domain.TrackID, viewerID *domain.UserID) ([]*domain.AudioTrack, error) {
+	tracks, err := uc.trackRepo.ListByIDs(ctx, trackIDs)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to load collection tracks for visibility check", "error", err)
+		return nil, fmt.Errorf("failed to retrieve track details for collection: %w", err)
 	}
+	return slices.DeleteFunc(tracks, func(t *domain.AudioTrack) bool { return !t.IsVisibleTo(viewerID) }), nil
+}
 
-	// If ownership confirmed, proceed with delete
-	err = uc.collectionRepo.Delete(ctx, collectionID)
+// checkTracksEditable verifies that the user may change the collection's track list: they must be its
+// owner or an editor. Smart collections and courses with an outline are changed through their rule and
+// lessons instead.
+func (uc *AudioContentUseCase) checkTracksEditable(ctx context.Context, collection *domain.AudioCollection, userID domain.UserID) error {
+	role, err := collectionRole(ctx, uc.memberRepo, collection, userID)
 	if err != nil {
-		// Log if not NotFound (e.g., unexpected DB error during delete)
-		if !errors.Is(err, domain.ErrNotFound) {
-			uc.logger.ErrorContext(ctx, "Failed to delete collection from repository", "error", err, "collectionID", collectionID, "userID", userID)
+		return err
+	}
+	if !role.CanEdit() {
+		return domain.ErrPermissionDenied
+	}
+	if collection.IsSmart() {
+		return fmt.Errorf("%w: the tracks of a smart collection come from its rule; update the rule instead", domain.ErrConflict)
+	}
+	if collection.Type == domain.TypeCourse {
+		// The track list of a course follows its lessons; it must be changed through the outline
+		sections, err := uc.courseRepo.GetOutline(ctx, collection.ID)
+		if err != nil {
+			return err
+		}
+		if len(sections) > 0 {
+			return fmt.Errorf("%w: this course has an outline; update its lessons instead", domain.ErrConflict)
 		}
-		return err // Return NotFound if it happened (e.g., deleted between check and delete)
 	}
-	uc.logger.InfoContext(ctx, "Collection deleted", "collectionID", collectionID, "userID", userID)
+	return nil
+}
+
+// checkTrackAccessible verifies that a track exists and is public or was uploaded by the collection's
+// owner or the editing user. Other users' private tracks are reported as missing.
+func (uc *AudioContentUseCase) checkTrackAccessible(ctx context.Context, collection *domain.AudioCollection, editorID domain.UserID, trackID domain.TrackID) error {
+	track, err := uc.trackRepo.FindByID(ctx, trackID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("%w: track %s does not exist", domain.ErrInvalidArgument, trackID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to verify track: %w", err)
+	}
+	if track.IsPublic {
+		return nil
+	}
+	if track.UploaderID == nil || (*track.UploaderID != collection.OwnerID && *track.UploaderID != editorID) {
+		return fmt.Errorf("%w: track %s does not exist", domain.ErrInvalidArgument, trackID)
+	}
 	return nil
 }
 
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/middleware"
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	mocks "github.com/yvanyang/language-learning-player-api/internal/mocks/port"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// fakeTxManager runs transactional functions directly.
+type fakeTxManager struct{ port.TransactionManager }
+
+func (fakeTxManager) Execute(ctx context.Context, fn func(txCtx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeAuditRepo discards audit events.
+type fakeAuditRepo struct{ port.AuditEventRepository }
+
+func (fakeAuditRepo) Append(context.Context, *domain.AuditEvent) error { return nil }
+
+// fakeChapterRepo returns no chapters.
+type fakeChapterRepo struct{ port.TrackChapterRepository }
+
+func (fakeChapterRepo) ListByTrack(context.Context, domain.TrackID) ([]domain.TrackChapter, error) {
+	return []domain.TrackChapter{}, nil
+}
+
+func newTestAudioContentUseCase(t *testing.T) (*AudioContentUseCase, *mocks.MockAudioTrackRepository, *mocks.MockAudioCollectionRepository) {
+	trackRepo := mocks.NewMockAudioTrackRepository(t)
+	collectionRepo := mocks.NewMockAudioCollectionRepository(t)
+	uc := NewAudioContentUseCase(config.Config{}, trackRepo, collectionRepo, nil, fakeTxManager{}, fakeAuditRepo{}, nil, nil, nil, nil, fakeChapterRepo{}, nil,
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return uc, trackRepo, collectionRepo
+}
+
+func withUser(userID domain.UserID) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func privateTrack(uploaderID domain.UserID) *domain.AudioTrack {
+	return &domain.AudioTrack{ID: domain.NewTrackID(), Title: "Private", UploaderID: &uploaderID}
+}
+
+func TestGetTrackChapters_PrivateTrackAccess(t *testing.T) {
+	uploaderID := domain.NewUserID()
+	memberID := domain.NewUserID()
+
+	t.Run("uploader", func(t *testing.T) {
+		uc, trackRepo, _ := newTestAudioContentUseCase(t)
+		track := privateTrack(uploaderID)
+		trackRepo.EXPECT().FindByID(mock.Anything, track.ID).Return(track, nil)
+
+		_, err := uc.GetTrackChapters(withUser(uploaderID), track.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		uc, trackRepo, _ := newTestAudioContentUseCase(t)
+		track := privateTrack(uploaderID)
+		trackRepo.EXPECT().FindByID(mock.Anything, track.ID).Return(track, nil)
+
+		_, err := uc.GetTrackChapters(context.Background(), track.ID)
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+
+	t.Run("member of a collection the owner added the track to", func(t *testing.T) {
+		uc, trackRepo, collectionRepo := newTestAudioContentUseCase(t)
+		track := privateTrack(uploaderID)
+		trackRepo.EXPECT().FindByID(mock.Anything, track.ID).Return(track, nil)
+		collectionRepo.EXPECT().HasAccessibleWithTrack(mock.Anything, memberID, track.ID).Return(true, nil)
+
+		_, err := uc.GetTrackChapters(withUser(memberID), track.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("track smuggled into the caller's own collection", func(t *testing.T) {
+		uc, trackRepo, collectionRepo := newTestAudioContentUseCase(t)
+		track := privateTrack(uploaderID)
+		trackRepo.EXPECT().FindByID(mock.Anything, track.ID).Return(track, nil)
+		// The repository only counts tracks uploaded by the collection's owner or editors
+		collectionRepo.EXPECT().HasAccessibleWithTrack(mock.Anything, memberID, track.ID).Return(false, nil)
+
+		_, err := uc.GetTrackChapters(withUser(memberID), track.ID)
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	})
+}
+
+func TestUpdateCollectionTracks_TrackAccess(t *testing.T) {
+	ownerID := domain.NewUserID()
+	otherID := domain.NewUserID()
+
+	newPlaylist := func(t *testing.T) *domain.AudioCollection {
+		collection, err := domain.NewAudioCollection("Mine", "", ownerID, domain.TypePlaylist)
+		require.NoError(t, err)
+		return collection
+	}
+
+	t.Run("owner adds their own private track", func(t *testing.T) {
+		uc, trackRepo, collectionRepo := newTestAudioContentUseCase(t)
+		collection := newPlaylist(t)
+		track := privateTrack(ownerID)
+		collectionRepo.EXPECT().FindWithTracks(mock.Anything, collection.ID).Return(collection, nil)
+		trackRepo.EXPECT().ListByIDs(mock.Anything, []domain.TrackID{track.ID}).Return([]*domain.AudioTrack{track}, nil)
+		trackRepo.EXPECT().FindByID(mock.Anything, track.ID).Return(track, nil)
+		collectionRepo.EXPECT().ManageTracks(mock.Anything, collection.ID, []domain.TrackID{track.ID}, 0).Return(2, nil)
+
+		version, err := uc.UpdateCollectionTracks(withUser(ownerID), collection.ID, []domain.TrackID{track.ID}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, version)
+	})
+
+	t.Run("another user's private track is refused", func(t *testing.T) {
+		uc, trackRepo, collectionRepo := newTestAudioContentUseCase(t)
+		collection := newPlaylist(t)
+		track := privateTrack(otherID)
+		collectionRepo.EXPECT().FindWithTracks(mock.Anything, collection.ID).Return(collection, nil)
+		trackRepo.EXPECT().ListByIDs(mock.Anything, []domain.TrackID{track.ID}).Return([]*domain.AudioTrack{track}, nil)
+		trackRepo.EXPECT().FindByID(mock.Anything, track.ID).Return(track, nil)
+
+		_, err := uc.UpdateCollectionTracks(withUser(ownerID), collection.ID, []domain.TrackID{track.ID}, 0)
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+		collectionRepo.AssertNotCalled(t, "ManageTracks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("tracks already in the collection are not checked again", func(t *testing.T) {
+		uc, trackRepo, collectionRepo := newTestAudioContentUseCase(t)
+		collection := newPlaylist(t)
+		existing := privateTrack(otherID) // e.g. added by a former editor
+		collection.TrackIDs = []domain.TrackID{existing.ID}
+		collectionRepo.EXPECT().FindWithTracks(mock.Anything, collection.ID).Return(collection, nil)
+		trackRepo.EXPECT().ListByIDs(mock.Anything, []domain.TrackID{existing.ID}).Return([]*domain.AudioTrack{existing}, nil)
+		collectionRepo.EXPECT().ManageTracks(mock.Anything, collection.ID, []domain.TrackID{existing.ID}, 0).Return(2, nil)
+
+		_, err := uc.UpdateCollectionTracks(withUser(ownerID), collection.ID, []domain.TrackID{existing.ID}, 0)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateCollection_RefusesOtherUsersPrivateTracks(t *testing.T) {
+	uc, trackRepo, collectionRepo := newTestAudioContentUseCase(t)
+	ownerID := domain.NewUserID()
+	track := privateTrack(domain.NewUserID())
+	collectionRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil)
+	trackRepo.EXPECT().ListByIDs(mock.Anything, []domain.TrackID{track.ID}).Return([]*domain.AudioTrack{track}, nil)
+	trackRepo.EXPECT().FindByID(mock.Anything, track.ID).Return(track, nil)
+
+	_, err := uc.CreateCollection(withUser(ownerID), "Mine", "", domain.TypePlaylist, "", []domain.TrackID{track.ID}, nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	collectionRepo.AssertNotCalled(t, "ManageTracks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
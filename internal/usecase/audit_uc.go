@@ -0,0 +1,139 @@
+// internal/usecase/audit_uc.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/httputil"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+)
+
+// recordAudit appends an audit event, stamping it with the request ID and client IP
+// from ctx. Call it with the transaction context inside TransactionManager.Execute so
+// the event is only persisted if the change it describes is committed.
+func recordAudit(ctx context.Context, repo port.AuditEventRepository, actorID *domain.UserID, action domain.AuditAction, targetType domain.AuditTargetType, targetID string, diff domain.AuditDiff) error {
+	event, err := domain.NewAuditEvent(actorID, action, targetType, targetID, diff)
+	if err != nil {
+		return err
+	}
+	event.RequestID = httputil.GetReqID(ctx)
+	event.IPAddress = httputil.GetClientIP(ctx)
+	if err := repo.Append(ctx, event); err != nil {
+		return fmt.Errorf("recording audit event %s: %w", action, err)
+	}
+	return nil
+}
+
+// trackAuditFields returns the audited fields of a track, for use with domain.DiffFields.
+func trackAuditFields(t *domain.AudioTrack) map[string]any {
+	if t == nil {
+		return nil
+	}
+	return map[string]any{
+		"title":        t.Title,
+		"description":  t.Description,
+		"languageCode": t.Language.Code(),
+		"level":        t.Level.String(),
+		"durationMs":   t.Duration.Milliseconds(),
+		"objectKey":    t.MinioObjectKey,
+		"isPublic":     t.IsPublic,
+		"tags":         t.Tags,
+	}
+}
+
+// collectionAuditFields returns the audited fields of a collection, for use with domain.DiffFields.
+func collectionAuditFields(c *domain.AudioCollection) map[string]any {
+	if c == nil {
+		return nil
+	}
+	trackIDs := make([]string, len(c.TrackIDs))
+	for i, id := range c.TrackIDs {
+		trackIDs[i] = id.String()
+	}
+	fields := map[string]any{
+		"title":       c.Title,
+		"description": c.Description,
+		"type":        c.Type.String(),
+		"visibility":  c.Visibility.String(),
+		"trackIds":    trackIDs,
+	}
+	if rule := c.SmartRule; rule != nil {
+		uploaderID := ""
+		if rule.UploaderID != nil {
+			uploaderID = rule.UploaderID.String()
+		}
+		fields["smartRule"] = map[string]any{
+			"languageCode":  rule.LanguageCode,
+			"level":         rule.Level.String(),
+			"tags":          rule.Tags,
+			"minDurationMs": rule.MinDuration.Milliseconds(),
+			"maxDurationMs": rule.MaxDuration.Milliseconds(),
+			"uploaderId":    uploaderID,
+			"listenState":   rule.ListenState.String(),
+			"sortBy":        rule.SortBy,
+			"sortDirection": rule.SortDirection,
+		}
+	}
+	return fields
+}
+
+// chapterAuditFields returns the chapters of a track as an audited field, for use with domain.DiffFields.
+func chapterAuditFields(chapters []domain.TrackChapter) map[string]any {
+	values := make([]map[string]any, len(chapters))
+	for i, c := range chapters {
+		values[i] = map[string]any{
+			"title":       c.Title,
+			"description": c.Description,
+			"startMs":     c.Start.Milliseconds(),
+			"endMs":       c.End.Milliseconds(),
+		}
+	}
+	return map[string]any{"chapters": values}
+}
+
+// AuditUseCase provides read access to the audit log for administrators.
+type AuditUseCase struct {
+	auditRepo port.AuditEventRepository
+	userRepo  port.UserRepository
+	logger    *slog.Logger
+}
+
+// NewAuditUseCase creates a new AuditUseCase.
+func NewAuditUseCase(ar port.AuditEventRepository, ur port.UserRepository, log *slog.Logger) *AuditUseCase {
+	return &AuditUseCase{
+		auditRepo: ar,
+		userRepo:  ur,
+		logger:    log.With("usecase", "AuditUseCase"),
+	}
+}
+
+// ListAuditEvents returns audit events matching the filter. Only admins may query the log.
+func (uc *AuditUseCase) ListAuditEvents(ctx context.Context, input port.ListAuditEventsInput) ([]*domain.AuditEvent, int, pagination.Page, error) {
+	pageParams := pagination.NewPageFromOffset(input.Page.Limit, input.Page.Offset)
+
+	if _, err := requireAdmin(ctx, uc.userRepo); err != nil {
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			uc.logger.WarnContext(ctx, "Non-admin attempted to read audit log")
+		}
+		return nil, 0, pageParams, err
+	}
+
+	if input.Filter.From != nil && input.Filter.To != nil && !input.Filter.From.Before(*input.Filter.To) {
+		return nil, 0, pageParams, fmt.Errorf("%w: 'from' must be before 'to'", domain.ErrInvalidArgument)
+	}
+
+	events, total, err := uc.auditRepo.List(ctx, input.Filter, pageParams)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to list audit events", "error", err, "filter", input.Filter, "page", pageParams)
+		return nil, 0, pageParams, fmt.Errorf("failed to retrieve audit events: %w", err)
+	}
+	return events, total, pageParams, nil
+}
+
+// Compile-time check to ensure AuditUseCase satisfies the port.AuditUseCase interface
+var _ port.AuditUseCase = (*AuditUseCase)(nil)
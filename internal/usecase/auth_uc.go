@@ -20,6 +20,9 @@ type AuthUseCase struct {
 	refreshTokenRepo port.RefreshTokenRepository // Dependency for refresh token storage
 	secHelper        port.SecurityHelper
 	extAuthService   port.ExternalAuthService
+	txManager        port.TransactionManager
+	auditRepo        port.AuditEventRepository
+	metrics          port.MetricsRecorder
 	cfg              config.JWTConfig // Store the whole JWT config for expiries
 	logger           *slog.Logger
 }
@@ -31,6 +34,9 @@ func NewAuthUseCase(
 	rtr port.RefreshTokenRepository, // Inject RefreshTokenRepository
 	sh port.SecurityHelper,
 	eas port.ExternalAuthService,
+	tm port.TransactionManager,
+	ar port.AuditEventRepository,
+	mr port.MetricsRecorder,
 	log *slog.Logger,
 ) *AuthUseCase {
 	if eas == nil {
@@ -46,6 +52,9 @@ func NewAuthUseCase(
 		refreshTokenRepo: rtr, // Assign injected repo
 		secHelper:        sh,
 		extAuthService:   eas,
+		txManager:        tm,
+		auditRepo:        ar,
+		metrics:          mr,
 		cfg:              cfg, // Store config
 		logger:           log.With("usecase", "AuthUseCase"),
 	}
@@ -121,17 +130,27 @@ func (uc *AuthUseCase) RegisterWithPassword(ctx context.Context, emailStr, passw
 		return nil, port.AuthResult{}, fmt.Errorf("failed to create user data: %w", err)
 	}
 
-	if err := uc.userRepo.Create(ctx, user); err != nil {
-		uc.logger.ErrorContext(ctx, "Failed to save new user to repository", "error", err, "userID", user.ID)
-		// Create should map unique constraints to ErrConflict
-		return nil, port.AuthResult{}, fmt.Errorf("failed to register user: %w", err)
-	}
+	// Create the user, its first session and the audit record atomically.
+	var accessToken, refreshToken string
+	txErr := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := uc.userRepo.Create(txCtx, user); err != nil {
+			uc.logger.ErrorContext(ctx, "Failed to save new user to repository", "error", err, "userID", user.ID)
+			// Create should map unique constraints to ErrConflict
+			return fmt.Errorf("failed to register user: %w", err)
+		}
 
-	// Generate and store tokens
-	accessToken, refreshToken, tokenErr := uc.generateAndStoreTokens(ctx, user.ID)
-	if tokenErr != nil {
-		uc.logger.ErrorContext(ctx, "Failed to generate/store tokens after registration", "error", tokenErr, "userID", user.ID)
-		return nil, port.AuthResult{}, fmt.Errorf("failed to finalize registration session: %w", tokenErr)
+		var tokenErr error
+		accessToken, refreshToken, tokenErr = uc.generateAndStoreTokens(txCtx, user.ID)
+		if tokenErr != nil {
+			uc.logger.ErrorContext(ctx, "Failed to generate/store tokens after registration", "error", tokenErr, "userID", user.ID)
+			return fmt.Errorf("failed to finalize registration session: %w", tokenErr)
+		}
+
+		diff := domain.DiffFields(nil, map[string]any{"email": user.Email.String(), "name": user.Name, "authProvider": user.AuthProvider})
+		return recordAudit(txCtx, uc.auditRepo, &user.ID, domain.AuditActionUserRegister, domain.AuditTargetUser, user.ID.String(), diff)
+	})
+	if txErr != nil {
+		return nil, port.AuthResult{}, txErr
 	}
 
 	uc.logger.InfoContext(ctx, "User registered successfully via password", "userID", user.ID, "email", emailStr)
@@ -156,6 +175,7 @@ func (uc *AuthUseCase) LoginWithPassword(ctx context.Context, emailStr, password
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			uc.logger.WarnContext(ctx, "Login attempt for non-existent email", "email", emailStr)
+			uc.recordLoginFailure(ctx, nil, emailStr, "unknown_email")
 			return port.AuthResult{}, domain.ErrAuthenticationFailed
 		}
 		uc.logger.ErrorContext(ctx, "Error finding user by email during login", "error", err, "email", emailStr)
@@ -163,21 +183,32 @@ func (uc *AuthUseCase) LoginWithPassword(ctx context.Context, emailStr, password
 	}
 	if user.AuthProvider != domain.AuthProviderLocal || user.HashedPassword == nil {
 		uc.logger.WarnContext(ctx, "Login attempt for user with non-local provider or no password", "email", emailStr, "userID", user.ID, "provider", user.AuthProvider)
+		uc.recordLoginFailure(ctx, user, emailStr, "password_not_set")
 		return port.AuthResult{}, domain.ErrAuthenticationFailed
 	}
 	if !uc.secHelper.CheckPasswordHash(ctx, password, *user.HashedPassword) {
 		uc.logger.WarnContext(ctx, "Incorrect password provided for user", "email", emailStr, "userID", user.ID)
+		uc.recordLoginFailure(ctx, user, emailStr, "invalid_password")
 		return port.AuthResult{}, domain.ErrAuthenticationFailed
 	}
 
 	// Generate and store tokens
-	accessToken, refreshToken, tokenErr := uc.generateAndStoreTokens(ctx, user.ID)
-	if tokenErr != nil {
-		uc.logger.ErrorContext(ctx, "Failed to generate/store tokens during login", "error", tokenErr, "userID", user.ID)
-		return port.AuthResult{}, fmt.Errorf("failed to finalize login session: %w", tokenErr)
+	var accessToken, refreshToken string
+	txErr := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var tokenErr error
+		accessToken, refreshToken, tokenErr = uc.generateAndStoreTokens(txCtx, user.ID)
+		if tokenErr != nil {
+			uc.logger.ErrorContext(ctx, "Failed to generate/store tokens during login", "error", tokenErr, "userID", user.ID)
+			return fmt.Errorf("failed to finalize login session: %w", tokenErr)
+		}
+		return recordAudit(txCtx, uc.auditRepo, &user.ID, domain.AuditActionLoginSuccess, domain.AuditTargetUser, user.ID.String(), nil)
+	})
+	if txErr != nil {
+		return port.AuthResult{}, txErr
 	}
 
 	uc.logger.InfoContext(ctx, "User logged in successfully via password", "userID", user.ID)
+	uc.metrics.LoginSucceeded(domain.AuthProviderLocal)
 	return port.AuthResult{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -230,19 +261,13 @@ func (uc *AuthUseCase) AuthenticateWithGoogle(ctx context.Context, googleIdToken
 			uc.logger.InfoContext(ctx, "Google token verified, but no email provided. Proceeding to create new user based on Google ID only.", "googleID", extInfo.ProviderUserID)
 		}
 
-		// Case 3: Create new user
+		// Case 3: Create new user (persisted below, together with the session)
 		uc.logger.InfoContext(ctx, "Creating new user via Google authentication", "googleID", extInfo.ProviderUserID, "email", extInfo.Email)
 		newUser, errCreate := domain.NewGoogleUser(extInfo.Email, extInfo.Name, extInfo.ProviderUserID, extInfo.PictureURL)
 		if errCreate != nil {
 			uc.logger.ErrorContext(ctx, "Failed to create new Google user domain object", "error", errCreate, "extInfo", extInfo)
 			return port.AuthResult{}, fmt.Errorf("failed to process user data from Google: %w", errCreate)
 		}
-		if errDb := uc.userRepo.Create(ctx, newUser); errDb != nil {
-			uc.logger.ErrorContext(ctx, "Failed to save new Google user to repository", "error", errDb, "googleID", newUser.GoogleID, "email", newUser.Email.String())
-			// Create maps unique constraints to ErrConflict
-			return port.AuthResult{}, fmt.Errorf("failed to create new user account: %w", errDb)
-		}
-		uc.logger.InfoContext(ctx, "New user created successfully via Google", "userID", newUser.ID, "email", newUser.Email.String())
 		targetUser = newUser
 		isNewUser = true
 
@@ -252,13 +277,35 @@ func (uc *AuthUseCase) AuthenticateWithGoogle(ctx context.Context, googleIdToken
 		return port.AuthResult{}, fmt.Errorf("database error during authentication: %w", err)
 	}
 
-	// Generate and store tokens for the targetUser (either found or newly created)
-	accessToken, refreshToken, tokenErr := uc.generateAndStoreTokens(ctx, targetUser.ID)
-	if tokenErr != nil {
-		uc.logger.ErrorContext(ctx, "Failed to generate/store tokens for Google auth", "error", tokenErr, "userID", targetUser.ID)
-		return port.AuthResult{}, fmt.Errorf("failed to finalize authentication session: %w", tokenErr)
+	var accessToken, refreshToken string
+	txErr := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if isNewUser {
+			if errDb := uc.userRepo.Create(txCtx, targetUser); errDb != nil {
+				uc.logger.ErrorContext(ctx, "Failed to save new Google user to repository", "error", errDb, "googleID", targetUser.GoogleID, "email", targetUser.Email.String())
+				// Create maps unique constraints to ErrConflict
+				return fmt.Errorf("failed to create new user account: %w", errDb)
+			}
+			uc.logger.InfoContext(ctx, "New user created successfully via Google", "userID", targetUser.ID, "email", targetUser.Email.String())
+			diff := domain.DiffFields(nil, map[string]any{"email": targetUser.Email.String(), "name": targetUser.Name, "authProvider": targetUser.AuthProvider})
+			if err := recordAudit(txCtx, uc.auditRepo, &targetUser.ID, domain.AuditActionUserRegister, domain.AuditTargetUser, targetUser.ID.String(), diff); err != nil {
+				return err
+			}
+		}
+
+		// Generate and store tokens for the targetUser (either found or newly created)
+		var tokenErr error
+		accessToken, refreshToken, tokenErr = uc.generateAndStoreTokens(txCtx, targetUser.ID)
+		if tokenErr != nil {
+			uc.logger.ErrorContext(ctx, "Failed to generate/store tokens for Google auth", "error", tokenErr, "userID", targetUser.ID)
+			return fmt.Errorf("failed to finalize authentication session: %w", tokenErr)
+		}
+		return recordAudit(txCtx, uc.auditRepo, &targetUser.ID, domain.AuditActionLoginSuccess, domain.AuditTargetUser, targetUser.ID.String(), nil)
+	})
+	if txErr != nil {
+		return port.AuthResult{}, txErr
 	}
 
+	uc.metrics.LoginSucceeded(domain.AuthProviderGoogle)
 	return port.AuthResult{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -301,21 +348,29 @@ func (uc *AuthUseCase) RefreshAccessToken(ctx context.Context, refreshTokenValue
 		return port.AuthResult{}, fmt.Errorf("%w: refresh token expired", domain.ErrAuthenticationFailed)
 	}
 
-	// --- Rotation: Invalidate the old token ---
-	delErr := uc.refreshTokenRepo.DeleteByTokenHash(ctx, tokenHash)
-	if delErr != nil && !errors.Is(delErr, domain.ErrNotFound) {
-		// Log the failure but proceed to issue new tokens.
-		// The user presented a valid token, failure to delete shouldn't block refresh.
-		// A background job could clean up orphaned tokens later if needed.
-		uc.logger.ErrorContext(ctx, "Failed to delete used refresh token during rotation, proceeding anyway", "error", delErr, "userID", tokenData.UserID)
-	}
+	var newAccessToken, newRefreshTokenValue string
+	txErr := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		// --- Rotation: Invalidate the old token ---
+		// A failed statement aborts the transaction, so a delete error fails the refresh.
+		// ErrNotFound (token already rotated concurrently) is not a database error and is tolerated.
+		delErr := uc.refreshTokenRepo.DeleteByTokenHash(txCtx, tokenHash)
+		if delErr != nil && !errors.Is(delErr, domain.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "Failed to delete used refresh token during rotation", "error", delErr, "userID", tokenData.UserID)
+			return fmt.Errorf("failed to rotate refresh token: %w", delErr)
+		}
 
-	// --- Issue new tokens ---
-	newAccessToken, newRefreshTokenValue, tokenErr := uc.generateAndStoreTokens(ctx, tokenData.UserID)
-	if tokenErr != nil {
-		uc.logger.ErrorContext(ctx, "Failed to generate/store new tokens during refresh", "error", tokenErr, "userID", tokenData.UserID)
-		// This is a more critical failure. User might be left logged out.
-		return port.AuthResult{}, fmt.Errorf("failed to issue new tokens after validating refresh token: %w", tokenErr)
+		// --- Issue new tokens ---
+		var tokenErr error
+		newAccessToken, newRefreshTokenValue, tokenErr = uc.generateAndStoreTokens(txCtx, tokenData.UserID)
+		if tokenErr != nil {
+			uc.logger.ErrorContext(ctx, "Failed to generate/store new tokens during refresh", "error", tokenErr, "userID", tokenData.UserID)
+			// This is a more critical failure. User might be left logged out.
+			return fmt.Errorf("failed to issue new tokens after validating refresh token: %w", tokenErr)
+		}
+		return recordAudit(txCtx, uc.auditRepo, &tokenData.UserID, domain.AuditActionTokenRefresh, domain.AuditTargetUser, tokenData.UserID.String(), nil)
+	})
+	if txErr != nil {
+		return port.AuthResult{}, txErr
 	}
 
 	uc.logger.InfoContext(ctx, "Access token refreshed successfully", "userID", tokenData.UserID)
@@ -333,7 +388,16 @@ func (uc *AuthUseCase) Logout(ctx context.Context, userID domain.UserID) error {
 	}
 
 	// Delete all tokens associated with the user ID
-	deletedCount, err := uc.refreshTokenRepo.DeleteByUser(ctx, userID)
+	var deletedCount int64
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var delErr error
+		deletedCount, delErr = uc.refreshTokenRepo.DeleteByUser(txCtx, userID)
+		if delErr != nil {
+			return delErr
+		}
+		diff := domain.AuditDiff{"revokedSessions": {Old: nil, New: deletedCount}}
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionLogout, domain.AuditTargetUser, userID.String(), diff)
+	})
 	if err != nil {
 		// Log actual errors during deletion but don't necessarily fail the logout flow for the client
 		uc.logger.ErrorContext(ctx, "Failed to delete refresh tokens during logout", "error", err, "userID", userID)
@@ -348,5 +412,20 @@ func (uc *AuthUseCase) Logout(ctx context.Context, userID domain.UserID) error {
 	return nil
 }
 
+// recordLoginFailure writes a failed login to the audit log. There is no transaction to
+// join, and an audit write failure must not change the response, so errors are only logged.
+// The caller is unauthenticated, so the event has no actor. user is nil when the email
+// is unknown; the attempted email is then used as the target.
+func (uc *AuthUseCase) recordLoginFailure(ctx context.Context, user *domain.User, emailStr, reason string) {
+	targetID := emailStr
+	if user != nil {
+		targetID = user.ID.String()
+	}
+	diff := domain.AuditDiff{"reason": {Old: nil, New: reason}}
+	if err := recordAudit(ctx, uc.auditRepo, nil, domain.AuditActionLoginFailure, domain.AuditTargetUser, targetID, diff); err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to record login failure in audit log", "error", err, "email", emailStr)
+	}
+}
+
 // Compile-time check to ensure AuthUseCase satisfies the port.AuthUseCase interface
 var _ port.AuthUseCase = (*AuthUseCase)(nil)
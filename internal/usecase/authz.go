@@ -0,0 +1,43 @@
+// internal/usecase/authz.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/yvanyang/language-learning-player-api/internal/adapter/handler/http/middleware"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// requireAdmin returns the ID of the authenticated user if they are an administrator.
+func requireAdmin(ctx context.Context, userRepo port.UserRepository) (domain.UserID, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return domain.UserID{}, domain.ErrUnauthenticated
+	}
+	user, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return domain.UserID{}, fmt.Errorf("failed to verify permissions: %w", err)
+	}
+	if !user.IsAdmin() {
+		return domain.UserID{}, domain.ErrPermissionDenied
+	}
+	return userID, nil
+}
+
+// collectionRole returns the user's role in the collection, or "" if they are neither its owner nor a member.
+func collectionRole(ctx context.Context, memberRepo port.CollectionMemberRepository, collection *domain.AudioCollection, userID domain.UserID) (domain.CollectionRole, error) {
+	if collection.OwnerID == userID {
+		return domain.CollectionRoleOwner, nil
+	}
+	member, err := memberRepo.FindMember(ctx, collection.ID, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to verify permissions: %w", err)
+	}
+	return member.Role, nil
+}
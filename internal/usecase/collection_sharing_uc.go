@@ -0,0 +1,351 @@
+// internal/usecase/collection_sharing_uc.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// CollectionSharingUseCase lets owners share collections with editors and viewers. Users join a
+// collection by accepting an invitation; the owner can remove members or hand the collection over.
+type CollectionSharingUseCase struct {
+	collectionRepo port.AudioCollectionRepository
+	memberRepo     port.CollectionMemberRepository
+	userRepo       port.UserRepository
+	txManager      port.TransactionManager
+	auditRepo      port.AuditEventRepository
+	outboxRepo     port.OutboxRepository
+	logger         *slog.Logger
+}
+
+// NewCollectionSharingUseCase creates a new CollectionSharingUseCase.
+func NewCollectionSharingUseCase(
+	acr port.AudioCollectionRepository,
+	mr port.CollectionMemberRepository,
+	ur port.UserRepository,
+	tm port.TransactionManager,
+	ar port.AuditEventRepository,
+	or port.OutboxRepository,
+	log *slog.Logger,
+) *CollectionSharingUseCase {
+	return &CollectionSharingUseCase{
+		collectionRepo: acr,
+		memberRepo:     mr,
+		userRepo:       ur,
+		txManager:      tm,
+		auditRepo:      ar,
+		outboxRepo:     or,
+		logger:         log.With("usecase", "CollectionSharingUseCase"),
+	}
+}
+
+// InviteCollaborator invites a user to a collection owned by the inviter. Invitations by email
+// address are linked to the account with that address if there is one.
+func (uc *CollectionSharingUseCase) InviteCollaborator(ctx context.Context, input port.InviteCollaboratorInput) (*domain.CollectionInvitation, error) {
+	log := uc.logger.With("collectionID", input.CollectionID.String(), "userID", input.InviterID.String())
+	inviteeID := input.InviteeID
+	if inviteeID != nil {
+		if _, err := uc.userRepo.FindByID(ctx, *inviteeID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, fmt.Errorf("%w: user %s does not exist", domain.ErrInvalidArgument, inviteeID)
+			}
+			return nil, fmt.Errorf("failed to look up invitee: %w", err)
+		}
+	} else if input.Email != "" {
+		email, err := domain.NewEmail(input.Email)
+		if err != nil {
+			return nil, err
+		}
+		user, err := uc.userRepo.FindByEmail(ctx, email)
+		if err == nil {
+			inviteeID = &user.ID
+		} else if !errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("failed to look up invitee: %w", err)
+		}
+	}
+	invitation, err := domain.NewCollectionInvitation(input.CollectionID, input.InviterID, inviteeID, input.Email, input.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if _, err := uc.findOwnedCollection(txCtx, input.InviterID, input.CollectionID); err != nil {
+			return err
+		}
+		if inviteeID != nil {
+			_, err := uc.memberRepo.FindMember(txCtx, input.CollectionID, *inviteeID)
+			if err == nil {
+				return fmt.Errorf("%w: this user is already a member of the collection", domain.ErrConflict)
+			}
+			if !errors.Is(err, domain.ErrNotFound) {
+				return err
+			}
+		}
+		if err := uc.memberRepo.CreateInvitation(txCtx, invitation); err != nil {
+			return err
+		}
+		diff := domain.DiffFields(nil, invitationAuditFields(invitation))
+		if err := recordAudit(txCtx, uc.auditRepo, &input.InviterID, domain.AuditActionCollectionInvite, domain.AuditTargetCollection, input.CollectionID.String(), diff); err != nil {
+			return err
+		}
+		data := map[string]any{
+			"invitationId": invitation.ID.String(),
+			"collectionId": input.CollectionID.String(),
+			"inviterId":    input.InviterID.String(),
+			"role":         invitation.Role,
+		}
+		if invitation.InviteeID != nil {
+			data["inviteeId"] = invitation.InviteeID.String()
+		}
+		if invitation.InviteeEmail != "" {
+			data["inviteeEmail"] = invitation.InviteeEmail
+		}
+		return publishEvent(txCtx, uc.outboxRepo, domain.EventCollectionInvited, data)
+	})
+	if err != nil {
+		log.WarnContext(ctx, "Inviting collaborator failed", "error", err)
+		return nil, err
+	}
+	log.InfoContext(ctx, "Collaborator invited", "invitationID", invitation.ID, "role", invitation.Role)
+	return invitation, nil
+}
+
+// ListCollectionInvitations lists the pending invitations of a collection owned by the user.
+func (uc *CollectionSharingUseCase) ListCollectionInvitations(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) ([]*domain.CollectionInvitation, error) {
+	if _, err := uc.findOwnedCollection(ctx, userID, collectionID); err != nil {
+		return nil, err
+	}
+	return uc.memberRepo.ListPendingInvitations(ctx, collectionID)
+}
+
+// RevokeInvitation withdraws a pending invitation to a collection owned by the user.
+func (uc *CollectionSharingUseCase) RevokeInvitation(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID, invitationID domain.InvitationID) error {
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if _, err := uc.findOwnedCollection(txCtx, userID, collectionID); err != nil {
+			return err
+		}
+		invitation, err := uc.memberRepo.FindInvitation(txCtx, invitationID)
+		if err != nil {
+			return err
+		}
+		if invitation.CollectionID != collectionID {
+			return domain.ErrNotFound
+		}
+		if err := invitation.Revoke(); err != nil {
+			return err
+		}
+		return uc.memberRepo.UpdateInvitation(txCtx, invitation)
+	})
+	if err != nil {
+		uc.logger.WarnContext(ctx, "Revoking invitation failed", "error", err, "invitationID", invitationID, "userID", userID)
+		return err
+	}
+	uc.logger.InfoContext(ctx, "Invitation revoked", "invitationID", invitationID, "collectionID", collectionID, "userID", userID)
+	return nil
+}
+
+// ListMyInvitations lists the pending invitations addressed to the user by ID or by email address.
+func (uc *CollectionSharingUseCase) ListMyInvitations(ctx context.Context, userID domain.UserID) ([]*domain.CollectionInvitation, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	return uc.memberRepo.ListPendingInvitationsFor(ctx, userID, user.Email.String())
+}
+
+// AcceptInvitation makes the user a member of the collection with the invited role.
+func (uc *CollectionSharingUseCase) AcceptInvitation(ctx context.Context, userID domain.UserID, invitationID domain.InvitationID) (*domain.CollectionMember, error) {
+	var member *domain.CollectionMember
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		invitation, err := uc.findInvitationFor(txCtx, userID, invitationID)
+		if err != nil {
+			return err
+		}
+		collection, err := uc.collectionRepo.FindByID(txCtx, invitation.CollectionID)
+		if err != nil {
+			return err
+		}
+		if collection.OwnerID == userID {
+			return fmt.Errorf("%w: you already own this collection", domain.ErrConflict)
+		}
+		member, err = invitation.Accept(userID)
+		if err != nil {
+			return err
+		}
+		if err := uc.memberRepo.UpdateInvitation(txCtx, invitation); err != nil {
+			return err
+		}
+		if err := uc.memberRepo.SaveMember(txCtx, member); err != nil {
+			return err
+		}
+		diff := domain.DiffFields(nil, map[string]any{"memberId": userID.String(), "role": member.Role.String()})
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCollectionMemberAdd, domain.AuditTargetCollection, collection.ID.String(), diff)
+	})
+	if err != nil {
+		uc.logger.WarnContext(ctx, "Accepting invitation failed", "error", err, "invitationID", invitationID, "userID", userID)
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "Invitation accepted", "invitationID", invitationID, "collectionID", member.CollectionID, "userID", userID, "role", member.Role)
+	return member, nil
+}
+
+// DeclineInvitation turns down an invitation addressed to the user.
+func (uc *CollectionSharingUseCase) DeclineInvitation(ctx context.Context, userID domain.UserID, invitationID domain.InvitationID) error {
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		invitation, err := uc.findInvitationFor(txCtx, userID, invitationID)
+		if err != nil {
+			return err
+		}
+		if err := invitation.Decline(); err != nil {
+			return err
+		}
+		return uc.memberRepo.UpdateInvitation(txCtx, invitation)
+	})
+	if err != nil {
+		uc.logger.WarnContext(ctx, "Declining invitation failed", "error", err, "invitationID", invitationID, "userID", userID)
+		return err
+	}
+	uc.logger.InfoContext(ctx, "Invitation declined", "invitationID", invitationID, "userID", userID)
+	return nil
+}
+
+// ListCollectionMembers lists the owner, followed by the members, of a collection the user belongs to.
+func (uc *CollectionSharingUseCase) ListCollectionMembers(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) ([]*domain.CollectionMember, error) {
+	collection, err := uc.collectionRepo.FindByID(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := collectionRole(ctx, uc.memberRepo, collection, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" {
+		return nil, domain.ErrPermissionDenied
+	}
+	members, err := uc.memberRepo.ListMembers(ctx, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection members: %w", err)
+	}
+	owner := &domain.CollectionMember{CollectionID: collectionID, UserID: collection.OwnerID, Role: domain.CollectionRoleOwner, AddedAt: collection.CreatedAt}
+	return append([]*domain.CollectionMember{owner}, members...), nil
+}
+
+// RemoveCollectionMember removes a member from a collection. The owner may remove anyone else;
+// members may leave on their own.
+func (uc *CollectionSharingUseCase) RemoveCollectionMember(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID, memberID domain.UserID) error {
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		collection, err := uc.collectionRepo.FindByID(txCtx, collectionID)
+		if err != nil {
+			return err
+		}
+		if memberID == collection.OwnerID {
+			return fmt.Errorf("%w: the owner cannot be removed; transfer the collection first", domain.ErrInvalidArgument)
+		}
+		if userID != collection.OwnerID && userID != memberID {
+			return domain.ErrPermissionDenied
+		}
+		if err := uc.memberRepo.RemoveMember(txCtx, collectionID, memberID); err != nil {
+			return err
+		}
+		diff := domain.DiffFields(map[string]any{"memberId": memberID.String()}, nil)
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCollectionMemberRemove, domain.AuditTargetCollection, collectionID.String(), diff)
+	})
+	if err != nil {
+		uc.logger.WarnContext(ctx, "Removing collection member failed", "error", err, "collectionID", collectionID, "userID", userID, "memberID", memberID)
+		return err
+	}
+	uc.logger.InfoContext(ctx, "Collection member removed", "collectionID", collectionID, "userID", userID, "memberID", memberID)
+	return nil
+}
+
+// TransferOwnership makes a member the owner of the collection. The previous owner becomes an editor.
+func (uc *CollectionSharingUseCase) TransferOwnership(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID, newOwnerID domain.UserID, expectedVersion int) (int, error) {
+	if newOwnerID == userID {
+		return 0, fmt.Errorf("%w: you already own this collection", domain.ErrInvalidArgument)
+	}
+	var newVersion int
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if _, err := uc.findOwnedCollection(txCtx, userID, collectionID); err != nil {
+			return err
+		}
+		if _, err := uc.memberRepo.FindMember(txCtx, collectionID, newOwnerID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("%w: the new owner must be a member of the collection", domain.ErrInvalidArgument)
+			}
+			return err
+		}
+		var err error
+		newVersion, err = uc.collectionRepo.TransferOwnership(txCtx, collectionID, newOwnerID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		if err := uc.memberRepo.RemoveMember(txCtx, collectionID, newOwnerID); err != nil {
+			return err
+		}
+		previousOwner, err := domain.NewCollectionMember(collectionID, userID, domain.CollectionRoleEditor)
+		if err != nil {
+			return err
+		}
+		if err := uc.memberRepo.SaveMember(txCtx, previousOwner); err != nil {
+			return err
+		}
+		diff := domain.DiffFields(map[string]any{"ownerId": userID.String()}, map[string]any{"ownerId": newOwnerID.String()})
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCollectionTransfer, domain.AuditTargetCollection, collectionID.String(), diff)
+	})
+	if err != nil {
+		uc.logger.WarnContext(ctx, "Transferring collection ownership failed", "error", err, "collectionID", collectionID, "userID", userID, "newOwnerID", newOwnerID)
+		return 0, err
+	}
+	uc.logger.InfoContext(ctx, "Collection ownership transferred", "collectionID", collectionID, "previousOwnerID", userID, "newOwnerID", newOwnerID, "version", newVersion)
+	return newVersion, nil
+}
+
+// --- Helpers ---
+
+// findOwnedCollection returns the collection if the user owns it.
+func (uc *CollectionSharingUseCase) findOwnedCollection(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) (*domain.AudioCollection, error) {
+	collection, err := uc.collectionRepo.FindByID(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if collection.OwnerID != userID {
+		return nil, domain.ErrPermissionDenied
+	}
+	return collection, nil
+}
+
+// findInvitationFor returns the invitation if it is addressed to the user. Other users' invitations are reported as not found.
+func (uc *CollectionSharingUseCase) findInvitationFor(ctx context.Context, userID domain.UserID, invitationID domain.InvitationID) (*domain.CollectionInvitation, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	invitation, err := uc.memberRepo.FindInvitation(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if !invitation.IsAddressedTo(user) {
+		return nil, domain.ErrNotFound
+	}
+	return invitation, nil
+}
+
+// invitationAuditFields returns the audited fields of an invitation, for use with domain.DiffFields.
+func invitationAuditFields(i *domain.CollectionInvitation) map[string]any {
+	fields := map[string]any{"invitationId": i.ID.String(), "role": i.Role.String()}
+	if i.InviteeID != nil {
+		fields["inviteeId"] = i.InviteeID.String()
+	}
+	if i.InviteeEmail != "" {
+		fields["inviteeEmail"] = i.InviteeEmail
+	}
+	return fields
+}
+
+// Compile-time check to ensure CollectionSharingUseCase satisfies the port.CollectionSharingUseCase interface
+var _ port.CollectionSharingUseCase = (*CollectionSharingUseCase)(nil)
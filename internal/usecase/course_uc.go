@@ -0,0 +1,439 @@
+// internal/usecase/course_uc.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+)
+
+// CourseUseCase handles the outline, publication and enrollments of courses. A course is an
+// audio collection of type COURSE; its track list always mirrors the lessons in course order.
+type CourseUseCase struct {
+	courseRepo     port.CourseRepository
+	collectionRepo port.AudioCollectionRepository
+	memberRepo     port.CollectionMemberRepository
+	trackRepo      port.AudioTrackRepository
+	progressRepo   port.PlaybackProgressRepository
+	txManager      port.TransactionManager
+	auditRepo      port.AuditEventRepository
+	outboxRepo     port.OutboxRepository
+	logger         *slog.Logger
+}
+
+// NewCourseUseCase creates a new CourseUseCase.
+func NewCourseUseCase(
+	cr port.CourseRepository,
+	acr port.AudioCollectionRepository,
+	mr port.CollectionMemberRepository,
+	tr port.AudioTrackRepository,
+	pr port.PlaybackProgressRepository,
+	tm port.TransactionManager,
+	ar port.AuditEventRepository,
+	or port.OutboxRepository,
+	log *slog.Logger,
+) *CourseUseCase {
+	return &CourseUseCase{
+		courseRepo:     cr,
+		collectionRepo: acr,
+		memberRepo:     mr,
+		trackRepo:      tr,
+		progressRepo:   pr,
+		txManager:      tm,
+		auditRepo:      ar,
+		outboxRepo:     or,
+		logger:         log.With("usecase", "CourseUseCase"),
+	}
+}
+
+// GetCourse returns a course and its outline if the viewer may see it.
+func (uc *CourseUseCase) GetCourse(ctx context.Context, viewerID *domain.UserID, collectionID domain.CollectionID) (*port.CourseDetails, error) {
+	course, err := uc.findCourse(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	var enrollment *domain.Enrollment
+	if viewerID != nil {
+		enrollment, err = uc.courseRepo.FindEnrollment(ctx, *viewerID, collectionID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("failed to check enrollment: %w", err)
+		}
+	}
+	var role domain.CollectionRole
+	if viewerID != nil {
+		if role, err = collectionRole(ctx, uc.memberRepo, course, *viewerID); err != nil {
+			return nil, err
+		}
+	}
+	if role == "" && !course.IsPublished() && enrollment == nil {
+		return nil, domain.ErrNotFound // Do not reveal unpublished courses
+	}
+	return uc.loadDetails(ctx, course, enrollment)
+}
+
+// UpdateCourseOutline validates and stores a new outline for a course the user owns or edits.
+func (uc *CourseUseCase) UpdateCourseOutline(ctx context.Context, input port.UpdateCourseOutlineInput) (*port.CourseDetails, error) {
+	log := uc.logger.With("collectionID", input.CollectionID.String(), "userID", input.UserID.String())
+	sections := buildCourseOutline(input.Sections)
+	if err := domain.ValidateCourseOutline(sections); err != nil {
+		return nil, err
+	}
+	trackIDs := domain.CourseTrackIDs(sections)
+
+	var course *domain.AudioCollection
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var err error
+		course, err = uc.findEditableCourse(txCtx, input.UserID, input.CollectionID)
+		if err != nil {
+			return err
+		}
+		if err := uc.checkLessonTracks(txCtx, course, input.UserID, trackIDs, course.IsPublished()); err != nil {
+			return err
+		}
+		before := collectionAuditFields(course)
+		course.Version, err = uc.collectionRepo.ManageTracks(txCtx, course.ID, trackIDs, input.ExpectedVersion)
+		if err != nil {
+			return fmt.Errorf("updating course tracks: %w", err)
+		}
+		if err := uc.courseRepo.ReplaceOutline(txCtx, course.ID, sections); err != nil {
+			return err
+		}
+		course.TrackIDs = trackIDs
+		diff := domain.DiffFields(before, collectionAuditFields(course))
+		return recordAudit(txCtx, uc.auditRepo, &input.UserID, domain.AuditActionCollectionUpdate, domain.AuditTargetCollection, course.ID.String(), diff)
+	})
+	if err != nil {
+		log.WarnContext(ctx, "Course outline update failed", "error", err)
+		return nil, fmt.Errorf("failed to update course outline: %w", err)
+	}
+
+	log.InfoContext(ctx, "Course outline updated", "sections", len(sections), "lessons", len(trackIDs), "version", course.Version)
+	return uc.loadDetails(ctx, course, nil)
+}
+
+// PublishCourse opens a course for enrollment. Every lesson track must be public, so that
+// enrolled learners can play it.
+func (uc *CourseUseCase) PublishCourse(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID, expectedVersion int) (*domain.AudioCollection, error) {
+	var course *domain.AudioCollection
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var err error
+		course, err = uc.findOwnedCourse(txCtx, userID, collectionID)
+		if err != nil {
+			return err
+		}
+		if course.IsPublished() {
+			return nil
+		}
+		sections, err := uc.courseRepo.GetOutline(txCtx, collectionID)
+		if err != nil {
+			return err
+		}
+		trackIDs := domain.CourseTrackIDs(sections)
+		if len(trackIDs) == 0 {
+			return fmt.Errorf("%w: a course needs at least one lesson to be published", domain.ErrInvalidArgument)
+		}
+		if err := uc.checkLessonTracks(txCtx, course, userID, trackIDs, true); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		course.Version, err = uc.courseRepo.SetPublished(txCtx, collectionID, &now, expectedVersion)
+		if err != nil {
+			return err
+		}
+		course.PublishedAt = &now
+		diff := domain.AuditDiff{"publishedAt": {Old: nil, New: now}}
+		if err := recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCoursePublish, domain.AuditTargetCollection, collectionID.String(), diff); err != nil {
+			return err
+		}
+		return publishEvent(txCtx, uc.outboxRepo, domain.EventCoursePublished, map[string]any{
+			"collectionId": collectionID.String(),
+			"title":        course.Title,
+			"ownerId":      course.OwnerID.String(),
+			"lessonCount":  len(trackIDs),
+		})
+	})
+	if err != nil {
+		uc.logger.WarnContext(ctx, "Publishing course failed", "error", err, "collectionID", collectionID, "userID", userID)
+		return nil, fmt.Errorf("failed to publish course: %w", err)
+	}
+	uc.logger.InfoContext(ctx, "Course published", "collectionID", collectionID, "userID", userID)
+	return course, nil
+}
+
+// UnpublishCourse closes a course for new enrollments. Learners already enrolled keep access.
+func (uc *CourseUseCase) UnpublishCourse(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID, expectedVersion int) (*domain.AudioCollection, error) {
+	var course *domain.AudioCollection
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var err error
+		course, err = uc.findOwnedCourse(txCtx, userID, collectionID)
+		if err != nil {
+			return err
+		}
+		if !course.IsPublished() {
+			return nil
+		}
+		course.Version, err = uc.courseRepo.SetPublished(txCtx, collectionID, nil, expectedVersion)
+		if err != nil {
+			return err
+		}
+		diff := domain.AuditDiff{"publishedAt": {Old: *course.PublishedAt, New: nil}}
+		course.PublishedAt = nil
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionCourseUnpublish, domain.AuditTargetCollection, collectionID.String(), diff)
+	})
+	if err != nil {
+		uc.logger.WarnContext(ctx, "Unpublishing course failed", "error", err, "collectionID", collectionID, "userID", userID)
+		return nil, fmt.Errorf("failed to unpublish course: %w", err)
+	}
+	uc.logger.InfoContext(ctx, "Course unpublished", "collectionID", collectionID, "userID", userID)
+	return course, nil
+}
+
+// ListPublishedCourses lists published courses, most recently published first.
+func (uc *CourseUseCase) ListPublishedCourses(ctx context.Context, input port.ListCoursesInput) ([]port.CourseSummary, int, pagination.Page, error) {
+	pageParams := pagination.NewPageFromOffset(input.Page.Limit, input.Page.Offset)
+	courses, total, err := uc.courseRepo.ListPublished(ctx, input.Filter, pageParams)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to list published courses", "error", err)
+		return nil, 0, pageParams, fmt.Errorf("failed to retrieve course list: %w", err)
+	}
+	return courses, total, pageParams, nil
+}
+
+// Enroll enrolls the user in a published course of another user.
+func (uc *CourseUseCase) Enroll(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) (*domain.Enrollment, error) {
+	course, err := uc.findCourse(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if course.OwnerID == userID {
+		return nil, fmt.Errorf("%w: you cannot enroll in your own course", domain.ErrInvalidArgument)
+	}
+
+	enrollment := domain.NewEnrollment(userID, collectionID)
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		existing, err := uc.courseRepo.FindEnrollment(txCtx, userID, collectionID)
+		if err == nil {
+			enrollment = existing
+			return nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		if !course.IsPublished() {
+			return domain.ErrNotFound // Unpublished courses cannot be discovered or joined
+		}
+		created, err := uc.courseRepo.Enroll(txCtx, enrollment)
+		if err != nil || !created {
+			return err
+		}
+		return publishEvent(txCtx, uc.outboxRepo, domain.EventCourseEnrolled, map[string]any{
+			"collectionId": collectionID.String(),
+			"userId":       userID.String(),
+			"enrolledAt":   enrollment.EnrolledAt,
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "Enrollment failed", "error", err, "collectionID", collectionID, "userID", userID)
+		}
+		return nil, fmt.Errorf("failed to enroll: %w", err)
+	}
+	uc.logger.InfoContext(ctx, "User enrolled in course", "collectionID", collectionID, "userID", userID)
+	return enrollment, nil
+}
+
+// Unenroll removes the user's enrollment. Playback progress on the lesson tracks is kept.
+func (uc *CourseUseCase) Unenroll(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) error {
+	if err := uc.courseRepo.Unenroll(ctx, userID, collectionID); err != nil {
+		return err
+	}
+	uc.logger.InfoContext(ctx, "User unenrolled from course", "collectionID", collectionID, "userID", userID)
+	return nil
+}
+
+// ListEnrollments lists the user's enrolled courses, most recent enrollment first, with progress.
+func (uc *CourseUseCase) ListEnrollments(ctx context.Context, userID domain.UserID, page pagination.Page) ([]port.EnrolledCourse, int, pagination.Page, error) {
+	pageParams := pagination.NewPageFromOffset(page.Limit, page.Offset)
+	courses, total, err := uc.courseRepo.ListEnrollments(ctx, userID, pageParams)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to list enrollments", "error", err, "userID", userID)
+		return nil, 0, pageParams, fmt.Errorf("failed to retrieve enrollments: %w", err)
+	}
+	for i := range courses {
+		sections, err := uc.courseRepo.GetOutline(ctx, courses[i].Course.ID)
+		if err != nil {
+			return nil, 0, pageParams, fmt.Errorf("failed to load course outline: %w", err)
+		}
+		progress, err := uc.computeProgress(ctx, userID, sections)
+		if err != nil {
+			return nil, 0, pageParams, err
+		}
+		courses[i].Progress = progress
+	}
+	return courses, total, pageParams, nil
+}
+
+// GetCourseProgress returns the user's progress through a course they are enrolled in or own.
+func (uc *CourseUseCase) GetCourseProgress(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) (*domain.CourseProgress, error) {
+	course, err := uc.findCourse(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if course.OwnerID != userID {
+		if _, err := uc.courseRepo.FindEnrollment(ctx, userID, collectionID); err != nil {
+			return nil, err // ErrNotFound if not enrolled
+		}
+	}
+	sections, err := uc.courseRepo.GetOutline(ctx, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load course outline: %w", err)
+	}
+	return uc.computeProgress(ctx, userID, sections)
+}
+
+// --- Helpers ---
+
+// findCourse returns the collection if it is a course.
+func (uc *CourseUseCase) findCourse(ctx context.Context, collectionID domain.CollectionID) (*domain.AudioCollection, error) {
+	collection, err := uc.collectionRepo.FindByID(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if collection.Type != domain.TypeCourse {
+		return nil, domain.ErrNotFound
+	}
+	return collection, nil
+}
+
+// findOwnedCourse returns the course if the user owns it.
+func (uc *CourseUseCase) findOwnedCourse(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) (*domain.AudioCollection, error) {
+	course, err := uc.findCourse(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if course.OwnerID != userID {
+		return nil, domain.ErrPermissionDenied
+	}
+	return course, nil
+}
+
+// findEditableCourse returns the course if the user is its owner or an editor.
+func (uc *CourseUseCase) findEditableCourse(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) (*domain.AudioCollection, error) {
+	course, err := uc.findCourse(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := collectionRole(ctx, uc.memberRepo, course, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.CanEdit() {
+		return nil, domain.ErrPermissionDenied
+	}
+	return course, nil
+}
+
+// checkLessonTracks verifies that every lesson track exists and is public or uploaded by the
+// course owner or the editing user. Published courses may only use public tracks.
+func (uc *CourseUseCase) checkLessonTracks(ctx context.Context, course *domain.AudioCollection, editorID domain.UserID, trackIDs []domain.TrackID, requirePublic bool) error {
+	if len(trackIDs) == 0 {
+		return nil
+	}
+	tracks, err := uc.trackRepo.ListByIDs(ctx, trackIDs)
+	if err != nil {
+		return fmt.Errorf("validating lesson tracks: %w", err)
+	}
+	if len(tracks) != len(trackIDs) {
+		return fmt.Errorf("%w: one or more lesson tracks do not exist", domain.ErrInvalidArgument)
+	}
+	for _, track := range tracks {
+		if track.IsPublic {
+			continue
+		}
+		if requirePublic {
+			return fmt.Errorf("%w: lesson track %s must be public in a published course", domain.ErrInvalidArgument, track.ID)
+		}
+		if track.UploaderID == nil || (*track.UploaderID != course.OwnerID && *track.UploaderID != editorID) {
+			return fmt.Errorf("%w: lesson track %s does not exist", domain.ErrInvalidArgument, track.ID)
+		}
+	}
+	return nil
+}
+
+// loadDetails loads the outline and lesson tracks of a course.
+func (uc *CourseUseCase) loadDetails(ctx context.Context, course *domain.AudioCollection, enrollment *domain.Enrollment) (*port.CourseDetails, error) {
+	sections, err := uc.courseRepo.GetOutline(ctx, course.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load course outline: %w", err)
+	}
+	tracks, err := uc.lessonTracks(ctx, sections)
+	if err != nil {
+		return nil, err
+	}
+	return &port.CourseDetails{Course: course, Sections: sections, Tracks: tracks, Enrollment: enrollment}, nil
+}
+
+func (uc *CourseUseCase) lessonTracks(ctx context.Context, sections []domain.CourseSection) (map[domain.TrackID]*domain.AudioTrack, error) {
+	tracks, err := uc.trackRepo.ListByIDs(ctx, domain.CourseTrackIDs(sections))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load lesson tracks: %w", err)
+	}
+	byID := make(map[domain.TrackID]*domain.AudioTrack, len(tracks))
+	for _, track := range tracks {
+		byID[track.ID] = track
+	}
+	return byID, nil
+}
+
+// computeProgress derives the user's progress through a course from their playback progress.
+func (uc *CourseUseCase) computeProgress(ctx context.Context, userID domain.UserID, sections []domain.CourseSection) (*domain.CourseProgress, error) {
+	tracks, err := uc.lessonTracks(ctx, sections)
+	if err != nil {
+		return nil, err
+	}
+	durations := make(map[domain.TrackID]time.Duration, len(tracks))
+	for id, track := range tracks {
+		durations[id] = track.Duration
+	}
+	progressList, err := uc.progressRepo.ListByUserAndTracks(ctx, userID, domain.CourseTrackIDs(sections))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load playback progress: %w", err)
+	}
+	progress := make(map[domain.TrackID]*domain.PlaybackProgress, len(progressList))
+	for _, p := range progressList {
+		progress[p.TrackID] = p
+	}
+	result := domain.ComputeCourseProgress(sections, durations, progress)
+	return &result, nil
+}
+
+// buildCourseOutline converts the requested outline into domain sections, generating IDs for
+// new sections and lessons.
+func buildCourseOutline(input []port.CourseSectionInput) []domain.CourseSection {
+	sections := make([]domain.CourseSection, len(input))
+	for i, s := range input {
+		section := domain.CourseSection{ID: domain.NewCourseSectionID(), Title: s.Title, Description: s.Description}
+		if s.ID != nil {
+			section.ID = *s.ID
+		}
+		for _, l := range s.Lessons {
+			lesson := domain.Lesson{ID: domain.NewLessonID(), TrackID: l.TrackID, Title: l.Title, Notes: l.Notes, Attachments: l.Attachments}
+			if l.ID != nil {
+				lesson.ID = *l.ID
+			}
+			section.Lessons = append(section.Lessons, lesson)
+		}
+		sections[i] = section
+	}
+	return sections
+}
+
+// Compile-time check to ensure CourseUseCase satisfies the port.CourseUseCase interface
+var _ port.CourseUseCase = (*CourseUseCase)(nil)
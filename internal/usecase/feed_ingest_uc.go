@@ -0,0 +1,796 @@
+// internal/usecase/feed_ingest_uc.go
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/audiotag"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+	"github.com/yvanyang/language-learning-player-api/pkg/podcast"
+)
+
+const (
+	// feedRefreshBatchSize bounds the feeds queued by one run of the due-feeds job.
+	feedRefreshBatchSize = 100
+	// feedRefreshMaxAttempts bounds the retries of a feed refresh job. Failures to fetch or
+	// parse the feed are recorded on the subscription instead of being retried.
+	feedRefreshMaxAttempts = 3
+	// feedTitleMaxLength is the length of the title columns of tracks and collections.
+	feedTitleMaxLength = 255
+	// feedCoverURLMaxLength is the length of the cover image column of tracks.
+	feedCoverURLMaxLength = 1024
+)
+
+// feedAudioExtensions maps the media types of enclosures to the extensions of audioContentTypes.
+var feedAudioExtensions = map[string]string{
+	"audio/mpeg":   ".mp3",
+	"audio/mp3":    ".mp3",
+	"audio/x-mp3":  ".mp3",
+	"audio/ogg":    ".ogg",
+	"audio/opus":   ".opus",
+	"audio/flac":   ".flac",
+	"audio/x-flac": ".flac",
+	"audio/mp4":    ".m4a",
+	"audio/m4a":    ".m4a",
+	"audio/x-m4a":  ".m4a",
+	"audio/wav":    ".wav",
+	"audio/wave":   ".wav",
+	"audio/x-wav":  ".wav",
+}
+
+// FeedIngestUseCase ingests external podcast feeds: every episode becomes an AudioTrack of the
+// subscription's owner and every feed a PLAYLIST collection of its episodes, oldest first.
+//
+// A recurring job queues a refresh of every feed that is due. A refresh fetches the feed
+// conditionally, downloads the audio of new episodes (up to MaxEpisodesPerRefresh; the rest
+// follow in the next run), updates the tracks of episodes ingested before and syncs the
+// collection. Like archive imports, audio is stored under a key derived from the owner and
+// the SHA-256 of the content, so an episode published in several feeds is stored once.
+type FeedIngestUseCase struct {
+	userRepo       port.UserRepository
+	trackRepo      port.AudioTrackRepository
+	chapterRepo    port.TrackChapterRepository
+	collectionRepo port.AudioCollectionRepository
+	subRepo        port.FeedSubscriptionRepository
+	jobRepo        port.JobRepository
+	storageService port.FileStorageService
+	fetcher        port.FeedFetcher
+	txManager      port.TransactionManager
+	auditRepo      port.AuditEventRepository
+	outboxRepo     port.OutboxRepository
+	cfg            config.FeedIngestConfig
+	minioBucket    string
+	logger         *slog.Logger
+}
+
+// NewFeedIngestUseCase creates a new FeedIngestUseCase.
+func NewFeedIngestUseCase(
+	cfg config.FeedIngestConfig,
+	minioCfg config.MinioConfig,
+	ur port.UserRepository,
+	tr port.AudioTrackRepository,
+	chr port.TrackChapterRepository,
+	cr port.AudioCollectionRepository,
+	sr port.FeedSubscriptionRepository,
+	jr port.JobRepository,
+	ss port.FileStorageService,
+	fetcher port.FeedFetcher,
+	tm port.TransactionManager,
+	ar port.AuditEventRepository,
+	or port.OutboxRepository,
+	log *slog.Logger,
+) *FeedIngestUseCase {
+	return &FeedIngestUseCase{
+		userRepo:       ur,
+		trackRepo:      tr,
+		chapterRepo:    chr,
+		collectionRepo: cr,
+		subRepo:        sr,
+		jobRepo:        jr,
+		storageService: ss,
+		fetcher:        fetcher,
+		txManager:      tm,
+		auditRepo:      ar,
+		outboxRepo:     or,
+		cfg:            cfg,
+		minioBucket:    minioCfg.BucketName,
+		logger:         log.With("usecase", "FeedIngestUseCase"),
+	}
+}
+
+// feedRefreshPayload identifies the subscription a refresh job fetches.
+type feedRefreshPayload struct {
+	SubscriptionID string `json:"subscriptionId"`
+}
+
+// Subscribe adds a feed and queues its first refresh.
+func (uc *FeedIngestUseCase) Subscribe(ctx context.Context, input port.SubscribeFeedInput) (*domain.FeedSubscription, error) {
+	adminID, err := requireAdmin(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+	ownerID, err := uc.resolveOwner(ctx, adminID, input.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := newFeedSubscription(input.FeedURL, ownerID, input.Options)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.createSubscription(ctx, adminID, sub); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, fmt.Errorf("%w: the owner is already subscribed to this feed", domain.ErrConflict)
+		}
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "Feed subscription created", "feedID", sub.ID, "feedURL", sub.FeedURL, "ownerID", ownerID, "userID", adminID)
+	return sub, nil
+}
+
+// ImportOPML subscribes to every feed of an OPML document. Feeds the owner is already
+// subscribed to and outlines with an invalid URL are skipped. The language of an outline,
+// if any, takes precedence over the default of the options.
+func (uc *FeedIngestUseCase) ImportOPML(ctx context.Context, input port.ImportOPMLInput) (*port.OPMLImportResult, error) {
+	adminID, err := requireAdmin(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+	ownerID, err := uc.resolveOwner(ctx, adminID, input.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parseFeedLevel(input.Options.Level); err != nil {
+		return nil, err
+	}
+	outlines, err := podcast.ParseOPML(input.Document)
+	if err != nil {
+		if errors.Is(err, podcast.ErrInvalid) {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err)
+		}
+		return nil, fmt.Errorf("reading OPML document: %w", err)
+	}
+	if len(outlines) == 0 {
+		return nil, fmt.Errorf("%w: the document lists no feeds", domain.ErrInvalidArgument)
+	}
+
+	result := &port.OPMLImportResult{Subscribed: []*domain.FeedSubscription{}, Skipped: []port.SkippedFeed{}}
+	for _, outline := range outlines {
+		opts := input.Options
+		if outline.Language != "" {
+			opts.LanguageCode = outline.Language
+		}
+		sub, err := newFeedSubscription(outline.FeedURL, ownerID, opts)
+		if err != nil {
+			result.Skipped = append(result.Skipped, port.SkippedFeed{FeedURL: outline.FeedURL, Reason: "invalid feed URL"})
+			continue
+		}
+		err = uc.createSubscription(ctx, adminID, sub)
+		if errors.Is(err, domain.ErrConflict) {
+			result.Skipped = append(result.Skipped, port.SkippedFeed{FeedURL: outline.FeedURL, Reason: "already subscribed"})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Subscribed = append(result.Subscribed, sub)
+	}
+	uc.logger.InfoContext(ctx, "OPML document imported", "subscribedCount", len(result.Subscribed), "skippedCount", len(result.Skipped), "ownerID", ownerID, "userID", adminID)
+	return result, nil
+}
+
+func (uc *FeedIngestUseCase) ListSubscriptions(ctx context.Context, page pagination.Page) ([]*domain.FeedSubscription, int, pagination.Page, error) {
+	pageParams := pagination.NewPageFromOffset(page.Limit, page.Offset)
+	if _, err := requireAdmin(ctx, uc.userRepo); err != nil {
+		return nil, 0, pageParams, err
+	}
+	subs, total, err := uc.subRepo.List(ctx, pageParams)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to list feed subscriptions", "error", err, "page", pageParams)
+		return nil, 0, pageParams, fmt.Errorf("failed to retrieve feed subscriptions: %w", err)
+	}
+	return subs, total, pageParams, nil
+}
+
+func (uc *FeedIngestUseCase) GetSubscription(ctx context.Context, id domain.FeedSubscriptionID) (*domain.FeedSubscription, error) {
+	if _, err := requireAdmin(ctx, uc.userRepo); err != nil {
+		return nil, err
+	}
+	return uc.subRepo.FindByID(ctx, id)
+}
+
+// RefreshSubscription queues a refresh of the feed now, regardless of its schedule.
+func (uc *FeedIngestUseCase) RefreshSubscription(ctx context.Context, id domain.FeedSubscriptionID) error {
+	adminID, err := requireAdmin(ctx, uc.userRepo)
+	if err != nil {
+		return err
+	}
+	sub, err := uc.subRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	job, err := newFeedRefreshJob(sub)
+	if err != nil {
+		return err
+	}
+	if err := uc.jobRepo.Enqueue(ctx, job); err != nil {
+		return err
+	}
+	uc.logger.InfoContext(ctx, "Feed refresh queued", "feedID", id, "userID", adminID)
+	return nil
+}
+
+// Unsubscribe stops refreshing a feed. The tracks and collection ingested from it remain.
+func (uc *FeedIngestUseCase) Unsubscribe(ctx context.Context, id domain.FeedSubscriptionID) error {
+	adminID, err := requireAdmin(ctx, uc.userRepo)
+	if err != nil {
+		return err
+	}
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		sub, err := uc.subRepo.FindByID(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if err := uc.subRepo.Delete(txCtx, id); err != nil {
+			return err
+		}
+		diff := domain.AuditDiff{"feedUrl": {Old: sub.FeedURL}, "ownerId": {Old: sub.OwnerID.String()}}
+		return recordAudit(txCtx, uc.auditRepo, &adminID, domain.AuditActionFeedUnsubscribe, domain.AuditTargetFeed, id.String(), diff)
+	})
+	if err != nil {
+		return err
+	}
+	uc.logger.InfoContext(ctx, "Feed subscription deleted", "feedID", id, "userID", adminID)
+	return nil
+}
+
+// resolveOwner returns the owner of ingested content: the given user, who must exist, or the admin.
+func (uc *FeedIngestUseCase) resolveOwner(ctx context.Context, adminID domain.UserID, ownerID *domain.UserID) (domain.UserID, error) {
+	if ownerID == nil {
+		return adminID, nil
+	}
+	if _, err := uc.userRepo.FindByID(ctx, *ownerID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.UserID{}, fmt.Errorf("%w: owner not found", domain.ErrInvalidArgument)
+		}
+		return domain.UserID{}, err
+	}
+	return *ownerID, nil
+}
+
+// createSubscription saves a subscription and queues its first refresh.
+func (uc *FeedIngestUseCase) createSubscription(ctx context.Context, adminID domain.UserID, sub *domain.FeedSubscription) error {
+	job, err := newFeedRefreshJob(sub)
+	if err != nil {
+		return err
+	}
+	return uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := uc.subRepo.Create(txCtx, sub); err != nil {
+			return err
+		}
+		if err := uc.jobRepo.Enqueue(txCtx, job); err != nil {
+			return err
+		}
+		diff := domain.AuditDiff{"feedUrl": {New: sub.FeedURL}, "ownerId": {New: sub.OwnerID.String()}}
+		return recordAudit(txCtx, uc.auditRepo, &adminID, domain.AuditActionFeedSubscribe, domain.AuditTargetFeed, sub.ID.String(), diff)
+	})
+}
+
+func newFeedSubscription(feedURL string, ownerID domain.UserID, opts port.FeedIngestOptions) (*domain.FeedSubscription, error) {
+	level, err := parseFeedLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewFeedSubscription(strings.TrimSpace(feedURL), ownerID, strings.TrimSpace(opts.LanguageCode), level, opts.IsPublic, opts.Tags)
+}
+
+func parseFeedLevel(s string) (domain.AudioLevel, error) {
+	level := domain.AudioLevel(strings.ToUpper(strings.TrimSpace(s)))
+	if level != domain.LevelUnknown && !level.IsValid() {
+		return "", fmt.Errorf("%w: invalid audio level '%s'", domain.ErrInvalidArgument, s)
+	}
+	return level, nil
+}
+
+func newFeedRefreshJob(sub *domain.FeedSubscription) (*domain.Job, error) {
+	job, err := domain.NewJob(JobTypeFeedRefresh, feedRefreshPayload{SubscriptionID: sub.ID.String()}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	job.MaxAttempts = feedRefreshMaxAttempts
+	return job, nil
+}
+
+// --- Background refresh ---
+
+// refreshDueFeedsHandler queues a refresh of every feed that is due. Before queueing, it
+// moves the next fetch of the feed one interval ahead, so that the feed is not queued again
+// while the refresh is pending and is retried in due course if the refresh job dies.
+func (uc *FeedIngestUseCase) refreshDueFeedsHandler() JobHandler {
+	return func(ctx context.Context, job *domain.Job) error {
+		now := time.Now()
+		subs, err := uc.subRepo.ListDue(ctx, now, feedRefreshBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, sub := range subs {
+			refresh, err := newFeedRefreshJob(sub)
+			if err != nil {
+				return err
+			}
+			sub.NextFetchAt, sub.UpdatedAt = now.Add(uc.cfg.RefreshInterval), now
+			err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+				if err := uc.subRepo.Update(txCtx, sub); err != nil {
+					return err
+				}
+				return uc.jobRepo.Enqueue(txCtx, refresh)
+			})
+			if err != nil && !errors.Is(err, domain.ErrNotFound) { // Unsubscribed meanwhile
+				return err
+			}
+		}
+		if len(subs) > 0 {
+			uc.logger.InfoContext(ctx, "Queued feed refreshes", "count", len(subs))
+		}
+		return nil
+	}
+}
+
+// refreshFeedHandler runs a feed refresh job.
+func (uc *FeedIngestUseCase) refreshFeedHandler() JobHandler {
+	return JSONJobHandler(func(ctx context.Context, p feedRefreshPayload) error {
+		id, err := domain.FeedSubscriptionIDFromString(p.SubscriptionID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrPermanentJobFailure, err)
+		}
+		sub, err := uc.subRepo.FindByID(ctx, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil // Unsubscribed since the job was queued
+		}
+		if err != nil {
+			return err
+		}
+		return uc.refresh(ctx, sub)
+	})
+}
+
+// feedRefreshReport counts what a refresh did with the episodes of a feed.
+type feedRefreshReport struct {
+	created  int
+	updated  int
+	failed   int
+	firstErr string
+	pending  bool // New episodes were left for the next refresh
+}
+
+// refresh fetches a feed and ingests it. Failures to fetch or parse the feed are recorded on
+// the subscription and the feed is tried again after the refresh interval; errors of the
+// database or storage are returned so that the job is retried.
+func (uc *FeedIngestUseCase) refresh(ctx context.Context, sub *domain.FeedSubscription) error {
+	log := uc.logger.With("feedID", sub.ID.String(), "feedURL", sub.FeedURL)
+
+	// Without a collection (never synced, or deleted) the whole feed is needed.
+	var validators port.FeedValidators
+	if sub.CollectionID != nil {
+		validators = port.FeedValidators{ETag: sub.ETag, LastModified: sub.LastModified}
+	}
+	fetched, err := uc.fetcher.FetchFeed(ctx, sub.FeedURL, validators)
+	if err == nil && fetched.NotModified {
+		sub.RecordFetch(fetched.Validators.ETag, fetched.Validators.LastModified, time.Now(), uc.cfg.RefreshInterval)
+		log.DebugContext(ctx, "Feed not modified")
+		return uc.subRepo.Update(ctx, sub)
+	}
+	var feed *podcast.Feed
+	if err == nil {
+		feed, err = podcast.Parse(bytes.NewReader(fetched.Body))
+	}
+	if err == nil && feed.Language == "" && sub.LanguageCode == "" {
+		err = errors.New("the feed declares no language and the subscription has no default")
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.WarnContext(ctx, "Failed to refresh feed", "error", err)
+		sub.RecordFailure(err.Error(), time.Now(), uc.cfg.RefreshInterval)
+		return uc.subRepo.Update(ctx, sub)
+	}
+
+	report, err := uc.ingestFeed(ctx, sub, feed)
+	if err != nil {
+		return err
+	}
+
+	sub.ETag, sub.LastModified = "", ""
+	now := time.Now()
+	sub.RecordFetch(fetched.Validators.ETag, fetched.Validators.LastModified, now, uc.cfg.RefreshInterval)
+	if report.failed > 0 {
+		sub.LastError = fmt.Sprintf("%d episodes could not be ingested: %s", report.failed, report.firstErr)
+	}
+	if report.pending || report.failed > 0 {
+		// A conditional request would not return the feed again until it changes.
+		sub.ETag, sub.LastModified = "", ""
+	}
+	if report.pending {
+		sub.NextFetchAt = now // Picked up by the next run of the due-feeds job
+	}
+	if err := uc.subRepo.Update(ctx, sub); err != nil {
+		return err
+	}
+	log.InfoContext(ctx, "Feed refreshed",
+		"episodesCreated", report.created, "episodesUpdated", report.updated, "episodesFailed", report.failed, "pending", report.pending)
+	return nil
+}
+
+// ingestFeed ingests new episodes, updates the tracks of known ones and syncs the collection.
+func (uc *FeedIngestUseCase) ingestFeed(ctx context.Context, sub *domain.FeedSubscription, feed *podcast.Feed) (*feedRefreshReport, error) {
+	lang, err := domain.NewLanguage(firstNonEmpty(feed.Language, sub.LanguageCode), "")
+	if err != nil {
+		return nil, err
+	}
+
+	episodes, err := uc.subRepo.ListEpisodes(ctx, sub.ID)
+	if err != nil {
+		return nil, err
+	}
+	byGUID := make(map[string]*domain.FeedEpisode, len(episodes))
+	for _, ep := range episodes {
+		byGUID[ep.GUID] = ep
+	}
+
+	report := &feedRefreshReport{}
+	for _, item := range feed.Items {
+		if item.GUID == "" || item.EnclosureURL == "" {
+			continue // Not an episode
+		}
+		if ep, ok := byGUID[item.GUID]; ok {
+			updated, err := uc.updateEpisode(ctx, ep, feed, item, lang)
+			if err != nil {
+				return nil, err
+			}
+			if updated {
+				report.updated++
+			}
+			continue
+		}
+		// Episodes that fail are tried again by every refresh, so they are bounded separately
+		// and do not hold back the episodes after them.
+		if report.created >= uc.cfg.MaxEpisodesPerRefresh || report.failed >= uc.cfg.MaxEpisodesPerRefresh {
+			report.pending = true
+			break
+		}
+		ep, err := uc.ingestEpisode(ctx, sub, feed, item, lang)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			uc.logger.WarnContext(ctx, "Failed to ingest feed episode", "error", err, "feedID", sub.ID, "guid", item.GUID)
+			if report.failed == 0 {
+				report.firstErr = err.Error()
+			}
+			report.failed++
+			continue
+		}
+		byGUID[ep.GUID] = ep
+		episodes = append(episodes, ep)
+		report.created++
+	}
+
+	if err := uc.syncFeedCollection(ctx, sub, feed, episodes); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ingestEpisode downloads the audio of a feed item and creates its track and episode record.
+// A track already stored under the same object key, e.g. from another feed of the owner, is
+// reused. The chapters of the item, or else those of the audio's ID3 tag, become the track's.
+func (uc *FeedIngestUseCase) ingestEpisode(ctx context.Context, sub *domain.FeedSubscription, feed *podcast.Feed, item podcast.Item, lang domain.Language) (*domain.FeedEpisode, error) {
+	tmp, err := os.CreateTemp("", "feed-episode-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h := sha256.New()
+	contentType, size, err := uc.fetcher.DownloadEnclosure(ctx, item.EnclosureURL, io.MultiWriter(tmp, h))
+	if err != nil {
+		return nil, err
+	}
+	ext, ok := feedAudioExtension(item.EnclosureURL, contentType, item.EnclosureType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported audio type %q", firstNonEmpty(contentType, item.EnclosureType))
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	objectKey := fmt.Sprintf("feeds/%s/%s%s", sub.OwnerID, sum, ext)
+
+	episode := &domain.FeedEpisode{
+		SubscriptionID: sub.ID,
+		GUID:           item.GUID,
+		EnclosureURL:   item.EnclosureURL,
+		PublishedAt:    feedItemPublishedAt(item),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	existing, err := uc.trackRepo.FindByObjectKey(ctx, objectKey)
+	if err == nil {
+		episode.TrackID = &existing.ID
+		if err := uc.subRepo.SaveEpisode(ctx, episode); err != nil {
+			return nil, err
+		}
+		return episode, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	duration := item.Duration
+	chapters := make([]domain.TrackChapter, 0, len(item.Chapters))
+	for _, c := range item.Chapters {
+		chapters = append(chapters, domain.TrackChapter{Title: c.Title, Start: c.Start})
+	}
+	if duration <= 0 || len(chapters) == 0 {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if tags, err := audiotag.Read(tmp, size); err == nil {
+			if duration <= 0 {
+				duration = tags.Duration
+			}
+			if len(chapters) == 0 {
+				for _, c := range tags.Chapters {
+					chapters = append(chapters, domain.TrackChapter{Title: c.Title, Description: c.Description, Start: c.Start, End: c.End})
+				}
+			}
+		}
+	}
+	if duration <= 0 {
+		return nil, errors.New("unknown duration: the feed and the audio file do not state it")
+	}
+	track, err := domain.NewAudioTrack(feedItemTitle(item), item.Description, uc.minioBucket, objectKey, lang, sub.Level, duration, &sub.OwnerID, sub.IsPublic, sub.Tags, feedItemCoverURL(feed, item))
+	if err != nil {
+		return nil, err
+	}
+	track.ContentSHA256, track.SizeBytes = sum, size
+	chapters, err = domain.NewTrackChapters(chapters, duration)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "Ignoring invalid chapters of feed episode", "error", err, "feedID", sub.ID, "guid", item.GUID)
+		chapters = nil
+	}
+
+	// The object may exist without a track if a previous refresh stopped in between.
+	exists, err := uc.storageService.ObjectExists(ctx, uc.minioBucket, objectKey)
+	if err == nil && !exists {
+		if _, err = tmp.Seek(0, io.SeekStart); err == nil {
+			err = uc.storageService.PutObject(ctx, uc.minioBucket, objectKey, tmp, size, audioContentTypes[ext])
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storing audio file: %w", err)
+	}
+
+	episode.TrackID = &track.ID
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := uc.trackRepo.Create(txCtx, track); err != nil {
+			return err
+		}
+		if err := uc.chapterRepo.Replace(txCtx, track.ID, chapters); err != nil {
+			return err
+		}
+		if err := uc.subRepo.SaveEpisode(txCtx, episode); err != nil {
+			return err
+		}
+		return recordTrackCreated(txCtx, uc.auditRepo, uc.outboxRepo, nil, track)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return episode, nil
+}
+
+// updateEpisode brings an episode record and its track up to date with the feed item. It
+// reports whether the track changed. Episodes whose track was deleted are left alone.
+func (uc *FeedIngestUseCase) updateEpisode(ctx context.Context, ep *domain.FeedEpisode, feed *podcast.Feed, item podcast.Item, lang domain.Language) (bool, error) {
+	if ep.TrackID == nil {
+		return false, nil
+	}
+	published := feedItemPublishedAt(item)
+	if ep.EnclosureURL != item.EnclosureURL || !equalTimePtr(ep.PublishedAt, published) {
+		ep.EnclosureURL, ep.PublishedAt, ep.UpdatedAt = item.EnclosureURL, published, time.Now()
+		if err := uc.subRepo.SaveEpisode(ctx, ep); err != nil {
+			return false, err
+		}
+	}
+
+	track, err := uc.trackRepo.FindByID(ctx, *ep.TrackID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	before := trackAuditFields(track)
+	track.Title = feedItemTitle(item)
+	track.Description = item.Description
+	track.Language = lang
+	if item.Duration > 0 {
+		track.Duration = item.Duration
+	}
+	if cover := feedItemCoverURL(feed, item); cover != nil {
+		track.CoverImageURL = cover
+	}
+	diff := domain.DiffFields(before, trackAuditFields(track))
+	if len(diff) == 0 {
+		return false, nil
+	}
+
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := uc.trackRepo.Update(txCtx, track); err != nil {
+			return err
+		}
+		return recordAudit(txCtx, uc.auditRepo, nil, domain.AuditActionTrackUpdate, domain.AuditTargetTrack, track.ID.String(), diff)
+	})
+	if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrConflict) {
+		return false, nil // Deleted or edited meanwhile; updated by the next refresh
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// syncFeedCollection creates the feed's PLAYLIST collection or brings its metadata and tracks
+// up to date. The subscription is saved with the collection it created, in the same transaction.
+func (uc *FeedIngestUseCase) syncFeedCollection(ctx context.Context, sub *domain.FeedSubscription, feed *podcast.Feed, episodes []*domain.FeedEpisode) error {
+	title := truncateRunes(firstNonEmpty(feed.Title, sub.FeedURL), feedTitleMaxLength)
+	sub.Title = title
+	trackIDs := domain.FeedEpisodeTrackOrder(episodes)
+
+	if sub.CollectionID != nil {
+		err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+			collection, err := uc.collectionRepo.FindWithTracks(txCtx, *sub.CollectionID)
+			if err != nil {
+				return err
+			}
+			before := collectionAuditFields(collection)
+			if collection.Title != title || collection.Description != feed.Description {
+				collection.Title, collection.Description = title, feed.Description
+				if err := uc.collectionRepo.UpdateMetadata(txCtx, collection); err != nil {
+					return err
+				}
+			}
+			if !slices.Equal(collection.TrackIDs, trackIDs) {
+				if _, err := uc.collectionRepo.ManageTracks(txCtx, collection.ID, trackIDs, collection.Version); err != nil {
+					return err
+				}
+				collection.TrackIDs = trackIDs
+			}
+			diff := domain.DiffFields(before, collectionAuditFields(collection))
+			if len(diff) == 0 {
+				return nil
+			}
+			return recordAudit(txCtx, uc.auditRepo, nil, domain.AuditActionCollectionUpdate, domain.AuditTargetCollection, collection.ID.String(), diff)
+		})
+		if !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		sub.CollectionID = nil // Deleted; created again below
+	}
+
+	collection, err := domain.NewAudioCollection(title, feed.Description, sub.OwnerID, domain.TypePlaylist)
+	if err != nil {
+		return err
+	}
+	if sub.IsPublic {
+		collection.Visibility = domain.VisibilityPublic
+	}
+	return uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := uc.collectionRepo.Create(txCtx, collection); err != nil {
+			return fmt.Errorf("saving collection metadata: %w", err)
+		}
+		newVersion, err := uc.collectionRepo.ManageTracks(txCtx, collection.ID, trackIDs, collection.Version)
+		if err != nil {
+			return fmt.Errorf("adding tracks: %w", err)
+		}
+		collection.TrackIDs = trackIDs
+		collection.Version = newVersion
+		sub.CollectionID = &collection.ID
+		if err := uc.subRepo.Update(txCtx, sub); err != nil {
+			return err
+		}
+		diff := domain.DiffFields(nil, collectionAuditFields(collection))
+		return recordAudit(txCtx, uc.auditRepo, nil, domain.AuditActionCollectionCreate, domain.AuditTargetCollection, collection.ID.String(), diff)
+	})
+}
+
+// feedAudioExtension returns the extension an enclosure is stored with: the extension of its
+// URL if audioContentTypes knows it, or else the one for the first known media type.
+func feedAudioExtension(enclosureURL string, mediaTypes ...string) (string, bool) {
+	if u, err := url.Parse(enclosureURL); err == nil {
+		if ext := strings.ToLower(path.Ext(u.Path)); audioContentTypes[ext] != "" {
+			return ext, true
+		}
+	}
+	for _, mediaType := range mediaTypes {
+		if mt, _, err := mime.ParseMediaType(mediaType); err == nil {
+			if ext, ok := feedAudioExtensions[strings.ToLower(mt)]; ok {
+				return ext, true
+			}
+		}
+	}
+	return "", false
+}
+
+// feedItemTitle returns the title of an item's track, falling back to the file name of the enclosure.
+func feedItemTitle(item podcast.Item) string {
+	title := item.Title
+	if title == "" {
+		if u, err := url.Parse(item.EnclosureURL); err == nil {
+			title = strings.TrimSuffix(path.Base(u.Path), path.Ext(u.Path))
+		}
+	}
+	return truncateRunes(firstNonEmpty(title, item.GUID), feedTitleMaxLength)
+}
+
+// feedItemCoverURL returns the image of the item, or else of the feed, if it fits the cover column.
+func feedItemCoverURL(feed *podcast.Feed, item podcast.Item) *string {
+	cover := firstNonEmpty(item.ImageURL, feed.ImageURL)
+	if cover == "" || len(cover) > feedCoverURLMaxLength {
+		return nil
+	}
+	return &cover
+}
+
+func feedItemPublishedAt(item podcast.Item) *time.Time {
+	if item.PubDate.IsZero() {
+		return nil
+	}
+	t := item.PubDate.UTC()
+	return &t
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// firstNonEmpty returns the first of the values that is not empty after trimming spaces.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// truncateRunes shortens s to at most n runes.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+var _ port.FeedIngestUseCase = (*FeedIngestUseCase)(nil)
@@ -0,0 +1,321 @@
+// internal/usecase/feed_uc.go
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+	"github.com/yvanyang/language-learning-player-api/pkg/podcast"
+)
+
+// feedSecretPrefix marks secret feed tokens so they are recognisable if leaked.
+const feedSecretPrefix = "feed_"
+
+// maxFeedItems caps the episodes in the feed of a smart collection.
+const maxFeedItems = 500
+
+// feedLinkWindow is the granularity of stream link expiry times. Links issued within the same window
+// share an expiry, so that a feed fetched repeatedly is byte-for-byte identical.
+const feedLinkWindow = 24 * time.Hour
+
+// FeedUseCase serves collections as podcast feeds. It reuses the visibility rules and smart
+// collection evaluation of AudioContentUseCase, so a feed lists what its viewer would see in the app.
+type FeedUseCase struct {
+	audio     *AudioContentUseCase
+	tokenRepo port.FeedTokenRepository
+	userRepo  port.UserRepository
+	signer    port.LinkSigner
+	cfg       config.FeedConfig
+	logger    *slog.Logger
+}
+
+// NewFeedUseCase creates a new FeedUseCase.
+func NewFeedUseCase(
+	cfg config.FeedConfig,
+	audio *AudioContentUseCase,
+	tr port.FeedTokenRepository,
+	ur port.UserRepository,
+	signer port.LinkSigner,
+	log *slog.Logger,
+) *FeedUseCase {
+	return &FeedUseCase{
+		audio:     audio,
+		tokenRepo: tr,
+		userRepo:  ur,
+		signer:    signer,
+		cfg:       cfg,
+		logger:    log.With("usecase", "FeedUseCase"),
+	}
+}
+
+func (uc *FeedUseCase) GetPublicFeed(ctx context.Context, collectionID domain.CollectionID) (*port.CollectionFeed, error) {
+	collection, err := uc.audio.collectionRepo.FindWithTracks(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if !collection.IsVisibleTo(nil) {
+		return nil, domain.ErrNotFound // Private collections only have secret feeds
+	}
+	return uc.buildFeed(ctx, collection, nil, "", uc.apiURL("feeds", "collections", collectionID.String()))
+}
+
+func (uc *FeedUseCase) GetPrivateFeed(ctx context.Context, secret string) (*port.CollectionFeed, error) {
+	if !strings.HasPrefix(secret, feedSecretPrefix) {
+		return nil, domain.ErrNotFound
+	}
+	token, err := uc.tokenRepo.FindByHash(ctx, hashFeedSecret(secret))
+	if err != nil {
+		return nil, err // Unknown or revoked
+	}
+	collection, err := uc.audio.collectionRepo.FindWithTracks(ctx, token.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := collectionRole(ctx, uc.audio.memberRepo, collection, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" && !collection.IsVisibleTo(&token.UserID) {
+		uc.logger.WarnContext(ctx, "Secret feed requested after access was lost", "collectionID", collection.ID, "userID", token.UserID)
+		return nil, domain.ErrNotFound
+	}
+	return uc.buildFeed(ctx, collection, &token.UserID, token.ID.String(), uc.apiURL("feeds", "private", secret))
+}
+
+func (uc *FeedUseCase) IssueFeedToken(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) (*port.FeedTokenResult, error) {
+	collection, err := uc.audio.collectionRepo.FindByID(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := collectionRole(ctx, uc.audio.memberRepo, collection, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" && !collection.IsVisibleTo(&userID) {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("generating feed secret: %w", err)
+	}
+	secret := feedSecretPrefix + hex.EncodeToString(b)
+	token, err := domain.NewFeedToken(collectionID, userID, hashFeedSecret(secret))
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.tokenRepo.Save(ctx, token); err != nil {
+		return nil, fmt.Errorf("failed to save feed token: %w", err)
+	}
+	uc.logger.InfoContext(ctx, "Secret feed URL issued", "collectionID", collectionID, "userID", userID, "feedTokenID", token.ID)
+	return &port.FeedTokenResult{Token: token, FeedURL: uc.apiURL("feeds", "private", secret)}, nil
+}
+
+func (uc *FeedUseCase) RevokeFeedToken(ctx context.Context, userID domain.UserID, collectionID domain.CollectionID) error {
+	if err := uc.tokenRepo.Delete(ctx, collectionID, userID); err != nil {
+		return err // NotFound if the user has no feed URL for the collection
+	}
+	uc.logger.InfoContext(ctx, "Secret feed URL revoked", "collectionID", collectionID, "userID", userID)
+	return nil
+}
+
+func (uc *FeedUseCase) ResolveStreamLink(ctx context.Context, input port.StreamLinkInput) (string, error) {
+	feedTokenID := ""
+	if input.FeedTokenID != nil {
+		feedTokenID = input.FeedTokenID.String()
+	}
+	if !uc.signer.Verify(streamLinkResource(input.TrackID, feedTokenID), input.Expires, input.Signature) {
+		return "", fmt.Errorf("%w: the stream link is invalid or has expired", domain.ErrPermissionDenied)
+	}
+	track, err := uc.audio.trackRepo.FindByID(ctx, input.TrackID)
+	if err != nil {
+		return "", err
+	}
+	if !track.IsPublic {
+		// Only links from a secret feed carry private tracks, and only while the feed URL is valid
+		if input.FeedTokenID == nil {
+			return "", domain.ErrPermissionDenied
+		}
+		token, err := uc.tokenRepo.FindByID(ctx, *input.FeedTokenID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return "", fmt.Errorf("%w: the feed URL of this link was revoked", domain.ErrPermissionDenied)
+		}
+		if err != nil {
+			return "", err
+		}
+		if !track.IsVisibleTo(&token.UserID) {
+			collection, err := uc.audio.collectionRepo.FindByID(ctx, token.CollectionID)
+			if err != nil {
+				return "", err
+			}
+			role, err := collectionRole(ctx, uc.audio.memberRepo, collection, token.UserID)
+			if err != nil {
+				return "", err
+			}
+			if role == "" {
+				return "", domain.ErrPermissionDenied
+			}
+		}
+	}
+	presignedURL, err := uc.audio.storageService.GetPresignedGetURL(ctx, track.MinioBucket, track.MinioObjectKey, uc.audio.presignExpiry)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to generate presigned URL for stream link", "error", err, "trackID", track.ID)
+		return "", fmt.Errorf("failed to generate stream URL: %w", err)
+	}
+	return uc.audio.rewriteURLForCDN(ctx, presignedURL), nil
+}
+
+// buildFeed lists the tracks of a collection as the viewer sees them and turns them into a feed.
+// feedTokenID binds the stream links to a secret feed URL; it is empty for public feeds.
+func (uc *FeedUseCase) buildFeed(ctx context.Context, collection *domain.AudioCollection, viewerID *domain.UserID, feedTokenID, feedURL string) (*port.CollectionFeed, error) {
+	var tracks []*domain.AudioTrack
+	var err error
+	if collection.IsSmart() {
+		tracks, _, err = uc.audio.evaluateSmartRule(ctx, collection, viewerID, pagination.Page{Limit: maxFeedItems})
+	} else {
+		var role domain.CollectionRole
+		if role, err = uc.audio.viewerRole(ctx, collection, viewerID); err != nil {
+			return nil, err
+		}
+		if role == "" {
+			tracks, err = uc.audio.visibleTracks(ctx, collection.TrackIDs, viewerID)
+		} else {
+			tracks, err = uc.audio.trackRepo.ListByIDs(ctx, collection.TrackIDs)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &podcast.Feed{
+		Title:         collection.Title,
+		Description:   collection.Description,
+		Link:          uc.apiURL("audio", "collections", collection.ID.String()),
+		FeedURL:       feedURL,
+		Type:          podcast.TypeSerial,
+		LastBuildDate: collection.UpdatedAt,
+		Items:         make([]podcast.Item, 0, len(tracks)),
+	}
+	if feed.Description == "" {
+		feed.Description = collection.Title // Podcast apps require a description
+	}
+	if collection.IsSmart() {
+		feed.Type = podcast.TypeEpisodic // Ordered by the rule, not by episode number
+	}
+	if owner, err := uc.userRepo.FindByID(ctx, collection.OwnerID); err == nil {
+		feed.Author = owner.Name
+	} else {
+		uc.logger.WarnContext(ctx, "Failed to load collection owner for feed", "error", err, "collectionID", collection.ID)
+	}
+
+	trackIDs := make([]domain.TrackID, len(tracks))
+	for i, t := range tracks {
+		trackIDs[i] = t.ID
+	}
+	chapters, err := uc.audio.chapterRepo.ListByTracks(ctx, trackIDs)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to load track chapters for feed", "error", err, "collectionID", collection.ID)
+		chapters = nil // Serve the feed without chapter marks
+	}
+
+	linkWindow := time.Now().Truncate(feedLinkWindow)
+	expires := linkWindow.Add(feedLinkWindow + uc.cfg.StreamLinkExpiry)
+	languages := make(map[string]struct{})
+	for i, t := range tracks {
+		item := podcast.Item{
+			GUID:          t.ID.String(),
+			Title:         t.Title,
+			Description:   t.Description,
+			EnclosureURL:  uc.streamLinkURL(t.ID, feedTokenID, expires),
+			EnclosureType: audioContentType(t.MinioObjectKey),
+			EnclosureSize: t.SizeBytes,
+			Duration:      t.Duration,
+			PubDate:       t.CreatedAt,
+		}
+		if feed.Type == podcast.TypeSerial {
+			item.Episode = i + 1
+		}
+		for _, c := range chapters[t.ID] {
+			item.Chapters = append(item.Chapters, podcast.Chapter{Start: c.Start, Title: c.Title})
+		}
+		if t.CoverImageURL != nil {
+			item.ImageURL = *t.CoverImageURL
+			if feed.ImageURL == "" {
+				feed.ImageURL = *t.CoverImageURL
+			}
+		}
+		languages[t.Language.Code()] = struct{}{}
+		feed.Items = append(feed.Items, item)
+	}
+	if len(languages) == 1 {
+		for code := range languages {
+			feed.Language = code
+		}
+	}
+
+	result := &port.CollectionFeed{Feed: feed}
+	if !collection.IsSmart() {
+		// The stream links change with every window, so a feed cached before it started is stale
+		// even if the collection is not: podcast apps must pick up fresh links before theirs expire.
+		result.LastModified = collection.UpdatedAt
+		if linkWindow.After(result.LastModified) {
+			result.LastModified = linkWindow
+		}
+	}
+	uc.logger.InfoContext(ctx, "Podcast feed built", "collectionID", collection.ID, "items", len(feed.Items), "secret", feedTokenID != "")
+	return result, nil
+}
+
+// streamLinkURL returns the signed stream link of a track, e.g. /api/v1/feeds/stream/{trackId}?exp=...&sig=...
+func (uc *FeedUseCase) streamLinkURL(trackID domain.TrackID, feedTokenID string, expires time.Time) string {
+	q := url.Values{}
+	q.Set("exp", strconv.FormatInt(expires.Unix(), 10))
+	if feedTokenID != "" {
+		q.Set("ft", feedTokenID)
+	}
+	q.Set("sig", uc.signer.Sign(streamLinkResource(trackID, feedTokenID), expires))
+	return uc.apiURL("feeds", "stream", trackID.String()) + "?" + q.Encode()
+}
+
+// apiURL returns the public URL of an API path below /api/v1, e.g. apiURL("feeds", "private", secret).
+func (uc *FeedUseCase) apiURL(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = url.PathEscape(s)
+	}
+	return strings.TrimSuffix(uc.cfg.PublicBaseURL, "/") + "/api/v1/" + strings.Join(escaped, "/")
+}
+
+// streamLinkResource is the signed part of a stream link.
+func streamLinkResource(trackID domain.TrackID, feedTokenID string) string {
+	return "stream:" + trackID.String() + ":" + feedTokenID
+}
+
+func hashFeedSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// audioContentType guesses the MIME type of an audio object from its key.
+func audioContentType(objectKey string) string {
+	if t, ok := audioContentTypes[strings.ToLower(path.Ext(objectKey))]; ok {
+		return t
+	}
+	return "audio/mpeg"
+}
+
+var _ port.FeedUseCase = (*FeedUseCase)(nil)
@@ -0,0 +1,159 @@
+// internal/usecase/import_manifest.go
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+)
+
+// importManifestNames are the manifest files looked for at the top level of a library, in order.
+var importManifestNames = []string{"manifest.yaml", "manifest.yml", "manifest.csv"}
+
+// ImportManifest describes the content of a library. Every field is optional: values
+// missing here are taken from the tags of the audio files and then from the import options.
+type ImportManifest struct {
+	Courses []ImportManifestCourse `yaml:"courses"`
+	Tracks  []ImportManifestTrack  `yaml:"tracks"`
+}
+
+// ImportManifestCourse overrides the course created for a directory.
+type ImportManifestCourse struct {
+	Path        string `yaml:"path"` // Directory relative to the library root ("." for the top level)
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+}
+
+// ImportManifestTrack overrides the metadata of one audio file.
+type ImportManifestTrack struct {
+	File         string   `yaml:"file"` // Path relative to the library root
+	Title        string   `yaml:"title"`
+	Description  string   `yaml:"description"`
+	LanguageCode string   `yaml:"languageCode"`
+	Level        string   `yaml:"level"`
+	Tags         []string `yaml:"tags"`
+	DurationMs   int64    `yaml:"durationMs"`
+	IsPublic     *bool    `yaml:"isPublic"`
+}
+
+// readImportManifest reads the manifest at the top level of fsys. A library without a
+// manifest yields an empty one.
+func readImportManifest(fsys fs.FS) (*ImportManifest, error) {
+	for _, name := range importManifestNames {
+		data, err := fs.ReadFile(fsys, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		var manifest *ImportManifest
+		if path.Ext(name) == ".csv" {
+			manifest, err = parseCSVManifest(bytes.NewReader(data))
+		} else {
+			manifest = &ImportManifest{}
+			err = yaml.Unmarshal(data, manifest)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: parsing %s: %v", domain.ErrInvalidArgument, name, err)
+		}
+		return manifest, nil
+	}
+	return &ImportManifest{}, nil
+}
+
+// parseCSVManifest reads track entries from a CSV file whose header names the columns:
+// file, title, description, languageCode, level, tags (separated by ";"), durationMs, isPublic.
+// Only the file column is required.
+func parseCSVManifest(r io.Reader) (*ImportManifest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		switch name {
+		case "file", "title", "description", "languageCode", "level", "tags", "durationMs", "isPublic":
+			columns[name] = i
+		default:
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+	}
+	if _, ok := columns["file"]; !ok {
+		return nil, fmt.Errorf("missing column \"file\"")
+	}
+
+	manifest := &ImportManifest{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return manifest, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		entry := ImportManifestTrack{
+			File:         field("file"),
+			Title:        field("title"),
+			Description:  field("description"),
+			LanguageCode: field("languageCode"),
+			Level:        field("level"),
+		}
+		for _, tag := range strings.Split(field("tags"), ";") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				entry.Tags = append(entry.Tags, tag)
+			}
+		}
+		if v := field("durationMs"); v != "" {
+			if entry.DurationMs, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid durationMs %q", line, v)
+			}
+		}
+		if v := field("isPublic"); v != "" {
+			isPublic, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid isPublic %q", line, v)
+			}
+			entry.IsPublic = &isPublic
+		}
+		manifest.Tracks = append(manifest.Tracks, entry)
+	}
+}
+
+// trackEntries indexes the manifest's track entries by cleaned file path.
+func (m *ImportManifest) trackEntries() map[string]*ImportManifestTrack {
+	entries := make(map[string]*ImportManifestTrack, len(m.Tracks))
+	for i := range m.Tracks {
+		entries[path.Clean(m.Tracks[i].File)] = &m.Tracks[i]
+	}
+	return entries
+}
+
+// courseEntries indexes the manifest's course entries by cleaned directory path.
+func (m *ImportManifest) courseEntries() map[string]*ImportManifestCourse {
+	entries := make(map[string]*ImportManifestCourse, len(m.Courses))
+	for i := range m.Courses {
+		entries[path.Clean(m.Courses[i].Path)] = &m.Courses[i]
+	}
+	return entries
+}
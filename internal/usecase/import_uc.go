@@ -0,0 +1,603 @@
+// internal/usecase/import_uc.go
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/audiotag"
+)
+
+// importArchiveMaxAttempts bounds the retries of an archive import job.
+const importArchiveMaxAttempts = 3
+
+// ImportUseCase imports libraries of audio files: every file becomes an AudioTrack owned by
+// the given user and every directory containing audio files a COURSE collection.
+//
+// Imports are idempotent. Audio objects are stored under a key derived from the owner and
+// the SHA-256 of the content, so a file that was imported before is recognised by its
+// object key and reused instead of being uploaded again. Courses are matched by owner and
+// title; tracks missing from an existing course are appended.
+type ImportUseCase struct {
+	userRepo       port.UserRepository
+	trackRepo      port.AudioTrackRepository
+	collectionRepo port.AudioCollectionRepository
+	importRunRepo  port.ImportRunRepository
+	jobRepo        port.JobRepository
+	storageService port.FileStorageService
+	txManager      port.TransactionManager
+	auditRepo      port.AuditEventRepository
+	outboxRepo     port.OutboxRepository
+	cfg            config.ImportConfig
+	minioBucket    string
+	logger         *slog.Logger
+}
+
+// NewImportUseCase creates a new ImportUseCase.
+func NewImportUseCase(
+	cfg config.ImportConfig,
+	minioCfg config.MinioConfig,
+	ur port.UserRepository,
+	tr port.AudioTrackRepository,
+	cr port.AudioCollectionRepository,
+	irr port.ImportRunRepository,
+	jr port.JobRepository,
+	ss port.FileStorageService,
+	tm port.TransactionManager,
+	ar port.AuditEventRepository,
+	or port.OutboxRepository,
+	log *slog.Logger,
+) *ImportUseCase {
+	return &ImportUseCase{
+		userRepo:       ur,
+		trackRepo:      tr,
+		collectionRepo: cr,
+		importRunRepo:  irr,
+		jobRepo:        jr,
+		storageService: ss,
+		txManager:      tm,
+		auditRepo:      ar,
+		outboxRepo:     or,
+		cfg:            cfg,
+		minioBucket:    minioCfg.BucketName,
+		logger:         log.With("usecase", "ImportUseCase"),
+	}
+}
+
+// importArchivePayload is the payload of an archive import job.
+type importArchivePayload struct {
+	RunID     string             `json:"runId"`
+	ObjectKey string             `json:"objectKey"` // Staged archive
+	Options   port.ImportOptions `json:"options"`
+}
+
+// StartArchiveImport stages the archive in storage and queues a job that imports it.
+func (uc *ImportUseCase) StartArchiveImport(ctx context.Context, input port.StartImportInput) (*domain.ImportRun, error) {
+	adminID, err := requireAdmin(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(path.Ext(input.Filename), ".zip") {
+		return nil, fmt.Errorf("%w: the archive must be a .zip file", domain.ErrInvalidArgument)
+	}
+	if input.Size > uc.cfg.MaxArchiveSize {
+		return nil, fmt.Errorf("%w: the archive exceeds the maximum size of %d bytes", domain.ErrInvalidArgument, uc.cfg.MaxArchiveSize)
+	}
+	ownerID := adminID
+	if input.OwnerID != nil {
+		if _, err := uc.userRepo.FindByID(ctx, *input.OwnerID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, fmt.Errorf("%w: owner not found", domain.ErrInvalidArgument)
+			}
+			return nil, err
+		}
+		ownerID = *input.OwnerID
+	}
+
+	run := domain.NewImportRun(adminID, ownerID, path.Base(input.Filename))
+	stagingKey := fmt.Sprintf("imports/staging/%s.zip", run.ID)
+	log := uc.logger.With("importID", run.ID.String(), "objectKey", stagingKey)
+	if err := uc.storageService.PutObject(ctx, uc.minioBucket, stagingKey, input.Archive, input.Size, "application/zip"); err != nil {
+		return nil, fmt.Errorf("storing archive: %w", err)
+	}
+
+	job, err := domain.NewJob(JobTypeImportArchive, importArchivePayload{RunID: run.ID.String(), ObjectKey: stagingKey, Options: input.Options}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	job.MaxAttempts = importArchiveMaxAttempts
+
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := uc.importRunRepo.Create(txCtx, run); err != nil {
+			return err
+		}
+		if err := uc.jobRepo.Enqueue(txCtx, job); err != nil {
+			return err
+		}
+		diff := domain.AuditDiff{"source": {New: run.Source}, "ownerId": {New: ownerID.String()}}
+		return recordAudit(txCtx, uc.auditRepo, &adminID, domain.AuditActionImportStart, domain.AuditTargetImport, run.ID.String(), diff)
+	})
+	if err != nil {
+		if delErr := uc.storageService.DeleteObject(context.WithoutCancel(ctx), uc.minioBucket, stagingKey); delErr != nil {
+			log.WarnContext(ctx, "Failed to delete staged archive", "error", delErr)
+		}
+		return nil, err
+	}
+	log.InfoContext(ctx, "Archive import queued", "source", run.Source, "ownerID", ownerID.String())
+	return run, nil
+}
+
+// GetImportRun returns the state of an archive import.
+func (uc *ImportUseCase) GetImportRun(ctx context.Context, id domain.ImportRunID) (*domain.ImportRun, error) {
+	if _, err := requireAdmin(ctx, uc.userRepo); err != nil {
+		return nil, err
+	}
+	return uc.importRunRepo.FindByID(ctx, id)
+}
+
+// ImportLibrary imports the library in fsys for the user with the given email. name (a
+// directory or archive name) is the default title of the top-level course. It is used by
+// the command line, where there is no authenticated user: audit events have no actor.
+func (uc *ImportUseCase) ImportLibrary(ctx context.Context, fsys fs.FS, name, ownerEmail string, opts port.ImportOptions) (*domain.ImportReport, error) {
+	email, err := domain.NewEmail(ownerEmail)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("finding owner: %w", err)
+	}
+	fsys, opts = unwrapLibraryRoot(fsys, name, opts)
+	return uc.importFS(ctx, fsys, owner.ID, nil, opts)
+}
+
+// archiveImportHandler runs an archive import job.
+func (uc *ImportUseCase) archiveImportHandler() JobHandler {
+	return func(ctx context.Context, job *domain.Job) error {
+		return JSONJobHandler(func(ctx context.Context, p importArchivePayload) error {
+			return uc.runArchiveImport(ctx, p, job.Attempts >= job.MaxAttempts)
+		})(ctx, job)
+	}
+}
+
+func (uc *ImportUseCase) runArchiveImport(ctx context.Context, p importArchivePayload, lastAttempt bool) error {
+	runID, err := domain.ImportRunIDFromString(p.RunID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPermanentJobFailure, err)
+	}
+	run, err := uc.importRunRepo.FindByID(ctx, runID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%w: import run %s not found", ErrPermanentJobFailure, runID)
+		}
+		return err
+	}
+	if run.Status == domain.ImportSucceeded || run.Status == domain.ImportFailed {
+		return nil
+	}
+	log := uc.logger.With("importID", run.ID.String())
+
+	run.Start(time.Now())
+	if err := uc.importRunRepo.Update(ctx, run); err != nil {
+		return err
+	}
+
+	report, err := uc.importArchive(ctx, run, p)
+	if err != nil {
+		permanent := errors.Is(err, ErrPermanentJobFailure)
+		if !permanent && !lastAttempt {
+			return err // Retried; files imported so far are skipped next time
+		}
+		run.Fail(err.Error(), report, time.Now())
+		if updateErr := uc.importRunRepo.Update(context.WithoutCancel(ctx), run); updateErr != nil {
+			log.ErrorContext(ctx, "Failed to record failed import", "error", updateErr)
+		}
+		return err
+	}
+
+	run.Succeed(report, time.Now())
+	if err := uc.importRunRepo.Update(ctx, run); err != nil {
+		return err
+	}
+	if err := uc.storageService.DeleteObject(ctx, uc.minioBucket, p.ObjectKey); err != nil {
+		log.WarnContext(ctx, "Failed to delete staged archive", "error", err, "objectKey", p.ObjectKey)
+	}
+	log.InfoContext(ctx, "Archive import finished",
+		"tracksCreated", report.TracksCreated, "tracksExisting", report.TracksExisting, "tracksFailed", report.TracksFailed,
+		"collectionsCreated", report.CollectionsCreated, "collectionsUpdated", report.CollectionsUpdated)
+	return nil
+}
+
+// importArchive downloads the staged archive to a temporary file (zip needs random access)
+// and imports its content.
+func (uc *ImportUseCase) importArchive(ctx context.Context, run *domain.ImportRun, p importArchivePayload) (*domain.ImportReport, error) {
+	obj, err := uc.storageService.GetObject(ctx, uc.minioBucket, p.ObjectKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: staged archive is missing", ErrPermanentJobFailure)
+		}
+		return nil, err
+	}
+	defer obj.Close()
+
+	tmp, err := os.CreateTemp("", "import-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	size, err := io.Copy(tmp, obj)
+	if err != nil {
+		return nil, fmt.Errorf("downloading archive: %w", err)
+	}
+
+	archive, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading archive: %v", ErrPermanentJobFailure, err)
+	}
+	fsys, opts := unwrapLibraryRoot(archive, run.Source, p.Options)
+	return uc.importFS(ctx, fsys, run.OwnerID, run.RequestedBy, opts)
+}
+
+// unwrapLibraryRoot descends into the top-level directory of a library that contains
+// nothing else (as created by zipping a folder) and defaults the root title to the name
+// of that directory, or else to the library name without extension.
+func unwrapLibraryRoot(fsys fs.FS, name string, opts port.ImportOptions) (fs.FS, port.ImportOptions) {
+	defaultTitle := strings.TrimSuffix(path.Base(name), path.Ext(name))
+	entries, err := fs.ReadDir(fsys, ".")
+	if err == nil {
+		entries = slices.DeleteFunc(entries, func(e fs.DirEntry) bool { return skipImportEntry(e.Name()) })
+		if len(entries) == 1 && entries[0].IsDir() {
+			if sub, err := fs.Sub(fsys, entries[0].Name()); err == nil {
+				fsys, defaultTitle = sub, entries[0].Name()
+			}
+		}
+	}
+	if opts.RootTitle == "" {
+		opts.RootTitle = defaultTitle
+	}
+	return fsys, opts
+}
+
+// skipImportEntry reports whether a file or directory is ignored (hidden files and the
+// resource forks added by macOS).
+func skipImportEntry(name string) bool {
+	return strings.HasPrefix(name, ".") || name == "__MACOSX"
+}
+
+// importFS imports every audio file in fsys and builds one course per directory.
+// Failures of single files are recorded in the report; errors that stop the import are
+// returned together with the partial report.
+func (uc *ImportUseCase) importFS(ctx context.Context, fsys fs.FS, ownerID domain.UserID, actorID *domain.UserID, opts port.ImportOptions) (*domain.ImportReport, error) {
+	manifest, err := readImportManifest(fsys)
+	if err != nil {
+		return nil, err
+	}
+	trackEntries := manifest.trackEntries()
+	courseEntries := manifest.courseEntries()
+
+	// fs.WalkDir visits files in lexical order, which becomes the track order of each course.
+	var dirs []string
+	dirTracks := make(map[string][]domain.TrackID)
+	report := &domain.ImportReport{Items: []domain.ImportItem{}}
+	err = fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name != "." && skipImportEntry(d.Name()) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, ok := audioContentTypes[strings.ToLower(path.Ext(name))]; !ok {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		item := uc.importFile(ctx, fsys, name, trackEntries[name], ownerID, actorID, opts)
+		report.AddItem(item)
+		if item.Status == domain.ImportItemFailed {
+			return nil
+		}
+		trackID, err := domain.TrackIDFromString(item.TrackID)
+		if err != nil {
+			return err
+		}
+		dir := path.Dir(name)
+		if _, ok := dirTracks[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		if !slices.Contains(dirTracks[dir], trackID) {
+			dirTracks[dir] = append(dirTracks[dir], trackID)
+		}
+		return nil
+	})
+	if err != nil {
+		return report, fmt.Errorf("walking library: %w", err)
+	}
+
+	for _, dir := range dirs {
+		title, description := importCourseTitle(dir, opts.RootTitle), ""
+		if entry := courseEntries[dir]; entry != nil {
+			if entry.Title != "" {
+				title = entry.Title
+			}
+			description = entry.Description
+		}
+		if title == "" {
+			continue // Files at the top level without a root title are not put in a course
+		}
+		if err := uc.syncCourse(ctx, ownerID, actorID, title, description, dirTracks[dir], report); err != nil {
+			return report, fmt.Errorf("building course %q: %w", title, err)
+		}
+	}
+	return report, nil
+}
+
+// importCourseTitle derives a course title from a directory path, e.g. "Spanish / Unit 1".
+func importCourseTitle(dir, rootTitle string) string {
+	if dir == "." {
+		return rootTitle
+	}
+	parts := strings.Split(dir, "/")
+	if rootTitle != "" {
+		parts = append([]string{rootTitle}, parts...)
+	}
+	return strings.Join(parts, " / ")
+}
+
+// importFile imports one audio file unless a track with the same content already exists.
+func (uc *ImportUseCase) importFile(ctx context.Context, fsys fs.FS, name string, entry *ImportManifestTrack, ownerID domain.UserID, actorID *domain.UserID, opts port.ImportOptions) domain.ImportItem {
+	item := domain.ImportItem{Path: name, Status: domain.ImportItemFailed}
+	log := uc.logger.With("path", name)
+
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		item.Error = "cannot read file"
+		log.WarnContext(ctx, "Failed to stat file", "error", err)
+		return item
+	}
+	if info.Size() > uc.cfg.MaxFileSize {
+		item.Error = fmt.Sprintf("file exceeds the maximum size of %d bytes", uc.cfg.MaxFileSize)
+		return item
+	}
+	sum, tags, err := hashAudioFile(fsys, name, info.Size())
+	if err != nil {
+		item.Error = "cannot read file"
+		log.WarnContext(ctx, "Failed to read file", "error", err)
+		return item
+	}
+
+	ext := strings.ToLower(path.Ext(name))
+	objectKey := fmt.Sprintf("imports/%s/%s%s", ownerID, sum, ext)
+	existing, err := uc.trackRepo.FindByObjectKey(ctx, objectKey)
+	if err == nil {
+		item.Status, item.TrackID = domain.ImportItemExists, existing.ID.String()
+		return item
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		item.Error = "failed to look up existing track"
+		log.ErrorContext(ctx, "Failed to find track by object key", "error", err, "objectKey", objectKey)
+		return item
+	}
+
+	track, err := uc.buildImportedTrack(name, objectKey, entry, tags, ownerID, opts)
+	if err != nil {
+		item.Error = err.Error()
+		return item
+	}
+	track.ContentSHA256, track.SizeBytes = sum, info.Size()
+
+	// The object may exist without a track if a previous run stopped in between.
+	exists, err := uc.storageService.ObjectExists(ctx, uc.minioBucket, objectKey)
+	if err == nil && !exists {
+		err = uc.uploadImportedFile(ctx, fsys, name, objectKey, info.Size(), audioContentTypes[ext])
+	}
+	if err != nil {
+		item.Error = "failed to store audio file"
+		log.ErrorContext(ctx, "Failed to upload file", "error", err, "objectKey", objectKey)
+		return item
+	}
+
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := uc.trackRepo.Create(txCtx, track); err != nil {
+			return err
+		}
+		return recordTrackCreated(txCtx, uc.auditRepo, uc.outboxRepo, actorID, track)
+	})
+	if errors.Is(err, domain.ErrConflict) {
+		// Imported concurrently by another run.
+		if existing, findErr := uc.trackRepo.FindByObjectKey(ctx, objectKey); findErr == nil {
+			item.Status, item.TrackID = domain.ImportItemExists, existing.ID.String()
+			return item
+		}
+	}
+	if err != nil {
+		item.Error = "failed to save track"
+		log.ErrorContext(ctx, "Failed to create imported track", "error", err, "objectKey", objectKey)
+		return item
+	}
+	item.Status, item.TrackID = domain.ImportItemCreated, track.ID.String()
+	return item
+}
+
+// hashAudioFile returns the hex SHA-256 of a file and the tags at its start. Tags are nil
+// for formats audiotag does not read; damaged tags are ignored in favour of the manifest.
+func hashAudioFile(fsys fs.FS, name string, size int64) (string, *audiotag.Tags, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	tags, tagErr := audiotag.Read(io.TeeReader(f, h), size)
+	if tagErr != nil {
+		tags = nil
+	}
+	if _, err := io.Copy(h, f); err != nil {
+		return "", nil, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), tags, nil
+}
+
+func (uc *ImportUseCase) uploadImportedFile(ctx context.Context, fsys fs.FS, name, objectKey string, size int64, contentType string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return uc.storageService.PutObject(ctx, uc.minioBucket, objectKey, f, size, contentType)
+}
+
+// buildImportedTrack combines the manifest entry, the file tags and the import options, in
+// that order of precedence. The title falls back to the file name.
+func (uc *ImportUseCase) buildImportedTrack(name, objectKey string, entry *ImportManifestTrack, tags *audiotag.Tags, ownerID domain.UserID, opts port.ImportOptions) (*domain.AudioTrack, error) {
+	if entry == nil {
+		entry = &ImportManifestTrack{}
+	}
+	if tags == nil {
+		tags = &audiotag.Tags{}
+	}
+	base := path.Base(name)
+	title := firstNonEmpty(entry.Title, tags.Title, strings.TrimSuffix(base, path.Ext(base)))
+	description := firstNonEmpty(entry.Description, tags.Comment)
+
+	langCode := firstNonEmpty(entry.LanguageCode, tags.Language, opts.LanguageCode)
+	if langCode == "" {
+		return nil, fmt.Errorf("no language: set languageCode in the manifest or a default")
+	}
+	lang, err := domain.NewLanguage(langCode, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// An unrecognised level in the file tags is ignored; one in the manifest is an error.
+	level := domain.AudioLevel(strings.ToUpper(entry.Level))
+	if level == domain.LevelUnknown {
+		if tagLevel := domain.AudioLevel(strings.ToUpper(tags.Level)); tagLevel.IsValid() {
+			level = tagLevel
+		} else {
+			level = domain.AudioLevel(strings.ToUpper(opts.Level))
+		}
+	}
+
+	duration := time.Duration(entry.DurationMs) * time.Millisecond
+	if duration <= 0 {
+		duration = tags.Duration
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("unknown duration: set durationMs in the manifest")
+	}
+
+	trackTags := entry.Tags
+	if trackTags == nil {
+		trackTags = mergeImportTags(opts.Tags, tags.Keywords)
+	}
+	isPublic := opts.IsPublic
+	if entry.IsPublic != nil {
+		isPublic = *entry.IsPublic
+	}
+
+	return domain.NewAudioTrack(title, description, uc.minioBucket, objectKey, lang, level, duration, &ownerID, isPublic, trackTags, nil)
+}
+
+// mergeImportTags returns the union of the tag lists, keeping the first occurrence.
+func mergeImportTags(lists ...[]string) []string {
+	var tags []string
+	for _, list := range lists {
+		for _, tag := range list {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// syncCourse creates the course or appends the tracks it is missing.
+func (uc *ImportUseCase) syncCourse(ctx context.Context, ownerID domain.UserID, actorID *domain.UserID, title, description string, trackIDs []domain.TrackID, report *domain.ImportReport) error {
+	existing, err := findCollectionByTitle(ctx, uc.collectionRepo, ownerID, title)
+	if errors.Is(err, domain.ErrNotFound) {
+		collection, err := domain.NewAudioCollection(title, description, ownerID, domain.TypeCourse)
+		if err != nil {
+			return err
+		}
+		err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+			if err := uc.collectionRepo.Create(txCtx, collection); err != nil {
+				return fmt.Errorf("saving collection metadata: %w", err)
+			}
+			newVersion, err := uc.collectionRepo.ManageTracks(txCtx, collection.ID, trackIDs, collection.Version)
+			if err != nil {
+				return fmt.Errorf("adding tracks: %w", err)
+			}
+			collection.TrackIDs = trackIDs
+			collection.Version = newVersion
+			diff := domain.DiffFields(nil, collectionAuditFields(collection))
+			return recordAudit(txCtx, uc.auditRepo, actorID, domain.AuditActionCollectionCreate, domain.AuditTargetCollection, collection.ID.String(), diff)
+		})
+		if err != nil {
+			return err
+		}
+		report.CollectionsCreated++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		collection, err := uc.collectionRepo.FindWithTracks(txCtx, existing.ID)
+		if err != nil {
+			return err
+		}
+		before := collectionAuditFields(collection)
+		merged := slices.Clone(collection.TrackIDs)
+		for _, id := range trackIDs {
+			if !slices.Contains(merged, id) {
+				merged = append(merged, id)
+			}
+		}
+		if len(merged) == len(collection.TrackIDs) {
+			return nil
+		}
+		if _, err := uc.collectionRepo.ManageTracks(txCtx, collection.ID, merged, collection.Version); err != nil {
+			return fmt.Errorf("adding tracks: %w", err)
+		}
+		collection.TrackIDs = merged
+		changed = true
+		diff := domain.DiffFields(before, collectionAuditFields(collection))
+		return recordAudit(txCtx, uc.auditRepo, actorID, domain.AuditActionCollectionUpdate, domain.AuditTargetCollection, collection.ID.String(), diff)
+	})
+	if err != nil {
+		return err
+	}
+	if changed {
+		report.CollectionsUpdated++
+	}
+	return nil
+}
+
+var _ port.ImportUseCase = (*ImportUseCase)(nil)
@@ -0,0 +1,252 @@
+// internal/usecase/job_runner.go
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/cron"
+)
+
+// ErrPermanentJobFailure marks a job error that must not be retried. Handlers wrap it,
+// e.g. fmt.Errorf("%w: file is not audio", usecase.ErrPermanentJobFailure).
+var ErrPermanentJobFailure = errors.New("permanent job failure")
+
+// JobHandler executes one job attempt. Returning an error schedules a retry with
+// backoff unless it wraps ErrPermanentJobFailure. ctx is cancelled when the job's
+// visibility timeout expires or the shutdown grace period ends.
+type JobHandler func(ctx context.Context, job *domain.Job) error
+
+// JSONJobHandler adapts a handler that takes a typed payload decoded from the job's JSON.
+// Payloads that cannot be decoded fail permanently.
+func JSONJobHandler[T any](fn func(ctx context.Context, payload T) error) JobHandler {
+	return func(ctx context.Context, job *domain.Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("%w: decoding %s payload: %v", ErrPermanentJobFailure, job.Type, err)
+		}
+		return fn(ctx, payload)
+	}
+}
+
+// recurringJob is a job enqueued on a cron schedule.
+type recurringJob struct {
+	name     string
+	jobType  string
+	payload  any
+	schedule cron.Schedule
+	next     time.Time
+}
+
+// JobRunner claims jobs from the queue and executes them with the handler registered
+// for their type. Several runners (API replicas, workers) may share one queue.
+type JobRunner struct {
+	jobRepo         port.JobRepository
+	handlers        map[string]JobHandler
+	recurring       []*recurringJob
+	concurrency     int
+	pollInterval    time.Duration
+	visibility      time.Duration
+	shutdownTimeout time.Duration
+	policy          domain.RetryPolicy
+	logger          *slog.Logger
+	now             func() time.Time
+}
+
+// NewJobRunner creates a JobRunner from configuration. Register handlers before calling Run.
+func NewJobRunner(cfg config.JobsConfig, jr port.JobRepository, log *slog.Logger) *JobRunner {
+	return &JobRunner{
+		jobRepo:         jr,
+		handlers:        make(map[string]JobHandler),
+		concurrency:     cfg.Concurrency,
+		pollInterval:    cfg.PollInterval,
+		visibility:      cfg.VisibilityTimeout,
+		shutdownTimeout: cfg.ShutdownTimeout,
+		policy: domain.RetryPolicy{
+			InitialBackoff: cfg.InitialBackoff,
+			MaxBackoff:     cfg.MaxBackoff,
+		},
+		logger: log.With("usecase", "JobRunner"),
+		now:    time.Now,
+	}
+}
+
+// Register sets the handler for a job type. It panics if the type is already registered.
+func (r *JobRunner) Register(jobType string, handler JobHandler) {
+	if _, exists := r.handlers[jobType]; exists {
+		panic(fmt.Sprintf("job handler for %q registered twice", jobType))
+	}
+	r.handlers[jobType] = handler
+}
+
+// Schedule enqueues a job of jobType whenever the cron spec fires. name identifies the
+// schedule; occurrences are deduplicated across processes by name and run time.
+func (r *JobRunner) Schedule(name, spec, jobType string, payload any) error {
+	if _, ok := r.handlers[jobType]; !ok {
+		return fmt.Errorf("scheduling %s: no handler registered for job type %q", name, jobType)
+	}
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		return fmt.Errorf("scheduling %s: %w", name, err)
+	}
+	r.recurring = append(r.recurring, &recurringJob{name: name, jobType: jobType, payload: payload, schedule: schedule})
+	return nil
+}
+
+// Run processes jobs until ctx is cancelled, then stops claiming new jobs and waits
+// up to the shutdown timeout for running jobs before cancelling them.
+func (r *JobRunner) Run(ctx context.Context) {
+	// Jobs outlive ctx so that they can finish during shutdown.
+	execCtx, cancelExec := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelExec()
+
+	slots := make(chan struct{}, r.concurrency)
+	var running sync.WaitGroup
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	r.logger.Info("Job runner started", "concurrency", r.concurrency, "handlers", len(r.handlers), "recurring", len(r.recurring))
+	for {
+		r.enqueueRecurring(ctx)
+		r.poll(ctx, execCtx, slots, &running)
+
+		select {
+		case <-ctx.Done():
+			r.drain(&running, cancelExec)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain waits for running jobs, cancelling them once the shutdown timeout has passed.
+func (r *JobRunner) drain(running *sync.WaitGroup, cancelExec context.CancelFunc) {
+	done := make(chan struct{})
+	go func() {
+		running.Wait()
+		close(done)
+	}()
+
+	r.logger.Info("Job runner stopping, waiting for running jobs", "timeout", r.shutdownTimeout)
+	select {
+	case <-done:
+	case <-time.After(r.shutdownTimeout):
+		r.logger.Warn("Shutdown timeout reached, cancelling running jobs")
+		cancelExec()
+		<-done
+	}
+	r.logger.Info("Job runner stopped")
+}
+
+// poll claims as many due jobs as there are free slots and starts them.
+func (r *JobRunner) poll(ctx, execCtx context.Context, slots chan struct{}, running *sync.WaitGroup) {
+	free := cap(slots) - len(slots)
+	if free == 0 || ctx.Err() != nil {
+		return
+	}
+	jobs, err := r.jobRepo.Claim(ctx, free, r.visibility)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to claim jobs", "error", err)
+		return
+	}
+	for _, job := range jobs {
+		slots <- struct{}{}
+		running.Add(1)
+		go func(job *domain.Job) {
+			defer func() {
+				<-slots
+				running.Done()
+			}()
+			r.execute(execCtx, job)
+		}(job)
+	}
+}
+
+// execute runs a claimed job and stores the outcome.
+func (r *JobRunner) execute(ctx context.Context, job *domain.Job) {
+	log := r.logger.With("jobID", job.ID, "jobType", job.Type, "attempt", job.Attempts)
+
+	handler, ok := r.handlers[job.Type]
+	switch {
+	case job.Attempts > job.MaxAttempts:
+		// The final attempt was claimed but never reported back (e.g., the worker died).
+		job.MarkDead("visibility timeout expired on the final attempt", r.now())
+	case !ok:
+		job.MarkDead(fmt.Sprintf("no handler registered for job type %q", job.Type), r.now())
+	default:
+		start := r.now()
+		err := r.invoke(ctx, handler, job)
+		switch {
+		case err == nil:
+			job.MarkSucceeded(r.now())
+			log.DebugContext(ctx, "Job succeeded", "duration", r.now().Sub(start))
+		case errors.Is(err, ErrPermanentJobFailure):
+			job.MarkDead(err.Error(), r.now())
+		default:
+			job.MarkFailed(err.Error(), r.now(), r.policy)
+		}
+	}
+
+	switch job.Status {
+	case domain.JobDead:
+		log.WarnContext(ctx, "Job failed permanently", "error", job.LastError)
+	case domain.JobQueued:
+		log.InfoContext(ctx, "Job failed, will retry", "error", job.LastError, "runAt", job.RunAt)
+	}
+	if err := r.jobRepo.Save(context.WithoutCancel(ctx), job); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			log.WarnContext(ctx, "Job outcome discarded; it was claimed again after its visibility timeout")
+			return
+		}
+		log.ErrorContext(ctx, "Failed to save job outcome", "error", err)
+	}
+}
+
+// invoke calls the handler with the visibility timeout as deadline and turns panics into errors.
+func (r *JobRunner) invoke(ctx context.Context, handler JobHandler, job *domain.Job) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, r.visibility)
+	defer cancel()
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job handler panicked: %v", p)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// enqueueRecurring enqueues the latest due occurrence of each recurring job. Missed
+// occurrences (e.g., while no process was running) are not caught up individually.
+func (r *JobRunner) enqueueRecurring(ctx context.Context) {
+	now := r.now()
+	for _, rj := range r.recurring {
+		if rj.next.IsZero() {
+			rj.next = rj.schedule.Next(now)
+			continue
+		}
+		if rj.next.After(now) {
+			continue
+		}
+		job, err := domain.NewJob(rj.jobType, rj.payload, rj.next)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Failed to create recurring job", "error", err, "schedule", rj.name)
+			rj.next = rj.schedule.Next(now)
+			continue
+		}
+		key := fmt.Sprintf("cron:%s:%d", rj.name, rj.next.Unix())
+		job.UniqueKey = &key
+		if err := r.jobRepo.Enqueue(ctx, job); err != nil && !errors.Is(err, domain.ErrConflict) {
+			// Retried on the next poll.
+			r.logger.ErrorContext(ctx, "Failed to enqueue recurring job", "error", err, "schedule", rj.name)
+			continue
+		}
+		rj.next = rj.schedule.Next(now)
+	}
+}
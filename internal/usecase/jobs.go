@@ -0,0 +1,122 @@
+// internal/usecase/jobs.go
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// Job types handled by the job runner.
+const (
+	JobTypeCleanupFinishedJobs = "jobs.cleanup"
+	JobTypeImportArchive       = "imports.archive"
+	JobTypeTrackChecksum       = "tracks.checksum"
+	JobTypeFeedRefreshDue      = "feeds.refresh_due"
+	JobTypeFeedRefresh         = "feeds.refresh"
+)
+
+// JobDependencies holds what the job handlers need. cmd/api (in-process mode) and
+// cmd/worker build it from the same configuration.
+type JobDependencies struct {
+	JobRepo   port.JobRepository
+	TrackRepo port.AudioTrackRepository
+	Storage   port.FileStorageService
+	Importer  *ImportUseCase
+	Feeds     *FeedIngestUseCase
+}
+
+// RegisterJobHandlers registers every job handler and recurring job with the runner.
+func RegisterJobHandlers(runner *JobRunner, cfg config.JobsConfig, deps JobDependencies, log *slog.Logger) error {
+	runner.Register(JobTypeCleanupFinishedJobs, cleanupFinishedJobsHandler(deps.JobRepo, cfg.Retention, log))
+	runner.Register(JobTypeImportArchive, deps.Importer.archiveImportHandler())
+	runner.Register(JobTypeTrackChecksum, trackChecksumHandler(deps.TrackRepo, deps.Storage, log))
+	runner.Register(JobTypeFeedRefreshDue, deps.Feeds.refreshDueFeedsHandler())
+	runner.Register(JobTypeFeedRefresh, deps.Feeds.refreshFeedHandler())
+	if cfg.Retention > 0 {
+		if err := runner.Schedule("cleanup-finished-jobs", "@hourly", JobTypeCleanupFinishedJobs, struct{}{}); err != nil {
+			return err
+		}
+	}
+	return runner.Schedule("refresh-feeds", deps.Feeds.cfg.RefreshSchedule, JobTypeFeedRefreshDue, struct{}{})
+}
+
+// cleanupFinishedJobsHandler deletes succeeded and dead jobs older than retention.
+func cleanupFinishedJobsHandler(repo port.JobRepository, retention time.Duration, log *slog.Logger) JobHandler {
+	return func(ctx context.Context, job *domain.Job) error {
+		removed, err := repo.DeleteFinished(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+		if removed > 0 {
+			log.InfoContext(ctx, "Removed finished jobs", "removed_count", removed, "retention", retention)
+		}
+		return nil
+	}
+}
+
+// trackChecksumPayload identifies the track whose audio object is hashed.
+type trackChecksumPayload struct {
+	TrackID string `json:"trackId"`
+}
+
+// trackChecksumHandler records the SHA-256 and size of an uploaded track's audio object.
+func trackChecksumHandler(trackRepo port.AudioTrackRepository, storage port.FileStorageService, log *slog.Logger) JobHandler {
+	return JSONJobHandler(func(ctx context.Context, p trackChecksumPayload) error {
+		trackID, err := domain.TrackIDFromString(p.TrackID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrPermanentJobFailure, err)
+		}
+		track, err := trackRepo.FindByID(ctx, trackID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil // Deleted since the upload
+		}
+		if err != nil {
+			return err
+		}
+		if track.ContentSHA256 != "" {
+			return nil
+		}
+		err = recordTrackChecksum(ctx, trackRepo, storage, track)
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%w: audio object %s not found", ErrPermanentJobFailure, track.MinioObjectKey)
+		}
+		if err != nil {
+			return err
+		}
+		log.DebugContext(ctx, "Recorded track checksum", "trackID", p.TrackID, "contentSha256", track.ContentSHA256, "sizeBytes", track.SizeBytes)
+		return nil
+	})
+}
+
+// recordTrackChecksum hashes the track's audio object and saves the checksum and size, also
+// setting them on track. It returns domain.ErrNotFound if the object does not exist.
+func recordTrackChecksum(ctx context.Context, trackRepo port.AudioTrackRepository, storage port.FileStorageService, track *domain.AudioTrack) error {
+	object, err := storage.GetObject(ctx, track.MinioBucket, track.MinioObjectKey)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, object)
+	if err != nil {
+		return fmt.Errorf("reading audio object: %w", err)
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	// A track deleted in the meantime is not an error.
+	if err := trackRepo.SetContentChecksum(ctx, track.ID, sum, size); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	track.ContentSHA256, track.SizeBytes = sum, size
+	return nil
+}
@@ -0,0 +1,531 @@
+// internal/usecase/maintenance_uc.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+)
+
+// MaintenanceUseCase implements operator tasks run from the command line (cmd/llpctl).
+// There is no authenticated user: audit events are recorded without an actor.
+type MaintenanceUseCase struct {
+	userRepo         port.UserRepository
+	refreshTokenRepo port.RefreshTokenRepository
+	trackRepo        port.AudioTrackRepository
+	collectionRepo   port.AudioCollectionRepository
+	storageService   port.FileStorageService
+	txManager        port.TransactionManager
+	auditRepo        port.AuditEventRepository
+	secHelper        port.SecurityHelper
+	minioBucket      string
+	logger           *slog.Logger
+}
+
+// NewMaintenanceUseCase creates a new MaintenanceUseCase.
+func NewMaintenanceUseCase(
+	cfg config.MinioConfig,
+	ur port.UserRepository,
+	rtr port.RefreshTokenRepository,
+	tr port.AudioTrackRepository,
+	cr port.AudioCollectionRepository,
+	ss port.FileStorageService,
+	tm port.TransactionManager,
+	ar port.AuditEventRepository,
+	sec port.SecurityHelper,
+	log *slog.Logger,
+) *MaintenanceUseCase {
+	return &MaintenanceUseCase{
+		userRepo:         ur,
+		refreshTokenRepo: rtr,
+		trackRepo:        tr,
+		collectionRepo:   cr,
+		storageService:   ss,
+		txManager:        tm,
+		auditRepo:        ar,
+		secHelper:        sec,
+		minioBucket:      cfg.BucketName,
+		logger:           log.With("usecase", "MaintenanceUseCase"),
+	}
+}
+
+// CreateUser creates a local user with the given role.
+func (uc *MaintenanceUseCase) CreateUser(ctx context.Context, email, name, password string, role domain.UserRole) (*domain.User, error) {
+	if len(password) < 8 {
+		return nil, fmt.Errorf("%w: password must be at least 8 characters long", domain.ErrInvalidArgument)
+	}
+	hashedPassword, err := uc.secHelper.HashPassword(ctx, password)
+	if err != nil {
+		return nil, fmt.Errorf("hashing password: %w", err)
+	}
+	user, err := domain.NewLocalUser(email, name, hashedPassword)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.SetRole(role); err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		exists, err := uc.userRepo.EmailExists(txCtx, user.Email)
+		if err != nil {
+			return fmt.Errorf("checking email existence: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("%w: a user with email '%s' already exists", domain.ErrConflict, user.Email.String())
+		}
+		if err := uc.userRepo.Create(txCtx, user); err != nil {
+			return err
+		}
+		diff := domain.AuditDiff{"role": {New: string(user.Role)}}
+		return recordAudit(txCtx, uc.auditRepo, nil, domain.AuditActionUserCreate, domain.AuditTargetUser, user.ID.String(), diff)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "User created", "userID", user.ID, "role", user.Role)
+	return user, nil
+}
+
+// SetUserRole changes the role of the user with the given email.
+func (uc *MaintenanceUseCase) SetUserRole(ctx context.Context, email string, role domain.UserRole) (*domain.User, error) {
+	var user *domain.User
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var err error
+		user, err = uc.findUserByEmail(txCtx, email)
+		if err != nil {
+			return err
+		}
+		oldRole := user.Role
+		if err := user.SetRole(role); err != nil {
+			return err
+		}
+		if oldRole == role {
+			return nil
+		}
+		if err := uc.userRepo.Update(txCtx, user); err != nil {
+			return err
+		}
+		diff := domain.AuditDiff{"role": {Old: string(oldRole), New: string(role)}}
+		return recordAudit(txCtx, uc.auditRepo, nil, domain.AuditActionUserRoleChange, domain.AuditTargetUser, user.ID.String(), diff)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "User role set", "userID", user.ID, "role", role)
+	return user, nil
+}
+
+// ResetPassword sets a new password for a local user and revokes their sessions.
+func (uc *MaintenanceUseCase) ResetPassword(ctx context.Context, email, password string) error {
+	if len(password) < 8 {
+		return fmt.Errorf("%w: password must be at least 8 characters long", domain.ErrInvalidArgument)
+	}
+	hashedPassword, err := uc.secHelper.HashPassword(ctx, password)
+	if err != nil {
+		return fmt.Errorf("hashing password: %w", err)
+	}
+
+	return uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := uc.findUserByEmail(txCtx, email)
+		if err != nil {
+			return err
+		}
+		if err := user.SetPasswordHash(hashedPassword); err != nil {
+			return err
+		}
+		if err := uc.userRepo.Update(txCtx, user); err != nil {
+			return err
+		}
+		if _, err := uc.refreshTokenRepo.DeleteByUser(txCtx, user.ID); err != nil {
+			return fmt.Errorf("revoking sessions: %w", err)
+		}
+		return recordAudit(txCtx, uc.auditRepo, nil, domain.AuditActionPasswordReset, domain.AuditTargetUser, user.ID.String(), nil)
+	})
+}
+
+// RevokeSessions deletes all refresh tokens of the user with the given email and returns
+// how many were deleted. Access tokens already issued stay valid until they expire.
+func (uc *MaintenanceUseCase) RevokeSessions(ctx context.Context, email string) (int64, error) {
+	var revoked int64
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		user, err := uc.findUserByEmail(txCtx, email)
+		if err != nil {
+			return err
+		}
+		revoked, err = uc.refreshTokenRepo.DeleteByUser(txCtx, user.ID)
+		if err != nil {
+			return fmt.Errorf("revoking sessions: %w", err)
+		}
+		diff := domain.AuditDiff{"revokedCount": {New: revoked}}
+		return recordAudit(txCtx, uc.auditRepo, nil, domain.AuditActionSessionsRevoke, domain.AuditTargetUser, user.ID.String(), diff)
+	})
+	return revoked, err
+}
+
+// SeedFixture describes demo content. Entries refer to each other by natural keys:
+// users by email and tracks by object key.
+type SeedFixture struct {
+	Users       []SeedUser       `yaml:"users"`
+	Tracks      []SeedTrack      `yaml:"tracks"`
+	Collections []SeedCollection `yaml:"collections"`
+}
+
+type SeedUser struct {
+	Email    string `yaml:"email"`
+	Name     string `yaml:"name"`
+	Password string `yaml:"password"`
+	Role     string `yaml:"role"` // Defaults to "user"
+}
+
+type SeedTrack struct {
+	Title         string   `yaml:"title"`
+	Description   string   `yaml:"description"`
+	LanguageCode  string   `yaml:"languageCode"`
+	Level         string   `yaml:"level"`
+	DurationMs    int64    `yaml:"durationMs"`
+	ObjectKey     string   `yaml:"objectKey"`
+	CoverImageURL *string  `yaml:"coverImageUrl"`
+	IsPublic      bool     `yaml:"isPublic"`
+	Tags          []string `yaml:"tags"`
+	UploaderEmail string   `yaml:"uploaderEmail"` // Optional
+}
+
+type SeedCollection struct {
+	Title           string   `yaml:"title"`
+	Description     string   `yaml:"description"`
+	Type            string   `yaml:"type"` // COURSE or PLAYLIST
+	OwnerEmail      string   `yaml:"ownerEmail"`
+	TrackObjectKeys []string `yaml:"trackObjectKeys"`
+}
+
+// SeedResult counts what Seed created and what already existed.
+type SeedResult struct {
+	UsersCreated       int
+	TracksCreated      int
+	CollectionsCreated int
+	Skipped            int
+}
+
+// Seed creates the users, tracks and collections of the fixture in one transaction.
+// Entries that already exist (same email, object key, or owner and collection title) are
+// skipped, so seeding twice is harmless. Audio objects are not uploaded.
+func (uc *MaintenanceUseCase) Seed(ctx context.Context, fixture SeedFixture) (*SeedResult, error) {
+	result := &SeedResult{}
+	err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		for _, su := range fixture.Users {
+			if err := uc.seedUser(txCtx, su, result); err != nil {
+				return fmt.Errorf("seeding user %s: %w", su.Email, err)
+			}
+		}
+		for _, st := range fixture.Tracks {
+			if err := uc.seedTrack(txCtx, st, result); err != nil {
+				return fmt.Errorf("seeding track %s: %w", st.ObjectKey, err)
+			}
+		}
+		for _, sc := range fixture.Collections {
+			if err := uc.seedCollection(txCtx, sc, result); err != nil {
+				return fmt.Errorf("seeding collection %q: %w", sc.Title, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "Fixture seeded", "users", result.UsersCreated, "tracks", result.TracksCreated, "collections", result.CollectionsCreated, "skipped", result.Skipped)
+	return result, nil
+}
+
+func (uc *MaintenanceUseCase) seedUser(ctx context.Context, su SeedUser, result *SeedResult) error {
+	_, err := uc.findUserByEmail(ctx, su.Email)
+	if err == nil {
+		result.Skipped++
+		return nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	hashedPassword, err := uc.secHelper.HashPassword(ctx, su.Password)
+	if err != nil {
+		return fmt.Errorf("hashing password: %w", err)
+	}
+	user, err := domain.NewLocalUser(su.Email, su.Name, hashedPassword)
+	if err != nil {
+		return err
+	}
+	if su.Role != "" {
+		if err := user.SetRole(domain.UserRole(su.Role)); err != nil {
+			return err
+		}
+	}
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return err
+	}
+	result.UsersCreated++
+	return nil
+}
+
+func (uc *MaintenanceUseCase) seedTrack(ctx context.Context, st SeedTrack, result *SeedResult) error {
+	_, err := uc.trackRepo.FindByObjectKey(ctx, st.ObjectKey)
+	if err == nil {
+		result.Skipped++
+		return nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	var uploaderID *domain.UserID
+	if st.UploaderEmail != "" {
+		uploader, err := uc.findUserByEmail(ctx, st.UploaderEmail)
+		if err != nil {
+			return fmt.Errorf("finding uploader: %w", err)
+		}
+		uploaderID = &uploader.ID
+	}
+	lang, err := domain.NewLanguage(st.LanguageCode, "")
+	if err != nil {
+		return err
+	}
+	track, err := domain.NewAudioTrack(
+		st.Title, st.Description, uc.minioBucket, st.ObjectKey, lang, domain.AudioLevel(st.Level),
+		time.Duration(st.DurationMs)*time.Millisecond, uploaderID, st.IsPublic, st.Tags, st.CoverImageURL,
+	)
+	if err != nil {
+		return err
+	}
+	if err := uc.trackRepo.Create(ctx, track); err != nil {
+		return err
+	}
+	result.TracksCreated++
+	return nil
+}
+
+func (uc *MaintenanceUseCase) seedCollection(ctx context.Context, sc SeedCollection, result *SeedResult) error {
+	owner, err := uc.findUserByEmail(ctx, sc.OwnerEmail)
+	if err != nil {
+		return fmt.Errorf("finding owner: %w", err)
+	}
+	_, err = findCollectionByTitle(ctx, uc.collectionRepo, owner.ID, sc.Title)
+	if err == nil {
+		result.Skipped++
+		return nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	collection, err := domain.NewAudioCollection(sc.Title, sc.Description, owner.ID, domain.CollectionType(sc.Type))
+	if err != nil {
+		return err
+	}
+	trackIDs := make([]domain.TrackID, 0, len(sc.TrackObjectKeys))
+	for _, key := range sc.TrackObjectKeys {
+		track, err := uc.trackRepo.FindByObjectKey(ctx, key)
+		if err != nil {
+			return fmt.Errorf("finding track %s: %w", key, err)
+		}
+		trackIDs = append(trackIDs, track.ID)
+	}
+	if err := uc.collectionRepo.Create(ctx, collection); err != nil {
+		return err
+	}
+	if len(trackIDs) > 0 {
+		if _, err := uc.collectionRepo.ManageTracks(ctx, collection.ID, trackIDs, collection.Version); err != nil {
+			return fmt.Errorf("adding tracks: %w", err)
+		}
+	}
+	result.CollectionsCreated++
+	return nil
+}
+
+// findCollectionByTitle returns the first collection of the owner with the given title,
+// or domain.ErrNotFound. Titles are not unique; seeding and imports use them as natural keys.
+func findCollectionByTitle(ctx context.Context, repo port.AudioCollectionRepository, ownerID domain.UserID, title string) (*domain.AudioCollection, error) {
+	for offset := 0; ; offset += pagination.MaxLimit {
+		collections, total, err := repo.ListByOwner(ctx, ownerID, pagination.NewPageFromOffset(pagination.MaxLimit, offset))
+		if err != nil {
+			return nil, fmt.Errorf("listing collections: %w", err)
+		}
+		for _, c := range collections {
+			if c.Title == title {
+				return c, nil
+			}
+		}
+		if offset+len(collections) >= total || len(collections) == 0 {
+			return nil, domain.ErrNotFound
+		}
+	}
+}
+
+// VerifyStorageInput configures a storage consistency check.
+type VerifyStorageInput struct {
+	// Prefix limits the search for orphaned objects to keys under it (e.g., "user-uploads/").
+	Prefix string
+	// MinOrphanAge excludes recently written objects, which may belong to uploads in progress.
+	MinOrphanAge time.Duration
+	// Repair deletes orphaned objects and the tracks whose audio object is missing.
+	Repair bool
+}
+
+// StorageReport is the outcome of VerifyStorage.
+type StorageReport struct {
+	TracksChecked   int
+	ObjectsChecked  int
+	MissingObjects  []*domain.AudioTrack // Tracks whose audio object does not exist
+	OrphanedObjects []port.StorageObject // Objects under the prefix that no track refers to
+	TracksDeleted   int
+	ObjectsDeleted  int
+}
+
+// VerifyStorage compares audio_tracks.minio_object_key with the objects in storage.
+func (uc *MaintenanceUseCase) VerifyStorage(ctx context.Context, input VerifyStorageInput) (*StorageReport, error) {
+	report := &StorageReport{}
+
+	// Collect the object keys of every track, per bucket.
+	referenced := make(map[string]map[string]*domain.AudioTrack)
+	for offset := 0; ; offset += pagination.MaxLimit {
+		tracks, total, err := uc.trackRepo.List(ctx, port.ListTracksFilters{SortBy: "createdAt", SortDirection: "asc"}, pagination.NewPageFromOffset(pagination.MaxLimit, offset))
+		if err != nil {
+			return nil, fmt.Errorf("listing tracks: %w", err)
+		}
+		for _, t := range tracks {
+			if referenced[t.MinioBucket] == nil {
+				referenced[t.MinioBucket] = make(map[string]*domain.AudioTrack)
+			}
+			referenced[t.MinioBucket][t.MinioObjectKey] = t
+			report.TracksChecked++
+		}
+		if offset+len(tracks) >= total || len(tracks) == 0 {
+			break
+		}
+	}
+	if referenced[uc.minioBucket] == nil {
+		referenced[uc.minioBucket] = make(map[string]*domain.AudioTrack)
+	}
+
+	cutoff := time.Now().Add(-input.MinOrphanAge)
+	buckets := make([]string, 0, len(referenced))
+	for bucket := range referenced {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+	for _, bucket := range buckets {
+		objects, err := uc.storageService.ListObjects(ctx, bucket, "")
+		if err != nil {
+			return nil, fmt.Errorf("listing objects in bucket %s: %w", bucket, err)
+		}
+		report.ObjectsChecked += len(objects)
+
+		stored := make(map[string]struct{}, len(objects))
+		for _, obj := range objects {
+			stored[obj.Key] = struct{}{}
+			if _, ok := referenced[bucket][obj.Key]; ok || bucket != uc.minioBucket {
+				continue
+			}
+			if strings.HasPrefix(obj.Key, input.Prefix) && obj.LastModified.Before(cutoff) {
+				report.OrphanedObjects = append(report.OrphanedObjects, obj)
+			}
+		}
+		for key, track := range referenced[bucket] {
+			if _, ok := stored[key]; !ok {
+				report.MissingObjects = append(report.MissingObjects, track)
+			}
+		}
+	}
+	sort.Slice(report.MissingObjects, func(i, j int) bool {
+		return report.MissingObjects[i].MinioObjectKey < report.MissingObjects[j].MinioObjectKey
+	})
+
+	if input.Repair {
+		uc.repairStorage(ctx, report)
+	}
+	uc.logger.InfoContext(ctx, "Storage verified",
+		"tracksChecked", report.TracksChecked, "objectsChecked", report.ObjectsChecked,
+		"missingObjects", len(report.MissingObjects), "orphanedObjects", len(report.OrphanedObjects),
+		"tracksDeleted", report.TracksDeleted, "objectsDeleted", report.ObjectsDeleted)
+	return report, nil
+}
+
+// repairStorage deletes what VerifyStorage found. Failures are logged and skipped.
+func (uc *MaintenanceUseCase) repairStorage(ctx context.Context, report *StorageReport) {
+	for _, obj := range report.OrphanedObjects {
+		if err := uc.storageService.DeleteObject(ctx, uc.minioBucket, obj.Key); err != nil {
+			uc.logger.ErrorContext(ctx, "Failed to delete orphaned object", "error", err, "key", obj.Key)
+			continue
+		}
+		report.ObjectsDeleted++
+	}
+	for _, track := range report.MissingObjects {
+		err := uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+			if err := uc.trackRepo.Delete(txCtx, track.ID); err != nil {
+				return err
+			}
+			diff := domain.DiffFields(trackAuditFields(track), nil)
+			return recordAudit(txCtx, uc.auditRepo, nil, domain.AuditActionTrackDelete, domain.AuditTargetTrack, track.ID.String(), diff)
+		})
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "Failed to delete track with missing object", "error", err, "trackID", track.ID)
+			continue
+		}
+		report.TracksDeleted++
+	}
+}
+
+// ChecksumReport is the outcome of ComputeChecksums.
+type ChecksumReport struct {
+	TracksChecked int
+	Computed      int
+	Failed        []*domain.AudioTrack // Tracks whose object could not be read
+}
+
+// ComputeChecksums records the checksum of every track that has none yet, such as tracks
+// uploaded before checksums were introduced. Failures are logged and skipped.
+func (uc *MaintenanceUseCase) ComputeChecksums(ctx context.Context) (*ChecksumReport, error) {
+	report := &ChecksumReport{}
+	var pending []*domain.AudioTrack
+	for offset := 0; ; offset += pagination.MaxLimit {
+		tracks, total, err := uc.trackRepo.List(ctx, port.ListTracksFilters{SortBy: "createdAt", SortDirection: "asc"}, pagination.NewPageFromOffset(pagination.MaxLimit, offset))
+		if err != nil {
+			return nil, fmt.Errorf("listing tracks: %w", err)
+		}
+		for _, t := range tracks {
+			report.TracksChecked++
+			if t.ContentSHA256 == "" {
+				pending = append(pending, t)
+			}
+		}
+		if offset+len(tracks) >= total || len(tracks) == 0 {
+			break
+		}
+	}
+
+	for _, track := range pending {
+		if err := recordTrackChecksum(ctx, uc.trackRepo, uc.storageService, track); err != nil {
+			uc.logger.ErrorContext(ctx, "Failed to compute track checksum", "error", err, "trackID", track.ID, "objectKey", track.MinioObjectKey)
+			report.Failed = append(report.Failed, track)
+			continue
+		}
+		report.Computed++
+	}
+	uc.logger.InfoContext(ctx, "Track checksums computed", "tracksChecked", report.TracksChecked, "computed", report.Computed, "failed", len(report.Failed))
+	return report, nil
+}
+
+func (uc *MaintenanceUseCase) findUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	emailVO, err := domain.NewEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	return uc.userRepo.FindByEmail(ctx, emailVO)
+}
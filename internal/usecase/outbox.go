@@ -0,0 +1,40 @@
+// internal/usecase/outbox.go
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// publishEvent writes a domain event to the outbox. Like recordAudit, call it with the
+// transaction context inside TransactionManager.Execute so the event is only
+// dispatched if the change it describes is committed.
+func publishEvent(ctx context.Context, repo port.OutboxRepository, eventType domain.EventType, data any) error {
+	event, err := domain.NewOutboxEvent(eventType, data)
+	if err != nil {
+		return err
+	}
+	if err := repo.Append(ctx, event); err != nil {
+		return fmt.Errorf("publishing %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+// trackEventData is the payload of track-related events.
+func trackEventData(t *domain.AudioTrack) map[string]any {
+	data := map[string]any{
+		"trackId":      t.ID.String(),
+		"title":        t.Title,
+		"languageCode": t.Language.Code(),
+		"level":        t.Level.String(),
+		"durationMs":   t.Duration.Milliseconds(),
+		"isPublic":     t.IsPublic,
+	}
+	if t.UploaderID != nil {
+		data["uploaderId"] = t.UploaderID.String()
+	}
+	return data
+}
@@ -0,0 +1,302 @@
+// internal/usecase/resumable_upload_uc.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+const (
+	resumableCleanupInterval  = 10 * time.Minute
+	resumableCleanupBatchSize = 100
+)
+
+// ResumableUploadUseCase implements tus uploads. Chunks are appended to a staging store; once the
+// file is complete it is copied to storage and its track is created by UploadUseCase.CompleteUpload,
+// exactly as for a file uploaded with a presigned URL.
+type ResumableUploadUseCase struct {
+	cfg        config.UploadConfig
+	uploads    *UploadUseCase
+	uploadRepo port.ResumableUploadRepository
+	staging    port.UploadStagingStore
+	logger     *slog.Logger
+
+	mu     sync.Mutex
+	active map[domain.ResumableUploadID]struct{} // Uploads a request is currently writing to
+}
+
+// NewResumableUploadUseCase creates a new ResumableUploadUseCase.
+func NewResumableUploadUseCase(
+	cfg config.UploadConfig,
+	uploads *UploadUseCase,
+	rr port.ResumableUploadRepository,
+	ss port.UploadStagingStore,
+	log *slog.Logger,
+) *ResumableUploadUseCase {
+	return &ResumableUploadUseCase{
+		cfg:        cfg,
+		uploads:    uploads,
+		uploadRepo: rr,
+		staging:    ss,
+		logger:     log.With("usecase", "ResumableUploadUseCase"),
+		active:     make(map[domain.ResumableUploadID]struct{}),
+	}
+}
+
+// CreateUpload validates the file and track details and prepares an empty upload.
+func (uc *ResumableUploadUseCase) CreateUpload(ctx context.Context, userID domain.UserID, input port.CreateResumableUploadInput) (*domain.ResumableUpload, error) {
+	log := uc.logger.With("userID", userID.String(), "filename", input.Filename, "length", input.Length)
+
+	if input.Filename == "" {
+		return nil, fmt.Errorf("%w: filename cannot be empty", domain.ErrInvalidArgument)
+	}
+	contentType, err := uc.uploads.resolveContentType(input.Filename, input.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	if input.Length <= 0 {
+		return nil, fmt.Errorf("%w: upload length must be positive", domain.ErrInvalidArgument)
+	}
+	if input.Length > uc.cfg.MaxFileSize {
+		return nil, fmt.Errorf("%w: file exceeds the maximum size of %d bytes", domain.ErrInvalidArgument, uc.cfg.MaxFileSize)
+	}
+
+	meta := input.Track
+	objectKey := uc.uploads.generateObjectKey(userID, input.Filename)
+	if err := uc.uploads.validateCompleteUploadRequest(ctx, userID, objectKey, meta.Title, meta.LanguageCode, meta.Duration, meta.Level); err != nil {
+		return nil, err
+	}
+	track := domain.ResumableUploadTrack{
+		Title:         meta.Title,
+		Description:   meta.Description,
+		LanguageCode:  meta.LanguageCode,
+		Level:         meta.Level,
+		DurationMs:    meta.Duration.Milliseconds(),
+		IsPublic:      meta.IsPublic,
+		Tags:          meta.Tags,
+		CoverImageURL: meta.CoverImageURL,
+	}
+	upload, err := domain.NewResumableUpload(userID, objectKey, input.Filename, contentType, input.Length, input.Metadata, track, uc.cfg.ResumableExpiration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.staging.Create(ctx, upload.ID.String()); err != nil {
+		log.Error("Failed to create staging file", "error", err, "uploadID", upload.ID)
+		return nil, fmt.Errorf("failed to prepare upload: %w", err)
+	}
+	if err := uc.uploadRepo.Create(ctx, upload); err != nil {
+		uc.deleteStaged(ctx, upload.ID)
+		return nil, fmt.Errorf("failed to save upload: %w", err)
+	}
+
+	log.Info("Resumable upload created", "uploadID", upload.ID)
+	return upload, nil
+}
+
+// GetUpload returns an upload of the user. Uploads of other users are reported as not found.
+func (uc *ResumableUploadUseCase) GetUpload(ctx context.Context, userID domain.UserID, id domain.ResumableUploadID) (*domain.ResumableUpload, error) {
+	upload, err := uc.uploadRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if upload.UserID != userID || upload.IsExpired(time.Now()) {
+		return nil, domain.ErrNotFound
+	}
+	return upload, nil
+}
+
+// WriteChunk appends a chunk to the upload and completes it once all bytes have been received.
+// Bytes received before the client disconnects are kept, so the upload can be resumed from there.
+// A completed upload whose track could not be created is completed again by an empty chunk.
+func (uc *ResumableUploadUseCase) WriteChunk(ctx context.Context, userID domain.UserID, id domain.ResumableUploadID, offset int64, chunk io.Reader) (*domain.ResumableUpload, error) {
+	if !uc.acquire(id) {
+		return nil, fmt.Errorf("%w: another request is writing to this upload", domain.ErrConflict)
+	}
+	defer uc.release(id)
+
+	upload, err := uc.GetUpload(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if offset != upload.Offset {
+		return nil, fmt.Errorf("%w: offset %d does not match the upload offset %d", domain.ErrConflict, offset, upload.Offset)
+	}
+	log := uc.logger.With("userID", userID.String(), "uploadID", id.String())
+
+	if !upload.IsComplete() {
+		n, writeErr := uc.staging.Append(ctx, id.String(), offset, io.LimitReader(chunk, upload.Length-offset))
+		if n > 0 {
+			if err := upload.Advance(n, time.Now(), uc.cfg.ResumableExpiration); err != nil {
+				return nil, err
+			}
+			if err := uc.uploadRepo.Update(ctx, upload); err != nil {
+				return nil, fmt.Errorf("failed to record upload progress: %w", err)
+			}
+		}
+		if writeErr != nil {
+			log.Warn("Upload chunk was interrupted", "error", writeErr, "offset", upload.Offset)
+			return nil, fmt.Errorf("failed to store upload chunk: %w", writeErr)
+		}
+		if upload.IsComplete() {
+			if extra, _ := chunk.Read(make([]byte, 1)); extra > 0 {
+				return nil, fmt.Errorf("%w: chunk exceeds the upload length of %d bytes", domain.ErrInvalidArgument, upload.Length)
+			}
+		}
+	}
+
+	if upload.IsComplete() && upload.TrackID == nil {
+		if err := uc.complete(ctx, upload); err != nil {
+			return nil, err
+		}
+	}
+	return upload, nil
+}
+
+// complete copies the staged file to storage and creates its track.
+func (uc *ResumableUploadUseCase) complete(ctx context.Context, upload *domain.ResumableUpload) error {
+	log := uc.logger.With("userID", upload.UserID.String(), "uploadID", upload.ID.String(), "objectKey", upload.ObjectKey)
+	storage, bucket := uc.uploads.storageService, uc.uploads.minioBucket
+
+	// A previous attempt may have created the track but failed to record it.
+	track, err := uc.uploads.trackRepo.FindByObjectKey(ctx, upload.ObjectKey)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("failed to check for an existing track: %w", err)
+	}
+	if track == nil {
+		staged, err := uc.staging.Open(ctx, upload.ID.String())
+		if err != nil {
+			log.Error("Failed to open staged upload", "error", err)
+			return fmt.Errorf("failed to read uploaded file: %w", err)
+		}
+		err = storage.PutObject(ctx, bucket, upload.ObjectKey, staged, upload.Length, upload.ContentType)
+		staged.Close()
+		if err != nil {
+			log.Error("Failed to store completed upload", "error", err)
+			return fmt.Errorf("failed to store uploaded file: %w", err)
+		}
+
+		t := upload.Track
+		track, err = uc.uploads.CompleteUpload(ctx, upload.UserID, port.CompleteUploadInput{
+			ObjectKey:     upload.ObjectKey,
+			Title:         t.Title,
+			Description:   t.Description,
+			LanguageCode:  t.LanguageCode,
+			Level:         t.Level,
+			Duration:      time.Duration(t.DurationMs) * time.Millisecond,
+			IsPublic:      t.IsPublic,
+			Tags:          t.Tags,
+			CoverImageURL: t.CoverImageURL,
+		})
+		if err != nil {
+			if delErr := storage.DeleteObject(context.WithoutCancel(ctx), bucket, upload.ObjectKey); delErr != nil {
+				log.Warn("Failed to delete stored file after track creation failed", "error", delErr)
+			}
+			return err
+		}
+	}
+
+	upload.Complete(track.ID, time.Now())
+	if err := uc.uploadRepo.Update(ctx, upload); err != nil {
+		return fmt.Errorf("failed to record completed upload: %w", err)
+	}
+	uc.deleteStaged(ctx, upload.ID)
+	log.Info("Resumable upload completed", "trackID", track.ID)
+	return nil
+}
+
+// TerminateUpload deletes an upload. The track of a completed upload is kept.
+func (uc *ResumableUploadUseCase) TerminateUpload(ctx context.Context, userID domain.UserID, id domain.ResumableUploadID) error {
+	if !uc.acquire(id) {
+		return fmt.Errorf("%w: another request is writing to this upload", domain.ErrConflict)
+	}
+	defer uc.release(id)
+
+	if _, err := uc.GetUpload(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := uc.uploadRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.deleteStaged(ctx, id)
+	uc.logger.Info("Resumable upload terminated", "userID", userID.String(), "uploadID", id.String())
+	return nil
+}
+
+// CleanUpExpired periodically deletes expired uploads. It blocks until ctx is cancelled.
+func (uc *ResumableUploadUseCase) CleanUpExpired(ctx context.Context) {
+	ticker := time.NewTicker(resumableCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.logger.Debug("Resumable upload cleanup stopped")
+			return
+		case <-ticker.C:
+			count, err := uc.deleteExpired(ctx)
+			if err != nil {
+				uc.logger.WarnContext(ctx, "Resumable upload cleanup failed", "error", err)
+			}
+			if count > 0 {
+				uc.logger.Debug("Resumable upload cleanup removed uploads", "removed_count", count)
+			}
+		}
+	}
+}
+
+// deleteExpired deletes every upload that has expired and returns how many were removed.
+func (uc *ResumableUploadUseCase) deleteExpired(ctx context.Context) (int, error) {
+	removed := 0
+	for {
+		uploads, err := uc.uploadRepo.ListExpired(ctx, time.Now(), resumableCleanupBatchSize)
+		if err != nil {
+			return removed, err
+		}
+		for _, upload := range uploads {
+			if err := uc.uploadRepo.Delete(ctx, upload.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return removed, err
+			}
+			uc.deleteStaged(ctx, upload.ID)
+			removed++
+		}
+		if len(uploads) < resumableCleanupBatchSize {
+			return removed, nil
+		}
+	}
+}
+
+func (uc *ResumableUploadUseCase) deleteStaged(ctx context.Context, id domain.ResumableUploadID) {
+	if err := uc.staging.Delete(ctx, id.String()); err != nil {
+		uc.logger.Warn("Failed to delete staged upload", "error", err, "uploadID", id.String())
+	}
+}
+
+// acquire reserves an upload for one request, so chunks are never written concurrently.
+func (uc *ResumableUploadUseCase) acquire(id domain.ResumableUploadID) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if _, busy := uc.active[id]; busy {
+		return false
+	}
+	uc.active[id] = struct{}{}
+	return true
+}
+
+func (uc *ResumableUploadUseCase) release(id domain.ResumableUploadID) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	delete(uc.active, id)
+}
+
+// Compile-time check to ensure ResumableUploadUseCase satisfies the port.ResumableUploadUseCase interface
+var _ port.ResumableUploadUseCase = (*ResumableUploadUseCase)(nil)
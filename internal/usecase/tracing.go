@@ -0,0 +1,9 @@
+// internal/usecase/tracing.go
+package usecase
+
+import (
+	"go.opentelemetry.io/otel"
+)
+
+// tracer creates use case spans via the global provider (a no-op unless tracing is enabled).
+var tracer = otel.Tracer("github.com/yvanyang/language-learning-player-api/usecase")
@@ -5,10 +5,15 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"mime"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -18,50 +23,94 @@ import (
 	"github.com/yvanyang/language-learning-player-api/internal/config"
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
 	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
 )
 
+// audioContentTypes lists the audio file extensions that are imported and their content types.
+// Direct uploads also use it for files sent without a specific content type.
+var audioContentTypes = map[string]string{
+	".mp3":  "audio/mpeg",
+	".ogg":  "audio/ogg",
+	".oga":  "audio/ogg",
+	".opus": "audio/ogg",
+	".flac": "audio/flac",
+	".m4a":  "audio/mp4",
+	".wav":  "audio/wav",
+}
+
 // UploadUseCase handles the business logic for file uploads.
 type UploadUseCase struct {
+	cfg            config.UploadConfig
+	userRepo       port.UserRepository
 	trackRepo      port.AudioTrackRepository
 	storageService port.FileStorageService
 	txManager      port.TransactionManager
+	jobRepo        port.JobRepository
+	auditRepo      port.AuditEventRepository
+	outboxRepo     port.OutboxRepository
+	metrics        port.MetricsRecorder
 	logger         *slog.Logger
 	minioBucket    string
 }
 
 // NewUploadUseCase creates a new UploadUseCase.
 func NewUploadUseCase(
-	cfg config.MinioConfig,
+	cfg config.UploadConfig,
+	minioCfg config.MinioConfig,
+	ur port.UserRepository,
 	tr port.AudioTrackRepository,
 	ss port.FileStorageService,
 	tm port.TransactionManager,
+	jr port.JobRepository,
+	ar port.AuditEventRepository,
+	or port.OutboxRepository,
+	mr port.MetricsRecorder,
 	log *slog.Logger,
 ) *UploadUseCase {
 	if tm == nil {
-		log.Warn("UploadUseCase created without TransactionManager implementation. Batch completion will not be transactional.")
+		log.Warn("UploadUseCase created without TransactionManager implementation. Upload completion will fail.")
 	}
 	if ss == nil {
 		log.Error("UploadUseCase created without FileStorageService implementation. Uploads will fail.")
 	}
 	return &UploadUseCase{
+		cfg:            cfg,
+		userRepo:       ur,
 		trackRepo:      tr,
 		storageService: ss,
 		txManager:      tm,
+		jobRepo:        jr,
+		auditRepo:      ar,
+		outboxRepo:     or,
+		metrics:        mr,
 		logger:         log.With("usecase", "UploadUseCase"),
-		minioBucket:    cfg.BucketName,
+		minioBucket:    minioCfg.BucketName,
 	}
 }
 
 // RequestUpload generates a presigned PUT URL for the client to upload a single file.
-func (uc *UploadUseCase) RequestUpload(ctx context.Context, userID domain.UserID, filename string, contentType string) (*port.RequestUploadResult, error) {
-	log := uc.logger.With("userID", userID.String(), "filename", filename, "contentType", contentType)
+// If the client declares the content hash and a track accessible to the user already has
+// that content, the existing track is returned instead.
+func (uc *UploadUseCase) RequestUpload(ctx context.Context, userID domain.UserID, req port.RequestUploadInput) (*port.RequestUploadResult, error) {
+	log := uc.logger.With("userID", userID.String(), "filename", req.Filename, "contentType", req.ContentType)
 
-	if filename == "" {
+	if req.Filename == "" {
 		return nil, fmt.Errorf("%w: filename cannot be empty", domain.ErrInvalidArgument)
 	}
-	_ = uc.validateContentType(contentType)
+	_ = uc.validateContentType(req.ContentType)
+
+	if req.ContentSHA256 != "" {
+		existing, err := uc.findExistingContent(ctx, userID, req.ContentSHA256)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			log.Info("Declared content matches an existing track", "trackID", existing.ID)
+			return &port.RequestUploadResult{ObjectKey: existing.MinioObjectKey, ExistingTrack: existing}, nil
+		}
+	}
 
-	objectKey := uc.generateObjectKey(userID, filename)
+	objectKey := uc.generateObjectKey(userID, req.Filename)
 	log = log.With("objectKey", objectKey)
 
 	if uc.storageService == nil {
@@ -95,6 +144,9 @@ func (uc *UploadUseCase) CompleteUpload(ctx context.Context, userID domain.UserI
 	if uc.storageService == nil {
 		return nil, fmt.Errorf("internal server error: storage service not available")
 	}
+	if uc.txManager == nil {
+		return nil, fmt.Errorf("internal configuration error: transaction manager not available")
+	}
 	exists, checkErr := uc.storageService.ObjectExists(ctx, uc.minioBucket, input.ObjectKey)
 	if checkErr != nil {
 		log.Error("Failed to check object existence in storage", "error", checkErr)
@@ -111,17 +163,87 @@ func (uc *UploadUseCase) CompleteUpload(ctx context.Context, userID domain.UserI
 		return nil, err
 	}
 
-	err = uc.trackRepo.Create(ctx, track)
-	if err != nil {
-		log.Error("Failed to create audio track record in repository", "error", err, "trackID", track.ID)
-		if errors.Is(err, domain.ErrConflict) {
-			log.Warn("Conflict during track creation, potentially duplicate object key", "objectKey", input.ObjectKey)
-			return nil, fmt.Errorf("%w: track identifier conflict, possibly duplicate object key", domain.ErrConflict)
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := uc.trackRepo.Create(txCtx, track); err != nil {
+			log.Error("Failed to create audio track record in repository", "error", err, "trackID", track.ID)
+			if errors.Is(err, domain.ErrConflict) {
+				log.Warn("Conflict during track creation, potentially duplicate object key", "objectKey", input.ObjectKey)
+				return fmt.Errorf("%w: track identifier conflict, possibly duplicate object key", domain.ErrConflict)
+			}
+			return fmt.Errorf("failed to save track information: %w", err) // Internal error
 		}
-		return nil, fmt.Errorf("failed to save track information: %w", err) // Internal error
+		return uc.recordUploadCompleted(txCtx, userID, track)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	log.Info("Upload completed and track record created", "trackID", track.ID)
+	uc.metrics.UploadsCompleted(1)
+	return track, nil
+}
+
+// UploadDirect stores an audio file streamed through the API and creates its track in the same
+// call. The file is hashed while it is stored, so no checksum job is queued.
+func (uc *UploadUseCase) UploadDirect(ctx context.Context, userID domain.UserID, input port.DirectUploadInput) (*domain.AudioTrack, error) {
+	log := uc.logger.With("userID", userID.String(), "filename", input.Filename)
+
+	if input.Filename == "" {
+		return nil, fmt.Errorf("%w: filename cannot be empty", domain.ErrInvalidArgument)
+	}
+	contentType, err := uc.resolveContentType(input.Filename, input.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	if input.Size > uc.cfg.MaxFileSize {
+		return nil, fmt.Errorf("%w: file exceeds the maximum size of %d bytes", domain.ErrInvalidArgument, uc.cfg.MaxFileSize)
+	}
+	if uc.storageService == nil {
+		return nil, fmt.Errorf("internal server error: storage service not available")
+	}
+	if uc.txManager == nil {
+		return nil, fmt.Errorf("internal configuration error: transaction manager not available")
+	}
+
+	meta := input.Track
+	meta.ObjectKey = uc.generateObjectKey(userID, input.Filename)
+	log = log.With("objectKey", meta.ObjectKey)
+	if err := uc.validateCompleteUploadRequest(ctx, userID, meta.ObjectKey, meta.Title, meta.LanguageCode, meta.Duration, meta.Level); err != nil {
+		return nil, err
+	}
+	track, err := uc.createDomainTrack(ctx, userID, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	limited := &maxSizeReader{r: input.File, limit: uc.cfg.MaxFileSize}
+	hash := sha256.New()
+	if err := uc.storageService.PutObject(ctx, uc.minioBucket, meta.ObjectKey, io.TeeReader(limited, hash), input.Size, contentType); err != nil {
+		if limited.exceeded {
+			return nil, fmt.Errorf("%w: file exceeds the maximum size of %d bytes", domain.ErrInvalidArgument, uc.cfg.MaxFileSize)
+		}
+		log.Error("Failed to store uploaded file", "error", err)
+		return nil, fmt.Errorf("failed to store uploaded file: %w", err)
+	}
+	track.ContentSHA256 = hex.EncodeToString(hash.Sum(nil))
+	track.SizeBytes = limited.n
+
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := uc.trackRepo.Create(txCtx, track); err != nil {
+			log.Error("Failed to create audio track record in repository", "error", err, "trackID", track.ID)
+			return fmt.Errorf("failed to save track information: %w", err)
+		}
+		return recordTrackCreated(txCtx, uc.auditRepo, uc.outboxRepo, &userID, track)
+	})
+	if err != nil {
+		if delErr := uc.storageService.DeleteObject(context.WithoutCancel(ctx), uc.minioBucket, meta.ObjectKey); delErr != nil {
+			log.Warn("Failed to delete stored file after track creation failed", "error", delErr)
+		}
+		return nil, err
+	}
+
+	log.Info("Direct upload stored and track record created", "trackID", track.ID, "sizeBytes", track.SizeBytes)
+	uc.metrics.UploadsCompleted(1)
 	return track, nil
 }
 
@@ -157,12 +279,26 @@ func (uc *UploadUseCase) RequestBatchUpload(ctx context.Context, userID domain.U
 			}
 			_ = uc.validateContentType(f.ContentType)
 
-			objectKey := uc.generateObjectKey(userID, f.Filename)
-			responseItem.ObjectKey = objectKey
-			itemLog = itemLog.With("objectKey", objectKey)
+			if responseItem.Error == "" && f.ContentSHA256 != "" {
+				existing, err := uc.findExistingContent(ctx, userID, f.ContentSHA256)
+				switch {
+				case errors.Is(err, domain.ErrInvalidArgument):
+					responseItem.Error = "contentSha256 must be a hex-encoded SHA-256 digest"
+				case err != nil:
+					responseItem.Error = "failed to look up existing content"
+				case existing != nil:
+					responseItem.ObjectKey = existing.MinioObjectKey
+					responseItem.ExistingTrackID = existing.ID.String()
+				}
+			}
 
-			if responseItem.Error == "" {
-				uploadURL, err := uc.storageService.GetPresignedPutURL(ctx, uc.minioBucket, objectKey, uploadURLExpiry)
+			if responseItem.ExistingTrackID == "" {
+				responseItem.ObjectKey = uc.generateObjectKey(userID, f.Filename)
+			}
+			itemLog = itemLog.With("objectKey", responseItem.ObjectKey)
+
+			if responseItem.Error == "" && responseItem.ExistingTrackID == "" {
+				uploadURL, err := uc.storageService.GetPresignedPutURL(ctx, uc.minioBucket, responseItem.ObjectKey, uploadURLExpiry)
 				if err != nil {
 					itemLog.Error("Failed to get presigned PUT URL for batch item", "error", err)
 					responseItem.Error = "failed to prepare upload URL"
@@ -272,6 +408,13 @@ func (uc *UploadUseCase) CompleteBatchUpload(ctx context.Context, userID domain.
 				if firstDbErr == nil {
 					firstDbErr = fmt.Errorf("item %s failed: %w", trackReq.ObjectKey, dbErr)
 				}
+			} else if auditErr := uc.recordUploadCompleted(txCtx, userID, track); auditErr != nil {
+				itemLog.Error("Failed to record events for batch item", "error", auditErr, "trackID", track.ID)
+				resultItemPtr.Success = false
+				resultItemPtr.Error = "failed to save track information"
+				if firstDbErr == nil {
+					firstDbErr = fmt.Errorf("item %s failed: %w", trackReq.ObjectKey, auditErr)
+				}
 			} else {
 				resultItemPtr.Success = true
 				resultItemPtr.TrackID = track.ID.String()
@@ -298,13 +441,121 @@ func (uc *UploadUseCase) CompleteBatchUpload(ctx context.Context, userID domain.
 		processingErr = fmt.Errorf("batch processing failed: %w", txErr)
 	} else {
 		log.Info("Batch completion finished and transaction committed")
+		uc.metrics.UploadsCompleted(len(input.Tracks))
 	}
 
 	return finalResults, processingErr
 }
 
+// ListDuplicates lists groups of tracks with the same content. Uploaders see the groups that
+// contain one of their tracks, limited to their own and public tracks; admins may list all.
+func (uc *UploadUseCase) ListDuplicates(ctx context.Context, input port.ListDuplicatesInput) ([]port.DuplicateTrackGroup, int, pagination.Page, error) {
+	pageParams := pagination.NewPageFromOffset(input.Page.Limit, input.Page.Offset)
+
+	filter := port.DuplicateTracksFilter{ViewerID: &input.UserID}
+	if input.AllUploaders {
+		if _, err := requireAdmin(ctx, uc.userRepo); err != nil {
+			return nil, 0, pageParams, err
+		}
+		filter.ViewerID = nil
+	}
+
+	groups, total, err := uc.trackRepo.ListDuplicates(ctx, filter, pageParams)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to list duplicate tracks", "error", err, "userID", input.UserID.String(), "page", pageParams)
+		return nil, 0, pageParams, fmt.Errorf("failed to retrieve duplicate tracks: %w", err)
+	}
+	return groups, total, pageParams, nil
+}
+
 // --- Helper Methods ---
 
+// findExistingContent returns a track with the given content that the user may use: one of
+// their own if possible, otherwise a public one. It returns nil if there is none.
+func (uc *UploadUseCase) findExistingContent(ctx context.Context, userID domain.UserID, contentSHA256 string) (*domain.AudioTrack, error) {
+	sum, err := domain.ParseContentHash(contentSHA256)
+	if err != nil {
+		return nil, err
+	}
+	tracks, err := uc.trackRepo.ListByContentHash(ctx, sum)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to look up tracks by content hash", "error", err, "contentSha256", sum)
+		return nil, fmt.Errorf("failed to look up existing content: %w", err)
+	}
+	var public *domain.AudioTrack
+	for _, t := range tracks {
+		if t.UploaderID != nil && *t.UploaderID == userID {
+			return t, nil
+		}
+		if t.IsPublic && public == nil {
+			public = t
+		}
+	}
+	return public, nil
+}
+
+// recordUploadCompleted records the events of an uploaded track and queues the computation
+// of its checksum. It must be called with the transaction context.
+func (uc *UploadUseCase) recordUploadCompleted(txCtx context.Context, userID domain.UserID, track *domain.AudioTrack) error {
+	if err := recordTrackCreated(txCtx, uc.auditRepo, uc.outboxRepo, &userID, track); err != nil {
+		return err
+	}
+	job, err := domain.NewJob(JobTypeTrackChecksum, trackChecksumPayload{TrackID: track.ID.String()}, time.Time{})
+	if err != nil {
+		return err
+	}
+	return uc.jobRepo.Enqueue(txCtx, job)
+}
+
+// recordTrackCreated writes the audit event and outbox events for a track created
+// from an upload or an import. It must be called with the transaction context.
+func recordTrackCreated(txCtx context.Context, auditRepo port.AuditEventRepository, outboxRepo port.OutboxRepository, actorID *domain.UserID, track *domain.AudioTrack) error {
+	diff := domain.DiffFields(nil, trackAuditFields(track))
+	if err := recordAudit(txCtx, auditRepo, actorID, domain.AuditActionTrackCreate, domain.AuditTargetTrack, track.ID.String(), diff); err != nil {
+		return err
+	}
+	data := trackEventData(track)
+	data["objectKey"] = track.MinioObjectKey
+	if err := publishEvent(txCtx, outboxRepo, domain.EventUploadCompleted, data); err != nil {
+		return err
+	}
+	if track.IsPublic {
+		return publishEvent(txCtx, outboxRepo, domain.EventTrackPublished, trackEventData(track))
+	}
+	return nil
+}
+
+// resolveContentType returns the media type of a file uploaded through the API. A missing or
+// generic type is replaced by the one for the file extension. It must be allowed by configuration.
+func (uc *UploadUseCase) resolveContentType(filename, declared string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil || mediaType == "application/octet-stream" {
+		mediaType = audioContentTypes[strings.ToLower(filepath.Ext(filename))]
+	}
+	if mediaType == "" || !slices.Contains(uc.cfg.AllowedContentTypes, mediaType) {
+		return "", fmt.Errorf("%w: unsupported content type '%s' for file '%s'", domain.ErrInvalidArgument, declared, filename)
+	}
+	return mediaType, nil
+}
+
+// maxSizeReader fails once more than limit bytes have been read from r.
+type maxSizeReader struct {
+	r        io.Reader
+	limit    int64
+	n        int64
+	exceeded bool
+}
+
+func (m *maxSizeReader) Read(p []byte) (int, error) {
+	n, err := m.r.Read(p)
+	m.n += int64(n)
+	if m.n > m.limit {
+		m.exceeded = true
+		return n, fmt.Errorf("%w: file exceeds the maximum size of %d bytes", domain.ErrInvalidArgument, m.limit)
+	}
+	return n, err
+}
+
 func (uc *UploadUseCase) validateContentType(contentType string) error {
 	if contentType == "" {
 		return fmt.Errorf("%w: contentType cannot be empty", domain.ErrInvalidArgument)
@@ -8,56 +8,99 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/yvanyang/language-learning-player-api/internal/config"
 	"github.com/yvanyang/language-learning-player-api/internal/domain"
 	"github.com/yvanyang/language-learning-player-api/internal/port"
 	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
 )
 
 type UserActivityUseCase struct {
-	progressRepo port.PlaybackProgressRepository
-	bookmarkRepo port.BookmarkRepository
-	trackRepo    port.AudioTrackRepository
-	logger       *slog.Logger
+	deepLinkBaseURL string
+	progressRepo    port.PlaybackProgressRepository
+	bookmarkRepo    port.BookmarkRepository
+	loopRepo        port.LoopRepository
+	trackRepo       port.AudioTrackRepository
+	chapterRepo     port.TrackChapterRepository
+	txManager       port.TransactionManager
+	outboxRepo      port.OutboxRepository
+	metrics         port.MetricsRecorder
+	logger          *slog.Logger
 }
 
 func NewUserActivityUseCase(
+	cfg config.DeepLinkConfig,
 	pr port.PlaybackProgressRepository,
 	br port.BookmarkRepository,
+	lr port.LoopRepository,
 	tr port.AudioTrackRepository,
+	chr port.TrackChapterRepository,
+	tm port.TransactionManager,
+	or port.OutboxRepository,
+	mr port.MetricsRecorder,
 	log *slog.Logger,
 ) *UserActivityUseCase {
 	return &UserActivityUseCase{
-		progressRepo: pr,
-		bookmarkRepo: br,
-		trackRepo:    tr,
-		logger:       log.With("usecase", "UserActivityUseCase"),
+		deepLinkBaseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		progressRepo:    pr,
+		bookmarkRepo:    br,
+		loopRepo:        lr,
+		trackRepo:       tr,
+		chapterRepo:     chr,
+		txManager:       tm,
+		outboxRepo:      or,
+		metrics:         mr,
+		logger:          log.With("usecase", "UserActivityUseCase"),
 	}
 }
 
 // --- Playback Progress Use Cases ---
 
 func (uc *UserActivityUseCase) RecordPlaybackProgress(ctx context.Context, userID domain.UserID, trackID domain.TrackID, progress time.Duration) error {
-	exists, err := uc.trackRepo.Exists(ctx, trackID)
+	track, err := uc.trackRepo.FindByID(ctx, trackID)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			uc.logger.WarnContext(ctx, "Attempt to record progress for non-existent track", "trackID", trackID, "userID", userID)
+			return domain.ErrNotFound
+		}
 		uc.logger.ErrorContext(ctx, "Failed to check track existence for progress update", "error", err, "trackID", trackID, "userID", userID)
 		return fmt.Errorf("failed to validate track: %w", err)
 	}
-	if !exists {
-		uc.logger.WarnContext(ctx, "Attempt to record progress for non-existent track", "trackID", trackID, "userID", userID)
-		return domain.ErrNotFound
-	}
 	prog, err := domain.NewOrUpdatePlaybackProgress(userID, trackID, progress)
 	if err != nil {
 		uc.logger.WarnContext(ctx, "Invalid progress value provided", "error", err, "userID", userID, "trackID", trackID, "progress", progress)
 		return err
 	}
-	if err := uc.progressRepo.Upsert(ctx, prog); err != nil {
-		uc.logger.ErrorContext(ctx, "Failed to upsert playback progress", "error", err, "userID", userID, "trackID", trackID)
-		return fmt.Errorf("failed to save progress: %w", err)
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		previous, err := uc.progressRepo.Find(txCtx, userID, trackID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "Failed to load previous playback progress", "error", err, "userID", userID, "trackID", trackID)
+			return fmt.Errorf("failed to save progress: %w", err)
+		}
+		if err := uc.progressRepo.Upsert(txCtx, prog); err != nil {
+			uc.logger.ErrorContext(ctx, "Failed to upsert playback progress", "error", err, "userID", userID, "trackID", trackID)
+			return fmt.Errorf("failed to save progress: %w", err)
+		}
+		// Only emit track.completed when the learner crosses the completion threshold.
+		if !prog.CompletesTrack(track.Duration) || (previous != nil && previous.CompletesTrack(track.Duration)) {
+			return nil
+		}
+		return publishEvent(txCtx, uc.outboxRepo, domain.EventTrackCompleted, map[string]any{
+			"userId":     userID.String(),
+			"trackId":    trackID.String(),
+			"progressMs": prog.Progress.Milliseconds(),
+			"durationMs": track.Duration.Milliseconds(),
+		})
+	})
+	if err != nil {
+		return err
 	}
 	uc.logger.InfoContext(ctx, "Playback progress recorded", "userID", userID, "trackID", trackID, "progressMs", progress.Milliseconds())
+	uc.metrics.ProgressUpdated()
 	return nil
 }
 
@@ -69,6 +112,9 @@ func (uc *UserActivityUseCase) GetPlaybackProgress(ctx context.Context, userID d
 		}
 		return nil, err
 	}
+	uc.resolveChapters(ctx, []domain.TrackID{trackID}, func(chapters map[domain.TrackID][]domain.TrackChapter) {
+		progress.Chapter = domain.ChapterAt(chapters[trackID], progress.Progress)
+	})
 	return progress, nil
 }
 
@@ -84,6 +130,15 @@ func (uc *UserActivityUseCase) ListUserProgress(ctx context.Context, input port.
 		uc.logger.ErrorContext(ctx, "Failed to list user progress", "error", err, "userID", input.UserID, "page", pageParams)
 		return nil, 0, pageParams, fmt.Errorf("failed to retrieve progress list: %w", err)
 	}
+	trackIDs := make([]domain.TrackID, len(progressList))
+	for i, p := range progressList {
+		trackIDs[i] = p.TrackID
+	}
+	uc.resolveChapters(ctx, trackIDs, func(chapters map[domain.TrackID][]domain.TrackChapter) {
+		for _, p := range progressList {
+			p.Chapter = domain.ChapterAt(chapters[p.TrackID], p.Progress)
+		}
+	})
 	return progressList, total, pageParams, nil
 }
 
@@ -109,6 +164,10 @@ func (uc *UserActivityUseCase) CreateBookmark(ctx context.Context, userID domain
 		return nil, fmt.Errorf("failed to create bookmark: %w", err)
 	}
 	uc.logger.InfoContext(ctx, "Bookmark created", "bookmarkID", bookmark.ID, "userID", userID, "trackID", trackID)
+	uc.metrics.BookmarkCreated()
+	uc.resolveChapters(ctx, []domain.TrackID{trackID}, func(chapters map[domain.TrackID][]domain.TrackChapter) {
+		bookmark.Chapter = domain.ChapterAt(chapters[trackID], bookmark.Timestamp)
+	})
 	return bookmark, nil
 }
 
@@ -141,9 +200,32 @@ func (uc *UserActivityUseCase) ListBookmarks(ctx context.Context, input port.Lis
 			return nil, 0, pageParams, fmt.Errorf("failed to retrieve bookmarks: %w", err)
 		}
 	}
+	trackIDs := make([]domain.TrackID, len(bookmarks))
+	for i, b := range bookmarks {
+		trackIDs[i] = b.TrackID
+	}
+	uc.resolveChapters(ctx, trackIDs, func(chapters map[domain.TrackID][]domain.TrackChapter) {
+		for _, b := range bookmarks {
+			b.Chapter = domain.ChapterAt(chapters[b.TrackID], b.Timestamp)
+		}
+	})
 	return bookmarks, total, pageParams, nil
 }
 
+// resolveChapters loads the chapters of the given tracks and passes them to apply, which sets the
+// chapter a progress record or bookmark falls in. Lookup failures are logged and leave it unset.
+func (uc *UserActivityUseCase) resolveChapters(ctx context.Context, trackIDs []domain.TrackID, apply func(map[domain.TrackID][]domain.TrackChapter)) {
+	if len(trackIDs) == 0 {
+		return
+	}
+	chapters, err := uc.chapterRepo.ListByTracks(ctx, trackIDs)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to get track chapters", "error", err, "trackCount", len(trackIDs))
+		return
+	}
+	apply(chapters)
+}
+
 func (uc *UserActivityUseCase) DeleteBookmark(ctx context.Context, userID domain.UserID, bookmarkID domain.BookmarkID) error {
 	bookmark, err := uc.bookmarkRepo.FindByID(ctx, bookmarkID)
 	if err != nil {
@@ -167,4 +249,133 @@ func (uc *UserActivityUseCase) DeleteBookmark(ctx context.Context, userID domain
 	return nil
 }
 
+// --- Loop Use Cases ---
+
+func (uc *UserActivityUseCase) CreateLoop(ctx context.Context, userID domain.UserID, trackID domain.TrackID, input port.LoopInput) (*domain.Loop, error) {
+	track, err := uc.trackRepo.FindByID(ctx, trackID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			uc.logger.WarnContext(ctx, "Attempt to create loop for non-existent track", "trackID", trackID, "userID", userID)
+			return nil, fmt.Errorf("%w: track not found", domain.ErrNotFound)
+		}
+		uc.logger.ErrorContext(ctx, "Failed to get track for loop creation", "error", err, "trackID", trackID, "userID", userID)
+		return nil, fmt.Errorf("failed to validate track: %w", err)
+	}
+	loop, err := domain.NewLoop(userID, trackID, input.Start, input.End, input.Label, input.RepeatCount, input.PlaybackSpeed, track.Duration)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "Invalid loop provided", "error", err, "userID", userID, "trackID", trackID)
+		return nil, err
+	}
+	if err := uc.loopRepo.Create(ctx, loop); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		uc.logger.ErrorContext(ctx, "Failed to save loop", "error", err, "userID", userID, "trackID", trackID)
+		return nil, fmt.Errorf("failed to create loop: %w", err)
+	}
+	uc.logger.InfoContext(ctx, "Loop created", "loopID", loop.ID, "userID", userID, "trackID", trackID)
+	return loop, nil
+}
+
+func (uc *UserActivityUseCase) GetLoop(ctx context.Context, userID domain.UserID, loopID domain.LoopID) (*domain.Loop, error) {
+	loop, err := uc.loopRepo.FindByID(ctx, loopID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "Failed to find loop", "error", err, "loopID", loopID, "userID", userID)
+		}
+		return nil, err
+	}
+	if loop.UserID != userID {
+		uc.logger.WarnContext(ctx, "Attempt to access loop not owned by user", "loopID", loopID, "ownerID", loop.UserID, "userID", userID)
+		return nil, domain.ErrPermissionDenied
+	}
+	return loop, nil
+}
+
+// ListLoops retrieves loops for a user, optionally filtered by track.
+func (uc *UserActivityUseCase) ListLoops(ctx context.Context, input port.ListLoopsInput) ([]*domain.Loop, int, pagination.Page, error) {
+	pageParams := pagination.NewPageFromOffset(input.Page.Limit, input.Page.Offset)
+
+	if input.TrackIDFilter != nil {
+		loops, err := uc.loopRepo.ListByUserAndTrack(ctx, input.UserID, *input.TrackIDFilter)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "Failed to list loops by user and track", "error", err, "userID", input.UserID, "trackID", *input.TrackIDFilter)
+			return nil, 0, pageParams, fmt.Errorf("failed to retrieve loops for track: %w", err)
+		}
+		pageParams = pagination.Page{Limit: len(loops), Offset: 0}
+		if len(loops) == 0 {
+			pageParams.Limit = pagination.DefaultLimit
+		}
+		return loops, len(loops), pageParams, nil
+	}
+
+	loops, total, err := uc.loopRepo.ListByUser(ctx, input.UserID, pageParams)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to list loops by user", "error", err, "userID", input.UserID, "page", pageParams)
+		return nil, 0, pageParams, fmt.Errorf("failed to retrieve loops: %w", err)
+	}
+	return loops, total, pageParams, nil
+}
+
+// UpdateLoop replaces the region and playback settings of a loop, validated against the current track duration.
+func (uc *UserActivityUseCase) UpdateLoop(ctx context.Context, userID domain.UserID, loopID domain.LoopID, input port.LoopInput) (*domain.Loop, error) {
+	loop, err := uc.GetLoop(ctx, userID, loopID)
+	if err != nil {
+		return nil, err
+	}
+	track, err := uc.trackRepo.FindByID(ctx, loop.TrackID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "Failed to get track for loop update", "error", err, "trackID", loop.TrackID, "loopID", loopID)
+			return nil, fmt.Errorf("failed to validate track: %w", err)
+		}
+		return nil, err
+	}
+	if err := loop.Update(input.Start, input.End, input.Label, input.RepeatCount, input.PlaybackSpeed, track.Duration); err != nil {
+		uc.logger.WarnContext(ctx, "Invalid loop update provided", "error", err, "loopID", loopID, "userID", userID)
+		return nil, err
+	}
+	if err := uc.loopRepo.Update(ctx, loop); err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "Failed to update loop", "error", err, "loopID", loopID, "userID", userID)
+			return nil, fmt.Errorf("failed to update loop: %w", err)
+		}
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "Loop updated", "loopID", loopID, "userID", userID)
+	return loop, nil
+}
+
+func (uc *UserActivityUseCase) DeleteLoop(ctx context.Context, userID domain.UserID, loopID domain.LoopID) error {
+	if _, err := uc.GetLoop(ctx, userID, loopID); err != nil {
+		return err
+	}
+	if err := uc.loopRepo.Delete(ctx, loopID); err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "Failed to delete loop from repository", "error", err, "loopID", loopID, "userID", userID)
+		}
+		return err
+	}
+	uc.logger.InfoContext(ctx, "Loop deleted", "loopID", loopID, "userID", userID)
+	return nil
+}
+
+// LoopLink builds a deep link to the loop's track carrying the loop region and settings, so the
+// recipient's player can open it without access to the sharer's saved loops.
+func (uc *UserActivityUseCase) LoopLink(ctx context.Context, userID domain.UserID, loopID domain.LoopID) (string, error) {
+	loop, err := uc.GetLoop(ctx, userID, loopID)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	query.Set("loopStartMs", strconv.FormatInt(loop.Start.Milliseconds(), 10))
+	query.Set("loopEndMs", strconv.FormatInt(loop.End.Milliseconds(), 10))
+	query.Set("repeat", strconv.Itoa(loop.RepeatCount))
+	query.Set("speed", strconv.FormatFloat(loop.PlaybackSpeed, 'f', -1, 64))
+	if loop.Label != "" {
+		query.Set("label", loop.Label)
+	}
+	return uc.deepLinkBaseURL + "/tracks/" + loop.TrackID.String() + "?" + query.Encode(), nil
+}
+
 var _ port.UserActivityUseCase = (*UserActivityUseCase)(nil)
@@ -0,0 +1,139 @@
+// internal/usecase/webhook_dispatcher.go
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/config"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+)
+
+// webhookEnvelope is the JSON body sent to webhook receivers.
+type webhookEnvelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// WebhookDispatcher moves outbox events into per-subscription deliveries and sends
+// due deliveries, retrying failures with exponential backoff until they are dead-lettered.
+type WebhookDispatcher struct {
+	deliveryRepo port.WebhookDeliveryRepository
+	sender       port.WebhookSender
+	pollInterval time.Duration
+	batchSize    int
+	lease        time.Duration
+	policy       domain.RetryPolicy
+	logger       *slog.Logger
+	now          func() time.Time
+}
+
+// NewWebhookDispatcher creates a new WebhookDispatcher from configuration.
+func NewWebhookDispatcher(cfg config.WebhookConfig, dr port.WebhookDeliveryRepository, sender port.WebhookSender, log *slog.Logger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		deliveryRepo: dr,
+		sender:       sender,
+		pollInterval: cfg.PollInterval,
+		batchSize:    cfg.BatchSize,
+		// Deliveries in a batch are sent concurrently, so a claim only has to
+		// outlive one request; afterwards another dispatcher may retry it.
+		lease: 2 * cfg.RequestTimeout,
+		policy: domain.RetryPolicy{
+			MaxAttempts:    cfg.MaxAttempts,
+			InitialBackoff: cfg.InitialBackoff,
+			MaxBackoff:     cfg.MaxBackoff,
+		},
+		logger: log.With("usecase", "WebhookDispatcher"),
+		now:    time.Now,
+	}
+}
+
+// Run dispatches webhooks every poll interval. It blocks until ctx is cancelled.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Debug("Webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+			d.DispatchOnce(ctx)
+		}
+	}
+}
+
+// DispatchOnce fans out pending outbox events and sends one batch of due deliveries.
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) {
+	fannedOut, err := d.deliveryRepo.FanOut(ctx, d.batchSize)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "Failed to fan out outbox events", "error", err)
+	} else if fannedOut > 0 {
+		d.logger.DebugContext(ctx, "Outbox events fanned out", "count", fannedOut)
+	}
+
+	pending, err := d.deliveryRepo.ClaimDue(ctx, d.batchSize, d.lease)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "Failed to claim due webhook deliveries", "error", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range pending {
+		wg.Add(1)
+		go func(p *port.PendingWebhookDelivery) {
+			defer wg.Done()
+			d.deliver(ctx, p)
+		}(p)
+	}
+	wg.Wait()
+}
+
+// deliver sends a single delivery and stores the outcome.
+func (d *WebhookDispatcher) deliver(ctx context.Context, p *port.PendingWebhookDelivery) {
+	delivery := p.Delivery
+	log := d.logger.With("deliveryID", delivery.ID, "webhookID", delivery.SubscriptionID, "eventType", delivery.EventType)
+
+	body, err := json.Marshal(webhookEnvelope{
+		ID:         p.Event.ID.String(),
+		Type:       string(p.Event.Type),
+		OccurredAt: p.Event.OccurredAt,
+		Data:       p.Event.Payload,
+	})
+	if err != nil {
+		// Not retryable: the stored payload is broken.
+		log.ErrorContext(ctx, "Failed to encode webhook payload", "error", err)
+		delivery.MarkFailed(0, err.Error(), d.now(), domain.RetryPolicy{MaxAttempts: 1})
+	} else {
+		status, sendErr := d.sender.Send(ctx, port.WebhookRequest{
+			URL:        p.URL,
+			Secret:     p.Secret,
+			DeliveryID: delivery.ID.String(),
+			EventType:  delivery.EventType,
+			Body:       body,
+		})
+		if sendErr != nil {
+			delivery.MarkFailed(status, sendErr.Error(), d.now(), d.policy)
+			if delivery.Status == domain.DeliveryDead {
+				log.WarnContext(ctx, "Webhook delivery dead-lettered", "error", sendErr, "attempts", delivery.Attempts)
+			} else {
+				log.InfoContext(ctx, "Webhook delivery failed, will retry", "error", sendErr, "attempts", delivery.Attempts, "nextAttemptAt", delivery.NextAttemptAt)
+			}
+		} else {
+			delivery.MarkSucceeded(status, d.now())
+			log.DebugContext(ctx, "Webhook delivered", "statusCode", status)
+		}
+	}
+
+	// Store the outcome even if shutdown has started, so a success is not re-sent.
+	if err := d.deliveryRepo.SaveAttempt(context.WithoutCancel(ctx), delivery); err != nil {
+		log.ErrorContext(ctx, "Failed to save webhook delivery attempt", "error", err)
+	}
+}
@@ -0,0 +1,233 @@
+// internal/usecase/webhook_uc.go
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+	"github.com/yvanyang/language-learning-player-api/internal/port"
+	"github.com/yvanyang/language-learning-player-api/pkg/pagination"
+)
+
+// webhookSecretPrefix marks signing secrets so they are recognisable if leaked.
+const webhookSecretPrefix = "whsec_"
+
+// WebhookUseCase manages webhook subscriptions for administrators.
+type WebhookUseCase struct {
+	subRepo      port.WebhookSubscriptionRepository
+	deliveryRepo port.WebhookDeliveryRepository
+	userRepo     port.UserRepository
+	txManager    port.TransactionManager
+	auditRepo    port.AuditEventRepository
+	logger       *slog.Logger
+}
+
+// NewWebhookUseCase creates a new WebhookUseCase.
+func NewWebhookUseCase(
+	sr port.WebhookSubscriptionRepository,
+	dr port.WebhookDeliveryRepository,
+	ur port.UserRepository,
+	tm port.TransactionManager,
+	ar port.AuditEventRepository,
+	log *slog.Logger,
+) *WebhookUseCase {
+	return &WebhookUseCase{
+		subRepo:      sr,
+		deliveryRepo: dr,
+		userRepo:     ur,
+		txManager:    tm,
+		auditRepo:    ar,
+		logger:       log.With("usecase", "WebhookUseCase"),
+	}
+}
+
+// webhookAuditFields returns the audited fields of a subscription. The secret is never audited.
+func webhookAuditFields(s *domain.WebhookSubscription) map[string]any {
+	if s == nil {
+		return nil
+	}
+	types := make([]string, len(s.EventTypes))
+	for i, t := range s.EventTypes {
+		types[i] = string(t)
+	}
+	return map[string]any{
+		"url":        s.URL,
+		"eventTypes": types,
+		"active":     s.Active,
+	}
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
+
+func (uc *WebhookUseCase) CreateWebhook(ctx context.Context, input port.CreateWebhookInput) (*domain.WebhookSubscription, error) {
+	userID, err := requireAdmin(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	sub, err := domain.NewWebhookSubscription(input.URL, input.EventTypes, secret, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		if err := uc.subRepo.Create(txCtx, sub); err != nil {
+			uc.logger.ErrorContext(ctx, "Failed to create webhook subscription", "error", err, "userID", userID)
+			return fmt.Errorf("failed to save webhook: %w", err)
+		}
+		diff := domain.DiffFields(nil, webhookAuditFields(sub))
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionWebhookCreate, domain.AuditTargetWebhook, sub.ID.String(), diff)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "Webhook subscription created", "webhookID", sub.ID, "url", sub.URL, "userID", userID)
+	return sub, nil
+}
+
+func (uc *WebhookUseCase) ListWebhooks(ctx context.Context, page pagination.Page) ([]*domain.WebhookSubscription, int, pagination.Page, error) {
+	pageParams := pagination.NewPageFromOffset(page.Limit, page.Offset)
+	if _, err := requireAdmin(ctx, uc.userRepo); err != nil {
+		return nil, 0, pageParams, err
+	}
+	subs, total, err := uc.subRepo.List(ctx, pageParams)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to list webhook subscriptions", "error", err, "page", pageParams)
+		return nil, 0, pageParams, fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+	return subs, total, pageParams, nil
+}
+
+func (uc *WebhookUseCase) GetWebhook(ctx context.Context, id domain.WebhookID) (*domain.WebhookSubscription, error) {
+	if _, err := requireAdmin(ctx, uc.userRepo); err != nil {
+		return nil, err
+	}
+	sub, err := uc.subRepo.FindByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			uc.logger.ErrorContext(ctx, "Failed to get webhook subscription", "error", err, "webhookID", id)
+		}
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (uc *WebhookUseCase) UpdateWebhook(ctx context.Context, id domain.WebhookID, input port.UpdateWebhookInput) (*domain.WebhookSubscription, error) {
+	userID, err := requireAdmin(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+	var sub *domain.WebhookSubscription
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		var err error
+		sub, err = uc.subRepo.FindByID(txCtx, id)
+		if err != nil {
+			return err
+		}
+		before := webhookAuditFields(sub)
+		if err := sub.Update(input.URL, input.EventTypes, input.Active); err != nil {
+			return err
+		}
+		if err := uc.subRepo.Update(txCtx, sub); err != nil {
+			if !errors.Is(err, domain.ErrNotFound) {
+				uc.logger.ErrorContext(ctx, "Failed to update webhook subscription", "error", err, "webhookID", id)
+			}
+			return err
+		}
+		diff := domain.DiffFields(before, webhookAuditFields(sub))
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionWebhookUpdate, domain.AuditTargetWebhook, id.String(), diff)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "Webhook subscription updated", "webhookID", id, "userID", userID)
+	return sub, nil
+}
+
+func (uc *WebhookUseCase) DeleteWebhook(ctx context.Context, id domain.WebhookID) error {
+	userID, err := requireAdmin(ctx, uc.userRepo)
+	if err != nil {
+		return err
+	}
+	err = uc.txManager.Execute(ctx, func(txCtx context.Context) error {
+		sub, err := uc.subRepo.FindByID(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if err := uc.subRepo.Delete(txCtx, id); err != nil {
+			if !errors.Is(err, domain.ErrNotFound) {
+				uc.logger.ErrorContext(ctx, "Failed to delete webhook subscription", "error", err, "webhookID", id)
+			}
+			return err
+		}
+		diff := domain.DiffFields(webhookAuditFields(sub), nil)
+		return recordAudit(txCtx, uc.auditRepo, &userID, domain.AuditActionWebhookDelete, domain.AuditTargetWebhook, id.String(), diff)
+	})
+	if err != nil {
+		return err
+	}
+	uc.logger.InfoContext(ctx, "Webhook subscription deleted", "webhookID", id, "userID", userID)
+	return nil
+}
+
+// ListDeliveries returns the delivery log of a subscription, newest first.
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, input port.ListWebhookDeliveriesInput) ([]*domain.WebhookDelivery, int, pagination.Page, error) {
+	pageParams := pagination.NewPageFromOffset(input.Page.Limit, input.Page.Offset)
+	if _, err := requireAdmin(ctx, uc.userRepo); err != nil {
+		return nil, 0, pageParams, err
+	}
+	if input.Filter.Status != nil && !input.Filter.Status.IsValid() {
+		return nil, 0, pageParams, fmt.Errorf("%w: invalid delivery status '%s'", domain.ErrInvalidArgument, *input.Filter.Status)
+	}
+	if _, err := uc.subRepo.FindByID(ctx, input.WebhookID); err != nil {
+		return nil, 0, pageParams, err
+	}
+	deliveries, total, err := uc.deliveryRepo.ListBySubscription(ctx, input.WebhookID, input.Filter, pageParams)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to list webhook deliveries", "error", err, "webhookID", input.WebhookID, "page", pageParams)
+		return nil, 0, pageParams, fmt.Errorf("failed to retrieve webhook deliveries: %w", err)
+	}
+	return deliveries, total, pageParams, nil
+}
+
+// RedeliverDelivery requeues a succeeded or dead-lettered delivery for immediate sending.
+func (uc *WebhookUseCase) RedeliverDelivery(ctx context.Context, webhookID domain.WebhookID, deliveryID domain.WebhookDeliveryID) (*domain.WebhookDelivery, error) {
+	userID, err := requireAdmin(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := uc.deliveryRepo.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.SubscriptionID != webhookID {
+		return nil, domain.ErrNotFound
+	}
+	if err := delivery.Requeue(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.deliveryRepo.SaveAttempt(ctx, delivery); err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to requeue webhook delivery", "error", err, "deliveryID", deliveryID)
+		return nil, fmt.Errorf("failed to requeue delivery: %w", err)
+	}
+	uc.logger.InfoContext(ctx, "Webhook delivery requeued", "webhookID", webhookID, "deliveryID", deliveryID, "userID", userID)
+	return delivery, nil
+}
+
+// Compile-time check to ensure WebhookUseCase satisfies the port.WebhookUseCase interface
+var _ port.WebhookUseCase = (*WebhookUseCase)(nil)
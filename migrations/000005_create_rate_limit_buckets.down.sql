@@ -0,0 +1,3 @@
+-- migrations/000005_create_rate_limit_buckets.down.sql
+
+DROP TABLE IF EXISTS rate_limit_buckets;
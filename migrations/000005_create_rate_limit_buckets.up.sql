@@ -0,0 +1,13 @@
+-- migrations/000005_create_rate_limit_buckets.up.sql
+
+-- Shared rate limiter state (GCRA). UNLOGGED: the data is disposable, so skip WAL
+-- for cheaper writes; the table is emptied after a crash, which only resets limits.
+CREATE UNLOGGED TABLE rate_limit_buckets (
+    key TEXT PRIMARY KEY,           -- "<policy>|<client>", e.g. "POST /api/v1/auth/login|ip:203.0.113.7"
+    tat TIMESTAMPTZ NOT NULL,       -- Theoretical arrival time of the next request
+    allowed BOOLEAN NOT NULL,       -- Outcome of the most recent check
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Index on tat for cleanup of idle buckets
+CREATE INDEX idx_ratelimitbuckets_tat ON rate_limit_buckets(tat);
@@ -0,0 +1,3 @@
+-- migrations/000006_add_user_role.down.sql
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,4 @@
+-- migrations/000006_add_user_role.up.sql
+
+-- Role used for authorization of administrative endpoints (e.g. audit log queries).
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin'));
@@ -0,0 +1,4 @@
+-- migrations/000007_create_audit_events.down.sql
+
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS reject_audit_event_modification();
@@ -0,0 +1,38 @@
+-- migrations/000007_create_audit_events.up.sql
+
+-- Append-only log of security-relevant and content-changing actions.
+CREATE TABLE audit_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    actor_id UUID NULL,                 -- NULL for anonymous actions (e.g. failed login); no FK so events outlive users
+    action VARCHAR(64) NOT NULL,        -- e.g. 'auth.login', 'collection.delete'
+    target_type VARCHAR(32) NOT NULL,   -- e.g. 'user', 'collection', 'track'
+    target_id TEXT NOT NULL DEFAULT '', -- ID of the affected entity (may be empty for unknown targets)
+    diff JSONB NULL,                    -- {"field": {"old": ..., "new": ...}}
+    request_id TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT ''
+);
+
+-- Indexes for the admin query filters
+CREATE INDEX idx_auditevents_occurred_at ON audit_events(occurred_at);
+CREATE INDEX idx_auditevents_actor_id ON audit_events(actor_id, occurred_at);
+CREATE INDEX idx_auditevents_action ON audit_events(action, occurred_at);
+CREATE INDEX idx_auditevents_target ON audit_events(target_type, target_id, occurred_at);
+
+-- Enforce append-only semantics: rows can be inserted but never changed or removed.
+CREATE OR REPLACE FUNCTION reject_audit_event_modification()
+RETURNS TRIGGER AS $$
+BEGIN
+   RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER audit_events_append_only
+BEFORE UPDATE OR DELETE ON audit_events
+FOR EACH ROW
+EXECUTE FUNCTION reject_audit_event_modification();
+
+CREATE TRIGGER audit_events_no_truncate
+BEFORE TRUNCATE ON audit_events
+FOR EACH STATEMENT
+EXECUTE FUNCTION reject_audit_event_modification();
@@ -0,0 +1,20 @@
+-- migrations/000008_create_idempotency_keys.up.sql
+
+-- Stored responses for requests carrying an Idempotency-Key header.
+-- A row is inserted when the request starts (completed = false) and filled in
+-- when it finishes, so concurrent retries can be detected and rejected.
+CREATE TABLE idempotency_keys (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(255) NOT NULL,
+    request_hash TEXT NOT NULL,      -- SHA-256 of method, path and body
+    completed BOOLEAN NOT NULL DEFAULT false,
+    response_status INT NULL,
+    response_content_type TEXT NULL,
+    response_body BYTEA NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+-- Index on expires_at for cleanup of expired keys
+CREATE INDEX idx_idempotencykeys_expires_at ON idempotency_keys(expires_at);
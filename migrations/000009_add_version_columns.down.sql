@@ -0,0 +1,4 @@
+-- migrations/000009_add_version_columns.down.sql
+
+ALTER TABLE audio_tracks DROP COLUMN IF EXISTS version;
+ALTER TABLE audio_collections DROP COLUMN IF EXISTS version;
@@ -0,0 +1,6 @@
+-- migrations/000009_add_version_columns.up.sql
+
+-- Row versions for optimistic concurrency control. Each successful update increments
+-- the version; clients send it back in If-Match (exposed as the resource ETag).
+ALTER TABLE audio_collections ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE audio_tracks ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
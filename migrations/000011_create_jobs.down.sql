@@ -0,0 +1,3 @@
+-- migrations/000011_create_jobs.down.sql
+
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,23 @@
+-- migrations/000011_create_jobs.up.sql
+
+-- Background job queue. Workers claim rows with FOR UPDATE SKIP LOCKED and hold them
+-- until locked_until (the visibility timeout); an expired lock makes the job claimable again.
+CREATE TABLE jobs (
+    id UUID PRIMARY KEY,
+    job_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'succeeded', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_until TIMESTAMPTZ NULL,
+    last_error TEXT NULL,
+    unique_key VARCHAR(255) NULL UNIQUE, -- Deduplicates scheduled occurrences of recurring jobs
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_jobs_due ON jobs(run_at) WHERE status = 'queued';
+CREATE INDEX idx_jobs_locked_until ON jobs(locked_until) WHERE status = 'running';
+CREATE INDEX idx_jobs_completed_at ON jobs(completed_at) WHERE status IN ('succeeded', 'dead');
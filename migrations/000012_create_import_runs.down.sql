@@ -0,0 +1,3 @@
+-- migrations/000012_create_import_runs.down.sql
+
+DROP TABLE IF EXISTS import_runs;
@@ -0,0 +1,18 @@
+-- migrations/000012_create_import_runs.up.sql
+
+-- Archive imports started through the admin API. The import itself runs as a background
+-- job; the row records its progress and the per-file report.
+CREATE TABLE import_runs (
+    id UUID PRIMARY KEY,
+    requested_by UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(255) NOT NULL, -- Original archive file name
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'succeeded', 'failed')),
+    report JSONB NULL,
+    error TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_import_runs_created_at ON import_runs(created_at DESC);
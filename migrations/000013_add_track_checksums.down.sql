@@ -0,0 +1,5 @@
+-- migrations/000013_add_track_checksums.down.sql
+
+DROP INDEX IF EXISTS idx_audiotracks_content_sha256;
+ALTER TABLE audio_tracks DROP COLUMN IF EXISTS size_bytes;
+ALTER TABLE audio_tracks DROP COLUMN IF EXISTS content_sha256;
@@ -0,0 +1,8 @@
+-- migrations/000013_add_track_checksums.up.sql
+
+-- Content checksums of the audio objects, computed by a background job after each
+-- upload. Tracks sharing a checksum are reported as possible duplicates.
+ALTER TABLE audio_tracks ADD COLUMN content_sha256 CHAR(64) NULL;
+ALTER TABLE audio_tracks ADD COLUMN size_bytes BIGINT NULL CHECK (size_bytes >= 0);
+
+CREATE INDEX idx_audiotracks_content_sha256 ON audio_tracks(content_sha256) WHERE content_sha256 IS NOT NULL;
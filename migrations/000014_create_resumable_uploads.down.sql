@@ -0,0 +1,3 @@
+-- migrations/000014_create_resumable_uploads.down.sql
+
+DROP TABLE IF EXISTS resumable_uploads;
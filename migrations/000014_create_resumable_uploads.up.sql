@@ -0,0 +1,21 @@
+-- migrations/000014_create_resumable_uploads.up.sql
+
+-- Uploads in progress through the tus endpoint (/uploads/tus). The received bytes are kept
+-- in the staging directory of the API server; the row records how many have arrived.
+CREATE TABLE resumable_uploads (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    object_key TEXT NOT NULL,  -- Storage key of the assembled file
+    filename TEXT NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    length BIGINT NOT NULL CHECK (length >= 0),
+    "offset" BIGINT NOT NULL DEFAULT 0 CHECK ("offset" >= 0 AND "offset" <= length),
+    metadata TEXT NOT NULL DEFAULT '', -- Upload-Metadata header as sent by the client
+    track JSONB NOT NULL,              -- Track details used once the upload is complete
+    track_id UUID NULL REFERENCES audio_tracks(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_resumable_uploads_expires_at ON resumable_uploads(expires_at);
@@ -0,0 +1,7 @@
+-- migrations/000015_create_courses.down.sql
+
+DROP TABLE IF EXISTS course_enrollments;
+DROP TABLE IF EXISTS course_lessons;
+DROP TABLE IF EXISTS course_sections;
+DROP INDEX IF EXISTS idx_audiocollections_published_at;
+ALTER TABLE audio_collections DROP COLUMN IF EXISTS published_at;
@@ -0,0 +1,40 @@
+-- migrations/000015_create_courses.up.sql
+
+-- Courses are audio collections of type COURSE. Their outline is a list of sections holding
+-- lessons; the lesson tracks are also kept in collection_tracks, in course order.
+ALTER TABLE audio_collections ADD COLUMN published_at TIMESTAMPTZ NULL; -- Courses open for enrollment
+
+CREATE INDEX idx_audiocollections_published_at ON audio_collections(published_at DESC) WHERE published_at IS NOT NULL;
+
+CREATE TABLE course_sections (
+    id UUID PRIMARY KEY,
+    collection_id UUID NOT NULL REFERENCES audio_collections(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    UNIQUE (collection_id, position)
+);
+
+CREATE TABLE course_lessons (
+    id UUID PRIMARY KEY,
+    section_id UUID NOT NULL REFERENCES course_sections(id) ON DELETE CASCADE,
+    collection_id UUID NOT NULL REFERENCES audio_collections(id) ON DELETE CASCADE,
+    track_id UUID NOT NULL REFERENCES audio_tracks(id) ON DELETE CASCADE, -- If track deleted, remove the lesson
+    position INTEGER NOT NULL CHECK (position >= 0), -- Order within the section
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    attachments JSONB NOT NULL DEFAULT '[]', -- [{"title": ..., "url": ...}]
+    UNIQUE (section_id, position),
+    UNIQUE (collection_id, track_id)
+);
+
+CREATE INDEX idx_course_lessons_track_id ON course_lessons(track_id);
+
+CREATE TABLE course_enrollments (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    collection_id UUID NOT NULL REFERENCES audio_collections(id) ON DELETE CASCADE,
+    enrolled_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, collection_id)
+);
+
+CREATE INDEX idx_course_enrollments_collection_id ON course_enrollments(collection_id);
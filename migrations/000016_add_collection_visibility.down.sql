@@ -0,0 +1,4 @@
+-- migrations/000016_add_collection_visibility.down.sql
+
+DROP INDEX IF EXISTS idx_audiocollections_public_created_at;
+ALTER TABLE audio_collections DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,9 @@
+-- migrations/000016_add_collection_visibility.up.sql
+
+-- Who besides the owner may read a collection: PRIVATE (owner only), UNLISTED (anyone with the
+-- link) or PUBLIC (anyone, and listed when browsing). Existing collections stay private.
+ALTER TABLE audio_collections
+    ADD COLUMN visibility VARCHAR(10) NOT NULL DEFAULT 'PRIVATE'
+    CHECK (visibility IN ('PRIVATE', 'UNLISTED', 'PUBLIC'));
+
+CREATE INDEX idx_audiocollections_public_created_at ON audio_collections(created_at DESC) WHERE visibility = 'PUBLIC';
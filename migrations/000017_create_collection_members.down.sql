@@ -0,0 +1,4 @@
+-- migrations/000017_create_collection_members.down.sql
+
+DROP TABLE IF EXISTS collection_invitations;
+DROP TABLE IF EXISTS collection_members;
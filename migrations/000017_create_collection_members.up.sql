@@ -0,0 +1,31 @@
+-- migrations/000017_create_collection_members.up.sql
+
+-- Users other than the owner who may edit or view a collection. The owner stays on audio_collections.
+CREATE TABLE collection_members (
+    collection_id UUID NOT NULL REFERENCES audio_collections(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(10) NOT NULL CHECK (role IN ('EDITOR', 'VIEWER')),
+    added_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (collection_id, user_id)
+);
+
+CREATE INDEX idx_collection_members_user_id ON collection_members(user_id);
+
+CREATE TABLE collection_invitations (
+    id UUID PRIMARY KEY,
+    collection_id UUID NOT NULL REFERENCES audio_collections(id) ON DELETE CASCADE,
+    inviter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invitee_id UUID NULL REFERENCES users(id) ON DELETE CASCADE, -- Set when invited by ID or once accepted
+    invitee_email VARCHAR(255) NOT NULL DEFAULT '', -- Lower case; set when invited by email
+    role VARCHAR(10) NOT NULL CHECK (role IN ('EDITOR', 'VIEWER')),
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'ACCEPTED', 'DECLINED', 'REVOKED')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    responded_at TIMESTAMPTZ NULL,
+    CHECK (invitee_id IS NOT NULL OR invitee_email <> '')
+);
+
+-- At most one pending invitation per collection and invitee
+CREATE UNIQUE INDEX idx_collection_invitations_pending_user ON collection_invitations(collection_id, invitee_id) WHERE status = 'PENDING' AND invitee_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_collection_invitations_pending_email ON collection_invitations(collection_id, invitee_email) WHERE status = 'PENDING' AND invitee_id IS NULL;
+CREATE INDEX idx_collection_invitations_invitee_id ON collection_invitations(invitee_id) WHERE status = 'PENDING';
+CREATE INDEX idx_collection_invitations_invitee_email ON collection_invitations(invitee_email) WHERE status = 'PENDING';
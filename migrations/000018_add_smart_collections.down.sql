@@ -0,0 +1,8 @@
+-- migrations/000018_add_smart_collections.down.sql
+
+DELETE FROM audio_collections WHERE type = 'SMART';
+ALTER TABLE audio_collections DROP CONSTRAINT IF EXISTS audio_collections_smart_rule_check;
+ALTER TABLE audio_collections DROP COLUMN IF EXISTS smart_rule;
+ALTER TABLE audio_collections DROP CONSTRAINT IF EXISTS audio_collections_type_check;
+ALTER TABLE audio_collections
+    ADD CONSTRAINT audio_collections_type_check CHECK (type IN ('COURSE', 'PLAYLIST'));
@@ -0,0 +1,11 @@
+-- migrations/000018_add_smart_collections.up.sql
+
+-- SMART collections store a track query instead of a track list; their tracks are evaluated
+-- for the viewing user on every read, so they never have rows in collection_tracks.
+ALTER TABLE audio_collections DROP CONSTRAINT IF EXISTS audio_collections_type_check;
+ALTER TABLE audio_collections
+    ADD CONSTRAINT audio_collections_type_check CHECK (type IN ('COURSE', 'PLAYLIST', 'SMART'));
+
+ALTER TABLE audio_collections ADD COLUMN smart_rule JSONB NULL;
+ALTER TABLE audio_collections
+    ADD CONSTRAINT audio_collections_smart_rule_check CHECK ((type = 'SMART') = (smart_rule IS NOT NULL));
@@ -0,0 +1,3 @@
+-- migrations/000019_create_feed_tokens.down.sql
+
+DROP TABLE IF EXISTS collection_feed_tokens;
@@ -0,0 +1,14 @@
+-- migrations/000019_create_feed_tokens.up.sql
+
+-- Secret podcast feed URLs for collections that are not public, one per user and collection.
+-- Only a hash of the secret is stored; deleting the row revokes the URL.
+CREATE TABLE collection_feed_tokens (
+    id UUID PRIMARY KEY,
+    collection_id UUID NOT NULL REFERENCES audio_collections(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE, -- Hex SHA-256 of the secret
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (collection_id, user_id)
+);
+
+CREATE INDEX idx_collection_feed_tokens_user_id ON collection_feed_tokens(user_id);
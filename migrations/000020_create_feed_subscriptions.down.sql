@@ -0,0 +1,4 @@
+-- migrations/000020_create_feed_subscriptions.down.sql
+
+DROP TABLE IF EXISTS feed_episodes;
+DROP TABLE IF EXISTS feed_subscriptions;
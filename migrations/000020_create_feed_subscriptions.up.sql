@@ -0,0 +1,40 @@
+-- migrations/000020_create_feed_subscriptions.up.sql
+
+-- External podcast feeds whose episodes are ingested as tracks. A background job fetches
+-- every feed that is due, conditionally with the validators of the previous response.
+CREATE TABLE feed_subscriptions (
+    id UUID PRIMARY KEY,
+    feed_url TEXT NOT NULL,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- Owner of the ingested tracks and collection
+    collection_id UUID NULL REFERENCES audio_collections(id) ON DELETE SET NULL, -- Recreated by the next refresh if deleted
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    language_code VARCHAR(10) NOT NULL DEFAULT '', -- Used for feeds that declare no language
+    level VARCHAR(50) NOT NULL DEFAULT '',
+    is_public BOOLEAN NOT NULL DEFAULT false,
+    tags TEXT[] NULL,
+    etag TEXT NOT NULL DEFAULT '',
+    last_modified TEXT NOT NULL DEFAULT '',
+    last_fetched_at TIMESTAMPTZ NULL,
+    next_fetch_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (owner_id, feed_url)
+);
+
+CREATE INDEX idx_feed_subscriptions_next_fetch_at ON feed_subscriptions(next_fetch_at);
+
+-- The track each feed item (by GUID) was ingested as. Deleting the track keeps the row, so
+-- that the item is not ingested again.
+CREATE TABLE feed_episodes (
+    subscription_id UUID NOT NULL REFERENCES feed_subscriptions(id) ON DELETE CASCADE,
+    guid TEXT NOT NULL,
+    track_id UUID NULL REFERENCES audio_tracks(id) ON DELETE SET NULL,
+    enclosure_url TEXT NOT NULL,
+    published_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (subscription_id, guid)
+);
+
+CREATE INDEX idx_feed_episodes_track_id ON feed_episodes(track_id);
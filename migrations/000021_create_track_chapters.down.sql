@@ -0,0 +1,3 @@
+-- migrations/000021_create_track_chapters.down.sql
+
+DROP TABLE IF EXISTS track_chapters;
@@ -0,0 +1,13 @@
+-- migrations/000021_create_track_chapters.up.sql
+
+-- Chapters of a track, set by its uploader. Position orders the chapters by start time.
+CREATE TABLE track_chapters (
+    track_id UUID NOT NULL REFERENCES audio_tracks(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    start_ms INTERVAL NOT NULL CHECK (start_ms >= interval '0 seconds'),
+    end_ms INTERVAL NOT NULL,
+    PRIMARY KEY (track_id, position),
+    CHECK (end_ms > start_ms)
+);
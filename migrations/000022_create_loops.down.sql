@@ -0,0 +1,3 @@
+-- migrations/000022_create_loops.down.sql
+
+DROP TABLE IF EXISTS loops;
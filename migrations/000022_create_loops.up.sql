@@ -0,0 +1,19 @@
+-- migrations/000022_create_loops.up.sql
+
+-- A-B loop regions saved by users: the player repeats [start_ms, end_ms) of a track.
+CREATE TABLE loops (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    track_id UUID NOT NULL REFERENCES audio_tracks(id) ON DELETE CASCADE,
+    start_ms INTERVAL NOT NULL CHECK (start_ms >= interval '0 seconds'),
+    end_ms INTERVAL NOT NULL,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    repeat_count INTEGER NOT NULL DEFAULT 0 CHECK (repeat_count >= 0), -- 0 repeats until stopped
+    playback_speed DOUBLE PRECISION NOT NULL DEFAULT 1 CHECK (playback_speed > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (end_ms > start_ms)
+);
+
+CREATE INDEX idx_loops_user_track_start ON loops(user_id, track_id, start_ms ASC);
+CREATE INDEX idx_loops_user_created_at ON loops(user_id, created_at DESC);
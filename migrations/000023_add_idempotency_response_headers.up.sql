@@ -0,0 +1,4 @@
+-- migrations/000023_add_idempotency_response_headers.up.sql
+
+-- Response headers replayed with a stored response (e.g. ETag and Location of a created resource).
+ALTER TABLE idempotency_keys ADD COLUMN response_headers JSONB NULL;
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	list, err := List()
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+
+	assert.Equal(t, uint(1), list[0].Version)
+	assert.Equal(t, "create_users_table", list[0].Name)
+	for i, m := range list {
+		assert.NotEmpty(t, m.Up, "version %d", m.Version)
+		assert.NotEmpty(t, m.Down, "version %d", m.Version)
+		if i > 0 {
+			assert.Greater(t, m.Version, list[i-1].Version)
+		}
+	}
+
+	latest, err := LatestVersion()
+	require.NoError(t, err)
+	assert.Equal(t, list[len(list)-1].Version, latest)
+}
@@ -0,0 +1,160 @@
+// pkg/audiotag/audiotag.go
+
+// Package audiotag reads descriptive metadata and the duration of audio files from
+// the beginning of the stream: ID3v2 tags and MPEG frame headers (MP3), Vorbis comments
+// (FLAC, Ogg Vorbis, Ogg Opus) and RIFF INFO chunks (WAV). Chapters are read from ID3
+// CHAP frames.
+//
+// Besides the standard fields, the custom keys LANGUAGE, LEVEL and TAGS (comma-separated)
+// are recognised in ID3 TXXX frames and Vorbis comments.
+package audiotag
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedFormat is returned when the stream is not in a recognised format.
+var ErrUnsupportedFormat = errors.New("audiotag: unsupported format")
+
+// Tags holds the metadata found in a file. Fields that were not present are empty.
+type Tags struct {
+	Format      string // "mp3", "flac", "vorbis", "opus" or "wav"
+	Title       string
+	Artist      string
+	Album       string
+	Genre       string
+	Comment     string
+	Language    string
+	Level       string
+	Keywords    []string
+	TrackNumber int
+	Duration    time.Duration // Zero if it cannot be determined from the file header
+	Chapters    []Chapter     // Ordered by start time; MP3 only
+}
+
+// Chapter is a chapter mark of an ID3 tag.
+type Chapter struct {
+	ID          string // Element ID, unique within the tag
+	Title       string
+	Description string
+	Start       time.Duration
+	End         time.Duration
+}
+
+// maxMetadataSize bounds how much metadata is buffered (tags may embed cover art).
+const maxMetadataSize = 32 << 20
+
+// Read parses the metadata at the start of r. size is the total length of the file,
+// used to estimate the duration of constant-bitrate MP3s (pass 0 if unknown).
+// Only the beginning of the stream is consumed.
+func Read(r io.Reader, size int64) (*Tags, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(4)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(magic, []byte("ID3")):
+		return readMP3(br, size)
+	case string(magic) == "fLaC":
+		return readFLAC(br)
+	case string(magic) == "OggS":
+		return readOgg(br)
+	case string(magic) == "RIFF":
+		return readWAV(br)
+	case magic[0] == 0xFF && magic[1]&0xE0 == 0xE0:
+		return readMP3(br, size)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// set assigns a metadata value by its (case-insensitive) Vorbis comment key.
+func (t *Tags) set(key, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	switch strings.ToUpper(key) {
+	case "TITLE":
+		t.Title = value
+	case "ARTIST":
+		t.Artist = value
+	case "ALBUM":
+		t.Album = value
+	case "GENRE":
+		t.Genre = value
+	case "COMMENT", "DESCRIPTION":
+		t.Comment = value
+	case "LANGUAGE":
+		t.Language = value
+	case "LEVEL":
+		t.Level = value
+	case "TAGS":
+		t.Keywords = append(t.Keywords, splitList(value)...)
+	case "TRACKNUMBER":
+		t.TrackNumber = leadingInt(value)
+	}
+}
+
+// splitList splits a comma- or semicolon-separated list, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// leadingInt parses the digits at the start of s ("3/12" -> 3).
+func leadingInt(s string) int {
+	n := 0
+	for _, c := range strings.TrimSpace(s) {
+		if c < '0' || c > '9' {
+			break
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n
+}
+
+// parseVorbisComment parses a Vorbis comment block (little-endian lengths).
+func parseVorbisComment(data []byte, t *Tags) {
+	readUint32 := func() (uint32, bool) {
+		if len(data) < 4 {
+			return 0, false
+		}
+		v := uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16 | uint32(data[3])<<24
+		data = data[4:]
+		return v, true
+	}
+	vendorLen, ok := readUint32()
+	if !ok || uint64(vendorLen) > uint64(len(data)) {
+		return
+	}
+	data = data[vendorLen:]
+	count, ok := readUint32()
+	if !ok {
+		return
+	}
+	for i := uint32(0); i < count; i++ {
+		n, ok := readUint32()
+		if !ok || uint64(n) > uint64(len(data)) {
+			return
+		}
+		key, value, found := strings.Cut(string(data[:n]), "=")
+		data = data[n:]
+		if found {
+			t.set(key, value)
+		}
+	}
+}
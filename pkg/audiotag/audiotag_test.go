@@ -0,0 +1,217 @@
+package audiotag
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func id3Frame(id string, data []byte) []byte {
+	frame := []byte(id)
+	frame = binary.BigEndian.AppendUint32(frame, uint32(len(data)))
+	frame = append(frame, 0, 0)
+	return append(frame, data...)
+}
+
+func id3Tag(frames ...[]byte) []byte {
+	body := bytes.Join(frames, nil)
+	size := len(body)
+	header := []byte{'I', 'D', '3', 3, 0, 0,
+		byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}
+	return append(header, body...)
+}
+
+// mpegFrame returns an MPEG-1 Layer III 128 kbps 44.1 kHz stereo frame (417 bytes).
+func mpegFrame() []byte {
+	frame := make([]byte, 417)
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+	return frame
+}
+
+func TestRead_MP3WithID3(t *testing.T) {
+	file := id3Tag(
+		id3Frame("TIT2", append([]byte{3}, "Lección 1"...)),
+		// UTF-16 with BOM
+		id3Frame("TPE1", []byte{1, 0xFF, 0xFE, 'A', 0, 'n', 0, 'a', 0}),
+		id3Frame("TLAN", append([]byte{0}, "spa"...)),
+		id3Frame("TCON", append([]byte{0}, "(101)"...)),
+		id3Frame("TRCK", append([]byte{0}, "3/12"...)),
+		id3Frame("TXXX", append([]byte{3}, "LEVEL\x00A2"...)),
+		id3Frame("TXXX", append([]byte{3}, "TAGS\x00travel, food"...)),
+		id3Frame("COMM", append([]byte{3, 'e', 'n', 'g'}, "\x00At the market"...)),
+	)
+	tagSize := len(file)
+	for i := 0; i < 100; i++ {
+		file = append(file, mpegFrame()...)
+	}
+
+	tags, err := Read(bytes.NewReader(file), int64(len(file)))
+	require.NoError(t, err)
+	assert.Equal(t, "mp3", tags.Format)
+	assert.Equal(t, "Lección 1", tags.Title)
+	assert.Equal(t, "Ana", tags.Artist)
+	assert.Equal(t, "spa", tags.Language)
+	assert.Equal(t, "Speech", tags.Genre)
+	assert.Equal(t, 3, tags.TrackNumber)
+	assert.Equal(t, "A2", tags.Level)
+	assert.Equal(t, []string{"travel", "food"}, tags.Keywords)
+	assert.Equal(t, "At the market", tags.Comment)
+	// Constant bitrate estimate: 100 frames * 417 bytes at 128 kbps.
+	wantDuration := time.Duration(int64(len(file)-tagSize) * 8 * int64(time.Second) / 128000)
+	assert.Equal(t, wantDuration, tags.Duration)
+}
+
+func TestRead_MP3Durations(t *testing.T) {
+	t.Run("TLEN", func(t *testing.T) {
+		file := append(id3Tag(id3Frame("TLEN", append([]byte{0}, "95000"...))), mpegFrame()...)
+		tags, err := Read(bytes.NewReader(file), int64(len(file)))
+		require.NoError(t, err)
+		assert.Equal(t, 95*time.Second, tags.Duration)
+	})
+
+	t.Run("Xing header without ID3", func(t *testing.T) {
+		frame := mpegFrame()
+		copy(frame[36:], "Xing")
+		binary.BigEndian.PutUint32(frame[40:], 0x01)
+		binary.BigEndian.PutUint32(frame[44:], 441) // frames
+		tags, err := Read(bytes.NewReader(frame), int64(len(frame)))
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(441*1152*int64(time.Second)/44100), tags.Duration)
+	})
+}
+
+func id3Chapter(id string, start, end uint32, subframes ...[]byte) []byte {
+	data := append([]byte(id), 0)
+	data = binary.BigEndian.AppendUint32(data, start)
+	data = binary.BigEndian.AppendUint32(data, end)
+	data = append(data, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF) // Byte offsets unused
+	return id3Frame("CHAP", append(data, bytes.Join(subframes, nil)...))
+}
+
+func TestRead_MP3Chapters(t *testing.T) {
+	file := id3Tag(
+		id3Frame("TIT2", append([]byte{3}, "Lesson 4"...)),
+		id3Chapter("ch1", 90000, 2400000,
+			id3Frame("TIT2", append([]byte{3}, "Drills"...)),
+			id3Frame("TIT3", append([]byte{0}, "Repeat after the speaker"...))),
+		id3Chapter("ch0", 0, 90000, id3Frame("TIT2", append([]byte{3}, "Dialogue"...))),
+		id3Frame("CHAP", []byte("broken")), // No terminator: ignored
+	)
+	file = append(file, mpegFrame()...)
+
+	tags, err := Read(bytes.NewReader(file), int64(len(file)))
+	require.NoError(t, err)
+	assert.Equal(t, "Lesson 4", tags.Title)
+	assert.Equal(t, []Chapter{
+		{ID: "ch0", Title: "Dialogue", Start: 0, End: 90 * time.Second},
+		{ID: "ch1", Title: "Drills", Description: "Repeat after the speaker", Start: 90 * time.Second, End: 40 * time.Minute},
+	}, tags.Chapters)
+}
+
+func vorbisComment(comments ...string) []byte {
+	var b []byte
+	b = binary.LittleEndian.AppendUint32(b, 4)
+	b = append(b, "test"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(comments)))
+	for _, c := range comments {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(c)))
+		b = append(b, c...)
+	}
+	return b
+}
+
+func TestRead_FLAC(t *testing.T) {
+	streamInfo := make([]byte, 34)
+	// 44100 Hz (20 bits), 2 channels, 16 bits per sample, 441000 samples (36 bits).
+	sampleRate, totalSamples := 44100, 441000
+	streamInfo[10] = byte(sampleRate >> 12)
+	streamInfo[11] = byte(sampleRate >> 4)
+	streamInfo[12] = byte(sampleRate<<4) | 0x02
+	streamInfo[13] = 0xF0
+	binary.BigEndian.PutUint32(streamInfo[14:], uint32(totalSamples))
+
+	comment := vorbisComment("TITLE=Unit 2", "language=de-DE", "LEVEL=B1", "GENRE=Speech")
+	file := []byte("fLaC")
+	file = append(file, 0x00, 0, 0, 34)
+	file = append(file, streamInfo...)
+	file = append(file, 0x84, byte(len(comment)>>16), byte(len(comment)>>8), byte(len(comment)))
+	file = append(file, comment...)
+	file = append(file, 0xFF, 0xF8) // Audio frames follow
+
+	tags, err := Read(bytes.NewReader(file), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "flac", tags.Format)
+	assert.Equal(t, "Unit 2", tags.Title)
+	assert.Equal(t, "de-DE", tags.Language)
+	assert.Equal(t, "B1", tags.Level)
+	assert.Equal(t, "Speech", tags.Genre)
+	assert.Equal(t, 10*time.Second, tags.Duration)
+}
+
+func oggPage(packets ...[]byte) []byte {
+	var segments, body []byte
+	for _, p := range packets {
+		n := len(p)
+		for n >= 255 {
+			segments = append(segments, 255)
+			n -= 255
+		}
+		segments = append(segments, byte(n))
+		body = append(body, p...)
+	}
+	page := append([]byte("OggS"), make([]byte, 22)...)
+	page = append(page, byte(len(segments)))
+	page = append(page, segments...)
+	return append(page, body...)
+}
+
+func TestRead_OggOpus(t *testing.T) {
+	head := append([]byte("OpusHead"), make([]byte, 11)...)
+	longTitle := "TITLE=" + string(bytes.Repeat([]byte("a"), 300)) // Spans several segments
+	tagsPacket := append([]byte("OpusTags"), vorbisComment(longTitle, "TAGS=kids; songs")...)
+	file := append(oggPage(head), oggPage(tagsPacket)...)
+
+	tags, err := Read(bytes.NewReader(file), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "opus", tags.Format)
+	assert.Len(t, tags.Title, 300)
+	assert.Equal(t, []string{"kids", "songs"}, tags.Keywords)
+}
+
+func TestRead_WAV(t *testing.T) {
+	fmtChunk := make([]byte, 16)
+	binary.LittleEndian.PutUint16(fmtChunk[0:], 1)     // PCM
+	binary.LittleEndian.PutUint16(fmtChunk[2:], 1)     // mono
+	binary.LittleEndian.PutUint32(fmtChunk[4:], 8000)  // sample rate
+	binary.LittleEndian.PutUint32(fmtChunk[8:], 16000) // byte rate
+	info := append([]byte("INFO"), "INAM"...)
+	info = binary.LittleEndian.AppendUint32(info, 5)
+	info = append(info, "Hallo\x00"...) // Odd size is padded
+
+	chunk := func(id string, data []byte) []byte {
+		b := binary.LittleEndian.AppendUint32([]byte(id), uint32(len(data)))
+		return append(b, data...)
+	}
+	file := []byte("RIFF\x00\x00\x00\x00WAVE")
+	file = append(file, chunk("fmt ", fmtChunk)...)
+	file = append(file, chunk("LIST", info)...)
+	file = append(file, chunk("data", make([]byte, 32000))...)
+
+	tags, err := Read(bytes.NewReader(file), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "wav", tags.Format)
+	assert.Equal(t, "Hallo", tags.Title)
+	assert.Equal(t, 2*time.Second, tags.Duration)
+}
+
+func TestRead_Unsupported(t *testing.T) {
+	_, err := Read(bytes.NewReader([]byte("%PDF-1.7 not audio")), 0)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	_, err = Read(bytes.NewReader(nil), 0)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
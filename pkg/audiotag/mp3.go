@@ -0,0 +1,366 @@
+// pkg/audiotag/mp3.go
+package audiotag
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// id3Frames maps ID3v2 text frames to Vorbis comment keys.
+var id3Frames = map[string]string{
+	"TIT2": "TITLE",
+	"TPE1": "ARTIST",
+	"TALB": "ALBUM",
+	"TCON": "GENRE",
+	"TLAN": "LANGUAGE",
+	"TRCK": "TRACKNUMBER",
+}
+
+func readMP3(br *bufio.Reader, size int64) (*Tags, error) {
+	t := &Tags{Format: "mp3"}
+	var consumed int64
+	var lengthMs int64
+
+	if header, err := br.Peek(10); err == nil && bytes.HasPrefix(header, []byte("ID3")) {
+		tagSize := int64(syncsafe(header[6:10])) + 10
+		if header[5]&0x10 != 0 {
+			tagSize += 10 // Footer
+		}
+		if tagSize > maxMetadataSize {
+			return nil, fmt.Errorf("audiotag: ID3 tag of %d bytes is too large", tagSize)
+		}
+		tag := make([]byte, tagSize)
+		if _, err := io.ReadFull(br, tag); err != nil {
+			return nil, fmt.Errorf("audiotag: reading ID3 tag: %w", err)
+		}
+		consumed = tagSize
+		lengthMs = parseID3(tag, t)
+	}
+
+	if lengthMs > 0 {
+		t.Duration = time.Duration(lengthMs) * time.Millisecond
+		return t, nil
+	}
+	t.Duration = mp3Duration(br, size-consumed)
+	return t, nil
+}
+
+// parseID3 fills t from an ID3v2.3/2.4 tag (including its 10-byte header) and returns
+// the TLEN value in milliseconds, or 0.
+func parseID3(tag []byte, t *Tags) int64 {
+	version := tag[3]
+	flags := tag[5]
+	body := tag[10:]
+	if version < 3 || version > 4 {
+		return 0 // ID3v2.2 uses three-letter frame IDs; not supported
+	}
+	if version == 3 && flags&0x80 != 0 {
+		body = unsynchronise(body)
+	}
+	if flags&0x40 != 0 && len(body) >= 4 {
+		// Extended header: its size excludes itself in v2.3 and includes itself in v2.4.
+		extSize := int(binary.BigEndian.Uint32(body[:4])) + 4
+		if version == 4 {
+			extSize = int(syncsafe(body[:4]))
+		}
+		if extSize > len(body) {
+			return 0
+		}
+		body = body[extSize:]
+	}
+
+	var lengthMs int64
+	forEachID3Frame(body, version, func(id string, data []byte) {
+		switch {
+		case id == "TXXX":
+			if len(data) < 1 {
+				return
+			}
+			parts := decodeID3Strings(data[0], data[1:])
+			if len(parts) >= 2 {
+				t.set(parts[0], strings.Join(parts[1:], ", "))
+			}
+		case id == "COMM":
+			if len(data) < 4 || t.Comment != "" {
+				return
+			}
+			parts := decodeID3Strings(data[0], data[4:])
+			if len(parts) >= 2 {
+				t.set("COMMENT", parts[1])
+			}
+		case id == "TLEN":
+			if len(data) < 1 {
+				return
+			}
+			if parts := decodeID3Strings(data[0], data[1:]); len(parts) > 0 {
+				lengthMs, _ = strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+			}
+		case id == "CHAP":
+			if c, ok := parseID3Chapter(data, version); ok {
+				t.Chapters = append(t.Chapters, c)
+			}
+		default:
+			key, ok := id3Frames[id]
+			if !ok || len(data) < 1 {
+				return
+			}
+			parts := decodeID3Strings(data[0], data[1:])
+			if id == "TCON" {
+				for i, p := range parts {
+					parts[i] = id3Genre(p)
+				}
+			}
+			t.set(key, strings.Join(parts, ", "))
+		}
+	})
+	// CHAP frames may appear in any order; the table of contents is not needed to sort them.
+	slices.SortStableFunc(t.Chapters, func(a, b Chapter) int { return cmp.Compare(a.Start, b.Start) })
+	return lengthMs
+}
+
+// forEachID3Frame calls fn with the ID and data of every frame in body, a sequence of
+// ID3v2.3 or 2.4 frames. Compressed and encrypted frames are skipped.
+func forEachID3Frame(body []byte, version byte, fn func(id string, data []byte)) {
+	for len(body) >= 10 && body[0] != 0 {
+		id := string(body[:4])
+		frameSize := int(binary.BigEndian.Uint32(body[4:8]))
+		if version == 4 {
+			frameSize = int(syncsafe(body[4:8]))
+		}
+		formatFlags := body[9]
+		if frameSize < 0 || 10+frameSize > len(body) {
+			break
+		}
+		data := body[10 : 10+frameSize]
+		body = body[10+frameSize:]
+
+		if version == 4 {
+			if formatFlags&0x01 != 0 && len(data) >= 4 { // Data length indicator
+				data = data[4:]
+			}
+			if formatFlags&0x02 != 0 {
+				data = unsynchronise(data)
+			}
+		}
+		if formatFlags&0x0C != 0 {
+			continue // Compressed or encrypted
+		}
+		fn(id, data)
+	}
+}
+
+// parseID3Chapter parses a CHAP frame (ID3v2 Chapter Frame Addendum): a null-terminated
+// element ID, start and end times in milliseconds, byte offsets, and subframes of which
+// TIT2 (title) and TIT3 (description) are read.
+func parseID3Chapter(data []byte, version byte) (Chapter, bool) {
+	end := bytes.IndexByte(data, 0)
+	if end < 0 || len(data) < end+1+16 {
+		return Chapter{}, false
+	}
+	c := Chapter{ID: string(data[:end])}
+	times := data[end+1:]
+	c.Start = time.Duration(binary.BigEndian.Uint32(times[0:4])) * time.Millisecond
+	c.End = time.Duration(binary.BigEndian.Uint32(times[4:8])) * time.Millisecond
+	forEachID3Frame(times[16:], version, func(id string, sub []byte) {
+		if len(sub) < 1 || (id != "TIT2" && id != "TIT3") {
+			return
+		}
+		text := strings.TrimSpace(strings.Join(decodeID3Strings(sub[0], sub[1:]), " "))
+		if id == "TIT2" {
+			c.Title = text
+		} else {
+			c.Description = text
+		}
+	})
+	return c, true
+}
+
+func syncsafe(b []byte) uint32 {
+	return uint32(b[0]&0x7F)<<21 | uint32(b[1]&0x7F)<<14 | uint32(b[2]&0x7F)<<7 | uint32(b[3]&0x7F)
+}
+
+// unsynchronise removes the 0x00 bytes inserted after 0xFF by the ID3 unsynchronisation scheme.
+func unsynchronise(b []byte) []byte {
+	return bytes.ReplaceAll(b, []byte{0xFF, 0x00}, []byte{0xFF})
+}
+
+// decodeID3Strings decodes null-separated strings in the given ID3 text encoding.
+func decodeID3Strings(encoding byte, data []byte) []string {
+	var parts []string
+	switch encoding {
+	case 1, 2: // UTF-16 with BOM, UTF-16BE
+		for len(data) >= 2 {
+			end := 0
+			for end+1 < len(data) && !(data[end] == 0 && data[end+1] == 0) {
+				end += 2
+			}
+			if end+1 >= len(data) {
+				end = len(data) &^ 1
+			}
+			parts = append(parts, decodeUTF16(data[:end], encoding == 2))
+			if end+2 > len(data) {
+				break
+			}
+			data = data[end+2:]
+		}
+	default: // 0 = ISO-8859-1, 3 = UTF-8
+		for _, p := range bytes.Split(bytes.TrimRight(data, "\x00"), []byte{0}) {
+			if encoding == 0 {
+				runes := make([]rune, len(p))
+				for i, c := range p {
+					runes[i] = rune(c)
+				}
+				parts = append(parts, string(runes))
+			} else {
+				parts = append(parts, string(p))
+			}
+		}
+	}
+	return parts
+}
+
+func decodeUTF16(b []byte, bigEndian bool) string {
+	if len(b) >= 2 {
+		switch {
+		case b[0] == 0xFF && b[1] == 0xFE:
+			bigEndian = false
+			b = b[2:]
+		case b[0] == 0xFE && b[1] == 0xFF:
+			bigEndian = true
+			b = b[2:]
+		}
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = binary.BigEndian.Uint16(b[2*i:])
+		} else {
+			units[i] = binary.LittleEndian.Uint16(b[2*i:])
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+// id3Genre resolves ID3v1 numeric genre references such as "(8)" or "8".
+func id3Genre(s string) string {
+	ref := strings.TrimSuffix(strings.TrimPrefix(s, "("), ")")
+	if n, err := strconv.Atoi(ref); err == nil && n >= 0 && n < len(id3v1Genres) {
+		return id3v1Genres[n]
+	}
+	return s
+}
+
+// id3v1Genres holds the first entries of the ID3v1 genre list, enough for spoken-word content.
+var id3v1Genres = []string{
+	"Blues", "Classic Rock", "Country", "Dance", "Disco", "Funk", "Grunge", "Hip-Hop", "Jazz", "Metal",
+	"New Age", "Oldies", "Other", "Pop", "R&B", "Rap", "Reggae", "Rock", "Techno", "Industrial",
+	"Alternative", "Ska", "Death Metal", "Pranks", "Soundtrack", "Euro-Techno", "Ambient", "Trip-Hop", "Vocal", "Jazz+Funk",
+	"Fusion", "Trance", "Classical", "Instrumental", "Acid", "House", "Game", "Sound Clip", "Gospel", "Noise",
+	"AlternRock", "Bass", "Soul", "Punk", "Space", "Meditative", "Instrumental Pop", "Instrumental Rock", "Ethnic", "Gothic",
+	"Darkwave", "Techno-Industrial", "Electronic", "Pop-Folk", "Eurodance", "Dream", "Southern Rock", "Comedy", "Cult", "Gangsta",
+	"Top 40", "Christian Rap", "Pop/Funk", "Jungle", "Native American", "Cabaret", "New Wave", "Psychadelic", "Rave", "Showtunes",
+	"Trailer", "Lo-Fi", "Tribal", "Acid Punk", "Acid Jazz", "Polka", "Retro", "Musical", "Rock & Roll", "Hard Rock",
+	"Folk", "Folk-Rock", "National Folk", "Swing", "Fast Fusion", "Bebob", "Latin", "Revival", "Celtic", "Bluegrass",
+	"Avantgarde", "Gothic Rock", "Progressive Rock", "Psychedelic Rock", "Symphonic Rock", "Slow Rock", "Big Band", "Chorus", "Easy Listening", "Acoustic",
+	"Humour", "Speech", "Chanson", "Opera", "Chamber Music", "Sonata", "Symphony", "Booty Bass", "Primus", "Porn Groove",
+}
+
+// MPEG audio header tables, indexed by [version][layer][bitrate index] with
+// version 0 = MPEG-1, 1 = MPEG-2/2.5 and layer 0 = Layer I, 1 = II, 2 = III.
+var mpegBitrates = [2][3][16]int{
+	{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0},
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+	},
+	{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+	},
+}
+
+var mpegSampleRates = map[byte][3]int{
+	3: {44100, 48000, 32000}, // MPEG-1
+	2: {22050, 24000, 16000}, // MPEG-2
+	0: {11025, 12000, 8000},  // MPEG-2.5
+}
+
+// mpegScanLimit is how far past the tag the first frame header is searched for.
+const mpegScanLimit = 64 << 10
+
+// mp3Duration finds the first MPEG frame and derives the duration from its Xing/Info or
+// VBRI header, or from the bitrate assuming constant bitrate. audioSize is the number of
+// bytes from the current position to the end of the file (<= 0 if unknown).
+func mp3Duration(br *bufio.Reader, audioSize int64) time.Duration {
+	buf, _ := br.Peek(mpegScanLimit)
+	for i := 0; i+4 <= len(buf); i++ {
+		if buf[i] != 0xFF || buf[i+1]&0xE0 != 0xE0 {
+			continue
+		}
+		versionBits := (buf[i+1] >> 3) & 0x03
+		layerBits := (buf[i+1] >> 1) & 0x03
+		bitrateIndex := buf[i+2] >> 4
+		rateIndex := (buf[i+2] >> 2) & 0x03
+		if versionBits == 1 || layerBits == 0 || bitrateIndex == 0 || bitrateIndex == 15 || rateIndex == 3 {
+			continue
+		}
+		version := 0
+		if versionBits != 3 {
+			version = 1
+		}
+		layer := 3 - int(layerBits) // 0 = Layer I
+		bitrate := mpegBitrates[version][layer][bitrateIndex] * 1000
+		sampleRate := mpegSampleRates[versionBits][rateIndex]
+		mono := buf[i+3]>>6 == 3
+
+		samplesPerFrame := 1152
+		switch {
+		case layer == 0:
+			samplesPerFrame = 384
+		case layer == 2 && version == 1:
+			samplesPerFrame = 576
+		}
+
+		if frames := vbrFrameCount(buf[i:], version, mono); frames > 0 {
+			return time.Duration(int64(frames) * int64(samplesPerFrame) * int64(time.Second) / int64(sampleRate))
+		}
+		if audioSize <= 0 {
+			return 0
+		}
+		return time.Duration((audioSize - int64(i)) * 8 * int64(time.Second) / int64(bitrate))
+	}
+	return 0
+}
+
+// vbrFrameCount reads the frame count from a Xing/Info or VBRI header in the first frame.
+func vbrFrameCount(frame []byte, version int, mono bool) uint32 {
+	sideInfo := 32
+	switch {
+	case version == 0 && mono:
+		sideInfo = 17
+	case version == 1 && !mono:
+		sideInfo = 17
+	case version == 1 && mono:
+		sideInfo = 9
+	}
+	if x := 4 + sideInfo; len(frame) >= x+12 {
+		id := string(frame[x : x+4])
+		if (id == "Xing" || id == "Info") && binary.BigEndian.Uint32(frame[x+4:])&0x01 != 0 {
+			return binary.BigEndian.Uint32(frame[x+8:])
+		}
+	}
+	if v := 4 + 32; len(frame) >= v+18 && string(frame[v:v+4]) == "VBRI" {
+		return binary.BigEndian.Uint32(frame[v+14:])
+	}
+	return 0
+}
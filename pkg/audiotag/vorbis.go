@@ -0,0 +1,103 @@
+// pkg/audiotag/vorbis.go
+package audiotag
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+func readFLAC(br *bufio.Reader) (*Tags, error) {
+	t := &Tags{Format: "flac"}
+	if _, err := br.Discard(4); err != nil {
+		return nil, err
+	}
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			return nil, fmt.Errorf("audiotag: reading FLAC metadata block: %w", err)
+		}
+		last := header[0]&0x80 != 0
+		blockType := header[0] & 0x7F
+		length := int(header[1])<<16 | int(header[2])<<8 | int(header[3])
+
+		switch blockType {
+		case 0, 4: // STREAMINFO, VORBIS_COMMENT
+			block := make([]byte, length)
+			if _, err := io.ReadFull(br, block); err != nil {
+				return nil, fmt.Errorf("audiotag: reading FLAC metadata block: %w", err)
+			}
+			if blockType == 4 {
+				parseVorbisComment(block, t)
+			} else if len(block) >= 18 {
+				sampleRate := int64(block[10])<<12 | int64(block[11])<<4 | int64(block[12])>>4
+				totalSamples := int64(block[13]&0x0F)<<32 | int64(binary.BigEndian.Uint32(block[14:18]))
+				if sampleRate > 0 {
+					t.Duration = time.Duration(totalSamples * int64(time.Second) / sampleRate)
+				}
+			}
+		default:
+			if _, err := br.Discard(length); err != nil {
+				return nil, fmt.Errorf("audiotag: skipping FLAC metadata block: %w", err)
+			}
+		}
+		if last {
+			return t, nil
+		}
+	}
+}
+
+// oggPacketLimit bounds how many bytes of Ogg pages are read to find the header packets.
+const oggPacketLimit = maxMetadataSize
+
+// readOgg reads the identification and comment header packets of an Ogg Vorbis or Opus
+// stream. The duration is not available without reading the last page, so it stays zero.
+func readOgg(br *bufio.Reader) (*Tags, error) {
+	var packets [][]byte
+	var current []byte
+	read := 0
+	for len(packets) < 2 {
+		var header [27]byte
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			return nil, fmt.Errorf("audiotag: reading Ogg page: %w", err)
+		}
+		if string(header[:4]) != "OggS" {
+			return nil, fmt.Errorf("audiotag: invalid Ogg page")
+		}
+		segments := make([]byte, header[26])
+		if _, err := io.ReadFull(br, segments); err != nil {
+			return nil, fmt.Errorf("audiotag: reading Ogg page: %w", err)
+		}
+		for _, segLen := range segments {
+			seg := make([]byte, segLen)
+			if _, err := io.ReadFull(br, seg); err != nil {
+				return nil, fmt.Errorf("audiotag: reading Ogg page: %w", err)
+			}
+			current = append(current, seg...)
+			read += int(segLen)
+			if segLen < 255 {
+				packets = append(packets, current)
+				current = nil
+			}
+		}
+		if read > oggPacketLimit {
+			return nil, fmt.Errorf("audiotag: Ogg header packets are too large")
+		}
+	}
+
+	t := &Tags{}
+	switch {
+	case bytes.HasPrefix(packets[0], []byte("\x01vorbis")) && bytes.HasPrefix(packets[1], []byte("\x03vorbis")):
+		t.Format = "vorbis"
+		parseVorbisComment(packets[1][7:], t)
+	case bytes.HasPrefix(packets[0], []byte("OpusHead")) && bytes.HasPrefix(packets[1], []byte("OpusTags")):
+		t.Format = "opus"
+		parseVorbisComment(packets[1][8:], t)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	return t, nil
+}
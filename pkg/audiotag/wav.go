@@ -0,0 +1,92 @@
+// pkg/audiotag/wav.go
+package audiotag
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// riffInfoKeys maps RIFF INFO chunk IDs to Vorbis comment keys.
+var riffInfoKeys = map[string]string{
+	"INAM": "TITLE",
+	"IART": "ARTIST",
+	"IPRD": "ALBUM",
+	"IGNR": "GENRE",
+	"ICMT": "COMMENT",
+	"ILNG": "LANGUAGE",
+	"IKEY": "TAGS",
+	"ITRK": "TRACKNUMBER",
+}
+
+// readWAV reads the chunks up to the audio data. INFO chunks after the data are not seen.
+func readWAV(br *bufio.Reader) (*Tags, error) {
+	t := &Tags{Format: "wav"}
+	var riff [12]byte
+	if _, err := io.ReadFull(br, riff[:]); err != nil {
+		return nil, fmt.Errorf("audiotag: reading RIFF header: %w", err)
+	}
+	if string(riff[8:12]) != "WAVE" {
+		return nil, ErrUnsupportedFormat
+	}
+
+	var byteRate uint32
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return t, nil
+			}
+			return nil, fmt.Errorf("audiotag: reading RIFF chunk: %w", err)
+		}
+		id := string(header[:4])
+		size := binary.LittleEndian.Uint32(header[4:])
+
+		switch id {
+		case "data":
+			if byteRate > 0 {
+				t.Duration = time.Duration(int64(size) * int64(time.Second) / int64(byteRate))
+			}
+			return t, nil
+		case "fmt ", "LIST":
+			if size > maxMetadataSize {
+				return nil, fmt.Errorf("audiotag: RIFF chunk of %d bytes is too large", size)
+			}
+			chunk := make([]byte, size+size%2) // Chunks are padded to an even size
+			if _, err := io.ReadFull(br, chunk); err != nil {
+				return nil, fmt.Errorf("audiotag: reading RIFF chunk: %w", err)
+			}
+			if id == "fmt " && len(chunk) >= 12 {
+				byteRate = binary.LittleEndian.Uint32(chunk[8:12])
+			} else if id == "LIST" && bytes.HasPrefix(chunk, []byte("INFO")) {
+				parseRIFFInfo(chunk[4:size], t)
+			}
+		default:
+			if _, err := br.Discard(int(size + size%2)); err != nil {
+				return nil, fmt.Errorf("audiotag: skipping RIFF chunk: %w", err)
+			}
+		}
+	}
+}
+
+func parseRIFFInfo(data []byte, t *Tags) {
+	for len(data) >= 8 {
+		id := string(data[:4])
+		size := int(binary.LittleEndian.Uint32(data[4:8]))
+		if 8+size > len(data) {
+			return
+		}
+		if key, ok := riffInfoKeys[id]; ok {
+			t.set(key, string(bytes.TrimRight(data[8:8+size], "\x00")))
+		}
+		next := 8 + size + size%2
+		if next > len(data) {
+			return
+		}
+		data = data[next:]
+	}
+}
@@ -0,0 +1,164 @@
+// pkg/chapters/chapters.go
+
+// Package chapters reads and writes chapter lists in three formats:
+//   - Podlove Simple Chapters JSON: an array of {"start": "HH:MM:SS.mmm", "title": ...}
+//     objects, as exported by Podlove Publisher and read by its web player.
+//   - WebVTT chapter tracks: one cue per chapter whose text is the title; further lines of
+//     the cue are read and written as the description.
+//   - ID3: an ID3v2.4 tag with a table of contents (CTOC) and one CHAP frame per chapter,
+//     which tag editors can apply to an MP3 file. Decoding accepts a whole MP3 file.
+//
+// Only WebVTT and ID3 carry end times; chapters decoded from Podlove JSON have a zero End.
+package chapters
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxChapters limits the number of chapters Decode accepts.
+const MaxChapters = 500
+
+// ErrInvalid is returned (wrapped) by Decode for malformed or oversized chapter lists.
+var ErrInvalid = errors.New("invalid chapters")
+
+// Format is a chapter file format.
+type Format string
+
+const (
+	FormatPodlove Format = "podlove"
+	FormatWebVTT  Format = "webvtt"
+	FormatID3     Format = "id3"
+)
+
+// ParseFormat parses a format name, case-insensitively. "json" is accepted for Podlove
+// JSON and "vtt" for WebVTT.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "podlove", "json":
+		return FormatPodlove, nil
+	case "webvtt", "vtt":
+		return FormatWebVTT, nil
+	case "id3", "mp3":
+		return FormatID3, nil
+	default:
+		return "", fmt.Errorf("unsupported chapter format '%s' (want podlove, webvtt or id3)", s)
+	}
+}
+
+// DetectFormat guesses the format from the first bytes of a chapter file.
+func DetectFormat(head []byte) (Format, bool) {
+	if bytes.HasPrefix(head, []byte("ID3")) {
+		return FormatID3, true
+	}
+	head = bytes.TrimPrefix(head, []byte("\xef\xbb\xbf")) // UTF-8 BOM
+	if bytes.HasPrefix(head, []byte("WEBVTT")) {
+		return FormatWebVTT, true
+	}
+	head = bytes.TrimLeft(head, " \t\r\n")
+	if bytes.HasPrefix(head, []byte("[")) || bytes.HasPrefix(head, []byte("{")) {
+		return FormatPodlove, true
+	}
+	return "", false
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatWebVTT:
+		return "text/vtt; charset=utf-8"
+	case FormatID3:
+		return "application/octet-stream"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Extension returns the file name extension of the format, without the dot.
+func (f Format) Extension() string {
+	switch f {
+	case FormatWebVTT:
+		return "vtt"
+	case FormatID3:
+		return "id3"
+	default:
+		return "json"
+	}
+}
+
+// Chapter is a named segment of an audio file.
+type Chapter struct {
+	Title       string
+	Description string // Optional
+	Start       time.Duration
+	End         time.Duration // Zero if the format has no end times
+}
+
+// Encode writes the chapters in the given format. Formats with end times require End to be
+// set and after Start.
+func Encode(w io.Writer, format Format, chapters []Chapter) error {
+	switch format {
+	case FormatPodlove:
+		return encodePodlove(w, chapters)
+	case FormatWebVTT:
+		return encodeWebVTT(w, chapters)
+	case FormatID3:
+		return encodeID3(w, chapters)
+	default:
+		return fmt.Errorf("unsupported chapter format '%s'", format)
+	}
+}
+
+// Decode reads a chapter list in the given format. Chapters keep the order of the file.
+func Decode(r io.Reader, format Format) ([]Chapter, error) {
+	var chapters []Chapter
+	var err error
+	switch format {
+	case FormatPodlove:
+		chapters, err = decodePodlove(r)
+	case FormatWebVTT:
+		chapters, err = decodeWebVTT(r)
+	case FormatID3:
+		chapters, err = decodeID3(r)
+	default:
+		return nil, fmt.Errorf("unsupported chapter format '%s'", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(chapters) > MaxChapters {
+		return nil, fmt.Errorf("%w: more than %d chapters", ErrInvalid, MaxChapters)
+	}
+	return chapters, nil
+}
+
+// parseTimestamp parses [[HH:]MM:]SS[.mmm], as used by Podlove normal play time and WebVTT.
+func parseTimestamp(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	parts := strings.Split(s, ":")
+	if s == "" || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+	var total float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil || v < 0 || math.IsInf(v, 0) || math.IsNaN(v) || (i < len(parts)-1 && v != math.Trunc(v)) {
+			return 0, fmt.Errorf("invalid timestamp %q", s)
+		}
+		total = total*60 + v
+	}
+	return time.Duration(math.Round(total*1000)) * time.Millisecond, nil
+}
+
+// formatTimestamp formats a position as HH:MM:SS.mmm, the form of Podlove normal play time
+// and WebVTT cue timings.
+func formatTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
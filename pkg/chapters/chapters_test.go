@@ -0,0 +1,109 @@
+package chapters
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleChapters() []Chapter {
+	return []Chapter{
+		{Title: "Intro", Start: 0, End: 65 * time.Second},
+		{Title: "Ordering <coffee> & tea", Description: "Café vocabulary\nRole play", Start: 65 * time.Second, End: 3*time.Minute + 500*time.Millisecond},
+		{Title: "Review", Start: 3*time.Minute + 500*time.Millisecond, End: time.Hour + 2*time.Second},
+	}
+}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatPodlove, FormatWebVTT, FormatID3} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Encode(&buf, format, sampleChapters()))
+
+			detected, ok := DetectFormat(buf.Bytes())
+			require.True(t, ok)
+			assert.Equal(t, format, detected)
+
+			got, err := Decode(&buf, format)
+			require.NoError(t, err)
+			want := sampleChapters()
+			if format == FormatPodlove {
+				for i := range want {
+					want[i].End = 0 // Podlove JSON has no end times or descriptions
+					want[i].Description = ""
+				}
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestEncode_RequiresEndTimes(t *testing.T) {
+	chapters := []Chapter{{Title: "Intro", Start: time.Second}}
+	assert.Error(t, Encode(&bytes.Buffer{}, FormatWebVTT, chapters))
+	assert.Error(t, Encode(&bytes.Buffer{}, FormatID3, chapters))
+	assert.NoError(t, Encode(&bytes.Buffer{}, FormatPodlove, chapters))
+}
+
+func TestDecodePodlove_Variants(t *testing.T) {
+	input := `{"version":"1.2.0","chapters":[
+		{"startTime":0,"title":"Intro"},
+		{"start":"1:05","title":" Main ","endTime":125.25},
+		{"start":"00:02:05.250","title":"Outro"}
+	]}`
+	got, err := Decode(strings.NewReader(input), FormatPodlove)
+	require.NoError(t, err)
+	assert.Equal(t, []Chapter{
+		{Title: "Intro", Start: 0},
+		{Title: "Main", Start: 65 * time.Second, End: 125250 * time.Millisecond},
+		{Title: "Outro", Start: 125250 * time.Millisecond},
+	}, got)
+
+	_, err = Decode(strings.NewReader(`[{"title":"No start"}]`), FormatPodlove)
+	assert.True(t, errors.Is(err, ErrInvalid))
+	_, err = Decode(strings.NewReader(`[{"start":"1:xx","title":"Bad"}]`), FormatPodlove)
+	assert.True(t, errors.Is(err, ErrInvalid))
+}
+
+func TestDecodeWebVTT_Foreign(t *testing.T) {
+	input := "\ufeffWEBVTT - chapters\r\n" +
+		"\r\n" +
+		"NOTE exported by hand\r\n" +
+		"\r\n" +
+		"00:00.000 --> 01:00.000 align:start\r\n" +
+		"Opening &amp; greetings\r\n" +
+		"\r\n" +
+		"chapter-2\r\n" +
+		"00:01:00.000 --> 00:02:30.000\r\n" +
+		"Numbers\r\n" +
+		"One to ten\r\n"
+	got, err := Decode(strings.NewReader(input), FormatWebVTT)
+	require.NoError(t, err)
+	assert.Equal(t, []Chapter{
+		{Title: "Opening & greetings", Start: 0, End: time.Minute},
+		{Title: "Numbers", Description: "One to ten", Start: time.Minute, End: 150 * time.Second},
+	}, got)
+
+	_, err = Decode(strings.NewReader("1\n00:00.000 --> 00:01.000\nIntro\n"), FormatWebVTT)
+	assert.True(t, errors.Is(err, ErrInvalid))
+}
+
+func TestDecodeID3_NotMP3(t *testing.T) {
+	_, err := Decode(strings.NewReader("fLaC\x00\x00\x00\x00"), FormatID3)
+	assert.True(t, errors.Is(err, ErrInvalid))
+}
+
+func TestParseFormat(t *testing.T) {
+	for input, want := range map[string]Format{"podlove": FormatPodlove, "JSON": FormatPodlove, "vtt": FormatWebVTT, " WebVTT ": FormatWebVTT, "id3": FormatID3, "mp3": FormatID3} {
+		got, err := ParseFormat(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+	_, err := ParseFormat("srt")
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+// pkg/chapters/id3.go
+package chapters
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/yvanyang/language-learning-player-api/pkg/audiotag"
+)
+
+// maxID3TagSize is the largest size a syncsafe ID3v2 size field can express.
+const maxID3TagSize = 1<<28 - 1
+
+func encodeID3(w io.Writer, chapters []Chapter) error {
+	var frames bytes.Buffer
+
+	// Table of contents: top-level (0x02) and ordered (0x01), listing every chapter.
+	if len(chapters) > math.MaxUint8 {
+		return fmt.Errorf("ID3 table of contents holds at most %d chapters", math.MaxUint8)
+	}
+	toc := []byte("toc\x00")
+	toc = append(toc, 0x03, byte(len(chapters)))
+	for i := range chapters {
+		toc = append(toc, id3ElementID(i)...)
+		toc = append(toc, 0)
+	}
+	writeID3Frame(&frames, "CTOC", toc)
+
+	for i, c := range chapters {
+		if c.End <= c.Start {
+			return fmt.Errorf("chapter %d has no end after its start", i+1)
+		}
+		if c.End.Milliseconds() > math.MaxUint32-1 {
+			return fmt.Errorf("chapter %d ends too late for ID3", i+1)
+		}
+		var chap bytes.Buffer
+		chap.WriteString(id3ElementID(i))
+		chap.WriteByte(0)
+		binary.Write(&chap, binary.BigEndian, uint32(c.Start.Milliseconds()))
+		binary.Write(&chap, binary.BigEndian, uint32(c.End.Milliseconds()))
+		binary.Write(&chap, binary.BigEndian, uint64(math.MaxUint64)) // No byte offsets
+		writeID3Frame(&chap, "TIT2", id3Text(c.Title))
+		if c.Description != "" {
+			writeID3Frame(&chap, "TIT3", id3Text(c.Description))
+		}
+		writeID3Frame(&frames, "CHAP", chap.Bytes())
+	}
+	if frames.Len() > maxID3TagSize {
+		return errors.New("chapters are too large for an ID3 tag")
+	}
+
+	header := []byte{'I', 'D', '3', 4, 0, 0}
+	header = append(header, syncsafeBytes(frames.Len())...)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(frames.Bytes())
+	return err
+}
+
+func decodeID3(r io.Reader) ([]Chapter, error) {
+	tags, err := audiotag.Read(r, 0)
+	if errors.Is(err, audiotag.ErrUnsupportedFormat) || (err == nil && tags.Format != "mp3") {
+		return nil, fmt.Errorf("%w: not an ID3 tag or MP3 file", ErrInvalid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	chapters := make([]Chapter, 0, len(tags.Chapters))
+	for _, c := range tags.Chapters {
+		chapters = append(chapters, Chapter{Title: c.Title, Description: c.Description, Start: c.Start, End: c.End})
+	}
+	return chapters, nil
+}
+
+func id3ElementID(i int) string {
+	return "chp" + strconv.Itoa(i)
+}
+
+// id3Text returns the data of a text frame in UTF-8 (encoding 3, ID3v2.4 only).
+func id3Text(s string) []byte {
+	return append([]byte{3}, s...)
+}
+
+// writeID3Frame appends an ID3v2.4 frame with no flags.
+func writeID3Frame(buf *bytes.Buffer, id string, data []byte) {
+	buf.WriteString(id)
+	buf.Write(syncsafeBytes(len(data)))
+	buf.Write([]byte{0, 0})
+	buf.Write(data)
+}
+
+func syncsafeBytes(n int) []byte {
+	return []byte{byte(n >> 21 & 0x7F), byte(n >> 14 & 0x7F), byte(n >> 7 & 0x7F), byte(n & 0x7F)}
+}
@@ -0,0 +1,100 @@
+// pkg/chapters/podlove.go
+package chapters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// podloveChapter is one element of a Podlove Simple Chapters JSON array.
+type podloveChapter struct {
+	Start string `json:"start"`
+	Title string `json:"title"`
+}
+
+// podloveInput is the lenient form read by Decode. Start and end may be normal play time
+// strings or numbers of seconds; the "startTime" and "endTime" numbers of Podcasting 2.0
+// JSON chapters are accepted as well.
+type podloveInput struct {
+	Start     json.RawMessage `json:"start"`
+	End       json.RawMessage `json:"end"`
+	StartTime json.RawMessage `json:"startTime"`
+	EndTime   json.RawMessage `json:"endTime"`
+	Title     string          `json:"title"`
+}
+
+func encodePodlove(w io.Writer, chapters []Chapter) error {
+	out := make([]podloveChapter, 0, len(chapters))
+	for _, c := range chapters {
+		out = append(out, podloveChapter{Start: formatTimestamp(c.Start), Title: c.Title})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func decodePodlove(r io.Reader) ([]Chapter, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	// Either a bare array or an object with a "chapters" array.
+	var items []podloveInput
+	if trimmed := bytes.TrimLeft(data, " \t\r\n"); bytes.HasPrefix(trimmed, []byte("{")) {
+		var doc struct {
+			Chapters []podloveInput `json:"chapters"`
+		}
+		err = json.Unmarshal(data, &doc)
+		items = doc.Chapters
+	} else {
+		err = json.Unmarshal(data, &items)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if len(items) > MaxChapters {
+		return nil, fmt.Errorf("%w: more than %d chapters", ErrInvalid, MaxChapters)
+	}
+
+	chapters := make([]Chapter, 0, len(items))
+	for i, item := range items {
+		start, ok, err := jsonTimestamp(item.Start, item.StartTime)
+		if err != nil || !ok {
+			return nil, fmt.Errorf("%w: chapter %d has no valid start", ErrInvalid, i+1)
+		}
+		end, _, err := jsonTimestamp(item.End, item.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: chapter %d has an invalid end", ErrInvalid, i+1)
+		}
+		chapters = append(chapters, Chapter{Title: strings.TrimSpace(item.Title), Start: start, End: end})
+	}
+	return chapters, nil
+}
+
+// jsonTimestamp reads the first of the values that is present: a normal play time string
+// or a number of seconds. ok is false if none is.
+func jsonTimestamp(values ...json.RawMessage) (d time.Duration, ok bool, err error) {
+	for _, raw := range values {
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			d, err := parseTimestamp(s)
+			return d, err == nil, err
+		}
+		var seconds float64
+		if err := json.Unmarshal(raw, &seconds); err != nil || seconds < 0 {
+			return 0, false, fmt.Errorf("invalid timestamp %s", raw)
+		}
+		return time.Duration(math.Round(seconds*1000)) * time.Millisecond, true, nil
+	}
+	return 0, false, nil
+}
@@ -0,0 +1,108 @@
+// pkg/chapters/webvtt.go
+package chapters
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	webvttEscaper   = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	webvttUnescaper = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&nbsp;", " ", "&lrm;", "\u200e", "&rlm;", "\u200f")
+)
+
+func encodeWebVTT(w io.Writer, chapters []Chapter) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("WEBVTT\n")
+	for i, c := range chapters {
+		if c.End <= c.Start {
+			return fmt.Errorf("chapter %d has no end after its start", i+1)
+		}
+		fmt.Fprintf(bw, "\n%d\n%s --> %s\n%s\n", i+1, formatTimestamp(c.Start), formatTimestamp(c.End), webvttText(c.Title))
+		for _, line := range strings.Split(c.Description, "\n") {
+			if line = webvttText(line); line != "" {
+				bw.WriteString(line + "\n")
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// webvttText escapes a line of cue text. A cue ends at the first blank line and must not
+// contain "-->", so both are removed.
+func webvttText(s string) string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "-->", "->"))
+	return webvttEscaper.Replace(s)
+}
+
+func decodeWebVTT(r io.Reader) ([]Chapter, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	if !sc.Scan() {
+		return nil, fmt.Errorf("%w: empty file", ErrInvalid)
+	}
+	header := strings.TrimPrefix(sc.Text(), "\ufeff")
+	if header != "WEBVTT" && !strings.HasPrefix(header, "WEBVTT ") && !strings.HasPrefix(header, "WEBVTT\t") {
+		return nil, fmt.Errorf("%w: missing WEBVTT header", ErrInvalid)
+	}
+
+	// Blocks are separated by blank lines. A cue block has an optional identifier line, a
+	// timing line and text lines; NOTE, STYLE and REGION blocks have no timing line.
+	var chapters []Chapter
+	var block []string
+	flush := func() error {
+		defer func() { block = block[:0] }()
+		timing := -1
+		for i, line := range block {
+			if strings.Contains(line, "-->") {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 || timing > 1 {
+			return nil
+		}
+		startStr, rest, _ := strings.Cut(block[timing], "-->")
+		endStr, _, _ := strings.Cut(strings.TrimSpace(rest), " ") // Cue settings follow the end time
+		start, err := parseTimestamp(startStr)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		end, err := parseTimestamp(endStr)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		c := Chapter{Start: start, End: end}
+		if text := block[timing+1:]; len(text) > 0 {
+			c.Title = strings.TrimSpace(webvttUnescaper.Replace(text[0]))
+			for i, line := range text[1:] {
+				text[1+i] = strings.TrimSpace(webvttUnescaper.Replace(line))
+			}
+			c.Description = strings.Join(text[1:], "\n")
+		}
+		if len(chapters) == MaxChapters {
+			return fmt.Errorf("%w: more than %d chapters", ErrInvalid, MaxChapters)
+		}
+		chapters = append(chapters, c)
+		return nil
+	}
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.TrimSpace(line) != "" {
+			block = append(block, line)
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return chapters, nil
+}
@@ -0,0 +1,104 @@
+// pkg/httputil/etag.go
+package httputil
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+)
+
+// VersionETag formats a resource version as a strong entity tag, e.g. "3".
+func VersionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// VersionDigestETag formats a resource version and a digest of its representation as a strong
+// entity tag, e.g. "3-ab12". Use it when the representation embeds data that changes without
+// the version (e.g. related resources): it changes with the body, and IfMatchVersion still reads
+// the version from it.
+func VersionDigestETag(version int, digest string) string {
+	return `"` + strconv.Itoa(version) + "-" + digest + `"`
+}
+
+// WeakETag formats an opaque value as a weak entity tag, e.g. W/"3-ab12".
+// Use it for representations that vary beyond the stored version (e.g. per-user data).
+func WeakETag(value string) string {
+	return `W/"` + value + `"`
+}
+
+// IfMatchVersion reads the If-Match header of an update to a versioned resource.
+// It returns the version the client expects, or 0 for "*" (any current version).
+// Tags from VersionDigestETag are accepted; only their version is compared.
+// A missing header yields domain.ErrPreconditionRequired; a tag that can never
+// match a version (weak or non-numeric) yields domain.ErrPreconditionFailed.
+func IfMatchVersion(r *http.Request) (int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return 0, fmt.Errorf("%w: If-Match header is required", domain.ErrPreconditionRequired)
+	}
+	if header == "*" {
+		return 0, nil
+	}
+	if strings.Contains(header, ",") {
+		return 0, fmt.Errorf("%w: If-Match must contain a single entity tag", domain.ErrInvalidArgument)
+	}
+	// If-Match uses strong comparison, so a weak tag never matches.
+	if strings.HasPrefix(header, "W/") {
+		return 0, fmt.Errorf("%w: If-Match requires a strong entity tag", domain.ErrPreconditionFailed)
+	}
+	if len(header) < 2 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return 0, fmt.Errorf("%w: malformed If-Match header", domain.ErrInvalidArgument)
+	}
+	tag := header[1 : len(header)-1]
+	if i := strings.IndexByte(tag, '-'); i > 0 {
+		tag = tag[:i] // Digest from VersionDigestETag
+	}
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("%w: entity tag %s does not match the resource", domain.ErrPreconditionFailed, header)
+	}
+	return version, nil
+}
+
+// NotModified sets the ETag response header and checks it against the request's
+// If-None-Match header using weak comparison. On a match it writes 304 Not Modified
+// and returns true; the caller must not write a body.
+func NotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// NotModifiedSince is NotModified for resources that also have a modification time. It sets the
+// ETag and Last-Modified response headers. If-None-Match takes precedence; without it the request's
+// If-Modified-Since header is compared with lastModified at one-second precision, as HTTP dates have.
+func NotModifiedSince(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time) bool {
+	w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	if r.Header.Get("If-None-Match") != "" {
+		return NotModified(w, r, etag)
+	}
+	w.Header().Set("ETag", etag)
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false // Missing or malformed
+	}
+	if lastModified.Truncate(time.Second).After(since) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
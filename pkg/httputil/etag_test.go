@@ -0,0 +1,121 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yvanyang/language-learning-player-api/internal/domain"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		wantVersion int
+		wantErr     error
+	}{
+		{"Missing", "", 0, domain.ErrPreconditionRequired},
+		{"Wildcard", "*", 0, nil},
+		{"Version", `"3"`, 3, nil},
+		{"Padded", ` "12" `, 12, nil},
+		{"Version With Digest", `"3-ab12cd"`, 3, nil},
+		{"Digest Only", `"-ab12"`, 0, domain.ErrPreconditionFailed},
+		{"Weak Tag", `W/"3"`, 0, domain.ErrPreconditionFailed},
+		{"Non-numeric Tag", `"abc"`, 0, domain.ErrPreconditionFailed},
+		{"Zero Version", `"0"`, 0, domain.ErrPreconditionFailed},
+		{"Unquoted", `3`, 0, domain.ErrInvalidArgument},
+		{"List", `"3", "4"`, 0, domain.ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/test", nil)
+			if tt.header != "" {
+				req.Header.Set("If-Match", tt.header)
+			}
+			version, err := IfMatchVersion(req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	tests := []struct {
+		name        string
+		etag        string
+		header      string
+		wantMatched bool
+	}{
+		{"No Header", `"3"`, "", false},
+		{"Match", `"3"`, `"3"`, true},
+		{"Mismatch", `"3"`, `"2"`, false},
+		{"Weak Comparison", `"3"`, `W/"3"`, true},
+		{"Weak ETag", `W/"3-ab"`, `"3-ab"`, true},
+		{"List", `"3"`, `"1", "3"`, true},
+		{"Wildcard", `"3"`, "*", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.header != "" {
+				req.Header.Set("If-None-Match", tt.header)
+			}
+			rr := httptest.NewRecorder()
+			matched := NotModified(rr, req, tt.etag)
+			assert.Equal(t, tt.wantMatched, matched)
+			assert.Equal(t, tt.etag, rr.Header().Get("ETag"))
+			if tt.wantMatched {
+				assert.Equal(t, http.StatusNotModified, rr.Code)
+			}
+		})
+	}
+}
+
+func TestNotModifiedSince(t *testing.T) {
+	modified := time.Date(2025, 3, 1, 9, 30, 0, 500_000_000, time.UTC)
+	tests := []struct {
+		name        string
+		noneMatch   string
+		since       string
+		wantMatched bool
+	}{
+		{"No Headers", "", "", false},
+		{"Same Second", "", "Sat, 01 Mar 2025 09:30:00 GMT", true},
+		{"Later", "", "Sat, 01 Mar 2025 10:00:00 GMT", true},
+		{"Earlier", "", "Sat, 01 Mar 2025 09:29:59 GMT", false},
+		{"Malformed Date", "", "yesterday", false},
+		{"ETag Takes Precedence", `"other"`, "Sat, 01 Mar 2025 10:00:00 GMT", false},
+		{"ETag Match", `W/"x"`, "Sat, 01 Mar 2025 09:00:00 GMT", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.noneMatch != "" {
+				req.Header.Set("If-None-Match", tt.noneMatch)
+			}
+			if tt.since != "" {
+				req.Header.Set("If-Modified-Since", tt.since)
+			}
+			rr := httptest.NewRecorder()
+			matched := NotModifiedSince(rr, req, `W/"x"`, modified)
+			assert.Equal(t, tt.wantMatched, matched)
+			assert.Equal(t, `W/"x"`, rr.Header().Get("ETag"))
+			assert.Equal(t, "Sat, 01 Mar 2025 09:30:00 GMT", rr.Header().Get("Last-Modified"))
+			if tt.wantMatched {
+				assert.Equal(t, http.StatusNotModified, rr.Code)
+			}
+		})
+	}
+}
+
+func TestVersionETag(t *testing.T) {
+	assert.Equal(t, `"7"`, VersionETag(7))
+	assert.Equal(t, `W/"7-ab"`, WeakETag("7-ab"))
+}
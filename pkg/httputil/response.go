@@ -18,7 +18,10 @@ import (
 // ContextKey is a custom type for context keys to avoid collisions.
 type ContextKey string
 
-const RequestIDKey ContextKey = "requestID"
+const (
+	RequestIDKey ContextKey = "requestID"
+	ClientIPKey  ContextKey = "clientIP"
+)
 
 // GetReqID retrieves the request ID from the context.
 // Returns an empty string if not found.
@@ -29,6 +32,15 @@ func GetReqID(ctx context.Context) string {
 	return ""
 }
 
+// GetClientIP retrieves the client IP address from the context.
+// Returns an empty string if not found.
+func GetClientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(ClientIPKey).(string); ok {
+		return ip
+	}
+	return ""
+}
+
 // ErrorResponseDTO defines the standard JSON error response body.
 type ErrorResponseDTO struct {
 	Code      string `json:"code"`                // Application-specific error code (e.g., "INVALID_INPUT", "NOT_FOUND")
@@ -73,6 +85,12 @@ func MapDomainErrorToHTTP(err error) (status int, code string, message string) {
 		// Use constant from apierrors package
 		// Use the specific error message for validation details
 		return http.StatusBadRequest, apierrors.CodeInvalidInput, err.Error()
+	case errors.Is(err, domain.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed, apierrors.CodePreconditionFailed, "The resource has been modified since it was last retrieved. Fetch it again and retry."
+	case errors.Is(err, domain.ErrPreconditionRequired):
+		return http.StatusPreconditionRequired, apierrors.CodePreconditionRequired, "This request must be conditional. Send the resource's ETag in an If-Match header."
+	case errors.Is(err, domain.ErrRateLimited):
+		return http.StatusTooManyRequests, apierrors.CodeRateLimitExceeded, "Too many requests. Please try again later."
 	case errors.Is(err, domain.ErrPermissionDenied):
 		// Use constant from apierrors package
 		// Check for specific rate limit error message if needed, otherwise use generic forbidden
@@ -33,6 +33,12 @@ func TestGetReqID(t *testing.T) {
 	assert.Empty(t, retrievedID)
 }
 
+func TestGetClientIP(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ClientIPKey, "203.0.113.7")
+	assert.Equal(t, "203.0.113.7", GetClientIP(ctx))
+	assert.Empty(t, GetClientIP(context.Background()))
+}
+
 func TestRespondJSON(t *testing.T) {
 	type samplePayload struct {
 		Message string `json:"message"`
@@ -98,6 +104,9 @@ func TestMapDomainErrorToHTTP(t *testing.T) {
 		{"Invalid Argument", fmt.Errorf("%w: email is required", domain.ErrInvalidArgument), http.StatusBadRequest, apierrors.CodeInvalidInput, "email is required"}, // Specific message used
 		{"Permission Denied", domain.ErrPermissionDenied, http.StatusForbidden, apierrors.CodeForbidden, "You do not have permission to perform this action."},
 		{"Rate Limit", fmt.Errorf("%w: rate limit exceeded", domain.ErrPermissionDenied), http.StatusTooManyRequests, apierrors.CodeRateLimitExceeded, "Too many requests. Please try again later."},
+		{"Rate Limited", fmt.Errorf("%w: retry in 3s", domain.ErrRateLimited), http.StatusTooManyRequests, apierrors.CodeRateLimitExceeded, "Too many requests. Please try again later."},
+		{"Precondition Failed", fmt.Errorf("%w: version mismatch", domain.ErrPreconditionFailed), http.StatusPreconditionFailed, apierrors.CodePreconditionFailed, "The resource has been modified since it was last retrieved. Fetch it again and retry."},
+		{"Precondition Required", domain.ErrPreconditionRequired, http.StatusPreconditionRequired, apierrors.CodePreconditionRequired, "This request must be conditional. Send the resource's ETag in an If-Match header."},
 		{"Authentication Failed", domain.ErrAuthenticationFailed, http.StatusUnauthorized, apierrors.CodeUnauthenticated, "Authentication failed. Please check your credentials."},
 		{"Unauthenticated", domain.ErrUnauthenticated, http.StatusUnauthorized, apierrors.CodeUnauthenticated, "Authentication required. Please log in."},
 		{"Wrapped Not Found", fmt.Errorf("specific item not found: %w", domain.ErrNotFound), http.StatusNotFound, apierrors.CodeNotFound, "The requested resource was not found."},
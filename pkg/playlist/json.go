@@ -0,0 +1,82 @@
+// pkg/playlist/json.go
+package playlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ManifestVersion is the version written to, and the highest version accepted from, JSON manifests.
+const ManifestVersion = 1
+
+// Manifest is the JSON playlist format.
+type Manifest struct {
+	Version     int             `json:"version"`
+	Title       string          `json:"title,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Tracks      []ManifestTrack `json:"tracks"`
+}
+
+// ManifestTrack is one entry of a Manifest.
+type ManifestTrack struct {
+	ID         string `json:"id,omitempty"`
+	Title      string `json:"title,omitempty"`
+	URL        string `json:"url,omitempty"`
+	DurationMs int64  `json:"durationMs,omitempty"`
+	SHA256     string `json:"sha256,omitempty"`
+}
+
+func encodeJSON(w io.Writer, p *Playlist) error {
+	m := Manifest{
+		Version:     ManifestVersion,
+		Title:       p.Title,
+		Description: p.Description,
+		Tracks:      make([]ManifestTrack, 0, len(p.Entries)),
+	}
+	for _, e := range p.Entries {
+		m.Tracks = append(m.Tracks, ManifestTrack{
+			ID:         e.ID,
+			Title:      e.Title,
+			URL:        e.Location,
+			DurationMs: e.Duration.Milliseconds(),
+			SHA256:     e.SHA256,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+func decodeJSON(r io.Reader) (*Playlist, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if m.Version > ManifestVersion {
+		return nil, fmt.Errorf("%w: unsupported manifest version %d", ErrInvalid, m.Version)
+	}
+	if len(m.Tracks) > MaxEntries {
+		return nil, fmt.Errorf("%w: more than %d entries", ErrInvalid, MaxEntries)
+	}
+	p := &Playlist{
+		Title:       strings.TrimSpace(m.Title),
+		Description: strings.TrimSpace(m.Description),
+		Entries:     make([]Entry, 0, len(m.Tracks)),
+	}
+	for _, t := range m.Tracks {
+		e := Entry{
+			ID:       strings.TrimSpace(t.ID),
+			Title:    strings.TrimSpace(t.Title),
+			Location: strings.TrimSpace(t.URL),
+			SHA256:   strings.TrimSpace(t.SHA256),
+		}
+		if t.DurationMs > 0 {
+			e.Duration = time.Duration(t.DurationMs) * time.Millisecond
+		}
+		p.Entries = append(p.Entries, e)
+	}
+	return p, nil
+}
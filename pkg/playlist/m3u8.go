@@ -0,0 +1,92 @@
+// pkg/playlist/m3u8.go
+package playlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	m3uHeader    = "#EXTM3U"
+	m3uPlaylist  = "#PLAYLIST:"
+	m3uInfo      = "#EXTINF:"
+	m3uTrackID   = "#LLP-ID:"
+	m3uTrackHash = "#LLP-SHA256:"
+)
+
+func encodeM3U8(w io.Writer, p *Playlist) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, m3uHeader)
+	if p.Title != "" {
+		fmt.Fprintln(bw, m3uPlaylist+oneLine(p.Title))
+	}
+	for _, e := range p.Entries {
+		seconds := -1 // Unknown duration
+		if e.Duration > 0 {
+			seconds = int(e.Duration.Round(time.Second) / time.Second)
+		}
+		fmt.Fprintf(bw, "%s%d,%s\n", m3uInfo, seconds, oneLine(e.Title))
+		if e.ID != "" {
+			fmt.Fprintln(bw, m3uTrackID+e.ID)
+		}
+		if e.SHA256 != "" {
+			fmt.Fprintln(bw, m3uTrackHash+e.SHA256)
+		}
+		fmt.Fprintln(bw, oneLine(e.Location))
+	}
+	return bw.Flush()
+}
+
+func decodeM3U8(r io.Reader) (*Playlist, error) {
+	p := &Playlist{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var pending Entry // Attributes collected for the next location line
+	first := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if first {
+			line = strings.TrimPrefix(line, "\ufeff") // UTF-8 BOM
+			first = false
+		}
+		switch {
+		case line == "" || line == m3uHeader:
+		case strings.HasPrefix(line, m3uPlaylist):
+			p.Title = strings.TrimSpace(strings.TrimPrefix(line, m3uPlaylist))
+		case strings.HasPrefix(line, m3uInfo):
+			seconds, title, _ := strings.Cut(strings.TrimPrefix(line, m3uInfo), ",")
+			if fields := strings.Fields(seconds); len(fields) > 0 { // Attributes may follow the duration
+				if sec, err := strconv.ParseFloat(fields[0], 64); err == nil && sec > 0 {
+					pending.Duration = time.Duration(sec * float64(time.Second))
+				}
+			}
+			pending.Title = strings.TrimSpace(title)
+		case strings.HasPrefix(line, m3uTrackID):
+			pending.ID = strings.TrimSpace(strings.TrimPrefix(line, m3uTrackID))
+		case strings.HasPrefix(line, m3uTrackHash):
+			pending.SHA256 = strings.TrimSpace(strings.TrimPrefix(line, m3uTrackHash))
+		case strings.HasPrefix(line, "#"):
+			// Other directives and comments are ignored
+		default:
+			pending.Location = line
+			p.Entries = append(p.Entries, pending)
+			if len(p.Entries) > MaxEntries {
+				return nil, fmt.Errorf("%w: more than %d entries", ErrInvalid, MaxEntries)
+			}
+			pending = Entry{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return p, nil
+}
+
+// oneLine replaces line breaks, which would end an M3U directive early.
+func oneLine(s string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+}
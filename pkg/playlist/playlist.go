@@ -0,0 +1,137 @@
+// pkg/playlist/playlist.go
+
+// Package playlist reads and writes playlists in three formats:
+//   - M3U8: extended M3U in UTF-8. Track IDs and checksums are carried in "#LLP-ID:" and
+//     "#LLP-SHA256:" lines, which other players ignore as comments.
+//   - XSPF: the XML Shareable Playlist Format. Track IDs and checksums are carried as
+//     "urn:uuid:<id>" and "urn:sha256:<hex>" identifiers.
+//   - JSON: a manifest of this service (see Manifest).
+//
+// Entries exported by this service can therefore be matched back to tracks on import even
+// after their URLs have expired; entries written by other players usually only have a location.
+package playlist
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// MaxEntries limits the number of entries Decode accepts.
+const MaxEntries = 5000
+
+// ErrInvalid is returned (wrapped) by Decode for malformed or oversized playlists.
+var ErrInvalid = errors.New("invalid playlist")
+
+// Format is a playlist file format.
+type Format string
+
+const (
+	FormatM3U8 Format = "m3u8"
+	FormatXSPF Format = "xspf"
+	FormatJSON Format = "json"
+)
+
+// ParseFormat parses a format name, case-insensitively. "m3u" is accepted for M3U8.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "m3u8", "m3u":
+		return FormatM3U8, nil
+	case "xspf":
+		return FormatXSPF, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported playlist format '%s' (want m3u8, xspf or json)", s)
+	}
+}
+
+// DetectFormat guesses the format from the first bytes of a playlist.
+func DetectFormat(head []byte) (Format, bool) {
+	head = bytes.TrimPrefix(head, []byte("\xef\xbb\xbf")) // UTF-8 BOM
+	head = bytes.TrimLeft(head, " \t\r\n")
+	switch {
+	case bytes.HasPrefix(head, []byte("#EXTM3U")):
+		return FormatM3U8, true
+	case bytes.HasPrefix(head, []byte("<")):
+		return FormatXSPF, true
+	case bytes.HasPrefix(head, []byte("{")):
+		return FormatJSON, true
+	default:
+		return "", false
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatM3U8:
+		return "audio/x-mpegurl; charset=utf-8"
+	case FormatXSPF:
+		return "application/xspf+xml; charset=utf-8"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Extension returns the file name extension of the format, without the dot.
+func (f Format) Extension() string { return string(f) }
+
+// Playlist is an ordered list of entries with optional metadata.
+type Playlist struct {
+	Title       string
+	Description string
+	Entries     []Entry
+}
+
+// Entry is one item of a playlist. Any field may be empty in decoded playlists.
+type Entry struct {
+	ID       string        // Track ID in this service
+	Title    string        // Display title
+	Location string        // URL of the audio
+	Duration time.Duration // Zero if unknown
+	SHA256   string        // Hex SHA-256 of the audio content
+}
+
+// Encode writes the playlist in the given format.
+func Encode(w io.Writer, format Format, p *Playlist) error {
+	switch format {
+	case FormatM3U8:
+		return encodeM3U8(w, p)
+	case FormatXSPF:
+		return encodeXSPF(w, p)
+	case FormatJSON:
+		return encodeJSON(w, p)
+	default:
+		return fmt.Errorf("unsupported playlist format '%s'", format)
+	}
+}
+
+// Decode reads a playlist in the given format.
+func Decode(r io.Reader, format Format) (*Playlist, error) {
+	var p *Playlist
+	var err error
+	switch format {
+	case FormatM3U8:
+		p, err = decodeM3U8(r)
+	case FormatXSPF:
+		p, err = decodeXSPF(r)
+	case FormatJSON:
+		p, err = decodeJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported playlist format '%s'", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Entries) > MaxEntries {
+		return nil, fmt.Errorf("%w: more than %d entries", ErrInvalid, MaxEntries)
+	}
+	for i := range p.Entries {
+		p.Entries[i].SHA256 = strings.ToLower(p.Entries[i].SHA256)
+	}
+	return p, nil
+}
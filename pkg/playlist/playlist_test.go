@@ -0,0 +1,124 @@
+package playlist
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func samplePlaylist() *Playlist {
+	return &Playlist{
+		Title:       "Spanish A1",
+		Description: "Beginner listening",
+		Entries: []Entry{
+			{
+				ID:       "6f1c0c2e-2b1a-4d7e-9c53-0b2f1d8f6a11",
+				Title:    "Greetings",
+				Location: "https://cdn.example.com/audio/greetings.mp3?sig=abc",
+				Duration: 95 * time.Second,
+				SHA256:   "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			},
+			{
+				Title:    "Numbers",
+				Location: "https://cdn.example.com/audio/numbers.mp3",
+				Duration: 2 * time.Minute,
+			},
+		},
+	}
+}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatM3U8, FormatXSPF, FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Encode(&buf, format, samplePlaylist()))
+
+			detected, ok := DetectFormat(buf.Bytes())
+			require.True(t, ok)
+			assert.Equal(t, format, detected)
+
+			got, err := Decode(&buf, format)
+			require.NoError(t, err)
+			want := samplePlaylist()
+			if format == FormatM3U8 {
+				want.Description = "" // M3U has no description
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestDecodeM3U8_Foreign(t *testing.T) {
+	input := "\ufeff#EXTM3U\r\n" +
+		"#EXTINF:12.5 tvg-id=\"x\",First\r\n" +
+		"first.mp3\r\n" +
+		"# a comment\r\n" +
+		"#EXTINF:-1,Stream\r\n" +
+		"http://example.com/stream\r\n" +
+		"bare.ogg\r\n"
+
+	p, err := Decode(strings.NewReader(input), FormatM3U8)
+	require.NoError(t, err)
+	require.Len(t, p.Entries, 3)
+	assert.Equal(t, Entry{Title: "First", Location: "first.mp3", Duration: 12500 * time.Millisecond}, p.Entries[0])
+	assert.Equal(t, Entry{Title: "Stream", Location: "http://example.com/stream"}, p.Entries[1])
+	assert.Equal(t, Entry{Location: "bare.ogg"}, p.Entries[2])
+}
+
+func TestDecodeXSPF_Identifiers(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<playlist version="1" xmlns="http://xspf.org/ns/0/">
+  <trackList>
+    <track>
+      <location>a.mp3</location>
+      <location>b.mp3</location>
+      <identifier>URN:SHA256:ABCDEF</identifier>
+      <identifier>http://example.com/other</identifier>
+    </track>
+  </trackList>
+</playlist>`
+
+	p, err := Decode(strings.NewReader(input), FormatXSPF)
+	require.NoError(t, err)
+	require.Len(t, p.Entries, 1)
+	assert.Equal(t, Entry{Location: "a.mp3", SHA256: "abcdef"}, p.Entries[0])
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	_, err := Decode(strings.NewReader(`{"version": 2, "tracks": []}`), FormatJSON)
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	_, err = Decode(strings.NewReader(`{"tracks": [`), FormatJSON)
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	_, err = Decode(strings.NewReader(`<playlist><trackList>`), FormatXSPF)
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	var sb strings.Builder
+	sb.WriteString("#EXTM3U\n")
+	for i := 0; i <= MaxEntries; i++ {
+		sb.WriteString("track.mp3\n")
+	}
+	_, err = Decode(strings.NewReader(sb.String()), FormatM3U8)
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat(" M3U ")
+	require.NoError(t, err)
+	assert.Equal(t, FormatM3U8, f)
+
+	f, err = ParseFormat("xspf")
+	require.NoError(t, err)
+	assert.Equal(t, FormatXSPF, f)
+
+	_, err = ParseFormat("pls")
+	assert.Error(t, err)
+
+	_, ok := DetectFormat([]byte("track.mp3"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,100 @@
+// pkg/playlist/xspf.go
+package playlist
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	xspfNamespace = "http://xspf.org/ns/0/"
+	urnUUID       = "urn:uuid:"
+	urnSHA256     = "urn:sha256:"
+)
+
+type xspfPlaylist struct {
+	XMLName    xml.Name    `xml:"playlist"`
+	Version    string      `xml:"version,attr"`
+	Namespace  string      `xml:"xmlns,attr,omitempty"`
+	Title      string      `xml:"title,omitempty"`
+	Annotation string      `xml:"annotation,omitempty"`
+	Tracks     []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Locations   []string `xml:"location"`
+	Identifiers []string `xml:"identifier"`
+	Title       string   `xml:"title,omitempty"`
+	DurationMs  int64    `xml:"duration,omitempty"`
+}
+
+func encodeXSPF(w io.Writer, p *Playlist) error {
+	doc := xspfPlaylist{
+		Version:    "1",
+		Namespace:  xspfNamespace,
+		Title:      p.Title,
+		Annotation: p.Description,
+		Tracks:     make([]xspfTrack, 0, len(p.Entries)),
+	}
+	for _, e := range p.Entries {
+		track := xspfTrack{Title: e.Title, DurationMs: e.Duration.Milliseconds()}
+		if e.Location != "" {
+			track.Locations = []string{e.Location}
+		}
+		if e.ID != "" {
+			track.Identifiers = append(track.Identifiers, urnUUID+e.ID)
+		}
+		if e.SHA256 != "" {
+			track.Identifiers = append(track.Identifiers, urnSHA256+e.SHA256)
+		}
+		doc.Tracks = append(doc.Tracks, track)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func decodeXSPF(r io.Reader) (*Playlist, error) {
+	var doc xspfPlaylist
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if len(doc.Tracks) > MaxEntries {
+		return nil, fmt.Errorf("%w: more than %d entries", ErrInvalid, MaxEntries)
+	}
+	p := &Playlist{
+		Title:       strings.TrimSpace(doc.Title),
+		Description: strings.TrimSpace(doc.Annotation),
+		Entries:     make([]Entry, 0, len(doc.Tracks)),
+	}
+	for _, t := range doc.Tracks {
+		e := Entry{Title: strings.TrimSpace(t.Title)}
+		if t.DurationMs > 0 {
+			e.Duration = time.Duration(t.DurationMs) * time.Millisecond
+		}
+		if len(t.Locations) > 0 {
+			e.Location = strings.TrimSpace(t.Locations[0])
+		}
+		for _, id := range t.Identifiers {
+			id = strings.TrimSpace(id)
+			switch {
+			case strings.HasPrefix(strings.ToLower(id), urnUUID):
+				e.ID = id[len(urnUUID):]
+			case strings.HasPrefix(strings.ToLower(id), urnSHA256):
+				e.SHA256 = id[len(urnSHA256):]
+			}
+		}
+		p.Entries = append(p.Entries, e)
+	}
+	return p, nil
+}
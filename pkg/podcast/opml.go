@@ -0,0 +1,68 @@
+// pkg/podcast/opml.go
+package podcast
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MaxOutlines bounds the number of feeds read from an OPML document.
+const MaxOutlines = 1000
+
+// Outline is a feed listed in an OPML subscription list.
+type Outline struct {
+	Title    string
+	FeedURL  string
+	Language string // Optional
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr"`
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	Language string        `xml:"language,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// ParseOPML reads the feeds of an OPML document, including those nested in categories,
+// in document order. Outlines without an xmlUrl are skipped, as are repeated URLs.
+func ParseOPML(r io.Reader) ([]Outline, error) {
+	var doc struct {
+		XMLName xml.Name      `xml:"opml"`
+		Body    []opmlOutline `xml:"body>outline"`
+	}
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charsetReader
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	var outlines []Outline
+	seen := make(map[string]bool)
+	var walk func([]opmlOutline) error
+	walk = func(list []opmlOutline) error {
+		for _, o := range list {
+			if u := strings.TrimSpace(o.XMLURL); u != "" && !seen[u] {
+				if len(outlines) == MaxOutlines {
+					return fmt.Errorf("%w: more than %d feeds", ErrInvalid, MaxOutlines)
+				}
+				seen[u] = true
+				outlines = append(outlines, Outline{
+					Title:    firstNonEmpty(o.Title, o.Text),
+					FeedURL:  u,
+					Language: strings.TrimSpace(o.Language),
+				})
+			}
+			if err := walk(o.Outlines); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(doc.Body); err != nil {
+		return nil, err
+	}
+	return outlines, nil
+}
@@ -0,0 +1,324 @@
+// pkg/podcast/parse.go
+package podcast
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	contentNamespace = "http://purl.org/rss/1.0/modules/content/"
+	mediaNamespace   = "http://search.yahoo.com/mrss/"
+)
+
+// ErrInvalid is returned (wrapped) for documents that are not a feed or OPML list this package can read.
+var ErrInvalid = errors.New("invalid feed")
+
+// Parse reads an RSS 2.0 or Atom feed. The iTunes, Podlove Simple Chapters and content
+// namespaces of RSS feeds are understood. Items keep the order of the document; fields
+// the feed does not set are left empty, and FeedURL is only set from an atom:link rel="self".
+func Parse(r io.Reader) (*Feed, error) {
+	root, err := parseTree(r)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case root.name.Local == "rss":
+		channel := root.child("", "channel")
+		if channel == nil {
+			return nil, fmt.Errorf("%w: rss without channel", ErrInvalid)
+		}
+		return parseRSS(channel), nil
+	case root.name.Space == atomNamespace && root.name.Local == "feed":
+		return parseAtom(root), nil
+	}
+	return nil, fmt.Errorf("%w: unsupported document <%s>", ErrInvalid, root.name.Local)
+}
+
+func parseRSS(ch *node) *Feed {
+	f := &Feed{
+		Title:       ch.text("", "title"),
+		Description: firstNonEmpty(ch.text("", "description"), ch.text(itunesNamespace, "summary")),
+		Link:        ch.text("", "link"),
+		Language:    ch.text("", "language"),
+		Author:      firstNonEmpty(ch.text(itunesNamespace, "author"), ch.text("", "managingEditor")),
+		Type:        ch.text(itunesNamespace, "type"),
+	}
+	for _, link := range ch.children(atomNamespace, "link") {
+		if link.attr("rel") == "self" {
+			f.FeedURL = link.attr("href")
+		}
+	}
+	if img := ch.child(itunesNamespace, "image"); img != nil {
+		f.ImageURL = img.attr("href")
+	}
+	if f.ImageURL == "" {
+		if img := ch.child("", "image"); img != nil {
+			f.ImageURL = img.text("", "url")
+		}
+	}
+	f.LastBuildDate, _ = parseDate(firstNonEmpty(ch.text("", "lastBuildDate"), ch.text("", "pubDate")))
+
+	for _, in := range ch.children("", "item") {
+		it := Item{
+			Title:       firstNonEmpty(in.text("", "title"), in.text(itunesNamespace, "title")),
+			Description: firstNonEmpty(in.text("", "description"), in.text(contentNamespace, "encoded"), in.text(itunesNamespace, "summary")),
+		}
+		if enc := in.child("", "enclosure"); enc != nil {
+			it.EnclosureURL = enc.attr("url")
+			it.EnclosureType = enc.attr("type")
+			it.EnclosureSize, _ = strconv.ParseInt(enc.attr("length"), 10, 64)
+		} else if media := in.child(mediaNamespace, "content"); media != nil {
+			it.EnclosureURL = media.attr("url")
+			it.EnclosureType = media.attr("type")
+			it.EnclosureSize, _ = strconv.ParseInt(media.attr("fileSize"), 10, 64)
+		}
+		it.GUID = firstNonEmpty(in.text("", "guid"), it.EnclosureURL, in.text("", "link"))
+		it.Duration, _ = ParseDuration(in.text(itunesNamespace, "duration"))
+		it.PubDate, _ = parseDate(in.text("", "pubDate"))
+		it.Episode, _ = strconv.Atoi(in.text(itunesNamespace, "episode"))
+		if img := in.child(itunesNamespace, "image"); img != nil {
+			it.ImageURL = img.attr("href")
+		}
+		if chapters := in.child(pscNamespace, "chapters"); chapters != nil {
+			for _, c := range chapters.children(pscNamespace, "chapter") {
+				start, err := ParseDuration(c.attr("start")) // Normal play time, e.g. "00:01:30.500"
+				if err != nil {
+					continue
+				}
+				it.Chapters = append(it.Chapters, Chapter{Start: start, Title: c.attr("title"), URL: c.attr("href")})
+			}
+		}
+		f.Items = append(f.Items, it)
+	}
+	return f
+}
+
+func parseAtom(feed *node) *Feed {
+	f := &Feed{
+		Title:       feed.text(atomNamespace, "title"),
+		Description: feed.text(atomNamespace, "subtitle"),
+		Language:    feed.attrNS(xmlNamespace, "lang"),
+		ImageURL:    firstNonEmpty(feed.text(atomNamespace, "logo"), feed.text(atomNamespace, "icon")),
+	}
+	for _, link := range feed.children(atomNamespace, "link") {
+		switch link.attr("rel") {
+		case "", "alternate":
+			if f.Link == "" {
+				f.Link = link.attr("href")
+			}
+		case "self":
+			f.FeedURL = link.attr("href")
+		}
+	}
+	if author := feed.child(atomNamespace, "author"); author != nil {
+		f.Author = author.text(atomNamespace, "name")
+	}
+	f.LastBuildDate, _ = parseDate(feed.text(atomNamespace, "updated"))
+
+	for _, entry := range feed.children(atomNamespace, "entry") {
+		it := Item{
+			GUID:        entry.text(atomNamespace, "id"),
+			Title:       entry.text(atomNamespace, "title"),
+			Description: firstNonEmpty(entry.text(atomNamespace, "summary"), entry.text(atomNamespace, "content")),
+		}
+		var alternate string
+		for _, link := range entry.children(atomNamespace, "link") {
+			switch link.attr("rel") {
+			case "enclosure":
+				if it.EnclosureURL == "" {
+					it.EnclosureURL = link.attr("href")
+					it.EnclosureType = link.attr("type")
+					it.EnclosureSize, _ = strconv.ParseInt(link.attr("length"), 10, 64)
+				}
+			case "", "alternate":
+				alternate = link.attr("href")
+			}
+		}
+		it.GUID = firstNonEmpty(it.GUID, it.EnclosureURL, alternate)
+		it.PubDate, _ = parseDate(firstNonEmpty(entry.text(atomNamespace, "published"), entry.text(atomNamespace, "updated")))
+		it.Duration, _ = ParseDuration(entry.text(itunesNamespace, "duration"))
+		f.Items = append(f.Items, it)
+	}
+	return f
+}
+
+// ParseDuration parses an itunes:duration value: seconds ("3725") or [H:]MM:SS ("1:02:05"),
+// optionally with fractional seconds.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var total float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil || v < 0 || (i < len(parts)-1 && v != float64(int64(v))) {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		total = total*60 + v
+	}
+	return time.Duration(total * float64(time.Second)).Round(time.Millisecond), nil
+}
+
+// dateLayouts are the date formats found in feeds, RFC 822 variants first.
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	time.RFC3339,
+	"2006-01-02",
+}
+
+func parseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q", s)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// --- Element tree ---
+
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// node is an element of a parsed document. Feeds reuse element names across namespaces
+// (title, itunes:title and media:title), so elements are looked up by namespace and name.
+type node struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	chardata strings.Builder
+	kids     []*node
+}
+
+func (n *node) children(space, local string) []*node {
+	var out []*node
+	for _, k := range n.kids {
+		if k.name.Space == space && k.name.Local == local {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+func (n *node) child(space, local string) *node {
+	for _, k := range n.kids {
+		if k.name.Space == space && k.name.Local == local {
+			return k
+		}
+	}
+	return nil
+}
+
+// text returns the trimmed character data of the first matching child.
+func (n *node) text(space, local string) string {
+	if k := n.child(space, local); k != nil {
+		return strings.TrimSpace(k.chardata.String())
+	}
+	return ""
+}
+
+func (n *node) attr(local string) string {
+	return n.attrNS("", local)
+}
+
+func (n *node) attrNS(space, local string) string {
+	for _, a := range n.attrs {
+		if a.Name.Space == space && a.Name.Local == local {
+			return strings.TrimSpace(a.Value)
+		}
+	}
+	return ""
+}
+
+// parseTree reads a whole document into a tree of elements.
+func parseTree(r io.Reader) (*node, error) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false // Feeds in the wild contain HTML entities and stray ampersands
+	dec.Entity = xml.HTMLEntity
+	dec.CharsetReader = charsetReader
+
+	var root *node
+	var stack []*node
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: t.Name, attrs: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.kids = append(parent.kids, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].chardata.Write(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("%w: empty document", ErrInvalid)
+	}
+	return root, nil
+}
+
+// charsetReader decodes ISO-8859-1, which older feeds still use; everything else is
+// expected to be UTF-8.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "latin1", "latin-1":
+		raw, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		buf.Grow(len(raw))
+		for _, b := range raw {
+			buf.WriteRune(rune(b))
+		}
+		return &buf, nil
+	}
+	return nil, fmt.Errorf("unsupported charset %q", charset)
+}
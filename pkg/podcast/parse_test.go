@@ -0,0 +1,180 @@
+package podcast
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd"
+     xmlns:atom="http://www.w3.org/2005/Atom" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>Slow Spanish</title>
+    <link>https://example.com/</link>
+    <atom:link href="https://example.com/feed.xml" rel="self" type="application/rss+xml"/>
+    <description>News in slow Spanish</description>
+    <language>es-ES</language>
+    <itunes:author>Radio Ejemplo</itunes:author>
+    <itunes:image href="https://example.com/cover.jpg"/>
+    <item>
+      <title>Episodio 2</title>
+      <itunes:title>Ignored</itunes:title>
+      <content:encoded><![CDATA[<p>Las noticias &amp; más</p>]]></content:encoded>
+      <guid isPermaLink="false">ep-2</guid>
+      <pubDate>Tue, 4 Mar 2025 08:00:00 GMT</pubDate>
+      <enclosure url="https://cdn.example.com/ep2.mp3" type="audio/mpeg" length="2048"/>
+      <itunes:duration>62:05</itunes:duration>
+      <itunes:episode>2</itunes:episode>
+    </item>
+    <item>
+      <title>Sin audio &nbsp;</title>
+      <link>https://example.com/post</link>
+    </item>
+  </channel>
+</rss>`
+
+func TestParse_RSS(t *testing.T) {
+	f, err := Parse(strings.NewReader(sampleRSS))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Slow Spanish", f.Title)
+	assert.Equal(t, "News in slow Spanish", f.Description)
+	assert.Equal(t, "https://example.com/", f.Link)
+	assert.Equal(t, "https://example.com/feed.xml", f.FeedURL)
+	assert.Equal(t, "es-ES", f.Language)
+	assert.Equal(t, "Radio Ejemplo", f.Author)
+	assert.Equal(t, "https://example.com/cover.jpg", f.ImageURL)
+	require.Len(t, f.Items, 2)
+
+	ep := f.Items[0]
+	assert.Equal(t, "ep-2", ep.GUID)
+	assert.Equal(t, "Episodio 2", ep.Title)
+	assert.Equal(t, "<p>Las noticias &amp; más</p>", ep.Description)
+	assert.Equal(t, "https://cdn.example.com/ep2.mp3", ep.EnclosureURL)
+	assert.Equal(t, "audio/mpeg", ep.EnclosureType)
+	assert.Equal(t, int64(2048), ep.EnclosureSize)
+	assert.Equal(t, 62*time.Minute+5*time.Second, ep.Duration)
+	assert.Equal(t, 2, ep.Episode)
+	assert.True(t, time.Date(2025, 3, 4, 8, 0, 0, 0, time.UTC).Equal(ep.PubDate))
+
+	// Without guid or enclosure the link identifies the item
+	assert.Equal(t, "https://example.com/post", f.Items[1].GUID)
+	assert.Empty(t, f.Items[1].EnclosureURL)
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	published := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	in := &Feed{
+		Title:   "Loop",
+		FeedURL: "https://api.example.com/feed",
+		Items: []Item{{
+			GUID: "a", Title: "One", EnclosureURL: "https://x/1.ogg", EnclosureType: "audio/ogg", EnclosureSize: 7,
+			Duration: 90 * time.Second, PubDate: published, Episode: 1,
+			Chapters: []Chapter{{Start: 1500 * time.Millisecond, Title: "Start", URL: "https://x/c"}},
+		}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, in))
+
+	out, err := Parse(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, in.Title, out.Title)
+	assert.Equal(t, in.FeedURL, out.FeedURL)
+	require.Len(t, out.Items, 1)
+	assert.Equal(t, in.Items[0].GUID, out.Items[0].GUID)
+	assert.Equal(t, in.Items[0].Duration, out.Items[0].Duration)
+	assert.Equal(t, in.Items[0].Chapters, out.Items[0].Chapters)
+	assert.True(t, published.Equal(out.Items[0].PubDate))
+}
+
+func TestParse_Atom(t *testing.T) {
+	input := `<?xml version="1.0" encoding="iso-8859-1"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="fr">
+  <title>Fran` + "\xe7" + `ais facile</title>
+  <subtitle>Podcast</subtitle>
+  <link href="https://example.fr/"/>
+  <link rel="self" href="https://example.fr/atom.xml"/>
+  <author><name>Claire</name></author>
+  <entry>
+    <id>urn:uuid:1</id>
+    <title>Le march` + "\xe9" + `</title>
+    <summary>Au march` + "\xe9" + `</summary>
+    <published>2025-01-02T10:00:00+01:00</published>
+    <link rel="alternate" href="https://example.fr/1"/>
+    <link rel="enclosure" href="https://example.fr/1.m4a" type="audio/mp4" length="99"/>
+  </entry>
+</feed>`
+
+	f, err := Parse(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, "Français facile", f.Title)
+	assert.Equal(t, "fr", f.Language)
+	assert.Equal(t, "https://example.fr/", f.Link)
+	assert.Equal(t, "https://example.fr/atom.xml", f.FeedURL)
+	assert.Equal(t, "Claire", f.Author)
+	require.Len(t, f.Items, 1)
+	assert.Equal(t, Item{
+		GUID:          "urn:uuid:1",
+		Title:         "Le marché",
+		Description:   "Au marché",
+		EnclosureURL:  "https://example.fr/1.m4a",
+		EnclosureType: "audio/mp4",
+		EnclosureSize: 99,
+		PubDate:       f.Items[0].PubDate,
+	}, f.Items[0])
+	assert.True(t, time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC).Equal(f.Items[0].PubDate))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{"", "not xml", `<html><body>Moved</body></html>`, `<rss version="2.0"></rss>`} {
+		_, err := Parse(strings.NewReader(input))
+		assert.ErrorIs(t, err, ErrInvalid, "input %q", input)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for input, want := range map[string]time.Duration{
+		"3725":         3725 * time.Second,
+		"05:30":        5*time.Minute + 30*time.Second,
+		"1:02:05":      time.Hour + 2*time.Minute + 5*time.Second,
+		"00:01:30.500": 90*time.Second + 500*time.Millisecond,
+	} {
+		got, err := ParseDuration(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+	for _, input := range []string{"", "abc", "1:2:3:4", "-5", "1.5:00"} {
+		_, err := ParseDuration(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestParseOPML(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<opml version="2.0">
+  <head><title>Subscriptions</title></head>
+  <body>
+    <outline text="Spanish">
+      <outline type="rss" text="Slow Spanish" xmlUrl="https://example.com/feed.xml" language="es"/>
+      <outline type="rss" text="Duplicate" xmlUrl="https://example.com/feed.xml"/>
+    </outline>
+    <outline type="rss" text="Text only" title="Français facile" xmlUrl=" https://example.fr/atom.xml "/>
+    <outline text="A link" htmlUrl="https://example.com"/>
+  </body>
+</opml>`
+
+	outlines, err := ParseOPML(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, []Outline{
+		{Title: "Slow Spanish", FeedURL: "https://example.com/feed.xml", Language: "es"},
+		{Title: "Français facile", FeedURL: "https://example.fr/atom.xml"},
+	}, outlines)
+
+	_, err = ParseOPML(strings.NewReader(sampleRSS))
+	assert.ErrorIs(t, err, ErrInvalid)
+}
@@ -0,0 +1,228 @@
+// pkg/podcast/podcast.go
+
+// Package podcast reads and writes podcast feeds: RSS 2.0 with the iTunes namespace, which
+// podcast apps require, and Podlove Simple Chapters for episodes with chapter marks. It also
+// reads Atom feeds and OPML subscription lists.
+package podcast
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	itunesNamespace = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+	pscNamespace    = "http://podlove.org/simple-chapters"
+	atomNamespace   = "http://www.w3.org/2005/Atom"
+)
+
+// Show types tell podcast apps how to order episodes.
+const (
+	TypeEpisodic = "episodic" // Newest first
+	TypeSerial   = "serial"   // In episode order
+)
+
+// Feed describes a podcast.
+type Feed struct {
+	Title         string
+	Description   string
+	Link          string // Web page of the podcast
+	FeedURL       string // URL of the feed itself (atom:link rel="self")
+	Language      string // e.g. "en" or "es-ES"; optional
+	Author        string
+	ImageURL      string // Cover art; optional
+	Type          string // TypeEpisodic or TypeSerial; optional
+	LastBuildDate time.Time
+	Items         []Item
+}
+
+// Item is one episode.
+type Item struct {
+	GUID          string // Stable, unique ID; must not change when the enclosure URL does
+	Title         string
+	Description   string
+	EnclosureURL  string
+	EnclosureType string // MIME type of the audio
+	EnclosureSize int64  // Bytes; 0 if unknown
+	Duration      time.Duration
+	PubDate       time.Time
+	Episode       int // 1-based episode number; 0 omits it
+	ImageURL      string
+	Chapters      []Chapter
+}
+
+// Chapter is a chapter mark within an episode.
+type Chapter struct {
+	Start time.Duration
+	Title string
+	URL   string // Optional link shown with the chapter
+}
+
+// Write encodes the feed as RSS 2.0.
+func Write(w io.Writer, f *Feed) error {
+	doc := rss{
+		Version: "2.0",
+		Itunes:  itunesNamespace,
+		PSC:     pscNamespace,
+		Atom:    atomNamespace,
+		Channel: channel{
+			Title:          f.Title,
+			Link:           f.Link,
+			Description:    f.Description,
+			Language:       f.Language,
+			Generator:      "language-learning-player-api",
+			ItunesAuthor:   f.Author,
+			ItunesSummary:  f.Description,
+			ItunesType:     f.Type,
+			ItunesExplicit: "false",
+			Items:          make([]item, 0, len(f.Items)),
+		},
+	}
+	if !f.LastBuildDate.IsZero() {
+		doc.Channel.LastBuildDate = f.LastBuildDate.UTC().Format(time.RFC1123Z)
+	}
+	if f.FeedURL != "" {
+		doc.Channel.AtomLink = &atomLink{Href: f.FeedURL, Rel: "self", Type: "application/rss+xml"}
+	}
+	if f.ImageURL != "" {
+		doc.Channel.ItunesImage = &itunesImage{Href: f.ImageURL}
+	}
+	for _, it := range f.Items {
+		out := item{
+			Title:       it.Title,
+			Description: it.Description,
+			GUID:        guid{Value: it.GUID, IsPermaLink: "false"},
+			Enclosure: enclosure{
+				URL:    it.EnclosureURL,
+				Type:   it.EnclosureType,
+				Length: strconv.FormatInt(it.EnclosureSize, 10),
+			},
+			ItunesTitle: it.Title,
+		}
+		if !it.PubDate.IsZero() {
+			out.PubDate = it.PubDate.UTC().Format(time.RFC1123Z)
+		}
+		if it.Duration > 0 {
+			out.ItunesDuration = FormatDuration(it.Duration)
+		}
+		if it.Episode > 0 {
+			out.ItunesEpisode = strconv.Itoa(it.Episode)
+		}
+		if it.ImageURL != "" {
+			out.ItunesImage = &itunesImage{Href: it.ImageURL}
+		}
+		if len(it.Chapters) > 0 {
+			out.Chapters = &chapters{Version: "1.2"}
+			for _, c := range it.Chapters {
+				out.Chapters.Chapters = append(out.Chapters.Chapters, chapter{
+					Start: formatTimestamp(c.Start),
+					Title: c.Title,
+					Href:  c.URL,
+				})
+			}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, out)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding podcast feed: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// FormatDuration formats a duration as H:MM:SS (or M:SS under an hour), the form itunes:duration expects.
+func FormatDuration(d time.Duration) string {
+	total := int64(d.Round(time.Second) / time.Second)
+	h, m, s := total/3600, total/60%60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// formatTimestamp formats a chapter start as HH:MM:SS.mmm (Podlove normal play time).
+func formatTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// --- XML document ---
+
+type rss struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	Itunes  string   `xml:"xmlns:itunes,attr"`
+	PSC     string   `xml:"xmlns:psc,attr"`
+	Atom    string   `xml:"xmlns:atom,attr"`
+	Channel channel  `xml:"channel"`
+}
+
+type channel struct {
+	Title          string       `xml:"title"`
+	Link           string       `xml:"link"`
+	Description    string       `xml:"description"`
+	Language       string       `xml:"language,omitempty"`
+	Generator      string       `xml:"generator"`
+	LastBuildDate  string       `xml:"lastBuildDate,omitempty"`
+	AtomLink       *atomLink    `xml:"atom:link"`
+	ItunesAuthor   string       `xml:"itunes:author,omitempty"`
+	ItunesSummary  string       `xml:"itunes:summary,omitempty"`
+	ItunesType     string       `xml:"itunes:type,omitempty"`
+	ItunesExplicit string       `xml:"itunes:explicit"`
+	ItunesImage    *itunesImage `xml:"itunes:image"`
+	Items          []item       `xml:"item"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type itunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+type item struct {
+	Title          string       `xml:"title"`
+	Description    string       `xml:"description,omitempty"`
+	GUID           guid         `xml:"guid"`
+	PubDate        string       `xml:"pubDate,omitempty"`
+	Enclosure      enclosure    `xml:"enclosure"`
+	ItunesTitle    string       `xml:"itunes:title"`
+	ItunesDuration string       `xml:"itunes:duration,omitempty"`
+	ItunesEpisode  string       `xml:"itunes:episode,omitempty"`
+	ItunesImage    *itunesImage `xml:"itunes:image"`
+	Chapters       *chapters    `xml:"psc:chapters"`
+}
+
+type guid struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink string `xml:"isPermaLink,attr"`
+}
+
+type enclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+type chapters struct {
+	Version  string    `xml:"version,attr"`
+	Chapters []chapter `xml:"psc:chapter"`
+}
+
+type chapter struct {
+	Start string `xml:"start,attr"`
+	Title string `xml:"title,attr"`
+	Href  string `xml:"href,attr,omitempty"`
+}
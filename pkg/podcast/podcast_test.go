@@ -0,0 +1,78 @@
+package podcast
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	published := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	feed := &Feed{
+		Title:         "Spanish A1 & friends",
+		Description:   "Beginner listening",
+		Link:          "https://example.com/collections/1",
+		FeedURL:       "https://api.example.com/feeds/collections/1.rss",
+		Language:      "es",
+		Author:        "Ana",
+		Type:          TypeSerial,
+		LastBuildDate: published,
+		Items: []Item{
+			{
+				GUID:          "track-1",
+				Title:         "Greetings",
+				EnclosureURL:  "https://api.example.com/feeds/stream/1?exp=1&sig=a&b=c",
+				EnclosureType: "audio/mpeg",
+				EnclosureSize: 1234,
+				Duration:      3725 * time.Second,
+				PubDate:       published,
+				Episode:       1,
+				Chapters: []Chapter{
+					{Start: 0, Title: "Intro"},
+					{Start: 90*time.Second + 500*time.Millisecond, Title: "Dialogue", URL: "https://example.com/d"},
+				},
+			},
+			{GUID: "track-2", Title: "Numbers", EnclosureURL: "https://x/2", EnclosureType: "audio/ogg"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, feed))
+	out := buf.String()
+
+	assert.Contains(t, out, `xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd"`)
+	assert.Contains(t, out, `<title>Spanish A1 &amp; friends</title>`)
+	assert.Contains(t, out, `<itunes:type>serial</itunes:type>`)
+	assert.Contains(t, out, `<atom:link href="https://api.example.com/feeds/collections/1.rss" rel="self" type="application/rss+xml"></atom:link>`)
+	assert.Contains(t, out, `<lastBuildDate>Sat, 01 Mar 2025 09:30:00 +0000</lastBuildDate>`)
+	assert.Contains(t, out, `<enclosure url="https://api.example.com/feeds/stream/1?exp=1&amp;sig=a&amp;b=c" type="audio/mpeg" length="1234"></enclosure>`)
+	assert.Contains(t, out, `<guid isPermaLink="false">track-1</guid>`)
+	assert.Contains(t, out, `<itunes:duration>1:02:05</itunes:duration>`)
+	assert.Contains(t, out, `<itunes:episode>1</itunes:episode>`)
+	assert.Contains(t, out, `<psc:chapter start="00:00:00.000" title="Intro"></psc:chapter>`)
+	assert.Contains(t, out, `<psc:chapter start="00:01:30.500" title="Dialogue" href="https://example.com/d"></psc:chapter>`)
+	assert.Contains(t, out, `<enclosure url="https://x/2" type="audio/ogg" length="0"></enclosure>`)
+	assert.NotContains(t, out, "<itunes:image")
+
+	// The output must be well-formed XML
+	var parsed struct {
+		Items []struct {
+			Title string `xml:"title"`
+		} `xml:"channel>item"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &parsed))
+	require.Len(t, parsed.Items, 2)
+	assert.Equal(t, "Numbers", parsed.Items[1].Title)
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0:00", FormatDuration(0))
+	assert.Equal(t, "0:59", FormatDuration(59*time.Second))
+	assert.Equal(t, "1:00", FormatDuration(59500*time.Millisecond))
+	assert.Equal(t, "59:59", FormatDuration(time.Hour-time.Second))
+	assert.Equal(t, "10:00:00", FormatDuration(10*time.Hour))
+}
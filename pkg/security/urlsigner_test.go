@@ -0,0 +1,31 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLSigner(t *testing.T) {
+	signer, err := NewURLSigner("test-url-signing-key")
+	require.NoError(t, err)
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	sig := signer.Sign("track/1", expires)
+	assert.True(t, signer.Verify("track/1", expires, sig))
+	assert.False(t, signer.Verify("track/2", expires, sig), "other resource")
+	assert.False(t, signer.Verify("track/1", expires.Add(time.Second), sig), "other expiry")
+	assert.False(t, signer.Verify("track/1", expires, sig+"x"), "malformed signature")
+
+	other, err := NewURLSigner("another-key")
+	require.NoError(t, err)
+	assert.False(t, other.Verify("track/1", expires, sig), "other key")
+
+	past := time.Now().Add(-time.Minute)
+	assert.False(t, signer.Verify("track/1", past, signer.Sign("track/1", past)), "expired")
+
+	_, err = NewURLSigner("")
+	assert.Error(t, err)
+}